@@ -2,16 +2,20 @@ package frostdb
 
 import (
 	"context"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
 	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 )
@@ -271,3 +275,1204 @@ func TestAggregateInconsistentSchema(t *testing.T) {
 	}
 	require.Equal(t, []int64{5, 1}, cols[len(cols)-1].(*array.Int64).Int64Values())
 }
+
+func TestAggregateDurationTruncate(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, ts := range []int64{60_001, 120_000, 125_000} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "label1", Value: "value1"},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: ts,
+			Value:     int64(i + 1),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	var res arrow.Record
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")).Alias("value_sum"),
+			logicalplan.DurationTruncate(logicalplan.Col("timestamp"), time.Minute),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		r.Retain()
+		res = r
+		return nil
+	})
+	require.NoError(t, err)
+	defer res.Release()
+
+	require.Equal(t, int64(2), res.NumRows())
+	require.Equal(t, "duration_truncate(timestamp, 1m0s)", res.Schema().Field(0).Name)
+
+	sums := map[int64]int64{}
+	buckets := res.Column(0).(*array.Int64)
+	values := res.Column(1).(*array.Int64)
+	for i := 0; i < int(res.NumRows()); i++ {
+		sums[buckets.Value(i)] = values.Value(i)
+	}
+	require.Equal(t, map[int64]int64{60_000: 1, 120_000: 5}, sums)
+}
+
+func TestAggregateFingerprint(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, value := range []string{"value1", "value1", "value2"} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "label1", Value: value},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i + 1),
+			Value:     int64(1 << i),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	var res arrow.Record
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")).Alias("value_sum"),
+			logicalplan.Fingerprint(logicalplan.Col("labels.label1")),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		r.Retain()
+		res = r
+		return nil
+	})
+	require.NoError(t, err)
+	defer res.Release()
+
+	require.Equal(t, int64(2), res.NumRows())
+	require.Equal(t, "fingerprint(labels.label1)", res.Schema().Field(0).Name)
+
+	sums := []int64{}
+	values := res.Column(1).(*array.Int64)
+	for i := 0; i < int(res.NumRows()); i++ {
+		sums = append(sums, values.Value(i))
+	}
+	require.ElementsMatch(t, []int64{3, 4}, sums)
+}
+
+func TestAggregateWhere(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, labels := range [][2]string{{"api", "500"}, {"api", "200"}, {"api", "503"}, {"web", "200"}} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "code", Value: labels[1]},
+				{Name: "job", Value: labels[0]},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i + 1),
+			Value:     int64(1 << i),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	var res arrow.Record
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")).Where(logicalplan.Col("labels.code").RegexMatch("5..")).Alias("errors"),
+			logicalplan.Col("labels.job"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		r.Retain()
+		res = r
+		return nil
+	})
+	require.NoError(t, err)
+	defer res.Release()
+
+	// Groups without matching rows are still part of the result.
+	require.Equal(t, int64(2), res.NumRows())
+
+	sums := map[string]int64{}
+	jobs := res.Column(0).(*array.Binary)
+	values := res.Column(1).(*array.Int64)
+	for i := 0; i < int(res.NumRows()); i++ {
+		sums[string(jobs.Value(i))] = values.Value(i)
+	}
+	require.Equal(t, map[string]int64{"api": 5, "web": 0}, sums)
+}
+
+func TestAggregateCount(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, labels := range [][]dynparquet.Label{
+		{{Name: "code", Value: "500"}, {Name: "job", Value: "api"}},
+		{{Name: "job", Value: "api"}},
+		{{Name: "code", Value: "200"}, {Name: "job", Value: "api"}},
+		{{Name: "job", Value: "web"}},
+	} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: labels,
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i + 1),
+			Value:     int64(i + 1),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	countByJob := func(agg *logicalplan.AggregationFunction) map[string]int64 {
+		var res arrow.Record
+		err := engine.ScanTable("test").
+			Aggregate(
+				agg.Alias("count"),
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			r.Retain()
+			res = r
+			return nil
+		})
+		require.NoError(t, err)
+		defer res.Release()
+
+		counts := map[string]int64{}
+		jobs := res.Column(0).(*array.Binary)
+		values := res.Column(1).(*array.Int64)
+		for i := 0; i < int(res.NumRows()); i++ {
+			counts[string(jobs.Value(i))] = values.Value(i)
+		}
+		return counts
+	}
+
+	require.Equal(t, map[string]int64{"api": 3, "web": 1}, countByJob(logicalplan.Count(logicalplan.Col("value"))))
+	// Null values are not counted.
+	require.Equal(t, map[string]int64{"api": 2, "web": 0}, countByJob(logicalplan.Count(logicalplan.Col("labels.code"))))
+	require.Equal(t, map[string]int64{"api": 2, "web": 0}, countByJob(logicalplan.ApproxCountDistinct(logicalplan.Col("labels.code"))))
+}
+
+func TestAggregateMinMax(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, job := range []string{"api", "api", "web", "api", "web"} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "job", Value: job},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i + 1),
+			Value:     int64((i * 3) % 5),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	byJob := func(agg *logicalplan.AggregationFunction) map[string]int64 {
+		var res arrow.Record
+		err := engine.ScanTable("test").
+			Aggregate(
+				agg,
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			r.Retain()
+			res = r
+			return nil
+		})
+		require.NoError(t, err)
+		defer res.Release()
+
+		require.Equal(t, agg.Name(), res.Schema().Field(1).Name)
+		values := map[string]int64{}
+		jobs := res.Column(0).(*array.Binary)
+		aggregated := res.Column(1).(*array.Int64)
+		for i := 0; i < int(res.NumRows()); i++ {
+			values[string(jobs.Value(i))] = aggregated.Value(i)
+		}
+		return values
+	}
+
+	// The values are 0, 3, 1, 4 and 2.
+	require.Equal(t, map[string]int64{"api": 0, "web": 1}, byJob(logicalplan.Min(logicalplan.Col("value"))))
+	require.Equal(t, map[string]int64{"api": 4, "web": 2}, byJob(logicalplan.Max(logicalplan.Col("value"))))
+}
+
+func TestAggregateMultiple(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, job := range []string{"api", "api", "web", "api", "web"} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "job", Value: job},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i + 1),
+			Value:     int64((i * 3) % 5),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	aggs := []logicalplan.Expr{
+		logicalplan.Sum(logicalplan.Col("value")),
+		logicalplan.Count(logicalplan.Col("value")),
+		logicalplan.Max(logicalplan.Col("timestamp")),
+	}
+
+	var res arrow.Record
+	err = engine.ScanTable("test").
+		Aggregations(
+			aggs,
+			logicalplan.Col("labels.job"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		r.Retain()
+		res = r
+		return nil
+	})
+	require.NoError(t, err)
+	defer res.Release()
+
+	require.Equal(t, int64(4), res.NumCols())
+	for i, agg := range aggs {
+		require.Equal(t, agg.Name(), res.Schema().Field(i+1).Name)
+	}
+
+	// The values are 0, 3, 1, 4 and 2.
+	values := map[string][]int64{}
+	jobs := res.Column(0).(*array.Binary)
+	for i := 0; i < int(res.NumRows()); i++ {
+		values[string(jobs.Value(i))] = []int64{
+			res.Column(1).(*array.Int64).Value(i),
+			res.Column(2).(*array.Int64).Value(i),
+			res.Column(3).(*array.Int64).Value(i),
+		}
+	}
+	require.Equal(t, map[string][]int64{
+		"api": {7, 3, 4},
+		"web": {3, 2, 5},
+	}, values)
+}
+
+func TestAggregateMultipleDynamicColumns(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "attributes",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "attributes",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// The row groups have different sets of concrete attribute columns. The
+	// columns are attributes.*, labels.job and value.
+	for _, rg := range []struct {
+		attribute string
+		rows      []parquet.Row
+	}{{
+		attribute: "region",
+		rows: []parquet.Row{
+			{parquet.ValueOf("eu").Level(0, 1, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(1).Level(0, 0, 2)},
+			{parquet.ValueOf("us").Level(0, 1, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(2).Level(0, 0, 2)},
+			{parquet.ValueOf("eu").Level(0, 1, 0), parquet.ValueOf("web").Level(0, 1, 1), parquet.ValueOf(3).Level(0, 0, 2)},
+		},
+	}, {
+		attribute: "zone",
+		rows: []parquet.Row{
+			{parquet.ValueOf("a").Level(0, 1, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(4).Level(0, 0, 2)},
+			{parquet.ValueOf(nil).Level(0, 0, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(5).Level(0, 0, 2)},
+			{parquet.ValueOf("a").Level(0, 1, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(6).Level(0, 0, 2)},
+		},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{
+			"attributes": {rg.attribute},
+			"labels":     {"job"},
+		})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rg.rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	var res arrow.Record
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.DynCol("labels"),
+			logicalplan.DynCol("attributes"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		r.Retain()
+		res = r
+		return nil
+	})
+	require.NoError(t, err)
+	defer res.Release()
+
+	fields := make([]string, 0, res.NumCols())
+	for _, field := range res.Schema().Fields() {
+		fields = append(fields, field.Name)
+	}
+	require.Equal(t, []string{"attributes.region", "attributes.zone", "labels.job", "sum(value)"}, fields)
+
+	value := func(arr arrow.Array, i int) string {
+		if arr.IsNull(i) {
+			return "-"
+		}
+		return string(arr.(*array.Binary).Value(i))
+	}
+	sums := map[string]int64{}
+	for i := 0; i < int(res.NumRows()); i++ {
+		key := value(res.Column(0), i) + "/" + value(res.Column(1), i) + "/" + value(res.Column(2), i)
+		sums[key] = res.Column(3).(*array.Int64).Value(i)
+	}
+	require.Equal(t, map[string]int64{
+		"eu/-/api": 1,
+		"us/-/api": 2,
+		"eu/-/web": 3,
+		"-/a/api":  10,
+		"-/-/api":  5,
+	}, sums)
+}
+
+func TestAggregateSortedGroups(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	// Each insert results in a separate row group, so the rows of the groups
+	// are spread over the row groups.
+	for i := 0; i < 3; i++ {
+		samples := dynparquet.Samples{}
+		for j, exampleType := range []string{"a", "b", "c", "d"} {
+			samples = append(samples, dynparquet.Sample{
+				ExampleType: exampleType,
+				Labels: []dynparquet.Label{
+					{Name: "job", Value: "api"},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: int64(i),
+				Value:     int64(i + j),
+			})
+		}
+
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	// The groups are a prefix of the sorting columns, so they are aggregated
+	// in order and passed on as soon as they are complete.
+	records := 0
+	sums := map[string]int64{}
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Col("example_type"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		records++
+		types := r.Column(0).(*array.Binary)
+		values := r.Column(1).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			_, seen := sums[string(types.Value(i))]
+			require.False(t, seen)
+			sums[string(types.Value(i))] = values.Value(i)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Greater(t, records, 1)
+	require.Equal(t, map[string]int64{"a": 3, "b": 6, "c": 9, "d": 12}, sums)
+}
+
+func TestAggregateConcurrent(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	// Each insert results in a separate row group, so the row groups are
+	// aggregated by different goroutines.
+	for i := 0; i < 8; i++ {
+		samples := dynparquet.Samples{}
+		for j, job := range []string{"api", "web", "db"} {
+			samples = append(samples, dynparquet.Sample{
+				Labels: []dynparquet.Label{
+					{Name: "job", Value: job},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: int64(i),
+				Value:     int64(i * (j + 1)),
+			})
+		}
+
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	aggregate := func(engine *query.LocalEngine) map[string][]int64 {
+		res := map[string][]int64{}
+		err := engine.ScanTable("test").
+			Filter(logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(1)))).
+			Aggregations(
+				[]logicalplan.Expr{
+					logicalplan.Sum(logicalplan.Col("value")),
+					logicalplan.Count(logicalplan.Col("value")).Alias("samples"),
+					logicalplan.Min(logicalplan.Col("timestamp")),
+					logicalplan.Max(logicalplan.Col("value")),
+				},
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			require.Equal(t, "samples", r.Schema().Field(2).Name)
+			jobs := r.Column(0).(*array.Binary)
+			for i := 0; i < int(r.NumRows()); i++ {
+				_, seen := res[string(jobs.Value(i))]
+				require.False(t, seen)
+				res[string(jobs.Value(i))] = []int64{
+					r.Column(1).(*array.Int64).Value(i),
+					r.Column(2).(*array.Int64).Value(i),
+					r.Column(3).(*array.Int64).Value(i),
+					r.Column(4).(*array.Int64).Value(i),
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	// The timestamps 2 to 7 are aggregated.
+	expected := map[string][]int64{
+		"api": {27, 6, 2, 7},
+		"web": {54, 6, 2, 14},
+		"db":  {81, 6, 2, 21},
+	}
+	require.Equal(t, expected, aggregate(query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)))
+	require.Equal(t, expected, aggregate(query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+		query.WithConcurrency(4),
+	)))
+
+	// The results of aggregations that aren't merged by the same aggregation
+	// are merged from the states of the partial aggregations.
+	aggExprs := []logicalplan.Expr{
+		logicalplan.Avg(logicalplan.Col("value")),
+		logicalplan.ApproxCountDistinct(logicalplan.Col("value")).Alias("values"),
+		logicalplan.Quantile(logicalplan.Col("value"), 0.5),
+		logicalplan.Variance(logicalplan.Col("value")),
+		logicalplan.StdDev(logicalplan.Col("value")),
+	}
+	mergeable := func(engine *query.LocalEngine) map[string][]float64 {
+		res := map[string][]float64{}
+		err := engine.ScanTable("test").
+			Filter(logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(1)))).
+			Aggregations(aggExprs, logicalplan.Col("labels.job")).
+			Execute(context.Background(), func(r arrow.Record) error {
+				for i, aggExpr := range aggExprs {
+					require.Equal(t, aggExpr.Name(), r.Schema().Field(i+1).Name)
+				}
+				jobs := r.Column(0).(*array.Binary)
+				for i := 0; i < int(r.NumRows()); i++ {
+					values := []float64{}
+					for _, col := range r.Columns()[1:] {
+						switch col := col.(type) {
+						case *array.Int64:
+							values = append(values, float64(col.Value(i)))
+						case *array.Float64:
+							values = append(values, col.Value(i))
+						}
+					}
+					res[string(jobs.Value(i))] = values
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		return res
+	}
+
+	concurrent := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+		query.WithConcurrency(4),
+	)
+	explain, err := concurrent.ScanTable("test").
+		Filter(logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(1)))).
+		Aggregations(aggExprs, logicalplan.Col("labels.job")).
+		Explain()
+	require.NoError(t, err)
+	require.Contains(t, explain, "HashAggregate Partial")
+
+	// The merged sketches are the sketches of all values, the merged
+	// variances only differ by rounding.
+	res := mergeable(concurrent)
+	serial := mergeable(query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	))
+	for job, expected := range map[string][]float64{
+		"api": {4.5, 3.5},
+		"web": {9, 14},
+		"db":  {13.5, 31.5},
+	} {
+		avg, variance := expected[0], expected[1]
+		require.Len(t, res[job], len(aggExprs))
+		require.Equal(t, serial[job][:3], res[job][:3])
+		require.Equal(t, avg, res[job][0])
+		require.Equal(t, 6.0, res[job][1])
+		require.InEpsilon(t, avg, res[job][2], 0.15)
+		require.InDelta(t, variance, res[job][3], 1e-9)
+		require.InDelta(t, math.Sqrt(variance), res[job][4], 1e-9)
+	}
+}
+
+func TestAggregateHaving(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i := 0; i < 8; i++ {
+		for j, job := range []string{"api", "web", "db"} {
+			samples = append(samples, dynparquet.Sample{
+				Labels: []dynparquet.Label{
+					{Name: "job", Value: job},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: int64(i),
+				Value:     int64(i * (j + 1)),
+			})
+		}
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	for _, test := range []struct {
+		name     string
+		having   logicalplan.Expr
+		expected map[string]int64
+	}{{
+		name:     "aggregation",
+		having:   logicalplan.Col("sum(value)").Gt(logicalplan.Literal(int64(30))),
+		expected: map[string]int64{"web": 56, "db": 84},
+	}, {
+		name: "group",
+		having: logicalplan.And(
+			logicalplan.Col("sum(value)").Gt(logicalplan.Literal(int64(30))),
+			logicalplan.Col("labels.job").Eq(logicalplan.Literal("db")),
+		),
+		expected: map[string]int64{"db": 84},
+	}, {
+		name:     "none",
+		having:   logicalplan.Col("sum(value)").Gt(logicalplan.Literal(int64(100))),
+		expected: map[string]int64{},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			res := map[string]int64{}
+			err := engine.ScanTable("test").
+				Aggregate(
+					logicalplan.Sum(logicalplan.Col("value")),
+					logicalplan.Col("labels.job"),
+				).
+				Having(test.having).
+				Execute(context.Background(), func(r arrow.Record) error {
+					jobs := r.Column(0).(*array.Binary)
+					sums := r.Column(1).(*array.Int64)
+					for i := 0; i < int(r.NumRows()); i++ {
+						res[string(jobs.Value(i))] = sums.Value(i)
+					}
+					return nil
+				})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestAggregateFirstLastBy(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	// The samples are inserted out of timestamp order.
+	for _, batch := range [][]struct {
+		job       string
+		timestamp int64
+		value     int64
+	}{
+		{{"api", 3, 30}, {"web", 2, 200}, {"api", 1, 10}},
+		{{"api", 4, 40}, {"web", 1, 100}, {"api", 2, 20}},
+	} {
+		samples := dynparquet.Samples{}
+		for _, s := range batch {
+			samples = append(samples, dynparquet.Sample{
+				Labels: []dynparquet.Label{
+					{Name: "job", Value: s.job},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: s.timestamp,
+				Value:     s.value,
+			})
+		}
+
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	byJob := func(agg *logicalplan.AggregationFunction) map[string]int64 {
+		var res arrow.Record
+		err := engine.ScanTable("test").
+			Aggregate(
+				agg,
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			r.Retain()
+			res = r
+			return nil
+		})
+		require.NoError(t, err)
+		defer res.Release()
+
+		values := map[string]int64{}
+		jobs := res.Column(0).(*array.Binary)
+		aggregated := res.Column(1).(*array.Int64)
+		for i := 0; i < int(res.NumRows()); i++ {
+			values[string(jobs.Value(i))] = aggregated.Value(i)
+		}
+		return values
+	}
+
+	value, timestamp := logicalplan.Col("value"), logicalplan.Col("timestamp")
+	require.Equal(t, map[string]int64{"api": 10, "web": 100}, byJob(logicalplan.FirstBy(value, timestamp)))
+	require.Equal(t, map[string]int64{"api": 40, "web": 200}, byJob(logicalplan.LastBy(value, timestamp)))
+	require.Equal(t, map[string]int64{"api": 30, "web": 200}, byJob(logicalplan.LastBy(value, timestamp).Where(timestamp.Lt(logicalplan.Literal(int64(4))))))
+}
+
+// maxAggregation is a user-defined aggregation of the maximum int64 value of
+// each group.
+type maxAggregation struct {
+	pool memory.Allocator
+	max  []int64
+	seen []bool
+}
+
+func (a *maxAggregation) Init(pool memory.Allocator) error {
+	a.pool = pool
+	return nil
+}
+
+func (a *maxAggregation) Update(arr arrow.Array, groups []int) error {
+	values := arr.(*array.Int64)
+	for i, g := range groups {
+		for len(a.max) <= g {
+			a.max = append(a.max, 0)
+			a.seen = append(a.seen, false)
+		}
+		if values.IsNull(i) {
+			continue
+		}
+		if !a.seen[g] || values.Value(i) > a.max[g] {
+			a.max[g] = values.Value(i)
+			a.seen[g] = true
+		}
+	}
+	return nil
+}
+
+func (a *maxAggregation) Finalize(numGroups int) (arrow.Array, error) {
+	b := array.NewInt64Builder(a.pool)
+	defer b.Release()
+	for g := 0; g < numGroups; g++ {
+		if g >= len(a.max) || !a.seen[g] {
+			b.AppendNull()
+			continue
+		}
+		b.Append(a.max[g])
+	}
+	return b.NewArray(), nil
+}
+
+func TestAggregateUserDefined(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, labels := range [][2]string{{"api", "500"}, {"api", "200"}, {"api", "503"}, {"web", "200"}} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "code", Value: labels[1]},
+				{Name: "job", Value: labels[0]},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i + 1),
+			Value:     int64(1 << i),
+		})
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+	newMax := func() logicalplan.UserDefinedAggregation { return &maxAggregation{} }
+	require.NoError(t, engine.RegisterAggregation("max_value", arrow.PrimitiveTypes.Int64, newMax))
+	require.ErrorIs(t, engine.RegisterAggregation("max_value", arrow.PrimitiveTypes.Int64, newMax), query.ErrFunctionExists)
+
+	maxByJob := func(agg *logicalplan.AggregationFunction) map[string]interface{} {
+		var res arrow.Record
+		err := engine.ScanTable("test").
+			Aggregate(
+				agg.Alias("max"),
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			r.Retain()
+			res = r
+			return nil
+		})
+		require.NoError(t, err)
+		defer res.Release()
+
+		maxes := map[string]interface{}{}
+		jobs := res.Column(0).(*array.Binary)
+		values := res.Column(1).(*array.Int64)
+		for i := 0; i < int(res.NumRows()); i++ {
+			if values.IsNull(i) {
+				maxes[string(jobs.Value(i))] = nil
+				continue
+			}
+			maxes[string(jobs.Value(i))] = values.Value(i)
+		}
+		return maxes
+	}
+
+	require.Equal(t,
+		map[string]interface{}{"api": int64(4), "web": int64(8)},
+		maxByJob(logicalplan.UserAggregation("max_value", logicalplan.Col("value"))),
+	)
+	require.Equal(t,
+		map[string]interface{}{"api": int64(4), "web": nil},
+		maxByJob(logicalplan.UserAggregation("max_value", logicalplan.Col("value")).Where(logicalplan.Col("labels.code").RegexMatch("5.."))),
+	)
+
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.UserAggregation("unknown", logicalplan.Col("value")),
+			logicalplan.Col("labels.job"),
+		).Execute(context.Background(), func(r arrow.Record) error { return nil })
+	require.ErrorIs(t, err, logicalplan.ErrUnknownFunction)
+}
+
+func TestAggregateFloat64(t *testing.T) {
+	engine := newFloat64TestEngine(t)
+
+	byJob := func(agg *logicalplan.AggregationFunction) map[string]float64 {
+		values := map[string]float64{}
+		err := engine.ScanTable("test").
+			Aggregate(
+				agg,
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			require.Equal(t, agg.Name(), r.Schema().Field(1).Name)
+			jobs := r.Column(0).(*array.Binary)
+			aggregated := r.Column(1).(*array.Float64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				values[string(jobs.Value(i))] = aggregated.Value(i)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return values
+	}
+
+	// The ratios of api are 1.5, 2.5 and 4, the ratio of web is -1.25.
+	require.Equal(t, map[string]float64{"api": 8, "web": -1.25}, byJob(logicalplan.Sum(logicalplan.Col("ratio"))))
+	require.Equal(t, map[string]float64{"api": 1.5, "web": -1.25}, byJob(logicalplan.Min(logicalplan.Col("ratio"))))
+	require.Equal(t, map[string]float64{"api": 4, "web": -1.25}, byJob(logicalplan.Max(logicalplan.Col("ratio"))))
+	require.Equal(t, map[string]float64{"api": 0.5, "web": 1}, byJob(logicalplan.Sum(logicalplan.Col("opt"))))
+
+	// Rows are grouped by their ratios.
+	counts := map[float64]int64{}
+	err := engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Count(logicalplan.Col("labels.job")),
+			logicalplan.Col("ratio"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		ratios := r.Column(0).(*array.Float64)
+		aggregated := r.Column(1).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			counts[ratios.Value(i)] += aggregated.Value(i)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[float64]int64{-1.25: 1, 1.5: 1, 2.5: 1, 4: 1}, counts)
+}
+
+func TestAggregateDecimal(t *testing.T) {
+	engine := newDecimalTestEngine(t)
+
+	byJob := func(agg *logicalplan.AggregationFunction) map[string]int64 {
+		values := map[string]int64{}
+		err := engine.ScanTable("test").
+			Aggregate(
+				agg,
+				logicalplan.Col("labels.job"),
+			).Execute(context.Background(), func(r arrow.Record) error {
+			require.Equal(t, &arrow.Decimal128Type{Precision: 10, Scale: 2}, r.Schema().Field(1).Type)
+			jobs := r.Column(0).(*array.Binary)
+			aggregated := r.Column(1).(*array.Decimal128)
+			for i := 0; i < int(r.NumRows()); i++ {
+				values[string(jobs.Value(i))] = int64(aggregated.Value(i).LowBits())
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return values
+	}
+
+	// The prices of api are 12.34, 5 and 20.10, the price of web is -1.50.
+	require.Equal(t, map[string]int64{"api": 3744, "web": -150}, byJob(logicalplan.Sum(logicalplan.Col("price"))))
+	require.Equal(t, map[string]int64{"api": 500, "web": -150}, byJob(logicalplan.Min(logicalplan.Col("price"))))
+	require.Equal(t, map[string]int64{"api": 2010, "web": -150}, byJob(logicalplan.Max(logicalplan.Col("price"))))
+}
@@ -1,8 +1,10 @@
 package frostdb
 
 import (
+	"bytes"
 	"errors"
 
+	"github.com/apache/arrow/go/v8/arrow/scalar"
 	"github.com/segmentio/parquet-go"
 
 	"github.com/polarsignals/frostdb/dynparquet"
@@ -14,29 +16,37 @@ type ColumnRef struct {
 }
 
 func (c *ColumnRef) Column(rg dynparquet.DynamicRowGroup) (parquet.ColumnChunk, bool, error) {
-	columnIndex := findColumnIndex(rg.Schema(), c.ColumnName)
+	leaf, found := dynparquet.LookupColumn(rg.Schema(), c.ColumnName)
 	var columnChunk parquet.ColumnChunk
 	// columnChunk can be nil if the column is not present in the row group.
-	if columnIndex != -1 {
-		columnChunk = rg.ColumnChunks()[columnIndex]
+	if found {
+		columnChunk = rg.ColumnChunks()[leaf.ColumnIndex]
 	}
 
-	return columnChunk, columnIndex != -1, nil
+	return columnChunk, found, nil
 }
 
-func findColumnIndex(s *parquet.Schema, columnName string) int {
-	for i, field := range s.Fields() {
-		if field.Name() == columnName {
-			return i
-		}
-	}
-	return -1
+// Type returns the type of the column in the schema of the row group. Unlike
+// the types of the column chunks of files it has the decimal types of
+// columns.
+func (c *ColumnRef) Type(rg dynparquet.DynamicRowGroup) parquet.Type {
+	leaf, _ := dynparquet.LookupColumn(rg.Schema(), c.ColumnName)
+	return leaf.Node.Type()
 }
 
 type BinaryScalarExpr struct {
 	Left  *ColumnRef
 	Op    logicalplan.Op
 	Right parquet.Value
+
+	// literal is the scalar of Right, if any, which is converted to the
+	// values of timestamp and decimal columns.
+	literal scalar.Scalar
+}
+
+// right returns the value compared with the values of a column of the type.
+func (e BinaryScalarExpr) right(typ parquet.Type) parquet.Value {
+	return columnValue(typ, e.literal, e.Right)
 }
 
 func (e BinaryScalarExpr) Eval(rg dynparquet.DynamicRowGroup) (bool, error) {
@@ -49,19 +59,55 @@ func (e BinaryScalarExpr) Eval(rg dynparquet.DynamicRowGroup) (bool, error) {
 	// existant columns or null values. I'm pretty sure this is completely
 	// wrong and needs per operation, per type specific behavior.
 	if !exists {
-		// only handling string for now.
+		// Missing string columns compare as the empty string, missing
+		// columns of other types are null and match no comparison.
 		if e.Right.Kind() == parquet.ByteArray || e.Right.Kind() == parquet.FixedLenByteArray {
 			switch {
 			case e.Op == logicalplan.OpEq && e.Right.String() == "":
 				return true, nil
 			case e.Op == logicalplan.OpNotEq && e.Right.String() != "":
 				return true, nil
+			case e.Op == logicalplan.OpLt && e.Right.String() != "":
+				return true, nil
+			case e.Op == logicalplan.OpLtEq:
+				return true, nil
+			case e.Op == logicalplan.OpGtEq && e.Right.String() == "":
+				return true, nil
+			case e.Op == logicalplan.OpStartsWith && e.Right.String() == "":
+				return true, nil
 			}
 		}
 		return false, nil
 	}
 
-	return BinaryScalarOperation(leftData, e.Right, e.Op)
+	return BinaryScalarOperation(leftData, e.right(e.Left.Type(rg)), e.Op)
+}
+
+// RowRanges returns the ranges of the pages of the column that may contain
+// values matching the expression.
+func (e BinaryScalarExpr) RowRanges(rg dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	mayContainUsefulData, err := e.Eval(rg)
+	if err != nil || !mayContainUsefulData {
+		return nil, err
+	}
+
+	columnChunk, exists, err := e.Left.Column(rg)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Op {
+	case logicalplan.OpEq, logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq, logicalplan.OpStartsWith:
+	default:
+		return allRows(rg), nil
+	}
+	if !exists {
+		return allRows(rg), nil
+	}
+
+	right := e.right(e.Left.Type(rg))
+	return pageRowRanges(rg, columnChunk, func(index parquet.ColumnIndex, i int) bool {
+		return pageMayMatch(columnChunk.Type(), index, i, right, e.Op)
+	}), nil
 }
 
 var ErrUnsupportedBinaryOperation = errors.New("unsupported binary operation")
@@ -69,22 +115,121 @@ var ErrUnsupportedBinaryOperation = errors.New("unsupported binary operation")
 func BinaryScalarOperation(left parquet.ColumnChunk, right parquet.Value, operator logicalplan.Op) (bool, error) {
 	switch operator {
 	case logicalplan.OpEq:
-		bloomFilter := left.BloomFilter()
-		if bloomFilter == nil {
-			// If there is no bloom filter then we cannot make a statement about a
-			// true negative so the rowgroup has to be a candidate.
-			return true, nil
+		// If there is no bloom filter then we cannot make a statement about a
+		// true negative from it. The same is true for values of a different
+		// kind, which are hashed differently than the column's values.
+		if bloomFilter := left.BloomFilter(); bloomFilter != nil && left.Type().Kind() == right.Kind() {
+			ok, err := bloomFilter.Check(right)
+			if err != nil {
+				return true, err
+			}
+			if !ok {
+				// Bloom filters may return false positives, but never return false
+				// negatives, we know this column chunk does not contain the value.
+				return false, nil
+			}
 		}
+		return columnIndexMayMatch(left, right, operator), nil
+	case logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq, logicalplan.OpStartsWith:
+		return columnIndexMayMatch(left, right, operator), nil
+	}
+	return true, nil
+}
 
-		ok, err := bloomFilter.Check(right)
-		if err != nil {
-			return true, err
+// columnIndexMayMatch returns whether any page of the column chunk may
+// contain values that compare to the value with the operator, according to
+// the min and max values of the chunk's column index. The column chunks of
+// dynamic columns only hold the values of a single concrete column, such as
+// labels.namespace, so their pages are ruled out like those of any other
+// column.
+func columnIndexMayMatch(columnChunk parquet.ColumnChunk, value parquet.Value, operator logicalplan.Op) bool {
+	index := columnChunk.ColumnIndex()
+	if index == nil {
+		return true
+	}
+
+	for i := 0; i < index.NumPages(); i++ {
+		if pageMayMatch(columnChunk.Type(), index, i, value, operator) {
+			return true
 		}
-		if !ok {
-			// Bloom filters may return false positives, but never return false
-			// negatives, we know this column chunk does not contain the value.
-			return false, nil
+	}
+	return false
+}
+
+// pageMayMatch returns whether the page at index i of the column index may
+// contain values that compare to the value with the operator.
+func pageMayMatch(typ parquet.Type, index parquet.ColumnIndex, i int, value parquet.Value, operator logicalplan.Op) bool {
+	if typ.Kind() != value.Kind() {
+		// The value can't be compared with the column's values.
+		return true
+	}
+
+	if typ.Kind() == parquet.ByteArray && index.NullCount(i) > 0 {
+		// Rows without a value of a dynamic column compare as the empty
+		// string, which is less than or equal to any value.
+		switch operator {
+		case logicalplan.OpEq, logicalplan.OpGtEq:
+			if len(value.ByteArray()) == 0 {
+				return true
+			}
+		case logicalplan.OpLt:
+			if len(value.ByteArray()) > 0 {
+				return true
+			}
+		case logicalplan.OpLtEq:
+			return true
+		case logicalplan.OpStartsWith:
+			if len(value.ByteArray()) == 0 {
+				return true
+			}
 		}
 	}
-	return true, nil
+	if index.NullPage(i) {
+		return false
+	}
+
+	min, max := index.MinValue(i), index.MaxValue(i)
+	if operator == logicalplan.OpStartsWith {
+		return prefixMayMatch(min.ByteArray(), max.ByteArray(), value.ByteArray())
+	}
+	minValue, maxValue := value, value
+	strict := operator == logicalplan.OpLt || operator == logicalplan.OpGt
+	if typ.Kind() == parquet.ByteArray {
+		// Page bounds of byte arrays may be truncated, a value that is
+		// only compared by its truncated prefix may equal the bound.
+		minValue = truncateByteArray(value, len(min.ByteArray()))
+		maxValue = truncateByteArray(value, len(max.ByteArray()))
+		if len(minValue.ByteArray()) < len(value.ByteArray()) || len(maxValue.ByteArray()) < len(value.ByteArray()) {
+			strict = false
+		}
+	}
+
+	switch operator {
+	case logicalplan.OpEq:
+		return typ.Compare(min, minValue) <= 0 && typ.Compare(maxValue, max) <= 0
+	case logicalplan.OpLt, logicalplan.OpLtEq:
+		cmp := typ.Compare(min, minValue)
+		return cmp < 0 || (!strict && cmp == 0)
+	case logicalplan.OpGt, logicalplan.OpGtEq:
+		cmp := typ.Compare(max, maxValue)
+		return cmp > 0 || (!strict && cmp == 0)
+	default:
+		return true
+	}
+}
+
+// prefixMayMatch returns whether any of the values between min and max may
+// start with the prefix. All values between min and max share a prefix
+// between the prefixes of min and max of the same length. The bounds may be
+// truncated, so the prefix is only compared with as much of max as it has.
+func prefixMayMatch(min, max, prefix []byte) bool {
+	return bytes.Compare(truncateBytes(min, len(prefix)), prefix) <= 0 &&
+		bytes.Compare(truncateBytes(prefix, len(max)), truncateBytes(max, len(prefix))) <= 0
+}
+
+func truncateBytes(b []byte, n int) []byte {
+	if len(b) > n {
+		return b[:n]
+	}
+	return b
 }
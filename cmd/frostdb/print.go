@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/hex"
+	"io"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/olekukonko/tablewriter"
+)
+
+const null = "NULL"
+
+// result holds the rows of a query as text, the columns are the columns of
+// all of its records in the order they were first seen.
+type result struct {
+	columns []string
+	index   map[string]int
+	rows    [][]string
+}
+
+func newResult() *result {
+	return &result{index: map[string]int{}}
+}
+
+func (res *result) append(r arrow.Record) error {
+	indices := make([]int, r.NumCols())
+	for i, field := range r.Schema().Fields() {
+		index, ok := res.index[field.Name]
+		if !ok {
+			index = len(res.columns)
+			res.index[field.Name] = index
+			res.columns = append(res.columns, field.Name)
+		}
+		indices[i] = index
+	}
+
+	for row := 0; row < int(r.NumRows()); row++ {
+		values := make([]string, len(res.columns))
+		for i := range values {
+			values[i] = null
+		}
+		for i, arr := range r.Columns() {
+			value, err := text(arr, row)
+			if err != nil {
+				return err
+			}
+			values[indices[i]] = value
+		}
+		res.rows = append(res.rows, values)
+	}
+	return nil
+}
+
+// printTable prints the rows as a table, rows shorter than the header are
+// padded with nulls.
+func printTable(w io.Writer, header []string, rows [][]string) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	for _, row := range rows {
+		for len(row) < len(header) {
+			row = append(row, null)
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// text returns the text of the value of the row.
+func text(arr arrow.Array, row int) (string, error) {
+	if arr.IsNull(row) {
+		return null, nil
+	}
+
+	switch arr := arr.(type) {
+	case *array.Int64:
+		return strconv.FormatInt(arr.Value(row), 10), nil
+	case *array.Uint64:
+		return strconv.FormatUint(arr.Value(row), 10), nil
+	case *array.Float64:
+		return strconv.FormatFloat(arr.Value(row), 'g', -1, 64), nil
+	case *array.String:
+		return arr.Value(row), nil
+	case *array.Binary:
+		// Strings are stored as binary, binary values that aren't
+		// printable strings are printed as hex.
+		v := arr.Value(row)
+		if printable(v) {
+			return string(v), nil
+		}
+		return `\x` + hex.EncodeToString(v), nil
+	default:
+		s, err := scalar.GetScalar(arr, row)
+		if err != nil {
+			return "", err
+		}
+		return s.String(), nil
+	}
+}
+
+func printable(v []byte) bool {
+	if !utf8.Valid(v) {
+		return false
+	}
+	for _, r := range string(v) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/thanos-io/objstore/client"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/sqlparse"
+)
+
+const queryUsage = `Usage: frostdb query [flags]
+
+Queries the tables of a database of a store with SQL, see the sqlparse
+package for the supported subset of SQL. Statements are terminated by a
+semicolon, the following commands are supported as well:
+
+  \d          list the tables
+  \d <table>  describe the columns of a table
+  \q          quit
+
+The tables of stores with a write-ahead log are replayed from the log.
+Tables that are only in a bucket are registered with the -table flag, which
+can't be used together with -storage-path since registering a table writes
+to the write-ahead log of the store.
+
+Flags:
+`
+
+// tableFlags are the schemas of the tables of the -table flag by their
+// names.
+type tableFlags map[string]*dynparquet.Schema
+
+func (f tableFlags) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+func (f tableFlags) Set(value string) error {
+	name, file, ok := strings.Cut(value, "=")
+	if !ok || name == "" || file == "" {
+		return fmt.Errorf("invalid table %q, expected <name>=<schema-file>", value)
+	}
+	schema, err := dynparquet.SchemaFromFile(file)
+	if err != nil {
+		return fmt.Errorf("read schema from file %q: %w", file, err)
+	}
+	f[name] = schema
+	return nil
+}
+
+func runQuery(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), queryUsage)
+		fs.PrintDefaults()
+	}
+	storagePath := fs.String("storage-path", "", "The storage path of a store with a write-ahead log.")
+	objstoreConfigFile := fs.String("objstore-config-file", "", "The YAML file of the configuration of the bucket of a store, see github.com/thanos-io/objstore/client.")
+	dbName := fs.String("db", "default", "The database to query.")
+	execute := fs.String("e", "", "Execute the statement and exit.")
+	tables := tableFlags{}
+	fs.Var(tables, "table", "Register the table of a bucket, as <name>=<schema-file> where the schema file is a JSON or YAML frostdb.schema.v1alpha1.Schema. May be repeated.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	if *storagePath != "" && len(tables) > 0 {
+		return fmt.Errorf("-table can't be used with -storage-path, the tables of the store are replayed from its write-ahead log")
+	}
+
+	logger := level.NewFilter(log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr)), level.AllowWarn())
+	var options []frostdb.Option
+	if *storagePath != "" {
+		options = append(options, frostdb.WithStoragePath(*storagePath), frostdb.WithWAL())
+	}
+	if *objstoreConfigFile != "" {
+		conf, err := os.ReadFile(*objstoreConfigFile)
+		if err != nil {
+			return fmt.Errorf("read objstore config: %w", err)
+		}
+		bucket, err := client.NewBucket(logger, conf, nil, "frostdb")
+		if err != nil {
+			return fmt.Errorf("create bucket: %w", err)
+		}
+		defer bucket.Close()
+		options = append(options, frostdb.WithBucketStorage(bucket))
+	}
+	if len(options) == 0 {
+		return fmt.Errorf("one of -storage-path and -objstore-config-file is required")
+	}
+
+	store, err := frostdb.New(logger, nil, options...)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	if err := store.ReplayWALs(context.Background()); err != nil {
+		return fmt.Errorf("replay WALs: %w", err)
+	}
+	db, err := store.DB(*dbName)
+	if err != nil {
+		return err
+	}
+	for name, schema := range tables {
+		if _, err := db.Table(name, frostdb.NewTableConfig(schema)); err != nil {
+			return fmt.Errorf("register table %q: %w", name, err)
+		}
+	}
+
+	r := &repl{
+		db:     db,
+		engine: query.NewEngine(memory.NewGoAllocator(), db.TableProvider()),
+		out:    os.Stdout,
+	}
+	if *execute != "" {
+		return r.run(strings.NewReader(*execute), false)
+	}
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return err
+	}
+	return r.run(os.Stdin, stat.Mode()&os.ModeCharDevice != 0)
+}
+
+// repl executes the statements and commands of its input.
+type repl struct {
+	db     *frostdb.DB
+	engine *query.LocalEngine
+	out    io.Writer
+}
+
+// run executes the statements of the input until its end, prompting for
+// them if the input is interactive. The errors of statements are printed.
+func (r *repl) run(in io.Reader, interactive bool) error {
+	scanner := bufio.NewScanner(in)
+	var stmt strings.Builder
+	prompt := func() {
+		if !interactive {
+			return
+		}
+		if stmt.Len() == 0 {
+			fmt.Fprint(r.out, "frostdb> ")
+		} else {
+			fmt.Fprint(r.out, "      -> ")
+		}
+	}
+
+	for prompt(); scanner.Scan(); prompt() {
+		line := strings.TrimSpace(scanner.Text())
+		if stmt.Len() == 0 && strings.HasPrefix(line, `\`) {
+			if quit := r.command(line); quit {
+				return nil
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		stmt.WriteString(line)
+		stmt.WriteByte('\n')
+		if strings.HasSuffix(line, ";") {
+			r.query(stmt.String())
+			stmt.Reset()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	// The last statement doesn't need to be terminated.
+	if strings.TrimSpace(stmt.String()) != "" {
+		r.query(stmt.String())
+	}
+	if interactive {
+		fmt.Fprintln(r.out)
+	}
+	return nil
+}
+
+// command executes a backslash command, it returns whether to quit.
+func (r *repl) command(line string) bool {
+	fields := strings.Fields(line)
+	switch {
+	case fields[0] == `\q`:
+		return true
+	case fields[0] == `\d` && len(fields) == 1:
+		printTable(r.out, []string{"table"}, tableRows(r.db.TableNames()))
+	case fields[0] == `\d` && len(fields) == 2:
+		table, err := r.db.GetTable(fields[1])
+		if err != nil {
+			fmt.Fprintln(r.out, "error:", err)
+			return false
+		}
+		var rows [][]string
+		for _, col := range table.Schema().Columns() {
+			rows = append(rows, []string{
+				col.Name,
+				col.StorageLayout.Type().String(),
+				fmt.Sprint(col.StorageLayout.Optional()),
+				fmt.Sprint(col.Dynamic),
+			})
+		}
+		printTable(r.out, []string{"column", "type", "nullable", "dynamic"}, rows)
+	default:
+		fmt.Fprintf(r.out, "unknown command %q, the commands are \\d, \\d <table> and \\q\n", line)
+	}
+	return false
+}
+
+func tableRows(names []string) [][]string {
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []string{name})
+	}
+	return rows
+}
+
+// query executes the statement and prints its rows, queries are canceled on
+// interrupts.
+func (r *repl) query(stmt string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	start := time.Now()
+	plan, err := sqlparse.Parse(r.db.TableProvider(), stmt)
+	if err != nil {
+		fmt.Fprintln(r.out, "error:", err)
+		return
+	}
+	q, err := r.engine.PreparePlan(plan)
+	if err != nil {
+		fmt.Fprintln(r.out, "error:", err)
+		return
+	}
+	res := newResult()
+	if err := q.Execute(ctx, nil, func(rec arrow.Record) error {
+		return res.append(rec)
+	}); err != nil {
+		fmt.Fprintln(r.out, "error:", err)
+		return
+	}
+
+	printTable(r.out, res.columns, res.rows)
+	rows := "rows"
+	if len(res.rows) == 1 {
+		rows = "row"
+	}
+	fmt.Fprintf(r.out, "(%d %s, %s)\n", len(res.rows), rows, time.Since(start).Round(time.Microsecond))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -360,7 +361,7 @@ func (db *DB) replayWAL(ctx context.Context) error {
 			table.pendingBlocks[table.active] = struct{}{}
 			go table.writeBlock(table.active)
 
-			if !proto.Equal(entry.Schema, table.config.schema.Definition()) {
+			if !proto.Equal(entry.Schema, table.config.Schema().Definition()) {
 				// If schemas are identical from block to block we should we
 				// reuse the previous schema in order to retain pooled memory
 				// for it.
@@ -370,7 +371,7 @@ func (db *DB) replayWAL(ctx context.Context) error {
 					return fmt.Errorf("instantiate schema: %w", err)
 				}
 
-				table.config.schema = schema
+				table.config.setSchema(schema)
 			}
 
 			table.active, err = newTableBlock(table, table.active.minTx, tx, id)
@@ -397,9 +398,32 @@ func (db *DB) replayWAL(ctx context.Context) error {
 				return fmt.Errorf("deserialize buffer: %w", err)
 			}
 
+			serBuf, err = table.Schema().MigrateSerializedBuffer(serBuf, table.config.migrations)
+			if err != nil {
+				return fmt.Errorf("migrate buffer: %w", err)
+			}
+
 			if err := table.active.Insert(ctx, tx, serBuf); err != nil {
 				return fmt.Errorf("insert buffer into block: %w", err)
 			}
+		case *walpb.Entry_TableSchema_:
+			entry := e.TableSchema
+			table, err := db.GetTable(entry.TableName)
+			var tableErr ErrTableNotFound
+			if errors.As(err, &tableErr) {
+				// The blocks of the table were persisted, the schemas of
+				// the blocks that follow are logged with them.
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("get table: %w", err)
+			}
+
+			schema, err := dynparquet.SchemaFromDefinition(entry.Schema)
+			if err != nil {
+				return fmt.Errorf("instantiate schema: %w", err)
+			}
+			table.config.setSchema(schema)
 		case *walpb.Entry_TableBlockPersisted_:
 			return nil
 		default:
@@ -505,6 +529,19 @@ func (db *DB) GetTable(name string) (*Table, error) {
 	return table, nil
 }
 
+// TableNames returns the names of the tables of the database in ascending
+// order.
+func (db *DB) TableNames() []string {
+	db.mtx.RLock()
+	names := make([]string, 0, len(db.tables))
+	for name := range db.tables {
+		names = append(names, name)
+	}
+	db.mtx.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
 func (db *DB) TableProvider() *DBTableProvider {
 	return NewDBTableProvider(db)
 }
@@ -525,6 +562,12 @@ func (p *DBTableProvider) GetTable(name string) logicalplan.TableReader {
 	return p.db.tables[name]
 }
 
+// TableNames returns the names of the tables of the database in ascending
+// order.
+func (p *DBTableProvider) TableNames() []string {
+	return p.db.TableNames()
+}
+
 // beginRead returns the high watermark. Reads can safely access any write that has a lower or equal tx id than the returned number.
 func (db *DB) beginRead() uint64 {
 	return db.highWatermark.Load()
@@ -1,24 +1,33 @@
 package frostdb
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
 	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
 	"github.com/thanos-io/objstore/providers/filesystem"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 )
@@ -206,6 +215,87 @@ func TestDBWithWAL(t *testing.T) {
 	require.Equal(t, 1, table.active.Index().Len())
 }
 
+func TestDBWithWALAddColumns(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+	config := NewTableConfig(schema)
+
+	logger := newTestLogger(t)
+	dir, err := ioutil.TempDir("", "frostdb-with-wal-add-columns-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := New(logger, prometheus.NewRegistry(), WithWAL(), WithStoragePath(dir))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	insert := func(row parquet.Row) {
+		buf, err := table.Schema().NewBuffer(nil)
+		require.NoError(t, err)
+		_, err = buf.WriteRows([]parquet.Row{row})
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	insert(parquet.Row{parquet.ValueOf(int64(1)).Level(0, 0, 0)})
+	require.NoError(t, table.AddColumns(&schemapb.Column{
+		Name: "region",
+		StorageLayout: &schemapb.StorageLayout{
+			Type:     schemapb.StorageLayout_TYPE_STRING,
+			Nullable: true,
+		},
+	}))
+	insert(parquet.Row{
+		parquet.ValueOf("eu").Level(0, 1, 0),
+		parquet.ValueOf(int64(2)).Level(0, 0, 1),
+	})
+	require.NoError(t, c.Close())
+
+	c, err = New(logger, prometheus.NewRegistry(), WithWAL(), WithStoragePath(dir))
+	require.NoError(t, err)
+	require.NoError(t, c.ReplayWALs(ctx))
+
+	// The replayed table has the schema with the added column.
+	db, err = c.DB("test")
+	require.NoError(t, err)
+	table, err = db.Table("test", config)
+	require.NoError(t, err)
+	_, ok := table.Schema().ColumnByName("region")
+	require.True(t, ok)
+	table.Sync()
+
+	regions := map[int64]interface{}{}
+	err = query.NewEngine(memory.NewGoAllocator(), db.TableProvider()).ScanTable("test").
+		Project(logicalplan.Col("region"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			region := r.Column(r.Schema().FieldIndices("region")[0]).(*array.Binary)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				regions[timestamps.Value(i)] = nil
+				if region.IsValid(i) {
+					regions[timestamps.Value(i)] = string(region.Value(i))
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]interface{}{1: nil, 2: "eu"}, regions)
+}
+
 func Test_DB_WithStorage(t *testing.T) {
 	config := NewTableConfig(
 		dynparquet.NewSampleSchema(),
@@ -297,3 +387,705 @@ func Test_DB_WithStorage(t *testing.T) {
 		})
 	require.NoError(t, err)
 }
+
+func TestDBWithStorageMigrations(t *testing.T) {
+	def := &schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}, {
+			Name:          "value",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	}
+	schema, err := dynparquet.SchemaFromDefinition(def)
+	require.NoError(t, err)
+
+	bucket := objstore.NewInMemBucket()
+	logger := newTestLogger(t)
+	c, err := New(logger, prometheus.NewRegistry(), WithBucketStorage(bucket))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	buf, err := schema.NewBuffer(map[string][]string{"labels": {"region"}})
+	require.NoError(t, err)
+	_, err = buf.WriteRows([]parquet.Row{{
+		parquet.ValueOf("eu").Level(0, 1, 0),
+		parquet.ValueOf(int64(1)).Level(0, 0, 1),
+		parquet.ValueOf(int64(3)).Level(0, 0, 2),
+	}, {
+		parquet.ValueOf("us").Level(0, 1, 0),
+		parquet.ValueOf(int64(2)).Level(0, 0, 1),
+		parquet.ValueOf(int64(4)).Level(0, 0, 2),
+	}})
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(ctx, buf)
+	require.NoError(t, err)
+
+	require.NoError(t, table.RotateBlock(table.ActiveBlock()))
+	for len(bucket.Objects()) == 0 {
+		time.Sleep(30 * time.Millisecond)
+	}
+	require.NoError(t, c.Close())
+
+	// The next version of the schema renames the labels and stores values as
+	// doubles, the persisted block is migrated when it's read.
+	def = proto.Clone(def).(*schemapb.Schema)
+	def.Columns[0].Name = "tags"
+	def.Columns[2].StorageLayout.Type = schemapb.StorageLayout_TYPE_DOUBLE
+	def.Version = 1
+	schema, err = dynparquet.SchemaFromDefinition(def)
+	require.NoError(t, err)
+
+	c, err = New(logger, prometheus.NewRegistry(), WithBucketStorage(bucket))
+	require.NoError(t, err)
+	defer c.Close()
+	db, err = c.DB("test")
+	require.NoError(t, err)
+	_, err = db.Table("test", NewTableConfig(schema, WithMigrations(dynparquet.Migrations{0: {
+		dynparquet.RenameColumn("labels", "tags"),
+		dynparquet.WidenColumn("value", schemapb.StorageLayout_TYPE_DOUBLE),
+	}})))
+	require.NoError(t, err)
+
+	values := []float64{}
+	err = query.NewEngine(memory.NewGoAllocator(), db.TableProvider()).ScanTable("test").
+		Filter(logicalplan.Col("tags.region").Eq(logicalplan.Literal("eu"))).
+		Project(logicalplan.Col("value")).
+		Execute(ctx, func(r arrow.Record) error {
+			col := r.Column(r.Schema().FieldIndices("value")[0]).(*array.Float64)
+			values = append(values, col.Float64Values()...)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []float64{3}, values)
+}
+
+func TestBucketReaderAtScanStats(t *testing.T) {
+	bucket := objstore.NewInMemBucket()
+	require.NoError(t, bucket.Upload(context.Background(), "block", bytes.NewReader([]byte("0123456789"))))
+
+	stats := &logicalplan.ScanStats{}
+	r := &BucketReaderAt{
+		name:   "block",
+		ctx:    logicalplan.WithScanStats(context.Background(), stats),
+		Bucket: bucket,
+	}
+	p := make([]byte, 4)
+	n, err := r.ReadAt(p, 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, "3456", string(p))
+	require.Equal(t, int64(4), stats.BytesRead())
+}
+
+func TestQueryCancelled(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	// Each insert is read as a separate record.
+	samples := dynparquet.NewTestSamples()
+	for i := range samples {
+		buf, err := samples[i : i+1].ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := 0
+	err = engine.ScanTable("test").Execute(ctx, func(r arrow.Record) error {
+		received++
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, received)
+
+	// Queries of cancelled contexts don't read anything.
+	err = engine.ScanTable("test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.label1")).
+		Execute(ctx, func(r arrow.Record) error {
+			received++
+			return nil
+		})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, received)
+}
+
+func TestPartialResults(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	for i := range samples {
+		buf, err := samples[i : i+1].ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithResultCache(10))
+	sums := func(r arrow.Record) map[string]int64 {
+		res := map[string]int64{}
+		labels := r.Column(r.Schema().FieldIndices("labels.namespace")[0]).(*array.Binary)
+		values := r.Column(r.Schema().FieldIndices("sum(value)")[0]).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			res[string(labels.Value(i))] = values.Value(i)
+		}
+		return res
+	}
+
+	var expected map[string]int64
+	err = engine.ScanTable("test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.namespace")).
+		Execute(context.Background(), func(r arrow.Record) error {
+			expected = sums(r)
+			return nil
+		})
+	require.NoError(t, err)
+
+	// Every record but the last one is a partial result, the last one has
+	// the same results as the query without partial results.
+	var (
+		partial []bool
+		last    map[string]int64
+	)
+	err = engine.ScanTable("test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.namespace")).
+		PartialResults(time.Nanosecond).
+		Execute(context.Background(), func(r arrow.Record) error {
+			partial = append(partial, query.IsPartialResult(r))
+			last = sums(r)
+			return nil
+		})
+	require.NoError(t, err)
+	require.NotEmpty(t, partial)
+	for _, p := range partial[:len(partial)-1] {
+		require.True(t, p)
+	}
+	require.False(t, partial[len(partial)-1])
+	require.Equal(t, expected, last)
+}
+
+func TestRemoteNodes(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// The samples are split between two nodes, the coordinator only knows
+	// the schema of the table. The reference engine has all of the samples.
+	samples := dynparquet.NewTestSamples()
+	newEngine := func(name string, samples dynparquet.Samples, options ...query.Option) *query.LocalEngine {
+		db, err := c.DB(name)
+		require.NoError(t, err)
+		table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+		require.NoError(t, err)
+		if len(samples) > 0 {
+			buf, err := samples.ToBuffer(table.Schema())
+			require.NoError(t, err)
+			_, err = table.InsertBuffer(context.Background(), buf)
+			require.NoError(t, err)
+		}
+		table.Sync()
+		return query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), options...)
+	}
+	reference := newEngine("reference", samples)
+	coordinator := newEngine("coordinator", nil, query.WithRemoteNodes(
+		newEngine("node1", samples[:2]),
+		newEngine("node2", samples[2:]),
+	))
+
+	rows := func(engine *query.LocalEngine, q func(query.Builder) query.Builder) []string {
+		res := []string{}
+		err := q(engine.ScanTable("test")).Execute(context.Background(), func(r arrow.Record) error {
+			for i := 0; i < int(r.NumRows()); i++ {
+				row := []string{}
+				for j, col := range r.Columns() {
+					var value interface{}
+					switch col := col.(type) {
+					case *array.Int64:
+						value = col.Value(i)
+					case *array.Float64:
+						// Merged results only differ by rounding.
+						value = strconv.FormatFloat(col.Value(i), 'g', 12, 64)
+					case *array.Binary:
+						value = string(col.Value(i))
+					}
+					if col.IsNull(i) {
+						value = nil
+					}
+					row = append(row, fmt.Sprintf("%s=%v", r.ColumnName(j), value))
+				}
+				res = append(res, strings.Join(row, " "))
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		sort.Strings(res)
+		return res
+	}
+
+	for name, q := range map[string]func(query.Builder) query.Builder{
+		"aggregation": func(b query.Builder) query.Builder {
+			return b.Aggregations(
+				[]logicalplan.Expr{
+					logicalplan.Sum(logicalplan.Col("value")),
+					logicalplan.Count(logicalplan.Col("value")),
+					logicalplan.Max(logicalplan.Col("timestamp")),
+				},
+				logicalplan.Col("labels.namespace"),
+			)
+		},
+		// Averages are merged from the sums and counts of the nodes.
+		"avg": func(b query.Builder) query.Builder {
+			return b.Aggregations(
+				[]logicalplan.Expr{
+					logicalplan.Avg(logicalplan.Col("value")),
+					logicalplan.Avg(logicalplan.Col("timestamp")).Alias("avg_timestamp"),
+				},
+				logicalplan.Col("labels.namespace"),
+			)
+		},
+		// The sketches of the nodes are merged.
+		"sketches": func(b query.Builder) query.Builder {
+			return b.Aggregations(
+				[]logicalplan.Expr{
+					logicalplan.ApproxCountDistinct(logicalplan.Col("value")),
+					logicalplan.ApproxCountDistinct(logicalplan.Col("timestamp")).Alias("timestamps"),
+					logicalplan.Quantile(logicalplan.Col("value"), 0.9),
+				},
+				logicalplan.Col("labels.namespace"),
+			)
+		},
+		// The variances of the nodes are merged.
+		"variance": func(b query.Builder) query.Builder {
+			return b.Aggregations(
+				[]logicalplan.Expr{
+					logicalplan.Variance(logicalplan.Col("value")),
+					logicalplan.StdDev(logicalplan.Col("timestamp")),
+				},
+				logicalplan.Col("labels.namespace"),
+			)
+		},
+		"filter": func(b query.Builder) query.Builder {
+			return b.
+				Filter(logicalplan.Col("value").GtEq(logicalplan.Literal(int64(3)))).
+				Project(logicalplan.Col("timestamp"), logicalplan.Col("value"))
+		},
+		"limit": func(b query.Builder) query.Builder {
+			return b.Project(logicalplan.Col("value")).Limit(2)
+		},
+		// Filtered aggregations aren't executed by the nodes, which only
+		// filter the rows.
+		"filtered aggregation": func(b query.Builder) query.Builder {
+			return b.
+				Filter(logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(0)))).
+				Aggregate(
+					logicalplan.Count(logicalplan.Col("value")).Where(logicalplan.Col("value").Gt(logicalplan.Literal(int64(3)))),
+					logicalplan.Col("labels.namespace"),
+				)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expected := rows(reference, q)
+			require.NotEmpty(t, expected)
+			res := rows(coordinator, q)
+			if name == "limit" {
+				require.Len(t, res, 2)
+				return
+			}
+			require.Equal(t, expected, res)
+		})
+	}
+
+	explain, err := coordinator.ScanTable("test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.namespace")).
+		Explain()
+	require.NoError(t, err)
+	require.Contains(t, explain, `Physical Plan:
+HashAggregate Final [sum(value)] Group: [labels.namespace]
+Gather Table: test Nodes: 2 Aggregation: [sum(value)] Group: [labels.namespace]`)
+
+	explain, err = coordinator.ScanTable("test").
+		Aggregate(logicalplan.Avg(logicalplan.Col("value")), logicalplan.Col("labels.namespace")).
+		Explain()
+	require.NoError(t, err)
+	require.Contains(t, explain, `Physical Plan:
+HashAggregate Final [avg(value)] Group: [labels.namespace]
+Gather Table: test Nodes: 2 Aggregation: [avg(value).sum, avg(value).count] Group: [labels.namespace]`)
+}
+
+func TestQueryTimeout(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	for i := range samples {
+		buf, err := samples[i : i+1].ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithQueryTimeout(time.Minute))
+
+	// The function takes longer than the query's timeout.
+	err = engine.RegisterFunction("slow", arrow.PrimitiveTypes.Int64, func(pool memory.Allocator, args []arrow.Array) (arrow.Array, error) {
+		time.Sleep(20 * time.Millisecond)
+		args[0].Retain()
+		return args[0], nil
+	})
+	require.NoError(t, err)
+
+	slowQuery := func() query.Builder {
+		return engine.ScanTable("test").
+			Project(logicalplan.Call("slow", logicalplan.Col("value")).Alias("value")).
+			OrderBy(logicalplan.Asc(logicalplan.Col("value")))
+	}
+
+	rows := int64(0)
+	err = slowQuery().Execute(context.Background(), func(r arrow.Record) error {
+		rows += r.NumRows()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(samples)), rows)
+
+	err = slowQuery().
+		Timeout(10*time.Millisecond).
+		Execute(context.Background(), func(r arrow.Record) error {
+			t.Fatal("unexpected record")
+			return nil
+		})
+	var timeoutErr *query.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueryMemoryLimit(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	for i := range samples {
+		buf, err := samples[i : i+1].ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	for _, test := range []struct {
+		name     string
+		limit    int64
+		expected error
+	}{{
+		name:  "within limit",
+		limit: 1 << 30,
+	}, {
+		name:     "exceeded",
+		limit:    1,
+		expected: query.ErrQueryMemoryExceeded,
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithQueryMemoryLimit(test.limit))
+
+			rows := int64(0)
+			err := engine.ScanTable("test").
+				OrderBy(logicalplan.Asc(logicalplan.Col("timestamp"))).
+				Execute(context.Background(), func(r arrow.Record) error {
+					rows += r.NumRows()
+					return nil
+				})
+			if test.expected != nil {
+				require.ErrorIs(t, err, test.expected)
+				require.Equal(t, int64(0), rows)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(len(samples)), rows)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	_, err = db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+	explain, err := engine.ScanTable("test").
+		Filter(logicalplan.Col("timestamp").GtEq(logicalplan.Literal(int64(1)))).
+		Limit(2).
+		Explain()
+	require.NoError(t, err)
+	require.Equal(t, `Logical Plan:
+Limit 2
+  Filter Expr: timestamp >= 1
+    TableScan Table: test Projection: [] Filter: timestamp >= 1 Distinct: [] PhysicalProjection: [timestamp]
+
+Physical Plan:
+Limit 2
+Filter timestamp >= 1
+TableScan Table: test Columns: [timestamp] Filter: timestamp >= 1`, explain)
+}
+
+func TestExecuteWithStats(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+	stats, err := engine.ScanTable("test").
+		Filter(logicalplan.Col("value").Gt(logicalplan.Literal(int64(3)))).
+		ExecuteWithStats(context.Background(), func(r arrow.Record) error { return nil })
+	require.NoError(t, err)
+	require.Len(t, stats.Operators, 2)
+	require.Equal(t, "Filter value > 3", stats.Operators[0].Name)
+	require.Equal(t, int64(1), stats.Operators[0].RowsOut)
+	require.Equal(t, stats.Operators[1].RowsOut, stats.Operators[0].RowsIn)
+	require.Equal(t, int64(len(samples)), stats.RowsScanned)
+	require.Equal(t, int64(1), stats.RowGroupsScanned)
+	require.Equal(t, int64(0), stats.RowGroupsPruned)
+	require.Equal(t, int64(0), stats.BytesRead)
+	require.Greater(t, stats.PeakMemory, int64(0))
+	require.Greater(t, stats.Duration, time.Duration(0))
+
+	// Row groups none of whose rows match the filter aren't scanned.
+	stats, err = engine.ScanTable("test").
+		Filter(logicalplan.Col("timestamp").Lt(logicalplan.Literal(int64(0)))).
+		ExecuteWithStats(context.Background(), func(r arrow.Record) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, int64(0), stats.RowsScanned)
+	require.Equal(t, int64(1), stats.RowGroupsPruned)
+
+	// The partial aggregations of concurrent aggregations are counted
+	// together.
+	stats, err = query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithConcurrency(2)).
+		ScanTable("test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.label1")).
+		ExecuteWithStats(context.Background(), func(r arrow.Record) error { return nil })
+	require.NoError(t, err)
+	require.Len(t, stats.Operators, 3)
+	require.Equal(t, "HashAggregate Final [sum(value)] Group: [labels.label1]", stats.Operators[0].Name)
+	require.Equal(t, int64(len(samples)), stats.Operators[1].RowsIn)
+	require.Equal(t, stats.Operators[1].RowsOut, stats.Operators[0].RowsIn)
+
+	_, err = engine.ScanTable("test").
+		Project(logicalplan.Call("unknown", logicalplan.Col("value"))).
+		ExecuteWithStats(context.Background(), func(r arrow.Record) error { return nil })
+	require.ErrorIs(t, err, logicalplan.ErrUnknownFunction)
+}
+
+func TestScanConcurrency(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	// Each insert is a separate row group.
+	for i := 0; i < 8; i++ {
+		samples := dynparquet.Samples{}
+		for j := 0; j < 10; j++ {
+			samples = append(samples, dynparquet.Sample{
+				ExampleType: "test",
+				Labels: []dynparquet.Label{
+					{Name: "label1", Value: "value1"},
+				},
+				Timestamp: int64(j),
+				Value:     int64(i*10 + j),
+			})
+		}
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithScanConcurrency(4))
+	filter := logicalplan.Col("value").GtEq(logicalplan.Literal(int64(25)))
+
+	explain, err := engine.ScanTable("test").Filter(filter).Explain()
+	require.NoError(t, err)
+	require.Contains(t, explain, "TableScan Table: test Concurrency: 4")
+
+	values := []int64{}
+	err = engine.ScanTable("test").Filter(filter).Execute(context.Background(), func(r arrow.Record) error {
+		col := r.Column(r.Schema().FieldIndices("value")[0]).(*array.Int64)
+		for i := 0; i < col.Len(); i++ {
+			values = append(values, col.Value(i))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	expected := []int64{}
+	for v := int64(25); v < 80; v++ {
+		expected = append(expected, v)
+	}
+	require.Equal(t, expected, values)
+
+	// Limits stop the goroutines reading the table.
+	rows := int64(0)
+	err = engine.ScanTable("test").Filter(filter).Limit(3).Execute(context.Background(), func(r arrow.Record) error {
+		rows += r.NumRows()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rows)
+}
+
+func TestResultCache(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	insert := func() {
+		samples := dynparquet.NewTestSamples()
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+		table.Sync()
+	}
+	insert()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithResultCache(8))
+
+	// The function counts how often the query's records are projected.
+	calls := 0
+	err = engine.RegisterFunction("count_calls", arrow.PrimitiveTypes.Int64, func(pool memory.Allocator, args []arrow.Array) (arrow.Array, error) {
+		calls++
+		args[0].Retain()
+		return args[0], nil
+	})
+	require.NoError(t, err)
+
+	execute := func(value int64) int64 {
+		rows := int64(0)
+		err := engine.ScanTable("test").
+			Filter(logicalplan.Col("value").GtEq(logicalplan.Literal(value))).
+			Project(logicalplan.Call("count_calls", logicalplan.Col("value")).Alias("value")).
+			Execute(context.Background(), func(r arrow.Record) error {
+				rows += r.NumRows()
+				return nil
+			})
+		require.NoError(t, err)
+		return rows
+	}
+
+	require.Equal(t, int64(1), execute(4))
+	require.Equal(t, 1, calls)
+
+	// The result of the identical plan is served from the cache.
+	require.Equal(t, int64(1), execute(4))
+	require.Equal(t, 1, calls)
+
+	// Plans with different literals are executed.
+	require.Equal(t, int64(3), execute(3))
+	require.Equal(t, 2, calls)
+
+	// Writing to the table invalidates the cached results. The function is
+	// called for each of the table's row groups.
+	insert()
+	require.Equal(t, int64(2), execute(4))
+	require.Greater(t, calls, 2)
+	executed := calls
+	require.Equal(t, int64(2), execute(4))
+	require.Equal(t, executed, calls)
+}
+
+func TestQueryPriority(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	// Each insert is a separate row group, so the queries yield between
+	// them.
+	for i := 0; i < 4; i++ {
+		samples := dynparquet.NewTestSamples()
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider(), query.WithScheduler(1))
+	priorities := []query.Priority{query.PriorityBackground, query.PriorityBatch, query.PriorityInteractive}
+	errs := make(chan error, 3*len(priorities))
+	for i := 0; i < 3; i++ {
+		for _, p := range priorities {
+			p := p
+			go func() {
+				errs <- engine.ScanTable("test").
+					Priority(p).
+					Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.label1")).
+					Execute(context.Background(), func(r arrow.Record) error { return nil })
+			}()
+		}
+	}
+	for i := 0; i < cap(errs); i++ {
+		require.NoError(t, <-errs)
+	}
+}
@@ -0,0 +1,104 @@
+package dynparquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+)
+
+// definitionEnums are the prefixes and values of the enums of schema
+// definitions by the names of their fields.
+var definitionEnums = map[string]struct {
+	prefix string
+	values map[string]int32
+}{
+	"type":                {"TYPE_", schemapb.StorageLayout_Type_value},
+	"value_type":          {"TYPE_", schemapb.StorageLayout_Type_value},
+	"valueType":           {"TYPE_", schemapb.StorageLayout_Type_value},
+	"encoding":            {"ENCODING_", schemapb.StorageLayout_Encoding_value},
+	"compression":         {"COMPRESSION_", schemapb.StorageLayout_Compression_value},
+	"default_compression": {"COMPRESSION_", schemapb.StorageLayout_Compression_value},
+	"defaultCompression":  {"COMPRESSION_", schemapb.StorageLayout_Compression_value},
+	"direction":           {"DIRECTION_", schemapb.SortingColumn_Direction_value},
+}
+
+// ParseDefinition parses a schema definition of the JSON or YAML form of the
+// frostdb.schema.v1alpha1.Schema message:
+//
+//	name: samples
+//	columns:
+//	  - name: labels
+//	    storage_layout: {type: string, nullable: true, encoding: rle_dictionary}
+//	    dynamic: true
+//	  - name: timestamp
+//	    storage_layout: {type: int64}
+//	sorting_columns:
+//	  - {name: timestamp, direction: ascending}
+//
+// Enum values are the names of the values of the enums, which may be written
+// without the prefix of the enum and in lower case, such as string for
+// TYPE_STRING and plain for ENCODING_PLAIN_UNSPECIFIED.
+func ParseDefinition(data []byte) (*schemapb.Schema, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parse definition: %w", err)
+	}
+	b, err := json.Marshal(normalizeDefinition(v))
+	if err != nil {
+		return nil, fmt.Errorf("parse definition: %w", err)
+	}
+
+	def := &schemapb.Schema{}
+	if err := protojson.Unmarshal(b, def); err != nil {
+		return nil, fmt.Errorf("parse definition: %w", err)
+	}
+	return def, nil
+}
+
+// normalizeDefinition returns the value of a parsed definition with the full
+// names of its enum values.
+func normalizeDefinition(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			s, ok := value.(string)
+			enum, isEnum := definitionEnums[key]
+			if !ok || !isEnum {
+				v[key] = normalizeDefinition(value)
+				continue
+			}
+			upper := strings.ToUpper(s)
+			for _, name := range []string{upper, enum.prefix + upper, enum.prefix + upper + "_UNSPECIFIED"} {
+				if _, ok := enum.values[name]; ok {
+					v[key] = name
+					break
+				}
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeDefinition(value)
+		}
+	}
+	return v
+}
+
+// SchemaFromFile returns the schema of the JSON or YAML definition of a file,
+// see ParseDefinition.
+func SchemaFromFile(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	def, err := ParseDefinition(data)
+	if err != nil {
+		return nil, err
+	}
+	return SchemaFromDefinition(def)
+}
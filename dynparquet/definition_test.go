@@ -0,0 +1,98 @@
+package dynparquet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+)
+
+func TestParseDefinition(t *testing.T) {
+	expected := &schemapb.Schema{
+		Name: "samples",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+				Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+			},
+			Dynamic: true,
+		}, {
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS,
+				Encoding: schemapb.StorageLayout_ENCODING_DELTA_BINARY_PACKED,
+			},
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:        schemapb.StorageLayout_TYPE_DOUBLE,
+				Compression: schemapb.StorageLayout_COMPRESSION_ZSTD,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:       "labels",
+			Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+			NullsFirst: true,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_DESCENDING,
+		}},
+		DefaultCompression: schemapb.StorageLayout_COMPRESSION_SNAPPY,
+	}
+
+	for name, data := range map[string]string{
+		"yaml": `
+name: samples
+columns:
+  - name: labels
+    storage_layout: {type: string, nullable: true, encoding: rle_dictionary}
+    dynamic: true
+  - name: timestamp
+    storage_layout:
+      type: timestamp_millis
+      encoding: DELTA_BINARY_PACKED
+  - name: value
+    storage_layout: {type: double, compression: zstd}
+sorting_columns:
+  - {name: labels, direction: ascending, nulls_first: true}
+  - {name: timestamp, direction: descending}
+default_compression: snappy
+`,
+		"json": `{
+  "name": "samples",
+  "columns": [
+    {"name": "labels", "storageLayout": {"type": "TYPE_STRING", "nullable": true, "encoding": "ENCODING_RLE_DICTIONARY"}, "dynamic": true},
+    {"name": "timestamp", "storageLayout": {"type": "TYPE_TIMESTAMP_MILLIS", "encoding": "delta_binary_packed"}},
+    {"name": "value", "storageLayout": {"type": "double", "compression": "COMPRESSION_ZSTD"}}
+  ],
+  "sortingColumns": [
+    {"name": "labels", "direction": "DIRECTION_ASCENDING", "nullsFirst": true},
+    {"name": "timestamp", "direction": "DIRECTION_DESCENDING"}
+  ],
+  "defaultCompression": "COMPRESSION_SNAPPY"
+}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			def, err := ParseDefinition([]byte(data))
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected, def), def.String())
+
+			file := filepath.Join(t.TempDir(), "schema."+name)
+			require.NoError(t, os.WriteFile(file, []byte(data), 0o600))
+			schema, err := SchemaFromFile(file)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected, schema.Definition()))
+		})
+	}
+
+	_, err := ParseDefinition([]byte("name: test\ncolumns:\n  - name: a\n    storage_layout: {type: varchar}\n"))
+	require.Error(t, err)
+	_, err = ParseDefinition([]byte("name: test\nunknown: true\n"))
+	require.Error(t, err)
+}
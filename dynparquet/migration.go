@@ -0,0 +1,290 @@
+package dynparquet
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+)
+
+// Migration migrates row groups of data written with a version of a schema
+// to the next version of the schema.
+type Migration interface {
+	// Migrate returns the row group with the columns of the next version of
+	// the schema.
+	Migrate(rg DynamicRowGroup) (DynamicRowGroup, error)
+}
+
+// MigrationFunc is a function that implements the Migration interface.
+type MigrationFunc func(rg DynamicRowGroup) (DynamicRowGroup, error)
+
+// Migrate implements the Migration interface.
+func (f MigrationFunc) Migrate(rg DynamicRowGroup) (DynamicRowGroup, error) {
+	return f(rg)
+}
+
+// Migrations are the migrations of the versions of a schema. The migrations
+// of a version migrate its data to the next version, in order. Versions
+// without migrations only added columns, which needs no migration.
+type Migrations map[uint32][]Migration
+
+// Migrate migrates a row group of data of version from to version to.
+func (m Migrations) Migrate(rg DynamicRowGroup, from, to uint32) (DynamicRowGroup, error) {
+	for v := from; v < to; v++ {
+		for _, migration := range m[v] {
+			var err error
+			rg, err = migration.Migrate(rg)
+			if err != nil {
+				return nil, fmt.Errorf("migrate version %d: %w", v, err)
+			}
+		}
+	}
+	return rg, nil
+}
+
+// migrates returns whether data of version from changes when it's migrated
+// to version to.
+func (m Migrations) migrates(from, to uint32) bool {
+	for v := from; v < to; v++ {
+		if len(m[v]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateSerializedBuffer returns the buffer migrated from the version of its
+// schema to the version of the schema, with the parquet schema of the schema
+// for its dynamic columns like ConvertSerializedBuffer.
+func (s *Schema) MigrateSerializedBuffer(buf *SerializedBuffer, migrations Migrations) (*SerializedBuffer, error) {
+	from, to := buf.SchemaVersion(), s.Version()
+	if !migrations.migrates(from, to) {
+		return s.ConvertSerializedBuffer(buf)
+	}
+
+	rowGroups := make([]DynamicRowGroup, buf.NumRowGroups())
+	for i := range rowGroups {
+		rg, err := migrations.Migrate(buf.DynamicRowGroup(i), from, to)
+		if err != nil {
+			return nil, err
+		}
+		rowGroups[i] = rg
+	}
+	return s.convertDynamicRowGroups(mergeDynamicRowGroupDynamicColumns(rowGroups), rowGroups)
+}
+
+// RenameColumn returns a migration that renames a column. The concrete
+// columns of dynamic columns are renamed with them.
+func RenameColumn(from, to string) Migration {
+	return &columnMigration{column: from, name: to}
+}
+
+// WidenColumn returns a migration that changes the type of a column to a type
+// that holds all of its values: int64 and decimal columns to double, and
+// timestamp columns to timestamps of finer units. Columns that already have
+// the type are left as is.
+func WidenColumn(column string, typ schemapb.StorageLayout_Type) Migration {
+	return &columnMigration{
+		column: column,
+		name:   column,
+		widen: func(f parquet.Field) (parquet.Node, func(parquet.Value) parquet.Value, error) {
+			if !f.Leaf() {
+				return nil, nil, fmt.Errorf("can't widen column %s, it isn't a leaf column", f.Name())
+			}
+			node, err := storageLayoutToParquetNode(&schemapb.StorageLayout{
+				Type:     typ,
+				Nullable: f.Optional(),
+				Repeated: f.Repeated(),
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			convert, err := widenValues(f.Type(), node.Type())
+			if err != nil {
+				return nil, nil, fmt.Errorf("widen column %s: %w", f.Name(), err)
+			}
+			if convert == nil {
+				return f, nil, nil
+			}
+			return node, convert, nil
+		},
+	}
+}
+
+// widenValues returns the conversion of values of type from to type to, or
+// nil if the types are the same.
+func widenValues(from, to parquet.Type) (func(parquet.Value) parquet.Value, error) {
+	if from.String() == to.String() {
+		return nil, nil
+	}
+
+	fromLT, toLT := from.LogicalType(), to.LogicalType()
+	switch {
+	case from.Kind() == parquet.Int64 && to.Kind() == parquet.Double && toLT == nil:
+		switch {
+		case fromLT == nil || fromLT.Integer != nil:
+			return func(v parquet.Value) parquet.Value {
+				return parquet.ValueOf(float64(v.Int64()))
+			}, nil
+		case fromLT.Decimal != nil:
+			scale := math.Pow10(int(fromLT.Decimal.Scale))
+			return func(v parquet.Value) parquet.Value {
+				return parquet.ValueOf(float64(v.Int64()) / scale)
+			}, nil
+		}
+	case fromLT != nil && fromLT.Timestamp != nil && toLT != nil && toLT.Timestamp != nil:
+		fromUnit, toUnit := timestampUnitsPerSecond(fromLT.Timestamp.Unit), timestampUnitsPerSecond(toLT.Timestamp.Unit)
+		if toUnit >= fromUnit {
+			factor := toUnit / fromUnit
+			return func(v parquet.Value) parquet.Value {
+				return parquet.ValueOf(v.Int64() * factor)
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("can't widen type %s to %s", from, to)
+}
+
+func timestampUnitsPerSecond(unit format.TimeUnit) int64 {
+	switch {
+	case unit.Millis != nil:
+		return 1e3
+	case unit.Micros != nil:
+		return 1e6
+	default:
+		return 1e9
+	}
+}
+
+// columnMigration renames a column and changes the types of its leaf columns.
+type columnMigration struct {
+	column string
+	name   string
+	// widen returns the node of a field of the column and the conversion of
+	// its values, which is nil if the values don't change.
+	widen func(f parquet.Field) (parquet.Node, func(parquet.Value) parquet.Value, error)
+}
+
+func (m *columnMigration) Migrate(rg DynamicRowGroup) (DynamicRowGroup, error) {
+	dynCols := rg.DynamicColumns()
+	_, dynamic := dynCols[m.column]
+
+	group := parquet.Group{}
+	// Names of the fields of the row group that are migrated by their new
+	// names, and the conversions of the values of the migrated fields.
+	names := map[string]string{}
+	conversions := map[string]func(parquet.Value) parquet.Value{}
+	for _, f := range rg.Schema().Fields() {
+		name := f.Name()
+		var node parquet.Node = f
+		if name == m.column || dynamic && strings.HasPrefix(name, m.column+".") {
+			name = m.name + strings.TrimPrefix(name, m.column)
+			var convert func(parquet.Value) parquet.Value
+			if m.widen != nil {
+				var err error
+				node, convert, err = m.widen(f)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if name != f.Name() || convert != nil {
+				names[f.Name()] = name
+				conversions[name] = convert
+			}
+		}
+		if _, ok := group[name]; ok {
+			return nil, fmt.Errorf("can't rename column %s to %s, the column already exists", f.Name(), name)
+		}
+		group[name] = node
+	}
+	if len(names) == 0 {
+		return rg, nil
+	}
+
+	migratedPath := func(path []string) []string {
+		if name, ok := names[path[0]]; ok {
+			return append([]string{name}, path[1:]...)
+		}
+		return path
+	}
+
+	schema := parquet.NewSchema(rg.Schema().Name(), group)
+	columns := rg.Schema().Columns()
+	indexes := make([]int, len(columns))
+	converts := make([]func(parquet.Value) parquet.Value, len(columns))
+	for i, path := range columns {
+		leaf, ok := schema.Lookup(migratedPath(path)...)
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in migrated schema", strings.Join(path, "."))
+		}
+		indexes[i] = leaf.ColumnIndex
+		if name, ok := names[path[0]]; ok {
+			converts[i] = conversions[name]
+		}
+	}
+
+	sortingColumns := make([]parquet.SortingColumn, 0, len(rg.SortingColumns()))
+	for _, col := range rg.SortingColumns() {
+		path := migratedPath(col.Path())
+		c := parquet.Ascending(path...)
+		if col.Descending() {
+			c = parquet.Descending(path...)
+		}
+		if col.NullsFirst() {
+			c = parquet.NullsFirst(c)
+		}
+		sortingColumns = append(sortingColumns, c)
+	}
+
+	migratedDynCols := make(map[string][]string, len(dynCols))
+	for name, cols := range dynCols {
+		if name == m.column {
+			name = m.name
+		}
+		migratedDynCols[name] = cols
+	}
+
+	buf := parquet.NewBuffer(schema, parquet.SortingColumns(sortingColumns...))
+	rows := rg.Rows()
+	defer rows.Close()
+
+	rowBuf := make([]parquet.Row, 64)
+	for {
+		n, err := rows.ReadRows(rowBuf)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+		for _, row := range rowBuf[:n] {
+			migrated := make(parquet.Row, len(row))
+			for j, v := range row {
+				col, rep, def := v.Column(), v.RepetitionLevel(), v.DefinitionLevel()
+				if convert := converts[col]; convert != nil && !v.IsNull() {
+					v = convert(v)
+				}
+				migrated[j] = v.Level(rep, def, indexes[col])
+			}
+			// The values of rows are ordered by their columns, which the
+			// renamed columns can change.
+			sort.SliceStable(migrated, func(a, b int) bool {
+				return migrated[a].Column() < migrated[b].Column()
+			})
+			if _, err := buf.WriteRows([]parquet.Row{migrated}); err != nil {
+				return nil, fmt.Errorf("write row: %w", err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return &Buffer{
+		dynamicColumns: migratedDynCols,
+		buffer:         buf,
+		fields:         schema.Fields(),
+	}, nil
+}
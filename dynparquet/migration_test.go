@@ -0,0 +1,151 @@
+package dynparquet
+
+import (
+	"io"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+)
+
+func TestMigrateSerializedBuffer(t *testing.T) {
+	def := &schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS},
+		}, {
+			Name:          "value",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	}
+	schema, err := SchemaFromDefinition(def)
+	require.NoError(t, err)
+
+	buf, err := schema.NewBuffer(map[string][]string{"labels": {"region"}})
+	require.NoError(t, err)
+	_, err = buf.WriteRows([]parquet.Row{{
+		parquet.ValueOf("eu").Level(0, 1, 0),
+		parquet.ValueOf(int64(1)).Level(0, 0, 1),
+		parquet.ValueOf(int64(3)).Level(0, 0, 2),
+	}, {
+		parquet.ValueOf(nil).Level(0, 0, 0),
+		parquet.ValueOf(int64(2)).Level(0, 0, 1),
+		parquet.ValueOf(int64(4)).Level(0, 0, 2),
+	}})
+	require.NoError(t, err)
+	b, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+	serialized, err := ReaderFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), serialized.SchemaVersion())
+
+	migrated, err := SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "tags",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_TIMESTAMP_MICROS},
+		}, {
+			Name:          "value",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_DOUBLE},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "tags",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+		Version: 1,
+	})
+	require.NoError(t, err)
+
+	migrations := Migrations{0: {
+		RenameColumn("labels", "tags"),
+		WidenColumn("timestamp", schemapb.StorageLayout_TYPE_TIMESTAMP_MICROS),
+		WidenColumn("value", schemapb.StorageLayout_TYPE_DOUBLE),
+	}}
+	result, err := migrated.MigrateSerializedBuffer(serialized, migrations)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), result.SchemaVersion())
+	require.Equal(t, map[string][]string{"tags": {"region"}}, result.DynamicColumns())
+	require.Equal(t, []string{"tags.region", "timestamp", "value"}, schemaLeafColumnNames(result.ParquetFile().Schema()))
+
+	rows := make([]parquet.Row, 2)
+	n, err := result.Reader().ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, n)
+	require.Equal(t, "eu", rows[0][0].String())
+	require.Equal(t, int64(1000), rows[0][1].Int64())
+	require.Equal(t, 3.0, rows[0][2].Double())
+	require.True(t, rows[1][0].IsNull())
+	require.Equal(t, int64(2000), rows[1][1].Int64())
+	require.Equal(t, 4.0, rows[1][2].Double())
+
+	// Buffers of the version of the schema aren't migrated again.
+	result, err = migrated.MigrateSerializedBuffer(result, migrations)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), result.SchemaVersion())
+
+	_, err = WidenColumn("labels", schemapb.StorageLayout_TYPE_DOUBLE).Migrate(serialized.DynamicRowGroup(0))
+	require.Error(t, err)
+	_, err = WidenColumn("timestamp", schemapb.StorageLayout_TYPE_INT64).Migrate(serialized.DynamicRowGroup(0))
+	require.Error(t, err)
+	_, err = RenameColumn("value", "timestamp").Migrate(serialized.DynamicRowGroup(0))
+	require.Error(t, err)
+}
+
+func TestWidenDecimalColumn(t *testing.T) {
+	schema, err := SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "price",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:      schemapb.StorageLayout_TYPE_DECIMAL,
+				Precision: 10,
+				Scale:     2,
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	buf, err := schema.NewBuffer(nil)
+	require.NoError(t, err)
+	_, err = buf.WriteRows([]parquet.Row{{parquet.ValueOf(int64(1234)).Level(0, 0, 0)}})
+	require.NoError(t, err)
+
+	rg, err := WidenColumn("price", schemapb.StorageLayout_TYPE_DOUBLE).Migrate(buf)
+	require.NoError(t, err)
+	rows := make([]parquet.Row, 1)
+	n, err := rg.Rows().ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, n)
+	require.Equal(t, 12.34, rows[0][0].Double())
+}
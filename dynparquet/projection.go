@@ -0,0 +1,54 @@
+package dynparquet
+
+import (
+	"github.com/segmentio/parquet-go"
+)
+
+// projectedRowGroup replaces the column chunks of the columns of the row group
+// it wraps that aren't projected with column chunks of null values, so
+// reading its rows doesn't decode the values of columns that aren't used. Its
+// schema is the schema of the wrapped row group.
+type projectedRowGroup struct {
+	DynamicRowGroup
+	projected func(column string) bool
+}
+
+// ProjectRowGroup returns the row group with the values of all of its columns
+// that the projected function doesn't return true for replaced with nulls.
+func ProjectRowGroup(rg DynamicRowGroup, projected func(column string) bool) DynamicRowGroup {
+	return &projectedRowGroup{
+		DynamicRowGroup: rg,
+		projected:       projected,
+	}
+}
+
+// ColumnChunks returns the column chunks of the projected columns, and column
+// chunks filled with nulls for the others. Implements the parquet.RowGroup
+// interface.
+func (g *projectedRowGroup) ColumnChunks() []parquet.ColumnChunk {
+	// The leaf columns of struct columns are projected with their column.
+	columns := g.Schema().Columns()
+	chunks := g.DynamicRowGroup.ColumnChunks()
+	res := make([]parquet.ColumnChunk, 0, len(chunks))
+	for i, c := range chunks {
+		if g.projected(columns[i][0]) {
+			res = append(res, c)
+			continue
+		}
+		leaf, _ := g.Schema().Lookup(columns[i]...)
+		res = append(res, NewNilColumnChunk(leaf.Node.Type(), i, int(g.NumRows())))
+	}
+	return res
+}
+
+// Rows returns an iterator over the rows of the row group. Implements the
+// parquet.RowGroup interface.
+func (g *projectedRowGroup) Rows() parquet.Rows {
+	return parquet.NewRowGroupRowReader(g)
+}
+
+// DynamicRows returns an iterator over the rows of the row group. Implements
+// the DynamicRowGroup interface.
+func (g *projectedRowGroup) DynamicRows() DynamicRowReader {
+	return newDynamicRowGroupReader(g, g.Schema().Fields())
+}
@@ -0,0 +1,73 @@
+package dynparquet
+
+import (
+	"io"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRowGroup(t *testing.T) {
+	schema := NewSampleSchema()
+	samples := NewTestSamples()
+	buf, err := samples.ToBuffer(schema)
+	require.NoError(t, err)
+
+	b, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+	serBuf, err := ReaderFromBytes(b)
+	require.NoError(t, err)
+
+	rg := ProjectRowGroup(serBuf.DynamicRowGroup(0), func(column string) bool {
+		return column == "labels.namespace" || column == "value"
+	})
+	require.Equal(t, serBuf.DynamicRowGroup(0).Schema(), rg.Schema())
+
+	rows := rg.Rows()
+	defer rows.Close()
+	rowBuf := make([]parquet.Row, len(samples))
+	n, err := rows.ReadRows(rowBuf)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, len(samples), n)
+
+	fields := rg.Schema().Fields()
+	namespaces := []string{}
+	values := []int64{}
+	for _, row := range rowBuf[:n] {
+		for i, field := range fields {
+			vals := ValuesForIndex(row, i)
+			switch field.Name() {
+			case "labels.namespace":
+				namespace := ""
+				if !vals[0].IsNull() {
+					namespace = vals[0].String()
+				}
+				namespaces = append(namespaces, namespace)
+			case "value":
+				values = append(values, vals[0].Int64())
+			default:
+				// Columns that aren't projected are null.
+				require.Len(t, vals, 1)
+				require.True(t, vals[0].IsNull(), field.Name())
+			}
+		}
+	}
+
+	expectedNamespaces := []string{}
+	expectedValues := []int64{}
+	for _, s := range samples {
+		namespace := ""
+		for _, l := range s.Labels {
+			if l.Name == "namespace" {
+				namespace = l.Value
+			}
+		}
+		expectedNamespaces = append(expectedNamespaces, namespace)
+		expectedValues = append(expectedValues, s.Value)
+	}
+	require.ElementsMatch(t, expectedNamespaces, namespaces)
+	require.ElementsMatch(t, expectedValues, values)
+}
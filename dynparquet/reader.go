@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
 )
 
 const (
 	DynamicColumnsKey = "dynamic_columns"
+	SchemaVersionKey  = "schema_version"
 )
 
 var ErrNoDynamicColumns = errors.New("no dynamic columns metadata found, it must be present")
@@ -17,7 +20,9 @@ var ErrNoDynamicColumns = errors.New("no dynamic columns metadata found, it must
 type SerializedBuffer struct {
 	f       *parquet.File
 	dynCols map[string][]string
+	schema  *parquet.Schema
 	fields  []parquet.Field
+	version uint32
 }
 
 func ReaderFromBytes(buf []byte) (*SerializedBuffer, error) {
@@ -40,13 +45,105 @@ func NewSerializedBuffer(f *parquet.File) (*SerializedBuffer, error) {
 		return nil, fmt.Errorf("deserialize dynamic columns metadata %q: %w", dynColString, err)
 	}
 
+	// Files written before schemas had versions are of the first version.
+	var version uint64
+	if versionString, found := f.Lookup(SchemaVersionKey); found {
+		version, err = strconv.ParseUint(versionString, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse schema version metadata %q: %w", versionString, err)
+		}
+	}
+
+	schema := fileSchema(f)
 	return &SerializedBuffer{
 		f:       f,
 		dynCols: dynCols,
-		fields:  f.Schema().Fields(),
+		schema:  schema,
+		fields:  schema.Fields(),
+		version: uint32(version),
 	}, nil
 }
 
+// fileSchema returns the schema of the file. parquet-go doesn't restore the
+// decimal types of columns and the map types of groups when opening files,
+// so the fields of such columns are replaced with fields of the types of the
+// file's metadata.
+func fileSchema(f *parquet.File) *parquet.Schema {
+	elements := f.Metadata().Schema
+	// The first schema element is the root of the schema, the elements of
+	// the fields follow in the order of the fields.
+	i := 1
+	restored := false
+	group := parquet.Group{}
+	for _, field := range f.Schema().Fields() {
+		node, next, ok := fileNode(field, elements, i)
+		group[field.Name()] = node
+		i = next
+		restored = restored || ok
+	}
+	if !restored {
+		return f.Schema()
+	}
+	return parquet.NewSchema(f.Schema().Name(), group)
+}
+
+// fileNode returns the node of the field with the types of the schema
+// element at index i and of the elements of its fields that follow it, and
+// the index of the next element. It returns false if no type was restored.
+func fileNode(field parquet.Field, elements []format.SchemaElement, i int) (parquet.Node, int, bool) {
+	e := elements[i]
+	i++
+	if field.Leaf() {
+		if d := e.LogicalType; d != nil && d.Decimal != nil {
+			return decimalField{
+				Field: field,
+				typ:   parquet.Decimal(int(d.Decimal.Scale), int(d.Decimal.Precision), field.Type()).Type(),
+			}, i, true
+		}
+		return field, i, false
+	}
+
+	restored := false
+	group := parquet.Group{}
+	for _, child := range field.Fields() {
+		node, next, ok := fileNode(child, elements, i)
+		group[child.Name()] = node
+		i = next
+		restored = restored || ok
+	}
+
+	var node parquet.Node = group
+	if e.LogicalType != nil && e.LogicalType.Map != nil {
+		// The fields of the key and value of maps are fields of their
+		// repeated key_value group.
+		kv := map[string]parquet.Node{}
+		for _, f := range group["key_value"].Fields() {
+			kv[f.Name()] = f
+		}
+		node = parquet.Map(kv["key"], kv["value"])
+		restored = true
+	}
+	if !restored {
+		return field, i, false
+	}
+
+	switch {
+	case field.Optional():
+		node = parquet.Optional(node)
+	case field.Repeated():
+		node = parquet.Repeated(node)
+	}
+	return node, i, true
+}
+
+// decimalField is a field of a decimal column of a file.
+type decimalField struct {
+	parquet.Field
+	typ parquet.Type
+}
+
+func (f decimalField) Type() parquet.Type { return f.typ }
+
 func (b *SerializedBuffer) Reader() *parquet.Reader {
 	return parquet.NewReader(b.ParquetFile())
 }
@@ -75,6 +172,7 @@ func (b *SerializedBuffer) DynamicRows() DynamicRowReader {
 type serializedRowGroup struct {
 	parquet.RowGroup
 	dynCols map[string][]string
+	schema  *parquet.Schema
 	fields  []parquet.Field
 }
 
@@ -86,10 +184,17 @@ func (b *SerializedBuffer) newDynamicRowGroup(rowGroup parquet.RowGroup) Dynamic
 	return &serializedRowGroup{
 		RowGroup: rowGroup,
 		dynCols:  b.dynCols,
+		schema:   b.schema,
 		fields:   b.fields,
 	}
 }
 
+// Schema returns the schema of the file of the row group, which has the
+// decimal types of its columns.
+func (g *serializedRowGroup) Schema() *parquet.Schema {
+	return g.schema
+}
+
 func (g *serializedRowGroup) DynamicColumns() map[string][]string {
 	return g.dynCols
 }
@@ -101,3 +206,9 @@ func (g *serializedRowGroup) DynamicRows() DynamicRowReader {
 func (b *SerializedBuffer) DynamicColumns() map[string][]string {
 	return b.dynCols
 }
+
+// SchemaVersion returns the version of the schema the buffer was written
+// with.
+func (b *SerializedBuffer) SchemaVersion() uint32 {
+	return b.version
+}
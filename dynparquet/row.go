@@ -47,8 +47,8 @@ func (s *Schema) RowLessThan(a, b *DynamicRow) bool {
 	for _, col := range cols {
 		name := col.Path()[0] // Currently we only support flat schemas.
 
-		aIndex := FindChildIndex(a.fields, name)
-		bIndex := FindChildIndex(b.fields, name)
+		aIndex := leafColumnIndex(a.fields, name)
+		bIndex := leafColumnIndex(b.fields, name)
 
 		if aIndex == -1 && bIndex == -1 {
 			continue
@@ -116,6 +116,55 @@ func FindChildIndex(fields []parquet.Field, name string) int {
 	return -1
 }
 
+// leafColumnIndex returns the index of the leaf column of the field of the
+// name, or -1 if there is no such field.
+func leafColumnIndex(fields []parquet.Field, name string) int {
+	offsets := FieldColumnOffsets(fields)
+	if i := FindChildIndex(fields, name); i != -1 {
+		return offsets[i]
+	}
+	return -1
+}
+
+// FieldColumnOffsets returns the index of the first leaf column of each of
+// the fields, followed by the number of leaf columns. The leaf columns of the
+// field at index i are the columns from offsets[i] to offsets[i+1], struct
+// columns have a leaf column per field.
+func FieldColumnOffsets(fields []parquet.Field) []int {
+	offsets := make([]int, 0, len(fields)+1)
+	n := 0
+	for _, field := range fields {
+		offsets = append(offsets, n)
+		n += numLeafColumns(field)
+	}
+	return append(offsets, n)
+}
+
+func numLeafColumns(n parquet.Node) int {
+	if n.Leaf() {
+		return 1
+	}
+	count := 0
+	for _, field := range n.Fields() {
+		count += numLeafColumns(field)
+	}
+	return count
+}
+
+// ValuesForColumns returns the values of the leaf columns from start to end
+// of the row.
+func ValuesForColumns(row parquet.Row, start, end int) []parquet.Value {
+	i := 0
+	for i < len(row) && row[i].Column() < start {
+		i++
+	}
+	j := i
+	for j < len(row) && row[j].Column() < end {
+		j++
+	}
+	return row[i:j]
+}
+
 func ValuesForIndex(row parquet.Row, index int) []parquet.Value {
 	start := -1
 	end := -1
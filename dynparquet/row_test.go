@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 )
 
 type TestStructMiddleList struct {
@@ -199,3 +201,46 @@ func TestLessWithDynamicSchemas(t *testing.T) {
 	require.True(t, schema.RowLessThan(row2.Get(0), row1.Get(0)))
 	require.False(t, schema.RowLessThan(row1.Get(0), row2.Get(0)))
 }
+
+func TestLessWithTypedDynamicColumns(t *testing.T) {
+	schema, err := SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "attributes",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_INT64,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:       "attributes",
+			Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+			NullsFirst: true,
+		}},
+	})
+	require.NoError(t, err)
+
+	rowOf := func(name string, value int64) *DynamicRow {
+		buf, err := schema.NewBuffer(map[string][]string{"attributes": {name}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows([]parquet.Row{{parquet.ValueOf(value).Level(0, 1, 0)}})
+		require.NoError(t, err)
+
+		rows := &DynamicRows{Rows: make([]parquet.Row, 1)}
+		n, err := buf.DynamicRows().ReadRows(rows)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		return rows.Get(0)
+	}
+
+	// The values of the concrete columns the rows don't have are null, which
+	// sort first.
+	retries, size := rowOf("retries", 10), rowOf("size", 1)
+	require.True(t, schema.RowLessThan(size, retries))
+	require.False(t, schema.RowLessThan(retries, size))
+
+	// Values of the same concrete column compare as int64.
+	require.True(t, schema.RowLessThan(rowOf("size", -20), size))
+	require.False(t, schema.RowLessThan(size, rowOf("size", -20)))
+}
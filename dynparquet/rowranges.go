@@ -0,0 +1,188 @@
+package dynparquet
+
+import (
+	"io"
+
+	"github.com/segmentio/parquet-go"
+)
+
+// RowRange is the range [Start, End) of the indexes of rows of a row group.
+type RowRange struct {
+	Start int64
+	End   int64
+}
+
+// NumRows returns the number of rows in the range.
+func (r RowRange) NumRows() int64 {
+	return r.End - r.Start
+}
+
+// rowRangesRowGroup only contains the rows of the ranges of the row group it
+// wraps. The pages of its column chunks outside of the ranges are skipped
+// without being read.
+type rowRangesRowGroup struct {
+	DynamicRowGroup
+	ranges  []RowRange
+	numRows int64
+}
+
+// NewRowRangesRowGroup returns a row group only containing the rows of the
+// ranges of the row group. The ranges must be sorted and must not overlap.
+func NewRowRangesRowGroup(rg DynamicRowGroup, ranges []RowRange) DynamicRowGroup {
+	numRows := int64(0)
+	for _, r := range ranges {
+		numRows += r.NumRows()
+	}
+	return &rowRangesRowGroup{
+		DynamicRowGroup: rg,
+		ranges:          ranges,
+		numRows:         numRows,
+	}
+}
+
+// NumRows returns the number of rows in the ranges. Implements the
+// parquet.RowGroup interface.
+func (g *rowRangesRowGroup) NumRows() int64 {
+	return g.numRows
+}
+
+// ColumnChunks returns the column chunks of the row group, whose pages only
+// contain the rows of the ranges. Implements the parquet.RowGroup interface.
+func (g *rowRangesRowGroup) ColumnChunks() []parquet.ColumnChunk {
+	chunks := g.DynamicRowGroup.ColumnChunks()
+	res := make([]parquet.ColumnChunk, 0, len(chunks))
+	for _, c := range chunks {
+		res = append(res, &rowRangesColumnChunk{
+			ColumnChunk: c,
+			ranges:      g.ranges,
+		})
+	}
+	return res
+}
+
+// Rows returns an iterator over the rows in the ranges. Implements the
+// parquet.RowGroup interface.
+func (g *rowRangesRowGroup) Rows() parquet.Rows {
+	return parquet.NewRowGroupRowReader(g)
+}
+
+// DynamicRows returns an iterator over the rows in the ranges. Implements the
+// DynamicRowGroup interface.
+func (g *rowRangesRowGroup) DynamicRows() DynamicRowReader {
+	return newDynamicRowGroupReader(g, g.Schema().Fields())
+}
+
+// rowRangesColumnChunk is a column chunk of a rowRangesRowGroup. It
+// implements the parquet.ColumnChunk interface.
+type rowRangesColumnChunk struct {
+	parquet.ColumnChunk
+	ranges []RowRange
+}
+
+// Pages returns an iterator over the pages of the column chunk, which are
+// sliced to the ranges. Implements the parquet.ColumnChunk interface.
+func (c *rowRangesColumnChunk) Pages() parquet.Pages {
+	p := &rowRangesPages{
+		pages:  c.ColumnChunk.Pages(),
+		ranges: c.ranges,
+		seek:   true,
+	}
+	if len(c.ranges) > 0 {
+		p.row = c.ranges[0].Start
+	}
+	return p
+}
+
+// ColumnIndex returns nil, since the column index of the wrapped column chunk
+// describes pages that are skipped. Implements the parquet.ColumnChunk
+// interface.
+func (c *rowRangesColumnChunk) ColumnIndex() parquet.ColumnIndex {
+	return nil
+}
+
+// OffsetIndex returns nil, since the offset index of the wrapped column chunk
+// describes pages that are skipped. Implements the parquet.ColumnChunk
+// interface.
+func (c *rowRangesColumnChunk) OffsetIndex() parquet.OffsetIndex {
+	return nil
+}
+
+// rowRangesPages reads the pages of a column chunk that contain rows of the
+// ranges, and slices them to the ranges. It implements the parquet.Pages
+// interface.
+type rowRangesPages struct {
+	pages  parquet.Pages
+	ranges []RowRange
+	// i is the index of the current range, and row the index of the next row
+	// of the wrapped column chunk to read.
+	i   int
+	row int64
+	// seek is whether the wrapped pages need to be seeked to the row before
+	// the next page is read.
+	seek bool
+}
+
+// ReadPage reads the next page of the ranges. Implements the parquet.Pages
+// interface.
+func (p *rowRangesPages) ReadPage() (parquet.Page, error) {
+	for p.i < len(p.ranges) {
+		r := p.ranges[p.i]
+		if p.row >= r.End {
+			p.i++
+			if p.i < len(p.ranges) {
+				p.row = p.ranges[p.i].Start
+				p.seek = true
+			}
+			continue
+		}
+
+		if p.seek {
+			if err := p.pages.SeekToRow(p.row); err != nil {
+				return nil, err
+			}
+			p.seek = false
+		}
+
+		page, err := p.pages.ReadPage()
+		if err != nil {
+			return nil, err
+		}
+
+		numRows := page.NumRows()
+		if remaining := r.End - p.row; numRows > remaining {
+			page = page.Buffer().Slice(0, remaining)
+			numRows = remaining
+		}
+		p.row += numRows
+		return page, nil
+	}
+
+	return nil, io.EOF
+}
+
+// SeekToRow seeks to the row at the index of the rows of the ranges.
+// Implements the parquet.Pages interface.
+func (p *rowRangesPages) SeekToRow(rowIndex int64) error {
+	if rowIndex < 0 {
+		return parquet.ErrSeekOutOfRange
+	}
+
+	for i, r := range p.ranges {
+		if rowIndex < r.NumRows() {
+			p.i = i
+			p.row = r.Start + rowIndex
+			p.seek = true
+			return nil
+		}
+		rowIndex -= r.NumRows()
+	}
+
+	// Seeking past the last row is like reading all rows.
+	p.i = len(p.ranges)
+	return nil
+}
+
+// Close closes the wrapped pages. Implements the parquet.Pages interface.
+func (p *rowRangesPages) Close() error {
+	return p.pages.Close()
+}
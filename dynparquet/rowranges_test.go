@@ -0,0 +1,116 @@
+package dynparquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowRangesRowGroup(t *testing.T) {
+	schema := NewSampleSchema()
+	samples := make(Samples, 0, 100)
+	for i := 0; i < 100; i++ {
+		samples = append(samples, Sample{
+			ExampleType: "test",
+			Labels:      []Label{{Name: "label1", Value: "value1"}},
+			Timestamp:   int64(i),
+			Value:       int64(i),
+		})
+	}
+	buf, err := samples.ToBuffer(schema)
+	require.NoError(t, err)
+
+	// Small pages, so the row group has many pages.
+	b := bytes.NewBuffer(nil)
+	w, err := schema.NewWriter(b, buf.DynamicColumns(), parquet.PageBufferSize(64))
+	require.NoError(t, err)
+	_, err = parquet.CopyRows(w, buf.Rows())
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	serBuf, err := ReaderFromBytes(b.Bytes())
+	require.NoError(t, err)
+	rg := serBuf.DynamicRowGroup(0)
+	require.Greater(t, rg.ColumnChunks()[0].OffsetIndex().NumPages(), 1)
+
+	ranges := []RowRange{{Start: 3, End: 5}, {Start: 40, End: 70}, {Start: 99, End: 100}}
+	expected := []int64{}
+	for _, r := range ranges {
+		for i := r.Start; i < r.End; i++ {
+			expected = append(expected, i)
+		}
+	}
+
+	pruned := NewRowRangesRowGroup(rg, ranges)
+	require.Equal(t, int64(len(expected)), pruned.NumRows())
+
+	timestampIndex := findColumnIndex(t, pruned.Schema(), "timestamp")
+
+	t.Run("pages", func(t *testing.T) {
+		pages := pruned.ColumnChunks()[timestampIndex].Pages()
+		defer pages.Close()
+
+		timestamps := []int64{}
+		for {
+			p, err := pages.ReadPage()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			values := make([]parquet.Value, p.NumValues())
+			_, err = p.Values().ReadValues(values)
+			if err != io.EOF {
+				require.NoError(t, err)
+			}
+			for _, v := range values {
+				timestamps = append(timestamps, v.Int64())
+			}
+		}
+		require.Equal(t, expected, timestamps)
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		rows := pruned.Rows()
+		defer rows.Close()
+
+		timestamps := []int64{}
+		rowBuf := make([]parquet.Row, 16)
+		for {
+			n, err := rows.ReadRows(rowBuf)
+			for _, row := range rowBuf[:n] {
+				timestamps = append(timestamps, row[timestampIndex].Int64())
+			}
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		require.Equal(t, expected, timestamps)
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		rows := pruned.Rows()
+		defer rows.Close()
+
+		// The third row is the first of the second range.
+		require.NoError(t, rows.SeekToRow(2))
+		rowBuf := make([]parquet.Row, 1)
+		_, err := rows.ReadRows(rowBuf)
+		require.NoError(t, err)
+		require.Equal(t, int64(40), rowBuf[0][timestampIndex].Int64())
+	})
+}
+
+func findColumnIndex(t *testing.T, s *parquet.Schema, name string) int {
+	for i, field := range s.Fields() {
+		if field.Name() == name {
+			return i
+		}
+	}
+	t.Fatalf("column %s not found", name)
+	return -1
+}
@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
+	kzstd "github.com/klauspost/compress/zstd"
 	"github.com/segmentio/parquet-go"
 	"github.com/segmentio/parquet-go/compress"
+	"github.com/segmentio/parquet-go/compress/brotli"
+	"github.com/segmentio/parquet-go/compress/gzip"
+	"github.com/segmentio/parquet-go/compress/zstd"
 	"github.com/segmentio/parquet-go/encoding"
+	"github.com/segmentio/parquet-go/format"
 
 	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 )
@@ -95,17 +102,41 @@ type Schema struct {
 	columnIndexes  map[string]int
 	sortingColumns []SortingColumn
 	dynamicColumns []int
+	// compression is the compression of the columns that don't have a
+	// compression of their own, nil if they are uncompressed.
+	compression compress.Codec
 
 	writers *sync.Map
 }
 
 func SchemaFromDefinition(def *schemapb.Schema) (*Schema, error) {
 	columns := make([]ColumnDefinition, 0, len(def.Columns))
+	// The kinds of the struct and map columns, which can't be dynamic or
+	// sorting columns.
+	nested := map[string]string{}
+	names := make(map[string]bool, len(def.Columns))
 	for _, col := range def.Columns {
+		names[col.Name] = true
 		layout, err := storageLayoutToParquetNode(col.StorageLayout)
 		if err != nil {
 			return nil, err
 		}
+		if !layout.Leaf() {
+			kind := "struct"
+			if IsMap(layout) {
+				kind = "map"
+			}
+			if col.Dynamic {
+				return nil, fmt.Errorf("%s column %s can't be dynamic", kind, col.Name)
+			}
+			nested[col.Name] = kind
+		}
+		// Rows don't have values for the concrete columns of dynamic
+		// columns they don't have, so the values of dynamic columns of any
+		// type must be nullable.
+		if col.Dynamic && !layout.Optional() {
+			return nil, fmt.Errorf("dynamic column %s must be nullable", col.Name)
+		}
 		columns = append(columns, ColumnDefinition{
 			Name:          col.Name,
 			StorageLayout: layout,
@@ -114,7 +145,18 @@ func SchemaFromDefinition(def *schemapb.Schema) (*Schema, error) {
 	}
 
 	sortingColumns := make([]SortingColumn, 0, len(def.SortingColumns))
+	sorted := make(map[string]bool, len(def.SortingColumns))
 	for _, col := range def.SortingColumns {
+		if !names[col.Name] {
+			return nil, fmt.Errorf("sorting column %s isn't a column of the schema", col.Name)
+		}
+		if sorted[col.Name] {
+			return nil, fmt.Errorf("column %s is sorted by more than once", col.Name)
+		}
+		sorted[col.Name] = true
+		if kind, ok := nested[col.Name]; ok {
+			return nil, fmt.Errorf("%s column %s can't be a sorting column", kind, col.Name)
+		}
 		var sortingColumn SortingColumn
 		switch col.Direction {
 		case schemapb.SortingColumn_DIRECTION_ASCENDING:
@@ -130,11 +172,21 @@ func SchemaFromDefinition(def *schemapb.Schema) (*Schema, error) {
 		sortingColumns = append(sortingColumns, sortingColumn)
 	}
 
-	return newSchema(
+	s := newSchema(
 		def,
 		columns,
 		sortingColumns,
-	), nil
+	)
+	if def.DefaultCompression != schemapb.StorageLayout_COMPRESSION_NONE_UNSPECIFIED {
+		comp, err := compressionFromDefinition(def.DefaultCompression, def.DefaultCompressionLevel)
+		if err != nil {
+			return nil, fmt.Errorf("default compression: %w", err)
+		}
+		s.compression = comp
+	} else if def.DefaultCompressionLevel != 0 {
+		return nil, fmt.Errorf("default compression level without a default compression")
+	}
+	return s, nil
 }
 
 func storageLayoutToParquetNode(l *schemapb.StorageLayout) (parquet.Node, error) {
@@ -146,12 +198,57 @@ func storageLayoutToParquetNode(l *schemapb.StorageLayout) (parquet.Node, error)
 		node = parquet.Int(64)
 	case schemapb.StorageLayout_TYPE_DOUBLE:
 		node = parquet.Leaf(parquet.DoubleType)
+	case schemapb.StorageLayout_TYPE_BOOL:
+		node = parquet.Leaf(parquet.BooleanType)
+	case schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS:
+		node = parquet.Timestamp(parquet.Millisecond)
+	case schemapb.StorageLayout_TYPE_TIMESTAMP_MICROS:
+		node = parquet.Timestamp(parquet.Microsecond)
+	case schemapb.StorageLayout_TYPE_TIMESTAMP_NANOS:
+		node = parquet.Timestamp(parquet.Nanosecond)
+	case schemapb.StorageLayout_TYPE_DECIMAL:
+		// Decimals are stored as unscaled int64 values, which hold up to 18
+		// digits.
+		if l.Precision < 1 || l.Precision > 18 {
+			return nil, fmt.Errorf("invalid decimal precision %d, expected 1 to 18", l.Precision)
+		}
+		if l.Scale < 0 || l.Scale > l.Precision {
+			return nil, fmt.Errorf("invalid decimal scale %d, expected 0 to %d", l.Scale, l.Precision)
+		}
+		node = parquet.Decimal(int(l.Scale), int(l.Precision), parquet.Int64Type)
+	case schemapb.StorageLayout_TYPE_BYTES:
+		node = parquet.Leaf(parquet.ByteArrayType)
+	case schemapb.StorageLayout_TYPE_STRUCT:
+		group, err := storageLayoutToParquetGroup(l)
+		if err != nil {
+			return nil, err
+		}
+		// The encodings and compressions of structs are the ones of their
+		// fields.
+		if l.Nullable {
+			return parquet.Optional(group), nil
+		}
+		return group, nil
+	case schemapb.StorageLayout_TYPE_MAP:
+		m, err := storageLayoutToParquetMap(l)
+		if err != nil {
+			return nil, err
+		}
+		if l.Nullable {
+			return parquet.Optional(m), nil
+		}
+		return m, nil
 	default:
 		return nil, fmt.Errorf("unknown storage layout type: %s", l.Type)
 	}
 
-	if l.Nullable {
+	switch {
+	case l.Nullable && l.Repeated:
+		return nil, fmt.Errorf("repeated columns can't be nullable")
+	case l.Nullable:
 		node = parquet.Optional(node)
+	case l.Repeated:
+		node = parquet.Repeated(node)
 	}
 
 	if l.Encoding != schemapb.StorageLayout_ENCODING_PLAIN_UNSPECIFIED {
@@ -159,20 +256,126 @@ func storageLayoutToParquetNode(l *schemapb.StorageLayout) (parquet.Node, error)
 		if err != nil {
 			return nil, err
 		}
+		if !canEncode(enc, node.Type().Kind()) {
+			return nil, fmt.Errorf("encoding %s can't encode columns of type %s", l.Encoding, l.Type)
+		}
 		node = parquet.Encoded(node, enc)
 	}
 
 	if l.Compression != schemapb.StorageLayout_COMPRESSION_NONE_UNSPECIFIED {
-		comp, err := compressionFromDefinition(l.Compression)
+		comp, err := compressionFromDefinition(l.Compression, l.CompressionLevel)
 		if err != nil {
 			return nil, err
 		}
 		node = parquet.Compressed(node, comp)
+	} else if l.CompressionLevel != 0 {
+		return nil, fmt.Errorf("compression level without a compression")
 	}
 
 	return node, nil
 }
 
+// storageLayoutToParquetGroup returns the group of the fields of a struct
+// storage layout.
+func storageLayoutToParquetGroup(l *schemapb.StorageLayout) (parquet.Group, error) {
+	switch {
+	case len(l.Fields) == 0:
+		return nil, fmt.Errorf("struct columns must have fields")
+	case l.Repeated:
+		return nil, fmt.Errorf("struct columns can't be repeated")
+	case l.Encoding != schemapb.StorageLayout_ENCODING_PLAIN_UNSPECIFIED:
+		return nil, fmt.Errorf("struct columns can't have an encoding, their fields can")
+	case l.Compression != schemapb.StorageLayout_COMPRESSION_NONE_UNSPECIFIED || l.CompressionLevel != 0:
+		return nil, fmt.Errorf("struct columns can't have a compression, their fields can")
+	}
+
+	group := parquet.Group{}
+	for _, field := range l.Fields {
+		switch {
+		case field.Dynamic:
+			return nil, fmt.Errorf("field %s of struct columns can't be dynamic", field.Name)
+		case field.StorageLayout.Type == schemapb.StorageLayout_TYPE_STRUCT:
+			return nil, fmt.Errorf("field %s of struct columns can't be a struct", field.Name)
+		case field.StorageLayout.Type == schemapb.StorageLayout_TYPE_MAP:
+			return nil, fmt.Errorf("field %s of struct columns can't be a map", field.Name)
+		case field.StorageLayout.Repeated:
+			return nil, fmt.Errorf("field %s of struct columns can't be repeated", field.Name)
+		}
+		if _, ok := group[field.Name]; ok {
+			return nil, fmt.Errorf("duplicate field %s of struct column", field.Name)
+		}
+
+		node, err := storageLayoutToParquetNode(field.StorageLayout)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		group[field.Name] = node
+	}
+	return group, nil
+}
+
+// storageLayoutToParquetMap returns the map of string keys to values of the
+// value type of a map storage layout.
+func storageLayoutToParquetMap(l *schemapb.StorageLayout) (parquet.Node, error) {
+	switch {
+	case l.ValueType == schemapb.StorageLayout_TYPE_STRUCT || l.ValueType == schemapb.StorageLayout_TYPE_MAP:
+		return nil, fmt.Errorf("values of map columns can't be of type %s", l.ValueType)
+	case l.Repeated:
+		return nil, fmt.Errorf("map columns can't be repeated")
+	case l.Encoding != schemapb.StorageLayout_ENCODING_PLAIN_UNSPECIFIED:
+		return nil, fmt.Errorf("map columns can't have an encoding")
+	case l.Compression != schemapb.StorageLayout_COMPRESSION_NONE_UNSPECIFIED || l.CompressionLevel != 0:
+		return nil, fmt.Errorf("map columns can't have a compression")
+	}
+
+	value, err := storageLayoutToParquetNode(&schemapb.StorageLayout{
+		Type:      l.ValueType,
+		Precision: l.Precision,
+		Scale:     l.Scale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("values of map columns: %w", err)
+	}
+	return parquet.Map(parquet.String(), value), nil
+}
+
+// IsMap returns whether the node is the node of a map column.
+func IsMap(n parquet.Node) bool {
+	lt := n.Type().LogicalType()
+	return lt != nil && lt.Map != nil
+}
+
+// LookupColumn returns the leaf column of the name in the schema. The leaf
+// columns of the fields of struct columns are looked up by their paths joined
+// by dots, such as "payload.status".
+func LookupColumn(schema *parquet.Schema, name string) (parquet.LeafColumn, bool) {
+	if leaf, ok := schema.Lookup(name); ok {
+		return leaf, true
+	}
+	if !strings.Contains(name, ".") {
+		return parquet.LeafColumn{}, false
+	}
+	return schema.Lookup(strings.Split(name, ".")...)
+}
+
+// StructField returns the node of the field of the name of the storage
+// layout of a struct column. Fields of nullable structs are nullable.
+func StructField(n parquet.Node, name string) (parquet.Node, bool) {
+	if n.Leaf() || IsMap(n) {
+		return nil, false
+	}
+	for _, field := range n.Fields() {
+		if field.Name() != name {
+			continue
+		}
+		if n.Optional() {
+			return parquet.Optional(field), true
+		}
+		return field, true
+	}
+	return nil, false
+}
+
 func encodingFromDefinition(enc schemapb.StorageLayout_Encoding) (encoding.Encoding, error) {
 	switch enc {
 	case schemapb.StorageLayout_ENCODING_RLE_DICTIONARY:
@@ -183,23 +386,73 @@ func encodingFromDefinition(enc schemapb.StorageLayout_Encoding) (encoding.Encod
 		return &parquet.DeltaByteArray, nil
 	case schemapb.StorageLayout_ENCODING_DELTA_LENGTH_BYTE_ARRAY:
 		return &parquet.DeltaLengthByteArray, nil
+	case schemapb.StorageLayout_ENCODING_RLE:
+		return &parquet.RLE, nil
 	default:
 		return nil, fmt.Errorf("unknown encoding: %s", enc)
 	}
 }
 
-func compressionFromDefinition(comp schemapb.StorageLayout_Compression) (compress.Codec, error) {
+// canEncode returns whether the encoding can encode values of the kind,
+// parquet-go panics when encoding nodes with encodings that can't.
+func canEncode(enc encoding.Encoding, kind parquet.Kind) bool {
+	if enc.Encoding() == format.RLEDictionary {
+		return true
+	}
+	switch kind {
+	case parquet.Boolean:
+		return encoding.CanEncodeBoolean(enc)
+	case parquet.Int64:
+		return encoding.CanEncodeInt64(enc)
+	case parquet.Double:
+		return encoding.CanEncodeDouble(enc)
+	case parquet.ByteArray:
+		return encoding.CanEncodeByteArray(enc)
+	default:
+		return false
+	}
+}
+
+// compressionFromDefinition returns the codec of the compression of the
+// level, the default codecs are shared by the columns of the compression.
+func compressionFromDefinition(comp schemapb.StorageLayout_Compression, level int32) (compress.Codec, error) {
 	switch comp {
 	case schemapb.StorageLayout_COMPRESSION_SNAPPY:
+		if level != 0 {
+			return nil, fmt.Errorf("compression %s doesn't have levels", comp)
+		}
 		return &parquet.Snappy, nil
 	case schemapb.StorageLayout_COMPRESSION_GZIP:
-		return &parquet.Gzip, nil
+		if level == 0 {
+			return &parquet.Gzip, nil
+		}
+		if level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, fmt.Errorf("invalid %s compression level %d, expected 1 to 9", comp, level)
+		}
+		return &gzip.Codec{Level: int(level)}, nil
 	case schemapb.StorageLayout_COMPRESSION_BROTLI:
-		return &parquet.Brotli, nil
+		if level == 0 {
+			return &parquet.Brotli, nil
+		}
+		if level < 1 || level > 11 {
+			return nil, fmt.Errorf("invalid %s compression level %d, expected 1 to 11", comp, level)
+		}
+		return &brotli.Codec{Quality: int(level)}, nil
 	case schemapb.StorageLayout_COMPRESSION_LZ4_RAW:
+		if level != 0 {
+			return nil, fmt.Errorf("compression %s doesn't have levels", comp)
+		}
 		return &parquet.Lz4Raw, nil
 	case schemapb.StorageLayout_COMPRESSION_ZSTD:
-		return &parquet.Zstd, nil
+		if level == 0 {
+			return &parquet.Zstd, nil
+		}
+		if level < 1 || level > 22 {
+			return nil, fmt.Errorf("invalid %s compression level %d, expected 1 to 22", comp, level)
+		}
+		// The encoder has fewer levels than zstd, zstd levels are mapped to
+		// the closest of them.
+		return &zstd.Codec{Level: kzstd.EncoderLevelFromZstd(int(level))}, nil
 	default:
 		return nil, fmt.Errorf("unknown compression: %s", comp)
 	}
@@ -244,6 +497,11 @@ func (s *Schema) Definition() *schemapb.Schema {
 	return s.def
 }
 
+// Version returns the version of the schema.
+func (s *Schema) Version() uint32 {
+	return s.def.Version
+}
+
 func (s *Schema) ColumnByName(name string) (ColumnDefinition, bool) {
 	i, ok := s.columnIndexes[name]
 	if !ok {
@@ -252,10 +510,42 @@ func (s *Schema) ColumnByName(name string) (ColumnDefinition, bool) {
 	return s.columns[i], true
 }
 
+// FindColumn returns the definition of the column with the given name. Unlike
+// ColumnByName it also resolves concrete dynamic column names, such as
+// "labels.label1", to the definition of their dynamic column, and fields of
+// struct columns, such as "payload.status", to definitions of the fields.
+func (s *Schema) FindColumn(name string) (ColumnDefinition, bool) {
+	if col, ok := s.ColumnByName(name); ok {
+		return col, true
+	}
+
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		if col, ok := s.ColumnByName(name[:i]); ok {
+			if node, ok := StructField(col.StorageLayout, name[i+1:]); ok {
+				return ColumnDefinition{Name: name, StorageLayout: node}, true
+			}
+		}
+	}
+
+	for _, i := range s.dynamicColumns {
+		col := s.columns[i]
+		if strings.HasPrefix(name, col.Name+".") {
+			return col, true
+		}
+	}
+
+	return ColumnDefinition{}, false
+}
+
 func (s *Schema) Columns() []ColumnDefinition {
 	return s.columns
 }
 
+// SortingColumns returns the columns the schema sorts by, in order.
+func (s *Schema) SortingColumns() []SortingColumn {
+	return s.sortingColumns
+}
+
 // parquetSchema returns the parquet schema for the dynamic schema with the
 // concrete dynamic column names given in the argument.
 func (s Schema) parquetSchema(
@@ -264,8 +554,12 @@ func (s Schema) parquetSchema(
 	*parquet.Schema,
 	error,
 ) {
-	if len(dynamicColumns) != len(s.dynamicColumns) {
-		return nil, fmt.Errorf("expected %d dynamic column names, got %d", len(s.dynamicColumns), len(dynamicColumns))
+	// Dynamic columns that were added to the schema after the rows were
+	// written don't have concrete column names.
+	for name := range dynamicColumns {
+		if i, ok := s.columnIndexes[name]; !ok || !s.columns[i].Dynamic {
+			return nil, fmt.Errorf("unknown dynamic column %s", name)
+		}
 	}
 
 	g := parquet.Group{}
@@ -364,6 +658,11 @@ func (r *dynamicRowGroupReader) ReadRows(rows *DynamicRows) (int, error) {
 	}
 
 	n, err := r.rows.ReadRows(rows.Rows)
+	if err == io.EOF && n > 0 {
+		// Rows may be read with io.EOF, such as the last row of repeated
+		// columns. They are returned without it, the next read returns it.
+		err = nil
+	}
 	if err == io.EOF {
 		rows.Rows = rows.Rows[:n]
 		return n, io.EOF
@@ -542,9 +841,89 @@ func (s *Schema) SerializeBuffer(buffer *Buffer) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// ConvertSerializedBuffer returns the buffer with the parquet schema of the
+// schema for the buffer's dynamic columns, such as buffers written with an
+// earlier schema that didn't have columns that were added since. The
+// columns the buffer doesn't have are null. Buffers that already have the
+// parquet schema are returned as is.
+func (s *Schema) ConvertSerializedBuffer(buf *SerializedBuffer) (*SerializedBuffer, error) {
+	cols := buf.DynamicColumns()
+	ps, err := s.parquetSchema(cols)
+	if err != nil {
+		return nil, fmt.Errorf("create parquet schema for buffer: %w", err)
+	}
+	names := schemaLeafColumnNames(ps)
+	if equalStrings(names, schemaLeafColumnNames(buf.schema)) {
+		return buf, nil
+	}
+
+	rowGroups := make([]DynamicRowGroup, buf.NumRowGroups())
+	for i := range rowGroups {
+		rowGroups[i] = buf.DynamicRowGroup(i)
+	}
+	return s.convertDynamicRowGroups(cols, rowGroups)
+}
+
+// convertDynamicRowGroups returns a buffer of the rows of the row groups with
+// the parquet schema of the schema for the dynamic columns, which include the
+// dynamic columns of the row groups.
+func (s *Schema) convertDynamicRowGroups(cols map[string][]string, rowGroups []DynamicRowGroup) (*SerializedBuffer, error) {
+	ps, err := s.parquetSchema(cols)
+	if err != nil {
+		return nil, fmt.Errorf("create parquet schema for buffer: %w", err)
+	}
+
+	b := bytes.NewBuffer(nil)
+	w, err := s.GetWriter(b, cols)
+	if err != nil {
+		return nil, fmt.Errorf("create writer: %w", err)
+	}
+	defer s.PutWriter(w)
+
+	sortingColumns := s.parquetSortingColumns(cols)
+	rowBuf := make([]parquet.Row, 64)
+	for _, rowGroup := range rowGroups {
+		rg := newDynamicRowGroupMergeAdapter(ps, sortingColumns, cols, rowGroup)
+		rows := rg.Rows()
+		for {
+			n, err := rows.ReadRows(rowBuf)
+			if err != nil && err != io.EOF {
+				return nil, fmt.Errorf("read row: %w", err)
+			}
+			if _, werr := w.WriteRows(rowBuf[:n]); werr != nil {
+				return nil, fmt.Errorf("write row: %w", werr)
+			}
+			if err == io.EOF {
+				break
+			}
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("close writer: %w", err)
+	}
+
+	return ReaderFromBytes(b.Bytes())
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // NewWriter returns a new parquet writer with a concrete parquet schema
-// generated using the given concrete dynamic column names.
-func (s *Schema) NewWriter(w io.Writer, dynamicColumns map[string][]string) (*parquet.Writer, error) {
+// generated using the given concrete dynamic column names. The options, such
+// as the size of the pages, are applied after the schema's default options.
+func (s *Schema) NewWriter(w io.Writer, dynamicColumns map[string][]string, options ...parquet.WriterOption) (*parquet.Writer, error) {
 	ps, err := s.parquetSchema(dynamicColumns)
 	if err != nil {
 		return nil, err
@@ -556,7 +935,10 @@ func (s *Schema) NewWriter(w io.Writer, dynamicColumns map[string][]string) (*pa
 		bloomFilterColumns = append(bloomFilterColumns, parquet.SplitBlockFilter(col.Path()...))
 	}
 
-	return parquet.NewWriter(w,
+	if s.compression != nil {
+		options = append([]parquet.WriterOption{parquet.Compression(s.compression)}, options...)
+	}
+	return parquet.NewWriter(w, append([]parquet.WriterOption{
 		ps,
 		parquet.ColumnIndexSizeLimit(ColumnIndexSize),
 		parquet.BloomFilters(bloomFilterColumns...),
@@ -564,7 +946,11 @@ func (s *Schema) NewWriter(w io.Writer, dynamicColumns map[string][]string) (*pa
 			DynamicColumnsKey,
 			serializeDynamicColumns(dynamicColumns),
 		),
-	), nil
+		parquet.KeyValueMetadata(
+			SchemaVersionKey,
+			strconv.FormatUint(uint64(s.def.Version), 10),
+		),
+	}, options...)...), nil
 }
 
 type PooledWriter struct {
@@ -730,17 +1116,20 @@ func newDynamicRowGroupMergeAdapter(
 		mergedDynamicColumns: mergedDynamicColumns,
 		originalRowGroup:     originalRowGroup,
 		indexMapping: mapMergedColumnNameIndexes(
-			schemaRootFieldNames(schema),
-			schemaRootFieldNames(originalRowGroup.Schema()),
+			schemaLeafColumnNames(schema),
+			schemaLeafColumnNames(originalRowGroup.Schema()),
 		),
 	}
 }
 
-func schemaRootFieldNames(schema *parquet.Schema) []string {
-	fields := schema.Fields()
-	names := make([]string, 0, len(fields))
-	for _, field := range fields {
-		names = append(names, field.Name())
+// schemaLeafColumnNames returns the names of the leaf columns of the schema,
+// the names of the leaf columns of struct columns are their paths joined by
+// dots.
+func schemaLeafColumnNames(schema *parquet.Schema) []string {
+	columns := schema.Columns()
+	names := make([]string, 0, len(columns))
+	for _, path := range columns {
+		names = append(names, strings.Join(path, "."))
 	}
 	return names
 }
@@ -791,15 +1180,14 @@ func FieldByName(fields []parquet.Field, name string) parquet.Field {
 // same column in the original batch. If not found returns a column chunk
 // filled with nulls.
 func (a *dynamicRowGroupMergeAdapter) ColumnChunks() []parquet.ColumnChunk {
-	// This only works because we currently only support flat schemas.
-	fields := a.schema.Fields()
+	columns := a.schema.Columns()
 	columnChunks := a.originalRowGroup.ColumnChunks()
-	remappedColumnChunks := make([]parquet.ColumnChunk, len(fields))
-	for i, field := range fields {
+	remappedColumnChunks := make([]parquet.ColumnChunk, len(columns))
+	for i, path := range columns {
 		colIndex := a.indexMapping[i]
 		if colIndex == -1 {
-			schemaField := FieldByName(fields, field.Name())
-			remappedColumnChunks[i] = NewNilColumnChunk(schemaField.Type(), i, int(a.NumRows()))
+			leaf, _ := a.schema.Lookup(path...)
+			remappedColumnChunks[i] = NewNilColumnChunk(leaf.Node.Type(), i, int(a.NumRows()))
 		} else {
 			remappedColumnChunks[i] = &remappedColumnChunk{
 				ColumnChunk:   columnChunks[colIndex],
@@ -826,7 +1214,22 @@ func (a *dynamicRowGroupMergeAdapter) SortingColumns() []parquet.SortingColumn {
 
 // Returns a reader exposing the rows of the row group.
 func (a *dynamicRowGroupMergeAdapter) Rows() parquet.Rows {
-	return parquet.NewRowGroupRowReader(a)
+	return &mergeAdapterRows{Rows: parquet.NewRowGroupRowReader(a)}
+}
+
+// mergeAdapterRows returns rows read with io.EOF, such as the last row of
+// repeated columns, without it. The merge of row groups stops reading the
+// rows of a row group at the first io.EOF and drops the rows read with it.
+type mergeAdapterRows struct {
+	parquet.Rows
+}
+
+func (r *mergeAdapterRows) ReadRows(rows []parquet.Row) (int, error) {
+	n, err := r.Rows.ReadRows(rows)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
 }
 
 // remappedColumnChunk is a ColumnChunk that wraps a ColumnChunk and makes it
@@ -11,6 +11,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 )
 
 func TestMergeRowBatches(t *testing.T) {
@@ -230,3 +232,347 @@ func TestMultipleIterations(t *testing.T) {
 	require.Equal(t, 3, i)
 	require.NoError(t, rows.Close())
 }
+
+func TestFindColumn(t *testing.T) {
+	schema := NewSampleSchema()
+
+	col, found := schema.FindColumn("timestamp")
+	require.True(t, found)
+	require.Equal(t, "timestamp", col.Name)
+
+	col, found = schema.FindColumn("labels.label1")
+	require.True(t, found)
+	require.Equal(t, "labels", col.Name)
+	require.True(t, col.Dynamic)
+
+	_, found = schema.FindColumn("labels")
+	require.True(t, found)
+
+	_, found = schema.FindColumn("timestamp.foo")
+	require.False(t, found)
+
+	_, found = schema.FindColumn("unknown")
+	require.False(t, found)
+}
+
+func TestStructColumn(t *testing.T) {
+	payload := func(l *schemapb.StorageLayout) *schemapb.Schema {
+		return &schemapb.Schema{
+			Name: "test",
+			Columns: []*schemapb.Column{{
+				Name:          "payload",
+				StorageLayout: l,
+			}},
+		}
+	}
+	fields := []*schemapb.Column{{
+		Name:          "status",
+		StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRING},
+	}}
+
+	schema, err := SchemaFromDefinition(payload(&schemapb.StorageLayout{
+		Type:     schemapb.StorageLayout_TYPE_STRUCT,
+		Nullable: true,
+		Fields:   fields,
+	}))
+	require.NoError(t, err)
+
+	// Fields of nullable structs are nullable.
+	col, found := schema.FindColumn("payload.status")
+	require.True(t, found)
+	require.Equal(t, "payload.status", col.Name)
+	require.True(t, col.StorageLayout.Optional())
+
+	_, found = schema.FindColumn("payload.unknown")
+	require.False(t, found)
+
+	_, err = SchemaFromDefinition(payload(&schemapb.StorageLayout{
+		Type:     schemapb.StorageLayout_TYPE_STRUCT,
+		Repeated: true,
+		Fields:   fields,
+	}))
+	require.EqualError(t, err, "struct columns can't be repeated")
+
+	_, err = SchemaFromDefinition(payload(&schemapb.StorageLayout{
+		Type: schemapb.StorageLayout_TYPE_STRUCT,
+		Fields: []*schemapb.Column{{
+			Name:          "nested",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRUCT, Fields: fields},
+		}},
+	}))
+	require.EqualError(t, err, "field nested of struct columns can't be a struct")
+
+	def := payload(&schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRUCT, Fields: fields})
+	def.SortingColumns = []*schemapb.SortingColumn{{
+		Name:      "payload",
+		Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+	}}
+	_, err = SchemaFromDefinition(def)
+	require.EqualError(t, err, "struct column payload can't be a sorting column")
+}
+
+func TestMapColumn(t *testing.T) {
+	attributes := func(l *schemapb.StorageLayout) *schemapb.Schema {
+		return &schemapb.Schema{
+			Name: "test",
+			Columns: []*schemapb.Column{{
+				Name:          "attributes",
+				StorageLayout: l,
+			}},
+		}
+	}
+
+	schema, err := SchemaFromDefinition(attributes(&schemapb.StorageLayout{
+		Type:      schemapb.StorageLayout_TYPE_MAP,
+		ValueType: schemapb.StorageLayout_TYPE_DOUBLE,
+		Nullable:  true,
+	}))
+	require.NoError(t, err)
+
+	col, found := schema.FindColumn("attributes")
+	require.True(t, found)
+	require.True(t, IsMap(col.StorageLayout))
+	require.True(t, col.StorageLayout.Optional())
+
+	_, err = SchemaFromDefinition(attributes(&schemapb.StorageLayout{
+		Type:      schemapb.StorageLayout_TYPE_MAP,
+		ValueType: schemapb.StorageLayout_TYPE_MAP,
+	}))
+	require.EqualError(t, err, "values of map columns can't be of type TYPE_MAP")
+
+	_, err = SchemaFromDefinition(attributes(&schemapb.StorageLayout{
+		Type:      schemapb.StorageLayout_TYPE_MAP,
+		ValueType: schemapb.StorageLayout_TYPE_STRING,
+		Repeated:  true,
+	}))
+	require.EqualError(t, err, "map columns can't be repeated")
+
+	def := attributes(&schemapb.StorageLayout{
+		Type:      schemapb.StorageLayout_TYPE_MAP,
+		ValueType: schemapb.StorageLayout_TYPE_STRING,
+	})
+	def.Columns[0].Dynamic = true
+	_, err = SchemaFromDefinition(def)
+	require.EqualError(t, err, "map column attributes can't be dynamic")
+}
+
+func TestDynamicColumnMustBeNullable(t *testing.T) {
+	_, err := SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "attributes",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_DOUBLE},
+			Dynamic:       true,
+		}},
+	})
+	require.EqualError(t, err, "dynamic column attributes must be nullable")
+}
+
+func TestSortingColumnsMustBeColumns(t *testing.T) {
+	columns := []*schemapb.Column{{
+		Name:          "timestamp",
+		StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+	}}
+	_, err := SchemaFromDefinition(&schemapb.Schema{
+		Name:    "test",
+		Columns: columns,
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "time",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.EqualError(t, err, "sorting column time isn't a column of the schema")
+
+	_, err = SchemaFromDefinition(&schemapb.Schema{
+		Name:    "test",
+		Columns: columns,
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_DESCENDING,
+		}},
+	})
+	require.EqualError(t, err, "column timestamp is sorted by more than once")
+}
+
+func TestCompression(t *testing.T) {
+	schema, err := SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:        schemapb.StorageLayout_TYPE_STRING,
+				Compression: schemapb.StorageLayout_COMPRESSION_SNAPPY,
+			},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:             schemapb.StorageLayout_TYPE_INT64,
+				Compression:      schemapb.StorageLayout_COMPRESSION_ZSTD,
+				CompressionLevel: 19,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+		DefaultCompression: schemapb.StorageLayout_COMPRESSION_LZ4_RAW,
+	})
+	require.NoError(t, err)
+
+	buf, err := schema.NewBuffer(nil)
+	require.NoError(t, err)
+	_, err = buf.WriteRows([]parquet.Row{{
+		parquet.ValueOf("a").Level(0, 0, 0),
+		parquet.ValueOf(int64(1)).Level(0, 0, 1),
+		parquet.ValueOf(int64(2)).Level(0, 0, 2),
+	}})
+	require.NoError(t, err)
+
+	b, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+	serialized, err := ReaderFromBytes(b)
+	require.NoError(t, err)
+
+	// Columns without a compression of their own have the default
+	// compression of the schema.
+	codecs := []string{}
+	for _, col := range serialized.ParquetFile().Metadata().RowGroups[0].Columns {
+		codecs = append(codecs, col.MetaData.Codec.String())
+	}
+	require.Equal(t, []string{"SNAPPY", "LZ4_RAW", "ZSTD"}, codecs)
+
+	rows := make([]parquet.Row, 1)
+	n, err := serialized.Reader().ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, n)
+	require.Equal(t, int64(2), rows[0][2].Int64())
+
+	for _, c := range []struct {
+		layout *schemapb.StorageLayout
+		err    string
+	}{{
+		layout: &schemapb.StorageLayout{
+			Type:             schemapb.StorageLayout_TYPE_INT64,
+			Compression:      schemapb.StorageLayout_COMPRESSION_ZSTD,
+			CompressionLevel: 23,
+		},
+		err: "invalid COMPRESSION_ZSTD compression level 23, expected 1 to 22",
+	}, {
+		layout: &schemapb.StorageLayout{
+			Type:             schemapb.StorageLayout_TYPE_INT64,
+			Compression:      schemapb.StorageLayout_COMPRESSION_SNAPPY,
+			CompressionLevel: 1,
+		},
+		err: "compression COMPRESSION_SNAPPY doesn't have levels",
+	}, {
+		layout: &schemapb.StorageLayout{
+			Type:             schemapb.StorageLayout_TYPE_INT64,
+			CompressionLevel: 1,
+		},
+		err: "compression level without a compression",
+	}} {
+		_, err := SchemaFromDefinition(&schemapb.Schema{
+			Name: "test",
+			Columns: []*schemapb.Column{{
+				Name:          "value",
+				StorageLayout: c.layout,
+			}},
+		})
+		require.EqualError(t, err, c.err)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	layout := func(typ schemapb.StorageLayout_Type, enc schemapb.StorageLayout_Encoding) *schemapb.StorageLayout {
+		return &schemapb.StorageLayout{Type: typ, Encoding: enc}
+	}
+	schema, err := SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "active",
+			StorageLayout: layout(schemapb.StorageLayout_TYPE_BOOL, schemapb.StorageLayout_ENCODING_RLE),
+		}, {
+			Name:          "labels",
+			StorageLayout: layout(schemapb.StorageLayout_TYPE_STRING, schemapb.StorageLayout_ENCODING_DELTA_BYTE_ARRAY),
+		}, {
+			Name:          "timestamp",
+			StorageLayout: layout(schemapb.StorageLayout_TYPE_INT64, schemapb.StorageLayout_ENCODING_DELTA_BINARY_PACKED),
+		}, {
+			Name:          "value",
+			StorageLayout: layout(schemapb.StorageLayout_TYPE_DOUBLE, schemapb.StorageLayout_ENCODING_RLE_DICTIONARY),
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	buf, err := schema.NewBuffer(nil)
+	require.NoError(t, err)
+	_, err = buf.WriteRows([]parquet.Row{{
+		parquet.ValueOf(true).Level(0, 0, 0),
+		parquet.ValueOf("a").Level(0, 0, 1),
+		parquet.ValueOf(int64(1)).Level(0, 0, 2),
+		parquet.ValueOf(0.5).Level(0, 0, 3),
+	}, {
+		parquet.ValueOf(false).Level(0, 0, 0),
+		parquet.ValueOf("b").Level(0, 0, 1),
+		parquet.ValueOf(int64(2)).Level(0, 0, 2),
+		parquet.ValueOf(1.5).Level(0, 0, 3),
+	}})
+	require.NoError(t, err)
+
+	b, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+	serialized, err := ReaderFromBytes(b)
+	require.NoError(t, err)
+
+	encodings := []string{}
+	for _, col := range serialized.ParquetFile().Metadata().RowGroups[0].Columns {
+		encodings = append(encodings, col.MetaData.Encoding[len(col.MetaData.Encoding)-1].String())
+	}
+	require.Equal(t, []string{"RLE", "DELTA_BYTE_ARRAY", "DELTA_BINARY_PACKED", "RLE_DICTIONARY"}, encodings)
+
+	rows := make([]parquet.Row, 2)
+	n, err := serialized.Reader().ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, n)
+	require.False(t, rows[1][0].Boolean())
+	require.Equal(t, "b", rows[1][1].String())
+	require.Equal(t, int64(2), rows[1][2].Int64())
+	require.Equal(t, 1.5, rows[1][3].Double())
+
+	for _, c := range []struct {
+		layout *schemapb.StorageLayout
+		err    string
+	}{{
+		layout: layout(schemapb.StorageLayout_TYPE_STRING, schemapb.StorageLayout_ENCODING_DELTA_BINARY_PACKED),
+		err:    "encoding ENCODING_DELTA_BINARY_PACKED can't encode columns of type TYPE_STRING",
+	}, {
+		layout: layout(schemapb.StorageLayout_TYPE_INT64, schemapb.StorageLayout_ENCODING_DELTA_LENGTH_BYTE_ARRAY),
+		err:    "encoding ENCODING_DELTA_LENGTH_BYTE_ARRAY can't encode columns of type TYPE_INT64",
+	}, {
+		layout: layout(schemapb.StorageLayout_TYPE_DOUBLE, schemapb.StorageLayout_ENCODING_RLE),
+		err:    "encoding ENCODING_RLE can't encode columns of type TYPE_DOUBLE",
+	}} {
+		_, err := SchemaFromDefinition(&schemapb.Schema{
+			Name: "test",
+			Columns: []*schemapb.Column{{
+				Name:          "value",
+				StorageLayout: c.layout,
+			}},
+		})
+		require.EqualError(t, err, c.err)
+	}
+}
@@ -0,0 +1,385 @@
+package dynparquet
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/segmentio/parquet-go"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+)
+
+// StructTag is the key of the struct tags of the fields of structs of struct
+// schemas.
+const StructTag = "frostdb"
+
+// StructSchema is a schema derived from the exported fields of a struct type,
+// which converts values of the type to buffers of the schema.
+//
+// The columns of the fields are named by their tags, which default to the
+// snake cased names of the fields. Tags list a name followed by options:
+//
+//	type Sample struct {
+//		Type      string            `frostdb:"type,rle_dict,asc(0)"`
+//		Labels    map[string]string `frostdb:"labels,rle_dict,asc(1),null_first"`
+//		Timestamp int64             `frostdb:"timestamp,asc(2)"`
+//		Value     float64           `frostdb:"value,zstd"`
+//		Internal  string            `frostdb:"-"`
+//	}
+//
+// Strings, byte slices, bools, integers, floats and time.Time values are
+// stored as columns of their types, pointers as nullable columns and other
+// slices as repeated columns. Maps of strings to values are dynamic columns
+// of the keys of the maps.
+//
+// Options set the encoding (rle_dict, rle, delta_binary_packed,
+// delta_byte_array, delta_length_byte_array) and the compression (snappy,
+// gzip, brotli, lz4_raw, zstd) of columns. The unit of time.Time columns is
+// nanoseconds unless the millis or micros option is set, which also store
+// integers as timestamps. The asc(n) and desc(n) options sort by the column,
+// in the order of n, and the null_first option sorts nulls first.
+type StructSchema[T any] struct {
+	*Schema
+	columns []structColumn
+}
+
+// structColumn is the column of a field of a struct.
+type structColumn struct {
+	name    string
+	index   []int
+	dynamic bool
+	layout  *schemapb.StorageLayout
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// NewStructSchema returns the schema of the struct type T.
+func NewStructSchema[T any](name string) (*StructSchema[T], error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type %v isn't a struct", t)
+	}
+
+	def := &schemapb.Schema{Name: name}
+	columns := make([]structColumn, 0, t.NumField())
+	sortingColumns := map[int]*schemapb.SortingColumn{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		col, sorting, order, err := structFieldColumn(f)
+		if err != nil {
+			return nil, err
+		}
+		if col == nil {
+			continue
+		}
+		if sorting != nil {
+			if _, ok := sortingColumns[order]; ok {
+				return nil, fmt.Errorf("field %s has the sorting order %d of another field", f.Name, order)
+			}
+			sortingColumns[order] = sorting
+		}
+		columns = append(columns, *col)
+		def.Columns = append(def.Columns, &schemapb.Column{
+			Name:          col.name,
+			StorageLayout: col.layout,
+			Dynamic:       col.dynamic,
+		})
+	}
+
+	orders := make([]int, 0, len(sortingColumns))
+	for order := range sortingColumns {
+		orders = append(orders, order)
+	}
+	sort.Ints(orders)
+	for _, order := range orders {
+		def.SortingColumns = append(def.SortingColumns, sortingColumns[order])
+	}
+
+	schema, err := SchemaFromDefinition(def)
+	if err != nil {
+		return nil, err
+	}
+	return &StructSchema[T]{
+		Schema:  schema,
+		columns: columns,
+	}, nil
+}
+
+// structFieldColumn returns the column of the field and its sorting column
+// and sorting order, if it's sorted by. It returns a nil column for fields
+// that are skipped.
+func structFieldColumn(f reflect.StructField) (*structColumn, *schemapb.SortingColumn, int, error) {
+	tag := f.Tag.Get(StructTag)
+	if tag == "-" {
+		return nil, nil, 0, nil
+	}
+	options := strings.Split(tag, ",")
+	name := options[0]
+	if name == "" {
+		name = snakeCase(f.Name)
+	}
+
+	col := &structColumn{
+		name:   name,
+		index:  f.Index,
+		layout: &schemapb.StorageLayout{},
+	}
+	t := f.Type
+	switch {
+	case t.Kind() == reflect.Pointer:
+		col.layout.Nullable = true
+		t = t.Elem()
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		col.dynamic = true
+		col.layout.Nullable = true
+		t = t.Elem()
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		col.layout.Repeated = true
+		t = t.Elem()
+	}
+	typ, ok := structFieldType(t)
+	if !ok {
+		return nil, nil, 0, fmt.Errorf("field %s has unsupported type %s", f.Name, f.Type)
+	}
+	col.layout.Type = typ
+
+	var (
+		sorting *schemapb.SortingColumn
+		order   int
+	)
+	nullsFirst := false
+	for _, option := range options[1:] {
+		switch option {
+		case "rle_dict":
+			col.layout.Encoding = schemapb.StorageLayout_ENCODING_RLE_DICTIONARY
+		case "rle":
+			col.layout.Encoding = schemapb.StorageLayout_ENCODING_RLE
+		case "delta_binary_packed":
+			col.layout.Encoding = schemapb.StorageLayout_ENCODING_DELTA_BINARY_PACKED
+		case "delta_byte_array":
+			col.layout.Encoding = schemapb.StorageLayout_ENCODING_DELTA_BYTE_ARRAY
+		case "delta_length_byte_array":
+			col.layout.Encoding = schemapb.StorageLayout_ENCODING_DELTA_LENGTH_BYTE_ARRAY
+		case "snappy":
+			col.layout.Compression = schemapb.StorageLayout_COMPRESSION_SNAPPY
+		case "gzip":
+			col.layout.Compression = schemapb.StorageLayout_COMPRESSION_GZIP
+		case "brotli":
+			col.layout.Compression = schemapb.StorageLayout_COMPRESSION_BROTLI
+		case "lz4_raw":
+			col.layout.Compression = schemapb.StorageLayout_COMPRESSION_LZ4_RAW
+		case "zstd":
+			col.layout.Compression = schemapb.StorageLayout_COMPRESSION_ZSTD
+		case "millis", "micros":
+			if typ != schemapb.StorageLayout_TYPE_INT64 && typ != schemapb.StorageLayout_TYPE_TIMESTAMP_NANOS {
+				return nil, nil, 0, fmt.Errorf("field %s of type %s can't be a timestamp", f.Name, f.Type)
+			}
+			col.layout.Type = schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS
+			if option == "micros" {
+				col.layout.Type = schemapb.StorageLayout_TYPE_TIMESTAMP_MICROS
+			}
+		case "null_first":
+			nullsFirst = true
+		default:
+			direction := schemapb.SortingColumn_DIRECTION_ASCENDING
+			arg, ok := optionArgument(option, "asc")
+			if !ok {
+				direction = schemapb.SortingColumn_DIRECTION_DESCENDING
+				arg, ok = optionArgument(option, "desc")
+			}
+			if !ok {
+				return nil, nil, 0, fmt.Errorf("field %s has unknown option %s", f.Name, option)
+			}
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, nil, 0, fmt.Errorf("field %s has invalid sorting order %s", f.Name, arg)
+			}
+			sorting = &schemapb.SortingColumn{Name: name, Direction: direction}
+			order = n
+		}
+	}
+	if nullsFirst {
+		if sorting == nil {
+			return nil, nil, 0, fmt.Errorf("field %s sorts nulls first but isn't sorted by", f.Name)
+		}
+		sorting.NullsFirst = true
+	}
+	return col, sorting, order, nil
+}
+
+// optionArgument returns the argument of options of the form name(argument).
+func optionArgument(option, name string) (string, bool) {
+	if !strings.HasPrefix(option, name+"(") || !strings.HasSuffix(option, ")") {
+		return "", false
+	}
+	return option[len(name)+1 : len(option)-1], true
+}
+
+func structFieldType(t reflect.Type) (schemapb.StorageLayout_Type, bool) {
+	if t == timeType {
+		return schemapb.StorageLayout_TYPE_TIMESTAMP_NANOS, true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return schemapb.StorageLayout_TYPE_STRING, true
+	case reflect.Bool:
+		return schemapb.StorageLayout_TYPE_BOOL, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schemapb.StorageLayout_TYPE_INT64, true
+	case reflect.Float32, reflect.Float64:
+		return schemapb.StorageLayout_TYPE_DOUBLE, true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return schemapb.StorageLayout_TYPE_BYTES, true
+		}
+	}
+	return schemapb.StorageLayout_TYPE_UNKNOWN_UNSPECIFIED, false
+}
+
+// snakeCase returns the snake cased name of a field, such as http_status for
+// HTTPStatus.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ToBuffer returns a buffer of the values, with the dynamic columns of the
+// keys of their maps.
+func (s *StructSchema[T]) ToBuffer(values ...T) (*Buffer, error) {
+	dynamicColumns := map[string][]string{}
+	for _, col := range s.columns {
+		if !col.dynamic {
+			continue
+		}
+		keys := map[string]struct{}{}
+		for _, v := range values {
+			for _, key := range reflect.ValueOf(v).FieldByIndex(col.index).MapKeys() {
+				keys[key.String()] = struct{}{}
+			}
+		}
+		names := make([]string, 0, len(keys))
+		for key := range keys {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		dynamicColumns[col.name] = names
+	}
+
+	// The values of rows are in the order of the columns of the parquet
+	// schema, which orders columns by their names.
+	type leaf struct {
+		name string
+		col  structColumn
+		key  string
+	}
+	leaves := make([]leaf, 0, len(s.columns))
+	for _, col := range s.columns {
+		if !col.dynamic {
+			leaves = append(leaves, leaf{name: col.name, col: col})
+			continue
+		}
+		for _, key := range dynamicColumns[col.name] {
+			leaves = append(leaves, leaf{name: col.name + "." + key, col: col, key: key})
+		}
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].name < leaves[j].name
+	})
+
+	buf, err := s.NewBuffer(dynamicColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]parquet.Row, 0, len(values))
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		row := make(parquet.Row, 0, len(leaves))
+		for i, l := range leaves {
+			field := rv.FieldByIndex(l.col.index)
+			switch {
+			case l.col.dynamic:
+				value := field.MapIndex(reflect.ValueOf(l.key).Convert(field.Type().Key()))
+				if !value.IsValid() {
+					row = append(row, parquet.ValueOf(nil).Level(0, 0, i))
+					continue
+				}
+				row = append(row, structValue(value, l.col.layout.Type).Level(0, 1, i))
+			case l.col.layout.Nullable:
+				if field.IsNil() {
+					row = append(row, parquet.ValueOf(nil).Level(0, 0, i))
+					continue
+				}
+				row = append(row, structValue(field.Elem(), l.col.layout.Type).Level(0, 1, i))
+			case l.col.layout.Repeated:
+				if field.Len() == 0 {
+					row = append(row, parquet.ValueOf(nil).Level(0, 0, i))
+					continue
+				}
+				for j := 0; j < field.Len(); j++ {
+					rep := 1
+					if j == 0 {
+						rep = 0
+					}
+					row = append(row, structValue(field.Index(j), l.col.layout.Type).Level(rep, 1, i))
+				}
+			default:
+				row = append(row, structValue(field, l.col.layout.Type).Level(0, 0, i))
+			}
+		}
+		rows = append(rows, row)
+	}
+	if _, err := buf.WriteRows(rows); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// structValue returns the parquet value of a value of a field of a column of
+// the type.
+func structValue(v reflect.Value, typ schemapb.StorageLayout_Type) parquet.Value {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		switch typ {
+		case schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS:
+			return parquet.ValueOf(t.UnixMilli())
+		case schemapb.StorageLayout_TYPE_TIMESTAMP_MICROS:
+			return parquet.ValueOf(t.UnixMicro())
+		default:
+			return parquet.ValueOf(t.UnixNano())
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return parquet.ValueOf(v.String())
+	case reflect.Bool:
+		return parquet.ValueOf(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return parquet.ValueOf(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return parquet.ValueOf(int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return parquet.ValueOf(v.Float())
+	default:
+		return parquet.ValueOf(v.Bytes())
+	}
+}
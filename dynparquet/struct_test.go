@@ -0,0 +1,141 @@
+package dynparquet
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+)
+
+type structSample struct {
+	ExampleType string            `frostdb:",rle_dict,asc(0)"`
+	Labels      map[string]string `frostdb:"labels,rle_dict,asc(1),null_first"`
+	Stacktrace  []string          `frostdb:"stacktrace"`
+	Timestamp   time.Time         `frostdb:"timestamp,millis,desc(2)"`
+	Value       *float64          `frostdb:"value,zstd"`
+	HTTPStatus  int
+	Skipped     string `frostdb:"-"`
+}
+
+func TestStructSchema(t *testing.T) {
+	schema, err := NewStructSchema[structSample]("test")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "example_type",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+			},
+		}, {
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "stacktrace",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Repeated: true,
+			},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS},
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:        schemapb.StorageLayout_TYPE_DOUBLE,
+				Compression: schemapb.StorageLayout_COMPRESSION_ZSTD,
+				Nullable:    true,
+			},
+		}, {
+			Name:          "http_status",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "example_type",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:       "labels",
+			Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+			NullsFirst: true,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_DESCENDING,
+		}},
+	}, schema.Definition()))
+
+	value := 1.5
+	buf, err := schema.ToBuffer(structSample{
+		ExampleType: "cpu",
+		Labels:      map[string]string{"node": "a"},
+		Stacktrace:  []string{"main", "run"},
+		Timestamp:   time.UnixMilli(10),
+		Value:       &value,
+		HTTPStatus:  200,
+	}, structSample{
+		ExampleType: "memory",
+		Labels:      map[string]string{"region": "eu"},
+		Timestamp:   time.UnixMilli(20),
+		HTTPStatus:  500,
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"labels": {"node", "region"}}, buf.DynamicColumns())
+
+	rows := make([]parquet.Row, 2)
+	n, err := buf.Rows().ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, n)
+	// example_type, http_status, labels.node, labels.region, stacktrace,
+	// timestamp and value.
+	require.Equal(t, "cpu", rows[0][0].String())
+	require.Equal(t, int64(200), rows[0][1].Int64())
+	require.Equal(t, "a", rows[0][2].String())
+	require.True(t, rows[0][3].IsNull())
+	require.Equal(t, "main", rows[0][4].String())
+	require.Equal(t, "run", rows[0][5].String())
+	require.Equal(t, int64(10), rows[0][6].Int64())
+	require.Equal(t, 1.5, rows[0][7].Double())
+
+	require.Equal(t, "memory", rows[1][0].String())
+	require.True(t, rows[1][2].IsNull())
+	require.Equal(t, "eu", rows[1][3].String())
+	require.True(t, rows[1][4].IsNull())
+	require.Equal(t, int64(20), rows[1][5].Int64())
+	require.True(t, rows[1][6].IsNull())
+}
+
+func TestStructSchemaErrors(t *testing.T) {
+	_, err := NewStructSchema[int]("test")
+	require.EqualError(t, err, "type int isn't a struct")
+
+	type unsupported struct {
+		Values map[int]string
+	}
+	_, err = NewStructSchema[unsupported]("test")
+	require.EqualError(t, err, "field Values has unsupported type map[int]string")
+
+	type unknownOption struct {
+		Value int64 `frostdb:"value,fast"`
+	}
+	_, err = NewStructSchema[unknownOption]("test")
+	require.EqualError(t, err, "field Value has unknown option fast")
+
+	type duplicateOrder struct {
+		A int64 `frostdb:"a,asc(0)"`
+		B int64 `frostdb:"b,asc(0)"`
+	}
+	_, err = NewStructSchema[duplicateOrder]("test")
+	require.EqualError(t, err, "field B has the sorting order 0 of another field")
+}
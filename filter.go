@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
 	"github.com/segmentio/parquet-go"
 
 	"github.com/polarsignals/frostdb/dynparquet"
 	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/pqarrow/convert"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 )
 
@@ -25,15 +28,169 @@ type TrueNegativeFilter interface {
 	Eval(dynparquet.DynamicRowGroup) (bool, error)
 }
 
+// rowRangesFilter is implemented by filters that can rule out the pages of a
+// row group by the column indexes of its column chunks, so pages none of
+// whose rows match the filter aren't read.
+type rowRangesFilter interface {
+	// RowRanges returns the sorted and non overlapping ranges of the rows of
+	// the row group that may contain useful data.
+	RowRanges(dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error)
+}
+
+// rowRanges returns the ranges of the rows of the row group that may contain
+// rows matching the filter.
+func rowRanges(filter TrueNegativeFilter, rg dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	if f, ok := filter.(rowRangesFilter); ok {
+		return f.RowRanges(rg)
+	}
+
+	mayContainUsefulData, err := filter.Eval(rg)
+	if err != nil || !mayContainUsefulData {
+		return nil, err
+	}
+	return allRows(rg), nil
+}
+
+// pruneRowGroup returns the row group only containing the ranges of its rows
+// that may contain rows matching the filter, or nil if none of its rows may.
+func pruneRowGroup(filter TrueNegativeFilter, rg dynparquet.DynamicRowGroup) (dynparquet.DynamicRowGroup, error) {
+	ranges, err := rowRanges(filter, rg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(ranges) == 0:
+		return nil, nil
+	case len(ranges) == 1 && ranges[0].NumRows() == rg.NumRows():
+		return rg, nil
+	default:
+		return dynparquet.NewRowRangesRowGroup(rg, ranges), nil
+	}
+}
+
+func allRows(rg dynparquet.DynamicRowGroup) []dynparquet.RowRange {
+	if rg.NumRows() == 0 {
+		return nil
+	}
+	return []dynparquet.RowRange{{Start: 0, End: rg.NumRows()}}
+}
+
+// pageRowRanges returns the ranges of the rows of the pages of the column
+// chunk that may match, according to the column chunk's column and offset
+// index.
+func pageRowRanges(
+	rg dynparquet.DynamicRowGroup,
+	columnChunk parquet.ColumnChunk,
+	mayMatch func(index parquet.ColumnIndex, i int) bool,
+) []dynparquet.RowRange {
+	columnIndex, offsetIndex := columnChunk.ColumnIndex(), columnChunk.OffsetIndex()
+	if columnIndex == nil || offsetIndex == nil || columnIndex.NumPages() != offsetIndex.NumPages() {
+		return allRows(rg)
+	}
+
+	var ranges []dynparquet.RowRange
+	numPages := columnIndex.NumPages()
+	for i := 0; i < numPages; i++ {
+		if !mayMatch(columnIndex, i) {
+			continue
+		}
+
+		start, end := offsetIndex.FirstRowIndex(i), rg.NumRows()
+		if i+1 < numPages {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].End == start {
+			ranges[n-1].End = end
+			continue
+		}
+		ranges = append(ranges, dynparquet.RowRange{Start: start, End: end})
+	}
+	return ranges
+}
+
+// intersectRowRanges returns the ranges of the rows that are in both ranges.
+func intersectRowRanges(a, b []dynparquet.RowRange) []dynparquet.RowRange {
+	var ranges []dynparquet.RowRange
+	for len(a) > 0 && len(b) > 0 {
+		start, end := a[0].Start, a[0].End
+		if b[0].Start > start {
+			start = b[0].Start
+		}
+		if b[0].End < end {
+			end = b[0].End
+		}
+		if start < end {
+			ranges = append(ranges, dynparquet.RowRange{Start: start, End: end})
+		}
+
+		if a[0].End < b[0].End {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return ranges
+}
+
+// unionRowRanges returns the ranges of the rows that are in either range.
+func unionRowRanges(a, b []dynparquet.RowRange) []dynparquet.RowRange {
+	ranges := make([]dynparquet.RowRange, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		var next dynparquet.RowRange
+		if len(b) == 0 || (len(a) > 0 && a[0].Start <= b[0].Start) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+
+		if n := len(ranges); n > 0 && ranges[n-1].End >= next.Start {
+			if next.End > ranges[n-1].End {
+				ranges[n-1].End = next.End
+			}
+			continue
+		}
+		ranges = append(ranges, next)
+	}
+	return ranges
+}
+
 type AlwaysTrueFilter struct{}
 
 func (f *AlwaysTrueFilter) Eval(dynparquet.DynamicRowGroup) (bool, error) {
 	return true, nil
 }
 
+func (f *AlwaysTrueFilter) RowRanges(rg dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	return allRows(rg), nil
+}
+
+type AlwaysFalseFilter struct{}
+
+func (f *AlwaysFalseFilter) Eval(dynparquet.DynamicRowGroup) (bool, error) {
+	return false, nil
+}
+
+func (f *AlwaysFalseFilter) RowRanges(dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	return nil, nil
+}
+
 func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (TrueNegativeFilter, error) {
+	if expanded, ok := logicalplan.ExpandTupleComparison(expr); ok {
+		return booleanExpr(expanded)
+	}
+
 	switch expr.Op {
-	case logicalplan.OpEq: //, logicalplan.OpNotEq, logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq, logicalplan.OpRegexMatch, logicalplan.RegexNotMatch:
+	case logicalplan.OpEq, logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq, logicalplan.OpStartsWith: //, logicalplan.OpNotEq, logicalplan.OpRegexMatch, logicalplan.RegexNotMatch:
+		if _, ok := expr.Left.(*logicalplan.Column); !ok {
+			// Computed values can't be checked against a column's bloom
+			// filter or statistics.
+			return &AlwaysTrueFilter{}, nil
+		}
+		if _, ok := expr.Right.(*logicalplan.LiteralExpr); !ok {
+			return &AlwaysTrueFilter{}, nil
+		}
+
 		var leftColumnRef *ColumnRef
 		expr.Left.Accept(PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
 			switch e := expr.(type) {
@@ -51,12 +208,14 @@ func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (TrueNegativeFilter, error)
 
 		var (
 			rightValue parquet.Value
+			literal    scalar.Scalar
 			err        error
 		)
 		expr.Right.Accept(PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
 			switch e := expr.(type) {
 			case *logicalplan.LiteralExpr:
 				rightValue, err = pqarrow.ArrowScalarToParquetValue(e.Value)
+				literal = e.Value
 				return false
 			}
 			return true
@@ -67,9 +226,10 @@ func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (TrueNegativeFilter, error)
 		}
 
 		return &BinaryScalarExpr{
-			Left:  leftColumnRef,
-			Op:    expr.Op,
-			Right: rightValue,
+			Left:    leftColumnRef,
+			Op:      expr.Op,
+			Right:   rightValue,
+			literal: literal,
 		}, nil
 	case logicalplan.OpAnd:
 		left, err := booleanExpr(expr.Left)
@@ -86,6 +246,21 @@ func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (TrueNegativeFilter, error)
 			Left:  left,
 			Right: right,
 		}, nil
+	case logicalplan.OpOr:
+		left, err := booleanExpr(expr.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := booleanExpr(expr.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		return &OrExpr{
+			Left:  left,
+			Right: right,
+		}, nil
 	default:
 		return &AlwaysTrueFilter{}, nil
 	}
@@ -114,6 +289,51 @@ func (a *AndExpr) Eval(rg dynparquet.DynamicRowGroup) (bool, error) {
 	return left && right, nil
 }
 
+func (a *AndExpr) RowRanges(rg dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	left, err := rowRanges(a.Left, rg)
+	if err != nil || len(left) == 0 {
+		return nil, err
+	}
+
+	right, err := rowRanges(a.Right, rg)
+	if err != nil {
+		return nil, err
+	}
+
+	return intersectRowRanges(left, right), nil
+}
+
+type OrExpr struct {
+	Left  TrueNegativeFilter
+	Right TrueNegativeFilter
+}
+
+func (a *OrExpr) Eval(rg dynparquet.DynamicRowGroup) (bool, error) {
+	left, err := a.Left.Eval(rg)
+	if err != nil {
+		return false, err
+	}
+	if left {
+		return true, nil
+	}
+
+	return a.Right.Eval(rg)
+}
+
+func (a *OrExpr) RowRanges(rg dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	left, err := rowRanges(a.Left, rg)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := rowRanges(a.Right, rg)
+	if err != nil {
+		return nil, err
+	}
+
+	return unionRowRanges(left, right), nil
+}
+
 func booleanExpr(expr logicalplan.Expr) (TrueNegativeFilter, error) {
 	if expr == nil {
 		return &AlwaysTrueFilter{}, nil
@@ -122,7 +342,169 @@ func booleanExpr(expr logicalplan.Expr) (TrueNegativeFilter, error) {
 	switch e := expr.(type) {
 	case *logicalplan.BinaryExpr:
 		return binaryBooleanExpr(e)
+	case *logicalplan.UnaryExpr:
+		// A bloom filter can only rule out the presence of a value, which
+		// says nothing about a negated predicate.
+		return &AlwaysTrueFilter{}, nil
+	case *logicalplan.BetweenExpr:
+		return betweenExpr(e)
+	case *logicalplan.LiteralExpr:
+		if b, ok := e.Value.(*scalar.Boolean); ok && b.Valid && !b.Value {
+			return &AlwaysFalseFilter{}, nil
+		}
+		return &AlwaysTrueFilter{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported boolean expression %T", e)
 	}
 }
+
+func betweenExpr(expr *logicalplan.BetweenExpr) (TrueNegativeFilter, error) {
+	column, ok := expr.Expr.(*logicalplan.Column)
+	if !ok {
+		// Computed values have no statistics to compare against.
+		return &AlwaysTrueFilter{}, nil
+	}
+	low, ok := expr.Low.(*logicalplan.LiteralExpr)
+	if !ok {
+		return &AlwaysTrueFilter{}, nil
+	}
+	high, ok := expr.High.(*logicalplan.LiteralExpr)
+	if !ok {
+		return &AlwaysTrueFilter{}, nil
+	}
+
+	lowValue, err := pqarrow.ArrowScalarToParquetValue(low.Value)
+	if err != nil {
+		return nil, err
+	}
+	highValue, err := pqarrow.ArrowScalarToParquetValue(high.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BetweenExpr{
+		Left:        &ColumnRef{ColumnName: column.ColumnName},
+		Low:         lowValue,
+		High:        highValue,
+		lowLiteral:  low.Value,
+		highLiteral: high.Value,
+	}, nil
+}
+
+// columnValue returns the value of a literal compared with the values of a
+// column of the type. Timestamps of epoch milliseconds are converted to the
+// unit of timestamp columns and numbers to the unscaled values of decimal
+// columns, numbers with more digits than the scale are between two of them.
+// Other values are returned as is.
+func columnValue(typ parquet.Type, literal scalar.Scalar, v parquet.Value) parquet.Value {
+	lt := typ.LogicalType()
+	switch {
+	case lt == nil:
+		return v
+	case lt.Timestamp != nil:
+		if _, ok := literal.(*scalar.Timestamp); !ok || v.Kind() != parquet.Int64 {
+			return v
+		}
+		return parquet.ValueOf(convert.ConvertTimestamp(v.Int64(), arrow.Millisecond, convert.TimeUnit(lt.Timestamp)))
+	case lt.Decimal != nil:
+		d, exact, ok := convert.DecimalValue(literal, lt.Decimal.Scale)
+		if !ok {
+			return v
+		}
+		n, ok := convert.DecimalInt64(d)
+		if !ok || !exact || typ.Kind() != parquet.Int64 {
+			// The value can't be compared with the column's values.
+			return parquet.ValueOf(float64(n) + 0.5)
+		}
+		return parquet.ValueOf(n)
+	default:
+		return v
+	}
+}
+
+// BetweenExpr rules out row groups where no page of the column's index has
+// values overlapping with the range [Low, High].
+type BetweenExpr struct {
+	Left *ColumnRef
+	Low  parquet.Value
+	High parquet.Value
+
+	// lowLiteral and highLiteral are the scalars of Low and High, which are
+	// converted to the values of timestamp and decimal columns.
+	lowLiteral, highLiteral scalar.Scalar
+}
+
+func (e *BetweenExpr) Eval(rg dynparquet.DynamicRowGroup) (bool, error) {
+	columnChunk, exists, err := e.Left.Column(rg)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		// A column that doesn't exist is the empty string.
+		return e.Low.Kind() == parquet.ByteArray && len(e.Low.ByteArray()) == 0, nil
+	}
+
+	index := columnChunk.ColumnIndex()
+	if index == nil {
+		return true, nil
+	}
+
+	typ := e.Left.Type(rg)
+	for i := 0; i < index.NumPages(); i++ {
+		if e.pageMayMatch(typ, index, i) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RowRanges returns the ranges of the pages of the column whose values may
+// overlap with the range.
+func (e *BetweenExpr) RowRanges(rg dynparquet.DynamicRowGroup) ([]dynparquet.RowRange, error) {
+	mayContainUsefulData, err := e.Eval(rg)
+	if err != nil || !mayContainUsefulData {
+		return nil, err
+	}
+
+	columnChunk, exists, err := e.Left.Column(rg)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return allRows(rg), nil
+	}
+
+	typ := e.Left.Type(rg)
+	return pageRowRanges(rg, columnChunk, func(index parquet.ColumnIndex, i int) bool {
+		return e.pageMayMatch(typ, index, i)
+	}), nil
+}
+
+func (e *BetweenExpr) pageMayMatch(typ parquet.Type, index parquet.ColumnIndex, i int) bool {
+	low, high := columnValue(typ, e.lowLiteral, e.Low), columnValue(typ, e.highLiteral, e.High)
+	if typ.Kind() != low.Kind() || typ.Kind() != high.Kind() {
+		// The bounds can't be compared with the column's values.
+		return true
+	}
+	if index.NullPage(i) {
+		return false
+	}
+
+	min, max := index.MinValue(i), index.MaxValue(i)
+	if typ.Kind() == parquet.ByteArray {
+		// Page bounds of byte arrays may be truncated.
+		low = truncateByteArray(low, len(max.ByteArray()))
+		high = truncateByteArray(high, len(min.ByteArray()))
+	}
+
+	return typ.Compare(low, max) <= 0 && typ.Compare(min, high) <= 0
+}
+
+func truncateByteArray(v parquet.Value, n int) parquet.Value {
+	if b := v.ByteArray(); len(b) > n {
+		return parquet.ValueOf(b[:n])
+	}
+	return v
+}
@@ -1,18 +1,29 @@
 package frostdb
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/decimal128"
 	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+	"github.com/polarsignals/frostdb/pqarrow"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/query/physicalplan"
 )
 
 func TestFilter(t *testing.T) {
@@ -94,6 +105,11 @@ func TestFilter(t *testing.T) {
 			cols: 6,
 			rows: 1,
 		},
+		"in string": {
+			filterExpr: logicalplan.Col("labels.label4").In(logicalplan.Literal("value4"), logicalplan.Literal("value5")),
+			cols:       6,
+			rows:       1,
+		},
 		"regexp and == string": {
 			filterExpr: logicalplan.And(
 				logicalplan.Col("labels.label1").RegexMatch("value."),
@@ -157,6 +173,113 @@ func TestFilter(t *testing.T) {
 			filterExpr: logicalplan.Col("labels.label1").RegexMatch("values."),
 			rows:       0,
 		},
+		">= arithmetic": {
+			filterExpr: logicalplan.Col("timestamp").Add(logicalplan.Col("value")).GtEq(logicalplan.Literal(4)),
+			cols:       7,
+			rows:       2,
+		},
+		"== arithmetic with literal": {
+			filterExpr: logicalplan.Col("value").Mul(logicalplan.Literal(2)).Eq(logicalplan.Literal(6)),
+			cols:       7,
+			rows:       1,
+		},
+		"cast literal": {
+			filterExpr: logicalplan.Col("value").Gt(logicalplan.Cast(logicalplan.Literal(1.5), arrow.PrimitiveTypes.Int64)),
+			cols:       7,
+			rows:       2,
+		},
+		"cast column": {
+			filterExpr: logicalplan.Cast(logicalplan.Col("timestamp"), arrow.BinaryTypes.String).Eq(logicalplan.Literal("3")),
+			cols:       7,
+			rows:       1,
+		},
+		"== upper": {
+			filterExpr: logicalplan.Upper(logicalplan.Col("labels.label1")).Eq(logicalplan.Literal("VALUE1")),
+			cols:       7,
+			rows:       1,
+		},
+		"== length": {
+			filterExpr: logicalplan.Length(logicalplan.Col("labels.label1")).Eq(logicalplan.Literal(6)),
+			cols:       7,
+			rows:       3,
+		},
+		"regexp lower": {
+			filterExpr: logicalplan.Lower(logicalplan.Col("labels.label1")).RegexMatch("value[12]"),
+			cols:       7,
+			rows:       2,
+		},
+		"starts with": {
+			filterExpr: logicalplan.Col("labels.label1").StartsWith("value"),
+			cols:       7,
+			rows:       3,
+		},
+		"ends with": {
+			filterExpr: logicalplan.Col("labels.label1").EndsWith("2"),
+			cols:       7,
+			rows:       1,
+		},
+		"contains missing column": {
+			filterExpr: logicalplan.Col("labels.label5").Contains("a"),
+			cols:       0,
+			rows:       0,
+		},
+		"contains lower": {
+			filterExpr: logicalplan.Lower(logicalplan.Col("labels.label1")).Contains("lue3"),
+			cols:       7,
+			rows:       1,
+		},
+		"== abs": {
+			filterExpr: logicalplan.Abs(logicalplan.Col("value").Sub(logicalplan.Literal(3))).Eq(logicalplan.Literal(1)),
+			cols:       7,
+			rows:       1,
+		},
+		"between": {
+			filterExpr: logicalplan.Col("timestamp").Between(logicalplan.Literal(2), logicalplan.Literal(3)),
+			cols:       7,
+			rows:       2,
+		},
+		">= time": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(time.UnixMilli(2))),
+			cols:       7,
+			rows:       2,
+		},
+		"not ==": {
+			filterExpr: logicalplan.Not(logicalplan.Col("labels.label1").Eq(logicalplan.Literal("value1"))),
+			cols:       7,
+			rows:       2,
+		},
+		"or": {
+			filterExpr: logicalplan.Or(
+				logicalplan.Col("timestamp").Eq(logicalplan.Literal(1)),
+				logicalplan.Col("timestamp").Eq(logicalplan.Literal(3)),
+			),
+			// The last row doesn't have the label3 column.
+			cols: 6,
+			rows: 2,
+		},
+		"regex column == string": {
+			filterExpr: logicalplan.RegexCol("labels.label[34]").Eq(logicalplan.Literal("value4")),
+			cols:       7,
+			rows:       1,
+		},
+		"not and": {
+			filterExpr: logicalplan.Not(logicalplan.And(
+				logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+				logicalplan.Col("labels.label1").Eq(logicalplan.Literal("value2")),
+			)),
+			cols: 7,
+			rows: 2,
+		},
+		"double not": {
+			filterExpr: logicalplan.Not(logicalplan.Not(logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)))),
+			cols:       7,
+			rows:       2,
+		},
+		"not arithmetic": {
+			filterExpr: logicalplan.Not(logicalplan.Col("timestamp").Add(logicalplan.Col("value")).GtEq(logicalplan.Literal(4))),
+			cols:       7,
+			rows:       1,
+		},
 	}
 
 	engine := query.NewEngine(
@@ -184,6 +307,26 @@ func TestFilter(t *testing.T) {
 			require.Equal(t, test.cols, cols)
 		})
 	}
+
+	t.Run("prepared", func(t *testing.T) {
+		q, err := engine.ScanTable("test").
+			Filter(logicalplan.Col("timestamp").GtEq(logicalplan.Placeholder("from"))).
+			Prepare()
+		require.NoError(t, err)
+
+		for from, expected := range map[int64]int64{1: 3, 2: 2, 4: 0} {
+			rows := int64(0)
+			err := q.Execute(context.Background(), map[string]interface{}{"from": from}, func(ar arrow.Record) error {
+				rows += ar.NumRows()
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, expected, rows)
+		}
+
+		err = q.Execute(context.Background(), nil, func(ar arrow.Record) error { return nil })
+		require.ErrorIs(t, err, logicalplan.ErrUnboundPlaceholder)
+	})
 }
 
 func Test_Projection(t *testing.T) {
@@ -271,6 +414,102 @@ func Test_Projection(t *testing.T) {
 			rows:        2,
 			cols:        1,
 		},
+		"arithmetic projection": {
+			filterExpr: logicalplan.And(
+				logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			),
+			projections: []logicalplan.Expr{
+				logicalplan.Col("timestamp"),
+				logicalplan.Col("value").Div(logicalplan.Col("timestamp")),
+			},
+			rows: 2,
+			cols: 2,
+		},
+		"regex column projection": {
+			filterExpr:  logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			projections: []logicalplan.Expr{logicalplan.RegexCol("labels.label[12]")},
+			rows:        2,
+			cols:        2,
+		},
+		"all columns projection": {
+			filterExpr:  logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			projections: []logicalplan.Expr{logicalplan.AllColumns()},
+			rows:        2,
+			cols:        8,
+		},
+		"all columns projection with computed column": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(1)),
+			projections: []logicalplan.Expr{
+				logicalplan.AllColumns(),
+				logicalplan.Col("value").Add(logicalplan.Col("timestamp")),
+			},
+			rows: 3,
+			cols: 9,
+		},
+		"case projection": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(1)),
+			projections: []logicalplan.Expr{
+				logicalplan.Col("timestamp"),
+				logicalplan.Case(
+					logicalplan.When(logicalplan.Col("labels.label1").Eq(logicalplan.Literal("value1")), logicalplan.Col("value")),
+					logicalplan.When(logicalplan.Col("timestamp").Gt(logicalplan.Literal(2)), logicalplan.Literal(int64(-1))),
+				).WithElse(logicalplan.Literal(int64(0))).Alias("bucket"),
+			},
+			rows: 3,
+			cols: 2,
+		},
+		"concat projection": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			projections: []logicalplan.Expr{
+				logicalplan.Concat(logicalplan.Col("labels.label1"), logicalplan.Literal("-"), logicalplan.Col("labels.label2")).Alias("labels"),
+			},
+			rows: 2,
+			cols: 1,
+		},
+		"log projection": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			projections: []logicalplan.Expr{
+				logicalplan.Log(logicalplan.Col("value")),
+			},
+			rows: 2,
+			cols: 1,
+		},
+		"cast projection": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			projections: []logicalplan.Expr{
+				logicalplan.Cast(logicalplan.Col("value"), arrow.PrimitiveTypes.Float64).Alias("value_float"),
+			},
+			rows: 2,
+			cols: 1,
+		},
+		"coalesce projection": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(1)),
+			projections: []logicalplan.Expr{
+				logicalplan.Coalesce(logicalplan.Col("labels.label3"), logicalplan.Col("labels.label4"), logicalplan.Literal("none")).Alias("label"),
+			},
+			rows: 3,
+			cols: 1,
+		},
+		"aliased column projection": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(2)),
+			projections: []logicalplan.Expr{
+				logicalplan.Col("timestamp").Alias("ts"),
+				logicalplan.Col("value").Mul(logicalplan.Literal(int64(2))).Alias("doubled"),
+			},
+			rows: 2,
+			cols: 2,
+		},
+		"boolean projections": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(1)),
+			projections: []logicalplan.Expr{
+				logicalplan.Literal(int64(1)),
+				logicalplan.Col("value").Gt(logicalplan.Literal(int64(1))).Alias("big"),
+				logicalplan.Not(logicalplan.Col("value").Gt(logicalplan.Literal(int64(1)))),
+				logicalplan.Col("value").Between(logicalplan.Literal(int64(1)), logicalplan.Literal(int64(2))),
+			},
+			rows: 3,
+			cols: 4,
+		},
 	}
 
 	engine := query.NewEngine(
@@ -295,4 +534,1098 @@ func Test_Projection(t *testing.T) {
 			require.Equal(t, test.rows, rows)
 		})
 	}
+
+	t.Run("computed columns", func(t *testing.T) {
+		res := map[int64][]interface{}{}
+		err := engine.ScanTable("test").
+			Project(
+				logicalplan.Col("timestamp").Alias("ts"),
+				logicalplan.Col("value").Add(logicalplan.Col("timestamp")).Alias("sum"),
+				logicalplan.Col("value").Gt(logicalplan.Literal(int64(1))).Alias("big"),
+				logicalplan.Col("value").Between(logicalplan.Literal(int64(2)), logicalplan.Literal(int64(3))).Alias("mid"),
+			).
+			Execute(context.Background(), func(ar arrow.Record) error {
+				require.Equal(t, []string{"ts", "sum", "big", "mid"}, []string{
+					ar.Schema().Field(0).Name,
+					ar.Schema().Field(1).Name,
+					ar.Schema().Field(2).Name,
+					ar.Schema().Field(3).Name,
+				})
+				for i := 0; i < int(ar.NumRows()); i++ {
+					res[ar.Column(0).(*array.Int64).Value(i)] = []interface{}{
+						ar.Column(1).(*array.Int64).Value(i),
+						ar.Column(2).(*array.Boolean).Value(i),
+						ar.Column(3).(*array.Boolean).Value(i),
+					}
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, map[int64][]interface{}{
+			1: {int64(2), false, false},
+			2: {int64(4), true, true},
+			3: {int64(6), true, true},
+		}, res)
+	})
+
+	t.Run("user-defined function", func(t *testing.T) {
+		err := engine.RegisterFunction("double", arrow.PrimitiveTypes.Int64, func(pool memory.Allocator, args []arrow.Array) (arrow.Array, error) {
+			b := array.NewInt64Builder(pool)
+			defer b.Release()
+			for _, v := range args[0].(*array.Int64).Int64Values() {
+				b.Append(2 * v)
+			}
+			return b.NewArray(), nil
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, engine.RegisterFunction("double", arrow.PrimitiveTypes.Int64, nil), query.ErrFunctionExists)
+
+		values := []int64{}
+		err = engine.ScanTable("test").
+			Filter(logicalplan.Call("double", logicalplan.Col("value")).Gt(logicalplan.Literal(int64(2)))).
+			Project(logicalplan.Call("double", logicalplan.Col("value")).Alias("doubled")).
+			Execute(context.Background(), func(ar arrow.Record) error {
+				values = append(values, ar.Column(0).(*array.Int64).Int64Values()...)
+				return nil
+			})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []int64{4, 6}, values)
+
+		err = engine.ScanTable("test").
+			Project(logicalplan.Call("unknown", logicalplan.Col("value"))).
+			Execute(context.Background(), func(ar arrow.Record) error { return nil })
+		require.ErrorIs(t, err, logicalplan.ErrUnknownFunction)
+	})
+}
+
+func TestFilterRowGroupStatistics(t *testing.T) {
+	schema := dynparquet.NewSampleSchema()
+	samples := dynparquet.Samples{{
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "namespace", Value: "b"},
+		},
+		Timestamp: 10,
+		Value:     1,
+	}, {
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "namespace", Value: "d"},
+		},
+		Timestamp: 20,
+		Value:     2,
+	}}
+
+	rowGroup := func(t *testing.T, samples dynparquet.Samples) dynparquet.DynamicRowGroup {
+		buf, err := samples.ToBuffer(schema)
+		require.NoError(t, err)
+		b, err := schema.SerializeBuffer(buf)
+		require.NoError(t, err)
+		serialized, err := dynparquet.ReaderFromBytes(b)
+		require.NoError(t, err)
+		return serialized.DynamicRowGroup(0)
+	}
+
+	withoutLabel := append(dynparquet.Samples{{
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "pod", Value: "a"},
+		},
+		Timestamp: 30,
+		Value:     3,
+	}}, samples...)
+
+	tests := map[string]struct {
+		samples dynparquet.Samples
+		expr    logicalplan.Expr
+		match   bool
+	}{
+		"equal": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").Eq(logicalplan.Literal("d")),
+			match:   true,
+		},
+		"equal out of range": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").Eq(logicalplan.Literal("e")),
+			match:   false,
+		},
+		"less than min": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").Lt(logicalplan.Literal("b")),
+			match:   false,
+		},
+		"less than or equal min": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").LtEq(logicalplan.Literal("b")),
+			match:   true,
+		},
+		"greater than max": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").Gt(logicalplan.Literal("d")),
+			match:   false,
+		},
+		"greater than or equal max": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").GtEq(logicalplan.Literal("d")),
+			match:   true,
+		},
+		"rows without label": {
+			samples: withoutLabel,
+			expr:    logicalplan.Col("labels.namespace").Lt(logicalplan.Literal("b")),
+			match:   true,
+		},
+		"rows without label out of range": {
+			samples: withoutLabel,
+			expr:    logicalplan.Col("labels.namespace").Gt(logicalplan.Literal("d")),
+			match:   false,
+		},
+		"missing column": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.pod").Gt(logicalplan.Literal("a")),
+			match:   false,
+		},
+		"int range": {
+			samples: samples,
+			expr:    logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(20))),
+			match:   false,
+		},
+		"int equal out of range": {
+			samples: samples,
+			expr:    logicalplan.Col("timestamp").Eq(logicalplan.Literal(int64(30))),
+			match:   false,
+		},
+		"in": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").In(logicalplan.Literal("a"), logicalplan.Literal("d")),
+			match:   true,
+		},
+		"in absent values": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").In(logicalplan.Literal("a"), logicalplan.Literal("c")),
+			match:   false,
+		},
+		"in without values": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").In(),
+			match:   false,
+		},
+		"equal value of different kind": {
+			samples: samples,
+			expr:    logicalplan.Col("timestamp").Eq(logicalplan.Literal(float64(10))),
+			match:   true,
+		},
+		"starts with": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").StartsWith("d"),
+			match:   true,
+		},
+		"starts with out of range": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.namespace").StartsWith("e"),
+			match:   false,
+		},
+		"starts with rows without label": {
+			samples: withoutLabel,
+			expr:    logicalplan.Col("labels.namespace").StartsWith(""),
+			match:   true,
+		},
+		"starts with missing column": {
+			samples: samples,
+			expr:    logicalplan.Col("labels.pod").StartsWith("a"),
+			match:   false,
+		},
+		"conjunction": {
+			samples: samples,
+			expr: logicalplan.And(
+				logicalplan.Col("labels.namespace").GtEq(logicalplan.Literal("a")),
+				logicalplan.Col("timestamp").Lt(logicalplan.Literal(int64(10))),
+			),
+			match: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter, err := booleanExpr(test.expr)
+			require.NoError(t, err)
+			match, err := filter.Eval(rowGroup(t, test.samples))
+			require.NoError(t, err)
+			require.Equal(t, test.match, match)
+		})
+	}
+}
+
+func TestFilterPageRowRanges(t *testing.T) {
+	schema := dynparquet.NewSampleSchema()
+	samples := make(dynparquet.Samples, 0, 100)
+	for i := 0; i < 100; i++ {
+		samples = append(samples, dynparquet.Sample{
+			ExampleType: "test",
+			Labels:      []dynparquet.Label{{Name: "namespace", Value: "default"}},
+			Timestamp:   int64(i),
+			Value:       int64(i),
+		})
+	}
+	buf, err := samples.ToBuffer(schema)
+	require.NoError(t, err)
+
+	// Small pages, so the row group has many pages.
+	b := bytes.NewBuffer(nil)
+	w, err := schema.NewWriter(b, buf.DynamicColumns(), parquet.PageBufferSize(64))
+	require.NoError(t, err)
+	_, err = parquet.CopyRows(w, buf.Rows())
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	serBuf, err := dynparquet.ReaderFromBytes(b.Bytes())
+	require.NoError(t, err)
+	rg := serBuf.DynamicRowGroup(0)
+
+	tests := map[string]struct {
+		expr logicalplan.Expr
+		rows int64
+	}{
+		"range": {
+			expr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(int64(90))),
+			rows: 10,
+		},
+		"between": {
+			expr: logicalplan.Col("timestamp").Between(logicalplan.Literal(int64(10)), logicalplan.Literal(int64(19))),
+			rows: 10,
+		},
+		"conjunction": {
+			expr: logicalplan.And(
+				logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(20))),
+				logicalplan.Col("value").Lt(logicalplan.Literal(int64(30))),
+			),
+			rows: 9,
+		},
+		"disjunction": {
+			expr: logicalplan.Or(
+				logicalplan.Col("timestamp").Lt(logicalplan.Literal(int64(5))),
+				logicalplan.Col("value").GtEq(logicalplan.Literal(int64(95))),
+			),
+			rows: 10,
+		},
+		"all rows": {
+			expr: logicalplan.Col("labels.namespace").Eq(logicalplan.Literal("default")),
+			rows: 100,
+		},
+	}
+
+	pool := memory.NewGoAllocator()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter, err := booleanExpr(test.expr)
+			require.NoError(t, err)
+			pruned, err := pruneRowGroup(filter, rg)
+			require.NoError(t, err)
+			require.NotNil(t, pruned)
+			if test.rows == rg.NumRows() {
+				require.Equal(t, rg, pruned)
+			} else {
+				// Only the pages that may contain matching rows are read.
+				require.Less(t, pruned.NumRows(), rg.NumRows())
+			}
+
+			as, err := pqarrow.ParquetRowGroupToArrowSchema(context.Background(), schema, pruned, nil, nil, nil, nil)
+			require.NoError(t, err)
+			r, err := pqarrow.ParquetRowGroupToArrowRecord(context.Background(), pool, pruned, as, test.expr, nil)
+			require.NoError(t, err)
+			defer r.Release()
+			require.Equal(t, pruned.NumRows(), r.NumRows())
+
+			f, err := physicalplan.Filter(pool, test.expr)
+			require.NoError(t, err)
+			rows := int64(0)
+			f.SetNextCallback(func(r arrow.Record) error {
+				rows += r.NumRows()
+				return nil
+			})
+			require.NoError(t, f.Callback(r))
+			require.Equal(t, test.rows, rows)
+		})
+	}
+
+	filter, err := booleanExpr(logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(100))))
+	require.NoError(t, err)
+	pruned, err := pruneRowGroup(filter, rg)
+	require.NoError(t, err)
+	require.Nil(t, pruned)
+}
+
+func TestFilterPageRowRangesStartsWith(t *testing.T) {
+	schema := dynparquet.NewSampleSchema()
+	samples := make(dynparquet.Samples, 0, 100)
+	for i := 0; i < 100; i++ {
+		samples = append(samples, dynparquet.Sample{
+			ExampleType: "test",
+			Labels:      []dynparquet.Label{{Name: "namespace", Value: fmt.Sprintf("namespace-%02d", i)}},
+			Timestamp:   int64(i),
+			Value:       int64(i),
+		})
+	}
+	buf, err := samples.ToBuffer(schema)
+	require.NoError(t, err)
+
+	// Small pages, so the row group has many pages.
+	b := bytes.NewBuffer(nil)
+	w, err := schema.NewWriter(b, buf.DynamicColumns(), parquet.PageBufferSize(64))
+	require.NoError(t, err)
+	_, err = parquet.CopyRows(w, buf.Rows())
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	serBuf, err := dynparquet.ReaderFromBytes(b.Bytes())
+	require.NoError(t, err)
+	rg := serBuf.DynamicRowGroup(0)
+
+	// Only the pages that may contain values with the prefix are read.
+	filter, err := booleanExpr(logicalplan.Col("labels.namespace").StartsWith("namespace-9"))
+	require.NoError(t, err)
+	pruned, err := pruneRowGroup(filter, rg)
+	require.NoError(t, err)
+	require.NotNil(t, pruned)
+	require.Less(t, pruned.NumRows(), rg.NumRows())
+	require.GreaterOrEqual(t, pruned.NumRows(), int64(10))
+
+	filter, err = booleanExpr(logicalplan.Col("labels.namespace").StartsWith("other"))
+	require.NoError(t, err)
+	pruned, err = pruneRowGroup(filter, rg)
+	require.NoError(t, err)
+	require.Nil(t, pruned)
+}
+
+func TestPrefixMayMatch(t *testing.T) {
+	require.True(t, prefixMayMatch([]byte("abc"), []byte("abz"), []byte("abd")))
+	require.True(t, prefixMayMatch([]byte("abc"), []byte("abz"), []byte("ab")))
+	require.False(t, prefixMayMatch([]byte("abc"), []byte("abz"), []byte("ac")))
+	require.False(t, prefixMayMatch([]byte("abc"), []byte("abz"), []byte("aa")))
+	// The max is truncated, so it may be less than the values with the
+	// prefix.
+	require.True(t, prefixMayMatch([]byte("abc"), []byte("ab"), []byte("abd")))
+}
+
+func TestRowRangesSetOperations(t *testing.T) {
+	a := []dynparquet.RowRange{{Start: 0, End: 10}, {Start: 20, End: 30}}
+	b := []dynparquet.RowRange{{Start: 5, End: 25}, {Start: 30, End: 40}}
+
+	require.Equal(t, []dynparquet.RowRange{{Start: 5, End: 10}, {Start: 20, End: 25}}, intersectRowRanges(a, b))
+	require.Equal(t, []dynparquet.RowRange{{Start: 0, End: 40}}, unionRowRanges(a, b))
+	require.Nil(t, intersectRowRanges(a, nil))
+	require.Equal(t, a, unionRowRanges(a, nil))
+}
+
+// newFloat64TestEngine returns an engine of the table "test" with a double
+// column "ratio" and a nullable double column "opt". The rows are inserted
+// in two buffers and the granules hold two rows, so filters on the columns
+// prune granules by their statistics.
+func newFloat64TestEngine(t *testing.T) *query.LocalEngine {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "ratio",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_DOUBLE,
+			},
+		}, {
+			Name: "opt",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_DOUBLE,
+				Nullable: true,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "ratio",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// The columns of rows are labels.job, opt and ratio.
+	for _, rows := range [][]parquet.Row{{
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(nil).Level(0, 0, 1), parquet.ValueOf(1.5).Level(0, 0, 2)},
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(0.5).Level(0, 1, 1), parquet.ValueOf(2.5).Level(0, 0, 2)},
+	}, {
+		{parquet.ValueOf("web").Level(0, 1, 0), parquet.ValueOf(1.0).Level(0, 1, 1), parquet.ValueOf(-1.25).Level(0, 0, 2)},
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(nil).Level(0, 0, 1), parquet.ValueOf(4.0).Level(0, 0, 2)},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	return query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+}
+
+func TestFilterFloat64(t *testing.T) {
+	engine := newFloat64TestEngine(t)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		ratios     []float64
+	}{
+		">= float": {
+			filterExpr: logicalplan.Col("ratio").GtEq(logicalplan.Literal(1.5)),
+			ratios:     []float64{1.5, 2.5, 4},
+		},
+		"< float": {
+			filterExpr: logicalplan.Col("ratio").Lt(logicalplan.Literal(0.0)),
+			ratios:     []float64{-1.25},
+		},
+		"< int": {
+			filterExpr: logicalplan.Col("ratio").Lt(logicalplan.Literal(int64(2))),
+			ratios:     []float64{-1.25, 1.5},
+		},
+		"== float": {
+			filterExpr: logicalplan.Col("ratio").Eq(logicalplan.Literal(2.5)),
+			ratios:     []float64{2.5},
+		},
+		"== int": {
+			filterExpr: logicalplan.Col("ratio").Eq(logicalplan.Literal(int64(4))),
+			ratios:     []float64{4},
+		},
+		"!= float": {
+			filterExpr: logicalplan.Col("ratio").NotEq(logicalplan.Literal(2.5)),
+			ratios:     []float64{-1.25, 1.5, 4},
+		},
+		"range": {
+			filterExpr: logicalplan.And(
+				logicalplan.Col("ratio").Gt(logicalplan.Literal(int64(1))),
+				logicalplan.Col("ratio").LtEq(logicalplan.Literal(2.5)),
+			),
+			ratios: []float64{1.5, 2.5},
+		},
+		"nullable": {
+			filterExpr: logicalplan.Col("opt").GtEq(logicalplan.Literal(0.5)),
+			ratios:     []float64{-1.25, 2.5},
+		},
+		"no match": {
+			filterExpr: logicalplan.Col("ratio").Gt(logicalplan.Literal(4.0)),
+			ratios:     []float64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ratios := []float64{}
+			err := engine.ScanTable("test").
+				Filter(test.filterExpr).
+				Project(logicalplan.Col("ratio")).
+				Execute(context.Background(), func(ar arrow.Record) error {
+					arr := ar.Column(0).(*array.Float64)
+					for i := 0; i < arr.Len(); i++ {
+						ratios = append(ratios, arr.Value(i))
+					}
+					return nil
+				})
+			require.NoError(t, err)
+			sort.Float64s(ratios)
+			require.Equal(t, test.ratios, ratios)
+		})
+	}
+}
+
+func TestFilterBoolean(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "error",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_BOOL,
+			},
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "error",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// The columns of rows are error, labels.job and value.
+	for _, rows := range [][]parquet.Row{{
+		{parquet.ValueOf(false).Level(0, 0, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(1).Level(0, 0, 2)},
+		{parquet.ValueOf(false).Level(0, 0, 0), parquet.ValueOf("web").Level(0, 1, 1), parquet.ValueOf(2).Level(0, 0, 2)},
+	}, {
+		{parquet.ValueOf(true).Level(0, 0, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(3).Level(0, 0, 2)},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	values := func(filterExpr logicalplan.Expr) []int64 {
+		values := []int64{}
+		err := engine.ScanTable("test").
+			Filter(filterExpr).
+			Project(logicalplan.Col("value")).
+			Execute(context.Background(), func(ar arrow.Record) error {
+				arr := ar.Column(0).(*array.Int64)
+				for i := 0; i < arr.Len(); i++ {
+					values = append(values, arr.Value(i))
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		return values
+	}
+
+	require.Equal(t, []int64{3}, values(logicalplan.Col("error").Eq(logicalplan.Literal(true))))
+	require.Equal(t, []int64{1, 2}, values(logicalplan.Col("error").Eq(logicalplan.Literal(false))))
+	require.Equal(t, []int64{1, 2}, values(logicalplan.Col("error").NotEq(logicalplan.Literal(true))))
+	require.Equal(t, []int64{1}, values(logicalplan.And(
+		logicalplan.Col("error").Eq(logicalplan.Literal(false)),
+		logicalplan.Col("labels.job").Eq(logicalplan.Literal("api")),
+	)))
+
+	_, err = engine.ScanTable("test").
+		Filter(logicalplan.Col("error").Gt(logicalplan.Literal(false))).
+		Prepare()
+	require.Error(t, err)
+
+	// Rows are grouped by their booleans.
+	sums := map[bool]int64{}
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Col("error"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		errors := r.Column(0).(*array.Boolean)
+		aggregated := r.Column(1).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			sums[errors.Value(i)] += aggregated.Value(i)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[bool]int64{false: 3, true: 3}, sums)
+}
+
+func TestFilterTimestamp(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_TIMESTAMP_MICROS,
+			},
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// The columns of rows are labels.job, timestamp and value, the
+	// timestamps are microseconds.
+	for _, rows := range [][]parquet.Row{{
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(3000).Level(0, 0, 1), parquet.ValueOf(3).Level(0, 0, 2)},
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(4500).Level(0, 0, 1), parquet.ValueOf(4).Level(0, 0, 2)},
+	}, {
+		{parquet.ValueOf("web").Level(0, 1, 0), parquet.ValueOf(1000).Level(0, 0, 1), parquet.ValueOf(1).Level(0, 0, 2)},
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(2000).Level(0, 0, 1), parquet.ValueOf(2).Level(0, 0, 2)},
+		{parquet.ValueOf("web").Level(0, 1, 0), parquet.ValueOf(3000).Level(0, 0, 1), parquet.ValueOf(5).Level(0, 0, 2)},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	// The rows are sorted by their timestamps.
+	var timestamps []arrow.Timestamp
+	err = engine.ScanTable("test").
+		Project(logicalplan.Col("timestamp")).
+		Execute(context.Background(), func(ar arrow.Record) error {
+			require.Equal(t, arrow.FixedWidthTypes.Timestamp_us, ar.Schema().Field(0).Type)
+			arr := ar.Column(0).(*array.Timestamp)
+			timestamps = append(timestamps, arr.TimestampValues()...)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []arrow.Timestamp{1000, 2000, 3000, 3000, 4500}, timestamps)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		values     []int64
+	}{
+		">= time": {
+			filterExpr: logicalplan.Col("timestamp").GtEq(logicalplan.Literal(time.UnixMilli(3))),
+			values:     []int64{3, 4, 5},
+		},
+		"< time": {
+			filterExpr: logicalplan.Col("timestamp").Lt(logicalplan.Literal(time.UnixMilli(3))),
+			values:     []int64{1, 2},
+		},
+		"== time": {
+			filterExpr: logicalplan.Col("timestamp").Eq(logicalplan.Literal(time.UnixMilli(2))),
+			values:     []int64{2},
+		},
+		"> time": {
+			filterExpr: logicalplan.Col("timestamp").Gt(logicalplan.Literal(time.UnixMilli(4))),
+			values:     []int64{4},
+		},
+		"between times": {
+			filterExpr: logicalplan.Col("timestamp").Between(
+				logicalplan.Literal(time.UnixMilli(2)),
+				logicalplan.Literal(time.UnixMilli(3)),
+			),
+			values: []int64{2, 3, 5},
+		},
+		"== int": {
+			filterExpr: logicalplan.Col("timestamp").Eq(logicalplan.Literal(int64(4500))),
+			values:     []int64{4},
+		},
+		"no match": {
+			filterExpr: logicalplan.Col("timestamp").Gt(logicalplan.Literal(time.UnixMilli(5))),
+			values:     []int64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := []int64{}
+			err := engine.ScanTable("test").
+				Filter(test.filterExpr).
+				Project(logicalplan.Col("value")).
+				Execute(context.Background(), func(ar arrow.Record) error {
+					arr := ar.Column(0).(*array.Int64)
+					values = append(values, arr.Int64Values()...)
+					return nil
+				})
+			require.NoError(t, err)
+			sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+			require.Equal(t, test.values, values)
+		})
+	}
+
+	// Rows are grouped by their timestamps.
+	sums := map[arrow.Timestamp]int64{}
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Col("timestamp"),
+		).Execute(context.Background(), func(r arrow.Record) error {
+		timestamps := r.Column(0).(*array.Timestamp)
+		aggregated := r.Column(1).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			sums[timestamps.Value(i)] += aggregated.Value(i)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[arrow.Timestamp]int64{1000: 1, 2000: 2, 3000: 8, 4500: 4}, sums)
+}
+
+func newDecimalTestEngine(t *testing.T) *query.LocalEngine {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "price",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:      schemapb.StorageLayout_TYPE_DECIMAL,
+				Precision: 10,
+				Scale:     2,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "price",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// The columns of rows are labels.job and price, the prices are unscaled
+	// so 1234 is 12.34.
+	for _, rows := range [][]parquet.Row{{
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(int64(1234)).Level(0, 0, 1)},
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(int64(500)).Level(0, 0, 1)},
+	}, {
+		{parquet.ValueOf("web").Level(0, 1, 0), parquet.ValueOf(int64(-150)).Level(0, 0, 1)},
+		{parquet.ValueOf("api").Level(0, 1, 0), parquet.ValueOf(int64(2010)).Level(0, 0, 1)},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	return query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+}
+
+func TestFilterDecimal(t *testing.T) {
+	engine := newDecimalTestEngine(t)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		prices     []int64
+	}{
+		"== float": {
+			filterExpr: logicalplan.Col("price").Eq(logicalplan.Literal(12.34)),
+			prices:     []int64{1234},
+		},
+		"== float with more digits than the scale": {
+			filterExpr: logicalplan.Col("price").Eq(logicalplan.Literal(12.345)),
+			prices:     []int64{},
+		},
+		"> float with more digits than the scale": {
+			filterExpr: logicalplan.Col("price").Gt(logicalplan.Literal(12.335)),
+			prices:     []int64{1234, 2010},
+		},
+		"< float with more digits than the scale": {
+			filterExpr: logicalplan.Col("price").Lt(logicalplan.Literal(12.345)),
+			prices:     []int64{-150, 500, 1234},
+		},
+		"!= float": {
+			filterExpr: logicalplan.Col("price").NotEq(logicalplan.Literal(5.0)),
+			prices:     []int64{-150, 1234, 2010},
+		},
+		">= int": {
+			filterExpr: logicalplan.Col("price").GtEq(logicalplan.Literal(int64(5))),
+			prices:     []int64{500, 1234, 2010},
+		},
+		"< int": {
+			filterExpr: logicalplan.Col("price").Lt(logicalplan.Literal(int64(0))),
+			prices:     []int64{-150},
+		},
+		"<= int": {
+			filterExpr: logicalplan.Col("price").LtEq(logicalplan.Literal(int64(20))),
+			prices:     []int64{-150, 500, 1234},
+		},
+		"<= decimal": {
+			filterExpr: logicalplan.Col("price").LtEq(logicalplan.Literal(
+				scalar.NewDecimal128Scalar(decimal128.FromI64(1234), &arrow.Decimal128Type{Precision: 10, Scale: 2}),
+			)),
+			prices: []int64{-150, 500, 1234},
+		},
+		"between floats": {
+			filterExpr: logicalplan.Col("price").Between(
+				logicalplan.Literal(-1.5),
+				logicalplan.Literal(12.34),
+			),
+			prices: []int64{-150, 500, 1234},
+		},
+		"no match": {
+			filterExpr: logicalplan.Col("price").Gt(logicalplan.Literal(20.1)),
+			prices:     []int64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			prices := []int64{}
+			err := engine.ScanTable("test").
+				Filter(test.filterExpr).
+				Project(logicalplan.Col("price")).
+				Execute(context.Background(), func(ar arrow.Record) error {
+					require.Equal(t, &arrow.Decimal128Type{Precision: 10, Scale: 2}, ar.Schema().Field(0).Type)
+					arr := ar.Column(0).(*array.Decimal128)
+					for i := 0; i < arr.Len(); i++ {
+						prices = append(prices, int64(arr.Value(i).LowBits()))
+					}
+					return nil
+				})
+			require.NoError(t, err)
+			sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+			require.Equal(t, test.prices, prices)
+		})
+	}
+}
+
+func TestFilterBinary(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "digest",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_BYTES,
+			},
+		}, {
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "digest",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// The columns of rows are digest, labels.job and value, digests aren't
+	// valid UTF-8.
+	for _, rows := range [][]parquet.Row{{
+		{parquet.ValueOf([]byte{0xff, 0x01}).Level(0, 0, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(1).Level(0, 0, 2)},
+		{parquet.ValueOf([]byte{0x00, 0xfe}).Level(0, 0, 0), parquet.ValueOf("api").Level(0, 1, 1), parquet.ValueOf(2).Level(0, 0, 2)},
+	}, {
+		{parquet.ValueOf([]byte{0x80}).Level(0, 0, 0), parquet.ValueOf("web").Level(0, 1, 1), parquet.ValueOf(3).Level(0, 0, 2)},
+		{parquet.ValueOf([]byte{0xff, 0x01}).Level(0, 0, 0), parquet.ValueOf("web").Level(0, 1, 1), parquet.ValueOf(4).Level(0, 0, 2)},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	// The digests are returned as is, sorted by their bytes.
+	var digests [][]byte
+	err = engine.ScanTable("test").
+		Project(logicalplan.Col("digest")).
+		Execute(context.Background(), func(ar arrow.Record) error {
+			require.Equal(t, &arrow.BinaryType{}, ar.Schema().Field(0).Type)
+			arr := ar.Column(0).(*array.Binary)
+			for i := 0; i < arr.Len(); i++ {
+				digests = append(digests, append([]byte{}, arr.Value(i)...))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x00, 0xfe}, {0x80}, {0xff, 0x01}, {0xff, 0x01}}, digests)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		values     []int64
+	}{
+		"== bytes": {
+			filterExpr: logicalplan.Col("digest").Eq(logicalplan.Literal([]byte{0xff, 0x01})),
+			values:     []int64{1, 4},
+		},
+		"!= bytes": {
+			filterExpr: logicalplan.Col("digest").NotEq(logicalplan.Literal([]byte{0xff, 0x01})),
+			values:     []int64{2, 3},
+		},
+		"== bytes and label": {
+			filterExpr: logicalplan.And(
+				logicalplan.Col("digest").Eq(logicalplan.Literal([]byte{0x80})),
+				logicalplan.Col("labels.job").Eq(logicalplan.Literal("web")),
+			),
+			values: []int64{3},
+		},
+		"no match": {
+			filterExpr: logicalplan.Col("digest").Eq(logicalplan.Literal([]byte{0xff})),
+			values:     []int64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := []int64{}
+			err := engine.ScanTable("test").
+				Filter(test.filterExpr).
+				Project(logicalplan.Col("value")).
+				Execute(context.Background(), func(ar arrow.Record) error {
+					arr := ar.Column(0).(*array.Int64)
+					values = append(values, arr.Int64Values()...)
+					return nil
+				})
+			require.NoError(t, err)
+			sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+			require.Equal(t, test.values, values)
+		})
+	}
+}
+
+func TestFilterStructField(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "payload",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRUCT,
+				Nullable: true,
+				Fields: []*schemapb.Column{{
+					Name:          "code",
+					StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+				}, {
+					Name: "status",
+					StorageLayout: &schemapb.StorageLayout{
+						Type:     schemapb.StorageLayout_TYPE_STRING,
+						Nullable: true,
+					},
+				}},
+			},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	row := func(code int64, status string, timestamp int64) parquet.Row {
+		return parquet.Row{
+			parquet.ValueOf(code).Level(0, 1, 0),
+			parquet.ValueOf(status).Level(0, 2, 1),
+			parquet.ValueOf(timestamp).Level(0, 0, 2),
+		}
+	}
+	buf, err := schema.NewBuffer(nil)
+	require.NoError(t, err)
+	_, err = buf.WriteRows([]parquet.Row{row(200, "ok", 1), row(404, "not found", 2)})
+	require.NoError(t, err)
+	b, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+	serialized, err := dynparquet.ReaderFromBytes(b)
+	require.NoError(t, err)
+	rg := serialized.DynamicRowGroup(0)
+
+	// The statistics of the leaf columns of the fields rule out row groups.
+	tests := map[string]struct {
+		expr  logicalplan.Expr
+		match bool
+	}{
+		"equal": {
+			expr:  logicalplan.Col("payload").Field("code").Eq(logicalplan.Literal(int64(404))),
+			match: true,
+		},
+		"greater than max": {
+			expr:  logicalplan.Col("payload").Field("code").Gt(logicalplan.Literal(int64(404))),
+			match: false,
+		},
+		"greater than max string": {
+			expr:  logicalplan.Field("payload", "status").Gt(logicalplan.Literal("ok")),
+			match: false,
+		},
+		"between": {
+			expr:  logicalplan.Field("payload", "code").Between(logicalplan.Literal(int64(300)), logicalplan.Literal(int64(400))),
+			match: true,
+		},
+		"missing field": {
+			expr:  logicalplan.Col("payload").Field("method").Eq(logicalplan.Literal(int64(1))),
+			match: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter, err := booleanExpr(test.expr)
+			require.NoError(t, err)
+			match, err := filter.Eval(rg)
+			require.NoError(t, err)
+			require.Equal(t, test.match, match)
+		})
+	}
 }
@@ -0,0 +1,258 @@
+// Package flight serves the results of frostdb queries over Arrow Flight, so
+// any Flight client can query frostdb. Tickets are serialized protobuf logical
+// plans, see NewTicket, and the records of their results are streamed back as
+// record batches. Flight SQL clients can list the tables of a server and
+// execute SQL queries on them, see WithCatalog and WithSQLParser.
+//
+// A Server is registered with an Arrow Flight server:
+//
+//	s := arrowflight.NewServerWithMiddleware(nil)
+//	s.RegisterFlightService(flight.NewServer(engine))
+//	if err := s.Init("localhost:8815"); err != nil {
+//		return err
+//	}
+//	return s.Serve()
+package flight
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	arrowflight "github.com/apache/arrow/go/v8/arrow/flight"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	logicalplanpb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/logicalplan/v1alpha1"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Server is a Flight service that executes the logical plans of tickets on
+// the tables of an engine and streams their results.
+type Server struct {
+	arrowflight.BaseFlightServer
+
+	engine  *query.LocalEngine
+	pool    memory.Allocator
+	catalog Catalog
+	parse   SQLParser
+}
+
+type Option func(*Server)
+
+// WithAllocator sets the allocator the records that are conformed to the
+// schema of a stream are allocated with.
+func WithAllocator(pool memory.Allocator) Option {
+	return func(s *Server) {
+		s.pool = pool
+	}
+}
+
+func NewServer(engine *query.LocalEngine, options ...Option) *Server {
+	s := &Server{
+		engine: engine,
+		pool:   memory.NewGoAllocator(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// NewTicket returns the ticket of a logical plan, which a Server executes.
+func NewTicket(plan *logicalplan.LogicalPlan) (*arrowflight.Ticket, error) {
+	node, err := logicalplan.ToProto(plan)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	return &arrowflight.Ticket{Ticket: data}, nil
+}
+
+// DoGet executes the logical plan of the ticket and streams the records of
+// its results. A stream has a single schema, the union of the schemas of all
+// records, so the records are only streamed once the query finished. Records
+// with a different schema, such as those missing dynamic columns, are
+// conformed to it. Tickets of Flight SQL commands are handled like
+// described by WithCatalog and WithSQLParser.
+func (s *Server) DoGet(ticket *arrowflight.Ticket, stream arrowflight.FlightService_DoGetServer) error {
+	if cmd, ok, err := sqlCommand(ticket.GetTicket()); ok {
+		if err != nil {
+			return err
+		}
+		return s.doGetSQL(cmd, stream)
+	}
+	return s.doGetPlan(ticket.GetTicket(), stream)
+}
+
+// doGetPlan streams the results of the serialized logical plan.
+func (s *Server) doGetPlan(plan []byte, stream arrowflight.FlightService_DoGetServer) error {
+	node := &logicalplanpb.PlanNode{}
+	if err := proto.Unmarshal(plan, node); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unmarshal ticket: %v", err)
+	}
+	q, err := s.engine.PrepareProto(node)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "prepare query: %v", err)
+	}
+
+	records := []arrow.Record{}
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+	err = q.Execute(stream.Context(), nil, func(r arrow.Record) error {
+		r.Retain()
+		records = append(records, r)
+		return nil
+	})
+	if err == nil && len(records) > 0 {
+		err = s.writeRecords(stream, records)
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "execute query: %v", err)
+	}
+	return nil
+}
+
+// writeRecords streams the records with the union of their schemas.
+func (s *Server) writeRecords(stream arrowflight.FlightService_DoGetServer, records []arrow.Record) error {
+	schemas := make([]*arrow.Schema, 0, len(records))
+	for _, r := range records {
+		schemas = append(schemas, r.Schema())
+	}
+	schema, err := unionSchema(schemas)
+	if err != nil {
+		return err
+	}
+
+	w := arrowflight.NewRecordWriter(stream, ipc.WithSchema(schema), ipc.WithAllocator(s.pool))
+	for _, r := range records {
+		r, err := conform(s.pool, schema, r)
+		if err != nil {
+			_ = w.Close()
+			return err
+		}
+		err = w.Write(r)
+		r.Release()
+		if err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// unionSchema returns the schema with the fields of all schemas, and the
+// metadata of the first. Fields keep their order, a field that's missing
+// from the schemas before is placed after the fields it follows and the
+// smaller fields of the other schemas, so sorted dynamic columns stay sorted.
+// Fields that aren't in all schemas are nullable.
+func unionSchema(schemas []*arrow.Schema) (*arrow.Schema, error) {
+	fields := []arrow.Field{}
+	// counts are the numbers of schemas that have each field.
+	counts := map[string]int{}
+	for _, schema := range schemas {
+		pos := 0
+		for _, field := range schema.Fields() {
+			i := fieldIndex(fields, field.Name)
+			if i >= 0 {
+				if !arrow.TypeEqual(fields[i].Type, field.Type) {
+					return nil, fmt.Errorf("column %q is %s and %s", field.Name, fields[i].Type, field.Type)
+				}
+				counts[field.Name]++
+				pos = i + 1
+				continue
+			}
+
+			// Fields of other schemas that sort before the field are
+			// skipped.
+			for pos < len(fields) && fields[pos].Name < field.Name && !schema.HasField(fields[pos].Name) {
+				pos++
+			}
+			fields = append(fields, arrow.Field{})
+			copy(fields[pos+1:], fields[pos:])
+			fields[pos] = field
+			counts[field.Name]++
+			pos++
+		}
+	}
+
+	for i, field := range fields {
+		if counts[field.Name] < len(schemas) {
+			fields[i].Nullable = true
+		}
+	}
+	metadata := schemas[0].Metadata()
+	return arrow.NewSchema(fields, &metadata), nil
+}
+
+func fieldIndex(fields []arrow.Field, name string) int {
+	for i, field := range fields {
+		if field.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// conform returns the record with the columns of the schema, in its order.
+// Columns that the record doesn't have are null. The returned record must be
+// released.
+func conform(pool memory.Allocator, schema *arrow.Schema, r arrow.Record) (arrow.Record, error) {
+	if schema.Equal(r.Schema()) {
+		r.Retain()
+		return r, nil
+	}
+
+	for _, field := range r.Schema().Fields() {
+		if !schema.HasField(field.Name) {
+			return nil, fmt.Errorf("column %q isn't in the schema of the stream", field.Name)
+		}
+	}
+
+	cols := make([]arrow.Array, 0, len(schema.Fields()))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	for _, field := range schema.Fields() {
+		indices := r.Schema().FieldIndices(field.Name)
+		if len(indices) == 0 {
+			cols = append(cols, nulls(pool, field.Type, int(r.NumRows())))
+			continue
+		}
+
+		col := r.Column(indices[0])
+		if !arrow.TypeEqual(col.DataType(), field.Type) {
+			return nil, fmt.Errorf("column %q is %s instead of %s", field.Name, col.DataType(), field.Type)
+		}
+		col.Retain()
+		cols = append(cols, col)
+	}
+
+	return array.NewRecord(schema, cols, r.NumRows()), nil
+}
+
+// nulls returns an array of n nulls of the type.
+func nulls(pool memory.Allocator, t arrow.DataType, n int) arrow.Array {
+	b := array.NewBuilder(pool, t)
+	defer b.Release()
+	b.Reserve(n)
+	for i := 0; i < n; i++ {
+		b.AppendNull()
+	}
+	return b.NewArray()
+}
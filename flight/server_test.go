@@ -0,0 +1,216 @@
+package flight
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	arrowflight "github.com/apache/arrow/go/v8/arrow/flight"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// newTestServer serves the test samples of the table "test" of a database,
+// with the options of the database if any, and returns a client of the server.
+func newTestServer(t *testing.T, options func(db *frostdb.DB) []Option) (arrowflight.Client, *frostdb.DB, dynparquet.Samples) {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+	var serverOptions []Option
+	if options != nil {
+		serverOptions = options(db)
+	}
+	s := arrowflight.NewServerWithMiddleware(nil)
+	s.RegisterFlightService(NewServer(engine, serverOptions...))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s.InitListener(lis)
+	go func() {
+		_ = s.Serve()
+	}()
+	t.Cleanup(s.Shutdown)
+
+	client, err := arrowflight.NewClientWithMiddleware(s.Addr().String(), nil, nil, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return client, db, samples
+}
+
+func TestServer(t *testing.T) {
+	client, db, samples := newTestServer(t, nil)
+
+	plan, err := (&logicalplan.Builder{}).
+		Scan(db.TableProvider(), "test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.namespace")).
+		OrderBy(logicalplan.Asc(logicalplan.Col("labels.namespace"))).
+		Build()
+	require.NoError(t, err)
+	ticket, err := NewTicket(plan)
+	require.NoError(t, err)
+
+	stream, err := client.DoGet(context.Background(), ticket)
+	require.NoError(t, err)
+	reader, err := arrowflight.NewRecordReader(stream)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	sums := map[string]int64{}
+	for reader.Next() {
+		r := reader.Record()
+		namespaces := r.Column(r.Schema().FieldIndices("labels.namespace")[0]).(*array.Binary)
+		values := r.Column(r.Schema().FieldIndices("sum(value)")[0]).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			sums[string(namespaces.Value(i))] = values.Value(i)
+		}
+	}
+	require.NoError(t, reader.Err())
+
+	want := map[string]int64{}
+	for _, sample := range samples {
+		// Samples without a namespace are grouped as null.
+		namespace := ""
+		for _, label := range sample.Labels {
+			if label.Name == "namespace" {
+				namespace = label.Value
+			}
+		}
+		want[namespace] += sample.Value
+	}
+	require.Equal(t, want, sums)
+
+	// Tickets that aren't logical plans are rejected.
+	stream, err = client.DoGet(context.Background(), &arrowflight.Ticket{Ticket: []byte("not a plan")})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestConform(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "b", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	}, nil)
+
+	b := array.NewInt64Builder(pool)
+	b.AppendValues([]int64{1, 2}, nil)
+	a := b.NewArray()
+	b.Release()
+	r := array.NewRecord(arrow.NewSchema(schema.Fields()[:1], nil), []arrow.Array{a}, 2)
+	a.Release()
+	defer r.Release()
+
+	conformed, err := conform(pool, schema, r)
+	require.NoError(t, err)
+	defer conformed.Release()
+	require.True(t, schema.Equal(conformed.Schema()))
+	require.Equal(t, []int64{1, 2}, conformed.Column(0).(*array.Int64).Int64Values())
+	require.Equal(t, 2, conformed.Column(1).NullN())
+
+	// Columns that aren't in the schema can't be streamed.
+	_, err = conform(pool, arrow.NewSchema(schema.Fields()[1:], nil), r)
+	require.Error(t, err)
+}
+
+func TestServerLaterColumn(t *testing.T) {
+	client, db, _ := newTestServer(t, nil)
+
+	// The zone label only exists in the granule of the later insert.
+	table, err := db.Table("test", nil)
+	require.NoError(t, err)
+	later := dynparquet.Samples{{
+		Labels:     []dynparquet.Label{{Name: "zone", Value: "eu"}},
+		Stacktrace: []uuid.UUID{{0x1}},
+		Timestamp:  10,
+		Value:      10,
+	}}
+	buf, err := later.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	plan, err := (&logicalplan.Builder{}).
+		Scan(db.TableProvider(), "test").
+		Project(logicalplan.DynCol("labels"), logicalplan.Col("value")).
+		Build()
+	require.NoError(t, err)
+	ticket, err := NewTicket(plan)
+	require.NoError(t, err)
+
+	stream, err := client.DoGet(context.Background(), ticket)
+	require.NoError(t, err)
+	reader, err := arrowflight.NewRecordReader(stream)
+	require.NoError(t, err)
+	defer reader.Release()
+	require.True(t, reader.Schema().HasField("labels.zone"))
+
+	zones := map[int64]string{}
+	for reader.Next() {
+		r := reader.Record()
+		col := r.Column(r.Schema().FieldIndices("labels.zone")[0]).(*array.Binary)
+		values := r.Column(r.Schema().FieldIndices("value")[0]).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			if !col.IsNull(i) {
+				zones[values.Value(i)] = string(col.Value(i))
+			}
+		}
+	}
+	require.NoError(t, reader.Err())
+	require.Equal(t, map[int64]string{10: "eu"}, zones)
+}
+
+func TestUnionSchema(t *testing.T) {
+	field := func(name string) arrow.Field {
+		return arrow.Field{Name: name, Type: arrow.BinaryTypes.Binary}
+	}
+	schema, err := unionSchema([]*arrow.Schema{
+		arrow.NewSchema([]arrow.Field{field("labels.a"), field("labels.c"), field("value")}, nil),
+		arrow.NewSchema([]arrow.Field{field("labels.b"), field("labels.c"), field("labels.d"), field("value")}, nil),
+	})
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, f := range schema.Fields() {
+		names = append(names, f.Name)
+		// Only the columns of all schemas aren't nullable.
+		require.Equal(t, f.Name != "labels.c" && f.Name != "value", f.Nullable)
+	}
+	require.Equal(t, []string{"labels.a", "labels.b", "labels.c", "labels.d", "value"}, names)
+
+	// Columns of different types can't be streamed together.
+	_, err = unionSchema([]*arrow.Schema{
+		arrow.NewSchema([]arrow.Field{field("value")}, nil),
+		arrow.NewSchema([]arrow.Field{{Name: "value", Type: arrow.PrimitiveTypes.Int64}}, nil),
+	})
+	require.Error(t, err)
+}
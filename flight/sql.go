@@ -0,0 +1,294 @@
+package flight
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	arrowflight "github.com/apache/arrow/go/v8/arrow/flight"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	flightsqlpb "github.com/polarsignals/frostdb/gen/proto/go/arrow/flight/protocol/sql"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// sqlTypeURLPrefix is the prefix of the type URLs of the Flight SQL commands.
+const sqlTypeURLPrefix = "type.googleapis.com/arrow.flight.protocol.sql."
+
+// tableType is the type of all tables in Flight SQL metadata.
+const tableType = "TABLE"
+
+// Catalog lists the tables of a database for the metadata commands of Flight
+// SQL clients. DBTableProvider is a Catalog.
+type Catalog interface {
+	logicalplan.TableProvider
+	TableNames() []string
+}
+
+// WithCatalog makes the server answer the Flight SQL commands that list the
+// tables of the catalog and their types. Tables don't have a catalog or a db
+// schema, and are all of type TABLE. The schemas of tables only contain the
+// dynamic columns that were written to them.
+func WithCatalog(catalog Catalog) Option {
+	return func(s *Server) {
+		s.catalog = catalog
+	}
+}
+
+// SQLParser parses a SQL query into a logical plan.
+type SQLParser func(query string) (*logicalplan.LogicalPlan, error)
+
+// WithSQLParser makes the server execute the SQL queries of Flight SQL
+// statements, which it parses with the parser. The flight infos of
+// statements don't contain the schemas of their results, which depend on
+// the rows that are read, clients read them from the streams of results.
+// sqlparse.Parse parses the SQL subset frostdb supports.
+func WithSQLParser(parse SQLParser) Option {
+	return func(s *Server) {
+		s.parse = parse
+	}
+}
+
+var (
+	catalogsSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	dbSchemasSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "db_schema_name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	tablesSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "db_schema_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "table_name", Type: arrow.BinaryTypes.String},
+		{Name: "table_type", Type: arrow.BinaryTypes.String},
+	}, nil)
+	tablesWithSchemaSchema = arrow.NewSchema(append(tablesSchema.Fields(),
+		arrow.Field{Name: "table_schema", Type: arrow.BinaryTypes.Binary},
+	), nil)
+	tableTypesSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "table_type", Type: arrow.BinaryTypes.String},
+	}, nil)
+)
+
+// sqlCommand returns the Flight SQL command of a descriptor or ticket, and
+// whether it is one at all. Other tickets are logical plans.
+func sqlCommand(data []byte) (proto.Message, bool, error) {
+	cmd := &anypb.Any{}
+	if err := proto.Unmarshal(data, cmd); err != nil || !strings.HasPrefix(cmd.GetTypeUrl(), sqlTypeURLPrefix) {
+		return nil, false, nil
+	}
+	msg, err := cmd.UnmarshalNew()
+	if err != nil {
+		return nil, true, status.Errorf(codes.InvalidArgument, "unsupported Flight SQL command %s: %v", cmd.GetTypeUrl(), err)
+	}
+	return msg, true, nil
+}
+
+// GetFlightInfo returns the flight info of a Flight SQL command, whose
+// results are streamed by DoGet.
+func (s *Server) GetFlightInfo(ctx context.Context, desc *arrowflight.FlightDescriptor) (*arrowflight.FlightInfo, error) {
+	cmd, ok, err := sqlCommand(desc.GetCmd())
+	if err != nil {
+		return nil, err
+	}
+	if desc.GetType() != arrowflight.DescriptorCMD || !ok {
+		return nil, status.Error(codes.InvalidArgument, "descriptor isn't a Flight SQL command")
+	}
+
+	var schema *arrow.Schema
+	ticket := desc.GetCmd()
+	switch cmd := cmd.(type) {
+	case *flightsqlpb.CommandStatementQuery:
+		if s.parse == nil {
+			return nil, status.Error(codes.Unimplemented, "SQL queries aren't supported")
+		}
+		plan, err := s.parse(cmd.GetQuery())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parse query: %v", err)
+		}
+		t, err := NewTicket(plan)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "serialize query: %v", err)
+		}
+		ticket, err = marshalAny(&flightsqlpb.TicketStatementQuery{StatementHandle: t.GetTicket()})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "serialize ticket: %v", err)
+		}
+	case *flightsqlpb.CommandGetCatalogs:
+		schema = catalogsSchema
+	case *flightsqlpb.CommandGetDbSchemas:
+		schema = dbSchemasSchema
+	case *flightsqlpb.CommandGetTables:
+		if s.catalog == nil {
+			return nil, status.Error(codes.Unimplemented, "tables can't be listed")
+		}
+		schema = tablesSchema
+		if cmd.GetIncludeSchema() {
+			schema = tablesWithSchemaSchema
+		}
+	case *flightsqlpb.CommandGetTableTypes:
+		schema = tableTypesSchema
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "unsupported Flight SQL command %T", cmd)
+	}
+
+	info := &arrowflight.FlightInfo{
+		FlightDescriptor: desc,
+		Endpoint:         []*arrowflight.FlightEndpoint{{Ticket: &arrowflight.Ticket{Ticket: ticket}}},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}
+	if schema != nil {
+		info.Schema = arrowflight.SerializeSchema(schema, s.pool)
+	}
+	return info, nil
+}
+
+// doGetSQL streams the results of a Flight SQL command.
+func (s *Server) doGetSQL(cmd proto.Message, stream arrowflight.FlightService_DoGetServer) error {
+	var (
+		r   arrow.Record
+		err error
+	)
+	switch cmd := cmd.(type) {
+	case *flightsqlpb.TicketStatementQuery:
+		return s.doGetPlan(cmd.GetStatementHandle(), stream)
+	case *flightsqlpb.CommandGetCatalogs:
+		r = s.stringRecord(catalogsSchema)
+	case *flightsqlpb.CommandGetDbSchemas:
+		r = s.stringRecord(dbSchemasSchema)
+	case *flightsqlpb.CommandGetTables:
+		r, err = s.tables(stream.Context(), cmd)
+	case *flightsqlpb.CommandGetTableTypes:
+		r = s.stringRecord(tableTypesSchema, tableType)
+	default:
+		return status.Errorf(codes.Unimplemented, "unsupported Flight SQL command %T", cmd)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+
+	w := arrowflight.NewRecordWriter(stream, ipc.WithSchema(r.Schema()), ipc.WithAllocator(s.pool))
+	if err := w.Write(r); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// stringRecord returns a record of the schema, whose only column has the
+// values. Other columns are null.
+func (s *Server) stringRecord(schema *arrow.Schema, values ...string) arrow.Record {
+	b := array.NewRecordBuilder(s.pool, schema)
+	defer b.Release()
+	for i := range schema.Fields() {
+		col := b.Field(i).(*array.StringBuilder)
+		if i == len(schema.Fields())-1 {
+			col.AppendValues(values, nil)
+			continue
+		}
+		for range values {
+			col.AppendNull()
+		}
+	}
+	return b.NewRecord()
+}
+
+// tables returns the record of the tables of the catalog that match the
+// filters of the command.
+func (s *Server) tables(ctx context.Context, cmd *flightsqlpb.CommandGetTables) (arrow.Record, error) {
+	if s.catalog == nil {
+		return nil, status.Error(codes.Unimplemented, "tables can't be listed")
+	}
+
+	schema := tablesSchema
+	if cmd.GetIncludeSchema() {
+		schema = tablesWithSchemaSchema
+	}
+	b := array.NewRecordBuilder(s.pool, schema)
+	defer b.Release()
+
+	// Tables have neither a catalog nor a db schema, so they only match
+	// filters that match empty names.
+	if cmd.GetCatalog() != "" ||
+		(cmd.DbSchemaFilterPattern != nil && !like(cmd.GetDbSchemaFilterPattern()).MatchString("")) ||
+		(len(cmd.GetTableTypes()) > 0 && !contains(cmd.GetTableTypes(), tableType)) {
+		return b.NewRecord(), nil
+	}
+
+	var tableName *regexp.Regexp
+	if cmd.TableNameFilterPattern != nil {
+		tableName = like(cmd.GetTableNameFilterPattern())
+	}
+	for _, name := range s.catalog.TableNames() {
+		if tableName != nil && !tableName.MatchString(name) {
+			continue
+		}
+
+		var tableSchema *arrow.Schema
+		if cmd.GetIncludeSchema() {
+			table := s.catalog.GetTable(name)
+			if table == nil {
+				continue
+			}
+			err := table.View(func(tx uint64) error {
+				var err error
+				tableSchema, err = table.ArrowSchema(ctx, tx, s.pool, nil, nil, nil, nil)
+				return err
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "read schema of table %s: %v", name, err)
+			}
+			b.Field(4).(*array.BinaryBuilder).Append(arrowflight.SerializeSchema(tableSchema, s.pool))
+		}
+
+		b.Field(0).AppendNull()
+		b.Field(1).AppendNull()
+		b.Field(2).(*array.StringBuilder).Append(name)
+		b.Field(3).(*array.StringBuilder).Append(tableType)
+	}
+	return b.NewRecord(), nil
+}
+
+// like returns the regular expression of a SQL LIKE pattern, in which %
+// matches any number of characters and _ matches any single character.
+func like(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func marshalAny(msg proto.Message) ([]byte, error) {
+	cmd, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(cmd)
+}
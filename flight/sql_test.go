@@ -0,0 +1,124 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	arrowflight "github.com/apache/arrow/go/v8/arrow/flight"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/polarsignals/frostdb"
+	flightsqlpb "github.com/polarsignals/frostdb/gen/proto/go/arrow/flight/protocol/sql"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// doGetSQL executes the Flight SQL command like Flight SQL clients do, and
+// returns the schema of its flight info and the records of its results.
+func doGetSQL(t *testing.T, client arrowflight.Client, cmd proto.Message) (*arrow.Schema, []arrow.Record, error) {
+	data, err := marshalAny(cmd)
+	require.NoError(t, err)
+	info, err := client.GetFlightInfo(context.Background(), &arrowflight.FlightDescriptor{
+		Type: arrowflight.DescriptorCMD,
+		Cmd:  data,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var schema *arrow.Schema
+	if len(info.GetSchema()) > 0 {
+		schema, err = arrowflight.DeserializeSchema(info.GetSchema(), memory.DefaultAllocator)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, info.GetEndpoint(), 1)
+	stream, err := client.DoGet(context.Background(), info.GetEndpoint()[0].GetTicket())
+	require.NoError(t, err)
+	reader, err := arrowflight.NewRecordReader(stream)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer reader.Release()
+
+	records := []arrow.Record{}
+	for reader.Next() {
+		r := reader.Record()
+		r.Retain()
+		records = append(records, r)
+	}
+	return schema, records, reader.Err()
+}
+
+func TestServerSQLMetadata(t *testing.T) {
+	client, _, _ := newTestServer(t, func(db *frostdb.DB) []Option {
+		return []Option{WithCatalog(db.TableProvider())}
+	})
+
+	schema, records, err := doGetSQL(t, client, &flightsqlpb.CommandGetTableTypes{})
+	require.NoError(t, err)
+	require.True(t, tableTypesSchema.Equal(schema))
+	require.Len(t, records, 1)
+	require.Equal(t, tableType, records[0].Column(0).(*array.String).Value(0))
+
+	schema, records, err = doGetSQL(t, client, &flightsqlpb.CommandGetCatalogs{})
+	require.NoError(t, err)
+	require.True(t, catalogsSchema.Equal(schema))
+	require.Len(t, records, 1)
+	require.Equal(t, int64(0), records[0].NumRows())
+
+	schema, records, err = doGetSQL(t, client, &flightsqlpb.CommandGetTables{
+		TableNameFilterPattern: proto.String("t_s%"),
+		IncludeSchema:          true,
+	})
+	require.NoError(t, err)
+	require.True(t, tablesWithSchemaSchema.Equal(schema))
+	require.Len(t, records, 1)
+	r := records[0]
+	require.Equal(t, int64(1), r.NumRows())
+	require.True(t, r.Column(0).IsNull(0))
+	require.Equal(t, "test", r.Column(2).(*array.String).Value(0))
+	tableSchema, err := arrowflight.DeserializeSchema(r.Column(4).(*array.Binary).Value(0), memory.DefaultAllocator)
+	require.NoError(t, err)
+	require.True(t, tableSchema.HasField("labels.namespace"))
+	require.True(t, tableSchema.HasField("value"))
+
+	// Tables have no catalog.
+	_, records, err = doGetSQL(t, client, &flightsqlpb.CommandGetTables{Catalog: proto.String("catalog")})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, int64(0), records[0].NumRows())
+}
+
+func TestServerSQLStatement(t *testing.T) {
+	// Without a parser, statements can't be executed.
+	client, _, _ := newTestServer(t, nil)
+	_, _, err := doGetSQL(t, client, &flightsqlpb.CommandStatementQuery{Query: "SELECT count(value) FROM test"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	client, _, samples := newTestServer(t, func(db *frostdb.DB) []Option {
+		return []Option{WithSQLParser(func(query string) (*logicalplan.LogicalPlan, error) {
+			if query != "SELECT count(value) FROM test" {
+				return nil, errors.New("unsupported query")
+			}
+			return (&logicalplan.Builder{}).
+				Scan(db.TableProvider(), "test").
+				Aggregate(logicalplan.Count(logicalplan.Col("value"))).
+				Build()
+		})}
+	})
+
+	_, records, err := doGetSQL(t, client, &flightsqlpb.CommandStatementQuery{Query: "SELECT count(value) FROM test"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, int64(len(samples)), records[0].Column(0).(*array.Int64).Value(0))
+
+	_, _, err = doGetSQL(t, client, &flightsqlpb.CommandStatementQuery{Query: "SELECT 1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,546 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: arrow/flight/protocol/sql/flightsql.proto
+
+// The subset of the Arrow Flight SQL protocol that frostdb handles. The
+// messages, their field numbers and their package are those of the
+// FlightSql.proto of Apache Arrow, so that Flight SQL clients can send them.
+
+package sql
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CommandGetCatalogs lists the catalogs of the server.
+type CommandGetCatalogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommandGetCatalogs) Reset() {
+	*x = CommandGetCatalogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetCatalogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetCatalogs) ProtoMessage() {}
+
+func (x *CommandGetCatalogs) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetCatalogs.ProtoReflect.Descriptor instead.
+func (*CommandGetCatalogs) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP(), []int{0}
+}
+
+// CommandGetDbSchemas lists the schemas of the server.
+type CommandGetDbSchemas struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// catalog restricts the schemas to those of the catalog, an empty catalog
+	// to those without a catalog.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	// db_schema_filter_pattern restricts the schemas to those whose names match
+	// the SQL LIKE pattern.
+	DbSchemaFilterPattern *string `protobuf:"bytes,2,opt,name=db_schema_filter_pattern,json=dbSchemaFilterPattern,proto3,oneof" json:"db_schema_filter_pattern,omitempty"`
+}
+
+func (x *CommandGetDbSchemas) Reset() {
+	*x = CommandGetDbSchemas{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetDbSchemas) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetDbSchemas) ProtoMessage() {}
+
+func (x *CommandGetDbSchemas) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetDbSchemas.ProtoReflect.Descriptor instead.
+func (*CommandGetDbSchemas) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CommandGetDbSchemas) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetDbSchemas) GetDbSchemaFilterPattern() string {
+	if x != nil && x.DbSchemaFilterPattern != nil {
+		return *x.DbSchemaFilterPattern
+	}
+	return ""
+}
+
+// CommandGetTables lists the tables of the server.
+type CommandGetTables struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// catalog restricts the tables to those of the catalog, an empty catalog to
+	// those without a catalog.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	// db_schema_filter_pattern restricts the tables to those of the schemas
+	// whose names match the SQL LIKE pattern.
+	DbSchemaFilterPattern *string `protobuf:"bytes,2,opt,name=db_schema_filter_pattern,json=dbSchemaFilterPattern,proto3,oneof" json:"db_schema_filter_pattern,omitempty"`
+	// table_name_filter_pattern restricts the tables to those whose names match
+	// the SQL LIKE pattern.
+	TableNameFilterPattern *string `protobuf:"bytes,3,opt,name=table_name_filter_pattern,json=tableNameFilterPattern,proto3,oneof" json:"table_name_filter_pattern,omitempty"`
+	// table_types restricts the tables to those of the types.
+	TableTypes []string `protobuf:"bytes,4,rep,name=table_types,json=tableTypes,proto3" json:"table_types,omitempty"`
+	// include_schema includes the serialized Arrow schemas of the tables.
+	IncludeSchema bool `protobuf:"varint,5,opt,name=include_schema,json=includeSchema,proto3" json:"include_schema,omitempty"`
+}
+
+func (x *CommandGetTables) Reset() {
+	*x = CommandGetTables{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetTables) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetTables) ProtoMessage() {}
+
+func (x *CommandGetTables) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetTables.ProtoReflect.Descriptor instead.
+func (*CommandGetTables) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CommandGetTables) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetDbSchemaFilterPattern() string {
+	if x != nil && x.DbSchemaFilterPattern != nil {
+		return *x.DbSchemaFilterPattern
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetTableNameFilterPattern() string {
+	if x != nil && x.TableNameFilterPattern != nil {
+		return *x.TableNameFilterPattern
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetTableTypes() []string {
+	if x != nil {
+		return x.TableTypes
+	}
+	return nil
+}
+
+func (x *CommandGetTables) GetIncludeSchema() bool {
+	if x != nil {
+		return x.IncludeSchema
+	}
+	return false
+}
+
+// CommandGetTableTypes lists the types of the tables of the server.
+type CommandGetTableTypes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommandGetTableTypes) Reset() {
+	*x = CommandGetTableTypes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetTableTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetTableTypes) ProtoMessage() {}
+
+func (x *CommandGetTableTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetTableTypes.ProtoReflect.Descriptor instead.
+func (*CommandGetTableTypes) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP(), []int{3}
+}
+
+// CommandStatementQuery executes a SQL query.
+type CommandStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// query is the SQL query.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *CommandStatementQuery) Reset() {
+	*x = CommandStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandStatementQuery) ProtoMessage() {}
+
+func (x *CommandStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandStatementQuery.ProtoReflect.Descriptor instead.
+func (*CommandStatementQuery) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CommandStatementQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// TicketStatementQuery is the ticket of the results of a SQL query.
+type TicketStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// statement_handle identifies the query to the server.
+	StatementHandle []byte `protobuf:"bytes,1,opt,name=statement_handle,json=statementHandle,proto3" json:"statement_handle,omitempty"`
+}
+
+func (x *TicketStatementQuery) Reset() {
+	*x = TicketStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TicketStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketStatementQuery) ProtoMessage() {}
+
+func (x *TicketStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketStatementQuery.ProtoReflect.Descriptor instead.
+func (*TicketStatementQuery) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TicketStatementQuery) GetStatementHandle() []byte {
+	if x != nil {
+		return x.StatementHandle
+	}
+	return nil
+}
+
+var File_arrow_flight_protocol_sql_flightsql_proto protoreflect.FileDescriptor
+
+var file_arrow_flight_protocol_sql_flightsql_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x71, 0x6c, 0x2f, 0x66, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x61, 0x72, 0x72,
+	0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x47, 0x65, 0x74, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0x9b, 0x01, 0x0a,
+	0x13, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x44, 0x62, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
+	0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x18, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x15, 0x64, 0x62, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01,
+	0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x42, 0x1b, 0x0a,
+	0x19, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0xbe, 0x02, 0x0a, 0x10, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12,
+	0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01, 0x12, 0x3c,
+	0x0a, 0x18, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x01, 0x52, 0x15, 0x64, 0x62, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x19,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x02, 0x52, 0x16, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
+	0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x1c, 0x0a,
+	0x1a, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x16, 0x0a, 0x14, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x22, 0x2d, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x22, 0x41, 0x0a, 0x14, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x42, 0x83, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x72,
+	0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x42, 0x0e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x71, 0x6c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x73, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2f, 0x66, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x71,
+	0x6c, 0x3b, 0x73, 0x71, 0x6c, 0xa2, 0x02, 0x04, 0x41, 0x46, 0x50, 0x58, 0xaa, 0x02, 0x19, 0x41,
+	0x72, 0x72, 0x6f, 0x77, 0x2e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x53, 0x71, 0x6c, 0xca, 0x02, 0x19, 0x41, 0x72, 0x72, 0x6f, 0x77,
+	0x5c, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x5c, 0x53, 0x71, 0x6c, 0xe2, 0x02, 0x25, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x5c, 0x46, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5c, 0x53, 0x71, 0x6c,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1c, 0x41,
+	0x72, 0x72, 0x6f, 0x77, 0x3a, 0x3a, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x3a, 0x3a, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x3a, 0x3a, 0x53, 0x71, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_arrow_flight_protocol_sql_flightsql_proto_rawDescOnce sync.Once
+	file_arrow_flight_protocol_sql_flightsql_proto_rawDescData = file_arrow_flight_protocol_sql_flightsql_proto_rawDesc
+)
+
+func file_arrow_flight_protocol_sql_flightsql_proto_rawDescGZIP() []byte {
+	file_arrow_flight_protocol_sql_flightsql_proto_rawDescOnce.Do(func() {
+		file_arrow_flight_protocol_sql_flightsql_proto_rawDescData = protoimpl.X.CompressGZIP(file_arrow_flight_protocol_sql_flightsql_proto_rawDescData)
+	})
+	return file_arrow_flight_protocol_sql_flightsql_proto_rawDescData
+}
+
+var file_arrow_flight_protocol_sql_flightsql_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_arrow_flight_protocol_sql_flightsql_proto_goTypes = []interface{}{
+	(*CommandGetCatalogs)(nil),    // 0: arrow.flight.protocol.sql.CommandGetCatalogs
+	(*CommandGetDbSchemas)(nil),   // 1: arrow.flight.protocol.sql.CommandGetDbSchemas
+	(*CommandGetTables)(nil),      // 2: arrow.flight.protocol.sql.CommandGetTables
+	(*CommandGetTableTypes)(nil),  // 3: arrow.flight.protocol.sql.CommandGetTableTypes
+	(*CommandStatementQuery)(nil), // 4: arrow.flight.protocol.sql.CommandStatementQuery
+	(*TicketStatementQuery)(nil),  // 5: arrow.flight.protocol.sql.TicketStatementQuery
+}
+var file_arrow_flight_protocol_sql_flightsql_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_arrow_flight_protocol_sql_flightsql_proto_init() }
+func file_arrow_flight_protocol_sql_flightsql_proto_init() {
+	if File_arrow_flight_protocol_sql_flightsql_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetCatalogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetDbSchemas); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetTables); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetTableTypes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_arrow_flight_protocol_sql_flightsql_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_arrow_flight_protocol_sql_flightsql_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_arrow_flight_protocol_sql_flightsql_proto_goTypes,
+		DependencyIndexes: file_arrow_flight_protocol_sql_flightsql_proto_depIdxs,
+		MessageInfos:      file_arrow_flight_protocol_sql_flightsql_proto_msgTypes,
+	}.Build()
+	File_arrow_flight_protocol_sql_flightsql_proto = out.File
+	file_arrow_flight_protocol_sql_flightsql_proto_rawDesc = nil
+	file_arrow_flight_protocol_sql_flightsql_proto_goTypes = nil
+	file_arrow_flight_protocol_sql_flightsql_proto_depIdxs = nil
+}
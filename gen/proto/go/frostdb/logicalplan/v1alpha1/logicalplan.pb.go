@@ -0,0 +1,3378 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: frostdb/logicalplan/v1alpha1/logicalplan.proto
+
+package logicalplanv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Op is the operator of a binary or unary expression.
+type Op int32
+
+const (
+	// Unknown operator.
+	Op_OP_UNKNOWN_UNSPECIFIED Op = 0
+	// Equality.
+	Op_OP_EQ Op = 1
+	// Inequality.
+	Op_OP_NOT_EQ Op = 2
+	// Less than.
+	Op_OP_LT Op = 3
+	// Less than or equal.
+	Op_OP_LT_EQ Op = 4
+	// Greater than.
+	Op_OP_GT Op = 5
+	// Greater than or equal.
+	Op_OP_GT_EQ Op = 6
+	// Regex match.
+	Op_OP_REGEX_MATCH Op = 7
+	// Regex not match.
+	Op_OP_REGEX_NOT_MATCH Op = 8
+	// Logical and.
+	Op_OP_AND Op = 9
+	// Addition.
+	Op_OP_ADD Op = 10
+	// Subtraction.
+	Op_OP_SUB Op = 11
+	// Multiplication.
+	Op_OP_MUL Op = 12
+	// Division.
+	Op_OP_DIV Op = 13
+	// Modulo.
+	Op_OP_MOD Op = 14
+	// Logical or.
+	Op_OP_OR Op = 15
+	// Logical not.
+	Op_OP_NOT Op = 16
+	// String prefix match.
+	Op_OP_STARTS_WITH Op = 17
+	// String suffix match.
+	Op_OP_ENDS_WITH Op = 18
+	// Substring match.
+	Op_OP_CONTAINS Op = 19
+)
+
+// Enum value maps for Op.
+var (
+	Op_name = map[int32]string{
+		0:  "OP_UNKNOWN_UNSPECIFIED",
+		1:  "OP_EQ",
+		2:  "OP_NOT_EQ",
+		3:  "OP_LT",
+		4:  "OP_LT_EQ",
+		5:  "OP_GT",
+		6:  "OP_GT_EQ",
+		7:  "OP_REGEX_MATCH",
+		8:  "OP_REGEX_NOT_MATCH",
+		9:  "OP_AND",
+		10: "OP_ADD",
+		11: "OP_SUB",
+		12: "OP_MUL",
+		13: "OP_DIV",
+		14: "OP_MOD",
+		15: "OP_OR",
+		16: "OP_NOT",
+		17: "OP_STARTS_WITH",
+		18: "OP_ENDS_WITH",
+		19: "OP_CONTAINS",
+	}
+	Op_value = map[string]int32{
+		"OP_UNKNOWN_UNSPECIFIED": 0,
+		"OP_EQ":                  1,
+		"OP_NOT_EQ":              2,
+		"OP_LT":                  3,
+		"OP_LT_EQ":               4,
+		"OP_GT":                  5,
+		"OP_GT_EQ":               6,
+		"OP_REGEX_MATCH":         7,
+		"OP_REGEX_NOT_MATCH":     8,
+		"OP_AND":                 9,
+		"OP_ADD":                 10,
+		"OP_SUB":                 11,
+		"OP_MUL":                 12,
+		"OP_DIV":                 13,
+		"OP_MOD":                 14,
+		"OP_OR":                  15,
+		"OP_NOT":                 16,
+		"OP_STARTS_WITH":         17,
+		"OP_ENDS_WITH":           18,
+		"OP_CONTAINS":            19,
+	}
+)
+
+func (x Op) Enum() *Op {
+	p := new(Op)
+	*p = x
+	return p
+}
+
+func (x Op) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Op) Descriptor() protoreflect.EnumDescriptor {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[0].Descriptor()
+}
+
+func (Op) Type() protoreflect.EnumType {
+	return &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[0]
+}
+
+func (x Op) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Op.Descriptor instead.
+func (Op) EnumDescriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{0}
+}
+
+// DataType is a type that values can be converted to.
+type DataType int32
+
+const (
+	// Unknown type.
+	DataType_DATA_TYPE_UNKNOWN_UNSPECIFIED DataType = 0
+	// Signed 64-bit integer.
+	DataType_DATA_TYPE_INT64 DataType = 1
+	// Unsigned 64-bit integer.
+	DataType_DATA_TYPE_UINT64 DataType = 2
+	// 64-bit floating point number.
+	DataType_DATA_TYPE_FLOAT64 DataType = 3
+	// UTF-8 string.
+	DataType_DATA_TYPE_STRING DataType = 4
+	// Byte string.
+	DataType_DATA_TYPE_BINARY DataType = 5
+)
+
+// Enum value maps for DataType.
+var (
+	DataType_name = map[int32]string{
+		0: "DATA_TYPE_UNKNOWN_UNSPECIFIED",
+		1: "DATA_TYPE_INT64",
+		2: "DATA_TYPE_UINT64",
+		3: "DATA_TYPE_FLOAT64",
+		4: "DATA_TYPE_STRING",
+		5: "DATA_TYPE_BINARY",
+	}
+	DataType_value = map[string]int32{
+		"DATA_TYPE_UNKNOWN_UNSPECIFIED": 0,
+		"DATA_TYPE_INT64":               1,
+		"DATA_TYPE_UINT64":              2,
+		"DATA_TYPE_FLOAT64":             3,
+		"DATA_TYPE_STRING":              4,
+		"DATA_TYPE_BINARY":              5,
+	}
+)
+
+func (x DataType) Enum() *DataType {
+	p := new(DataType)
+	*p = x
+	return p
+}
+
+func (x DataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[1].Descriptor()
+}
+
+func (DataType) Type() protoreflect.EnumType {
+	return &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[1]
+}
+
+func (x DataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataType.Descriptor instead.
+func (DataType) EnumDescriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{1}
+}
+
+// Type enum of an aggregation function.
+type AggregationFunction_Type int32
+
+const (
+	// Unknown aggregation function.
+	AggregationFunction_TYPE_UNKNOWN_UNSPECIFIED AggregationFunction_Type = 0
+	// Sum of the values.
+	AggregationFunction_TYPE_SUM AggregationFunction_Type = 1
+	// Number of the values that aren't null.
+	AggregationFunction_TYPE_COUNT AggregationFunction_Type = 2
+	// Minimum of the values.
+	AggregationFunction_TYPE_MIN AggregationFunction_Type = 3
+	// Maximum of the values.
+	AggregationFunction_TYPE_MAX AggregationFunction_Type = 4
+	// Estimated number of the distinct values that aren't null.
+	AggregationFunction_TYPE_APPROX_COUNT_DISTINCT AggregationFunction_Type = 5
+	// Estimated value at the first of the quantiles.
+	AggregationFunction_TYPE_QUANTILE AggregationFunction_Type = 6
+	// Estimated values at each of the quantiles.
+	AggregationFunction_TYPE_QUANTILES AggregationFunction_Type = 7
+	// Sample variance of the values.
+	AggregationFunction_TYPE_VARIANCE AggregationFunction_Type = 8
+	// Sample standard deviation of the values.
+	AggregationFunction_TYPE_STDDEV AggregationFunction_Type = 9
+)
+
+// Enum value maps for AggregationFunction_Type.
+var (
+	AggregationFunction_Type_name = map[int32]string{
+		0: "TYPE_UNKNOWN_UNSPECIFIED",
+		1: "TYPE_SUM",
+		2: "TYPE_COUNT",
+		3: "TYPE_MIN",
+		4: "TYPE_MAX",
+		5: "TYPE_APPROX_COUNT_DISTINCT",
+		6: "TYPE_QUANTILE",
+		7: "TYPE_QUANTILES",
+		8: "TYPE_VARIANCE",
+		9: "TYPE_STDDEV",
+	}
+	AggregationFunction_Type_value = map[string]int32{
+		"TYPE_UNKNOWN_UNSPECIFIED":   0,
+		"TYPE_SUM":                   1,
+		"TYPE_COUNT":                 2,
+		"TYPE_MIN":                   3,
+		"TYPE_MAX":                   4,
+		"TYPE_APPROX_COUNT_DISTINCT": 5,
+		"TYPE_QUANTILE":              6,
+		"TYPE_QUANTILES":             7,
+		"TYPE_VARIANCE":              8,
+		"TYPE_STDDEV":                9,
+	}
+)
+
+func (x AggregationFunction_Type) Enum() *AggregationFunction_Type {
+	p := new(AggregationFunction_Type)
+	*p = x
+	return p
+}
+
+func (x AggregationFunction_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregationFunction_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[2].Descriptor()
+}
+
+func (AggregationFunction_Type) Type() protoreflect.EnumType {
+	return &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[2]
+}
+
+func (x AggregationFunction_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregationFunction_Type.Descriptor instead.
+func (AggregationFunction_Type) EnumDescriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{18, 0}
+}
+
+// Type enum of a scalar function.
+type ScalarFunction_Type int32
+
+const (
+	// Unknown function.
+	ScalarFunction_TYPE_UNKNOWN_UNSPECIFIED ScalarFunction_Type = 0
+	// Lowercase a string.
+	ScalarFunction_TYPE_LOWER ScalarFunction_Type = 1
+	// Uppercase a string.
+	ScalarFunction_TYPE_UPPER ScalarFunction_Type = 2
+	// Concatenate values.
+	ScalarFunction_TYPE_CONCAT ScalarFunction_Type = 3
+	// Length of a string.
+	ScalarFunction_TYPE_LENGTH ScalarFunction_Type = 4
+	// Absolute value.
+	ScalarFunction_TYPE_ABS ScalarFunction_Type = 5
+	// Round to the nearest integer.
+	ScalarFunction_TYPE_ROUND ScalarFunction_Type = 6
+	// Round down.
+	ScalarFunction_TYPE_FLOOR ScalarFunction_Type = 7
+	// Round up.
+	ScalarFunction_TYPE_CEIL ScalarFunction_Type = 8
+	// Natural logarithm.
+	ScalarFunction_TYPE_LOG ScalarFunction_Type = 9
+)
+
+// Enum value maps for ScalarFunction_Type.
+var (
+	ScalarFunction_Type_name = map[int32]string{
+		0: "TYPE_UNKNOWN_UNSPECIFIED",
+		1: "TYPE_LOWER",
+		2: "TYPE_UPPER",
+		3: "TYPE_CONCAT",
+		4: "TYPE_LENGTH",
+		5: "TYPE_ABS",
+		6: "TYPE_ROUND",
+		7: "TYPE_FLOOR",
+		8: "TYPE_CEIL",
+		9: "TYPE_LOG",
+	}
+	ScalarFunction_Type_value = map[string]int32{
+		"TYPE_UNKNOWN_UNSPECIFIED": 0,
+		"TYPE_LOWER":               1,
+		"TYPE_UPPER":               2,
+		"TYPE_CONCAT":              3,
+		"TYPE_LENGTH":              4,
+		"TYPE_ABS":                 5,
+		"TYPE_ROUND":               6,
+		"TYPE_FLOOR":               7,
+		"TYPE_CEIL":                8,
+		"TYPE_LOG":                 9,
+	}
+)
+
+func (x ScalarFunction_Type) Enum() *ScalarFunction_Type {
+	p := new(ScalarFunction_Type)
+	*p = x
+	return p
+}
+
+func (x ScalarFunction_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScalarFunction_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[3].Descriptor()
+}
+
+func (ScalarFunction_Type) Type() protoreflect.EnumType {
+	return &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes[3]
+}
+
+func (x ScalarFunction_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScalarFunction_Type.Descriptor instead.
+func (ScalarFunction_Type) EnumDescriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{22, 0}
+}
+
+// PlanNode is a node of a logical plan. Every node except for scans has an
+// input that it processes the results of.
+type PlanNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Input of the node.
+	Input *PlanNode `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	// The operation of the node.
+	//
+	// Types that are assignable to Spec:
+	//	*PlanNode_TableScan
+	//	*PlanNode_SchemaScan
+	//	*PlanNode_Filter
+	//	*PlanNode_Distinct
+	//	*PlanNode_Projection
+	//	*PlanNode_Aggregation
+	//	*PlanNode_Limit
+	//	*PlanNode_Offset
+	//	*PlanNode_OrderBy
+	Spec isPlanNode_Spec `protobuf_oneof:"spec"`
+}
+
+func (x *PlanNode) Reset() {
+	*x = PlanNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanNode) ProtoMessage() {}
+
+func (x *PlanNode) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanNode.ProtoReflect.Descriptor instead.
+func (*PlanNode) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlanNode) GetInput() *PlanNode {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (m *PlanNode) GetSpec() isPlanNode_Spec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (x *PlanNode) GetTableScan() *TableScan {
+	if x, ok := x.GetSpec().(*PlanNode_TableScan); ok {
+		return x.TableScan
+	}
+	return nil
+}
+
+func (x *PlanNode) GetSchemaScan() *SchemaScan {
+	if x, ok := x.GetSpec().(*PlanNode_SchemaScan); ok {
+		return x.SchemaScan
+	}
+	return nil
+}
+
+func (x *PlanNode) GetFilter() *Filter {
+	if x, ok := x.GetSpec().(*PlanNode_Filter); ok {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *PlanNode) GetDistinct() *Distinct {
+	if x, ok := x.GetSpec().(*PlanNode_Distinct); ok {
+		return x.Distinct
+	}
+	return nil
+}
+
+func (x *PlanNode) GetProjection() *Projection {
+	if x, ok := x.GetSpec().(*PlanNode_Projection); ok {
+		return x.Projection
+	}
+	return nil
+}
+
+func (x *PlanNode) GetAggregation() *Aggregation {
+	if x, ok := x.GetSpec().(*PlanNode_Aggregation); ok {
+		return x.Aggregation
+	}
+	return nil
+}
+
+func (x *PlanNode) GetLimit() *Limit {
+	if x, ok := x.GetSpec().(*PlanNode_Limit); ok {
+		return x.Limit
+	}
+	return nil
+}
+
+func (x *PlanNode) GetOffset() *Offset {
+	if x, ok := x.GetSpec().(*PlanNode_Offset); ok {
+		return x.Offset
+	}
+	return nil
+}
+
+func (x *PlanNode) GetOrderBy() *OrderBy {
+	if x, ok := x.GetSpec().(*PlanNode_OrderBy); ok {
+		return x.OrderBy
+	}
+	return nil
+}
+
+type isPlanNode_Spec interface {
+	isPlanNode_Spec()
+}
+
+type PlanNode_TableScan struct {
+	// TableScan is set if the node scans a table.
+	TableScan *TableScan `protobuf:"bytes,2,opt,name=table_scan,json=tableScan,proto3,oneof"`
+}
+
+type PlanNode_SchemaScan struct {
+	// SchemaScan is set if the node scans the schema of a table.
+	SchemaScan *SchemaScan `protobuf:"bytes,3,opt,name=schema_scan,json=schemaScan,proto3,oneof"`
+}
+
+type PlanNode_Filter struct {
+	// Filter is set if the node filters its input.
+	Filter *Filter `protobuf:"bytes,4,opt,name=filter,proto3,oneof"`
+}
+
+type PlanNode_Distinct struct {
+	// Distinct is set if the node deduplicates its input.
+	Distinct *Distinct `protobuf:"bytes,5,opt,name=distinct,proto3,oneof"`
+}
+
+type PlanNode_Projection struct {
+	// Projection is set if the node projects its input.
+	Projection *Projection `protobuf:"bytes,6,opt,name=projection,proto3,oneof"`
+}
+
+type PlanNode_Aggregation struct {
+	// Aggregation is set if the node aggregates its input.
+	Aggregation *Aggregation `protobuf:"bytes,7,opt,name=aggregation,proto3,oneof"`
+}
+
+type PlanNode_Limit struct {
+	// Limit is set if the node passes on a number of rows of its input only.
+	Limit *Limit `protobuf:"bytes,8,opt,name=limit,proto3,oneof"`
+}
+
+type PlanNode_Offset struct {
+	// Offset is set if the node skips a number of rows of its input.
+	Offset *Offset `protobuf:"bytes,9,opt,name=offset,proto3,oneof"`
+}
+
+type PlanNode_OrderBy struct {
+	// OrderBy is set if the node sorts its input.
+	OrderBy *OrderBy `protobuf:"bytes,10,opt,name=order_by,json=orderBy,proto3,oneof"`
+}
+
+func (*PlanNode_TableScan) isPlanNode_Spec() {}
+
+func (*PlanNode_SchemaScan) isPlanNode_Spec() {}
+
+func (*PlanNode_Filter) isPlanNode_Spec() {}
+
+func (*PlanNode_Distinct) isPlanNode_Spec() {}
+
+func (*PlanNode_Projection) isPlanNode_Spec() {}
+
+func (*PlanNode_Aggregation) isPlanNode_Spec() {}
+
+func (*PlanNode_Limit) isPlanNode_Spec() {}
+
+func (*PlanNode_Offset) isPlanNode_Spec() {}
+
+func (*PlanNode_OrderBy) isPlanNode_Spec() {}
+
+// TableScan reads the data of a table.
+type TableScan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the table to scan.
+	TableName string `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	// Columns that are physically read by the scan.
+	PhysicalProjection []*Expr `protobuf:"bytes,2,rep,name=physical_projection,json=physicalProjection,proto3" json:"physical_projection,omitempty"`
+	// Predicate used to rule out data that doesn't need to be read.
+	Filter *Expr `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Columns that are to be distinct.
+	Distinct []*Expr `protobuf:"bytes,4,rep,name=distinct,proto3" json:"distinct,omitempty"`
+	// Columns that are to be projected.
+	Projection []*Expr `protobuf:"bytes,5,rep,name=projection,proto3" json:"projection,omitempty"`
+	// Number of rows after which the scan can stop reading, if greater than zero.
+	Limit int64 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Random sample of the rows to read, all rows are read if not set.
+	Sample *Sample `protobuf:"bytes,7,opt,name=sample,proto3" json:"sample,omitempty"`
+}
+
+func (x *TableScan) Reset() {
+	*x = TableScan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableScan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableScan) ProtoMessage() {}
+
+func (x *TableScan) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableScan.ProtoReflect.Descriptor instead.
+func (*TableScan) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TableScan) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *TableScan) GetPhysicalProjection() []*Expr {
+	if x != nil {
+		return x.PhysicalProjection
+	}
+	return nil
+}
+
+func (x *TableScan) GetFilter() *Expr {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *TableScan) GetDistinct() []*Expr {
+	if x != nil {
+		return x.Distinct
+	}
+	return nil
+}
+
+func (x *TableScan) GetProjection() []*Expr {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+func (x *TableScan) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TableScan) GetSample() *Sample {
+	if x != nil {
+		return x.Sample
+	}
+	return nil
+}
+
+// Sample is a random sample of the rows of a table.
+type Sample struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fraction of the rows that are sampled.
+	Fraction float64 `protobuf:"fixed64,1,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	// Seed of the random number generator that samples the rows.
+	Seed int64 `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+}
+
+func (x *Sample) Reset() {
+	*x = Sample{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sample) ProtoMessage() {}
+
+func (x *Sample) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sample.ProtoReflect.Descriptor instead.
+func (*Sample) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Sample) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+func (x *Sample) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+// SchemaScan reads the schema of a table.
+type SchemaScan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the table to scan.
+	TableName string `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	// Columns that are physically read by the scan.
+	PhysicalProjection []*Expr `protobuf:"bytes,2,rep,name=physical_projection,json=physicalProjection,proto3" json:"physical_projection,omitempty"`
+	// Predicate used to rule out data that doesn't need to be read.
+	Filter *Expr `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Columns that are to be distinct.
+	Distinct []*Expr `protobuf:"bytes,4,rep,name=distinct,proto3" json:"distinct,omitempty"`
+	// Columns that are to be projected.
+	Projection []*Expr `protobuf:"bytes,5,rep,name=projection,proto3" json:"projection,omitempty"`
+}
+
+func (x *SchemaScan) Reset() {
+	*x = SchemaScan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchemaScan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaScan) ProtoMessage() {}
+
+func (x *SchemaScan) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaScan.ProtoReflect.Descriptor instead.
+func (*SchemaScan) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SchemaScan) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *SchemaScan) GetPhysicalProjection() []*Expr {
+	if x != nil {
+		return x.PhysicalProjection
+	}
+	return nil
+}
+
+func (x *SchemaScan) GetFilter() *Expr {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SchemaScan) GetDistinct() []*Expr {
+	if x != nil {
+		return x.Distinct
+	}
+	return nil
+}
+
+func (x *SchemaScan) GetProjection() []*Expr {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+// Filter keeps the rows its expression is true for.
+type Filter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Predicate of the filter.
+	Expr *Expr `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Filter) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+// Distinct deduplicates rows by the values of its expressions.
+type Distinct struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expressions to deduplicate by.
+	Exprs []*Expr `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+}
+
+func (x *Distinct) Reset() {
+	*x = Distinct{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Distinct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Distinct) ProtoMessage() {}
+
+func (x *Distinct) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Distinct.ProtoReflect.Descriptor instead.
+func (*Distinct) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Distinct) GetExprs() []*Expr {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// Projection evaluates its expressions for every row.
+type Projection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expressions to project.
+	Exprs []*Expr `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+}
+
+func (x *Projection) Reset() {
+	*x = Projection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Projection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Projection) ProtoMessage() {}
+
+func (x *Projection) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Projection.ProtoReflect.Descriptor instead.
+func (*Projection) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Projection) GetExprs() []*Expr {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// Aggregation aggregates rows grouped by the values of its group expressions.
+type Aggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expressions to group by.
+	GroupExprs []*Expr `protobuf:"bytes,1,rep,name=group_exprs,json=groupExprs,proto3" json:"group_exprs,omitempty"`
+	// Aggregation to compute for every group, if agg_exprs is empty.
+	AggExpr *Expr `protobuf:"bytes,2,opt,name=agg_expr,json=aggExpr,proto3" json:"agg_expr,omitempty"`
+	// Aggregations to compute for every group.
+	AggExprs []*Expr `protobuf:"bytes,3,rep,name=agg_exprs,json=aggExprs,proto3" json:"agg_exprs,omitempty"`
+}
+
+func (x *Aggregation) Reset() {
+	*x = Aggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Aggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregation) ProtoMessage() {}
+
+func (x *Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Aggregation) GetGroupExprs() []*Expr {
+	if x != nil {
+		return x.GroupExprs
+	}
+	return nil
+}
+
+func (x *Aggregation) GetAggExpr() *Expr {
+	if x != nil {
+		return x.AggExpr
+	}
+	return nil
+}
+
+func (x *Aggregation) GetAggExprs() []*Expr {
+	if x != nil {
+		return x.AggExprs
+	}
+	return nil
+}
+
+// Limit passes on the first rows of its input.
+type Limit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of rows to pass on.
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Limit) Reset() {
+	*x = Limit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limit) ProtoMessage() {}
+
+func (x *Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limit.ProtoReflect.Descriptor instead.
+func (*Limit) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Limit) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Offset skips the first rows of its input.
+type Offset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of rows to skip.
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Offset) Reset() {
+	*x = Offset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Offset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Offset) ProtoMessage() {}
+
+func (x *Offset) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Offset.ProtoReflect.Descriptor instead.
+func (*Offset) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Offset) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// OrderBy sorts rows by the values of its expressions.
+type OrderBy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expressions to sort by, in order of precedence.
+	Exprs []*SortExpr `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+}
+
+func (x *OrderBy) Reset() {
+	*x = OrderBy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBy) ProtoMessage() {}
+
+func (x *OrderBy) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBy.ProtoReflect.Descriptor instead.
+func (*OrderBy) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderBy) GetExprs() []*SortExpr {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// SortExpr sorts rows by the values of an expression.
+type SortExpr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expression to sort by.
+	Expr *Expr `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// Whether the rows are sorted in descending order.
+	Descending bool `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *SortExpr) Reset() {
+	*x = SortExpr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortExpr) ProtoMessage() {}
+
+func (x *SortExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortExpr.ProtoReflect.Descriptor instead.
+func (*SortExpr) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SortExpr) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *SortExpr) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+// Expr is an expression of a logical plan.
+type Expr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The kind of expression.
+	//
+	// Types that are assignable to Expr:
+	//	*Expr_Column
+	//	*Expr_DynamicColumn
+	//	*Expr_Literal
+	//	*Expr_Binary
+	//	*Expr_Unary
+	//	*Expr_AggregationFunction
+	//	*Expr_Alias
+	//	*Expr_CaseExpr
+	//	*Expr_Cast
+	//	*Expr_ScalarFunction
+	//	*Expr_DurationTruncate
+	//	*Expr_Coalesce
+	//	*Expr_Between
+	Expr isExpr_Expr `protobuf_oneof:"expr"`
+}
+
+func (x *Expr) Reset() {
+	*x = Expr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expr) ProtoMessage() {}
+
+func (x *Expr) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expr.ProtoReflect.Descriptor instead.
+func (*Expr) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{12}
+}
+
+func (m *Expr) GetExpr() isExpr_Expr {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+func (x *Expr) GetColumn() *Column {
+	if x, ok := x.GetExpr().(*Expr_Column); ok {
+		return x.Column
+	}
+	return nil
+}
+
+func (x *Expr) GetDynamicColumn() *DynamicColumn {
+	if x, ok := x.GetExpr().(*Expr_DynamicColumn); ok {
+		return x.DynamicColumn
+	}
+	return nil
+}
+
+func (x *Expr) GetLiteral() *Literal {
+	if x, ok := x.GetExpr().(*Expr_Literal); ok {
+		return x.Literal
+	}
+	return nil
+}
+
+func (x *Expr) GetBinary() *BinaryExpr {
+	if x, ok := x.GetExpr().(*Expr_Binary); ok {
+		return x.Binary
+	}
+	return nil
+}
+
+func (x *Expr) GetUnary() *UnaryExpr {
+	if x, ok := x.GetExpr().(*Expr_Unary); ok {
+		return x.Unary
+	}
+	return nil
+}
+
+func (x *Expr) GetAggregationFunction() *AggregationFunction {
+	if x, ok := x.GetExpr().(*Expr_AggregationFunction); ok {
+		return x.AggregationFunction
+	}
+	return nil
+}
+
+func (x *Expr) GetAlias() *Alias {
+	if x, ok := x.GetExpr().(*Expr_Alias); ok {
+		return x.Alias
+	}
+	return nil
+}
+
+func (x *Expr) GetCaseExpr() *CaseExpr {
+	if x, ok := x.GetExpr().(*Expr_CaseExpr); ok {
+		return x.CaseExpr
+	}
+	return nil
+}
+
+func (x *Expr) GetCast() *Cast {
+	if x, ok := x.GetExpr().(*Expr_Cast); ok {
+		return x.Cast
+	}
+	return nil
+}
+
+func (x *Expr) GetScalarFunction() *ScalarFunction {
+	if x, ok := x.GetExpr().(*Expr_ScalarFunction); ok {
+		return x.ScalarFunction
+	}
+	return nil
+}
+
+func (x *Expr) GetDurationTruncate() *DurationTruncate {
+	if x, ok := x.GetExpr().(*Expr_DurationTruncate); ok {
+		return x.DurationTruncate
+	}
+	return nil
+}
+
+func (x *Expr) GetCoalesce() *Coalesce {
+	if x, ok := x.GetExpr().(*Expr_Coalesce); ok {
+		return x.Coalesce
+	}
+	return nil
+}
+
+func (x *Expr) GetBetween() *Between {
+	if x, ok := x.GetExpr().(*Expr_Between); ok {
+		return x.Between
+	}
+	return nil
+}
+
+type isExpr_Expr interface {
+	isExpr_Expr()
+}
+
+type Expr_Column struct {
+	// Column is set if the expression references a column.
+	Column *Column `protobuf:"bytes,1,opt,name=column,proto3,oneof"`
+}
+
+type Expr_DynamicColumn struct {
+	// DynamicColumn is set if the expression references all columns of a dynamic column.
+	DynamicColumn *DynamicColumn `protobuf:"bytes,2,opt,name=dynamic_column,json=dynamicColumn,proto3,oneof"`
+}
+
+type Expr_Literal struct {
+	// Literal is set if the expression is a literal value.
+	Literal *Literal `protobuf:"bytes,3,opt,name=literal,proto3,oneof"`
+}
+
+type Expr_Binary struct {
+	// Binary is set if the expression is a binary operation.
+	Binary *BinaryExpr `protobuf:"bytes,4,opt,name=binary,proto3,oneof"`
+}
+
+type Expr_Unary struct {
+	// Unary is set if the expression is a unary operation.
+	Unary *UnaryExpr `protobuf:"bytes,5,opt,name=unary,proto3,oneof"`
+}
+
+type Expr_AggregationFunction struct {
+	// AggregationFunction is set if the expression is an aggregation.
+	AggregationFunction *AggregationFunction `protobuf:"bytes,6,opt,name=aggregation_function,json=aggregationFunction,proto3,oneof"`
+}
+
+type Expr_Alias struct {
+	// Alias is set if the expression renames another expression.
+	Alias *Alias `protobuf:"bytes,7,opt,name=alias,proto3,oneof"`
+}
+
+type Expr_CaseExpr struct {
+	// CaseExpr is set if the expression is a case expression.
+	CaseExpr *CaseExpr `protobuf:"bytes,8,opt,name=case_expr,json=caseExpr,proto3,oneof"`
+}
+
+type Expr_Cast struct {
+	// Cast is set if the expression converts another expression to a different type.
+	Cast *Cast `protobuf:"bytes,9,opt,name=cast,proto3,oneof"`
+}
+
+type Expr_ScalarFunction struct {
+	// ScalarFunction is set if the expression is a scalar function call.
+	ScalarFunction *ScalarFunction `protobuf:"bytes,10,opt,name=scalar_function,json=scalarFunction,proto3,oneof"`
+}
+
+type Expr_DurationTruncate struct {
+	// DurationTruncate is set if the expression truncates timestamps.
+	DurationTruncate *DurationTruncate `protobuf:"bytes,11,opt,name=duration_truncate,json=durationTruncate,proto3,oneof"`
+}
+
+type Expr_Coalesce struct {
+	// Coalesce is set if the expression is a coalesce expression.
+	Coalesce *Coalesce `protobuf:"bytes,12,opt,name=coalesce,proto3,oneof"`
+}
+
+type Expr_Between struct {
+	// Between is set if the expression is a range predicate.
+	Between *Between `protobuf:"bytes,13,opt,name=between,proto3,oneof"`
+}
+
+func (*Expr_Column) isExpr_Expr() {}
+
+func (*Expr_DynamicColumn) isExpr_Expr() {}
+
+func (*Expr_Literal) isExpr_Expr() {}
+
+func (*Expr_Binary) isExpr_Expr() {}
+
+func (*Expr_Unary) isExpr_Expr() {}
+
+func (*Expr_AggregationFunction) isExpr_Expr() {}
+
+func (*Expr_Alias) isExpr_Expr() {}
+
+func (*Expr_CaseExpr) isExpr_Expr() {}
+
+func (*Expr_Cast) isExpr_Expr() {}
+
+func (*Expr_ScalarFunction) isExpr_Expr() {}
+
+func (*Expr_DurationTruncate) isExpr_Expr() {}
+
+func (*Expr_Coalesce) isExpr_Expr() {}
+
+func (*Expr_Between) isExpr_Expr() {}
+
+// Column references a column by name.
+type Column struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the column.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Column) Reset() {
+	*x = Column{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Column) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Column) ProtoMessage() {}
+
+func (x *Column) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Column.ProtoReflect.Descriptor instead.
+func (*Column) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Column) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// DynamicColumn references all concrete columns of a dynamic column.
+type DynamicColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the dynamic column.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DynamicColumn) Reset() {
+	*x = DynamicColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DynamicColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DynamicColumn) ProtoMessage() {}
+
+func (x *DynamicColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DynamicColumn.ProtoReflect.Descriptor instead.
+func (*DynamicColumn) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DynamicColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Literal is a constant value.
+type Literal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value of the literal.
+	//
+	// Types that are assignable to Value:
+	//	*Literal_NullValue
+	//	*Literal_BoolValue
+	//	*Literal_Int64Value
+	//	*Literal_Uint64Value
+	//	*Literal_Float64Value
+	//	*Literal_StringValue
+	//	*Literal_BinaryValue
+	//	*Literal_TimestampValue
+	Value isLiteral_Value `protobuf_oneof:"value"`
+}
+
+func (x *Literal) Reset() {
+	*x = Literal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Literal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Literal) ProtoMessage() {}
+
+func (x *Literal) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Literal.ProtoReflect.Descriptor instead.
+func (*Literal) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{15}
+}
+
+func (m *Literal) GetValue() isLiteral_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *Literal) GetNullValue() *Literal_Null {
+	if x, ok := x.GetValue().(*Literal_NullValue); ok {
+		return x.NullValue
+	}
+	return nil
+}
+
+func (x *Literal) GetBoolValue() bool {
+	if x, ok := x.GetValue().(*Literal_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (x *Literal) GetInt64Value() int64 {
+	if x, ok := x.GetValue().(*Literal_Int64Value); ok {
+		return x.Int64Value
+	}
+	return 0
+}
+
+func (x *Literal) GetUint64Value() uint64 {
+	if x, ok := x.GetValue().(*Literal_Uint64Value); ok {
+		return x.Uint64Value
+	}
+	return 0
+}
+
+func (x *Literal) GetFloat64Value() float64 {
+	if x, ok := x.GetValue().(*Literal_Float64Value); ok {
+		return x.Float64Value
+	}
+	return 0
+}
+
+func (x *Literal) GetStringValue() string {
+	if x, ok := x.GetValue().(*Literal_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (x *Literal) GetBinaryValue() []byte {
+	if x, ok := x.GetValue().(*Literal_BinaryValue); ok {
+		return x.BinaryValue
+	}
+	return nil
+}
+
+func (x *Literal) GetTimestampValue() int64 {
+	if x, ok := x.GetValue().(*Literal_TimestampValue); ok {
+		return x.TimestampValue
+	}
+	return 0
+}
+
+type isLiteral_Value interface {
+	isLiteral_Value()
+}
+
+type Literal_NullValue struct {
+	// NullValue is set if the literal is null.
+	NullValue *Literal_Null `protobuf:"bytes,1,opt,name=null_value,json=nullValue,proto3,oneof"`
+}
+
+type Literal_BoolValue struct {
+	// BoolValue is set if the literal is a boolean.
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Literal_Int64Value struct {
+	// Int64Value is set if the literal is a signed integer.
+	Int64Value int64 `protobuf:"varint,3,opt,name=int64_value,json=int64Value,proto3,oneof"`
+}
+
+type Literal_Uint64Value struct {
+	// Uint64Value is set if the literal is an unsigned integer.
+	Uint64Value uint64 `protobuf:"varint,4,opt,name=uint64_value,json=uint64Value,proto3,oneof"`
+}
+
+type Literal_Float64Value struct {
+	// Float64Value is set if the literal is a floating point number.
+	Float64Value float64 `protobuf:"fixed64,5,opt,name=float64_value,json=float64Value,proto3,oneof"`
+}
+
+type Literal_StringValue struct {
+	// StringValue is set if the literal is a string.
+	StringValue string `protobuf:"bytes,6,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Literal_BinaryValue struct {
+	// BinaryValue is set if the literal is a byte string.
+	BinaryValue []byte `protobuf:"bytes,7,opt,name=binary_value,json=binaryValue,proto3,oneof"`
+}
+
+type Literal_TimestampValue struct {
+	// TimestampValue is set if the literal is a timestamp, in milliseconds since the epoch.
+	TimestampValue int64 `protobuf:"varint,8,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+func (*Literal_NullValue) isLiteral_Value() {}
+
+func (*Literal_BoolValue) isLiteral_Value() {}
+
+func (*Literal_Int64Value) isLiteral_Value() {}
+
+func (*Literal_Uint64Value) isLiteral_Value() {}
+
+func (*Literal_Float64Value) isLiteral_Value() {}
+
+func (*Literal_StringValue) isLiteral_Value() {}
+
+func (*Literal_BinaryValue) isLiteral_Value() {}
+
+func (*Literal_TimestampValue) isLiteral_Value() {}
+
+// BinaryExpr applies an operator to two expressions.
+type BinaryExpr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Left operand.
+	Left *Expr `protobuf:"bytes,1,opt,name=left,proto3" json:"left,omitempty"`
+	// Operator.
+	Op Op `protobuf:"varint,2,opt,name=op,proto3,enum=frostdb.logicalplan.v1alpha1.Op" json:"op,omitempty"`
+	// Right operand.
+	Right *Expr `protobuf:"bytes,3,opt,name=right,proto3" json:"right,omitempty"`
+}
+
+func (x *BinaryExpr) Reset() {
+	*x = BinaryExpr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BinaryExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinaryExpr) ProtoMessage() {}
+
+func (x *BinaryExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BinaryExpr.ProtoReflect.Descriptor instead.
+func (*BinaryExpr) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BinaryExpr) GetLeft() *Expr {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *BinaryExpr) GetOp() Op {
+	if x != nil {
+		return x.Op
+	}
+	return Op_OP_UNKNOWN_UNSPECIFIED
+}
+
+func (x *BinaryExpr) GetRight() *Expr {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+// UnaryExpr applies an operator to a single expression.
+type UnaryExpr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Operator.
+	Op Op `protobuf:"varint,1,opt,name=op,proto3,enum=frostdb.logicalplan.v1alpha1.Op" json:"op,omitempty"`
+	// Operand.
+	Expr *Expr `protobuf:"bytes,2,opt,name=expr,proto3" json:"expr,omitempty"`
+}
+
+func (x *UnaryExpr) Reset() {
+	*x = UnaryExpr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnaryExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnaryExpr) ProtoMessage() {}
+
+func (x *UnaryExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnaryExpr.ProtoReflect.Descriptor instead.
+func (*UnaryExpr) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnaryExpr) GetOp() Op {
+	if x != nil {
+		return x.Op
+	}
+	return Op_OP_UNKNOWN_UNSPECIFIED
+}
+
+func (x *UnaryExpr) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+// AggregationFunction aggregates the values of an expression.
+type AggregationFunction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the aggregation.
+	Type AggregationFunction_Type `protobuf:"varint,1,opt,name=type,proto3,enum=frostdb.logicalplan.v1alpha1.AggregationFunction_Type" json:"type,omitempty"`
+	// Expression to aggregate.
+	Expr *Expr `protobuf:"bytes,2,opt,name=expr,proto3" json:"expr,omitempty"`
+	// State is set if the aggregation returns its serialized state instead of
+	// its result, so the states of partial aggregations can be merged.
+	State bool `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Quantiles of quantile aggregations, between 0 and 1.
+	Quantiles []float64 `protobuf:"fixed64,4,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"`
+}
+
+func (x *AggregationFunction) Reset() {
+	*x = AggregationFunction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationFunction) ProtoMessage() {}
+
+func (x *AggregationFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationFunction.ProtoReflect.Descriptor instead.
+func (*AggregationFunction) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AggregationFunction) GetType() AggregationFunction_Type {
+	if x != nil {
+		return x.Type
+	}
+	return AggregationFunction_TYPE_UNKNOWN_UNSPECIFIED
+}
+
+func (x *AggregationFunction) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *AggregationFunction) GetState() bool {
+	if x != nil {
+		return x.State
+	}
+	return false
+}
+
+func (x *AggregationFunction) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+// Alias gives an expression a different name.
+type Alias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expression to rename.
+	Expr *Expr `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// Name of the expression.
+	Alias string `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+}
+
+func (x *Alias) Reset() {
+	*x = Alias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alias) ProtoMessage() {}
+
+func (x *Alias) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alias.ProtoReflect.Descriptor instead.
+func (*Alias) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Alias) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *Alias) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+// CaseExpr evaluates to the result of the first branch whose condition is true.
+type CaseExpr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Branches of the case expression.
+	Cases []*CaseExpr_WhenThen `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
+	// Result if none of the conditions are true.
+	Else *Expr `protobuf:"bytes,2,opt,name=else,proto3" json:"else,omitempty"`
+}
+
+func (x *CaseExpr) Reset() {
+	*x = CaseExpr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaseExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseExpr) ProtoMessage() {}
+
+func (x *CaseExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseExpr.ProtoReflect.Descriptor instead.
+func (*CaseExpr) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CaseExpr) GetCases() []*CaseExpr_WhenThen {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+func (x *CaseExpr) GetElse() *Expr {
+	if x != nil {
+		return x.Else
+	}
+	return nil
+}
+
+// Cast converts the values of an expression to a different type.
+type Cast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expression to convert.
+	Expr *Expr `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// Type to convert to.
+	Type DataType `protobuf:"varint,2,opt,name=type,proto3,enum=frostdb.logicalplan.v1alpha1.DataType" json:"type,omitempty"`
+}
+
+func (x *Cast) Reset() {
+	*x = Cast{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cast) ProtoMessage() {}
+
+func (x *Cast) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cast.ProtoReflect.Descriptor instead.
+func (*Cast) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Cast) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *Cast) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNKNOWN_UNSPECIFIED
+}
+
+// ScalarFunction applies a function to the values of its arguments.
+type ScalarFunction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the function.
+	Type ScalarFunction_Type `protobuf:"varint,1,opt,name=type,proto3,enum=frostdb.logicalplan.v1alpha1.ScalarFunction_Type" json:"type,omitempty"`
+	// Arguments of the function.
+	Args []*Expr `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+}
+
+func (x *ScalarFunction) Reset() {
+	*x = ScalarFunction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScalarFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScalarFunction) ProtoMessage() {}
+
+func (x *ScalarFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScalarFunction.ProtoReflect.Descriptor instead.
+func (*ScalarFunction) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ScalarFunction) GetType() ScalarFunction_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ScalarFunction_TYPE_UNKNOWN_UNSPECIFIED
+}
+
+func (x *ScalarFunction) GetArgs() []*Expr {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// DurationTruncate truncates timestamps to a multiple of a duration.
+type DurationTruncate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expression to truncate.
+	Expr *Expr `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// Duration to truncate to, in nanoseconds.
+	Duration int64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *DurationTruncate) Reset() {
+	*x = DurationTruncate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DurationTruncate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationTruncate) ProtoMessage() {}
+
+func (x *DurationTruncate) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationTruncate.ProtoReflect.Descriptor instead.
+func (*DurationTruncate) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DurationTruncate) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *DurationTruncate) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+// Coalesce evaluates to the first of its expressions that is not null.
+type Coalesce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expressions to pick from.
+	Exprs []*Expr `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+}
+
+func (x *Coalesce) Reset() {
+	*x = Coalesce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Coalesce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coalesce) ProtoMessage() {}
+
+func (x *Coalesce) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coalesce.ProtoReflect.Descriptor instead.
+func (*Coalesce) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Coalesce) GetExprs() []*Expr {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// Between is true for values within an inclusive range.
+type Between struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Expression to check.
+	Expr *Expr `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// Lower bound of the range.
+	Low *Expr `protobuf:"bytes,2,opt,name=low,proto3" json:"low,omitempty"`
+	// Upper bound of the range.
+	High *Expr `protobuf:"bytes,3,opt,name=high,proto3" json:"high,omitempty"`
+}
+
+func (x *Between) Reset() {
+	*x = Between{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Between) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Between) ProtoMessage() {}
+
+func (x *Between) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Between.ProtoReflect.Descriptor instead.
+func (*Between) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Between) GetExpr() *Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *Between) GetLow() *Expr {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *Between) GetHigh() *Expr {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+// Null is the value of null literals.
+type Literal_Null struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Literal_Null) Reset() {
+	*x = Literal_Null{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Literal_Null) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Literal_Null) ProtoMessage() {}
+
+func (x *Literal_Null) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Literal_Null.ProtoReflect.Descriptor instead.
+func (*Literal_Null) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{15, 0}
+}
+
+// WhenThen is a branch of a case expression.
+type CaseExpr_WhenThen struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Condition of the branch.
+	When *Expr `protobuf:"bytes,1,opt,name=when,proto3" json:"when,omitempty"`
+	// Result of the branch.
+	Then *Expr `protobuf:"bytes,2,opt,name=then,proto3" json:"then,omitempty"`
+}
+
+func (x *CaseExpr_WhenThen) Reset() {
+	*x = CaseExpr_WhenThen{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaseExpr_WhenThen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseExpr_WhenThen) ProtoMessage() {}
+
+func (x *CaseExpr_WhenThen) ProtoReflect() protoreflect.Message {
+	mi := &file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseExpr_WhenThen.ProtoReflect.Descriptor instead.
+func (*CaseExpr_WhenThen) Descriptor() ([]byte, []int) {
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *CaseExpr_WhenThen) GetWhen() *Expr {
+	if x != nil {
+		return x.When
+	}
+	return nil
+}
+
+func (x *CaseExpr_WhenThen) GetThen() *Expr {
+	if x != nil {
+		return x.Then
+	}
+	return nil
+}
+
+var File_frostdb_logicalplan_v1alpha1_logicalplan_proto protoreflect.FileDescriptor
+
+var file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x1c, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x22, 0xc9,
+	0x05, 0x0a, 0x08, 0x50, 0x6c, 0x61, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x3c, 0x0a, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x72, 0x6f,
+	0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x48, 0x0a, 0x0a, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x73, 0x63, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53,
+	0x63, 0x61, 0x6e, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x73, 0x63,
+	0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74,
+	0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x63,
+	0x61, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x63, 0x61, 0x6e,
+	0x12, 0x3e, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x44, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x48, 0x00, 0x52, 0x08, 0x64, 0x69,
+	0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x4a, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x66, 0x72, 0x6f,
+	0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64,
+	0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x3b, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x3e,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x48, 0x00, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x42,
+	0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x42, 0x79, 0x42, 0x06, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x93, 0x03, 0x0a, 0x09, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x53, 0x0a, 0x13, 0x70, 0x68, 0x79, 0x73, 0x69,
+	0x63, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x12, 0x70, 0x68, 0x79, 0x73, 0x69, 0x63,
+	0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x63, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f,
+	0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72,
+	0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x3c, 0x0a, 0x06, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x06, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x22, 0x38, 0x0a, 0x06, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x22, 0xc0, 0x02, 0x0a, 0x0a, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x53, 0x0a, 0x13, 0x70, 0x68, 0x79, 0x73,
+	0x69, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x12, 0x70, 0x68, 0x79, 0x73, 0x69,
+	0x63, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70,
+	0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x08, 0x64, 0x69, 0x73,
+	0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72,
+	0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61,
+	0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52,
+	0x08, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70,
+	0x72, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x40, 0x0a,
+	0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x22,
+	0x44, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x38, 0x0a, 0x05, 0x65,
+	0x78, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f,
+	0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x05,
+	0x65, 0x78, 0x70, 0x72, 0x73, 0x22, 0x46, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x05, 0x65, 0x78, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x05, 0x65, 0x78, 0x70, 0x72, 0x73, 0x22, 0xd2, 0x01,
+	0x0a, 0x0b, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x43, 0x0a,
+	0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x78, 0x70,
+	0x72, 0x73, 0x12, 0x3d, 0x0a, 0x08, 0x61, 0x67, 0x67, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x07, 0x61, 0x67, 0x67, 0x45, 0x78, 0x70,
+	0x72, 0x12, 0x3f, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x08, 0x61, 0x67, 0x67, 0x45, 0x78, 0x70,
+	0x72, 0x73, 0x22, 0x1d, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x1e, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x47, 0x0a, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x3c, 0x0a, 0x05,
+	0x65, 0x78, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x72,
+	0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61,
+	0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x45,
+	0x78, 0x70, 0x72, 0x52, 0x05, 0x65, 0x78, 0x70, 0x72, 0x73, 0x22, 0x62, 0x0a, 0x08, 0x53, 0x6f,
+	0x72, 0x74, 0x45, 0x78, 0x70, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xd3,
+	0x07, 0x0a, 0x04, 0x45, 0x78, 0x70, 0x72, 0x12, 0x3e, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64,
+	0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x48, 0x00, 0x52,
+	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x54, 0x0a, 0x0e, 0x64, 0x79, 0x6e, 0x61, 0x6d,
+	0x69, 0x63, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44,
+	0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x48, 0x00, 0x52, 0x0d,
+	0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x41, 0x0a,
+	0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69,
+	0x74, 0x65, 0x72, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c,
+	0x12, 0x42, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x12, 0x3f, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x48, 0x00, 0x52, 0x05,
+	0x75, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x66, 0x0a, 0x14, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a,
+	0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x48, 0x00, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x45, 0x0a, 0x09, 0x63, 0x61,
+	0x73, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x73,
+	0x65, 0x45, 0x78, 0x70, 0x72, 0x48, 0x00, 0x52, 0x08, 0x63, 0x61, 0x73, 0x65, 0x45, 0x78, 0x70,
+	0x72, 0x12, 0x38, 0x0a, 0x04, 0x63, 0x61, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x63, 0x61, 0x73, 0x74, 0x12, 0x57, 0x0a, 0x0f, 0x73,
+	0x63, 0x61, 0x6c, 0x61, 0x72, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x46, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5d, 0x0a, 0x11, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2e, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x48,
+	0x00, 0x52, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63,
+	0x61, 0x74, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x48, 0x00, 0x52,
+	0x08, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x07, 0x62, 0x65, 0x74,
+	0x77, 0x65, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x66, 0x72, 0x6f,
+	0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65,
+	0x6e, 0x48, 0x00, 0x52, 0x07, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x42, 0x06, 0x0a, 0x04,
+	0x65, 0x78, 0x70, 0x72, 0x22, 0x1c, 0x0a, 0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xec, 0x02, 0x0a, 0x07, 0x4c, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x12, 0x4b, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64,
+	0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x2e, 0x4e,
+	0x75, 0x6c, 0x6c, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1f, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x21, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x69,
+	0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0d, 0x66, 0x6c, 0x6f,
+	0x61, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x00, 0x52, 0x0c, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x23, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x62,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29, 0x0a, 0x0f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x06, 0x0a, 0x04, 0x4e, 0x75, 0x6c, 0x6c, 0x42, 0x07, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x0a, 0x42, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x45, 0x78, 0x70, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x30, 0x0a,
+	0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x66, 0x72, 0x6f, 0x73,
+	0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12,
+	0x38, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78,
+	0x70, 0x72, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x22, 0x75, 0x0a, 0x09, 0x55, 0x6e, 0x61,
+	0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x12, 0x30, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x20, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62,
+	0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72,
+	0x22, 0x99, 0x03, 0x0a, 0x13, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62,
+	0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73,
+	0x22, 0xc9, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x53, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f,
+	0x55, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x49,
+	0x4e, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x58, 0x10,
+	0x04, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x50, 0x50, 0x52, 0x4f, 0x58,
+	0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x43, 0x54, 0x10,
+	0x05, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x51, 0x55, 0x41, 0x4e, 0x54, 0x49,
+	0x4c, 0x45, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x51, 0x55, 0x41,
+	0x4e, 0x54, 0x49, 0x4c, 0x45, 0x53, 0x10, 0x07, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x56, 0x41, 0x52, 0x49, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x44, 0x44, 0x45, 0x56, 0x10, 0x09, 0x22, 0x55, 0x0a, 0x05,
+	0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x22, 0x85, 0x02, 0x0a, 0x08, 0x43, 0x61, 0x73, 0x65, 0x45, 0x78, 0x70, 0x72,
+	0x12, 0x45, 0x0a, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x61, 0x73, 0x65, 0x45, 0x78, 0x70, 0x72, 0x2e, 0x57, 0x68, 0x65, 0x6e, 0x54, 0x68, 0x65, 0x6e,
+	0x52, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x6c, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x6c, 0x73, 0x65, 0x1a,
+	0x7a, 0x0a, 0x08, 0x57, 0x68, 0x65, 0x6e, 0x54, 0x68, 0x65, 0x6e, 0x12, 0x36, 0x0a, 0x04, 0x77,
+	0x68, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73,
+	0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x77,
+	0x68, 0x65, 0x6e, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x68, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x74, 0x68, 0x65, 0x6e, 0x22, 0x7a, 0x0a, 0x04, 0x43,
+	0x61, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12, 0x3a, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x66, 0x72, 0x6f, 0x73,
+	0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xc3, 0x02, 0x0a, 0x0e, 0x53, 0x63, 0x61, 0x6c,
+	0x61, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74,
+	0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x36, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45,
+	0x78, 0x70, 0x72, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x50, 0x45, 0x52, 0x10, 0x02,
+	0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x43, 0x41, 0x54, 0x10,
+	0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48,
+	0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x42, 0x53, 0x10, 0x05,
+	0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x06,
+	0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x4f, 0x52, 0x10, 0x07,
+	0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x45, 0x49, 0x4c, 0x10, 0x08, 0x12,
+	0x0c, 0x0a, 0x08, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x47, 0x10, 0x09, 0x22, 0x66, 0x0a,
+	0x10, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74,
+	0x65, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61,
+	0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45,
+	0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x08, 0x43, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63,
+	0x65, 0x12, 0x38, 0x0a, 0x05, 0x65, 0x78, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x45, 0x78, 0x70, 0x72, 0x52, 0x05, 0x65, 0x78, 0x70, 0x72, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x07,
+	0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x12, 0x36, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12,
+	0x34, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72,
+	0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x36, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x2a, 0xae, 0x02,
+	0x0a, 0x02, 0x4f, 0x70, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x50, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x45, 0x51, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4f,
+	0x50, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50,
+	0x5f, 0x4c, 0x54, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x50, 0x5f, 0x4c, 0x54, 0x5f, 0x45,
+	0x51, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x47, 0x54, 0x10, 0x05, 0x12, 0x0c,
+	0x0a, 0x08, 0x4f, 0x50, 0x5f, 0x47, 0x54, 0x5f, 0x45, 0x51, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e,
+	0x4f, 0x50, 0x5f, 0x52, 0x45, 0x47, 0x45, 0x58, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x07,
+	0x12, 0x16, 0x0a, 0x12, 0x4f, 0x50, 0x5f, 0x52, 0x45, 0x47, 0x45, 0x58, 0x5f, 0x4e, 0x4f, 0x54,
+	0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x08, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x50, 0x5f, 0x41,
+	0x4e, 0x44, 0x10, 0x09, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x50, 0x5f, 0x41, 0x44, 0x44, 0x10, 0x0a,
+	0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x50, 0x5f, 0x53, 0x55, 0x42, 0x10, 0x0b, 0x12, 0x0a, 0x0a, 0x06,
+	0x4f, 0x50, 0x5f, 0x4d, 0x55, 0x4c, 0x10, 0x0c, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x50, 0x5f, 0x44,
+	0x49, 0x56, 0x10, 0x0d, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x50, 0x5f, 0x4d, 0x4f, 0x44, 0x10, 0x0e,
+	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x50, 0x5f, 0x4f, 0x52, 0x10, 0x0f, 0x12, 0x0a, 0x0a, 0x06, 0x4f,
+	0x50, 0x5f, 0x4e, 0x4f, 0x54, 0x10, 0x10, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x50, 0x5f, 0x53, 0x54,
+	0x41, 0x52, 0x54, 0x53, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x10, 0x11, 0x12, 0x10, 0x0a, 0x0c, 0x4f,
+	0x50, 0x5f, 0x45, 0x4e, 0x44, 0x53, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x10, 0x12, 0x12, 0x0f, 0x0a,
+	0x0b, 0x4f, 0x50, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x13, 0x2a, 0x9b,
+	0x01, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x44,
+	0x41, 0x54, 0x41, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13,
+	0x0a, 0x0f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x36,
+	0x34, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x41, 0x54,
+	0x41, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x36, 0x34, 0x10, 0x03,
+	0x12, 0x14, 0x0a, 0x10, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54,
+	0x52, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x05, 0x42, 0xa5, 0x02, 0x0a,
+	0x20, 0x63, 0x6f, 0x6d, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x42, 0x10, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x5d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x2f, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x67, 0x6f, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x3b, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x46, 0x4c, 0x58, 0xaa, 0x02, 0x1c, 0x46, 0x72, 0x6f,
+	0x73, 0x74, 0x64, 0x62, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x1c, 0x46, 0x72, 0x6f, 0x73,
+	0x74, 0x64, 0x62, 0x5c, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x5c,
+	0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x28, 0x46, 0x72, 0x6f, 0x73, 0x74,
+	0x64, 0x62, 0x5c, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x5c, 0x56,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x1e, 0x46, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x3a, 0x3a, 0x4c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x70, 0x6c, 0x61, 0x6e, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescOnce sync.Once
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescData = file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDesc
+)
+
+func file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescGZIP() []byte {
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescOnce.Do(func() {
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescData = protoimpl.X.CompressGZIP(file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescData)
+	})
+	return file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDescData
+}
+
+var file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_frostdb_logicalplan_v1alpha1_logicalplan_proto_goTypes = []interface{}{
+	(Op)(0),                       // 0: frostdb.logicalplan.v1alpha1.Op
+	(DataType)(0),                 // 1: frostdb.logicalplan.v1alpha1.DataType
+	(AggregationFunction_Type)(0), // 2: frostdb.logicalplan.v1alpha1.AggregationFunction.Type
+	(ScalarFunction_Type)(0),      // 3: frostdb.logicalplan.v1alpha1.ScalarFunction.Type
+	(*PlanNode)(nil),              // 4: frostdb.logicalplan.v1alpha1.PlanNode
+	(*TableScan)(nil),             // 5: frostdb.logicalplan.v1alpha1.TableScan
+	(*Sample)(nil),                // 6: frostdb.logicalplan.v1alpha1.Sample
+	(*SchemaScan)(nil),            // 7: frostdb.logicalplan.v1alpha1.SchemaScan
+	(*Filter)(nil),                // 8: frostdb.logicalplan.v1alpha1.Filter
+	(*Distinct)(nil),              // 9: frostdb.logicalplan.v1alpha1.Distinct
+	(*Projection)(nil),            // 10: frostdb.logicalplan.v1alpha1.Projection
+	(*Aggregation)(nil),           // 11: frostdb.logicalplan.v1alpha1.Aggregation
+	(*Limit)(nil),                 // 12: frostdb.logicalplan.v1alpha1.Limit
+	(*Offset)(nil),                // 13: frostdb.logicalplan.v1alpha1.Offset
+	(*OrderBy)(nil),               // 14: frostdb.logicalplan.v1alpha1.OrderBy
+	(*SortExpr)(nil),              // 15: frostdb.logicalplan.v1alpha1.SortExpr
+	(*Expr)(nil),                  // 16: frostdb.logicalplan.v1alpha1.Expr
+	(*Column)(nil),                // 17: frostdb.logicalplan.v1alpha1.Column
+	(*DynamicColumn)(nil),         // 18: frostdb.logicalplan.v1alpha1.DynamicColumn
+	(*Literal)(nil),               // 19: frostdb.logicalplan.v1alpha1.Literal
+	(*BinaryExpr)(nil),            // 20: frostdb.logicalplan.v1alpha1.BinaryExpr
+	(*UnaryExpr)(nil),             // 21: frostdb.logicalplan.v1alpha1.UnaryExpr
+	(*AggregationFunction)(nil),   // 22: frostdb.logicalplan.v1alpha1.AggregationFunction
+	(*Alias)(nil),                 // 23: frostdb.logicalplan.v1alpha1.Alias
+	(*CaseExpr)(nil),              // 24: frostdb.logicalplan.v1alpha1.CaseExpr
+	(*Cast)(nil),                  // 25: frostdb.logicalplan.v1alpha1.Cast
+	(*ScalarFunction)(nil),        // 26: frostdb.logicalplan.v1alpha1.ScalarFunction
+	(*DurationTruncate)(nil),      // 27: frostdb.logicalplan.v1alpha1.DurationTruncate
+	(*Coalesce)(nil),              // 28: frostdb.logicalplan.v1alpha1.Coalesce
+	(*Between)(nil),               // 29: frostdb.logicalplan.v1alpha1.Between
+	(*Literal_Null)(nil),          // 30: frostdb.logicalplan.v1alpha1.Literal.Null
+	(*CaseExpr_WhenThen)(nil),     // 31: frostdb.logicalplan.v1alpha1.CaseExpr.WhenThen
+}
+var file_frostdb_logicalplan_v1alpha1_logicalplan_proto_depIdxs = []int32{
+	4,  // 0: frostdb.logicalplan.v1alpha1.PlanNode.input:type_name -> frostdb.logicalplan.v1alpha1.PlanNode
+	5,  // 1: frostdb.logicalplan.v1alpha1.PlanNode.table_scan:type_name -> frostdb.logicalplan.v1alpha1.TableScan
+	7,  // 2: frostdb.logicalplan.v1alpha1.PlanNode.schema_scan:type_name -> frostdb.logicalplan.v1alpha1.SchemaScan
+	8,  // 3: frostdb.logicalplan.v1alpha1.PlanNode.filter:type_name -> frostdb.logicalplan.v1alpha1.Filter
+	9,  // 4: frostdb.logicalplan.v1alpha1.PlanNode.distinct:type_name -> frostdb.logicalplan.v1alpha1.Distinct
+	10, // 5: frostdb.logicalplan.v1alpha1.PlanNode.projection:type_name -> frostdb.logicalplan.v1alpha1.Projection
+	11, // 6: frostdb.logicalplan.v1alpha1.PlanNode.aggregation:type_name -> frostdb.logicalplan.v1alpha1.Aggregation
+	12, // 7: frostdb.logicalplan.v1alpha1.PlanNode.limit:type_name -> frostdb.logicalplan.v1alpha1.Limit
+	13, // 8: frostdb.logicalplan.v1alpha1.PlanNode.offset:type_name -> frostdb.logicalplan.v1alpha1.Offset
+	14, // 9: frostdb.logicalplan.v1alpha1.PlanNode.order_by:type_name -> frostdb.logicalplan.v1alpha1.OrderBy
+	16, // 10: frostdb.logicalplan.v1alpha1.TableScan.physical_projection:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 11: frostdb.logicalplan.v1alpha1.TableScan.filter:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 12: frostdb.logicalplan.v1alpha1.TableScan.distinct:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 13: frostdb.logicalplan.v1alpha1.TableScan.projection:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	6,  // 14: frostdb.logicalplan.v1alpha1.TableScan.sample:type_name -> frostdb.logicalplan.v1alpha1.Sample
+	16, // 15: frostdb.logicalplan.v1alpha1.SchemaScan.physical_projection:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 16: frostdb.logicalplan.v1alpha1.SchemaScan.filter:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 17: frostdb.logicalplan.v1alpha1.SchemaScan.distinct:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 18: frostdb.logicalplan.v1alpha1.SchemaScan.projection:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 19: frostdb.logicalplan.v1alpha1.Filter.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 20: frostdb.logicalplan.v1alpha1.Distinct.exprs:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 21: frostdb.logicalplan.v1alpha1.Projection.exprs:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 22: frostdb.logicalplan.v1alpha1.Aggregation.group_exprs:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 23: frostdb.logicalplan.v1alpha1.Aggregation.agg_expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 24: frostdb.logicalplan.v1alpha1.Aggregation.agg_exprs:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	15, // 25: frostdb.logicalplan.v1alpha1.OrderBy.exprs:type_name -> frostdb.logicalplan.v1alpha1.SortExpr
+	16, // 26: frostdb.logicalplan.v1alpha1.SortExpr.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	17, // 27: frostdb.logicalplan.v1alpha1.Expr.column:type_name -> frostdb.logicalplan.v1alpha1.Column
+	18, // 28: frostdb.logicalplan.v1alpha1.Expr.dynamic_column:type_name -> frostdb.logicalplan.v1alpha1.DynamicColumn
+	19, // 29: frostdb.logicalplan.v1alpha1.Expr.literal:type_name -> frostdb.logicalplan.v1alpha1.Literal
+	20, // 30: frostdb.logicalplan.v1alpha1.Expr.binary:type_name -> frostdb.logicalplan.v1alpha1.BinaryExpr
+	21, // 31: frostdb.logicalplan.v1alpha1.Expr.unary:type_name -> frostdb.logicalplan.v1alpha1.UnaryExpr
+	22, // 32: frostdb.logicalplan.v1alpha1.Expr.aggregation_function:type_name -> frostdb.logicalplan.v1alpha1.AggregationFunction
+	23, // 33: frostdb.logicalplan.v1alpha1.Expr.alias:type_name -> frostdb.logicalplan.v1alpha1.Alias
+	24, // 34: frostdb.logicalplan.v1alpha1.Expr.case_expr:type_name -> frostdb.logicalplan.v1alpha1.CaseExpr
+	25, // 35: frostdb.logicalplan.v1alpha1.Expr.cast:type_name -> frostdb.logicalplan.v1alpha1.Cast
+	26, // 36: frostdb.logicalplan.v1alpha1.Expr.scalar_function:type_name -> frostdb.logicalplan.v1alpha1.ScalarFunction
+	27, // 37: frostdb.logicalplan.v1alpha1.Expr.duration_truncate:type_name -> frostdb.logicalplan.v1alpha1.DurationTruncate
+	28, // 38: frostdb.logicalplan.v1alpha1.Expr.coalesce:type_name -> frostdb.logicalplan.v1alpha1.Coalesce
+	29, // 39: frostdb.logicalplan.v1alpha1.Expr.between:type_name -> frostdb.logicalplan.v1alpha1.Between
+	30, // 40: frostdb.logicalplan.v1alpha1.Literal.null_value:type_name -> frostdb.logicalplan.v1alpha1.Literal.Null
+	16, // 41: frostdb.logicalplan.v1alpha1.BinaryExpr.left:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	0,  // 42: frostdb.logicalplan.v1alpha1.BinaryExpr.op:type_name -> frostdb.logicalplan.v1alpha1.Op
+	16, // 43: frostdb.logicalplan.v1alpha1.BinaryExpr.right:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	0,  // 44: frostdb.logicalplan.v1alpha1.UnaryExpr.op:type_name -> frostdb.logicalplan.v1alpha1.Op
+	16, // 45: frostdb.logicalplan.v1alpha1.UnaryExpr.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	2,  // 46: frostdb.logicalplan.v1alpha1.AggregationFunction.type:type_name -> frostdb.logicalplan.v1alpha1.AggregationFunction.Type
+	16, // 47: frostdb.logicalplan.v1alpha1.AggregationFunction.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 48: frostdb.logicalplan.v1alpha1.Alias.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	31, // 49: frostdb.logicalplan.v1alpha1.CaseExpr.cases:type_name -> frostdb.logicalplan.v1alpha1.CaseExpr.WhenThen
+	16, // 50: frostdb.logicalplan.v1alpha1.CaseExpr.else:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 51: frostdb.logicalplan.v1alpha1.Cast.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	1,  // 52: frostdb.logicalplan.v1alpha1.Cast.type:type_name -> frostdb.logicalplan.v1alpha1.DataType
+	3,  // 53: frostdb.logicalplan.v1alpha1.ScalarFunction.type:type_name -> frostdb.logicalplan.v1alpha1.ScalarFunction.Type
+	16, // 54: frostdb.logicalplan.v1alpha1.ScalarFunction.args:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 55: frostdb.logicalplan.v1alpha1.DurationTruncate.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 56: frostdb.logicalplan.v1alpha1.Coalesce.exprs:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 57: frostdb.logicalplan.v1alpha1.Between.expr:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 58: frostdb.logicalplan.v1alpha1.Between.low:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 59: frostdb.logicalplan.v1alpha1.Between.high:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 60: frostdb.logicalplan.v1alpha1.CaseExpr.WhenThen.when:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	16, // 61: frostdb.logicalplan.v1alpha1.CaseExpr.WhenThen.then:type_name -> frostdb.logicalplan.v1alpha1.Expr
+	62, // [62:62] is the sub-list for method output_type
+	62, // [62:62] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
+}
+
+func init() { file_frostdb_logicalplan_v1alpha1_logicalplan_proto_init() }
+func file_frostdb_logicalplan_v1alpha1_logicalplan_proto_init() {
+	if File_frostdb_logicalplan_v1alpha1_logicalplan_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableScan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchemaScan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Distinct); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Projection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Limit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Offset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortExpr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Column); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DynamicColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Literal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BinaryExpr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnaryExpr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregationFunction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaseExpr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScalarFunction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DurationTruncate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Coalesce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Between); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Literal_Null); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaseExpr_WhenThen); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*PlanNode_TableScan)(nil),
+		(*PlanNode_SchemaScan)(nil),
+		(*PlanNode_Filter)(nil),
+		(*PlanNode_Distinct)(nil),
+		(*PlanNode_Projection)(nil),
+		(*PlanNode_Aggregation)(nil),
+		(*PlanNode_Limit)(nil),
+		(*PlanNode_Offset)(nil),
+		(*PlanNode_OrderBy)(nil),
+	}
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[12].OneofWrappers = []interface{}{
+		(*Expr_Column)(nil),
+		(*Expr_DynamicColumn)(nil),
+		(*Expr_Literal)(nil),
+		(*Expr_Binary)(nil),
+		(*Expr_Unary)(nil),
+		(*Expr_AggregationFunction)(nil),
+		(*Expr_Alias)(nil),
+		(*Expr_CaseExpr)(nil),
+		(*Expr_Cast)(nil),
+		(*Expr_ScalarFunction)(nil),
+		(*Expr_DurationTruncate)(nil),
+		(*Expr_Coalesce)(nil),
+		(*Expr_Between)(nil),
+	}
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes[15].OneofWrappers = []interface{}{
+		(*Literal_NullValue)(nil),
+		(*Literal_BoolValue)(nil),
+		(*Literal_Int64Value)(nil),
+		(*Literal_Uint64Value)(nil),
+		(*Literal_Float64Value)(nil),
+		(*Literal_StringValue)(nil),
+		(*Literal_BinaryValue)(nil),
+		(*Literal_TimestampValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_frostdb_logicalplan_v1alpha1_logicalplan_proto_goTypes,
+		DependencyIndexes: file_frostdb_logicalplan_v1alpha1_logicalplan_proto_depIdxs,
+		EnumInfos:         file_frostdb_logicalplan_v1alpha1_logicalplan_proto_enumTypes,
+		MessageInfos:      file_frostdb_logicalplan_v1alpha1_logicalplan_proto_msgTypes,
+	}.Build()
+	File_frostdb_logicalplan_v1alpha1_logicalplan_proto = out.File
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_rawDesc = nil
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_goTypes = nil
+	file_frostdb_logicalplan_v1alpha1_logicalplan_proto_depIdxs = nil
+}
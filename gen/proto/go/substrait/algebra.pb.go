@@ -0,0 +1,2967 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: substrait/algebra.proto
+
+// The subset of the relations and expressions of Substrait plans that frostdb
+// converts logical plans from and to. The messages, their field numbers and
+// their package are those of the Substrait specification.
+
+package substrait
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AggregationPhase is the phase of an aggregation.
+type AggregationPhase int32
+
+const (
+	// AGGREGATION_PHASE_UNSPECIFIED is an unspecified phase.
+	AggregationPhase_AGGREGATION_PHASE_UNSPECIFIED AggregationPhase = 0
+	// AGGREGATION_PHASE_INITIAL_TO_INTERMEDIATE computes partial results.
+	AggregationPhase_AGGREGATION_PHASE_INITIAL_TO_INTERMEDIATE AggregationPhase = 1
+	// AGGREGATION_PHASE_INTERMEDIATE_TO_INTERMEDIATE merges partial results.
+	AggregationPhase_AGGREGATION_PHASE_INTERMEDIATE_TO_INTERMEDIATE AggregationPhase = 2
+	// AGGREGATION_PHASE_INITIAL_TO_RESULT computes final results.
+	AggregationPhase_AGGREGATION_PHASE_INITIAL_TO_RESULT AggregationPhase = 3
+	// AGGREGATION_PHASE_INTERMEDIATE_TO_RESULT merges partial results into
+	// final results.
+	AggregationPhase_AGGREGATION_PHASE_INTERMEDIATE_TO_RESULT AggregationPhase = 4
+)
+
+// Enum value maps for AggregationPhase.
+var (
+	AggregationPhase_name = map[int32]string{
+		0: "AGGREGATION_PHASE_UNSPECIFIED",
+		1: "AGGREGATION_PHASE_INITIAL_TO_INTERMEDIATE",
+		2: "AGGREGATION_PHASE_INTERMEDIATE_TO_INTERMEDIATE",
+		3: "AGGREGATION_PHASE_INITIAL_TO_RESULT",
+		4: "AGGREGATION_PHASE_INTERMEDIATE_TO_RESULT",
+	}
+	AggregationPhase_value = map[string]int32{
+		"AGGREGATION_PHASE_UNSPECIFIED":                  0,
+		"AGGREGATION_PHASE_INITIAL_TO_INTERMEDIATE":      1,
+		"AGGREGATION_PHASE_INTERMEDIATE_TO_INTERMEDIATE": 2,
+		"AGGREGATION_PHASE_INITIAL_TO_RESULT":            3,
+		"AGGREGATION_PHASE_INTERMEDIATE_TO_RESULT":       4,
+	}
+)
+
+func (x AggregationPhase) Enum() *AggregationPhase {
+	p := new(AggregationPhase)
+	*p = x
+	return p
+}
+
+func (x AggregationPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregationPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_substrait_algebra_proto_enumTypes[0].Descriptor()
+}
+
+func (AggregationPhase) Type() protoreflect.EnumType {
+	return &file_substrait_algebra_proto_enumTypes[0]
+}
+
+func (x AggregationPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregationPhase.Descriptor instead.
+func (AggregationPhase) EnumDescriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{0}
+}
+
+// AggregationInvocation is whether only distinct values are aggregated.
+type AggregateFunction_AggregationInvocation int32
+
+const (
+	// AGGREGATION_INVOCATION_UNSPECIFIED aggregates all values.
+	AggregateFunction_AGGREGATION_INVOCATION_UNSPECIFIED AggregateFunction_AggregationInvocation = 0
+	// AGGREGATION_INVOCATION_ALL aggregates all values.
+	AggregateFunction_AGGREGATION_INVOCATION_ALL AggregateFunction_AggregationInvocation = 1
+	// AGGREGATION_INVOCATION_DISTINCT aggregates distinct values.
+	AggregateFunction_AGGREGATION_INVOCATION_DISTINCT AggregateFunction_AggregationInvocation = 2
+)
+
+// Enum value maps for AggregateFunction_AggregationInvocation.
+var (
+	AggregateFunction_AggregationInvocation_name = map[int32]string{
+		0: "AGGREGATION_INVOCATION_UNSPECIFIED",
+		1: "AGGREGATION_INVOCATION_ALL",
+		2: "AGGREGATION_INVOCATION_DISTINCT",
+	}
+	AggregateFunction_AggregationInvocation_value = map[string]int32{
+		"AGGREGATION_INVOCATION_UNSPECIFIED": 0,
+		"AGGREGATION_INVOCATION_ALL":         1,
+		"AGGREGATION_INVOCATION_DISTINCT":    2,
+	}
+)
+
+func (x AggregateFunction_AggregationInvocation) Enum() *AggregateFunction_AggregationInvocation {
+	p := new(AggregateFunction_AggregationInvocation)
+	*p = x
+	return p
+}
+
+func (x AggregateFunction_AggregationInvocation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregateFunction_AggregationInvocation) Descriptor() protoreflect.EnumDescriptor {
+	return file_substrait_algebra_proto_enumTypes[1].Descriptor()
+}
+
+func (AggregateFunction_AggregationInvocation) Type() protoreflect.EnumType {
+	return &file_substrait_algebra_proto_enumTypes[1]
+}
+
+func (x AggregateFunction_AggregationInvocation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregateFunction_AggregationInvocation.Descriptor instead.
+func (AggregateFunction_AggregationInvocation) EnumDescriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{10, 0}
+}
+
+// SortDirection is the order of rows.
+type SortField_SortDirection int32
+
+const (
+	// SORT_DIRECTION_UNSPECIFIED is an unspecified order.
+	SortField_SORT_DIRECTION_UNSPECIFIED SortField_SortDirection = 0
+	// SORT_DIRECTION_ASC_NULLS_FIRST is ascending, nulls first.
+	SortField_SORT_DIRECTION_ASC_NULLS_FIRST SortField_SortDirection = 1
+	// SORT_DIRECTION_ASC_NULLS_LAST is ascending, nulls last.
+	SortField_SORT_DIRECTION_ASC_NULLS_LAST SortField_SortDirection = 2
+	// SORT_DIRECTION_DESC_NULLS_FIRST is descending, nulls first.
+	SortField_SORT_DIRECTION_DESC_NULLS_FIRST SortField_SortDirection = 3
+	// SORT_DIRECTION_DESC_NULLS_LAST is descending, nulls last.
+	SortField_SORT_DIRECTION_DESC_NULLS_LAST SortField_SortDirection = 4
+	// SORT_DIRECTION_CLUSTERED groups equal values.
+	SortField_SORT_DIRECTION_CLUSTERED SortField_SortDirection = 5
+)
+
+// Enum value maps for SortField_SortDirection.
+var (
+	SortField_SortDirection_name = map[int32]string{
+		0: "SORT_DIRECTION_UNSPECIFIED",
+		1: "SORT_DIRECTION_ASC_NULLS_FIRST",
+		2: "SORT_DIRECTION_ASC_NULLS_LAST",
+		3: "SORT_DIRECTION_DESC_NULLS_FIRST",
+		4: "SORT_DIRECTION_DESC_NULLS_LAST",
+		5: "SORT_DIRECTION_CLUSTERED",
+	}
+	SortField_SortDirection_value = map[string]int32{
+		"SORT_DIRECTION_UNSPECIFIED":      0,
+		"SORT_DIRECTION_ASC_NULLS_FIRST":  1,
+		"SORT_DIRECTION_ASC_NULLS_LAST":   2,
+		"SORT_DIRECTION_DESC_NULLS_FIRST": 3,
+		"SORT_DIRECTION_DESC_NULLS_LAST":  4,
+		"SORT_DIRECTION_CLUSTERED":        5,
+	}
+)
+
+func (x SortField_SortDirection) Enum() *SortField_SortDirection {
+	p := new(SortField_SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortField_SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField_SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_substrait_algebra_proto_enumTypes[2].Descriptor()
+}
+
+func (SortField_SortDirection) Type() protoreflect.EnumType {
+	return &file_substrait_algebra_proto_enumTypes[2]
+}
+
+func (x SortField_SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField_SortDirection.Descriptor instead.
+func (SortField_SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{11, 0}
+}
+
+// RelCommon holds the fields shared by all relations.
+type RelCommon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// emit_kind is how the relation emits its fields.
+	//
+	// Types that are assignable to EmitKind:
+	//	*RelCommon_Direct_
+	//	*RelCommon_Emit_
+	EmitKind isRelCommon_EmitKind `protobuf_oneof:"emit_kind"`
+}
+
+func (x *RelCommon) Reset() {
+	*x = RelCommon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelCommon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelCommon) ProtoMessage() {}
+
+func (x *RelCommon) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelCommon.ProtoReflect.Descriptor instead.
+func (*RelCommon) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *RelCommon) GetEmitKind() isRelCommon_EmitKind {
+	if m != nil {
+		return m.EmitKind
+	}
+	return nil
+}
+
+func (x *RelCommon) GetDirect() *RelCommon_Direct {
+	if x, ok := x.GetEmitKind().(*RelCommon_Direct_); ok {
+		return x.Direct
+	}
+	return nil
+}
+
+func (x *RelCommon) GetEmit() *RelCommon_Emit {
+	if x, ok := x.GetEmitKind().(*RelCommon_Emit_); ok {
+		return x.Emit
+	}
+	return nil
+}
+
+type isRelCommon_EmitKind interface {
+	isRelCommon_EmitKind()
+}
+
+type RelCommon_Direct_ struct {
+	// direct emits all of the fields of the relation.
+	Direct *RelCommon_Direct `protobuf:"bytes,1,opt,name=direct,proto3,oneof"`
+}
+
+type RelCommon_Emit_ struct {
+	// emit emits a selection of the fields of the relation.
+	Emit *RelCommon_Emit `protobuf:"bytes,2,opt,name=emit,proto3,oneof"`
+}
+
+func (*RelCommon_Direct_) isRelCommon_EmitKind() {}
+
+func (*RelCommon_Emit_) isRelCommon_EmitKind() {}
+
+// ReadRel reads the rows of a table.
+type ReadRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// common holds the fields shared by all relations.
+	Common *RelCommon `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// base_schema is the schema of the table.
+	BaseSchema *NamedStruct `protobuf:"bytes,2,opt,name=base_schema,json=baseSchema,proto3" json:"base_schema,omitempty"`
+	// filter selects the rows that are read.
+	Filter *Expression `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// projection selects the fields that are read.
+	Projection *Expression_MaskExpression `protobuf:"bytes,4,opt,name=projection,proto3" json:"projection,omitempty"`
+	// read_type is where the rows are read from.
+	//
+	// Types that are assignable to ReadType:
+	//	*ReadRel_NamedTable_
+	ReadType isReadRel_ReadType `protobuf_oneof:"read_type"`
+}
+
+func (x *ReadRel) Reset() {
+	*x = ReadRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRel) ProtoMessage() {}
+
+func (x *ReadRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRel.ProtoReflect.Descriptor instead.
+func (*ReadRel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReadRel) GetCommon() *RelCommon {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *ReadRel) GetBaseSchema() *NamedStruct {
+	if x != nil {
+		return x.BaseSchema
+	}
+	return nil
+}
+
+func (x *ReadRel) GetFilter() *Expression {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ReadRel) GetProjection() *Expression_MaskExpression {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+func (m *ReadRel) GetReadType() isReadRel_ReadType {
+	if m != nil {
+		return m.ReadType
+	}
+	return nil
+}
+
+func (x *ReadRel) GetNamedTable() *ReadRel_NamedTable {
+	if x, ok := x.GetReadType().(*ReadRel_NamedTable_); ok {
+		return x.NamedTable
+	}
+	return nil
+}
+
+type isReadRel_ReadType interface {
+	isReadRel_ReadType()
+}
+
+type ReadRel_NamedTable_ struct {
+	// named_table is a table of the database.
+	NamedTable *ReadRel_NamedTable `protobuf:"bytes,7,opt,name=named_table,json=namedTable,proto3,oneof"`
+}
+
+func (*ReadRel_NamedTable_) isReadRel_ReadType() {}
+
+// ProjectRel appends the values of expressions to the fields of its input.
+type ProjectRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// common holds the fields shared by all relations.
+	Common *RelCommon `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// input is the relation whose rows are projected.
+	Input *Rel `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// expressions are the appended expressions.
+	Expressions []*Expression `protobuf:"bytes,3,rep,name=expressions,proto3" json:"expressions,omitempty"`
+}
+
+func (x *ProjectRel) Reset() {
+	*x = ProjectRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectRel) ProtoMessage() {}
+
+func (x *ProjectRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectRel.ProtoReflect.Descriptor instead.
+func (*ProjectRel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProjectRel) GetCommon() *RelCommon {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *ProjectRel) GetInput() *Rel {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *ProjectRel) GetExpressions() []*Expression {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
+// FilterRel selects the rows of its input.
+type FilterRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// common holds the fields shared by all relations.
+	Common *RelCommon `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// input is the relation whose rows are filtered.
+	Input *Rel `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// condition selects the rows.
+	Condition *Expression `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+}
+
+func (x *FilterRel) Reset() {
+	*x = FilterRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterRel) ProtoMessage() {}
+
+func (x *FilterRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterRel.ProtoReflect.Descriptor instead.
+func (*FilterRel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FilterRel) GetCommon() *RelCommon {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *FilterRel) GetInput() *Rel {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *FilterRel) GetCondition() *Expression {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+// FetchRel skips the first rows of its input and limits the number of the
+// other rows.
+type FetchRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// common holds the fields shared by all relations.
+	Common *RelCommon `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// input is the relation whose rows are fetched.
+	Input *Rel `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// offset is the number of skipped rows.
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// count is the maximum number of rows, -1 for all of them.
+	Count int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *FetchRel) Reset() {
+	*x = FetchRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRel) ProtoMessage() {}
+
+func (x *FetchRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRel.ProtoReflect.Descriptor instead.
+func (*FetchRel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FetchRel) GetCommon() *RelCommon {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *FetchRel) GetInput() *Rel {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *FetchRel) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FetchRel) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// AggregateRel groups the rows of its input and aggregates the rows of each
+// of the groups. Its fields are the grouping expressions followed by the
+// measures.
+type AggregateRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// common holds the fields shared by all relations.
+	Common *RelCommon `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// input is the relation whose rows are aggregated.
+	Input *Rel `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// groupings are the grouping sets.
+	Groupings []*AggregateRel_Grouping `protobuf:"bytes,3,rep,name=groupings,proto3" json:"groupings,omitempty"`
+	// measures are the aggregations.
+	Measures []*AggregateRel_Measure `protobuf:"bytes,4,rep,name=measures,proto3" json:"measures,omitempty"`
+}
+
+func (x *AggregateRel) Reset() {
+	*x = AggregateRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRel) ProtoMessage() {}
+
+func (x *AggregateRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRel.ProtoReflect.Descriptor instead.
+func (*AggregateRel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AggregateRel) GetCommon() *RelCommon {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *AggregateRel) GetInput() *Rel {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *AggregateRel) GetGroupings() []*AggregateRel_Grouping {
+	if x != nil {
+		return x.Groupings
+	}
+	return nil
+}
+
+func (x *AggregateRel) GetMeasures() []*AggregateRel_Measure {
+	if x != nil {
+		return x.Measures
+	}
+	return nil
+}
+
+// SortRel sorts the rows of its input.
+type SortRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// common holds the fields shared by all relations.
+	Common *RelCommon `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	// input is the relation whose rows are sorted.
+	Input *Rel `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// sorts are the fields the rows are sorted by.
+	Sorts []*SortField `protobuf:"bytes,3,rep,name=sorts,proto3" json:"sorts,omitempty"`
+}
+
+func (x *SortRel) Reset() {
+	*x = SortRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortRel) ProtoMessage() {}
+
+func (x *SortRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortRel.ProtoReflect.Descriptor instead.
+func (*SortRel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SortRel) GetCommon() *RelCommon {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *SortRel) GetInput() *Rel {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *SortRel) GetSorts() []*SortField {
+	if x != nil {
+		return x.Sorts
+	}
+	return nil
+}
+
+// Rel is a relation of a plan.
+type Rel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rel_type is the kind of the relation.
+	//
+	// Types that are assignable to RelType:
+	//	*Rel_Read
+	//	*Rel_Filter
+	//	*Rel_Fetch
+	//	*Rel_Aggregate
+	//	*Rel_Sort
+	//	*Rel_Project
+	RelType isRel_RelType `protobuf_oneof:"rel_type"`
+}
+
+func (x *Rel) Reset() {
+	*x = Rel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rel) ProtoMessage() {}
+
+func (x *Rel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rel.ProtoReflect.Descriptor instead.
+func (*Rel) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *Rel) GetRelType() isRel_RelType {
+	if m != nil {
+		return m.RelType
+	}
+	return nil
+}
+
+func (x *Rel) GetRead() *ReadRel {
+	if x, ok := x.GetRelType().(*Rel_Read); ok {
+		return x.Read
+	}
+	return nil
+}
+
+func (x *Rel) GetFilter() *FilterRel {
+	if x, ok := x.GetRelType().(*Rel_Filter); ok {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *Rel) GetFetch() *FetchRel {
+	if x, ok := x.GetRelType().(*Rel_Fetch); ok {
+		return x.Fetch
+	}
+	return nil
+}
+
+func (x *Rel) GetAggregate() *AggregateRel {
+	if x, ok := x.GetRelType().(*Rel_Aggregate); ok {
+		return x.Aggregate
+	}
+	return nil
+}
+
+func (x *Rel) GetSort() *SortRel {
+	if x, ok := x.GetRelType().(*Rel_Sort); ok {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *Rel) GetProject() *ProjectRel {
+	if x, ok := x.GetRelType().(*Rel_Project); ok {
+		return x.Project
+	}
+	return nil
+}
+
+type isRel_RelType interface {
+	isRel_RelType()
+}
+
+type Rel_Read struct {
+	// read reads a table.
+	Read *ReadRel `protobuf:"bytes,1,opt,name=read,proto3,oneof"`
+}
+
+type Rel_Filter struct {
+	// filter filters rows.
+	Filter *FilterRel `protobuf:"bytes,2,opt,name=filter,proto3,oneof"`
+}
+
+type Rel_Fetch struct {
+	// fetch skips and limits rows.
+	Fetch *FetchRel `protobuf:"bytes,3,opt,name=fetch,proto3,oneof"`
+}
+
+type Rel_Aggregate struct {
+	// aggregate aggregates rows.
+	Aggregate *AggregateRel `protobuf:"bytes,4,opt,name=aggregate,proto3,oneof"`
+}
+
+type Rel_Sort struct {
+	// sort sorts rows.
+	Sort *SortRel `protobuf:"bytes,5,opt,name=sort,proto3,oneof"`
+}
+
+type Rel_Project struct {
+	// project projects rows.
+	Project *ProjectRel `protobuf:"bytes,7,opt,name=project,proto3,oneof"`
+}
+
+func (*Rel_Read) isRel_RelType() {}
+
+func (*Rel_Filter) isRel_RelType() {}
+
+func (*Rel_Fetch) isRel_RelType() {}
+
+func (*Rel_Aggregate) isRel_RelType() {}
+
+func (*Rel_Sort) isRel_RelType() {}
+
+func (*Rel_Project) isRel_RelType() {}
+
+// FunctionArgument is an argument of a function.
+type FunctionArgument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// arg_type is the kind of the argument.
+	//
+	// Types that are assignable to ArgType:
+	//	*FunctionArgument_Value
+	ArgType isFunctionArgument_ArgType `protobuf_oneof:"arg_type"`
+}
+
+func (x *FunctionArgument) Reset() {
+	*x = FunctionArgument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FunctionArgument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionArgument) ProtoMessage() {}
+
+func (x *FunctionArgument) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionArgument.ProtoReflect.Descriptor instead.
+func (*FunctionArgument) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *FunctionArgument) GetArgType() isFunctionArgument_ArgType {
+	if m != nil {
+		return m.ArgType
+	}
+	return nil
+}
+
+func (x *FunctionArgument) GetValue() *Expression {
+	if x, ok := x.GetArgType().(*FunctionArgument_Value); ok {
+		return x.Value
+	}
+	return nil
+}
+
+type isFunctionArgument_ArgType interface {
+	isFunctionArgument_ArgType()
+}
+
+type FunctionArgument_Value struct {
+	// value is the value of an expression.
+	Value *Expression `protobuf:"bytes,3,opt,name=value,proto3,oneof"`
+}
+
+func (*FunctionArgument_Value) isFunctionArgument_ArgType() {}
+
+// Expression computes a value from the fields of a row.
+type Expression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rex_type is the kind of the expression.
+	//
+	// Types that are assignable to RexType:
+	//	*Expression_Literal_
+	//	*Expression_Selection
+	//	*Expression_ScalarFunction_
+	RexType isExpression_RexType `protobuf_oneof:"rex_type"`
+}
+
+func (x *Expression) Reset() {
+	*x = Expression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression) ProtoMessage() {}
+
+func (x *Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
+func (*Expression) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *Expression) GetRexType() isExpression_RexType {
+	if m != nil {
+		return m.RexType
+	}
+	return nil
+}
+
+func (x *Expression) GetLiteral() *Expression_Literal {
+	if x, ok := x.GetRexType().(*Expression_Literal_); ok {
+		return x.Literal
+	}
+	return nil
+}
+
+func (x *Expression) GetSelection() *Expression_FieldReference {
+	if x, ok := x.GetRexType().(*Expression_Selection); ok {
+		return x.Selection
+	}
+	return nil
+}
+
+func (x *Expression) GetScalarFunction() *Expression_ScalarFunction {
+	if x, ok := x.GetRexType().(*Expression_ScalarFunction_); ok {
+		return x.ScalarFunction
+	}
+	return nil
+}
+
+type isExpression_RexType interface {
+	isExpression_RexType()
+}
+
+type Expression_Literal_ struct {
+	// literal is a constant.
+	Literal *Expression_Literal `protobuf:"bytes,1,opt,name=literal,proto3,oneof"`
+}
+
+type Expression_Selection struct {
+	// selection is a field.
+	Selection *Expression_FieldReference `protobuf:"bytes,2,opt,name=selection,proto3,oneof"`
+}
+
+type Expression_ScalarFunction_ struct {
+	// scalar_function is a function of other expressions.
+	ScalarFunction *Expression_ScalarFunction `protobuf:"bytes,3,opt,name=scalar_function,json=scalarFunction,proto3,oneof"`
+}
+
+func (*Expression_Literal_) isExpression_RexType() {}
+
+func (*Expression_Selection) isExpression_RexType() {}
+
+func (*Expression_ScalarFunction_) isExpression_RexType() {}
+
+// AggregateFunction aggregates the values of its arguments.
+type AggregateFunction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// function_reference is the anchor of the declaration of the function.
+	FunctionReference uint32 `protobuf:"varint,1,opt,name=function_reference,json=functionReference,proto3" json:"function_reference,omitempty"`
+	// arguments are the arguments of the function.
+	Arguments []*FunctionArgument `protobuf:"bytes,7,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	// output_type is the type of the result.
+	OutputType *Type `protobuf:"bytes,5,opt,name=output_type,json=outputType,proto3" json:"output_type,omitempty"`
+	// phase is the phase of the aggregation.
+	Phase AggregationPhase `protobuf:"varint,4,opt,name=phase,proto3,enum=substrait.AggregationPhase" json:"phase,omitempty"`
+	// invocation is whether only distinct values are aggregated.
+	Invocation AggregateFunction_AggregationInvocation `protobuf:"varint,6,opt,name=invocation,proto3,enum=substrait.AggregateFunction_AggregationInvocation" json:"invocation,omitempty"`
+}
+
+func (x *AggregateFunction) Reset() {
+	*x = AggregateFunction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateFunction) ProtoMessage() {}
+
+func (x *AggregateFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateFunction.ProtoReflect.Descriptor instead.
+func (*AggregateFunction) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AggregateFunction) GetFunctionReference() uint32 {
+	if x != nil {
+		return x.FunctionReference
+	}
+	return 0
+}
+
+func (x *AggregateFunction) GetArguments() []*FunctionArgument {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *AggregateFunction) GetOutputType() *Type {
+	if x != nil {
+		return x.OutputType
+	}
+	return nil
+}
+
+func (x *AggregateFunction) GetPhase() AggregationPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return AggregationPhase_AGGREGATION_PHASE_UNSPECIFIED
+}
+
+func (x *AggregateFunction) GetInvocation() AggregateFunction_AggregationInvocation {
+	if x != nil {
+		return x.Invocation
+	}
+	return AggregateFunction_AGGREGATION_INVOCATION_UNSPECIFIED
+}
+
+// SortField sorts rows by an expression.
+type SortField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// expr is the expression the rows are sorted by.
+	Expr *Expression `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// sort_kind is how the rows are sorted.
+	//
+	// Types that are assignable to SortKind:
+	//	*SortField_Direction
+	SortKind isSortField_SortKind `protobuf_oneof:"sort_kind"`
+}
+
+func (x *SortField) Reset() {
+	*x = SortField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortField) ProtoMessage() {}
+
+func (x *SortField) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortField.ProtoReflect.Descriptor instead.
+func (*SortField) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SortField) GetExpr() *Expression {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (m *SortField) GetSortKind() isSortField_SortKind {
+	if m != nil {
+		return m.SortKind
+	}
+	return nil
+}
+
+func (x *SortField) GetDirection() SortField_SortDirection {
+	if x, ok := x.GetSortKind().(*SortField_Direction); ok {
+		return x.Direction
+	}
+	return SortField_SORT_DIRECTION_UNSPECIFIED
+}
+
+type isSortField_SortKind interface {
+	isSortField_SortKind()
+}
+
+type SortField_Direction struct {
+	// direction is the order of the rows.
+	Direction SortField_SortDirection `protobuf:"varint,2,opt,name=direction,proto3,enum=substrait.SortField_SortDirection,oneof"`
+}
+
+func (*SortField_Direction) isSortField_SortKind() {}
+
+// Direct emits all of the fields of the relation in order.
+type RelCommon_Direct struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RelCommon_Direct) Reset() {
+	*x = RelCommon_Direct{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelCommon_Direct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelCommon_Direct) ProtoMessage() {}
+
+func (x *RelCommon_Direct) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelCommon_Direct.ProtoReflect.Descriptor instead.
+func (*RelCommon_Direct) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Emit emits a selection of the fields of the relation.
+type RelCommon_Emit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// output_mapping are the indices of the emitted fields.
+	OutputMapping []int32 `protobuf:"varint,1,rep,packed,name=output_mapping,json=outputMapping,proto3" json:"output_mapping,omitempty"`
+}
+
+func (x *RelCommon_Emit) Reset() {
+	*x = RelCommon_Emit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelCommon_Emit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelCommon_Emit) ProtoMessage() {}
+
+func (x *RelCommon_Emit) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelCommon_Emit.ProtoReflect.Descriptor instead.
+func (*RelCommon_Emit) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *RelCommon_Emit) GetOutputMapping() []int32 {
+	if x != nil {
+		return x.OutputMapping
+	}
+	return nil
+}
+
+// NamedTable is a table of the database.
+type ReadRel_NamedTable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// names is the name of the table, qualified by its namespaces.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *ReadRel_NamedTable) Reset() {
+	*x = ReadRel_NamedTable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadRel_NamedTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRel_NamedTable) ProtoMessage() {}
+
+func (x *ReadRel_NamedTable) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRel_NamedTable.ProtoReflect.Descriptor instead.
+func (*ReadRel_NamedTable) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ReadRel_NamedTable) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// Grouping is a grouping set.
+type AggregateRel_Grouping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// grouping_expressions are the expressions the rows are grouped by.
+	GroupingExpressions []*Expression `protobuf:"bytes,1,rep,name=grouping_expressions,json=groupingExpressions,proto3" json:"grouping_expressions,omitempty"`
+}
+
+func (x *AggregateRel_Grouping) Reset() {
+	*x = AggregateRel_Grouping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateRel_Grouping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRel_Grouping) ProtoMessage() {}
+
+func (x *AggregateRel_Grouping) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRel_Grouping.ProtoReflect.Descriptor instead.
+func (*AggregateRel_Grouping) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *AggregateRel_Grouping) GetGroupingExpressions() []*Expression {
+	if x != nil {
+		return x.GroupingExpressions
+	}
+	return nil
+}
+
+// Measure is an aggregation.
+type AggregateRel_Measure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// measure is the aggregation.
+	Measure *AggregateFunction `protobuf:"bytes,1,opt,name=measure,proto3" json:"measure,omitempty"`
+	// filter selects the aggregated rows.
+	Filter *Expression `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *AggregateRel_Measure) Reset() {
+	*x = AggregateRel_Measure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateRel_Measure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRel_Measure) ProtoMessage() {}
+
+func (x *AggregateRel_Measure) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRel_Measure.ProtoReflect.Descriptor instead.
+func (*AggregateRel_Measure) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *AggregateRel_Measure) GetMeasure() *AggregateFunction {
+	if x != nil {
+		return x.Measure
+	}
+	return nil
+}
+
+func (x *AggregateRel_Measure) GetFilter() *Expression {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// Literal is a constant.
+type Expression_Literal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// literal_type is the type of the constant.
+	//
+	// Types that are assignable to LiteralType:
+	//	*Expression_Literal_Boolean
+	//	*Expression_Literal_I8
+	//	*Expression_Literal_I16
+	//	*Expression_Literal_I32
+	//	*Expression_Literal_I64
+	//	*Expression_Literal_Fp32
+	//	*Expression_Literal_Fp64
+	//	*Expression_Literal_String_
+	//	*Expression_Literal_Binary
+	//	*Expression_Literal_Timestamp
+	LiteralType isExpression_Literal_LiteralType `protobuf_oneof:"literal_type"`
+	// nullable is whether the type of the constant is nullable.
+	Nullable bool `protobuf:"varint,50,opt,name=nullable,proto3" json:"nullable,omitempty"`
+}
+
+func (x *Expression_Literal) Reset() {
+	*x = Expression_Literal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_Literal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_Literal) ProtoMessage() {}
+
+func (x *Expression_Literal) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_Literal.ProtoReflect.Descriptor instead.
+func (*Expression_Literal) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (m *Expression_Literal) GetLiteralType() isExpression_Literal_LiteralType {
+	if m != nil {
+		return m.LiteralType
+	}
+	return nil
+}
+
+func (x *Expression_Literal) GetBoolean() bool {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_Boolean); ok {
+		return x.Boolean
+	}
+	return false
+}
+
+func (x *Expression_Literal) GetI8() int32 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_I8); ok {
+		return x.I8
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetI16() int32 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_I16); ok {
+		return x.I16
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetI32() int32 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_I32); ok {
+		return x.I32
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetI64() int64 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_I64); ok {
+		return x.I64
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetFp32() float32 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_Fp32); ok {
+		return x.Fp32
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetFp64() float64 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_Fp64); ok {
+		return x.Fp64
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetString_() string {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_String_); ok {
+		return x.String_
+	}
+	return ""
+}
+
+func (x *Expression_Literal) GetBinary() []byte {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_Binary); ok {
+		return x.Binary
+	}
+	return nil
+}
+
+func (x *Expression_Literal) GetTimestamp() int64 {
+	if x, ok := x.GetLiteralType().(*Expression_Literal_Timestamp); ok {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Expression_Literal) GetNullable() bool {
+	if x != nil {
+		return x.Nullable
+	}
+	return false
+}
+
+type isExpression_Literal_LiteralType interface {
+	isExpression_Literal_LiteralType()
+}
+
+type Expression_Literal_Boolean struct {
+	// boolean is a boolean.
+	Boolean bool `protobuf:"varint,1,opt,name=boolean,proto3,oneof"`
+}
+
+type Expression_Literal_I8 struct {
+	// i8 is an 8 bit integer.
+	I8 int32 `protobuf:"varint,2,opt,name=i8,proto3,oneof"`
+}
+
+type Expression_Literal_I16 struct {
+	// i16 is a 16 bit integer.
+	I16 int32 `protobuf:"varint,3,opt,name=i16,proto3,oneof"`
+}
+
+type Expression_Literal_I32 struct {
+	// i32 is a 32 bit integer.
+	I32 int32 `protobuf:"varint,5,opt,name=i32,proto3,oneof"`
+}
+
+type Expression_Literal_I64 struct {
+	// i64 is a 64 bit integer.
+	I64 int64 `protobuf:"varint,7,opt,name=i64,proto3,oneof"`
+}
+
+type Expression_Literal_Fp32 struct {
+	// fp32 is a 32 bit floating point number.
+	Fp32 float32 `protobuf:"fixed32,10,opt,name=fp32,proto3,oneof"`
+}
+
+type Expression_Literal_Fp64 struct {
+	// fp64 is a 64 bit floating point number.
+	Fp64 float64 `protobuf:"fixed64,11,opt,name=fp64,proto3,oneof"`
+}
+
+type Expression_Literal_String_ struct {
+	// string is a UTF-8 string.
+	String_ string `protobuf:"bytes,12,opt,name=string,proto3,oneof"`
+}
+
+type Expression_Literal_Binary struct {
+	// binary is a byte string.
+	Binary []byte `protobuf:"bytes,13,opt,name=binary,proto3,oneof"`
+}
+
+type Expression_Literal_Timestamp struct {
+	// timestamp is a timestamp in microseconds.
+	Timestamp int64 `protobuf:"varint,14,opt,name=timestamp,proto3,oneof"`
+}
+
+func (*Expression_Literal_Boolean) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_I8) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_I16) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_I32) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_I64) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_Fp32) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_Fp64) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_String_) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_Binary) isExpression_Literal_LiteralType() {}
+
+func (*Expression_Literal_Timestamp) isExpression_Literal_LiteralType() {}
+
+// ScalarFunction applies a function to its arguments.
+type Expression_ScalarFunction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// function_reference is the anchor of the declaration of the function.
+	FunctionReference uint32 `protobuf:"varint,1,opt,name=function_reference,json=functionReference,proto3" json:"function_reference,omitempty"`
+	// arguments are the arguments of the function.
+	Arguments []*FunctionArgument `protobuf:"bytes,4,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	// output_type is the type of the result.
+	OutputType *Type `protobuf:"bytes,3,opt,name=output_type,json=outputType,proto3" json:"output_type,omitempty"`
+}
+
+func (x *Expression_ScalarFunction) Reset() {
+	*x = Expression_ScalarFunction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_ScalarFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_ScalarFunction) ProtoMessage() {}
+
+func (x *Expression_ScalarFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_ScalarFunction.ProtoReflect.Descriptor instead.
+func (*Expression_ScalarFunction) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 1}
+}
+
+func (x *Expression_ScalarFunction) GetFunctionReference() uint32 {
+	if x != nil {
+		return x.FunctionReference
+	}
+	return 0
+}
+
+func (x *Expression_ScalarFunction) GetArguments() []*FunctionArgument {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *Expression_ScalarFunction) GetOutputType() *Type {
+	if x != nil {
+		return x.OutputType
+	}
+	return nil
+}
+
+// ReferenceSegment selects a part of a value.
+type Expression_ReferenceSegment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// reference_type is the kind of the segment.
+	//
+	// Types that are assignable to ReferenceType:
+	//	*Expression_ReferenceSegment_StructField_
+	ReferenceType isExpression_ReferenceSegment_ReferenceType `protobuf_oneof:"reference_type"`
+}
+
+func (x *Expression_ReferenceSegment) Reset() {
+	*x = Expression_ReferenceSegment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_ReferenceSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_ReferenceSegment) ProtoMessage() {}
+
+func (x *Expression_ReferenceSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_ReferenceSegment.ProtoReflect.Descriptor instead.
+func (*Expression_ReferenceSegment) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 2}
+}
+
+func (m *Expression_ReferenceSegment) GetReferenceType() isExpression_ReferenceSegment_ReferenceType {
+	if m != nil {
+		return m.ReferenceType
+	}
+	return nil
+}
+
+func (x *Expression_ReferenceSegment) GetStructField() *Expression_ReferenceSegment_StructField {
+	if x, ok := x.GetReferenceType().(*Expression_ReferenceSegment_StructField_); ok {
+		return x.StructField
+	}
+	return nil
+}
+
+type isExpression_ReferenceSegment_ReferenceType interface {
+	isExpression_ReferenceSegment_ReferenceType()
+}
+
+type Expression_ReferenceSegment_StructField_ struct {
+	// struct_field selects a field of a struct.
+	StructField *Expression_ReferenceSegment_StructField `protobuf:"bytes,2,opt,name=struct_field,json=structField,proto3,oneof"`
+}
+
+func (*Expression_ReferenceSegment_StructField_) isExpression_ReferenceSegment_ReferenceType() {}
+
+// MaskExpression selects fields of a struct.
+type Expression_MaskExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// select selects the fields.
+	Select *Expression_MaskExpression_StructSelect `protobuf:"bytes,1,opt,name=select,proto3" json:"select,omitempty"`
+	// maintain_singular_struct keeps a single selected field in a struct.
+	MaintainSingularStruct bool `protobuf:"varint,2,opt,name=maintain_singular_struct,json=maintainSingularStruct,proto3" json:"maintain_singular_struct,omitempty"`
+}
+
+func (x *Expression_MaskExpression) Reset() {
+	*x = Expression_MaskExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_MaskExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_MaskExpression) ProtoMessage() {}
+
+func (x *Expression_MaskExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_MaskExpression.ProtoReflect.Descriptor instead.
+func (*Expression_MaskExpression) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 3}
+}
+
+func (x *Expression_MaskExpression) GetSelect() *Expression_MaskExpression_StructSelect {
+	if x != nil {
+		return x.Select
+	}
+	return nil
+}
+
+func (x *Expression_MaskExpression) GetMaintainSingularStruct() bool {
+	if x != nil {
+		return x.MaintainSingularStruct
+	}
+	return false
+}
+
+// FieldReference selects a field of a row.
+type Expression_FieldReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// reference_type is how the field is selected.
+	//
+	// Types that are assignable to ReferenceType:
+	//	*Expression_FieldReference_DirectReference
+	ReferenceType isExpression_FieldReference_ReferenceType `protobuf_oneof:"reference_type"`
+	// root_type is what the field is selected from.
+	//
+	// Types that are assignable to RootType:
+	//	*Expression_FieldReference_RootReference_
+	RootType isExpression_FieldReference_RootType `protobuf_oneof:"root_type"`
+}
+
+func (x *Expression_FieldReference) Reset() {
+	*x = Expression_FieldReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_FieldReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_FieldReference) ProtoMessage() {}
+
+func (x *Expression_FieldReference) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_FieldReference.ProtoReflect.Descriptor instead.
+func (*Expression_FieldReference) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 4}
+}
+
+func (m *Expression_FieldReference) GetReferenceType() isExpression_FieldReference_ReferenceType {
+	if m != nil {
+		return m.ReferenceType
+	}
+	return nil
+}
+
+func (x *Expression_FieldReference) GetDirectReference() *Expression_ReferenceSegment {
+	if x, ok := x.GetReferenceType().(*Expression_FieldReference_DirectReference); ok {
+		return x.DirectReference
+	}
+	return nil
+}
+
+func (m *Expression_FieldReference) GetRootType() isExpression_FieldReference_RootType {
+	if m != nil {
+		return m.RootType
+	}
+	return nil
+}
+
+func (x *Expression_FieldReference) GetRootReference() *Expression_FieldReference_RootReference {
+	if x, ok := x.GetRootType().(*Expression_FieldReference_RootReference_); ok {
+		return x.RootReference
+	}
+	return nil
+}
+
+type isExpression_FieldReference_ReferenceType interface {
+	isExpression_FieldReference_ReferenceType()
+}
+
+type Expression_FieldReference_DirectReference struct {
+	// direct_reference selects the field by its index.
+	DirectReference *Expression_ReferenceSegment `protobuf:"bytes,1,opt,name=direct_reference,json=directReference,proto3,oneof"`
+}
+
+func (*Expression_FieldReference_DirectReference) isExpression_FieldReference_ReferenceType() {}
+
+type isExpression_FieldReference_RootType interface {
+	isExpression_FieldReference_RootType()
+}
+
+type Expression_FieldReference_RootReference_ struct {
+	// root_reference is the row of the input of the relation.
+	RootReference *Expression_FieldReference_RootReference `protobuf:"bytes,4,opt,name=root_reference,json=rootReference,proto3,oneof"`
+}
+
+func (*Expression_FieldReference_RootReference_) isExpression_FieldReference_RootType() {}
+
+// StructField selects a field of a struct.
+type Expression_ReferenceSegment_StructField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// field is the index of the field.
+	Field int32 `protobuf:"varint,1,opt,name=field,proto3" json:"field,omitempty"`
+	// child selects a part of the field.
+	Child *Expression_ReferenceSegment `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+}
+
+func (x *Expression_ReferenceSegment_StructField) Reset() {
+	*x = Expression_ReferenceSegment_StructField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_ReferenceSegment_StructField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_ReferenceSegment_StructField) ProtoMessage() {}
+
+func (x *Expression_ReferenceSegment_StructField) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_ReferenceSegment_StructField.ProtoReflect.Descriptor instead.
+func (*Expression_ReferenceSegment_StructField) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 2, 0}
+}
+
+func (x *Expression_ReferenceSegment_StructField) GetField() int32 {
+	if x != nil {
+		return x.Field
+	}
+	return 0
+}
+
+func (x *Expression_ReferenceSegment_StructField) GetChild() *Expression_ReferenceSegment {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+// StructSelect selects fields of a struct.
+type Expression_MaskExpression_StructSelect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// struct_items are the selected fields.
+	StructItems []*Expression_MaskExpression_StructItem `protobuf:"bytes,1,rep,name=struct_items,json=structItems,proto3" json:"struct_items,omitempty"`
+}
+
+func (x *Expression_MaskExpression_StructSelect) Reset() {
+	*x = Expression_MaskExpression_StructSelect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_MaskExpression_StructSelect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_MaskExpression_StructSelect) ProtoMessage() {}
+
+func (x *Expression_MaskExpression_StructSelect) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_MaskExpression_StructSelect.ProtoReflect.Descriptor instead.
+func (*Expression_MaskExpression_StructSelect) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 3, 0}
+}
+
+func (x *Expression_MaskExpression_StructSelect) GetStructItems() []*Expression_MaskExpression_StructItem {
+	if x != nil {
+		return x.StructItems
+	}
+	return nil
+}
+
+// StructItem selects a field of a struct.
+type Expression_MaskExpression_StructItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// field is the index of the field.
+	Field int32 `protobuf:"varint,1,opt,name=field,proto3" json:"field,omitempty"`
+}
+
+func (x *Expression_MaskExpression_StructItem) Reset() {
+	*x = Expression_MaskExpression_StructItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_MaskExpression_StructItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_MaskExpression_StructItem) ProtoMessage() {}
+
+func (x *Expression_MaskExpression_StructItem) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_MaskExpression_StructItem.ProtoReflect.Descriptor instead.
+func (*Expression_MaskExpression_StructItem) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 3, 1}
+}
+
+func (x *Expression_MaskExpression_StructItem) GetField() int32 {
+	if x != nil {
+		return x.Field
+	}
+	return 0
+}
+
+// RootReference is the row of the input of the relation.
+type Expression_FieldReference_RootReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Expression_FieldReference_RootReference) Reset() {
+	*x = Expression_FieldReference_RootReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_algebra_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression_FieldReference_RootReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression_FieldReference_RootReference) ProtoMessage() {}
+
+func (x *Expression_FieldReference_RootReference) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_algebra_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression_FieldReference_RootReference.ProtoReflect.Descriptor instead.
+func (*Expression_FieldReference_RootReference) Descriptor() ([]byte, []int) {
+	return file_substrait_algebra_proto_rawDescGZIP(), []int{9, 4, 0}
+}
+
+var File_substrait_algebra_proto protoreflect.FileDescriptor
+
+var file_substrait_algebra_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x61, 0x6c, 0x67, 0x65,
+	0x62, 0x72, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x1a, 0x14, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9, 0x01, 0x0a, 0x09, 0x52,
+	0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x06, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x06, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x12,
+	0x2f, 0x0a, 0x04, 0x65, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x43, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x04, 0x65, 0x6d, 0x69, 0x74,
+	0x1a, 0x08, 0x0a, 0x06, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x1a, 0x2d, 0x0a, 0x04, 0x45, 0x6d,
+	0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0d, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x42, 0x0b, 0x0a, 0x09, 0x65, 0x6d, 0x69,
+	0x74, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xd8, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52,
+	0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x12, 0x37, 0x0a, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x74, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0a, 0x62,
+	0x61, 0x73, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x2d, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73,
+	0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40,
+	0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x6c, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x1a, 0x22, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x22, 0x99, 0x01, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x6c,
+	0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c,
+	0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x24,
+	0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x52, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x94, 0x01,
+	0x0a, 0x09, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12,
+	0x33, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8c, 0x01, 0x0a, 0x08, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65,
+	0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12,
+	0x24, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x52, 0x05,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xa7, 0x03, 0x0a, 0x0c, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74,
+	0x2e, 0x52, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65,
+	0x6c, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x3e, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x6c, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x65, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x6c, 0x2e, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x61,
+	0x73, 0x75, 0x72, 0x65, 0x73, 0x1a, 0x54, 0x0a, 0x08, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e,
+	0x67, 0x12, 0x48, 0x0a, 0x14, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67,
+	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x70, 0x0a, 0x07, 0x4d,
+	0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x6d, 0x65, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x46, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x2d,
+	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x89, 0x01,
+	0x0a, 0x07, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52,
+	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x2a, 0x0a,
+	0x05, 0x73, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73,
+	0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x52, 0x05, 0x73, 0x6f, 0x72, 0x74, 0x73, 0x22, 0xae, 0x02, 0x0a, 0x03, 0x52, 0x65,
+	0x6c, 0x12, 0x28, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x61, 0x64,
+	0x52, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x04, 0x72, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x6c, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x05, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x6c, 0x48,
+	0x00, 0x52, 0x05, 0x66, 0x65, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73,
+	0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x42, 0x0a,
+	0x0a, 0x08, 0x72, 0x65, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x4d, 0x0a, 0x10, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2d,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0a, 0x0a,
+	0x08, 0x61, 0x72, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0xb3, 0x0b, 0x0a, 0x0a, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x07, 0x6c, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x2e, 0x4c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x12, 0x44, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x09,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x0f, 0x73, 0x63, 0x61,
+	0x6c, 0x61, 0x72, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x63, 0x61, 0x6c,
+	0x61, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x9f, 0x02, 0x0a, 0x07, 0x4c,
+	0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65,
+	0x61, 0x6e, 0x12, 0x10, 0x0a, 0x02, 0x69, 0x38, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
+	0x52, 0x02, 0x69, 0x38, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x31, 0x36, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x48, 0x00, 0x52, 0x03, 0x69, 0x31, 0x36, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x03, 0x69, 0x33, 0x32, 0x12, 0x12, 0x0a, 0x03,
+	0x69, 0x36, 0x34, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x69, 0x36, 0x34,
+	0x12, 0x14, 0x0a, 0x04, 0x66, 0x70, 0x33, 0x32, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00,
+	0x52, 0x04, 0x66, 0x70, 0x33, 0x32, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x70, 0x36, 0x34, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x04, 0x66, 0x70, 0x36, 0x34, 0x12, 0x18, 0x0a, 0x06,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x12, 0x1e, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x32, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0e, 0x0a, 0x0c,
+	0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x1a, 0xac, 0x01, 0x0a,
+	0x0e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2d, 0x0a, 0x12, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x39,
+	0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09,
+	0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x0b, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0xe0, 0x01, 0x0a, 0x10,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x57, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x1a, 0x61, 0x0a, 0x0b, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x3c,
+	0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x42, 0x10, 0x0a, 0x0e,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x9d,
+	0x02, 0x0a, 0x0e, 0x4d, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x49, 0x0a, 0x06, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x31, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x52, 0x06, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x38, 0x0a, 0x18,
+	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x6e, 0x67, 0x75, 0x6c, 0x61,
+	0x72, 0x5f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16,
+	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x53, 0x69, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x1a, 0x62, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x52, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73,
+	0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x0b, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x22, 0x0a, 0x0a, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x1a, 0xf2,
+	0x01, 0x0a, 0x0e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x53, 0x0a, 0x10, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0e, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x48, 0x01, 0x52, 0x0d, 0x72, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x1a, 0x0f, 0x0a, 0x0d, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22,
+	0xbd, 0x03, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x46, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x11, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x74, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x30, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x31, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x69, 0x6e,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x15, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x22, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x47,
+	0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x4f, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x47,
+	0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x4f, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x43, 0x54, 0x10, 0x02, 0x22,
+	0xe7, 0x02, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x29, 0x0a,
+	0x04, 0x65, 0x78, 0x70, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x12, 0x42, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdd, 0x01, 0x0a,
+	0x0d, 0x53, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e,
+	0x0a, 0x1a, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22,
+	0x0a, 0x1e, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x41, 0x53, 0x43, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x53, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54,
+	0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x53, 0x43, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x53, 0x5f, 0x4c,
+	0x41, 0x53, 0x54, 0x10, 0x02, 0x12, 0x23, 0x0a, 0x1f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x49,
+	0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x53, 0x43, 0x5f, 0x4e, 0x55, 0x4c,
+	0x4c, 0x53, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x4f,
+	0x52, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x53,
+	0x43, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x53, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x10, 0x04, 0x12, 0x1c,
+	0x0a, 0x18, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x05, 0x42, 0x0b, 0x0a, 0x09,
+	0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x2a, 0xef, 0x01, 0x0a, 0x10, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x21,
+	0x0a, 0x1d, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x48,
+	0x41, 0x53, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x2d, 0x0a, 0x29, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x54,
+	0x4f, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x54, 0x45, 0x10, 0x01,
+	0x12, 0x32, 0x0a, 0x2e, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4d, 0x45, 0x44, 0x49, 0x41,
+	0x54, 0x45, 0x5f, 0x54, 0x4f, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4d, 0x45, 0x44, 0x49, 0x41,
+	0x54, 0x45, 0x10, 0x02, 0x12, 0x27, 0x0a, 0x23, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41,
+	0x4c, 0x5f, 0x54, 0x4f, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x10, 0x03, 0x12, 0x2c, 0x0a,
+	0x28, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x48, 0x41,
+	0x53, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x54, 0x45, 0x5f,
+	0x54, 0x4f, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x10, 0x04, 0x42, 0xa1, 0x01, 0x0a, 0x0d,
+	0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x42, 0x0c, 0x41,
+	0x6c, 0x67, 0x65, 0x62, 0x72, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x40, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x3b, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xa2,
+	0x02, 0x01, 0x58, 0xaa, 0x02, 0x09, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xca,
+	0x02, 0x09, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xe2, 0x02, 0x15, 0x53, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x09, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_substrait_algebra_proto_rawDescOnce sync.Once
+	file_substrait_algebra_proto_rawDescData = file_substrait_algebra_proto_rawDesc
+)
+
+func file_substrait_algebra_proto_rawDescGZIP() []byte {
+	file_substrait_algebra_proto_rawDescOnce.Do(func() {
+		file_substrait_algebra_proto_rawDescData = protoimpl.X.CompressGZIP(file_substrait_algebra_proto_rawDescData)
+	})
+	return file_substrait_algebra_proto_rawDescData
+}
+
+var file_substrait_algebra_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_substrait_algebra_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_substrait_algebra_proto_goTypes = []interface{}{
+	(AggregationPhase)(0),                           // 0: substrait.AggregationPhase
+	(AggregateFunction_AggregationInvocation)(0),    // 1: substrait.AggregateFunction.AggregationInvocation
+	(SortField_SortDirection)(0),                    // 2: substrait.SortField.SortDirection
+	(*RelCommon)(nil),                               // 3: substrait.RelCommon
+	(*ReadRel)(nil),                                 // 4: substrait.ReadRel
+	(*ProjectRel)(nil),                              // 5: substrait.ProjectRel
+	(*FilterRel)(nil),                               // 6: substrait.FilterRel
+	(*FetchRel)(nil),                                // 7: substrait.FetchRel
+	(*AggregateRel)(nil),                            // 8: substrait.AggregateRel
+	(*SortRel)(nil),                                 // 9: substrait.SortRel
+	(*Rel)(nil),                                     // 10: substrait.Rel
+	(*FunctionArgument)(nil),                        // 11: substrait.FunctionArgument
+	(*Expression)(nil),                              // 12: substrait.Expression
+	(*AggregateFunction)(nil),                       // 13: substrait.AggregateFunction
+	(*SortField)(nil),                               // 14: substrait.SortField
+	(*RelCommon_Direct)(nil),                        // 15: substrait.RelCommon.Direct
+	(*RelCommon_Emit)(nil),                          // 16: substrait.RelCommon.Emit
+	(*ReadRel_NamedTable)(nil),                      // 17: substrait.ReadRel.NamedTable
+	(*AggregateRel_Grouping)(nil),                   // 18: substrait.AggregateRel.Grouping
+	(*AggregateRel_Measure)(nil),                    // 19: substrait.AggregateRel.Measure
+	(*Expression_Literal)(nil),                      // 20: substrait.Expression.Literal
+	(*Expression_ScalarFunction)(nil),               // 21: substrait.Expression.ScalarFunction
+	(*Expression_ReferenceSegment)(nil),             // 22: substrait.Expression.ReferenceSegment
+	(*Expression_MaskExpression)(nil),               // 23: substrait.Expression.MaskExpression
+	(*Expression_FieldReference)(nil),               // 24: substrait.Expression.FieldReference
+	(*Expression_ReferenceSegment_StructField)(nil), // 25: substrait.Expression.ReferenceSegment.StructField
+	(*Expression_MaskExpression_StructSelect)(nil),  // 26: substrait.Expression.MaskExpression.StructSelect
+	(*Expression_MaskExpression_StructItem)(nil),    // 27: substrait.Expression.MaskExpression.StructItem
+	(*Expression_FieldReference_RootReference)(nil), // 28: substrait.Expression.FieldReference.RootReference
+	(*NamedStruct)(nil),                             // 29: substrait.NamedStruct
+	(*Type)(nil),                                    // 30: substrait.Type
+}
+var file_substrait_algebra_proto_depIdxs = []int32{
+	15, // 0: substrait.RelCommon.direct:type_name -> substrait.RelCommon.Direct
+	16, // 1: substrait.RelCommon.emit:type_name -> substrait.RelCommon.Emit
+	3,  // 2: substrait.ReadRel.common:type_name -> substrait.RelCommon
+	29, // 3: substrait.ReadRel.base_schema:type_name -> substrait.NamedStruct
+	12, // 4: substrait.ReadRel.filter:type_name -> substrait.Expression
+	23, // 5: substrait.ReadRel.projection:type_name -> substrait.Expression.MaskExpression
+	17, // 6: substrait.ReadRel.named_table:type_name -> substrait.ReadRel.NamedTable
+	3,  // 7: substrait.ProjectRel.common:type_name -> substrait.RelCommon
+	10, // 8: substrait.ProjectRel.input:type_name -> substrait.Rel
+	12, // 9: substrait.ProjectRel.expressions:type_name -> substrait.Expression
+	3,  // 10: substrait.FilterRel.common:type_name -> substrait.RelCommon
+	10, // 11: substrait.FilterRel.input:type_name -> substrait.Rel
+	12, // 12: substrait.FilterRel.condition:type_name -> substrait.Expression
+	3,  // 13: substrait.FetchRel.common:type_name -> substrait.RelCommon
+	10, // 14: substrait.FetchRel.input:type_name -> substrait.Rel
+	3,  // 15: substrait.AggregateRel.common:type_name -> substrait.RelCommon
+	10, // 16: substrait.AggregateRel.input:type_name -> substrait.Rel
+	18, // 17: substrait.AggregateRel.groupings:type_name -> substrait.AggregateRel.Grouping
+	19, // 18: substrait.AggregateRel.measures:type_name -> substrait.AggregateRel.Measure
+	3,  // 19: substrait.SortRel.common:type_name -> substrait.RelCommon
+	10, // 20: substrait.SortRel.input:type_name -> substrait.Rel
+	14, // 21: substrait.SortRel.sorts:type_name -> substrait.SortField
+	4,  // 22: substrait.Rel.read:type_name -> substrait.ReadRel
+	6,  // 23: substrait.Rel.filter:type_name -> substrait.FilterRel
+	7,  // 24: substrait.Rel.fetch:type_name -> substrait.FetchRel
+	8,  // 25: substrait.Rel.aggregate:type_name -> substrait.AggregateRel
+	9,  // 26: substrait.Rel.sort:type_name -> substrait.SortRel
+	5,  // 27: substrait.Rel.project:type_name -> substrait.ProjectRel
+	12, // 28: substrait.FunctionArgument.value:type_name -> substrait.Expression
+	20, // 29: substrait.Expression.literal:type_name -> substrait.Expression.Literal
+	24, // 30: substrait.Expression.selection:type_name -> substrait.Expression.FieldReference
+	21, // 31: substrait.Expression.scalar_function:type_name -> substrait.Expression.ScalarFunction
+	11, // 32: substrait.AggregateFunction.arguments:type_name -> substrait.FunctionArgument
+	30, // 33: substrait.AggregateFunction.output_type:type_name -> substrait.Type
+	0,  // 34: substrait.AggregateFunction.phase:type_name -> substrait.AggregationPhase
+	1,  // 35: substrait.AggregateFunction.invocation:type_name -> substrait.AggregateFunction.AggregationInvocation
+	12, // 36: substrait.SortField.expr:type_name -> substrait.Expression
+	2,  // 37: substrait.SortField.direction:type_name -> substrait.SortField.SortDirection
+	12, // 38: substrait.AggregateRel.Grouping.grouping_expressions:type_name -> substrait.Expression
+	13, // 39: substrait.AggregateRel.Measure.measure:type_name -> substrait.AggregateFunction
+	12, // 40: substrait.AggregateRel.Measure.filter:type_name -> substrait.Expression
+	11, // 41: substrait.Expression.ScalarFunction.arguments:type_name -> substrait.FunctionArgument
+	30, // 42: substrait.Expression.ScalarFunction.output_type:type_name -> substrait.Type
+	25, // 43: substrait.Expression.ReferenceSegment.struct_field:type_name -> substrait.Expression.ReferenceSegment.StructField
+	26, // 44: substrait.Expression.MaskExpression.select:type_name -> substrait.Expression.MaskExpression.StructSelect
+	22, // 45: substrait.Expression.FieldReference.direct_reference:type_name -> substrait.Expression.ReferenceSegment
+	28, // 46: substrait.Expression.FieldReference.root_reference:type_name -> substrait.Expression.FieldReference.RootReference
+	22, // 47: substrait.Expression.ReferenceSegment.StructField.child:type_name -> substrait.Expression.ReferenceSegment
+	27, // 48: substrait.Expression.MaskExpression.StructSelect.struct_items:type_name -> substrait.Expression.MaskExpression.StructItem
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
+}
+
+func init() { file_substrait_algebra_proto_init() }
+func file_substrait_algebra_proto_init() {
+	if File_substrait_algebra_proto != nil {
+		return
+	}
+	file_substrait_type_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_substrait_algebra_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelCommon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProjectRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FunctionArgument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateFunction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelCommon_Direct); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelCommon_Emit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadRel_NamedTable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateRel_Grouping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateRel_Measure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_Literal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_ScalarFunction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_ReferenceSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_MaskExpression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_FieldReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_ReferenceSegment_StructField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_MaskExpression_StructSelect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_MaskExpression_StructItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_algebra_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expression_FieldReference_RootReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_substrait_algebra_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*RelCommon_Direct_)(nil),
+		(*RelCommon_Emit_)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*ReadRel_NamedTable_)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*Rel_Read)(nil),
+		(*Rel_Filter)(nil),
+		(*Rel_Fetch)(nil),
+		(*Rel_Aggregate)(nil),
+		(*Rel_Sort)(nil),
+		(*Rel_Project)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*FunctionArgument_Value)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*Expression_Literal_)(nil),
+		(*Expression_Selection)(nil),
+		(*Expression_ScalarFunction_)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*SortField_Direction)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*Expression_Literal_Boolean)(nil),
+		(*Expression_Literal_I8)(nil),
+		(*Expression_Literal_I16)(nil),
+		(*Expression_Literal_I32)(nil),
+		(*Expression_Literal_I64)(nil),
+		(*Expression_Literal_Fp32)(nil),
+		(*Expression_Literal_Fp64)(nil),
+		(*Expression_Literal_String_)(nil),
+		(*Expression_Literal_Binary)(nil),
+		(*Expression_Literal_Timestamp)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[19].OneofWrappers = []interface{}{
+		(*Expression_ReferenceSegment_StructField_)(nil),
+	}
+	file_substrait_algebra_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*Expression_FieldReference_DirectReference)(nil),
+		(*Expression_FieldReference_RootReference_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_substrait_algebra_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_substrait_algebra_proto_goTypes,
+		DependencyIndexes: file_substrait_algebra_proto_depIdxs,
+		EnumInfos:         file_substrait_algebra_proto_enumTypes,
+		MessageInfos:      file_substrait_algebra_proto_msgTypes,
+	}.Build()
+	File_substrait_algebra_proto = out.File
+	file_substrait_algebra_proto_rawDesc = nil
+	file_substrait_algebra_proto_goTypes = nil
+	file_substrait_algebra_proto_depIdxs = nil
+}
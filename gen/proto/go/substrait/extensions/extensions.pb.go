@@ -0,0 +1,363 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: substrait/extensions/extensions.proto
+
+// The subset of the extension declarations of Substrait plans that frostdb
+// converts logical plans from and to. The messages, their field numbers and
+// their package are those of the Substrait specification.
+
+package extensions
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SimpleExtensionURI declares a YAML file of extension functions.
+type SimpleExtensionURI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// extension_uri_anchor is the reference of the URI in the plan.
+	ExtensionUriAnchor uint32 `protobuf:"varint,1,opt,name=extension_uri_anchor,json=extensionUriAnchor,proto3" json:"extension_uri_anchor,omitempty"`
+	// uri is the URI of the YAML file.
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *SimpleExtensionURI) Reset() {
+	*x = SimpleExtensionURI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_extensions_extensions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimpleExtensionURI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimpleExtensionURI) ProtoMessage() {}
+
+func (x *SimpleExtensionURI) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_extensions_extensions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimpleExtensionURI.ProtoReflect.Descriptor instead.
+func (*SimpleExtensionURI) Descriptor() ([]byte, []int) {
+	return file_substrait_extensions_extensions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SimpleExtensionURI) GetExtensionUriAnchor() uint32 {
+	if x != nil {
+		return x.ExtensionUriAnchor
+	}
+	return 0
+}
+
+func (x *SimpleExtensionURI) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// SimpleExtensionDeclaration declares an extension of a plan.
+type SimpleExtensionDeclaration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// mapping_type is the kind of the extension.
+	//
+	// Types that are assignable to MappingType:
+	//	*SimpleExtensionDeclaration_ExtensionFunction_
+	MappingType isSimpleExtensionDeclaration_MappingType `protobuf_oneof:"mapping_type"`
+}
+
+func (x *SimpleExtensionDeclaration) Reset() {
+	*x = SimpleExtensionDeclaration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_extensions_extensions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimpleExtensionDeclaration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimpleExtensionDeclaration) ProtoMessage() {}
+
+func (x *SimpleExtensionDeclaration) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_extensions_extensions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimpleExtensionDeclaration.ProtoReflect.Descriptor instead.
+func (*SimpleExtensionDeclaration) Descriptor() ([]byte, []int) {
+	return file_substrait_extensions_extensions_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *SimpleExtensionDeclaration) GetMappingType() isSimpleExtensionDeclaration_MappingType {
+	if m != nil {
+		return m.MappingType
+	}
+	return nil
+}
+
+func (x *SimpleExtensionDeclaration) GetExtensionFunction() *SimpleExtensionDeclaration_ExtensionFunction {
+	if x, ok := x.GetMappingType().(*SimpleExtensionDeclaration_ExtensionFunction_); ok {
+		return x.ExtensionFunction
+	}
+	return nil
+}
+
+type isSimpleExtensionDeclaration_MappingType interface {
+	isSimpleExtensionDeclaration_MappingType()
+}
+
+type SimpleExtensionDeclaration_ExtensionFunction_ struct {
+	// extension_function declares a function.
+	ExtensionFunction *SimpleExtensionDeclaration_ExtensionFunction `protobuf:"bytes,3,opt,name=extension_function,json=extensionFunction,proto3,oneof"`
+}
+
+func (*SimpleExtensionDeclaration_ExtensionFunction_) isSimpleExtensionDeclaration_MappingType() {}
+
+// ExtensionFunction declares a function of a YAML file.
+type SimpleExtensionDeclaration_ExtensionFunction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// extension_uri_reference is the anchor of the URI of the YAML file.
+	ExtensionUriReference uint32 `protobuf:"varint,1,opt,name=extension_uri_reference,json=extensionUriReference,proto3" json:"extension_uri_reference,omitempty"`
+	// function_anchor is the reference of the function in the plan.
+	FunctionAnchor uint32 `protobuf:"varint,2,opt,name=function_anchor,json=functionAnchor,proto3" json:"function_anchor,omitempty"`
+	// name is the name of the function, optionally followed by the
+	// signature of its arguments after a colon.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SimpleExtensionDeclaration_ExtensionFunction) Reset() {
+	*x = SimpleExtensionDeclaration_ExtensionFunction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_extensions_extensions_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimpleExtensionDeclaration_ExtensionFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimpleExtensionDeclaration_ExtensionFunction) ProtoMessage() {}
+
+func (x *SimpleExtensionDeclaration_ExtensionFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_extensions_extensions_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimpleExtensionDeclaration_ExtensionFunction.ProtoReflect.Descriptor instead.
+func (*SimpleExtensionDeclaration_ExtensionFunction) Descriptor() ([]byte, []int) {
+	return file_substrait_extensions_extensions_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *SimpleExtensionDeclaration_ExtensionFunction) GetExtensionUriReference() uint32 {
+	if x != nil {
+		return x.ExtensionUriReference
+	}
+	return 0
+}
+
+func (x *SimpleExtensionDeclaration_ExtensionFunction) GetFunctionAnchor() uint32 {
+	if x != nil {
+		return x.FunctionAnchor
+	}
+	return 0
+}
+
+func (x *SimpleExtensionDeclaration_ExtensionFunction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_substrait_extensions_extensions_proto protoreflect.FileDescriptor
+
+var file_substrait_extensions_extensions_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x58, 0x0a,
+	0x12, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x55, 0x52, 0x49, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x75, 0x72, 0x69, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x69, 0x41,
+	0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0xac, 0x02, 0x0a, 0x1a, 0x53, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x63, 0x6c, 0x61,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x73, 0x0a, 0x12, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x42, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x88, 0x01, 0x0a, 0x11,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x36, 0x0a, 0x17, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x75,
+	0x72, 0x69, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x15, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x69,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x63, 0x68,
+	0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0xe9, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x73,
+	0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x42, 0x0f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x4c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x2f,
+	0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x3b, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0xa2, 0x02, 0x02, 0x53, 0x58, 0xaa, 0x02, 0x14, 0x53, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0xca, 0x02, 0x14, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x5c, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0xe2, 0x02, 0x20, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x74, 0x5c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x15, 0x53, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x3a, 0x3a, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_substrait_extensions_extensions_proto_rawDescOnce sync.Once
+	file_substrait_extensions_extensions_proto_rawDescData = file_substrait_extensions_extensions_proto_rawDesc
+)
+
+func file_substrait_extensions_extensions_proto_rawDescGZIP() []byte {
+	file_substrait_extensions_extensions_proto_rawDescOnce.Do(func() {
+		file_substrait_extensions_extensions_proto_rawDescData = protoimpl.X.CompressGZIP(file_substrait_extensions_extensions_proto_rawDescData)
+	})
+	return file_substrait_extensions_extensions_proto_rawDescData
+}
+
+var file_substrait_extensions_extensions_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_substrait_extensions_extensions_proto_goTypes = []interface{}{
+	(*SimpleExtensionURI)(nil),                           // 0: substrait.extensions.SimpleExtensionURI
+	(*SimpleExtensionDeclaration)(nil),                   // 1: substrait.extensions.SimpleExtensionDeclaration
+	(*SimpleExtensionDeclaration_ExtensionFunction)(nil), // 2: substrait.extensions.SimpleExtensionDeclaration.ExtensionFunction
+}
+var file_substrait_extensions_extensions_proto_depIdxs = []int32{
+	2, // 0: substrait.extensions.SimpleExtensionDeclaration.extension_function:type_name -> substrait.extensions.SimpleExtensionDeclaration.ExtensionFunction
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_substrait_extensions_extensions_proto_init() }
+func file_substrait_extensions_extensions_proto_init() {
+	if File_substrait_extensions_extensions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_substrait_extensions_extensions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimpleExtensionURI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_extensions_extensions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimpleExtensionDeclaration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_extensions_extensions_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimpleExtensionDeclaration_ExtensionFunction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_substrait_extensions_extensions_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*SimpleExtensionDeclaration_ExtensionFunction_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_substrait_extensions_extensions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_substrait_extensions_extensions_proto_goTypes,
+		DependencyIndexes: file_substrait_extensions_extensions_proto_depIdxs,
+		MessageInfos:      file_substrait_extensions_extensions_proto_msgTypes,
+	}.Build()
+	File_substrait_extensions_extensions_proto = out.File
+	file_substrait_extensions_extensions_proto_rawDesc = nil
+	file_substrait_extensions_extensions_proto_goTypes = nil
+	file_substrait_extensions_extensions_proto_depIdxs = nil
+}
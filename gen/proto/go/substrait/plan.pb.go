@@ -0,0 +1,507 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: substrait/plan.proto
+
+// The subset of Substrait plans that frostdb converts logical plans from and
+// to. The messages, their field numbers and their package are those of the
+// Substrait specification.
+
+package substrait
+
+import (
+	extensions "github.com/polarsignals/frostdb/gen/proto/go/substrait/extensions"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Plan is a tree of relations.
+type Plan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// version is the version of Substrait the plan was produced with.
+	Version *Version `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	// extension_uris are the YAML files of the extension functions.
+	ExtensionUris []*extensions.SimpleExtensionURI `protobuf:"bytes,1,rep,name=extension_uris,json=extensionUris,proto3" json:"extension_uris,omitempty"`
+	// extensions are the extension functions of the plan.
+	Extensions []*extensions.SimpleExtensionDeclaration `protobuf:"bytes,2,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	// relations are the trees of relations of the plan.
+	Relations []*PlanRel `protobuf:"bytes,3,rep,name=relations,proto3" json:"relations,omitempty"`
+}
+
+func (x *Plan) Reset() {
+	*x = Plan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_plan_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Plan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plan) ProtoMessage() {}
+
+func (x *Plan) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_plan_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plan.ProtoReflect.Descriptor instead.
+func (*Plan) Descriptor() ([]byte, []int) {
+	return file_substrait_plan_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Plan) GetVersion() *Version {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *Plan) GetExtensionUris() []*extensions.SimpleExtensionURI {
+	if x != nil {
+		return x.ExtensionUris
+	}
+	return nil
+}
+
+func (x *Plan) GetExtensions() []*extensions.SimpleExtensionDeclaration {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+func (x *Plan) GetRelations() []*PlanRel {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+// Version is the version of Substrait a plan was produced with.
+type Version struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// major_number is the major version.
+	MajorNumber uint32 `protobuf:"varint,1,opt,name=major_number,json=majorNumber,proto3" json:"major_number,omitempty"`
+	// minor_number is the minor version.
+	MinorNumber uint32 `protobuf:"varint,2,opt,name=minor_number,json=minorNumber,proto3" json:"minor_number,omitempty"`
+	// patch_number is the patch version.
+	PatchNumber uint32 `protobuf:"varint,3,opt,name=patch_number,json=patchNumber,proto3" json:"patch_number,omitempty"`
+	// git_hash is the commit of the version, if it isn't released.
+	GitHash string `protobuf:"bytes,4,opt,name=git_hash,json=gitHash,proto3" json:"git_hash,omitempty"`
+	// producer is the name of the producer of the plan.
+	Producer string `protobuf:"bytes,5,opt,name=producer,proto3" json:"producer,omitempty"`
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_plan_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_plan_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Version.ProtoReflect.Descriptor instead.
+func (*Version) Descriptor() ([]byte, []int) {
+	return file_substrait_plan_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Version) GetMajorNumber() uint32 {
+	if x != nil {
+		return x.MajorNumber
+	}
+	return 0
+}
+
+func (x *Version) GetMinorNumber() uint32 {
+	if x != nil {
+		return x.MinorNumber
+	}
+	return 0
+}
+
+func (x *Version) GetPatchNumber() uint32 {
+	if x != nil {
+		return x.PatchNumber
+	}
+	return 0
+}
+
+func (x *Version) GetGitHash() string {
+	if x != nil {
+		return x.GitHash
+	}
+	return ""
+}
+
+func (x *Version) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+// PlanRel is a tree of relations of a plan.
+type PlanRel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rel_type is the kind of the tree.
+	//
+	// Types that are assignable to RelType:
+	//	*PlanRel_Rel
+	//	*PlanRel_Root
+	RelType isPlanRel_RelType `protobuf_oneof:"rel_type"`
+}
+
+func (x *PlanRel) Reset() {
+	*x = PlanRel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_plan_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanRel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanRel) ProtoMessage() {}
+
+func (x *PlanRel) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_plan_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanRel.ProtoReflect.Descriptor instead.
+func (*PlanRel) Descriptor() ([]byte, []int) {
+	return file_substrait_plan_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *PlanRel) GetRelType() isPlanRel_RelType {
+	if m != nil {
+		return m.RelType
+	}
+	return nil
+}
+
+func (x *PlanRel) GetRel() *Rel {
+	if x, ok := x.GetRelType().(*PlanRel_Rel); ok {
+		return x.Rel
+	}
+	return nil
+}
+
+func (x *PlanRel) GetRoot() *RelRoot {
+	if x, ok := x.GetRelType().(*PlanRel_Root); ok {
+		return x.Root
+	}
+	return nil
+}
+
+type isPlanRel_RelType interface {
+	isPlanRel_RelType()
+}
+
+type PlanRel_Rel struct {
+	// rel is a tree whose fields have no names.
+	Rel *Rel `protobuf:"bytes,1,opt,name=rel,proto3,oneof"`
+}
+
+type PlanRel_Root struct {
+	// root is a tree whose fields have names.
+	Root *RelRoot `protobuf:"bytes,2,opt,name=root,proto3,oneof"`
+}
+
+func (*PlanRel_Rel) isPlanRel_RelType() {}
+
+func (*PlanRel_Root) isPlanRel_RelType() {}
+
+// RelRoot is a tree of relations whose fields have names.
+type RelRoot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// input is the relation at the root of the tree.
+	Input *Rel `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	// names are the names of the fields of the relation.
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *RelRoot) Reset() {
+	*x = RelRoot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_plan_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelRoot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelRoot) ProtoMessage() {}
+
+func (x *RelRoot) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_plan_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelRoot.ProtoReflect.Descriptor instead.
+func (*RelRoot) Descriptor() ([]byte, []int) {
+	return file_substrait_plan_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RelRoot) GetInput() *Rel {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *RelRoot) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+var File_substrait_plan_proto protoreflect.FileDescriptor
+
+var file_substrait_plan_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x74, 0x1a, 0x17, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x61, 0x6c, 0x67,
+	0x65, 0x62, 0x72, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x25, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x89, 0x02, 0x0a, 0x04, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x69, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x52, 0x49, 0x52, 0x0d, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x69, 0x73, 0x12, 0x50, 0x0a, 0x0a, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52,
+	0x65, 0x6c, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa9, 0x01,
+	0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x6a,
+	0x6f, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x69, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x22, 0x63, 0x0a, 0x07, 0x50, 0x6c, 0x61,
+	0x6e, 0x52, 0x65, 0x6c, 0x12, 0x22, 0x0a, 0x03, 0x72, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65,
+	0x6c, 0x48, 0x00, 0x52, 0x03, 0x72, 0x65, 0x6c, 0x12, 0x28, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x04, 0x72, 0x6f,
+	0x6f, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x45,
+	0x0a, 0x07, 0x52, 0x65, 0x6c, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x9e, 0x01, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x42, 0x09, 0x50, 0x6c, 0x61, 0x6e, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x2f, 0x66, 0x72,
+	0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x67, 0x6f, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x3b, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xa2, 0x02, 0x01, 0x58, 0xaa, 0x02, 0x09, 0x53, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xca, 0x02, 0x09, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0xe2, 0x02, 0x15, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x09, 0x53, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_substrait_plan_proto_rawDescOnce sync.Once
+	file_substrait_plan_proto_rawDescData = file_substrait_plan_proto_rawDesc
+)
+
+func file_substrait_plan_proto_rawDescGZIP() []byte {
+	file_substrait_plan_proto_rawDescOnce.Do(func() {
+		file_substrait_plan_proto_rawDescData = protoimpl.X.CompressGZIP(file_substrait_plan_proto_rawDescData)
+	})
+	return file_substrait_plan_proto_rawDescData
+}
+
+var file_substrait_plan_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_substrait_plan_proto_goTypes = []interface{}{
+	(*Plan)(nil),                          // 0: substrait.Plan
+	(*Version)(nil),                       // 1: substrait.Version
+	(*PlanRel)(nil),                       // 2: substrait.PlanRel
+	(*RelRoot)(nil),                       // 3: substrait.RelRoot
+	(*extensions.SimpleExtensionURI)(nil), // 4: substrait.extensions.SimpleExtensionURI
+	(*extensions.SimpleExtensionDeclaration)(nil), // 5: substrait.extensions.SimpleExtensionDeclaration
+	(*Rel)(nil), // 6: substrait.Rel
+}
+var file_substrait_plan_proto_depIdxs = []int32{
+	1, // 0: substrait.Plan.version:type_name -> substrait.Version
+	4, // 1: substrait.Plan.extension_uris:type_name -> substrait.extensions.SimpleExtensionURI
+	5, // 2: substrait.Plan.extensions:type_name -> substrait.extensions.SimpleExtensionDeclaration
+	2, // 3: substrait.Plan.relations:type_name -> substrait.PlanRel
+	6, // 4: substrait.PlanRel.rel:type_name -> substrait.Rel
+	3, // 5: substrait.PlanRel.root:type_name -> substrait.RelRoot
+	6, // 6: substrait.RelRoot.input:type_name -> substrait.Rel
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_substrait_plan_proto_init() }
+func file_substrait_plan_proto_init() {
+	if File_substrait_plan_proto != nil {
+		return
+	}
+	file_substrait_algebra_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_substrait_plan_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Plan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_plan_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Version); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_plan_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanRel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_plan_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelRoot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_substrait_plan_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*PlanRel_Rel)(nil),
+		(*PlanRel_Root)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_substrait_plan_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_substrait_plan_proto_goTypes,
+		DependencyIndexes: file_substrait_plan_proto_depIdxs,
+		MessageInfos:      file_substrait_plan_proto_msgTypes,
+	}.Build()
+	File_substrait_plan_proto = out.File
+	file_substrait_plan_proto_rawDesc = nil
+	file_substrait_plan_proto_goTypes = nil
+	file_substrait_plan_proto_depIdxs = nil
+}
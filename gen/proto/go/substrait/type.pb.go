@@ -0,0 +1,1230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: substrait/type.proto
+
+// The subset of the types of Substrait plans that frostdb converts logical
+// plans from and to. The messages, their field numbers and their package are
+// those of the Substrait specification.
+
+package substrait
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Nullability is whether values of a type can be null.
+type Type_Nullability int32
+
+const (
+	// NULLABILITY_UNSPECIFIED is unspecified nullability.
+	Type_NULLABILITY_UNSPECIFIED Type_Nullability = 0
+	// NULLABILITY_NULLABLE is nullable.
+	Type_NULLABILITY_NULLABLE Type_Nullability = 1
+	// NULLABILITY_REQUIRED is not nullable.
+	Type_NULLABILITY_REQUIRED Type_Nullability = 2
+)
+
+// Enum value maps for Type_Nullability.
+var (
+	Type_Nullability_name = map[int32]string{
+		0: "NULLABILITY_UNSPECIFIED",
+		1: "NULLABILITY_NULLABLE",
+		2: "NULLABILITY_REQUIRED",
+	}
+	Type_Nullability_value = map[string]int32{
+		"NULLABILITY_UNSPECIFIED": 0,
+		"NULLABILITY_NULLABLE":    1,
+		"NULLABILITY_REQUIRED":    2,
+	}
+)
+
+func (x Type_Nullability) Enum() *Type_Nullability {
+	p := new(Type_Nullability)
+	*p = x
+	return p
+}
+
+func (x Type_Nullability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Type_Nullability) Descriptor() protoreflect.EnumDescriptor {
+	return file_substrait_type_proto_enumTypes[0].Descriptor()
+}
+
+func (Type_Nullability) Type() protoreflect.EnumType {
+	return &file_substrait_type_proto_enumTypes[0]
+}
+
+func (x Type_Nullability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Type_Nullability.Descriptor instead.
+func (Type_Nullability) EnumDescriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Type is the type of a value.
+type Type struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// kind is the kind of the type.
+	//
+	// Types that are assignable to Kind:
+	//	*Type_Bool
+	//	*Type_I32_
+	//	*Type_I64_
+	//	*Type_Fp64
+	//	*Type_String_
+	//	*Type_Binary_
+	//	*Type_Timestamp_
+	//	*Type_Struct_
+	//	*Type_List_
+	Kind isType_Kind `protobuf_oneof:"kind"`
+}
+
+func (x *Type) Reset() {
+	*x = Type{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type) ProtoMessage() {}
+
+func (x *Type) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type.ProtoReflect.Descriptor instead.
+func (*Type) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Type) GetKind() isType_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *Type) GetBool() *Type_Boolean {
+	if x, ok := x.GetKind().(*Type_Bool); ok {
+		return x.Bool
+	}
+	return nil
+}
+
+func (x *Type) GetI32() *Type_I32 {
+	if x, ok := x.GetKind().(*Type_I32_); ok {
+		return x.I32
+	}
+	return nil
+}
+
+func (x *Type) GetI64() *Type_I64 {
+	if x, ok := x.GetKind().(*Type_I64_); ok {
+		return x.I64
+	}
+	return nil
+}
+
+func (x *Type) GetFp64() *Type_FP64 {
+	if x, ok := x.GetKind().(*Type_Fp64); ok {
+		return x.Fp64
+	}
+	return nil
+}
+
+func (x *Type) GetString_() *Type_String {
+	if x, ok := x.GetKind().(*Type_String_); ok {
+		return x.String_
+	}
+	return nil
+}
+
+func (x *Type) GetBinary() *Type_Binary {
+	if x, ok := x.GetKind().(*Type_Binary_); ok {
+		return x.Binary
+	}
+	return nil
+}
+
+func (x *Type) GetTimestamp() *Type_Timestamp {
+	if x, ok := x.GetKind().(*Type_Timestamp_); ok {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Type) GetStruct() *Type_Struct {
+	if x, ok := x.GetKind().(*Type_Struct_); ok {
+		return x.Struct
+	}
+	return nil
+}
+
+func (x *Type) GetList() *Type_List {
+	if x, ok := x.GetKind().(*Type_List_); ok {
+		return x.List
+	}
+	return nil
+}
+
+type isType_Kind interface {
+	isType_Kind()
+}
+
+type Type_Bool struct {
+	// bool is a boolean.
+	Bool *Type_Boolean `protobuf:"bytes,1,opt,name=bool,proto3,oneof"`
+}
+
+type Type_I32_ struct {
+	// i32 is a 32 bit integer.
+	I32 *Type_I32 `protobuf:"bytes,5,opt,name=i32,proto3,oneof"`
+}
+
+type Type_I64_ struct {
+	// i64 is a 64 bit integer.
+	I64 *Type_I64 `protobuf:"bytes,7,opt,name=i64,proto3,oneof"`
+}
+
+type Type_Fp64 struct {
+	// fp64 is a 64 bit floating point number.
+	Fp64 *Type_FP64 `protobuf:"bytes,11,opt,name=fp64,proto3,oneof"`
+}
+
+type Type_String_ struct {
+	// string is a UTF-8 string.
+	String_ *Type_String `protobuf:"bytes,12,opt,name=string,proto3,oneof"`
+}
+
+type Type_Binary_ struct {
+	// binary is a byte string.
+	Binary *Type_Binary `protobuf:"bytes,13,opt,name=binary,proto3,oneof"`
+}
+
+type Type_Timestamp_ struct {
+	// timestamp is a timestamp in microseconds.
+	Timestamp *Type_Timestamp `protobuf:"bytes,14,opt,name=timestamp,proto3,oneof"`
+}
+
+type Type_Struct_ struct {
+	// struct is a struct of types.
+	Struct *Type_Struct `protobuf:"bytes,25,opt,name=struct,proto3,oneof"`
+}
+
+type Type_List_ struct {
+	// list is a list of values of a type.
+	List *Type_List `protobuf:"bytes,27,opt,name=list,proto3,oneof"`
+}
+
+func (*Type_Bool) isType_Kind() {}
+
+func (*Type_I32_) isType_Kind() {}
+
+func (*Type_I64_) isType_Kind() {}
+
+func (*Type_Fp64) isType_Kind() {}
+
+func (*Type_String_) isType_Kind() {}
+
+func (*Type_Binary_) isType_Kind() {}
+
+func (*Type_Timestamp_) isType_Kind() {}
+
+func (*Type_Struct_) isType_Kind() {}
+
+func (*Type_List_) isType_Kind() {}
+
+// NamedStruct is a struct whose fields have names.
+type NamedStruct struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// names are the names of the fields.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	// struct is the types of the fields.
+	Struct *Type_Struct `protobuf:"bytes,2,opt,name=struct,proto3" json:"struct,omitempty"`
+}
+
+func (x *NamedStruct) Reset() {
+	*x = NamedStruct{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamedStruct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedStruct) ProtoMessage() {}
+
+func (x *NamedStruct) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedStruct.ProtoReflect.Descriptor instead.
+func (*NamedStruct) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NamedStruct) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *NamedStruct) GetStruct() *Type_Struct {
+	if x != nil {
+		return x.Struct
+	}
+	return nil
+}
+
+// Boolean is a boolean.
+type Type_Boolean struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_Boolean) Reset() {
+	*x = Type_Boolean{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_Boolean) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_Boolean) ProtoMessage() {}
+
+func (x *Type_Boolean) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_Boolean.ProtoReflect.Descriptor instead.
+func (*Type_Boolean) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Type_Boolean) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_Boolean) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// I32 is a 32 bit integer.
+type Type_I32 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_I32) Reset() {
+	*x = Type_I32{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_I32) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_I32) ProtoMessage() {}
+
+func (x *Type_I32) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_I32.ProtoReflect.Descriptor instead.
+func (*Type_I32) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Type_I32) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_I32) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// I64 is a 64 bit integer.
+type Type_I64 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_I64) Reset() {
+	*x = Type_I64{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_I64) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_I64) ProtoMessage() {}
+
+func (x *Type_I64) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_I64.ProtoReflect.Descriptor instead.
+func (*Type_I64) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *Type_I64) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_I64) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// FP64 is a 64 bit floating point number.
+type Type_FP64 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_FP64) Reset() {
+	*x = Type_FP64{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_FP64) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_FP64) ProtoMessage() {}
+
+func (x *Type_FP64) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_FP64.ProtoReflect.Descriptor instead.
+func (*Type_FP64) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *Type_FP64) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_FP64) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// String is a UTF-8 string.
+type Type_String struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_String) Reset() {
+	*x = Type_String{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_String) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_String) ProtoMessage() {}
+
+func (x *Type_String) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_String.ProtoReflect.Descriptor instead.
+func (*Type_String) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *Type_String) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_String) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// Binary is a byte string.
+type Type_Binary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_Binary) Reset() {
+	*x = Type_Binary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_Binary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_Binary) ProtoMessage() {}
+
+func (x *Type_Binary) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_Binary.ProtoReflect.Descriptor instead.
+func (*Type_Binary) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *Type_Binary) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_Binary) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// Timestamp is a timestamp in microseconds.
+type Type_Timestamp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,1,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,2,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_Timestamp) Reset() {
+	*x = Type_Timestamp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_Timestamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_Timestamp) ProtoMessage() {}
+
+func (x *Type_Timestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_Timestamp.ProtoReflect.Descriptor instead.
+func (*Type_Timestamp) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 6}
+}
+
+func (x *Type_Timestamp) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_Timestamp) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// Struct is a struct of types.
+type Type_Struct struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// types are the types of the fields of the struct.
+	Types []*Type `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,2,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,3,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_Struct) Reset() {
+	*x = Type_Struct{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_Struct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_Struct) ProtoMessage() {}
+
+func (x *Type_Struct) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_Struct.ProtoReflect.Descriptor instead.
+func (*Type_Struct) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 7}
+}
+
+func (x *Type_Struct) GetTypes() []*Type {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Type_Struct) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_Struct) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+// List is a list of values of a type.
+type Type_List struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type is the type of the values.
+	Type *Type `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// type_variation_reference is the variation of the type.
+	TypeVariationReference uint32 `protobuf:"varint,2,opt,name=type_variation_reference,json=typeVariationReference,proto3" json:"type_variation_reference,omitempty"`
+	// nullability is whether values can be null.
+	Nullability Type_Nullability `protobuf:"varint,3,opt,name=nullability,proto3,enum=substrait.Type_Nullability" json:"nullability,omitempty"`
+}
+
+func (x *Type_List) Reset() {
+	*x = Type_List{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_substrait_type_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type_List) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type_List) ProtoMessage() {}
+
+func (x *Type_List) ProtoReflect() protoreflect.Message {
+	mi := &file_substrait_type_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type_List.ProtoReflect.Descriptor instead.
+func (*Type_List) Descriptor() ([]byte, []int) {
+	return file_substrait_type_proto_rawDescGZIP(), []int{0, 8}
+}
+
+func (x *Type_List) GetType() *Type {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Type_List) GetTypeVariationReference() uint32 {
+	if x != nil {
+		return x.TypeVariationReference
+	}
+	return 0
+}
+
+func (x *Type_List) GetNullability() Type_Nullability {
+	if x != nil {
+		return x.Nullability
+	}
+	return Type_NULLABILITY_UNSPECIFIED
+}
+
+var File_substrait_type_proto protoreflect.FileDescriptor
+
+var file_substrait_type_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2f, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x74, 0x22, 0xff, 0x0d, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x62, 0x6f,
+	0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61,
+	0x6e, 0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x27, 0x0a, 0x03, 0x69, 0x33, 0x32,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x49, 0x33, 0x32, 0x48, 0x00, 0x52, 0x03, 0x69,
+	0x33, 0x32, 0x12, 0x27, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x2e, 0x49, 0x36, 0x34, 0x48, 0x00, 0x52, 0x03, 0x69, 0x36, 0x34, 0x12, 0x2a, 0x0a, 0x04, 0x66,
+	0x70, 0x36, 0x34, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x46, 0x50, 0x36, 0x34, 0x48,
+	0x00, 0x52, 0x04, 0x66, 0x70, 0x36, 0x34, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x48,
+	0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x30, 0x0a, 0x06, 0x62, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x39, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00,
+	0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04,
+	0x6c, 0x69, 0x73, 0x74, 0x1a, 0x82, 0x01, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e,
+	0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x7e, 0x0a, 0x03, 0x49, 0x33, 0x32,
+	0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x7e, 0x0a, 0x03, 0x49, 0x36, 0x34,
+	0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x7f, 0x0a, 0x04, 0x46, 0x50, 0x36,
+	0x34, 0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e,
+	0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70,
+	0x65, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e,
+	0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x81, 0x01, 0x0a, 0x06, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61,
+	0x72, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x3d, 0x0a, 0x0b, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x52, 0x0b, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x81,
+	0x01, 0x0a, 0x06, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70,
+	0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x1a, 0x84, 0x01, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e, 0x75,
+	0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0xa8, 0x01, 0x0a, 0x06, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x74,
+	0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4e, 0x75, 0x6c, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x1a, 0xa4, 0x01, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x74, 0x79, 0x70, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b,
+	0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b,
+	0x6e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x5e, 0x0a, 0x0b, 0x4e,
+	0x75, 0x6c, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x17, 0x4e, 0x55,
+	0x4c, 0x4c, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4e, 0x55, 0x4c, 0x4c, 0x41,
+	0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x4e, 0x55, 0x4c, 0x4c, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x42, 0x06, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x22, 0x53, 0x0a, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x53, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x9e, 0x01, 0x0a, 0x0d, 0x63, 0x6f, 0x6d,
+	0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x42, 0x09, 0x54, 0x79, 0x70, 0x65,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6c, 0x61, 0x72, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73,
+	0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x3b,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xa2, 0x02, 0x01, 0x58, 0xaa, 0x02, 0x09,
+	0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0xca, 0x02, 0x09, 0x53, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x74, 0xe2, 0x02, 0x15, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x74, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x09,
+	0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x69, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_substrait_type_proto_rawDescOnce sync.Once
+	file_substrait_type_proto_rawDescData = file_substrait_type_proto_rawDesc
+)
+
+func file_substrait_type_proto_rawDescGZIP() []byte {
+	file_substrait_type_proto_rawDescOnce.Do(func() {
+		file_substrait_type_proto_rawDescData = protoimpl.X.CompressGZIP(file_substrait_type_proto_rawDescData)
+	})
+	return file_substrait_type_proto_rawDescData
+}
+
+var file_substrait_type_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_substrait_type_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_substrait_type_proto_goTypes = []interface{}{
+	(Type_Nullability)(0),  // 0: substrait.Type.Nullability
+	(*Type)(nil),           // 1: substrait.Type
+	(*NamedStruct)(nil),    // 2: substrait.NamedStruct
+	(*Type_Boolean)(nil),   // 3: substrait.Type.Boolean
+	(*Type_I32)(nil),       // 4: substrait.Type.I32
+	(*Type_I64)(nil),       // 5: substrait.Type.I64
+	(*Type_FP64)(nil),      // 6: substrait.Type.FP64
+	(*Type_String)(nil),    // 7: substrait.Type.String
+	(*Type_Binary)(nil),    // 8: substrait.Type.Binary
+	(*Type_Timestamp)(nil), // 9: substrait.Type.Timestamp
+	(*Type_Struct)(nil),    // 10: substrait.Type.Struct
+	(*Type_List)(nil),      // 11: substrait.Type.List
+}
+var file_substrait_type_proto_depIdxs = []int32{
+	3,  // 0: substrait.Type.bool:type_name -> substrait.Type.Boolean
+	4,  // 1: substrait.Type.i32:type_name -> substrait.Type.I32
+	5,  // 2: substrait.Type.i64:type_name -> substrait.Type.I64
+	6,  // 3: substrait.Type.fp64:type_name -> substrait.Type.FP64
+	7,  // 4: substrait.Type.string:type_name -> substrait.Type.String
+	8,  // 5: substrait.Type.binary:type_name -> substrait.Type.Binary
+	9,  // 6: substrait.Type.timestamp:type_name -> substrait.Type.Timestamp
+	10, // 7: substrait.Type.struct:type_name -> substrait.Type.Struct
+	11, // 8: substrait.Type.list:type_name -> substrait.Type.List
+	10, // 9: substrait.NamedStruct.struct:type_name -> substrait.Type.Struct
+	0,  // 10: substrait.Type.Boolean.nullability:type_name -> substrait.Type.Nullability
+	0,  // 11: substrait.Type.I32.nullability:type_name -> substrait.Type.Nullability
+	0,  // 12: substrait.Type.I64.nullability:type_name -> substrait.Type.Nullability
+	0,  // 13: substrait.Type.FP64.nullability:type_name -> substrait.Type.Nullability
+	0,  // 14: substrait.Type.String.nullability:type_name -> substrait.Type.Nullability
+	0,  // 15: substrait.Type.Binary.nullability:type_name -> substrait.Type.Nullability
+	0,  // 16: substrait.Type.Timestamp.nullability:type_name -> substrait.Type.Nullability
+	1,  // 17: substrait.Type.Struct.types:type_name -> substrait.Type
+	0,  // 18: substrait.Type.Struct.nullability:type_name -> substrait.Type.Nullability
+	1,  // 19: substrait.Type.List.type:type_name -> substrait.Type
+	0,  // 20: substrait.Type.List.nullability:type_name -> substrait.Type.Nullability
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_substrait_type_proto_init() }
+func file_substrait_type_proto_init() {
+	if File_substrait_type_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_substrait_type_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamedStruct); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_Boolean); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_I32); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_I64); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_FP64); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_String); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_Binary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_Timestamp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_Struct); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_substrait_type_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Type_List); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_substrait_type_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Type_Bool)(nil),
+		(*Type_I32_)(nil),
+		(*Type_I64_)(nil),
+		(*Type_Fp64)(nil),
+		(*Type_String_)(nil),
+		(*Type_Binary_)(nil),
+		(*Type_Timestamp_)(nil),
+		(*Type_Struct_)(nil),
+		(*Type_List_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_substrait_type_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_substrait_type_proto_goTypes,
+		DependencyIndexes: file_substrait_type_proto_depIdxs,
+		EnumInfos:         file_substrait_type_proto_enumTypes,
+		MessageInfos:      file_substrait_type_proto_msgTypes,
+	}.Build()
+	File_substrait_type_proto = out.File
+	file_substrait_type_proto_rawDesc = nil
+	file_substrait_type_proto_goTypes = nil
+	file_substrait_type_proto_depIdxs = nil
+}
@@ -0,0 +1,41 @@
+package frostdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+)
+
+// GenericTable is a table of the values of a struct type, whose schema is
+// derived from the struct's tags, see dynparquet.StructSchema.
+type GenericTable[T any] struct {
+	*Table
+	schema *dynparquet.StructSchema[T]
+}
+
+// NewGenericTable returns the table of the database of the values of the
+// struct type T.
+func NewGenericTable[T any](db *DB, name string, options ...TableOption) (*GenericTable[T], error) {
+	schema, err := dynparquet.NewStructSchema[T](name)
+	if err != nil {
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+	table, err := db.Table(name, NewTableConfig(schema.Schema, options...))
+	if err != nil {
+		return nil, err
+	}
+	return &GenericTable[T]{
+		Table:  table,
+		schema: schema,
+	}, nil
+}
+
+// Write inserts the values into the table.
+func (t *GenericTable[T]) Write(ctx context.Context, values ...T) (uint64, error) {
+	buf, err := t.schema.ToBuffer(values...)
+	if err != nil {
+		return 0, fmt.Errorf("convert values to buffer: %w", err)
+	}
+	return t.InsertBuffer(ctx, buf)
+}
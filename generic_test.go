@@ -0,0 +1,81 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+type genericSample struct {
+	Labels    map[string]string `frostdb:"labels,rle_dict,asc(0)"`
+	Timestamp int64             `frostdb:"timestamp,asc(1)"`
+	Value     *float64          `frostdb:"value"`
+}
+
+func TestGenericTable(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	defer c.Close()
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := NewGenericTable[genericSample](db, "test")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	value := 1.5
+	_, err = table.Write(ctx, genericSample{
+		Labels:    map[string]string{"node": "a"},
+		Timestamp: 2,
+		Value:     &value,
+	}, genericSample{
+		Labels:    map[string]string{"node": "b", "region": "eu"},
+		Timestamp: 1,
+	})
+	require.NoError(t, err)
+	_, err = table.Write(ctx, genericSample{
+		Labels:    map[string]string{"region": "us"},
+		Timestamp: 3,
+	})
+	require.NoError(t, err)
+	table.Sync()
+
+	timestamps := []int64{}
+	values := []interface{}{}
+	err = query.NewEngine(memory.NewGoAllocator(), db.TableProvider()).ScanTable("test").
+		Filter(logicalplan.Or(
+			logicalplan.Col("labels.node").Eq(logicalplan.Literal("a")),
+			logicalplan.Col("labels.region").Eq(logicalplan.Literal("eu")),
+		)).
+		Project(logicalplan.Col("timestamp"), logicalplan.Col("value")).
+		Execute(ctx, func(r arrow.Record) error {
+			ts := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			vs := r.Column(r.Schema().FieldIndices("value")[0]).(*array.Float64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				timestamps = append(timestamps, ts.Value(i))
+				if vs.IsNull(i) {
+					values = append(values, nil)
+					continue
+				}
+				values = append(values, vs.Value(i))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 2}, timestamps)
+	require.Len(t, values, 2)
+	for i, ts := range timestamps {
+		if ts == 2 {
+			require.Equal(t, 1.5, values[i])
+		} else {
+			require.Nil(t, values[i])
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Package grafana implements the endpoints of the Grafana JSON datasource on
+// top of a frostdb table, so dashboards can query frostdb with the JSON (or
+// SimpleJson) datasource plugin. The table has the conventional schema of
+// time series: a timestamp column of milliseconds, a value column of
+// integers or floats and a dynamic labels column whose concrete columns are
+// the labels of the series.
+//
+//	http.Handle("/grafana/", http.StripPrefix("/grafana", grafana.NewHandler(engine, "metrics")))
+//
+// The targets of queries are filter expressions of the rows, see
+// logicalplan.ParseExpr, such as `labels.job = "api"`. The empty target
+// selects all rows.
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+const (
+	timestampColumn = "timestamp"
+	valueColumn     = "value"
+	labelsColumn    = "labels"
+)
+
+// maxRequestSize limits the size of the bodies of requests.
+const maxRequestSize = 1 << 20
+
+// Handler serves the endpoints of the datasource on the table of an engine:
+//
+//	/        tests the connection of the datasource
+//	/search  returns the columns of the table
+//	/query   returns the time series of targets
+type Handler struct {
+	engine *query.LocalEngine
+	table  string
+	mux    *http.ServeMux
+}
+
+func NewHandler(engine *query.LocalEngine, table string) *Handler {
+	h := &Handler{
+		engine: engine,
+		table:  table,
+		mux:    http.NewServeMux(),
+	}
+	h.mux.HandleFunc("/", h.serveRoot)
+	h.mux.HandleFunc("/search", h.serveSearch)
+	h.mux.HandleFunc("/query", h.serveQuery)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) serveRoot(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// SearchRequest is the request of the columns of the table that contain the
+// target.
+type SearchRequest struct {
+	Target string `json:"target"`
+}
+
+func (h *Handler) serveSearch(w http.ResponseWriter, r *http.Request) {
+	var req SearchRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	seen := map[string]bool{}
+	err := h.engine.ScanSchema(h.table).Execute(r.Context(), func(rec arrow.Record) error {
+		names, ok := rec.Column(0).(*array.String)
+		if !ok {
+			return fmt.Errorf("unexpected type of column names: %s", rec.Column(0).DataType())
+		}
+		for i := 0; i < names.Len(); i++ {
+			if strings.Contains(names.Value(i), req.Target) {
+				seen[names.Value(i)] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("search columns: %w", err))
+		return
+	}
+
+	columns := make([]string, 0, len(seen))
+	for name := range seen {
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+	writeJSON(w, columns)
+}
+
+// QueryRequest is the request of the time series of the targets within the
+// range. The values of the series are summed by intervals, if any.
+type QueryRequest struct {
+	Range    Range    `json:"range"`
+	Interval int64    `json:"intervalMs"`
+	Targets  []Target `json:"targets"`
+}
+
+type Range struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type Target struct {
+	Target string `json:"target"`
+	RefID  string `json:"refId"`
+	// Type is the type of the result of the target, only time series are
+	// supported.
+	Type string `json:"type"`
+}
+
+// TimeSeries is the result of a time series of a target, its datapoints are
+// pairs of values and timestamps in milliseconds.
+type TimeSeries struct {
+	Target     string       `json:"target"`
+	RefID      string       `json:"refId,omitempty"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+func (h *Handler) serveQuery(w http.ResponseWriter, r *http.Request) {
+	var req QueryRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Interval < 0 {
+		writeError(w, http.StatusBadRequest, errors.New("negative interval"))
+		return
+	}
+
+	from, to := req.Range.From.UnixMilli(), req.Range.To.UnixMilli()
+	res := []TimeSeries{}
+	for _, target := range req.Targets {
+		if target.Type != "" && target.Type != "timeserie" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported type %q of target %s", target.Type, target.RefID))
+			return
+		}
+		filter := logicalplan.And(
+			logicalplan.Col(timestampColumn).GtEq(logicalplan.Literal(from)),
+			logicalplan.Col(timestampColumn).LtEq(logicalplan.Literal(to)),
+		)
+		if target.Target != "" {
+			expr, err := logicalplan.ParseExpr(target.Target)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("parse target %s: %w", target.RefID, err))
+				return
+			}
+			filter = logicalplan.And(filter, expr)
+		}
+
+		series, err := h.series(r, filter, req.Interval)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("query target %s: %w", target.RefID, err))
+			return
+		}
+		for i := range series {
+			series[i].RefID = target.RefID
+		}
+		res = append(res, series...)
+	}
+	writeJSON(w, res)
+}
+
+// series returns the time series of the rows that match the filter, sorted
+// by their labels. The values of each interval are summed, the timestamps
+// of the datapoints are the start of their intervals.
+func (h *Handler) series(r *http.Request, filter logicalplan.Expr, interval int64) ([]TimeSeries, error) {
+	type series struct {
+		labels labels.Labels
+		values map[int64]float64
+	}
+	seriesByLabels := map[string]*series{}
+	err := h.engine.ScanTable(h.table).
+		Filter(filter).
+		Project(
+			logicalplan.DynCol(labelsColumn),
+			logicalplan.Col(timestampColumn),
+			logicalplan.Col(valueColumn),
+		).
+		Execute(r.Context(), func(rec arrow.Record) error {
+			timestamps, values, err := samples(rec)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < int(rec.NumRows()); i++ {
+				lset := rowLabels(rec, i)
+				key := lset.String()
+				s, ok := seriesByLabels[key]
+				if !ok {
+					s = &series{labels: lset, values: map[int64]float64{}}
+					seriesByLabels[key] = s
+				}
+				ts := timestamps.Value(i)
+				if interval > 0 {
+					ts -= ts % interval
+				}
+				s.values[ts] += values(i)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]TimeSeries, 0, len(seriesByLabels))
+	for key, s := range seriesByLabels {
+		datapoints := make([][2]float64, 0, len(s.values))
+		for ts, v := range s.values {
+			datapoints = append(datapoints, [2]float64{v, float64(ts)})
+		}
+		sort.Slice(datapoints, func(i, j int) bool {
+			return datapoints[i][1] < datapoints[j][1]
+		})
+		res = append(res, TimeSeries{Target: key, Datapoints: datapoints})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return labels.Compare(seriesByLabels[res[i].Target].labels, seriesByLabels[res[j].Target].labels) < 0
+	})
+	return res, nil
+}
+
+// samples returns the timestamps of the record and a function that returns
+// the values of its rows as floats.
+func samples(r arrow.Record) (*array.Int64, func(i int) float64, error) {
+	var timestamps *array.Int64
+	var values func(i int) float64
+	for i, field := range r.Schema().Fields() {
+		switch field.Name {
+		case timestampColumn:
+			arr, ok := r.Column(i).(*array.Int64)
+			if !ok {
+				return nil, nil, fmt.Errorf("unsupported type of column %s: %s", field.Name, field.Type)
+			}
+			timestamps = arr
+		case valueColumn:
+			switch arr := r.Column(i).(type) {
+			case *array.Int64:
+				values = func(i int) float64 { return float64(arr.Value(i)) }
+			case *array.Float64:
+				values = arr.Value
+			default:
+				return nil, nil, fmt.Errorf("unsupported type of column %s: %s", field.Name, field.Type)
+			}
+		}
+	}
+	if timestamps == nil || values == nil {
+		return nil, nil, fmt.Errorf("missing %s or %s column", timestampColumn, valueColumn)
+	}
+	return timestamps, values, nil
+}
+
+// rowLabels returns the non-empty labels of the row.
+func rowLabels(r arrow.Record, row int) labels.Labels {
+	lset := labels.Labels{}
+	for i, field := range r.Schema().Fields() {
+		name := strings.TrimPrefix(field.Name, labelsColumn+".")
+		if name == field.Name || r.Column(i).IsNull(row) {
+			continue
+		}
+		var value string
+		switch arr := r.Column(i).(type) {
+		case *array.Binary:
+			value = string(arr.Value(row))
+		case *array.String:
+			value = arr.Value(row)
+		}
+		if value != "" {
+			lset = append(lset, labels.Label{Name: name, Value: value})
+		}
+	}
+	sort.Sort(lset)
+	return lset
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// decodeRequest decodes the JSON body of a POST request, it writes the error
+// and returns false if the request is invalid.
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,123 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+)
+
+// newTestHandler returns a handler of the test samples of the table "test".
+func newTestHandler(t *testing.T) *Handler {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	return NewHandler(query.NewEngine(memory.NewGoAllocator(), db.TableProvider()), "test")
+}
+
+func post(h http.Handler, path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rec
+}
+
+func TestRoot(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSearch(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := post(h, "/search", `{"target": "labels."}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var columns []string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &columns))
+	require.Equal(t, []string{"labels.container", "labels.namespace", "labels.node", "labels.pod"}, columns)
+}
+
+func TestQuery(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := post(h, "/query", `{
+		"range": {"from": "1970-01-01T00:00:00Z", "to": "1970-01-01T00:00:01Z"},
+		"intervalMs": 10,
+		"targets": [
+			{"target": "labels.namespace = \"default\"", "refId": "A", "type": "timeserie"},
+			{"target": "value > 4", "refId": "B"}
+		]
+	}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res []TimeSeries
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Equal(t, []TimeSeries{{
+		Target:     `{container="test2", namespace="default"}`,
+		RefID:      "A",
+		Datapoints: [][2]float64{{3, 0}},
+	}, {
+		Target:     `{namespace="default", pod="test1"}`,
+		RefID:      "A",
+		Datapoints: [][2]float64{{3, 0}},
+	}, {
+		Target:     `{node="test3"}`,
+		RefID:      "B",
+		Datapoints: [][2]float64{{5, 0}},
+	}}, res)
+
+	// The range excludes all samples.
+	rec = post(h, "/query", `{
+		"range": {"from": "1970-01-01T00:00:01Z", "to": "1970-01-01T00:00:02Z"},
+		"targets": [{"refId": "A"}]
+	}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.JSONEq(t, `[]`, rec.Body.String())
+}
+
+func TestQueryErrors(t *testing.T) {
+	h := newTestHandler(t)
+
+	for name, body := range map[string]string{
+		"invalid json":      `{"targets":`,
+		"invalid target":    `{"targets": [{"target": "value >", "refId": "A"}]}`,
+		"table target":      `{"targets": [{"refId": "A", "type": "table"}]}`,
+		"negative interval": `{"intervalMs": -1}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := post(h, "/query", body)
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -93,7 +94,7 @@ func (g *Granule) addPart(p *Part, r *dynparquet.DynamicRow) (uint64, error) {
 
 	for {
 		least := g.metadata.least.Load()
-		if least == nil || g.tableConfig.schema.RowLessThan(r, (*dynparquet.DynamicRow)(least)) {
+		if least == nil || g.tableConfig.Schema().RowLessThan(r, (*dynparquet.DynamicRow)(least)) {
 			if g.metadata.least.CAS(least, unsafe.Pointer(r)) {
 				break
 			}
@@ -139,8 +140,9 @@ func (g *Granule) AddPart(p *Part) (uint64, error) {
 
 // split a granule into n sized granules. With the last granule containing the remainder.
 // Returns the granules in order.
-// This assumes the Granule has had its parts merged into a single part.
-func (g *Granule) split(tx uint64, n int) ([]*Granule, error) {
+// This assumes the Granule has had its parts merged into a single part, which
+// was written with the schema.
+func (g *Granule) split(tx uint64, n int, schema *dynparquet.Schema) ([]*Granule, error) {
 	// Get the first part in the granule's part list.
 	var p *Part
 	g.parts.Iterate(func(part *Part) bool {
@@ -162,7 +164,7 @@ func (g *Granule) split(tx uint64, n int) ([]*Granule, error) {
 		w      *dynparquet.PooledWriter
 	)
 	b = bytes.NewBuffer(nil)
-	w, err := g.tableConfig.schema.GetWriter(b, p.Buf.DynamicColumns())
+	w, err := schema.GetWriter(b, p.Buf.DynamicColumns())
 	if err != nil {
 		return nil, ErrCreateSchemaWriter{err}
 	}
@@ -173,11 +175,13 @@ func (g *Granule) split(tx uint64, n int) ([]*Granule, error) {
 	for _, rowGroup := range f.RowGroups() {
 		rows := rowGroup.Rows()
 		for {
-			_, err = rows.ReadRows(rowBuf)
-			if err == io.EOF {
+			// The last row may be read with io.EOF, such as the last row
+			// of repeated columns.
+			read, err := rows.ReadRows(rowBuf)
+			if err == io.EOF && read == 0 {
 				break
 			}
-			if err != nil {
+			if err != nil && err != io.EOF {
 				return nil, ErrReadRow{err}
 			}
 
@@ -202,8 +206,8 @@ func (g *Granule) split(tx uint64, n int) ([]*Granule, error) {
 				}
 				granules = append(granules, gran)
 				b = bytes.NewBuffer(nil)
-				g.tableConfig.schema.PutWriter(w)
-				w, err = g.tableConfig.schema.GetWriter(b, p.Buf.DynamicColumns())
+				schema.PutWriter(w)
+				w, err = schema.GetWriter(b, p.Buf.DynamicColumns())
 				if err != nil {
 					return nil, ErrCreateSchemaWriter{err}
 				}
@@ -221,7 +225,7 @@ func (g *Granule) split(tx uint64, n int) ([]*Granule, error) {
 		if err != nil {
 			return nil, fmt.Errorf("close last writer: %w", err)
 		}
-		g.tableConfig.schema.PutWriter(w)
+		schema.PutWriter(w)
 
 		r, err := dynparquet.ReaderFromBytes(b.Bytes())
 		if err != nil {
@@ -251,7 +255,7 @@ func (g *Granule) PartBuffersForTx(watermark uint64, iterator func(*dynparquet.S
 
 // Less implements the btree.Item interface.
 func (g *Granule) Less(than btree.Item) bool {
-	return g.tableConfig.schema.RowLessThan(g.Least(), than.(*Granule).Least())
+	return g.tableConfig.Schema().RowLessThan(g.Least(), than.(*Granule).Least())
 }
 
 // Least returns the least row in a Granule.
@@ -264,7 +268,10 @@ func (g *Granule) minmaxes(p *Part) error {
 	f := p.Buf.ParquetFile()
 
 	for _, rowGroup := range f.RowGroups() {
+		columns := rowGroup.Schema().Columns()
 		for _, columnChunk := range rowGroup.ColumnChunks() {
+			// The leaf columns of struct columns are named by their paths.
+			name := strings.Join(columns[columnChunk.Column()], ".")
 			idx := columnChunk.ColumnIndex()
 			minvalues := make([]parquet.Value, 0, idx.NumPages())
 			maxvalues := make([]parquet.Value, 0, idx.NumPages())
@@ -277,13 +284,13 @@ func (g *Granule) minmaxes(p *Part) error {
 			min := findMin(columnChunk.Type(), minvalues)
 
 			g.metadata.minlock.RLock()
-			val := g.metadata.min[rowGroup.Schema().Fields()[columnChunk.Column()].Name()]
+			val := g.metadata.min[name]
 			g.metadata.minlock.RUnlock()
 			if val == nil || columnChunk.Type().Compare(*val, *min) == 1 {
 				if !min.IsNull() {
 					g.metadata.minlock.Lock() // Check again after acquiring the write lock
-					if val := g.metadata.min[rowGroup.Schema().Fields()[columnChunk.Column()].Name()]; val == nil || columnChunk.Type().Compare(*val, *min) == 1 {
-						g.metadata.min[rowGroup.Schema().Fields()[columnChunk.Column()].Name()] = min
+					if val := g.metadata.min[name]; val == nil || columnChunk.Type().Compare(*val, *min) == 1 {
+						g.metadata.min[name] = min
 					}
 					g.metadata.minlock.Unlock()
 				}
@@ -292,13 +299,13 @@ func (g *Granule) minmaxes(p *Part) error {
 			// Check for max
 			max := findMax(columnChunk.Type(), maxvalues)
 			g.metadata.maxlock.RLock()
-			val = g.metadata.max[rowGroup.Schema().Fields()[columnChunk.Column()].Name()]
+			val = g.metadata.max[name]
 			g.metadata.maxlock.RUnlock()
 			if val == nil || columnChunk.Type().Compare(*val, *max) == -1 {
 				if !max.IsNull() {
 					g.metadata.maxlock.Lock() // Check again after acquiring the write lock
-					if val := g.metadata.max[rowGroup.Schema().Fields()[columnChunk.Column()].Name()]; val == nil || columnChunk.Type().Compare(*val, *max) == -1 {
-						g.metadata.max[rowGroup.Schema().Fields()[columnChunk.Column()].Name()] = max
+					if val := g.metadata.max[name]; val == nil || columnChunk.Type().Compare(*val, *max) == -1 {
+						g.metadata.max[name] = max
 					}
 					g.metadata.maxlock.Unlock()
 				}
@@ -0,0 +1,281 @@
+// Package httpapi serves frostdb queries as JSON over HTTP, for clients such
+// as frontends that don't speak Arrow. Queries are POSTed as JSON:
+//
+//	{
+//		"table": "test",
+//		"filter": "labels.namespace = \"default\"",
+//		"group_by": ["labels.namespace"],
+//		"aggregations": [{"function": "sum", "column": "value"}],
+//		"start": 1000,
+//		"end": 2000
+//	}
+//
+// The rows of the result are streamed as a JSON array of objects, or as
+// newline delimited JSON objects if the request accepts
+// application/x-ndjson. The columns of aggregations are named like
+// "sum(value)".
+//
+//	http.Handle("/query", httpapi.NewHandler(engine))
+package httpapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+const contentTypeNDJSON = "application/x-ndjson"
+
+// maxRequestSize limits the size of the bodies of requests.
+const maxRequestSize = 1 << 20
+
+// Request is a query of a table.
+type Request struct {
+	Table string `json:"table"`
+	// Filter is a filter expression, see logicalplan.ParseExpr.
+	Filter string `json:"filter,omitempty"`
+	// GroupBy are the columns the rows are grouped by. The distinct values
+	// of the columns are returned if there are no aggregations.
+	GroupBy      []string      `json:"group_by,omitempty"`
+	Aggregations []Aggregation `json:"aggregations,omitempty"`
+	// Start and End are the inclusive range of the timestamps of the rows,
+	// if any.
+	Start *int64 `json:"start,omitempty"`
+	End   *int64 `json:"end,omitempty"`
+}
+
+// Aggregation is an aggregation of a column, the function is one of sum,
+// count, min, max and avg.
+type Aggregation struct {
+	Function string `json:"function"`
+	Column   string `json:"column"`
+}
+
+// aggregations are the aggregation functions by their names.
+var aggregations = map[string]func(logicalplan.Expr) *logicalplan.AggregationFunction{
+	"sum":   logicalplan.Sum,
+	"count": logicalplan.Count,
+	"min":   logicalplan.Min,
+	"max":   logicalplan.Max,
+	"avg":   logicalplan.Avg,
+}
+
+// Handler executes the queries of requests on the tables of an engine.
+type Handler struct {
+	engine          *query.LocalEngine
+	timestampColumn string
+}
+
+type Option func(*Handler)
+
+// WithTimestampColumn sets the column the time ranges of queries apply to,
+// the column is "timestamp" by default.
+func WithTimestampColumn(column string) Option {
+	return func(h *Handler) {
+		h.timestampColumn = column
+	}
+}
+
+func NewHandler(engine *query.LocalEngine, options ...Option) *Handler {
+	h := &Handler{
+		engine:          engine,
+		timestampColumn: "timestamp",
+	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	var req Request
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return
+	}
+
+	b, err := h.builder(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	q, err := b.Prepare()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("prepare query: %w", err))
+		return
+	}
+
+	rw := newRowWriter(w, strings.Contains(r.Header.Get("Accept"), contentTypeNDJSON))
+	if err := q.Execute(r.Context(), nil, rw.write); err != nil {
+		if !rw.started {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("execute query: %w", err))
+			return
+		}
+		// The status was sent with the first rows, the response is aborted
+		// so clients don't mistake the rows for the complete result.
+		panic(http.ErrAbortHandler)
+	}
+	_ = rw.close()
+}
+
+// builder returns the builder of the query of the request.
+func (h *Handler) builder(req *Request) (query.Builder, error) {
+	if req.Table == "" {
+		return nil, errors.New("missing table")
+	}
+
+	var filters []logicalplan.Expr
+	if req.Filter != "" {
+		expr, err := logicalplan.ParseExpr(req.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("parse filter: %w", err)
+		}
+		filters = append(filters, expr)
+	}
+	if req.Start != nil {
+		filters = append(filters, logicalplan.Col(h.timestampColumn).GtEq(logicalplan.Literal(*req.Start)))
+	}
+	if req.End != nil {
+		filters = append(filters, logicalplan.Col(h.timestampColumn).LtEq(logicalplan.Literal(*req.End)))
+	}
+
+	groupBy := make([]logicalplan.Expr, 0, len(req.GroupBy))
+	for _, column := range req.GroupBy {
+		groupBy = append(groupBy, logicalplan.Col(column))
+	}
+	aggExprs := make([]logicalplan.Expr, 0, len(req.Aggregations))
+	for _, agg := range req.Aggregations {
+		fn, ok := aggregations[strings.ToLower(agg.Function)]
+		if !ok {
+			return nil, fmt.Errorf("unknown aggregation function %q", agg.Function)
+		}
+		if agg.Column == "" {
+			return nil, fmt.Errorf("missing column of aggregation %s", agg.Function)
+		}
+		aggExprs = append(aggExprs, fn(logicalplan.Col(agg.Column)))
+	}
+
+	b := h.engine.ScanTable(req.Table)
+	if len(filters) > 0 {
+		b = b.Filter(logicalplan.And(filters...))
+	}
+	switch {
+	case len(aggExprs) > 0:
+		b = b.Aggregations(aggExprs, groupBy...)
+	case len(groupBy) > 0:
+		b = b.Distinct(groupBy...)
+	}
+	return b, nil
+}
+
+// rowWriter streams the rows of records as JSON objects, either as the
+// elements of an array or delimited by newlines.
+type rowWriter struct {
+	w       http.ResponseWriter
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	ndjson  bool
+	started bool
+	rows    int
+}
+
+func newRowWriter(w http.ResponseWriter, ndjson bool) *rowWriter {
+	buf := bufio.NewWriter(w)
+	return &rowWriter{
+		w:      w,
+		buf:    buf,
+		enc:    json.NewEncoder(buf),
+		ndjson: ndjson,
+	}
+}
+
+// start writes the header of the response.
+func (rw *rowWriter) start() error {
+	if rw.started {
+		return nil
+	}
+	rw.started = true
+	if rw.ndjson {
+		rw.w.Header().Set("Content-Type", contentTypeNDJSON)
+		return nil
+	}
+	rw.w.Header().Set("Content-Type", "application/json")
+	return rw.buf.WriteByte('[')
+}
+
+func (rw *rowWriter) write(r arrow.Record) error {
+	if err := rw.start(); err != nil {
+		return err
+	}
+
+	fields := r.Schema().Fields()
+	for i := 0; i < int(r.NumRows()); i++ {
+		row := make(map[string]interface{}, len(fields))
+		for j, field := range fields {
+			v, err := value(r.Column(j), i)
+			if err != nil {
+				return err
+			}
+			row[field.Name] = v
+		}
+
+		if !rw.ndjson && rw.rows > 0 {
+			if err := rw.buf.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		// The encoder terminates each row with a newline.
+		if err := rw.enc.Encode(row); err != nil {
+			return err
+		}
+		rw.rows++
+	}
+
+	// The rows of each record are sent as they are produced.
+	if err := rw.buf.Flush(); err != nil {
+		return err
+	}
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// close terminates the rows, results without rows are empty arrays.
+func (rw *rowWriter) close() error {
+	if err := rw.start(); err != nil {
+		return err
+	}
+	if !rw.ndjson {
+		if _, err := rw.buf.WriteString("]\n"); err != nil {
+			return err
+		}
+	}
+	return rw.buf.Flush()
+}
@@ -0,0 +1,121 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+)
+
+// newTestHandler returns a handler of the test samples of the table "test".
+func newTestHandler(t *testing.T) *Handler {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	return NewHandler(query.NewEngine(memory.NewGoAllocator(), db.TableProvider()))
+}
+
+func serve(h http.Handler, body, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := serve(h, `{
+		"table": "test",
+		"filter": "value > 0",
+		"group_by": ["labels.namespace"],
+		"aggregations": [{"function": "sum", "column": "value"}],
+		"start": 1,
+		"end": 2
+	}`, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var rows []map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rows))
+	sums := map[interface{}]float64{}
+	for _, row := range rows {
+		sums[row["labels.namespace"]] = row["sum(value)"].(float64)
+	}
+	// Samples without a namespace are grouped as null.
+	require.Equal(t, map[interface{}]float64{nil: 5, "default": 6}, sums)
+
+	// The time range excludes all samples.
+	rec = serve(h, `{"table": "test", "start": 3}`, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.JSONEq(t, `[]`, rec.Body.String())
+}
+
+func TestHandlerNDJSON(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := serve(h, `{"table": "test", "group_by": ["value"]}`, contentTypeNDJSON)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, contentTypeNDJSON, rec.Header().Get("Content-Type"))
+
+	var values []float64
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var row map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		values = append(values, row["value"].(float64))
+	}
+	require.NoError(t, scanner.Err())
+	require.ElementsMatch(t, []float64{3, 5}, values)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := newTestHandler(t)
+
+	for name, body := range map[string]string{
+		"invalid json":     `{"table":`,
+		"unknown field":    `{"table": "test", "columns": ["value"]}`,
+		"missing table":    `{"filter": "value > 0"}`,
+		"invalid filter":   `{"table": "test", "filter": "value >"}`,
+		"unknown function": `{"table": "test", "aggregations": [{"function": "median", "column": "value"}]}`,
+		"missing column":   `{"table": "test", "aggregations": [{"function": "sum"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(h, body, "")
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var res errorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			require.NotEmpty(t, res.Error)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,71 @@
+package httpapi
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// value returns the JSON value of the row. Binary values are strings if they
+// are valid UTF-8 and base64 otherwise, floats that JSON can't represent are
+// strings.
+func value(arr arrow.Array, row int) (interface{}, error) {
+	if arr.IsNull(row) {
+		return nil, nil
+	}
+
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return arr.Value(row), nil
+	case *array.Int8:
+		return arr.Value(row), nil
+	case *array.Int16:
+		return arr.Value(row), nil
+	case *array.Int32:
+		return arr.Value(row), nil
+	case *array.Int64:
+		return arr.Value(row), nil
+	case *array.Uint8:
+		return arr.Value(row), nil
+	case *array.Uint16:
+		return arr.Value(row), nil
+	case *array.Uint32:
+		return arr.Value(row), nil
+	case *array.Uint64:
+		return arr.Value(row), nil
+	case *array.Float32:
+		return float(float64(arr.Value(row))), nil
+	case *array.Float64:
+		return float(arr.Value(row)), nil
+	case *array.String:
+		return arr.Value(row), nil
+	case *array.Binary:
+		v := arr.Value(row)
+		if utf8.Valid(v) {
+			return string(v), nil
+		}
+		return v, nil
+	default:
+		s, err := scalar.GetScalar(arr, row)
+		if err != nil {
+			return nil, err
+		}
+		return s.String(), nil
+	}
+}
+
+func float(v float64) interface{} {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return v
+	}
+}
@@ -0,0 +1,95 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestIterator(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	// Each insert is read as a separate record.
+	samples := dynparquet.NewTestSamples()
+	for i := range samples {
+		buf, err := samples[i : i+1].ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	t.Run("all records", func(t *testing.T) {
+		rr, err := engine.ScanTable("test").
+			Filter(logicalplan.Col("timestamp").GtEq(logicalplan.Literal(int64(1)))).
+			Iterator(context.Background())
+		require.NoError(t, err)
+
+		rows := int64(0)
+		for rr.Next() {
+			rows += rr.Record().NumRows()
+		}
+		require.NoError(t, rr.Err())
+		require.NoError(t, rr.Close())
+		require.Equal(t, int64(len(samples)), rows)
+	})
+
+	t.Run("close early", func(t *testing.T) {
+		rr, err := engine.ScanTable("test").Iterator(context.Background())
+		require.NoError(t, err)
+
+		require.True(t, rr.Next())
+		require.Equal(t, int64(1), rr.Record().NumRows())
+		// Closing stops the query, which is not an error.
+		require.NoError(t, rr.Close())
+		require.False(t, rr.Next())
+		require.NoError(t, rr.Close())
+	})
+
+	t.Run("failed query", func(t *testing.T) {
+		_, err := engine.ScanTable("test").
+			Project(logicalplan.Call("unknown", logicalplan.Col("value"))).
+			Iterator(context.Background())
+		require.ErrorIs(t, err, logicalplan.ErrUnknownFunction)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		rr, err := engine.ScanTable("test").Iterator(ctx)
+		require.NoError(t, err)
+		require.False(t, rr.Next())
+		require.ErrorIs(t, rr.Err(), context.Canceled)
+		require.ErrorIs(t, rr.Close(), context.Canceled)
+	})
+
+	t.Run("prepared query", func(t *testing.T) {
+		q, err := engine.ScanTable("test").
+			Filter(logicalplan.Col("value").Eq(logicalplan.Placeholder("value"))).
+			Prepare()
+		require.NoError(t, err)
+
+		rr, err := q.Iterator(context.Background(), map[string]interface{}{"value": samples[0].Value})
+		require.NoError(t, err)
+		defer rr.Close()
+
+		rows := int64(0)
+		for rr.Next() {
+			rows += rr.Record().NumRows()
+		}
+		require.NoError(t, rr.Err())
+		require.Equal(t, int64(1), rows)
+	})
+}
@@ -0,0 +1,295 @@
+package frostdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestJoin(t *testing.T) {
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+
+	metrics, err := db.Table("metrics", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i, job := range []string{"api", "web", "api", "batch", "web"} {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "job", Value: job},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i),
+			Value:     int64(i + 1),
+		})
+	}
+	buf, err := samples.ToBuffer(metrics.Schema())
+	require.NoError(t, err)
+	_, err = metrics.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "jobs",
+		Columns: []*schemapb.Column{{
+			Name: "job",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_STRING,
+			},
+		}, {
+			Name: "team",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_STRING,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "job",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+	jobs, err := db.Table("jobs", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	// There is no row for the batch job, and no samples of the db job.
+	jobsBuf, err := schema.NewBuffer(nil)
+	require.NoError(t, err)
+	_, err = jobsBuf.WriteRows([]parquet.Row{
+		{parquet.ValueOf("api").Level(0, 0, 0), parquet.ValueOf("backend").Level(0, 0, 1)},
+		{parquet.ValueOf("db").Level(0, 0, 0), parquet.ValueOf("storage").Level(0, 0, 1)},
+		{parquet.ValueOf("web").Level(0, 0, 0), parquet.ValueOf("frontend").Level(0, 0, 1)},
+	})
+	require.NoError(t, err)
+	_, err = jobs.InsertBuffer(context.Background(), jobsBuf)
+	require.NoError(t, err)
+
+	// Ensure all transactions are completed
+	metrics.Sync()
+	jobs.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	type row struct {
+		job   string
+		team  string
+		value int64
+	}
+	rows := []row{}
+	err = engine.ScanTable("metrics").
+		Join(
+			engine.ScanTable("jobs"),
+			[]logicalplan.Expr{logicalplan.Col("labels.job")},
+			[]logicalplan.Expr{logicalplan.Col("job")},
+		).
+		Project(
+			logicalplan.Col("labels.job"),
+			logicalplan.Col("team"),
+			logicalplan.Col("value"),
+		).
+		Execute(context.Background(), func(r arrow.Record) error {
+			jobCol := r.Column(0).(*array.Binary)
+			teamCol := r.Column(1).(*array.Binary)
+			valueCol := r.Column(2).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				rows = append(rows, row{
+					job:   string(jobCol.Value(i)),
+					team:  string(teamCol.Value(i)),
+					value: valueCol.Value(i),
+				})
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []row{
+		{job: "api", team: "backend", value: 1},
+		{job: "web", team: "frontend", value: 2},
+		{job: "api", team: "backend", value: 3},
+		{job: "web", team: "frontend", value: 5},
+	}, rows)
+
+	// The columns of the joined table can be aggregated by.
+	sums := map[string]int64{}
+	err = engine.ScanTable("metrics").
+		Join(
+			engine.ScanTable("jobs").Filter(logicalplan.Col("team").Eq(logicalplan.Literal("backend"))),
+			[]logicalplan.Expr{logicalplan.Col("labels.job")},
+			[]logicalplan.Expr{logicalplan.Col("job")},
+		).
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Col("team"),
+		).
+		Execute(context.Background(), func(r arrow.Record) error {
+			teamCol := r.Column(0).(*array.Binary)
+			sumCol := r.Column(1).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				sums[string(teamCol.Value(i))] = sumCol.Value(i)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"backend": 4}, sums)
+}
+
+func TestMergeJoin(t *testing.T) {
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+
+	// Both tables are sorted by job, so they are joined by merging them.
+	metricsSchema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "metrics",
+		Columns: []*schemapb.Column{{
+			Name: "job",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_STRING,
+			},
+		}, {
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}, {
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "job",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+	metrics, err := db.Table("metrics", NewTableConfig(metricsSchema))
+	require.NoError(t, err)
+
+	jobsSchema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "jobs",
+		Columns: []*schemapb.Column{{
+			Name: "job",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_STRING,
+			},
+		}, {
+			Name: "team",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_STRING,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "job",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+	jobs, err := db.Table("jobs", NewTableConfig(jobsSchema))
+	require.NoError(t, err)
+
+	// The rows of each table are inserted in multiple buffers, so they are
+	// merged when read in order.
+	insert := func(table *Table, schema *dynparquet.Schema, rows []parquet.Row) {
+		buf, err := schema.NewBuffer(nil)
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		buf.Sort()
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	metric := func(job string, timestamp, value int64) parquet.Row {
+		return parquet.Row{
+			parquet.ValueOf(job).Level(0, 0, 0),
+			parquet.ValueOf(timestamp).Level(0, 0, 1),
+			parquet.ValueOf(value).Level(0, 0, 2),
+		}
+	}
+	job := func(job, team string) parquet.Row {
+		return parquet.Row{
+			parquet.ValueOf(job).Level(0, 0, 0),
+			parquet.ValueOf(team).Level(0, 0, 1),
+		}
+	}
+	insert(metrics, metricsSchema, []parquet.Row{metric("web", 1, 1), metric("api", 2, 2), metric("batch", 3, 3)})
+	insert(metrics, metricsSchema, []parquet.Row{metric("api", 1, 4), metric("web", 2, 5)})
+	insert(jobs, jobsSchema, []parquet.Row{job("web", "frontend"), job("api", "backend")})
+	insert(jobs, jobsSchema, []parquet.Row{job("db", "storage"), job("api", "platform")})
+
+	// Ensure all transactions are completed
+	metrics.Sync()
+	jobs.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	rows := []string{}
+	err = engine.ScanTable("metrics").
+		Join(
+			engine.ScanTable("jobs"),
+			[]logicalplan.Expr{logicalplan.Col("job")},
+			[]logicalplan.Expr{logicalplan.Col("job")},
+		).
+		Project(
+			logicalplan.Col("job"),
+			logicalplan.Col("timestamp"),
+			logicalplan.Col("team"),
+		).
+		Execute(context.Background(), func(r arrow.Record) error {
+			jobCol := r.Column(0).(*array.Binary)
+			timestampCol := r.Column(1).(*array.Int64)
+			teamCol := r.Column(2).(*array.Binary)
+			for i := 0; i < int(r.NumRows()); i++ {
+				rows = append(rows, fmt.Sprintf("%s %d %s", jobCol.Value(i), timestampCol.Value(i), teamCol.Value(i)))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	// The rows are in the order of the left table, the order of the rows
+	// they are joined with isn't defined.
+	require.ElementsMatch(t, []string{
+		"api 1 backend",
+		"api 1 platform",
+		"api 2 backend",
+		"api 2 platform",
+		"web 1 frontend",
+		"web 2 frontend",
+	}, rows)
+	order := make([]string, 0, len(rows))
+	for _, row := range rows {
+		order = append(order, row[:5])
+	}
+	require.Equal(t, []string{"api 1", "api 1", "api 2", "api 2", "web 1", "web 2"}, order)
+}
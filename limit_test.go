@@ -0,0 +1,102 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestLimit(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	for i := int64(0); i < 4; i++ {
+		samples := dynparquet.Samples{}
+		for j := int64(0); j < 5; j++ {
+			samples = append(samples, dynparquet.Sample{
+				Labels: []dynparquet.Label{
+					{Name: "job", Value: []string{"api", "web"}[j%2]},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: i*5 + j,
+				Value:     i*5 + j,
+			})
+		}
+
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	values := func(b query.Builder) []int64 {
+		values := []int64{}
+		err := b.Execute(context.Background(), func(r arrow.Record) error {
+			for i, field := range r.Schema().Fields() {
+				if field.Name == "value" {
+					col := r.Column(i).(*array.Int64)
+					for j := 0; j < col.Len(); j++ {
+						values = append(values, col.Value(j))
+					}
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return values
+	}
+
+	// The scan stops early, which rows are read first isn't defined.
+	require.Len(t, values(engine.ScanTable("test").Limit(7)), 7)
+	require.Len(t, values(engine.ScanTable("test").Offset(15)), 5)
+	require.Len(t, values(engine.ScanTable("test").Limit(0)), 0)
+
+	require.Equal(t, []int64{17, 16, 15}, values(engine.ScanTable("test").
+		OrderBy(logicalplan.Desc(logicalplan.Col("value"))).
+		Offset(2).
+		Limit(3).
+		Project(logicalplan.Col("value")),
+	))
+
+	require.Equal(t, []int64{1, 3}, values(engine.ScanTable("test").
+		Filter(logicalplan.Col("labels.job").Eq(logicalplan.Literal("web"))).
+		OrderBy(logicalplan.Asc(logicalplan.Col("value"))).
+		Limit(2).
+		Project(logicalplan.Col("value")),
+	))
+}
@@ -0,0 +1,342 @@
+package frostdb
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/query/physicalplan"
+)
+
+// lateMaterializationMaxGap is the number of rows between two ranges of
+// matching rows up to which the rows in between are decoded too. Skipping
+// fewer rows than that isn't worth it, since the page they are in has to be
+// decoded again to seek past them.
+const lateMaterializationMaxGap = 1024
+
+// lateMaterializer converts row groups to records by evaluating the filter on
+// the columns it uses first, and then only decoding the row ranges of the
+// other columns that contain matching rows. The records only contain the
+// matching rows. Its methods can be called on nil, in which case the row
+// groups are converted without filtering them.
+type lateMaterializer struct {
+	// predicates are the predicates the filter requires all rows to match.
+	predicates []*materializedPredicate
+}
+
+// materializedPredicate is one of the predicates that are combined by the
+// filter's conjunctions.
+type materializedPredicate struct {
+	filter  *physicalplan.PredicateFilter
+	columns []logicalplan.Expr
+	// column is the name of the only column the predicate uses, or empty if
+	// it uses none or more than one. Predicates on a single column are
+	// evaluated on the values of the column's dictionary if it has one.
+	column string
+}
+
+// newLateMaterializer returns a lateMaterializer for the filter, or nil if
+// the filter can't be evaluated before the row groups are converted. The
+// filters of distinct scans are evaluated on the distinct values only, so
+// they aren't materialized late either. The lateMaterializer must not be
+// used concurrently.
+func newLateMaterializer(
+	pool memory.Allocator,
+	filterExpr logicalplan.Expr,
+	distinctColumns []logicalplan.Expr,
+) *lateMaterializer {
+	if filterExpr == nil || len(distinctColumns) > 0 {
+		return nil
+	}
+
+	m := &lateMaterializer{}
+	for _, expr := range conjuncts(filterExpr, nil) {
+		filter, err := physicalplan.Filter(pool, expr)
+		if err != nil {
+			return nil
+		}
+		m.predicates = append(m.predicates, &materializedPredicate{
+			filter:  filter,
+			columns: expr.ColumnsUsedExprs(),
+			column:  singleColumn(expr),
+		})
+	}
+	return m
+}
+
+// conjuncts appends the predicates combined by the conjunctions of the
+// expression.
+func conjuncts(expr logicalplan.Expr, exprs []logicalplan.Expr) []logicalplan.Expr {
+	if e, ok := expr.(*logicalplan.BinaryExpr); ok && e.Op == logicalplan.OpAnd {
+		return conjuncts(e.Right, conjuncts(e.Left, exprs))
+	}
+	return append(exprs, expr)
+}
+
+// singleColumn returns the name of the only column the expression uses, or
+// an empty string if it doesn't use exactly one column.
+func singleColumn(expr logicalplan.Expr) string {
+	name := ""
+	for _, used := range expr.ColumnsUsedExprs() {
+		col, ok := used.(*logicalplan.Column)
+		if !ok || (name != "" && name != col.ColumnName) {
+			return ""
+		}
+		name = col.ColumnName
+	}
+	return name
+}
+
+// record converts the row group to a record of the schema, it returns nil if
+// none of the rows match the filter.
+func (m *lateMaterializer) record(
+	ctx context.Context,
+	pool memory.Allocator,
+	rg dynparquet.DynamicRowGroup,
+	schema *arrow.Schema,
+	filterExpr logicalplan.Expr,
+	distinctColumns []logicalplan.Expr,
+) (arrow.Record, error) {
+	if m == nil {
+		return pqarrow.ParquetRowGroupToArrowRecord(ctx, pool, rg, schema, filterExpr, distinctColumns)
+	}
+	// The rows of merged row groups aren't in the order of the rows of their
+	// column chunks.
+	if _, ok := rg.(*dynparquet.MergedRowGroup); ok {
+		return pqarrow.ParquetRowGroupToArrowRecord(ctx, pool, rg, schema, filterExpr, distinctColumns)
+	}
+
+	// The predicates on dictionary encoded columns are evaluated first, the
+	// columns they use don't need to be decoded unless other predicates use
+	// them too.
+	var bitmap *physicalplan.Bitmap
+	remaining := make([]*materializedPredicate, 0, len(m.predicates))
+	for _, p := range m.predicates {
+		matches, ok, err := p.evalDictionary(pool, rg)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			remaining = append(remaining, p)
+			continue
+		}
+		bitmap = intersect(bitmap, matches)
+		if bitmap.IsEmpty() {
+			return nil, nil
+		}
+	}
+
+	filterFields := []arrow.Field{}
+	otherFields := []arrow.Field{}
+	for _, field := range schema.Fields() {
+		if usesColumn(remaining, field.Name) {
+			filterFields = append(filterFields, field)
+		} else {
+			otherFields = append(otherFields, field)
+		}
+	}
+	if bitmap == nil && (len(filterFields) == 0 || len(otherFields) == 0) {
+		return pqarrow.ParquetRowGroupToArrowRecord(ctx, pool, rg, schema, filterExpr, distinctColumns)
+	}
+
+	var filterRecord arrow.Record
+	if len(filterFields) > 0 {
+		var err error
+		filterRecord, err = pqarrow.ParquetRowGroupToArrowRecord(
+			ctx,
+			pool,
+			rg,
+			arrow.NewSchema(filterFields, nil),
+			filterExpr,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer filterRecord.Release()
+
+		for _, p := range remaining {
+			matches, err := p.filter.Eval(filterRecord)
+			if err != nil {
+				return nil, err
+			}
+			bitmap = intersect(bitmap, matches)
+			if bitmap.IsEmpty() {
+				return nil, nil
+			}
+		}
+	}
+	rows := bitmap.ToArray()
+
+	var ranges []dynparquet.RowRange
+	if len(rows) < int(rg.NumRows()) {
+		ranges = selectedRowRanges(rows)
+	}
+
+	if filterRecord != nil && ranges != nil {
+		filtered, err := pqarrow.SelectRows(pool, filterRecord, rows)
+		if err != nil {
+			return nil, err
+		}
+		defer filtered.Release()
+		filterRecord = filtered
+	}
+
+	var otherRecord arrow.Record
+	if len(otherFields) > 0 {
+		otherRG := dynparquet.DynamicRowGroup(rg)
+		if ranges != nil {
+			otherRG = dynparquet.NewRowRangesRowGroup(rg, ranges)
+		}
+		var err error
+		otherRecord, err = pqarrow.ParquetRowGroupToArrowRecord(
+			ctx,
+			pool,
+			otherRG,
+			arrow.NewSchema(otherFields, nil),
+			filterExpr,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer otherRecord.Release()
+
+		if ranges != nil && int(otherRecord.NumRows()) > len(rows) {
+			// The ranges include the non-matching rows of the gaps that
+			// weren't skipped.
+			selected, err := pqarrow.SelectRows(pool, otherRecord, rangeRows(ranges, rows))
+			if err != nil {
+				return nil, err
+			}
+			defer selected.Release()
+			otherRecord = selected
+		}
+	}
+
+	cols := make([]arrow.Array, 0, len(schema.Fields()))
+	filterIndex, otherIndex := 0, 0
+	for _, field := range schema.Fields() {
+		if usesColumn(remaining, field.Name) {
+			cols = append(cols, filterRecord.Column(filterIndex))
+			filterIndex++
+		} else {
+			cols = append(cols, otherRecord.Column(otherIndex))
+			otherIndex++
+		}
+	}
+	return array.NewRecord(schema, cols, int64(len(rows))), nil
+}
+
+// evalDictionary returns the rows of the row group that match the predicate
+// by evaluating it on the values of the dictionary of the column it uses once,
+// and then matching the indexes of the rows against the matching values. It
+// returns false if the predicate doesn't use a single column, or if the
+// column isn't dictionary encoded.
+func (p *materializedPredicate) evalDictionary(
+	pool memory.Allocator,
+	rg dynparquet.DynamicRowGroup,
+) (*physicalplan.Bitmap, bool, error) {
+	if p.column == "" {
+		return nil, false, nil
+	}
+	leaf, ok := dynparquet.LookupColumn(rg.Schema(), p.column)
+	if !ok {
+		return nil, false, nil
+	}
+
+	dict, ok, err := pqarrow.ParquetColumnToDictionary(pool, leaf.Node, rg.ColumnChunks()[leaf.ColumnIndex])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	defer dict.Release()
+	if int64(len(dict.Indexes)) != rg.NumRows() {
+		return nil, false, nil
+	}
+
+	values := array.NewRecord(
+		arrow.NewSchema([]arrow.Field{{Name: p.column, Type: dict.Values.DataType(), Nullable: true}}, nil),
+		[]arrow.Array{dict.Values},
+		int64(dict.Values.Len()),
+	)
+	defer values.Release()
+	matchingValues, err := p.filter.Eval(values)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res := physicalplan.NewBitmap()
+	if matchingValues.IsEmpty() {
+		return res, true, nil
+	}
+	matching := make([]bool, dict.Values.Len())
+	for _, index := range matchingValues.ToArray() {
+		matching[index] = true
+	}
+	rows := make([]uint32, 0, len(dict.Indexes))
+	for row, index := range dict.Indexes {
+		if matching[index] {
+			rows = append(rows, uint32(row))
+		}
+	}
+	res.AddMany(rows)
+	return res, true, nil
+}
+
+// intersect returns the rows both bitmaps contain, a nil bitmap contains all
+// rows.
+func intersect(bitmap, other *physicalplan.Bitmap) *physicalplan.Bitmap {
+	if bitmap == nil {
+		return other
+	}
+	bitmap.And(other)
+	return bitmap
+}
+
+func usesColumn(predicates []*materializedPredicate, name string) bool {
+	for _, p := range predicates {
+		for _, c := range p.columns {
+			if c.MatchColumn(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectedRowRanges returns the ranges of the rows, which must be in
+// ascending order. Ranges separated by at most lateMaterializationMaxGap rows
+// are joined.
+func selectedRowRanges(rows []uint32) []dynparquet.RowRange {
+	ranges := []dynparquet.RowRange{}
+	cur := dynparquet.RowRange{Start: int64(rows[0]), End: int64(rows[0]) + 1}
+	for _, row := range rows[1:] {
+		if int64(row)-cur.End > lateMaterializationMaxGap {
+			ranges = append(ranges, cur)
+			cur = dynparquet.RowRange{Start: int64(row)}
+		}
+		cur.End = int64(row) + 1
+	}
+	return append(ranges, cur)
+}
+
+// rangeRows returns the indexes of the rows within the rows of the ranges
+// they are in.
+func rangeRows(ranges []dynparquet.RowRange, rows []uint32) []uint32 {
+	res := make([]uint32, 0, len(rows))
+	offset := int64(0)
+	i := 0
+	for _, row := range rows {
+		for int64(row) >= ranges[i].End {
+			offset += ranges[i].NumRows()
+			i++
+		}
+		res = append(res, uint32(offset+int64(row)-ranges[i].Start))
+	}
+	return res
+}
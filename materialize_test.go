@@ -0,0 +1,217 @@
+package frostdb
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestLateMaterialization(t *testing.T) {
+	table := basicTable(t, 1<<13)
+
+	samples := make(dynparquet.Samples, 0, 3000)
+	for i := 0; i < 3000; i++ {
+		samples = append(samples, dynparquet.Sample{
+			ExampleType: "test",
+			Labels: []dynparquet.Label{
+				{Name: "label1", Value: "value1"},
+			},
+			Timestamp: int64(i),
+			Value:     int64(i),
+		})
+	}
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		values     []int64
+	}{
+		"selective": {
+			// The first values are a separate range, the rows between
+			// the last values are decoded but don't match.
+			filterExpr: logicalplan.Col("value").In(
+				logicalplan.Literal(5),
+				logicalplan.Literal(6),
+				logicalplan.Literal(7),
+				logicalplan.Literal(2000),
+				logicalplan.Literal(2005),
+			),
+			values: []int64{5, 6, 7, 2000, 2005},
+		},
+		"all rows": {
+			filterExpr: logicalplan.Col("value").GtEq(logicalplan.Literal(0)),
+		},
+		"no rows": {
+			filterExpr: logicalplan.Col("value").Lt(logicalplan.Literal(0)),
+			values:     []int64{},
+		},
+	}
+
+	pool := memory.NewGoAllocator()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expected := test.values
+			if expected == nil {
+				for i := 0; i < len(samples); i++ {
+					expected = append(expected, int64(i))
+				}
+			}
+
+			err := table.View(func(tx uint64) error {
+				schema, err := table.ArrowSchema(context.Background(), tx, pool, nil, nil, nil, nil)
+				require.NoError(t, err)
+
+				timestamps := []int64{}
+				values := []int64{}
+				err = table.Iterator(context.Background(), tx, pool, schema, nil, nil, test.filterExpr, nil, func(r arrow.Record) error {
+					require.Equal(t, schema.Fields(), r.Schema().Fields())
+					timestamp := r.Column(schema.FieldIndices("timestamp")[0]).(*array.Int64)
+					value := r.Column(schema.FieldIndices("value")[0]).(*array.Int64)
+					for i := 0; i < int(r.NumRows()); i++ {
+						timestamps = append(timestamps, timestamp.Value(i))
+						values = append(values, value.Value(i))
+					}
+					return nil
+				})
+				require.NoError(t, err)
+				require.Equal(t, expected, values)
+				require.Equal(t, expected, timestamps)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDictionaryFilter(t *testing.T) {
+	table := basicTable(t, 1<<13)
+
+	// Every third row has the same value of label1, and label2 is null on
+	// the odd rows.
+	label1 := []string{"a", "b", "c"}
+	label2 := []string{"x", "", "y", ""}
+	samples := make(dynparquet.Samples, 0, 300)
+	for i := 0; i < 300; i++ {
+		labels := []dynparquet.Label{{Name: "label1", Value: label1[i%3]}}
+		if v := label2[i%4]; v != "" {
+			labels = append(labels, dynparquet.Label{Name: "label2", Value: v})
+		}
+		samples = append(samples, dynparquet.Sample{
+			ExampleType: "test",
+			Labels:      labels,
+			Timestamp:   int64(i),
+			Value:       int64(i),
+		})
+	}
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+	err = table.View(func(tx uint64) error {
+		rowGroups, err := table.collectRowGroups(ctx, tx, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, rowGroups)
+		for _, rg := range rowGroups {
+			fields := rg.Schema().Fields()
+			for i, field := range fields {
+				if field.Name() != "labels.label1" {
+					continue
+				}
+				dict, ok, err := pqarrow.ParquetColumnToDictionary(pool, field, rg.ColumnChunks()[i])
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, int(rg.NumRows()), len(dict.Indexes))
+				dict.Release()
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		matches    func(i int) bool
+	}{
+		"equal": {
+			filterExpr: logicalplan.Col("labels.label1").Eq(logicalplan.Literal("b")),
+			matches:    func(i int) bool { return label1[i%3] == "b" },
+		},
+		"regex": {
+			filterExpr: logicalplan.Col("labels.label1").RegexMatch("^(a|c)$"),
+			matches:    func(i int) bool { return label1[i%3] != "b" },
+		},
+		"not equal with nulls": {
+			filterExpr: logicalplan.Col("labels.label2").NotEq(logicalplan.Literal("x")),
+			matches:    func(i int) bool { return label2[i%4] != "x" },
+		},
+		"regex with nulls": {
+			filterExpr: logicalplan.Col("labels.label2").RegexMatch("x|y"),
+			matches:    func(i int) bool { return label2[i%4] != "" },
+		},
+		"and": {
+			filterExpr: logicalplan.And(
+				logicalplan.Col("labels.label1").Eq(logicalplan.Literal("b")),
+				logicalplan.Col("value").GtEq(logicalplan.Literal(150)),
+			),
+			matches: func(i int) bool { return label1[i%3] == "b" && i >= 150 },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expected := []int64{}
+			for i := range samples {
+				if test.matches(i) {
+					expected = append(expected, int64(i))
+				}
+			}
+
+			err := table.View(func(tx uint64) error {
+				schema, err := table.ArrowSchema(ctx, tx, pool, nil, nil, nil, nil)
+				require.NoError(t, err)
+
+				values := []int64{}
+				err = table.Iterator(ctx, tx, pool, schema, nil, nil, test.filterExpr, nil, func(r arrow.Record) error {
+					require.Equal(t, schema.Fields(), r.Schema().Fields())
+					value := r.Column(schema.FieldIndices("value")[0]).(*array.Int64)
+					for i := 0; i < int(r.NumRows()); i++ {
+						values = append(values, value.Value(i))
+					}
+					return nil
+				})
+				require.NoError(t, err)
+				sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+				require.Equal(t, expected, values)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSelectedRowRanges(t *testing.T) {
+	rows := []uint32{1, 2, 3, 1000, 3000, 3001}
+	ranges := selectedRowRanges(rows)
+	require.Equal(t, []dynparquet.RowRange{
+		{Start: 1, End: 1001},
+		{Start: 3000, End: 3002},
+	}, ranges)
+	require.Equal(t, []uint32{0, 1, 2, 999, 1000, 1001}, rangeRows(ranges, rows))
+}
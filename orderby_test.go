@@ -0,0 +1,352 @@
+package frostdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestOrderBy(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	sample := func(job, region string, timestamp, value int64) dynparquet.Sample {
+		labels := []dynparquet.Label{{Name: "job", Value: job}}
+		if region != "" {
+			labels = append(labels, dynparquet.Label{Name: "region", Value: region})
+		}
+		return dynparquet.Sample{
+			Labels: labels,
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: timestamp,
+			Value:     value,
+		}
+	}
+
+	// The inserts result in records with different columns.
+	for _, samples := range []dynparquet.Samples{{
+		sample("web", "", 1, 10),
+		sample("api", "", 2, 20),
+		sample("web", "", 3, 30),
+	}, {
+		sample("api", "eu", 1, 40),
+		sample("web", "us", 2, 50),
+		sample("api", "us", 3, 60),
+	}} {
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	for _, test := range []struct {
+		name     string
+		exprs    []logicalplan.SortExpr
+		expected []int64
+	}{{
+		name:     "ascending",
+		exprs:    []logicalplan.SortExpr{logicalplan.Asc(logicalplan.Col("value"))},
+		expected: []int64{10, 20, 30, 40, 50, 60},
+	}, {
+		name:     "descending",
+		exprs:    []logicalplan.SortExpr{logicalplan.Desc(logicalplan.Col("value"))},
+		expected: []int64{60, 50, 40, 30, 20, 10},
+	}, {
+		name: "multiple columns",
+		exprs: []logicalplan.SortExpr{
+			logicalplan.Asc(logicalplan.Col("labels.job")),
+			logicalplan.Desc(logicalplan.Col("timestamp")),
+		},
+		expected: []int64{60, 20, 40, 30, 50, 10},
+	}, {
+		name: "nulls last",
+		exprs: []logicalplan.SortExpr{
+			logicalplan.Asc(logicalplan.Col("labels.region")),
+			logicalplan.Asc(logicalplan.Col("timestamp")),
+		},
+		expected: []int64{40, 50, 60, 10, 20, 30},
+	}, {
+		name: "nulls first",
+		exprs: []logicalplan.SortExpr{
+			logicalplan.Desc(logicalplan.Col("labels.region")),
+			logicalplan.Asc(logicalplan.Col("timestamp")),
+		},
+		expected: []int64{10, 20, 30, 50, 60, 40},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			values := []int64{}
+			err := engine.ScanTable("test").
+				OrderBy(test.exprs...).
+				Project(
+					logicalplan.DynCol("labels"),
+					logicalplan.Col("timestamp"),
+					logicalplan.Col("value"),
+				).
+				Execute(context.Background(), func(r arrow.Record) error {
+					for i, field := range r.Schema().Fields() {
+						if field.Name == "value" {
+							col := r.Column(i).(*array.Int64)
+							for j := 0; j < col.Len(); j++ {
+								values = append(values, col.Value(j))
+							}
+						}
+					}
+					return nil
+				})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestOrderBySortingColumns(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "region",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}, {
+			Name:          "value",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "region",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_DESCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	// Small granules are split and compacted as the rows are inserted.
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	row := func(region string, timestamp int64) parquet.Row {
+		r := parquet.ValueOf(nil).Level(0, 0, 0)
+		if region != "" {
+			r = parquet.ValueOf(region).Level(0, 1, 0)
+		}
+		return parquet.Row{
+			r,
+			parquet.ValueOf(timestamp).Level(0, 0, 1),
+			parquet.ValueOf(timestamp*10).Level(0, 0, 2),
+		}
+	}
+	for _, rows := range [][]parquet.Row{
+		{row("us", 1), row("eu", 2), row("", 3)},
+		{row("eu", 4), row("us", 5), row("eu", 1)},
+		{row("", 2), row("us", 3), row("eu", 3)},
+	} {
+		buf, err := schema.NewBuffer(nil)
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		buf.Sort()
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+	for _, test := range []struct {
+		name     string
+		exprs    []logicalplan.SortExpr
+		elided   bool
+		expected []int64
+	}{{
+		name:     "sorting columns",
+		exprs:    []logicalplan.SortExpr{logicalplan.Asc(logicalplan.Col("region")), logicalplan.Desc(logicalplan.Col("timestamp"))},
+		elided:   true,
+		expected: []int64{4, 3, 2, 1, 5, 3, 1, 3, 2},
+	}, {
+		name:     "prefix of the sorting columns",
+		exprs:    []logicalplan.SortExpr{logicalplan.Asc(logicalplan.Col("region"))},
+		elided:   true,
+		expected: []int64{4, 3, 2, 1, 5, 3, 1, 3, 2},
+	}, {
+		name:     "other direction",
+		exprs:    []logicalplan.SortExpr{logicalplan.Asc(logicalplan.Col("region")), logicalplan.Asc(logicalplan.Col("timestamp"))},
+		expected: []int64{1, 2, 3, 4, 1, 3, 5, 2, 3},
+	}, {
+		// Order bys sort nulls first in descending order, unlike the
+		// sorting column.
+		name:     "nullable column in other direction",
+		exprs:    []logicalplan.SortExpr{logicalplan.Desc(logicalplan.Col("region")), logicalplan.Desc(logicalplan.Col("timestamp"))},
+		expected: []int64{3, 2, 5, 3, 1, 4, 3, 2, 1},
+	}, {
+		name:     "reverse of the sorting columns",
+		exprs:    []logicalplan.SortExpr{logicalplan.Desc(logicalplan.Col("region")), logicalplan.Asc(logicalplan.Col("timestamp"))},
+		expected: []int64{2, 3, 1, 3, 5, 1, 2, 3, 4},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			explain, err := engine.ScanTable("test").
+				OrderBy(test.exprs...).
+				Project(logicalplan.Col("timestamp")).
+				Explain()
+			require.NoError(t, err)
+			require.Equal(t, test.elided, !strings.Contains(explain[strings.Index(explain, "Physical Plan:"):], "OrderBy"), explain)
+
+			timestamps := []int64{}
+			err = engine.ScanTable("test").
+				OrderBy(test.exprs...).
+				Project(logicalplan.Col("timestamp")).
+				Execute(ctx, func(r arrow.Record) error {
+					col := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+					timestamps = append(timestamps, col.Int64Values()...)
+					return nil
+				})
+			require.NoError(t, err)
+			// Rows of the same region and timestamp are in any order, they
+			// have the same values.
+			require.Equal(t, test.expected, timestamps)
+		})
+	}
+}
+
+func TestOrderByReverseSortingColumns(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "region",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRING},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "region",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_DESCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	row := func(region string, timestamp int64) parquet.Row {
+		return parquet.Row{
+			parquet.ValueOf(region).Level(0, 0, 0),
+			parquet.ValueOf(timestamp).Level(0, 0, 1),
+		}
+	}
+	for _, rows := range [][]parquet.Row{
+		{row("us", 1), row("eu", 2), row("us", 4)},
+		{row("eu", 4), row("us", 5), row("eu", 1)},
+	} {
+		buf, err := schema.NewBuffer(nil)
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		buf.Sort()
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	// Only order bys in the directions of the sorting columns are elided,
+	// the columns aren't nullable so the order of nulls doesn't matter.
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+	for _, test := range []struct {
+		name     string
+		exprs    []logicalplan.SortExpr
+		elided   bool
+		expected []int64
+	}{{
+		name:     "sorting columns",
+		exprs:    []logicalplan.SortExpr{logicalplan.Asc(logicalplan.Col("region")), logicalplan.Desc(logicalplan.Col("timestamp"))},
+		elided:   true,
+		expected: []int64{4, 2, 1, 5, 4, 1},
+	}, {
+		name:     "reverse of the sorting columns",
+		exprs:    []logicalplan.SortExpr{logicalplan.Desc(logicalplan.Col("region")), logicalplan.Asc(logicalplan.Col("timestamp"))},
+		expected: []int64{1, 4, 5, 1, 2, 4},
+	}, {
+		name:     "reverse of the first sorting column",
+		exprs:    []logicalplan.SortExpr{logicalplan.Desc(logicalplan.Col("region"))},
+		expected: []int64{5, 4, 1, 4, 2, 1},
+	}, {
+		name:     "reverse of the second sorting column",
+		exprs:    []logicalplan.SortExpr{logicalplan.Asc(logicalplan.Col("region")), logicalplan.Asc(logicalplan.Col("timestamp"))},
+		expected: []int64{1, 2, 4, 1, 4, 5},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			explain, err := engine.ScanTable("test").
+				OrderBy(test.exprs...).
+				Project(logicalplan.Col("timestamp")).
+				Explain()
+			require.NoError(t, err)
+			require.Equal(t, test.elided, !strings.Contains(explain[strings.Index(explain, "Physical Plan:"):], "OrderBy"), explain)
+
+			timestamps := []int64{}
+			err = engine.ScanTable("test").
+				OrderBy(test.exprs...).
+				Project(logicalplan.Col("timestamp")).
+				Execute(ctx, func(r arrow.Record) error {
+					col := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+					timestamps = append(timestamps, col.Int64Values()...)
+					return nil
+				})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, timestamps)
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package pgwire
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// The SQLSTATE codes of errors.
+const (
+	codeSyntaxError         = "42601"
+	codeFeatureNotSupported = "0A000"
+	codeProtocolViolation   = "08P01"
+	codeInternalError       = "XX000"
+	codeQueryCanceled       = "57014"
+)
+
+// The OIDs of the types of columns.
+const (
+	oidBool    = 16
+	oidInt8    = 20
+	oidInt2    = 21
+	oidInt4    = 23
+	oidText    = 25
+	oidFloat4  = 700
+	oidFloat8  = 701
+	oidNumeric = 1700
+)
+
+// messageWriter writes the messages of the server, the first write error is
+// returned by flush.
+type messageWriter struct {
+	w   *bufio.Writer
+	buf []byte
+	err error
+}
+
+func (m *messageWriter) begin(typ byte) {
+	m.buf = append(m.buf[:0], typ, 0, 0, 0, 0)
+}
+
+func (m *messageWriter) int16(v int16) {
+	m.buf = append(m.buf, byte(v>>8), byte(v))
+}
+
+func (m *messageWriter) int32(v int32) {
+	m.buf = append(m.buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (m *messageWriter) string(s string) {
+	m.buf = append(append(m.buf, s...), 0)
+}
+
+func (m *messageWriter) end() {
+	binary.BigEndian.PutUint32(m.buf[1:], uint32(len(m.buf)-1))
+	if m.err == nil {
+		_, m.err = m.w.Write(m.buf)
+	}
+}
+
+func (m *messageWriter) flush() error {
+	if m.err != nil {
+		return m.err
+	}
+	return m.w.Flush()
+}
+
+func (m *messageWriter) authenticationOk() {
+	m.begin('R')
+	m.int32(0)
+	m.end()
+}
+
+func (m *messageWriter) backendKeyData(processID, secret int32) {
+	m.begin('K')
+	m.int32(processID)
+	m.int32(secret)
+	m.end()
+}
+
+func (m *messageWriter) parameterStatus(name, value string) {
+	m.begin('S')
+	m.string(name)
+	m.string(value)
+	m.end()
+}
+
+func (m *messageWriter) readyForQuery() {
+	m.begin('Z')
+	m.buf = append(m.buf, 'I')
+	m.end()
+}
+
+func (m *messageWriter) emptyQueryResponse() {
+	m.begin('I')
+	m.end()
+}
+
+func (m *messageWriter) commandComplete(tag string) {
+	m.begin('C')
+	m.string(tag)
+	m.end()
+}
+
+func (m *messageWriter) errorResponse(code, message string) {
+	m.begin('E')
+	for _, field := range []struct {
+		typ   byte
+		value string
+	}{
+		{'S', "ERROR"},
+		{'V', "ERROR"},
+		{'C', code},
+		{'M', message},
+	} {
+		m.buf = append(m.buf, field.typ)
+		m.string(field.value)
+	}
+	m.buf = append(m.buf, 0)
+	m.end()
+}
+
+func (m *messageWriter) rowDescription(columns []column) {
+	m.begin('T')
+	m.int16(int16(len(columns)))
+	for _, c := range columns {
+		m.string(c.name)
+		m.int32(0) // The OID of the table.
+		m.int16(0) // The attribute number of the column.
+		m.int32(c.oid)
+		m.int16(c.size)
+		m.int32(-1) // The type modifier.
+		m.int16(0)  // The text format.
+	}
+	m.end()
+}
+
+// dataRow writes the values of the row in text format, nil values and the
+// columns after the end of the row are null.
+func (m *messageWriter) dataRow(row []*string, columns int) {
+	m.begin('D')
+	m.int16(int16(columns))
+	for i := 0; i < columns; i++ {
+		if i >= len(row) || row[i] == nil {
+			m.int32(-1)
+			continue
+		}
+		m.int32(int32(len(*row[i])))
+		m.buf = append(m.buf, *row[i]...)
+	}
+	m.end()
+}
+
+type column struct {
+	name string
+	oid  int32
+	size int16
+}
+
+// result holds the rows of a query as text, the columns are the columns of
+// all of its records in the order they were first seen.
+type result struct {
+	columns []column
+	index   map[string]int
+	rows    [][]*string
+}
+
+func (res *result) append(r arrow.Record) error {
+	indices := make([]int, r.NumCols())
+	for i, field := range r.Schema().Fields() {
+		index, ok := res.index[field.Name]
+		if !ok {
+			index = len(res.columns)
+			res.index[field.Name] = index
+			oid, size := columnType(field.Type)
+			res.columns = append(res.columns, column{name: field.Name, oid: oid, size: size})
+		}
+		indices[i] = index
+	}
+
+	for row := 0; row < int(r.NumRows()); row++ {
+		values := make([]*string, len(res.columns))
+		for i, arr := range r.Columns() {
+			if arr.IsNull(row) {
+				continue
+			}
+			value, err := text(arr, row)
+			if err != nil {
+				return err
+			}
+			values[indices[i]] = &value
+		}
+		res.rows = append(res.rows, values)
+	}
+	return nil
+}
+
+// columnType returns the OID and the size of the Postgres type of the Arrow
+// type, values of types without a Postgres type are text.
+func columnType(t arrow.DataType) (int32, int16) {
+	switch t.ID() {
+	case arrow.BOOL:
+		return oidBool, 1
+	case arrow.INT8, arrow.UINT8, arrow.INT16:
+		return oidInt2, 2
+	case arrow.UINT16, arrow.INT32:
+		return oidInt4, 4
+	case arrow.UINT32, arrow.INT64:
+		return oidInt8, 8
+	case arrow.UINT64:
+		return oidNumeric, -1
+	case arrow.FLOAT32:
+		return oidFloat4, 4
+	case arrow.FLOAT64:
+		return oidFloat8, 8
+	default:
+		return oidText, -1
+	}
+}
+
+// text returns the text format of the value of the row.
+func text(arr arrow.Array, row int) (string, error) {
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		if arr.Value(row) {
+			return "t", nil
+		}
+		return "f", nil
+	case *array.Int8:
+		return strconv.FormatInt(int64(arr.Value(row)), 10), nil
+	case *array.Int16:
+		return strconv.FormatInt(int64(arr.Value(row)), 10), nil
+	case *array.Int32:
+		return strconv.FormatInt(int64(arr.Value(row)), 10), nil
+	case *array.Int64:
+		return strconv.FormatInt(arr.Value(row), 10), nil
+	case *array.Uint8:
+		return strconv.FormatUint(uint64(arr.Value(row)), 10), nil
+	case *array.Uint16:
+		return strconv.FormatUint(uint64(arr.Value(row)), 10), nil
+	case *array.Uint32:
+		return strconv.FormatUint(uint64(arr.Value(row)), 10), nil
+	case *array.Uint64:
+		return strconv.FormatUint(arr.Value(row), 10), nil
+	case *array.Float32:
+		return strconv.FormatFloat(float64(arr.Value(row)), 'g', -1, 32), nil
+	case *array.Float64:
+		return strconv.FormatFloat(arr.Value(row), 'g', -1, 64), nil
+	case *array.String:
+		return arr.Value(row), nil
+	case *array.Binary:
+		// Strings are stored as binary, binary values that aren't strings
+		// are written like bytea values.
+		v := arr.Value(row)
+		if utf8.Valid(v) {
+			return string(v), nil
+		}
+		return `\x` + hex.EncodeToString(v), nil
+	default:
+		s, err := scalar.GetScalar(arr, row)
+		if err != nil {
+			return "", err
+		}
+		return s.String(), nil
+	}
+}
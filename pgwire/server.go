@@ -0,0 +1,364 @@
+// Package pgwire serves frostdb queries over the PostgreSQL wire protocol, so
+// psql, the Postgres datasources of dashboards and Postgres drivers can query
+// an embedded frostdb. Only the simple query protocol is supported, the SQL
+// of queries is translated to logical plans by a parser, such as
+// sqlparse.Parse:
+//
+//	s := pgwire.NewServer(engine, func(query string) (*logicalplan.LogicalPlan, error) {
+//		return sqlparse.Parse(db.TableProvider(), query)
+//	})
+//	lis, err := net.Listen("tcp", "localhost:5432")
+//	if err != nil {
+//		return err
+//	}
+//	return s.Serve(lis)
+//
+// Clients aren't authenticated, the server must only be reachable by trusted
+// clients.
+package pgwire
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// SQLParser translates the SQL of a query to a logical plan.
+type SQLParser func(query string) (*logicalplan.LogicalPlan, error)
+
+// Server executes the SQL queries of PostgreSQL clients on the tables of an
+// engine.
+type Server struct {
+	engine *query.LocalEngine
+	parse  SQLParser
+	logger log.Logger
+
+	mtx sync.Mutex
+	// sessions are the sessions of the connections by their process IDs,
+	// which cancel requests refer to.
+	sessions      map[int32]*session
+	nextProcessID int32
+}
+
+// session is the state of a connection that is shared with the cancel
+// requests of other connections.
+type session struct {
+	secret int32
+
+	mtx sync.Mutex
+	// cancel cancels the query the connection is executing, if any.
+	cancel context.CancelFunc
+}
+
+// setCancel sets the function that cancels the query of the session.
+func (s *session) setCancel(cancel context.CancelFunc) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.cancel = cancel
+}
+
+// cancelQuery cancels the query the session is executing, if any.
+func (s *session) cancelQuery() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+type Option func(*Server)
+
+// WithLogger sets the logger the errors of connections are logged with.
+func WithLogger(logger log.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+func NewServer(engine *query.LocalEngine, parse SQLParser, options ...Option) *Server {
+	s := &Server{
+		engine:   engine,
+		parse:    parse,
+		logger:   log.NewNopLogger(),
+		sessions: map[int32]*session{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Serve serves the connections of the listener until it is closed.
+func (s *Server) Serve(lis net.Listener) error {
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			if err := s.serveConn(conn); err != nil && !errors.Is(err, io.EOF) {
+				level.Warn(s.logger).Log("msg", "failed to serve connection", "remote", conn.RemoteAddr(), "err", err)
+			}
+		}()
+	}
+}
+
+// The codes of the startup requests.
+const (
+	protocolVersion = 196608
+	sslRequest      = 80877103
+	gssEncRequest   = 80877104
+	cancelRequest   = 80877102
+)
+
+// maxMessageSize limits the size of the messages of clients.
+const maxMessageSize = 1 << 24
+
+// readStartup reads the startup message of a connection, declining requests
+// for encryption. It returns the code of the startup message, which is
+// either the protocol version or a cancel request, and its body.
+func readStartup(r *bufio.Reader, w *bufio.Writer) (uint32, []byte, error) {
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(msg) < 4 {
+			return 0, nil, errors.New("invalid startup message")
+		}
+
+		switch code := binary.BigEndian.Uint32(msg); code {
+		case protocolVersion, cancelRequest:
+			return code, msg[4:], nil
+		case sslRequest, gssEncRequest:
+			if err := w.WriteByte('N'); err != nil {
+				return 0, nil, err
+			}
+			if err := w.Flush(); err != nil {
+				return 0, nil, err
+			}
+		default:
+			return 0, nil, fmt.Errorf("unsupported protocol version %d", code)
+		}
+	}
+}
+
+// readMessage reads the body of a message without a type.
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n < 4 || n > maxMessageSize {
+		return nil, fmt.Errorf("invalid message size %d", n)
+	}
+	msg := make([]byte, n-4)
+	_, err := io.ReadFull(r, msg)
+	return msg, err
+}
+
+// newSession registers a new session and returns its process ID.
+func (s *Server) newSession() (int32, *session, error) {
+	var secret [4]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return 0, nil, err
+	}
+	sess := &session{secret: int32(binary.BigEndian.Uint32(secret[:]))}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.nextProcessID++
+	s.sessions[s.nextProcessID] = sess
+	return s.nextProcessID, sess, nil
+}
+
+func (s *Server) closeSession(processID int32) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.sessions, processID)
+}
+
+// cancel cancels the query of the session that the cancel request refers to
+// by its process ID and secret key. Requests with the wrong key are ignored.
+func (s *Server) cancel(msg []byte) {
+	if len(msg) < 8 {
+		return
+	}
+	processID := int32(binary.BigEndian.Uint32(msg))
+	secret := int32(binary.BigEndian.Uint32(msg[4:]))
+
+	s.mtx.Lock()
+	sess, ok := s.sessions[processID]
+	s.mtx.Unlock()
+	if ok && sess.secret == secret {
+		sess.cancelQuery()
+	}
+}
+
+// message is a message of a client.
+type message struct {
+	typ  byte
+	body []byte
+}
+
+func (s *Server) serveConn(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	code, startup, err := readStartup(r, w)
+	if err != nil {
+		return err
+	}
+	if code == cancelRequest {
+		// Cancel requests are sent on connections of their own, which
+		// are closed without a response.
+		s.cancel(startup)
+		return nil
+	}
+
+	processID, sess, err := s.newSession()
+	if err != nil {
+		return err
+	}
+	defer s.closeSession(processID)
+
+	m := &messageWriter{w: w}
+	m.authenticationOk()
+	for _, p := range [][2]string{
+		{"server_version", "14.0"},
+		{"server_encoding", "UTF8"},
+		{"client_encoding", "UTF8"},
+		{"DateStyle", "ISO"},
+		{"integer_datetimes", "on"},
+		{"standard_conforming_strings", "on"},
+	} {
+		m.parameterStatus(p[0], p[1])
+	}
+	m.backendKeyData(processID, sess.secret)
+	m.readyForQuery()
+	if err := m.flush(); err != nil {
+		return err
+	}
+
+	// The context of the connection is canceled once the connection is
+	// closed, which cancels the query it's executing. The messages are read
+	// while queries are executed to notice that.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	msgs := make(chan message)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			typ, err := r.ReadByte()
+			var body []byte
+			if err == nil {
+				body, err = readMessage(r)
+			}
+			if err != nil {
+				cancel()
+				readErr <- err
+				return
+			}
+			select {
+			case msgs <- message{typ: typ, body: body}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// failed is set once a message of the extended query protocol failed,
+	// the following messages are discarded until the next sync.
+	failed := false
+	for {
+		var msg message
+		select {
+		case msg = <-msgs:
+		case err := <-readErr:
+			return err
+		}
+
+		switch msg.typ {
+		case 'Q':
+			queryCtx, cancelQuery := context.WithCancel(ctx)
+			sess.setCancel(cancelQuery)
+			s.query(queryCtx, m, strings.TrimRight(string(msg.body), "\x00"))
+			sess.setCancel(nil)
+			cancelQuery()
+			m.readyForQuery()
+		case 'X':
+			return nil
+		case 'S':
+			failed = false
+			m.readyForQuery()
+		case 'P', 'B', 'D', 'E', 'C', 'H', 'F':
+			if !failed {
+				failed = true
+				m.errorResponse(codeFeatureNotSupported, "the extended query protocol is not supported")
+			}
+		default:
+			m.errorResponse(codeProtocolViolation, fmt.Sprintf("unsupported message type %q", msg.typ))
+		}
+		if err := m.flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// query executes the query and writes its rows.
+func (s *Server) query(ctx context.Context, m *messageWriter, sql string) {
+	if strings.Trim(sql, " \t\r\n;") == "" {
+		m.emptyQueryResponse()
+		return
+	}
+
+	plan, err := s.parse(sql)
+	if err != nil {
+		m.errorResponse(codeSyntaxError, err.Error())
+		return
+	}
+	q, err := s.engine.PreparePlan(plan)
+	if err != nil {
+		m.errorResponse(codeSyntaxError, err.Error())
+		return
+	}
+
+	// The records of a query can have different dynamic columns, all rows
+	// are collected so the columns of all of them are described.
+	res := &result{index: map[string]int{}}
+	if err := q.Execute(ctx, nil, func(r arrow.Record) error {
+		return res.append(r)
+	}); err != nil {
+		if ctx.Err() != nil {
+			m.errorResponse(codeQueryCanceled, "canceling statement due to user request")
+			return
+		}
+		m.errorResponse(codeInternalError, err.Error())
+		return
+	}
+
+	m.rowDescription(res.columns)
+	for _, row := range res.rows {
+		m.dataRow(row, len(res.columns))
+	}
+	m.commandComplete(fmt.Sprintf("SELECT %d", len(res.rows)))
+}
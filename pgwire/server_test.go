@@ -0,0 +1,210 @@
+package pgwire
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/sqlparse"
+)
+
+// blockingTable is a table whose scans block until they're canceled.
+type blockingTable struct {
+	logicalplan.TableReader
+	started  chan struct{}
+	canceled chan struct{}
+}
+
+func (t *blockingTable) Iterator(
+	ctx context.Context,
+	_ uint64,
+	_ memory.Allocator,
+	_ *arrow.Schema,
+	_ []logicalplan.Expr,
+	_ []logicalplan.Expr,
+	_ logicalplan.Expr,
+	_ []logicalplan.Expr,
+	_ func(r arrow.Record) error,
+) error {
+	close(t.started)
+	<-ctx.Done()
+	close(t.canceled)
+	return ctx.Err()
+}
+
+// tableProvider provides the blocking table as the table "blocking".
+type tableProvider struct {
+	logicalplan.TableProvider
+	blocking *blockingTable
+}
+
+func (p *tableProvider) GetTable(name string) logicalplan.TableReader {
+	if name == "blocking" {
+		return p.blocking
+	}
+	return p.TableProvider.GetTable(name)
+}
+
+// newTestServer serves the test samples of the table "test" and the table
+// "blocking", and returns the address of the server.
+func newTestServer(t *testing.T) (string, *blockingTable) {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	provider := &tableProvider{
+		TableProvider: db.TableProvider(),
+		blocking: &blockingTable{
+			TableReader: table,
+			started:     make(chan struct{}),
+			canceled:    make(chan struct{}),
+		},
+	}
+	engine := query.NewEngine(memory.NewGoAllocator(), provider)
+	s := NewServer(engine, func(query string) (*logicalplan.LogicalPlan, error) {
+		return sqlparse.Parse(provider, query)
+	})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(func() {
+		lis.Close()
+	})
+	return lis.Addr().String(), provider.blocking
+}
+
+// newTestClient returns a client of a test server, see newTestServer.
+func newTestClient(t *testing.T) (*sql.DB, *blockingTable) {
+	addr, blocking := newTestServer(t)
+	client, err := sql.Open("postgres", "postgres://test@"+addr+"/test?sslmode=disable")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return client, blocking
+}
+
+func TestServer(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	rows, err := client.Query(`
+		SELECT labels['namespace'] AS namespace, sum(value) AS total
+		FROM test
+		GROUP BY namespace
+		ORDER BY total`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+	require.Equal(t, []string{"namespace", "total"}, columns)
+	types, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	require.Equal(t, "TEXT", types[0].DatabaseTypeName())
+	require.Equal(t, "INT8", types[1].DatabaseTypeName())
+
+	totals := map[sql.NullString]int64{}
+	for rows.Next() {
+		var namespace sql.NullString
+		var total int64
+		require.NoError(t, rows.Scan(&namespace, &total))
+		totals[namespace] = total
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, map[sql.NullString]int64{
+		{}:                               5,
+		{String: "default", Valid: true}: 6,
+	}, totals)
+}
+
+func TestServerErrors(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.Query("SELECT value FROM")
+	require.Error(t, err)
+
+	// The extended query protocol isn't supported.
+	_, err = client.Query("SELECT value FROM test WHERE value > $1", 1)
+	require.Error(t, err)
+
+	// The connections can be used after errors.
+	var count int64
+	require.NoError(t, client.QueryRow("SELECT count(value) FROM test").Scan(&count))
+	require.Equal(t, int64(3), count)
+}
+
+func TestServerCancelRequest(t *testing.T) {
+	client, blocking := newTestClient(t)
+
+	// The client sends a cancel request once the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-blocking.started
+		cancel()
+	}()
+	_, err := client.QueryContext(ctx, "SELECT value FROM blocking")
+	require.Error(t, err)
+
+	select {
+	case <-blocking.canceled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("query wasn't canceled")
+	}
+
+	// The connections can be used after cancellations.
+	var count int64
+	require.NoError(t, client.QueryRow("SELECT count(value) FROM test").Scan(&count))
+	require.Equal(t, int64(3), count)
+}
+
+func TestServerConnectionClosed(t *testing.T) {
+	addr, blocking := newTestServer(t)
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The startup message of protocol version 3.0, after which the query is
+	// sent without waiting for the responses.
+	params := "user\x00test\x00\x00"
+	startup := binary.BigEndian.AppendUint32(nil, uint32(8+len(params)))
+	startup = binary.BigEndian.AppendUint32(startup, protocolVersion)
+	startup = append(startup, params...)
+	sql := "SELECT value FROM blocking\x00"
+	q := binary.BigEndian.AppendUint32([]byte{'Q'}, uint32(4+len(sql)))
+	q = append(q, sql...)
+	_, err = conn.Write(append(startup, q...))
+	require.NoError(t, err)
+
+	<-blocking.started
+	require.NoError(t, conn.Close())
+	select {
+	case <-blocking.canceled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("query wasn't canceled")
+	}
+}
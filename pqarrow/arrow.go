@@ -8,6 +8,7 @@ import (
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/decimal128"
 	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/segmentio/parquet-go"
 
@@ -53,13 +54,13 @@ func ParquetRowGroupToArrowSchema(
 
 	fields := make([]arrow.Field, 0, len(parquetFields))
 
-	for _, parquetField := range parquetFields {
+	for _, f := range columnFields(parquetFields) {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			if includedProjection(physicalProjections, parquetField.Name()) {
-				af, err := convert.ParquetFieldToArrowField(parquetField)
+			if f.projected(physicalProjections) {
+				af, err := f.arrowField()
 				if err != nil {
 					return nil, err
 				}
@@ -85,6 +86,70 @@ func ParquetRowGroupToArrowSchema(
 	return arrow.NewSchema(fields, nil), nil
 }
 
+// columnField is a field that a column of arrow records can be converted
+// from, the field of a column or of a struct column, such as
+// "payload.status".
+type columnField struct {
+	name string
+	node parquet.Node
+	// The leaf columns of the values of the field are the columns from start
+	// to end.
+	start, end int
+	nested     bool
+}
+
+// columnFields returns the fields of the schema followed by the fields of
+// each of its struct columns. The keys and values of map columns aren't
+// fields of their own.
+func columnFields(parquetFields []parquet.Field) []columnField {
+	offsets := dynparquet.FieldColumnOffsets(parquetFields)
+	fields := make([]columnField, 0, len(parquetFields))
+	for i, pf := range parquetFields {
+		fields = append(fields, columnField{
+			name:  pf.Name(),
+			node:  pf,
+			start: offsets[i],
+			end:   offsets[i+1],
+		})
+		if pf.Leaf() || dynparquet.IsMap(pf) {
+			continue
+		}
+		for j, child := range pf.Fields() {
+			node, _ := dynparquet.StructField(pf, child.Name())
+			fields = append(fields, columnField{
+				name:   pf.Name() + "." + child.Name(),
+				node:   node,
+				start:  offsets[i] + j,
+				end:    offsets[i] + j + 1,
+				nested: true,
+			})
+		}
+	}
+	return fields
+}
+
+// projected returns whether the field is converted for the projections.
+// Fields of struct columns are only converted on their own if a projection
+// matches them.
+func (f columnField) projected(projections []logicalplan.Expr) bool {
+	if f.nested && len(projections) == 0 {
+		return false
+	}
+	return includedProjection(projections, f.name)
+}
+
+func (f columnField) arrowField() (arrow.Field, error) {
+	typ, err := convert.ParquetNodeToType(f.node)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	return arrow.Field{
+		Name:     f.name,
+		Type:     typ,
+		Nullable: f.node.Optional(),
+	}, nil
+}
+
 func includedProjection(projections []logicalplan.Expr, name string) bool {
 	if len(projections) == 0 {
 		return true
@@ -142,6 +207,7 @@ func rowBasedParquetRowGroupToArrowRecord(
 	}
 
 	// Create arrow writers from arrow and parquet schema
+	offsets := dynparquet.FieldColumnOffsets(parquetFields)
 	writers := make([]writer.ValueWriter, len(parquetFields))
 	b := array.NewRecordBuilder(pool, schema)
 	for i, field := range b.Fields() {
@@ -175,7 +241,7 @@ func rowBasedParquetRowGroupToArrowRecord(
 
 		for i, writer := range writers {
 			for _, row := range rowBuf {
-				values := dynparquet.ValuesForIndex(row, i)
+				values := dynparquet.ValuesForColumns(row, offsets[i], offsets[i+1])
 				writer.Write(values)
 			}
 		}
@@ -187,6 +253,81 @@ func rowBasedParquetRowGroupToArrowRecord(
 	return b.NewRecord(), nil
 }
 
+// ParquetRowGroupToArrowRecords converts a parquet row group row by row to
+// arrow records of at most batchSize rows, which keeps the order of the rows.
+// Only the columns matching the physical projections are converted.
+func ParquetRowGroupToArrowRecords(
+	ctx context.Context,
+	pool memory.Allocator,
+	rg parquet.RowGroup,
+	physicalProjections []logicalplan.Expr,
+	batchSize int,
+	callback func(r arrow.Record) error,
+) error {
+	parquetFields := rg.Schema().Fields()
+
+	fields := make([]arrow.Field, 0, len(parquetFields))
+	projected := make([]columnField, 0, len(parquetFields))
+	for _, f := range columnFields(parquetFields) {
+		if !f.projected(physicalProjections) {
+			continue
+		}
+		af, err := f.arrowField()
+		if err != nil {
+			return err
+		}
+		fields = append(fields, af)
+		projected = append(projected, f)
+	}
+
+	b := array.NewRecordBuilder(pool, arrow.NewSchema(fields, nil))
+	defer b.Release()
+
+	writers := make([]writer.ValueWriter, len(fields))
+	for i, field := range b.Fields() {
+		_, newValueWriter, err := convert.ParquetNodeToTypeWithWriterFunc(projected[i].node)
+		if err != nil {
+			return err
+		}
+		writers[i] = newValueWriter(field, 0)
+	}
+
+	rows := rg.Rows()
+	defer rows.Close()
+	rowBuf := make([]parquet.Row, batchSize)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		n, err := rows.ReadRows(rowBuf)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("read row: %w", err)
+		}
+
+		if n > 0 {
+			for i, writer := range writers {
+				for _, row := range rowBuf[:n] {
+					writer.Write(dynparquet.ValuesForColumns(row, projected[i].start, projected[i].end))
+				}
+			}
+
+			r := b.NewRecord()
+			cbErr := callback(r)
+			r.Release()
+			if cbErr != nil {
+				return cbErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
 // contiguousParquetRowGroupToArrowRecord converts a parquet row group to an arrow record.
 func contiguousParquetRowGroupToArrowRecord(
 	ctx context.Context,
@@ -199,6 +340,7 @@ func contiguousParquetRowGroupToArrowRecord(
 	s := rg.Schema()
 	parquetColumns := rg.ColumnChunks()
 	parquetFields := s.Fields()
+	offsets := dynparquet.FieldColumnOffsets(parquetFields)
 
 	if filterExpr == nil && len(distinctColumns) == 0 && SingleMatchingColumn(distinctColumns, parquetFields) {
 		// We can use the faster path for a single distinct column by just
@@ -212,10 +354,10 @@ func contiguousParquetRowGroupToArrowRecord(
 			default:
 				name := field.Name()
 				if distinctColumns[0].MatchColumn(name) {
-					array, err := parquetColumnToArrowArray(
+					array, err := parquetFieldToArrowArray(
 						pool,
 						field,
-						parquetColumns[i],
+						parquetColumns[offsets[i]:offsets[i+1]],
 						true,
 					)
 					if err != nil {
@@ -254,23 +396,23 @@ func contiguousParquetRowGroupToArrowRecord(
 
 	cols := make([]arrow.Array, len(schema.Fields()))
 
-	for i, parquetField := range parquetFields {
+	for _, f := range columnFields(parquetFields) {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			if schema.HasField(parquetField.Name()) {
-				a, err := parquetColumnToArrowArray(
+			if schema.HasField(f.name) {
+				a, err := parquetFieldToArrowArray(
 					pool,
-					parquetField,
-					parquetColumns[i],
+					f.node,
+					parquetColumns[f.start:f.end],
 					false,
 				)
 				if err != nil {
 					return nil, fmt.Errorf("convert parquet column to arrow array: %w", err)
 				}
 
-				index := schema.FieldIndices(parquetField.Name())[0]
+				index := schema.FieldIndices(f.name)[0]
 				cols[index] = a
 			}
 		}
@@ -294,6 +436,158 @@ func contiguousParquetRowGroupToArrowRecord(
 	return r, nil
 }
 
+// parquetFieldToArrowArray converts the leaf columns of a field to an arrow
+// array, struct columns have a leaf column per field and map columns have a
+// leaf column of keys and one of values.
+func parquetFieldToArrowArray(
+	pool memory.Allocator,
+	n parquet.Node,
+	columns []parquet.ColumnChunk,
+	dictionaryOnly bool,
+) (
+	arrow.Array,
+	error,
+) {
+	if n.Leaf() {
+		return parquetColumnToArrowArray(pool, n, columns[0], dictionaryOnly)
+	}
+	if dynparquet.IsMap(n) {
+		return parquetMapToArrowArray(pool, n, columns)
+	}
+	return parquetStructToArrowArray(pool, n, columns)
+}
+
+// parquetMapToArrowArray converts the leaf columns of the keys and values of
+// a map column to an arrow map array.
+func parquetMapToArrowArray(
+	pool memory.Allocator,
+	n parquet.Node,
+	columns []parquet.ColumnChunk,
+) (
+	arrow.Array,
+	error,
+) {
+	at, newValueWriter, err := convert.ParquetNodeToTypeWithWriterFunc(n)
+	if err != nil {
+		return nil, fmt.Errorf("convert ParquetNodeToTypeWithWriterFunc failed: %v", err)
+	}
+	b := array.NewBuilder(pool, at)
+	defer b.Release()
+
+	// The map writer pairs the keys and values of the entries, so it writes
+	// the values of both columns at once.
+	values := make([]parquet.Value, 0, columns[0].NumValues()+columns[1].NumValues())
+	for _, c := range columns {
+		if values, err = appendColumnValues(values, c); err != nil {
+			return nil, err
+		}
+	}
+	newValueWriter(b, len(values)).Write(values)
+
+	return b.NewArray(), nil
+}
+
+// appendColumnValues appends the values of the column chunk to values.
+func appendColumnValues(values []parquet.Value, columnChunk parquet.ColumnChunk) ([]parquet.Value, error) {
+	pages := columnChunk.Pages()
+	defer pages.Close()
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				return values, nil
+			}
+			return nil, fmt.Errorf("read page: %w", err)
+		}
+
+		pageValues := make([]parquet.Value, p.NumValues())
+		_, err = p.Values().ReadValues(pageValues)
+		// We're reading all values in the page so we always expect an io.EOF.
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("read values: %w", err)
+		}
+		// The buffers of pages may be reused once the next page is read.
+		for _, v := range pageValues {
+			values = append(values, v.Clone())
+		}
+	}
+}
+
+// parquetStructToArrowArray converts the leaf columns of the fields of a
+// struct column to an arrow struct array. The structs of nullable struct
+// columns are null in the rows where the values of the first field aren't
+// defined at the level of the struct.
+func parquetStructToArrowArray(
+	pool memory.Allocator,
+	n parquet.Node,
+	columns []parquet.ColumnChunk,
+) (
+	arrow.Array,
+	error,
+) {
+	at, err := convert.ParquetNodeToType(n)
+	if err != nil {
+		return nil, fmt.Errorf("convert ParquetNodeToType failed: %v", err)
+	}
+	b := array.NewBuilder(pool, at).(*array.StructBuilder)
+	defer b.Release()
+
+	for i, field := range n.Fields() {
+		_, newValueWriter, err := convert.ParquetNodeToTypeWithWriterFunc(field)
+		if err != nil {
+			return nil, fmt.Errorf("convert ParquetNodeToTypeWithWriterFunc failed: %v", err)
+		}
+		w := newValueWriter(b.FieldBuilder(i), int(columns[i].NumValues()))
+		// The values of the fields of null structs are null.
+		optional := n.Optional() || field.Optional()
+		if err := writeColumnToArray(columns[i], optional, false, nil, w, false); err != nil {
+			return nil, fmt.Errorf("writePagesToArray failed: %v", err)
+		}
+	}
+
+	validity := make([]bool, b.FieldBuilder(0).Len())
+	if n.Optional() {
+		if err := definedValues(columns[0], validity); err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range validity {
+			validity[i] = true
+		}
+	}
+	b.AppendValues(validity)
+
+	return b.NewArray(), nil
+}
+
+// definedValues sets whether the values of the column are defined at the
+// first definition level.
+func definedValues(columnChunk parquet.ColumnChunk, defined []bool) error {
+	pages := columnChunk.Pages()
+	defer pages.Close()
+	i := 0
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("read page: %w", err)
+		}
+
+		values := make([]parquet.Value, p.NumValues())
+		_, err = p.Values().ReadValues(values)
+		// We're reading all values in the page so we always expect an io.EOF.
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("read values: %w", err)
+		}
+		for _, v := range values {
+			defined[i] = v.DefinitionLevel() > 0
+			i++
+		}
+	}
+}
+
 // parquetColumnToArrowArray converts a single parquet column to an arrow array
 // and returns the type, nullability, and the actual resulting arrow array. If
 // a column is a repeated type, it automatically boxes it into the appropriate
@@ -307,7 +601,12 @@ func parquetColumnToArrowArray(
 	arrow.Array,
 	error,
 ) {
-	at, newValueWriter, err := convert.ParquetNodeToTypeWithWriterFunc(n)
+	elem := n
+	if n.Repeated() {
+		// The values of lists are written by the value writer of their type.
+		elem = parquet.Required(n)
+	}
+	at, newValueWriter, err := convert.ParquetNodeToTypeWithWriterFunc(elem)
 	if err != nil {
 		return nil, fmt.Errorf("convert ParquetNodeToTypeWithWriterFunc failed: %v", err)
 	}
@@ -327,10 +626,9 @@ func parquetColumnToArrowArray(
 	// builder once and can perform optimized transfers of the page values to
 	// the target array.
 	if n.Repeated() {
-		// If the column is repeated, we need to box it into a list.
-		lt := arrow.ListOf(at)
-		lt.SetElemNullable(optional)
-		at = lt
+		// If the column is repeated, we need to box it into a list. List
+		// builders always build lists of nullable values.
+		at = arrow.ListOf(at)
 
 		repeated = true
 
@@ -358,17 +656,7 @@ func parquetColumnToArrowArray(
 		return nil, fmt.Errorf("writePagesToArray failed: %v", err)
 	}
 
-	arr := b.NewArray()
-
-	// Is this a bug in arrow? We already set the nullability above, but it
-	// doesn't appear to transfer into the resulting array's type. Needs to be
-	// investigated.
-	switch t := arr.DataType().(type) {
-	case *arrow.ListType:
-		t.SetElemNullable(optional)
-	}
-
-	return arr, nil
+	return b.NewArray(), nil
 }
 
 // writeColumnToArray writes the values of a single parquet column to an arrow
@@ -411,6 +699,11 @@ func writeColumnToArray(
 			if err != nil {
 				return fmt.Errorf("write dictionary page: %w", err)
 			}
+			// The dictionary doesn't have the null values of optional
+			// columns, which are distinct values too.
+			if optional && p.NumNulls() > 0 {
+				w.Write([]parquet.Value{parquet.ValueOf(nil)})
+			}
 		case !repeated && !optional && dict == nil:
 			// If the column is not optional, we can read all values at once
 			// consecutively without worrying about null values.
@@ -427,29 +720,31 @@ func writeColumnToArray(
 				return fmt.Errorf("read values: %w", err)
 			}
 
-			w.Write(values)
-
-			if repeated {
-				offsets := []int32{}
-				validity := []bool{}
-				for _, v := range values {
-					rep := v.RepetitionLevel()
-					def := v.DefinitionLevel()
-					if rep == 0 && def == 1 {
-						offsets = append(offsets, int32(i))
-						validity = append(validity, true)
-					}
-					if rep == 0 && def == 0 {
-						offsets = append(offsets, int32(i))
-						validity = append(validity, false)
-					}
-					// rep == 1 && def == 1 means the item in the list is not null which is handled by the value writer
-					// rep == 1 && def == 0 means the item in the list is null which is handled by the value writer
+			if !repeated {
+				w.Write(values)
+				continue
+			}
+
+			offsets := []int32{}
+			validity := []bool{}
+			items := make([]parquet.Value, 0, len(values))
+			for _, v := range values {
+				rep := v.RepetitionLevel()
+				def := v.DefinitionLevel()
+				if rep == 0 {
+					// A value of repetition level 0 starts a list,
+					// lists of rows without values are null.
+					offsets = append(offsets, int32(i))
+					validity = append(validity, def > 0)
+				}
+				if def > 0 {
+					items = append(items, v)
 					i++
 				}
-
-				lb.AppendValues(offsets, validity)
 			}
+
+			w.Write(items)
+			lb.AppendValues(offsets, validity)
 		}
 	}
 
@@ -483,6 +778,7 @@ func distinctColumnsToArrowRecord(
 	distinctColumns []logicalplan.Expr,
 ) (arrow.Record, error) {
 	cols := make([]arrow.Array, len(schema.Fields()))
+	offsets := dynparquet.FieldColumnOffsets(parquetFields)
 	for i, field := range parquetFields {
 		select {
 		case <-ctx.Done():
@@ -497,11 +793,15 @@ func distinctColumnsToArrowRecord(
 					return nil, nil
 				}
 				if matchers[0].MatchColumn(name) {
+					if !field.Leaf() {
+						// The fast path only applies to leaf columns.
+						return nil, nil
+					}
 					// Fast path for distinct queries.
 					array, err := writeDistinctColumnToArray(
 						pool,
 						field,
-						parquetColumns[i],
+						parquetColumns[offsets[i]],
 						distinctColumn,
 					)
 					if err != nil {
@@ -691,6 +991,15 @@ func allOrNoneGreaterThan(
 	allTrue := true
 	allFalse := true
 	for i := 0; i < numPages; i++ {
+		// Null values are never greater than the value, and the min and max
+		// values of pages of only null values are meaningless.
+		if index.NullCount(i) > 0 {
+			allTrue = false
+		}
+		if index.NullPage(i) {
+			continue
+		}
+
 		min := index.MinValue(i)
 		max := index.MaxValue(i)
 
@@ -712,6 +1021,14 @@ func repeatArray(
 	count int,
 ) arrow.Array {
 	defer arr.Release()
+	if arr.IsNull(0) {
+		b := array.NewBuilder(pool, arr.DataType())
+		defer b.Release()
+		for i := 0; i < count; i++ {
+			b.AppendNull()
+		}
+		return b.NewArray()
+	}
 	switch arr := arr.(type) {
 	case *array.Boolean:
 		return repeatBooleanArray(pool, arr, count)
@@ -723,6 +1040,10 @@ func repeatArray(
 		return repeatUint64Array(pool, arr, count)
 	case *array.Float64:
 		return repeatFloat64Array(pool, arr, count)
+	case *array.Timestamp:
+		return repeatTimestampArray(pool, arr, count)
+	case *array.Decimal128:
+		return repeatDecimal128Array(pool, arr, count)
 	default:
 		panic(fmt.Sprintf("unsupported array type: %T", arr))
 	}
@@ -807,3 +1128,75 @@ func repeatFloat64Array(
 	b.AppendValues(vals, nil)
 	return b.NewFloat64Array()
 }
+
+func repeatTimestampArray(
+	pool memory.Allocator,
+	arr *array.Timestamp,
+	count int,
+) *array.Timestamp {
+	b := array.NewTimestampBuilder(pool, arr.DataType().(*arrow.TimestampType))
+	defer b.Release()
+	val := arr.Value(0)
+	vals := make([]arrow.Timestamp, count)
+	for i := 0; i < count; i++ {
+		vals[i] = val
+	}
+	b.AppendValues(vals, nil)
+	return b.NewTimestampArray()
+}
+
+func repeatDecimal128Array(
+	pool memory.Allocator,
+	arr *array.Decimal128,
+	count int,
+) *array.Decimal128 {
+	b := array.NewDecimal128Builder(pool, arr.DataType().(*arrow.Decimal128Type))
+	defer b.Release()
+	val := arr.Value(0)
+	vals := make([]decimal128.Num, count)
+	for i := 0; i < count; i++ {
+		vals[i] = val
+	}
+	b.AppendValues(vals, nil)
+	return b.NewDecimal128Array()
+}
+
+// SelectRows returns a record with the given rows of the record. The rows
+// must be in ascending order. The result must be released.
+func SelectRows(pool memory.Allocator, r arrow.Record, rows []uint32) (arrow.Record, error) {
+	// Consecutive rows are copied together.
+	slices := []arrow.Record{}
+	defer func() {
+		for _, s := range slices {
+			s.Release()
+		}
+	}()
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && rows[end] == rows[end-1]+1 {
+			end++
+		}
+		slices = append(slices, r.NewSlice(int64(rows[start]), int64(rows[end-1])+1))
+		start = end
+	}
+
+	cols := make([]arrow.Array, 0, r.NumCols())
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	for i := 0; i < int(r.NumCols()); i++ {
+		arrs := make([]arrow.Array, 0, len(slices))
+		for _, s := range slices {
+			arrs = append(arrs, s.Column(i))
+		}
+		col, err := array.Concatenate(arrs, pool)
+		if err != nil {
+			return nil, fmt.Errorf("select rows of column %s: %w", r.ColumnName(i), err)
+		}
+		cols = append(cols, col)
+	}
+
+	return array.NewRecord(r.Schema(), cols, int64(len(rows))), nil
+}
@@ -6,12 +6,14 @@ import (
 	"testing"
 
 	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
 	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/google/uuid"
 	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 )
 
@@ -166,10 +168,16 @@ type minMax struct {
 
 type fakeIndex struct {
 	minMax []minMax
+	nulls  []int64
 }
 
-func (i *fakeIndex) NumPages() int              { return len(i.minMax) }
-func (i *fakeIndex) NullCount(int) int64        { return 0 }
+func (i *fakeIndex) NumPages() int { return len(i.minMax) }
+func (i *fakeIndex) NullCount(p int) int64 {
+	if p < len(i.nulls) {
+		return i.nulls[p]
+	}
+	return 0
+}
 func (i *fakeIndex) NullPage(int) bool          { return false }
 func (i *fakeIndex) MinValue(int) parquet.Value { return i.minMax[0].min }
 func (i *fakeIndex) MaxValue(int) parquet.Value { return i.minMax[0].max }
@@ -182,6 +190,7 @@ func TestAllOrNoneGreaterThan(t *testing.T) {
 		name            string
 		value           parquet.Value
 		minMax          []minMax
+		nulls           []int64
 		allGreaterThan  bool
 		noneGreaterThan bool
 	}{{
@@ -220,11 +229,21 @@ func TestAllOrNoneGreaterThan(t *testing.T) {
 		value:           parquet.ValueOf(int64(3)),
 		allGreaterThan:  false,
 		noneGreaterThan: true,
+	}, {
+		name: "nulls",
+		minMax: []minMax{
+			{parquet.ValueOf(int64(1)), parquet.ValueOf(int64(2))},
+			{parquet.ValueOf(int64(3)), parquet.ValueOf(int64(4))},
+		},
+		nulls:           []int64{1, 0},
+		value:           parquet.ValueOf(int64(0)),
+		allGreaterThan:  false,
+		noneGreaterThan: false,
 	}}
 
 	for _, c := range cases {
 		t.Run(fmt.Sprintf("%v", c.value), func(t *testing.T) {
-			index := &fakeIndex{minMax: c.minMax}
+			index := &fakeIndex{minMax: c.minMax, nulls: c.nulls}
 			allGreaterThan, noneGreaterThan := allOrNoneGreaterThan(typ, index, c.value)
 			require.Equal(t, c.allGreaterThan, allGreaterThan)
 			require.Equal(t, c.noneGreaterThan, noneGreaterThan)
@@ -378,3 +397,332 @@ func TestDistinctBinaryExprOptimizationMixed(t *testing.T) {
 	require.Equal(t, int64(3), ar.NumCols())
 	require.Len(t, ar.Schema().Fields(), 3)
 }
+
+func TestRepeatedColumnToArrow(t *testing.T) {
+	dynSchema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "locations",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_INT64,
+				Repeated: true,
+			},
+		}, {
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	// The columns of rows are labels.<name>, locations and timestamp, the
+	// locations of a row are values of repetition level 0 and 1. A row
+	// without locations has a single null value.
+	buf1, err := dynSchema.NewBuffer(map[string][]string{"labels": {"a"}})
+	require.NoError(t, err)
+	_, err = buf1.WriteRows([]parquet.Row{{
+		parquet.ValueOf("x").Level(0, 1, 0),
+		parquet.ValueOf(int64(1)).Level(0, 1, 1),
+		parquet.ValueOf(int64(2)).Level(1, 1, 1),
+		parquet.ValueOf(int64(3)).Level(1, 1, 1),
+		parquet.ValueOf(int64(3)).Level(0, 0, 2),
+	}, {
+		parquet.ValueOf("y").Level(0, 1, 0),
+		parquet.ValueOf(nil).Level(0, 0, 1),
+		parquet.ValueOf(int64(1)).Level(0, 0, 2),
+	}})
+	require.NoError(t, err)
+	buf1.Sort()
+
+	buf2, err := dynSchema.NewBuffer(map[string][]string{"labels": {"b"}})
+	require.NoError(t, err)
+	_, err = buf2.WriteRows([]parquet.Row{{
+		parquet.ValueOf("z").Level(0, 1, 0),
+		parquet.ValueOf(int64(4)).Level(0, 1, 1),
+		parquet.ValueOf(int64(2)).Level(0, 0, 2),
+	}})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pool := memory.NewGoAllocator()
+	locationsOf := func(rg parquet.RowGroup) [][]int64 {
+		as, err := ParquetRowGroupToArrowSchema(ctx, dynSchema, rg, nil, nil, nil, nil)
+		require.NoError(t, err)
+		field := as.Field(as.FieldIndices("locations")[0])
+		require.Equal(t, arrow.Field{Name: "locations", Type: arrow.ListOf(&arrow.Int64Type{})}, field)
+
+		ar, err := ParquetRowGroupToArrowRecord(ctx, pool, rg, as, nil, nil)
+		require.NoError(t, err)
+		defer ar.Release()
+
+		list := ar.Column(as.FieldIndices("locations")[0]).(*array.List)
+		values := list.ListValues().(*array.Int64)
+		offsets := list.Offsets()
+		locations := make([][]int64, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			if list.IsNull(i) {
+				locations = append(locations, nil)
+				continue
+			}
+			locations = append(locations, values.Int64Values()[offsets[i]:offsets[i+1]])
+		}
+		return locations
+	}
+
+	// Rows of sorted buffers are converted by their columns.
+	require.Equal(t, [][]int64{nil, {1, 2, 3}}, locationsOf(buf1))
+
+	// Rows of serialized buffers are converted by their columns.
+	b, err := dynSchema.SerializeBuffer(buf1)
+	require.NoError(t, err)
+	serialized, err := dynparquet.ReaderFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, [][]int64{nil, {1, 2, 3}}, locationsOf(serialized.DynamicRowGroup(0)))
+
+	// Rows of merged row groups are converted row by row.
+	merge, err := dynSchema.MergeDynamicRowGroups([]dynparquet.DynamicRowGroup{buf1, buf2})
+	require.NoError(t, err)
+	require.Equal(t, [][]int64{nil, {4}, {1, 2, 3}}, locationsOf(merge))
+}
+
+func TestStructColumnToArrow(t *testing.T) {
+	dynSchema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "payload",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRUCT,
+				Nullable: true,
+				Fields: []*schemapb.Column{{
+					Name:          "code",
+					StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+				}, {
+					Name: "status",
+					StorageLayout: &schemapb.StorageLayout{
+						Type:     schemapb.StorageLayout_TYPE_STRING,
+						Nullable: true,
+					},
+				}},
+			},
+		}, {
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	// The columns of rows are labels.<name>, payload.code, payload.status and
+	// timestamp. The values of null payloads are undefined at the level of
+	// the payload.
+	buf1, err := dynSchema.NewBuffer(map[string][]string{"labels": {"a"}})
+	require.NoError(t, err)
+	_, err = buf1.WriteRows([]parquet.Row{{
+		parquet.ValueOf("x").Level(0, 1, 0),
+		parquet.ValueOf(int64(200)).Level(0, 1, 1),
+		parquet.ValueOf("ok").Level(0, 2, 2),
+		parquet.ValueOf(int64(3)).Level(0, 0, 3),
+	}, {
+		parquet.ValueOf("y").Level(0, 1, 0),
+		parquet.ValueOf(nil).Level(0, 0, 1),
+		parquet.ValueOf(nil).Level(0, 0, 2),
+		parquet.ValueOf(int64(1)).Level(0, 0, 3),
+	}})
+	require.NoError(t, err)
+	buf1.Sort()
+
+	buf2, err := dynSchema.NewBuffer(map[string][]string{"labels": {"b"}})
+	require.NoError(t, err)
+	_, err = buf2.WriteRows([]parquet.Row{{
+		parquet.ValueOf("z").Level(0, 1, 0),
+		parquet.ValueOf(int64(404)).Level(0, 1, 1),
+		parquet.ValueOf(nil).Level(0, 1, 2),
+		parquet.ValueOf(int64(2)).Level(0, 0, 3),
+	}})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pool := memory.NewGoAllocator()
+	payloadsOf := func(rg parquet.RowGroup) []string {
+		as, err := ParquetRowGroupToArrowSchema(ctx, dynSchema, rg, nil, nil, nil, nil)
+		require.NoError(t, err)
+		require.False(t, as.HasField("payload.code"))
+		field := as.Field(as.FieldIndices("payload")[0])
+		require.Equal(t, arrow.Field{
+			Name: "payload",
+			Type: arrow.StructOf(
+				arrow.Field{Name: "code", Type: &arrow.Int64Type{}},
+				arrow.Field{Name: "status", Type: &arrow.BinaryType{}, Nullable: true},
+			),
+			Nullable: true,
+		}, field)
+
+		ar, err := ParquetRowGroupToArrowRecord(ctx, pool, rg, as, nil, nil)
+		require.NoError(t, err)
+		defer ar.Release()
+
+		payload := ar.Column(as.FieldIndices("payload")[0]).(*array.Struct)
+		codes := payload.Field(0).(*array.Int64)
+		statuses := payload.Field(1).(*array.Binary)
+		payloads := make([]string, 0, payload.Len())
+		for i := 0; i < payload.Len(); i++ {
+			switch {
+			case payload.IsNull(i):
+				payloads = append(payloads, "null")
+			case statuses.IsNull(i):
+				payloads = append(payloads, fmt.Sprintf("%d", codes.Value(i)))
+			default:
+				payloads = append(payloads, fmt.Sprintf("%d %s", codes.Value(i), statuses.Value(i)))
+			}
+		}
+		return payloads
+	}
+
+	// Rows of sorted buffers are converted by their columns.
+	require.Equal(t, []string{"null", "200 ok"}, payloadsOf(buf1))
+
+	// Rows of serialized buffers are converted by their columns.
+	b, err := dynSchema.SerializeBuffer(buf1)
+	require.NoError(t, err)
+	serialized, err := dynparquet.ReaderFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, []string{"null", "200 ok"}, payloadsOf(serialized.DynamicRowGroup(0)))
+
+	// Rows of merged row groups are converted row by row.
+	merge, err := dynSchema.MergeDynamicRowGroups([]dynparquet.DynamicRowGroup{buf1, buf2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"null", "404", "200 ok"}, payloadsOf(merge))
+
+	// Fields of struct columns are projected on their own, the values of
+	// the fields of null structs are null.
+	projections := []logicalplan.Expr{logicalplan.Col("payload.status")}
+	as, err := ParquetRowGroupToArrowSchema(ctx, dynSchema, buf1, projections, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []arrow.Field{{Name: "payload.status", Type: &arrow.BinaryType{}, Nullable: true}}, as.Fields())
+
+	ar, err := ParquetRowGroupToArrowRecord(ctx, pool, buf1, as, nil, nil)
+	require.NoError(t, err)
+	defer ar.Release()
+	require.Equal(t, `[(null) "ok"]`, fmt.Sprint(ar.Column(0).(*array.Binary)))
+}
+
+func TestMapColumnToArrow(t *testing.T) {
+	dynSchema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "attributes",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:      schemapb.StorageLayout_TYPE_MAP,
+				ValueType: schemapb.StorageLayout_TYPE_INT64,
+				Nullable:  true,
+			},
+		}, {
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	// The columns of rows are attributes.key_value.key,
+	// attributes.key_value.value, labels.<name> and timestamp. The keys and
+	// values of null maps are undefined at the level of the map, those of
+	// empty maps at the level of the entries.
+	buf1, err := dynSchema.NewBuffer(map[string][]string{"labels": {"a"}})
+	require.NoError(t, err)
+	_, err = buf1.WriteRows([]parquet.Row{{
+		parquet.ValueOf("retries").Level(0, 2, 0),
+		parquet.ValueOf("size").Level(1, 2, 0),
+		parquet.ValueOf(int64(2)).Level(0, 2, 1),
+		parquet.ValueOf(int64(512)).Level(1, 2, 1),
+		parquet.ValueOf("x").Level(0, 1, 2),
+		parquet.ValueOf(int64(3)).Level(0, 0, 3),
+	}, {
+		parquet.ValueOf(nil).Level(0, 0, 0),
+		parquet.ValueOf(nil).Level(0, 0, 1),
+		parquet.ValueOf("y").Level(0, 1, 2),
+		parquet.ValueOf(int64(1)).Level(0, 0, 3),
+	}})
+	require.NoError(t, err)
+	buf1.Sort()
+
+	buf2, err := dynSchema.NewBuffer(map[string][]string{"labels": {"b"}})
+	require.NoError(t, err)
+	_, err = buf2.WriteRows([]parquet.Row{{
+		parquet.ValueOf(nil).Level(0, 1, 0),
+		parquet.ValueOf(nil).Level(0, 1, 1),
+		parquet.ValueOf("z").Level(0, 1, 2),
+		parquet.ValueOf(int64(2)).Level(0, 0, 3),
+	}})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pool := memory.NewGoAllocator()
+	attributesOf := func(rg parquet.RowGroup) string {
+		as, err := ParquetRowGroupToArrowSchema(ctx, dynSchema, rg, nil, nil, nil, nil)
+		require.NoError(t, err)
+		field := as.Field(as.FieldIndices("attributes")[0])
+		require.Equal(t, arrow.Field{
+			Name:     "attributes",
+			Type:     arrow.MapOf(&arrow.BinaryType{}, &arrow.Int64Type{}),
+			Nullable: true,
+		}, field)
+
+		ar, err := ParquetRowGroupToArrowRecord(ctx, pool, rg, as, nil, nil)
+		require.NoError(t, err)
+		defer ar.Release()
+
+		return fmt.Sprint(ar.Column(as.FieldIndices("attributes")[0]))
+	}
+
+	// Rows of sorted buffers are converted by their columns.
+	require.Equal(t, `[(null) {["retries" "size"] [2 512]}]`, attributesOf(buf1))
+
+	// Rows of serialized buffers are converted by their columns.
+	b, err := dynSchema.SerializeBuffer(buf1)
+	require.NoError(t, err)
+	serialized, err := dynparquet.ReaderFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, `[(null) {["retries" "size"] [2 512]}]`, attributesOf(serialized.DynamicRowGroup(0)))
+
+	// Rows of merged row groups are converted row by row.
+	merge, err := dynSchema.MergeDynamicRowGroups([]dynparquet.DynamicRowGroup{buf1, buf2})
+	require.NoError(t, err)
+	require.Equal(t, `[(null) {[] []} {["retries" "size"] [2 512]}]`, attributesOf(merge))
+}
@@ -2,10 +2,17 @@ package convert
 
 import (
 	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/decimal128"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
 	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
 
 	"github.com/polarsignals/frostdb/pqarrow/writer"
 )
@@ -33,14 +40,29 @@ func ParquetNodeToType(n parquet.Node) (arrow.DataType, error) {
 }
 
 // ParquetNodeToTypeWithWriterFunc converts a parquet node to an arrow type and a function to
-// create a value writer.
+// create a value writer. Repeated nodes are converted to lists of the type of
+// their values, which are nullable like the values of lists of arrow's list
+// builders.
 func ParquetNodeToTypeWithWriterFunc(n parquet.Node) (arrow.DataType, func(b array.Builder, numValues int) writer.ValueWriter, error) {
+	typ, newValueWriter, err := parquetTypeToArrowType(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n.Repeated() {
+		return arrow.ListOf(typ), writer.NewListValueWriter(newValueWriter), nil
+	}
+	return typ, newValueWriter, nil
+}
+
+func parquetTypeToArrowType(n parquet.Node) (arrow.DataType, func(b array.Builder, numValues int) writer.ValueWriter, error) {
 	t := n.Type()
 	lt := t.LogicalType()
 
 	switch {
 	case lt != nil:
 		switch {
+		case lt.Map != nil:
+			return parquetMapToArrowMap(n)
 		case lt.UTF8 != nil:
 			return &arrow.BinaryType{}, writer.NewBinaryValueWriter, nil
 		case lt.Integer != nil:
@@ -53,14 +75,201 @@ func ParquetNodeToTypeWithWriterFunc(n parquet.Node) (arrow.DataType, func(b arr
 			default:
 				return nil, nil, errors.New("unsupported int bit width")
 			}
+		case lt.Decimal != nil:
+			switch t.Kind() {
+			case parquet.Int32, parquet.Int64:
+				return &arrow.Decimal128Type{Precision: lt.Decimal.Precision, Scale: lt.Decimal.Scale}, writer.NewDecimal128ValueWriter, nil
+			default:
+				return nil, nil, errors.New("unsupported decimal type: " + n.Type().String())
+			}
+		case lt.Timestamp != nil:
+			return &arrow.TimestampType{Unit: TimeUnit(lt.Timestamp), TimeZone: timeZone(lt.Timestamp)}, writer.NewTimestampValueWriter, nil
 		default:
 			return nil, nil, errors.New("unsupported logical type: " + n.Type().String())
 		}
 	case t.String() == "group": // NOTE: this needs to be perfomed before t.Kind() because t.Kind() will panic when called on a group
-		return nil, nil, errors.New("unsupported type: " + n.Type().String())
+		return parquetGroupToArrowStruct(n)
 	case t.Kind() == parquet.Double:
 		return &arrow.Float64Type{}, writer.NewFloat64ValueWriter, nil
+	case t.Kind() == parquet.Boolean:
+		return &arrow.BooleanType{}, writer.NewBooleanValueWriter, nil
+	case t.Kind() == parquet.ByteArray:
+		return &arrow.BinaryType{}, writer.NewBinaryValueWriter, nil
 	default:
 		return nil, nil, errors.New("unsupported type: " + n.Type().String())
 	}
 }
+
+// parquetGroupToArrowStruct converts a group to a struct of the types of its
+// fields. Groups without fields aren't supported.
+func parquetGroupToArrowStruct(n parquet.Node) (arrow.DataType, func(b array.Builder, numValues int) writer.ValueWriter, error) {
+	if len(n.Fields()) == 0 {
+		return nil, nil, errors.New("unsupported type: " + n.Type().String())
+	}
+	fields := make([]arrow.Field, 0, len(n.Fields()))
+	newFieldWriters := make([]func(b array.Builder, numValues int) writer.ValueWriter, 0, len(n.Fields()))
+	for _, pf := range n.Fields() {
+		if !pf.Leaf() || pf.Repeated() {
+			return nil, nil, errors.New("unsupported field of group: " + pf.Name())
+		}
+		typ, newFieldWriter, err := ParquetNodeToTypeWithWriterFunc(pf)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, arrow.Field{
+			Name:     pf.Name(),
+			Type:     typ,
+			Nullable: pf.Optional(),
+		})
+		newFieldWriters = append(newFieldWriters, newFieldWriter)
+	}
+	return arrow.StructOf(fields...), writer.NewStructValueWriter(n.Optional(), newFieldWriters), nil
+}
+
+// parquetMapToArrowMap converts a map to a map of the types of its keys and
+// values.
+func parquetMapToArrowMap(n parquet.Node) (arrow.DataType, func(b array.Builder, numValues int) writer.ValueWriter, error) {
+	var key, value parquet.Node
+	for _, kv := range n.Fields() {
+		for _, f := range kv.Fields() {
+			switch f.Name() {
+			case "key":
+				key = f
+			case "value":
+				value = f
+			}
+		}
+	}
+	if key == nil || value == nil || !key.Leaf() || !value.Leaf() {
+		return nil, nil, errors.New("unsupported map type: " + n.Type().String())
+	}
+
+	keyType, newKeyWriter, err := ParquetNodeToTypeWithWriterFunc(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	valueType, newItemWriter, err := ParquetNodeToTypeWithWriterFunc(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return arrow.MapOf(keyType, valueType), writer.NewMapValueWriter(n.Optional(), newKeyWriter, newItemWriter), nil
+}
+
+// TimeUnit returns the arrow time unit of a parquet timestamp type.
+func TimeUnit(t *format.TimestampType) arrow.TimeUnit {
+	switch {
+	case t.Unit.Micros != nil:
+		return arrow.Microsecond
+	case t.Unit.Nanos != nil:
+		return arrow.Nanosecond
+	default:
+		return arrow.Millisecond
+	}
+}
+
+// timeZone returns the time zone of the arrow timestamp type of a parquet
+// timestamp type, timestamps that aren't adjusted to UTC are local times
+// without a time zone.
+func timeZone(t *format.TimestampType) string {
+	if t.IsAdjustedToUTC {
+		return "UTC"
+	}
+	return ""
+}
+
+// ConvertTimestamp converts a timestamp from one unit to another. Timestamps
+// converted to coarser units are truncated.
+func ConvertTimestamp(v int64, from, to arrow.TimeUnit) int64 {
+	switch f, t := int64(from.Multiplier()), int64(to.Multiplier()); {
+	case f > t:
+		return v * (f / t)
+	case f < t:
+		return v / (t / f)
+	default:
+		return v
+	}
+}
+
+// DecimalValue returns the value of an integer, float or decimal scalar as an
+// unscaled decimal of the scale, rounded down. Floats are converted by their
+// shortest decimal representation, so 0.1 is exactly 0.1. It returns whether
+// the value is exact and false if the scalar has no such value.
+func DecimalValue(s scalar.Scalar, scale int32) (decimal128.Num, bool, bool) {
+	var (
+		unscaled *big.Int
+		from     int32
+	)
+	switch s := s.(type) {
+	case *scalar.Int64:
+		unscaled = big.NewInt(s.Value)
+	case *scalar.Decimal128:
+		unscaled = s.Value.BigInt()
+		from = s.Type.(*arrow.Decimal128Type).Scale
+	case *scalar.Float64:
+		if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+			return decimal128.Num{}, false, false
+		}
+		digits := strconv.FormatFloat(s.Value, 'f', -1, 64)
+		if i := strings.IndexByte(digits, '.'); i >= 0 {
+			from = int32(len(digits) - i - 1)
+			digits = digits[:i] + digits[i+1:]
+		}
+		var ok bool
+		if unscaled, ok = new(big.Int).SetString(digits, 10); !ok {
+			return decimal128.Num{}, false, false
+		}
+	default:
+		return decimal128.Num{}, false, false
+	}
+
+	exact := true
+	switch {
+	case from < scale:
+		unscaled.Mul(unscaled, pow10(scale-from))
+	case from > scale:
+		// Div rounds down for positive divisors.
+		var rem big.Int
+		unscaled.DivMod(unscaled, pow10(from-scale), &rem)
+		exact = rem.Sign() == 0
+	}
+	if unscaled.BitLen() > 127 {
+		return decimal128.Num{}, false, false
+	}
+	return decimal128.FromBigInt(unscaled), exact, true
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// CompareDecimals compares two unscaled decimals of the same scale.
+func CompareDecimals(a, b decimal128.Num) int {
+	switch {
+	case a.HighBits() < b.HighBits():
+		return -1
+	case a.HighBits() > b.HighBits():
+		return 1
+	case a.LowBits() < b.LowBits():
+		return -1
+	case a.LowBits() > b.LowBits():
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DecimalInt64 returns the unscaled decimal as an int64, or false if it
+// doesn't fit.
+func DecimalInt64(n decimal128.Num) (int64, bool) {
+	lo := int64(n.LowBits())
+	if (lo < 0 && n.HighBits() != -1) || (lo >= 0 && n.HighBits() != 0) {
+		return 0, false
+	}
+	return lo, true
+}
+
+// DecimalFloat64 returns the unscaled decimal of the scale as a float.
+func DecimalFloat64(n decimal128.Num, scale int32) float64 {
+	f, _ := new(big.Float).SetInt(n.BigInt()).Float64()
+	return f / math.Pow10(int(scale))
+}
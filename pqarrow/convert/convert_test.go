@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/decimal128"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
 	"github.com/segmentio/parquet-go"
 	"github.com/stretchr/testify/require"
 )
@@ -25,6 +27,55 @@ func TestParquetNodeToType(t *testing.T) {
 			parquetNode: parquet.Uint(64),
 			arrowType:   &arrow.Uint64Type{},
 		},
+		{
+			parquetNode: parquet.Leaf(parquet.DoubleType),
+			arrowType:   &arrow.Float64Type{},
+		},
+		{
+			parquetNode: parquet.Leaf(parquet.BooleanType),
+			arrowType:   &arrow.BooleanType{},
+		},
+		{
+			parquetNode: parquet.Leaf(parquet.ByteArrayType),
+			arrowType:   &arrow.BinaryType{},
+		},
+		{
+			parquetNode: parquet.Timestamp(parquet.Millisecond),
+			arrowType:   arrow.FixedWidthTypes.Timestamp_ms,
+		},
+		{
+			parquetNode: parquet.Timestamp(parquet.Microsecond),
+			arrowType:   arrow.FixedWidthTypes.Timestamp_us,
+		},
+		{
+			parquetNode: parquet.Timestamp(parquet.Nanosecond),
+			arrowType:   arrow.FixedWidthTypes.Timestamp_ns,
+		},
+		{
+			parquetNode: parquet.Decimal(0, 9, parquet.Int32Type),
+			arrowType:   &arrow.Decimal128Type{Precision: 9, Scale: 0},
+		},
+		{
+			parquetNode: parquet.Decimal(2, 10, parquet.Int64Type),
+			arrowType:   &arrow.Decimal128Type{Precision: 10, Scale: 2},
+		},
+		{
+			parquetNode: parquet.Group{
+				"code":   parquet.Int(64),
+				"status": parquet.Optional(parquet.String()),
+			},
+			arrowType: arrow.StructOf(
+				arrow.Field{Name: "code", Type: &arrow.Int64Type{}},
+				arrow.Field{Name: "status", Type: &arrow.BinaryType{}, Nullable: true},
+			),
+		},
+		{
+			parquetNode: parquet.Map(
+				parquet.String(),
+				parquet.Int(64),
+			),
+			arrowType: arrow.MapOf(&arrow.BinaryType{}, &arrow.Int64Type{}),
+		},
 	}
 
 	for _, c := range cases {
@@ -37,10 +88,6 @@ func TestParquetNodeToType(t *testing.T) {
 		parquetNode parquet.Node
 		msg         string
 	}{
-		{
-			parquetNode: parquet.Leaf(parquet.BooleanType),
-			msg:         "unsupported type: BOOLEAN",
-		},
 		{
 			parquetNode: parquet.Int(32),
 			msg:         "unsupported int bit width",
@@ -53,17 +100,13 @@ func TestParquetNodeToType(t *testing.T) {
 			parquetNode: parquet.Leaf(parquet.FloatType),
 			msg:         "unsupported type: FLOAT",
 		},
-		{
-			parquetNode: parquet.Leaf(parquet.ByteArrayType),
-			msg:         "unsupported type: BYTE_ARRAY",
-		},
 		{
 			parquetNode: parquet.Leaf(parquet.FixedLenByteArrayType(8)),
 			msg:         "unsupported type: FIXED_LEN_BYTE_ARRAY(8)",
 		},
 		{
-			parquetNode: parquet.Decimal(0, 9, parquet.Int32Type),
-			msg:         "unsupported logical type: DECIMAL(0,9)",
+			parquetNode: parquet.Decimal(0, 9, parquet.FixedLenByteArrayType(4)),
+			msg:         "unsupported decimal type: DECIMAL(0,9)",
 		},
 		{
 			parquetNode: parquet.UUID(),
@@ -91,21 +134,10 @@ func TestParquetNodeToType(t *testing.T) {
 			parquetNode: parquet.Time(parquet.Millisecond),
 			msg:         "unsupported logical type: TIME(isAdjustedToUTC=true,unit=MILLIS)",
 		},
-		{
-			parquetNode: parquet.Timestamp(parquet.Millisecond),
-			msg:         "unsupported logical type: TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS)",
-		},
 		{
 			parquetNode: parquet.List(parquet.String()),
 			msg:         "unsupported logical type: LIST",
 		},
-		{
-			parquetNode: parquet.Map(
-				parquet.String(),
-				parquet.String(),
-			),
-			msg: "unsupported logical type: MAP",
-		},
 		{
 			parquetNode: parquet.Group{},
 			msg:         "unsupported type: group",
@@ -117,3 +149,40 @@ func TestParquetNodeToType(t *testing.T) {
 		require.EqualError(t, err, c.msg)
 	}
 }
+
+func TestConvertTimestamp(t *testing.T) {
+	require.Equal(t, int64(1500), ConvertTimestamp(1500, arrow.Millisecond, arrow.Millisecond))
+	require.Equal(t, int64(1500000), ConvertTimestamp(1500, arrow.Millisecond, arrow.Microsecond))
+	require.Equal(t, int64(1500000000), ConvertTimestamp(1500, arrow.Millisecond, arrow.Nanosecond))
+	require.Equal(t, int64(1), ConvertTimestamp(1500, arrow.Microsecond, arrow.Millisecond))
+}
+
+func TestDecimalValue(t *testing.T) {
+	cases := []struct {
+		scalar   scalar.Scalar
+		unscaled int64
+		exact    bool
+	}{
+		{scalar: scalar.NewInt64Scalar(12), unscaled: 1200, exact: true},
+		{scalar: scalar.NewFloat64Scalar(12.34), unscaled: 1234, exact: true},
+		{scalar: scalar.NewFloat64Scalar(0.1), unscaled: 10, exact: true},
+		{scalar: scalar.NewFloat64Scalar(12.345), unscaled: 1234, exact: false},
+		{scalar: scalar.NewFloat64Scalar(-12.345), unscaled: -1235, exact: false},
+		{
+			scalar:   scalar.NewDecimal128Scalar(decimal128.FromI64(12345), &arrow.Decimal128Type{Precision: 10, Scale: 3}),
+			unscaled: 1234,
+			exact:    false,
+		},
+	}
+	for _, c := range cases {
+		v, exact, ok := DecimalValue(c.scalar, 2)
+		require.True(t, ok)
+		require.Equal(t, c.exact, exact, c.scalar.String())
+		n, ok := DecimalInt64(v)
+		require.True(t, ok)
+		require.Equal(t, c.unscaled, n, c.scalar.String())
+	}
+
+	_, _, ok := DecimalValue(scalar.NewStringScalar("12"), 2)
+	require.False(t, ok)
+}
@@ -0,0 +1,116 @@
+package pqarrow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/segmentio/parquet-go"
+
+	"github.com/polarsignals/frostdb/pqarrow/convert"
+)
+
+// DictionaryColumn is a column chunk whose pages are all encoded with the same
+// dictionary, which allows evaluating predicates on the values of the
+// dictionary instead of on the values of every row.
+type DictionaryColumn struct {
+	// Values holds the values of the dictionary followed by a null, which
+	// the rows that are null index.
+	Values arrow.Array
+	// Indexes holds the index of the value of each row of the column chunk.
+	Indexes []int32
+}
+
+// Release releases the values of the dictionary.
+func (c *DictionaryColumn) Release() {
+	c.Values.Release()
+}
+
+// ParquetColumnToDictionary returns the dictionary of the column chunk and
+// the indexes of the values of its rows. It returns false if the column is
+// repeated, or if any of its pages isn't dictionary encoded with the same
+// dictionary, in which case its values have to be converted row by row.
+func ParquetColumnToDictionary(
+	pool memory.Allocator,
+	n parquet.Node,
+	c parquet.ColumnChunk,
+) (*DictionaryColumn, bool, error) {
+	if n.Repeated() {
+		return nil, false, nil
+	}
+
+	pages := c.Pages()
+	defer pages.Close()
+
+	var (
+		dict    parquet.Dictionary
+		indexes = make([]int32, 0, c.NumValues())
+	)
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, false, fmt.Errorf("read page: %w", err)
+		}
+
+		pageDict := p.Dictionary()
+		if pageDict == nil || (dict != nil && pageDict != dict) {
+			return nil, false, nil
+		}
+		dict = pageDict
+
+		buffered, ok := p.(parquet.BufferedPage)
+		if !ok {
+			return nil, false, nil
+		}
+		// The data of dictionary encoded pages are the indexes of the values
+		// of the rows that aren't null.
+		data := buffered.Data()
+		levels := buffered.DefinitionLevels()
+		numNulls := int(p.NumNulls())
+		if len(data) != 4*(int(p.NumValues())-numNulls) || (numNulls > 0 && levels == nil) {
+			return nil, false, nil
+		}
+
+		nullIndex := int32(dict.Len())
+		if levels == nil {
+			for i := 0; i < len(data); i += 4 {
+				indexes = append(indexes, int32(binary.LittleEndian.Uint32(data[i:])))
+			}
+			continue
+		}
+		j := 0
+		for _, level := range levels {
+			if level == 0 {
+				indexes = append(indexes, nullIndex)
+				continue
+			}
+			indexes = append(indexes, int32(binary.LittleEndian.Uint32(data[j:])))
+			j += 4
+		}
+	}
+	if dict == nil {
+		return nil, false, nil
+	}
+
+	at, newValueWriter, err := convert.ParquetNodeToTypeWithWriterFunc(n)
+	if err != nil {
+		return nil, false, fmt.Errorf("convert ParquetNodeToTypeWithWriterFunc failed: %v", err)
+	}
+	b := array.NewBuilder(pool, at)
+	defer b.Release()
+	if err := newValueWriter(b, dict.Len()+1).WritePage(dict.Page()); err != nil {
+		return nil, false, fmt.Errorf("write dictionary page: %w", err)
+	}
+	b.AppendNull()
+
+	return &DictionaryColumn{
+		Values:  b.NewArray(),
+		Indexes: indexes,
+	}, true, nil
+}
@@ -1,6 +1,7 @@
 package pqarrow
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/apache/arrow/go/v8/arrow"
@@ -14,6 +15,23 @@ func ArrowScalarToParquetValue(sc scalar.Scalar) (parquet.Value, error) {
 		return parquet.ValueOf(string(s.Data())), nil
 	case *scalar.Int64:
 		return parquet.ValueOf(s.Value), nil
+	case *scalar.Uint64:
+		return parquet.ValueOf(s.Value), nil
+	case *scalar.Float64:
+		return parquet.ValueOf(s.Value), nil
+	case *scalar.Boolean:
+		return parquet.ValueOf(s.Value), nil
+	case *scalar.Binary:
+		return parquet.ValueOf(s.Data()), nil
+	case *scalar.Timestamp:
+		return parquet.ValueOf(int64(s.Value)), nil
+	case *scalar.Decimal128:
+		// Decimals are big-endian two's complement integers like decimals
+		// of fixed length byte arrays.
+		v := [16]byte{}
+		binary.BigEndian.PutUint64(v[:8], uint64(s.Value.HighBits()))
+		binary.BigEndian.PutUint64(v[8:], s.Value.LowBits())
+		return parquet.ValueOf(v), nil
 	case *scalar.FixedSizeBinary:
 		width := s.Type.(*arrow.FixedSizeBinaryType).ByteWidth
 		v := [16]byte{}
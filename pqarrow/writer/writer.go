@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/decimal128"
 	"github.com/segmentio/parquet-go"
 )
 
@@ -201,16 +203,24 @@ func NewListValueWriter(newValueWriter func(b array.Builder, numValues int) Valu
 	}
 }
 
+// Write writes the values of the lists of rows, a value of repetition level
+// 0 starts the list of a row. A single value of definition level 0 is the
+// list of a row without values, which is null.
 func (w *repeatedValueWriter) Write(values []parquet.Value) {
-	v0 := values[0]
-	rep := v0.RepetitionLevel()
-	def := v0.DefinitionLevel()
-	if rep == 0 && def == 0 {
-		w.b.AppendNull()
-	}
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j].RepetitionLevel() > 0 {
+			j++
+		}
 
-	w.b.Append(true)
-	w.values.Write(values)
+		if values[i].DefinitionLevel() == 0 {
+			w.b.AppendNull()
+		} else {
+			w.b.Append(true)
+			w.values.Write(values[i:j])
+		}
+		i = j
+	}
 }
 
 // TODO: implement fast path of writing the whole page directly.
@@ -229,6 +239,127 @@ func (w *repeatedValueWriter) WritePage(p parquet.Page) error {
 	return nil
 }
 
+type structValueWriter struct {
+	b        *array.StructBuilder
+	optional bool
+	fields   []ValueWriter
+}
+
+// NewStructValueWriter returns a function to create writers of the values of
+// structs with the value writers of their fields.
+func NewStructValueWriter(optional bool, newFieldWriters []func(b array.Builder, numValues int) ValueWriter) func(b array.Builder, numValues int) ValueWriter {
+	return func(b array.Builder, numValues int) ValueWriter {
+		builder := b.(*array.StructBuilder)
+
+		fields := make([]ValueWriter, len(newFieldWriters))
+		for i, newFieldWriter := range newFieldWriters {
+			fields[i] = newFieldWriter(builder.FieldBuilder(i), numValues)
+		}
+		return &structValueWriter{
+			b:        builder,
+			optional: optional,
+			fields:   fields,
+		}
+	}
+}
+
+// Write writes the values of the leaf columns of the fields of structs, the
+// values of each field are consecutive and in the order of the fields. The
+// values of the first field determine which structs are null.
+func (w *structValueWriter) Write(values []parquet.Value) {
+	field := 0
+	start := 0
+	for i := 1; i <= len(values); i++ {
+		if i < len(values) && values[i].Column() == values[start].Column() {
+			continue
+		}
+		if field == 0 {
+			validity := make([]bool, i-start)
+			for j, v := range values[start:i] {
+				validity[j] = !w.optional || v.DefinitionLevel() > 0
+			}
+			w.b.AppendValues(validity)
+		}
+		w.fields[field].Write(values[start:i])
+		field++
+		start = i
+	}
+}
+
+func (w *structValueWriter) WritePage(p parquet.Page) error {
+	return fmt.Errorf("struct values can't be written from the page of a single column")
+}
+
+type mapValueWriter struct {
+	b        *array.MapBuilder
+	optional bool
+	keys     ValueWriter
+	items    ValueWriter
+}
+
+// NewMapValueWriter returns a function to create writers of the values of
+// maps with the value writers of their keys and values.
+func NewMapValueWriter(optional bool, newKeyWriter, newItemWriter func(b array.Builder, numValues int) ValueWriter) func(b array.Builder, numValues int) ValueWriter {
+	return func(b array.Builder, numValues int) ValueWriter {
+		builder := b.(*array.MapBuilder)
+
+		return &mapValueWriter{
+			b:        builder,
+			optional: optional,
+			keys:     newKeyWriter(builder.KeyBuilder(), numValues),
+			items:    newItemWriter(builder.ItemBuilder(), numValues),
+		}
+	}
+}
+
+// Write writes the values of the key and value columns of maps, the values of
+// the two columns may be interleaved as in the rows of maps. A key of
+// repetition level 0 starts the map of a row, the map of a row without
+// entries has a single key that isn't defined at the level of the entries.
+func (w *mapValueWriter) Write(values []parquet.Value) {
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]parquet.Value, 0, len(values)/2)
+	items := make([]parquet.Value, 0, len(values)/2)
+	for _, v := range values {
+		if v.Column() == values[0].Column() {
+			keys = append(keys, v)
+		} else {
+			items = append(items, v)
+		}
+	}
+
+	entryLevel := 1
+	if w.optional {
+		entryLevel = 2
+	}
+	offset := int32(w.b.KeyBuilder().Len())
+	offsets := []int32{}
+	validity := []bool{}
+	entryKeys := make([]parquet.Value, 0, len(keys))
+	entryItems := make([]parquet.Value, 0, len(items))
+	for i, k := range keys {
+		if k.RepetitionLevel() == 0 {
+			offsets = append(offsets, offset)
+			validity = append(validity, !w.optional || k.DefinitionLevel() > 0)
+		}
+		if k.DefinitionLevel() >= entryLevel {
+			entryKeys = append(entryKeys, k)
+			entryItems = append(entryItems, items[i])
+			offset++
+		}
+	}
+
+	w.keys.Write(entryKeys)
+	w.items.Write(entryItems)
+	w.b.AppendValues(offsets, validity)
+}
+
+func (w *mapValueWriter) WritePage(p parquet.Page) error {
+	return fmt.Errorf("map values can't be written from the page of a single column")
+}
+
 type float64ValueWriter struct {
 	b   *array.Float64Builder
 	buf []float64
@@ -287,3 +418,137 @@ func (w *float64ValueWriter) WritePage(p parquet.Page) error {
 
 	return nil
 }
+
+type booleanValueWriter struct {
+	b   *array.BooleanBuilder
+	buf []bool
+}
+
+func NewBooleanValueWriter(b array.Builder, numValues int) ValueWriter {
+	res := &booleanValueWriter{
+		b: b.(*array.BooleanBuilder),
+	}
+	res.b.Reserve(numValues)
+	return res
+}
+
+func (w *booleanValueWriter) Write(values []parquet.Value) {
+	for _, v := range values {
+		if v.IsNull() {
+			w.b.AppendNull()
+		} else {
+			w.b.Append(v.Boolean())
+		}
+	}
+}
+
+func (w *booleanValueWriter) WritePage(p parquet.Page) error {
+	reader := p.Values()
+
+	breader, ok := reader.(parquet.BooleanReader)
+	if ok {
+		// fast path
+		if w.buf == nil {
+			w.buf = make([]bool, p.NumValues())
+		}
+		values := w.buf
+		for {
+			n, err := breader.ReadBooleans(values)
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("read values: %w", err)
+			}
+
+			w.b.AppendValues(values[:n], nil)
+			if err == io.EOF {
+				break
+			}
+		}
+		return nil
+	}
+
+	values := make([]parquet.Value, p.NumValues())
+	_, err := reader.ReadValues(values)
+	// We're reading all values in the page so we always expect an io.EOF.
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	w.Write(values)
+
+	return nil
+}
+
+type timestampValueWriter struct {
+	b *array.TimestampBuilder
+}
+
+func NewTimestampValueWriter(b array.Builder, numValues int) ValueWriter {
+	res := &timestampValueWriter{
+		b: b.(*array.TimestampBuilder),
+	}
+	res.b.Reserve(numValues)
+	return res
+}
+
+func (w *timestampValueWriter) Write(values []parquet.Value) {
+	for _, v := range values {
+		if v.IsNull() {
+			w.b.AppendNull()
+		} else {
+			w.b.Append(arrow.Timestamp(v.Int64()))
+		}
+	}
+}
+
+func (w *timestampValueWriter) WritePage(p parquet.Page) error {
+	values := make([]parquet.Value, p.NumValues())
+	_, err := p.Values().ReadValues(values)
+	// We're reading all values in the page so we always expect an io.EOF.
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	w.Write(values)
+
+	return nil
+}
+
+type decimal128ValueWriter struct {
+	b *array.Decimal128Builder
+}
+
+// NewDecimal128ValueWriter returns a writer of the unscaled int32 or int64
+// values of decimal columns.
+func NewDecimal128ValueWriter(b array.Builder, numValues int) ValueWriter {
+	res := &decimal128ValueWriter{
+		b: b.(*array.Decimal128Builder),
+	}
+	res.b.Reserve(numValues)
+	return res
+}
+
+func (w *decimal128ValueWriter) Write(values []parquet.Value) {
+	for _, v := range values {
+		switch {
+		case v.IsNull():
+			w.b.AppendNull()
+		case v.Kind() == parquet.Int32:
+			w.b.Append(decimal128.FromI64(int64(v.Int32())))
+		default:
+			w.b.Append(decimal128.FromI64(v.Int64()))
+		}
+	}
+}
+
+func (w *decimal128ValueWriter) WritePage(p parquet.Page) error {
+	values := make([]parquet.Value, p.NumValues())
+	_, err := p.Values().ReadValues(values)
+	// We're reading all values in the page so we always expect an io.EOF.
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	w.Write(values)
+
+	return nil
+}
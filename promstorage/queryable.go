@@ -0,0 +1,293 @@
+// Package promstorage implements the Prometheus storage.Queryable interface
+// on top of a frostdb table, so the PromQL engine can query frostdb. The
+// table has the conventional schema of time series: a timestamp column of
+// milliseconds, a value column of integers or floats and a dynamic labels
+// column whose concrete columns are the labels of the series.
+//
+//	engine := promql.NewEngine(promql.EngineOpts{...})
+//	q, err := engine.NewInstantQuery(promstorage.NewQueryable(e, "metrics"), nil, "sum(up)", ts)
+package promstorage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/tsdbutil"
+
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+const (
+	timestampColumn = "timestamp"
+	valueColumn     = "value"
+	labelsColumn    = "labels"
+)
+
+// Queryable queries the time series of a table of an engine.
+type Queryable struct {
+	engine *query.LocalEngine
+	table  string
+}
+
+func NewQueryable(engine *query.LocalEngine, table string) *Queryable {
+	return &Queryable{
+		engine: engine,
+		table:  table,
+	}
+}
+
+// Querier returns a querier of the samples between mint and maxt, in
+// milliseconds, that queries the table within the context.
+func (q *Queryable) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
+	return &querier{
+		ctx:    ctx,
+		engine: q.engine,
+		table:  q.table,
+		mint:   mint,
+		maxt:   maxt,
+	}, nil
+}
+
+type querier struct {
+	ctx    context.Context
+	engine *query.LocalEngine
+	table  string
+	mint   int64
+	maxt   int64
+}
+
+// filter returns the filter of the rows between start and end that match the
+// matchers. Matchers that match the empty string also match rows without the
+// label, which are null, so they are applied to the rows once they are read.
+func filter(start, end int64, matchers []*labels.Matcher) logicalplan.Expr {
+	exprs := []logicalplan.Expr{
+		logicalplan.Col(timestampColumn).GtEq(logicalplan.Literal(start)),
+		logicalplan.Col(timestampColumn).LtEq(logicalplan.Literal(end)),
+	}
+	for _, m := range matchers {
+		if m.Matches("") {
+			continue
+		}
+		col := logicalplan.Col(labelsColumn + "." + m.Name)
+		switch m.Type {
+		case labels.MatchEqual:
+			exprs = append(exprs, col.Eq(logicalplan.Literal(m.Value)))
+		case labels.MatchNotEqual:
+			exprs = append(exprs, col.NotEq(logicalplan.Literal(m.Value)))
+		case labels.MatchRegexp:
+			// Prometheus regular expressions are anchored.
+			exprs = append(exprs, col.RegexMatch("^(?:"+m.Value+")$"))
+		case labels.MatchNotRegexp:
+			exprs = append(exprs, col.RegexNotMatch("^(?:"+m.Value+")$"))
+		}
+	}
+	return logicalplan.And(exprs...)
+}
+
+// matches returns whether the labels match all of the matchers, labels that
+// are missing match the empty string.
+func matches(lset labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(lset.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the series that match the matchers, sorted by their labels.
+// The samples of the series are sorted by their timestamps.
+func (q *querier) Select(_ bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	start, end := q.mint, q.maxt
+	if hints != nil {
+		start, end = hints.Start, hints.End
+	}
+
+	seriesByLabels := map[string]*series{}
+	err := q.engine.ScanTable(q.table).
+		Filter(filter(start, end, matchers)).
+		Project(
+			logicalplan.DynCol(labelsColumn),
+			logicalplan.Col(timestampColumn),
+			logicalplan.Col(valueColumn),
+		).
+		Execute(q.ctx, func(r arrow.Record) error {
+			timestamps, values, err := samples(r)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < int(r.NumRows()); i++ {
+				lset := rowLabels(r, i)
+				if !matches(lset, matchers) {
+					continue
+				}
+				key := lset.String()
+				s, ok := seriesByLabels[key]
+				if !ok {
+					s = &series{labels: lset}
+					seriesByLabels[key] = s
+				}
+				s.samples = append(s.samples, sample{t: timestamps.Value(i), v: values(i)})
+			}
+			return nil
+		})
+	if err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+
+	set := &seriesSet{i: -1, series: make([]storage.Series, 0, len(seriesByLabels))}
+	for _, s := range seriesByLabels {
+		sort.Slice(s.samples, func(i, j int) bool {
+			return s.samples[i].T() < s.samples[j].T()
+		})
+		set.series = append(set.series, storage.NewListSeries(s.labels, s.samples))
+	}
+	sort.Slice(set.series, func(i, j int) bool {
+		return labels.Compare(set.series[i].Labels(), set.series[j].Labels()) < 0
+	})
+	return set
+}
+
+// samples returns the timestamps of the record and a function that returns
+// the values of its rows as floats.
+func samples(r arrow.Record) (*array.Int64, func(i int) float64, error) {
+	var timestamps *array.Int64
+	var values func(i int) float64
+	for i, field := range r.Schema().Fields() {
+		switch field.Name {
+		case timestampColumn:
+			arr, ok := r.Column(i).(*array.Int64)
+			if !ok {
+				return nil, nil, fmt.Errorf("unsupported type of column %s: %s", field.Name, field.Type)
+			}
+			timestamps = arr
+		case valueColumn:
+			switch arr := r.Column(i).(type) {
+			case *array.Int64:
+				values = func(i int) float64 { return float64(arr.Value(i)) }
+			case *array.Float64:
+				values = arr.Value
+			default:
+				return nil, nil, fmt.Errorf("unsupported type of column %s: %s", field.Name, field.Type)
+			}
+		}
+	}
+	if timestamps == nil || values == nil {
+		return nil, nil, fmt.Errorf("missing %s or %s column", timestampColumn, valueColumn)
+	}
+	return timestamps, values, nil
+}
+
+// rowLabels returns the labels of the row, which are the non-empty values of
+// the concrete columns of the labels column.
+func rowLabels(r arrow.Record, row int) labels.Labels {
+	lset := labels.Labels{}
+	for i, field := range r.Schema().Fields() {
+		name := strings.TrimPrefix(field.Name, labelsColumn+".")
+		if name == field.Name {
+			continue
+		}
+		if value := stringValue(r.Column(i), row); value != "" {
+			lset = append(lset, labels.Label{Name: name, Value: value})
+		}
+	}
+	sort.Sort(lset)
+	return lset
+}
+
+// stringValue returns the value of the row of a string column, or the empty
+// string if it is null.
+func stringValue(arr arrow.Array, row int) string {
+	if arr.IsNull(row) {
+		return ""
+	}
+	switch arr := arr.(type) {
+	case *array.Binary:
+		return string(arr.Value(row))
+	case *array.String:
+		return arr.Value(row)
+	default:
+		return ""
+	}
+}
+
+// LabelValues returns the sorted values of the label of the series that
+// match the matchers.
+func (q *querier) LabelValues(name string, matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	set := q.Select(false, nil, matchers...)
+	seen := map[string]bool{}
+	for set.Next() {
+		if value := set.At().Labels().Get(name); value != "" {
+			seen[value] = true
+		}
+	}
+	return sortedKeys(seen), nil, set.Err()
+}
+
+// LabelNames returns the sorted names of the labels of the series that match
+// the matchers.
+func (q *querier) LabelNames(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	set := q.Select(false, nil, matchers...)
+	seen := map[string]bool{}
+	for set.Next() {
+		for _, l := range set.At().Labels() {
+			seen[l.Name] = true
+		}
+	}
+	return sortedKeys(seen), nil, set.Err()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (q *querier) Close() error {
+	return nil
+}
+
+type series struct {
+	labels  labels.Labels
+	samples []tsdbutil.Sample
+}
+
+type sample struct {
+	t int64
+	v float64
+}
+
+func (s sample) T() int64   { return s.t }
+func (s sample) V() float64 { return s.v }
+
+type seriesSet struct {
+	series []storage.Series
+	i      int
+}
+
+func (s *seriesSet) Next() bool {
+	s.i++
+	return s.i < len(s.series)
+}
+
+func (s *seriesSet) At() storage.Series {
+	return s.series[s.i]
+}
+
+func (s *seriesSet) Err() error {
+	return nil
+}
+
+func (s *seriesSet) Warnings() storage.Warnings {
+	return nil
+}
@@ -0,0 +1,93 @@
+package promstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+)
+
+func newTestQueryable(t *testing.T) *Queryable {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	// A second sample of the series of the first one.
+	samples = append(samples, samples[0])
+	samples[len(samples)-1].Timestamp = 1
+	samples[len(samples)-1].Value = 1
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	return NewQueryable(query.NewEngine(memory.NewGoAllocator(), db.TableProvider()), "test")
+}
+
+func TestQuerier(t *testing.T) {
+	q, err := newTestQueryable(t).Querier(context.Background(), 0, 10)
+	require.NoError(t, err)
+	defer q.Close()
+
+	set := q.Select(true, nil, labels.MustNewMatcher(labels.MatchRegexp, "node", "test.*"))
+	require.True(t, set.Next())
+	require.Equal(t, labels.FromStrings("node", "test3"), set.At().Labels())
+	it := set.At().Iterator()
+	var samples [][2]float64
+	for it.Next() {
+		ts, v := it.At()
+		samples = append(samples, [2]float64{float64(ts), v})
+	}
+	require.Equal(t, [][2]float64{{1, 1}, {2, 5}}, samples)
+	require.False(t, set.Next())
+	require.NoError(t, set.Err())
+
+	// Matchers of empty values match series without the label.
+	set = q.Select(true, nil,
+		labels.MustNewMatcher(labels.MatchEqual, "namespace", "default"),
+		labels.MustNewMatcher(labels.MatchEqual, "pod", ""),
+	)
+	require.True(t, set.Next())
+	require.Equal(t, labels.FromStrings("container", "test2", "namespace", "default"), set.At().Labels())
+	require.False(t, set.Next())
+
+	names, _, err := q.LabelNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"container", "namespace", "node", "pod"}, names)
+
+	values, _, err := q.LabelValues("pod", labels.MustNewMatcher(labels.MatchEqual, "namespace", "default"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"test1"}, values)
+}
+
+func TestPromQL(t *testing.T) {
+	engine := promql.NewEngine(promql.EngineOpts{
+		Logger:     log.NewNopLogger(),
+		MaxSamples: 1000,
+		Timeout:    time.Minute,
+	})
+
+	qry, err := engine.NewInstantQuery(newTestQueryable(t), nil, `sum by (namespace) ({namespace=~".+"})`, time.UnixMilli(2))
+	require.NoError(t, err)
+	res := qry.Exec(context.Background())
+	require.NoError(t, res.Err)
+	vector, err := res.Vector()
+	require.NoError(t, err)
+	require.Len(t, vector, 1)
+	require.Equal(t, labels.FromStrings("namespace", "default"), vector[0].Metric)
+	require.Equal(t, float64(6), vector[0].V)
+}
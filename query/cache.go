@@ -0,0 +1,157 @@
+package query
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/v8/arrow"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// resultCache holds the records of the results of queries, so that queries
+// with identical plans are served from memory as long as the databases of the
+// tables they read didn't change. Once it holds more than its maximum number of results, the
+// least recently used results are evicted.
+type resultCache struct {
+	maxResults int
+
+	mtx     sync.Mutex
+	results map[string]*list.Element
+	lru     *list.List
+}
+
+type cachedResult struct {
+	key     string
+	records []arrow.Record
+}
+
+func newResultCache(maxResults int) *resultCache {
+	return &resultCache{
+		maxResults: maxResults,
+		results:    map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// get returns the records of the result with the key, or false if it isn't
+// cached. The records must be released.
+func (c *resultCache) get(key string) ([]arrow.Record, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.results[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	records := e.Value.(*cachedResult).records
+	for _, r := range records {
+		r.Retain()
+	}
+	return records, true
+}
+
+// put caches the records of the result with the key, the cache takes over
+// the references of the records.
+func (c *resultCache) put(key string, records []arrow.Record) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.results[key]; ok {
+		// The result was cached by a query executed at the same time.
+		c.lru.MoveToFront(e)
+		releaseRecords(records)
+		return
+	}
+	c.results[key] = c.lru.PushFront(&cachedResult{key: key, records: records})
+
+	for c.lru.Len() > c.maxResults {
+		e := c.lru.Back()
+		res := c.lru.Remove(e).(*cachedResult)
+		delete(c.results, res.key)
+		releaseRecords(res.records)
+	}
+}
+
+func releaseRecords(records []arrow.Record) {
+	for _, r := range records {
+		r.Release()
+	}
+}
+
+// cacheKey returns the key of the result of the plan, or false if its result
+// can't be cached. The key consists of the plan, the types of its literals,
+// since literals of different types that have the same value have the same
+// names, and the transactions the tables it reads are read at. The results of
+// plans that sample their rows aren't cached, as they differ every time they
+// are executed.
+func cacheKey(plan *logicalplan.LogicalPlan) (string, bool) {
+	b := &cacheKeyBuilder{cacheable: true}
+	plan.Rewrite(b)
+	plan.RewriteExprs(b)
+	if !b.cacheable {
+		return "", false
+	}
+
+	return plan.String() + "\n" +
+		"Literals: " + strings.Join(b.literals, ", ") + "\n" +
+		"Transactions: " + strings.Join(b.txs, ", "), true
+}
+
+// cacheKeyBuilder collects the parts of the key of a plan's result from the
+// nodes and expressions of the plan, which it leaves as they are.
+type cacheKeyBuilder struct {
+	cacheable bool
+	literals  []string
+	txs       []string
+}
+
+func (b *cacheKeyBuilder) RewritePlan(plan *logicalplan.LogicalPlan) *logicalplan.LogicalPlan {
+	switch {
+	case plan.Sample != nil:
+		b.cacheable = false
+	case plan.TableScan != nil:
+		if plan.TableScan.Sample != nil {
+			b.cacheable = false
+		}
+		b.table(plan.TableScan.TableProvider, plan.TableScan.TableName)
+	case plan.SchemaScan != nil:
+		b.table(plan.SchemaScan.TableProvider, plan.SchemaScan.TableName)
+	}
+	return plan
+}
+
+// table adds the transaction the table is read at. The transaction is the
+// high watermark of the table's database rather than the transaction of the
+// table's last write, so writes to any table of the database invalidate the
+// cached results of all of its tables. Invalidating them per table would
+// need the last transaction that wrote to the table and is visible at the
+// watermark, which tables don't track.
+func (b *cacheKeyBuilder) table(provider logicalplan.TableProvider, name string) {
+	table := provider.GetTable(name)
+	if table == nil {
+		b.cacheable = false
+		return
+	}
+	err := table.View(func(tx uint64) error {
+		b.txs = append(b.txs, name+"@"+strconv.FormatUint(tx, 10))
+		return nil
+	})
+	if err != nil {
+		b.cacheable = false
+	}
+}
+
+func (b *cacheKeyBuilder) PreRewrite(expr logicalplan.Expr) bool {
+	return true
+}
+
+func (b *cacheKeyBuilder) PostRewrite(expr logicalplan.Expr) logicalplan.Expr {
+	if l, ok := expr.(*logicalplan.LiteralExpr); ok {
+		b.literals = append(b.literals, l.Value.DataType().Name())
+	}
+	return expr
+}
@@ -2,53 +2,325 @@ package query
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/memory"
 
+	logicalplanpb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/logicalplan/v1alpha1"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 	"github.com/polarsignals/frostdb/query/physicalplan"
 )
 
 type Builder interface {
 	Aggregate(aggExpr logicalplan.Expr, groupExprs ...logicalplan.Expr) Builder
+	Aggregations(aggExprs []logicalplan.Expr, groupExprs ...logicalplan.Expr) Builder
 	Filter(expr logicalplan.Expr) Builder
 	Distinct(expr ...logicalplan.Expr) Builder
+	Having(expr logicalplan.Expr) Builder
+	Window(windowExprs []logicalplan.Expr, orderBy logicalplan.Expr, partitionBy ...logicalplan.Expr) Builder
+	OrderBy(exprs ...logicalplan.SortExpr) Builder
+	Limit(count int64) Builder
+	Offset(count int64) Builder
+	Sample(fraction float64) Builder
+	Unpivot(column *logicalplan.DynamicColumn, nameColumn, valueColumn string) Builder
+	Join(right Builder, leftKeys, rightKeys []logicalplan.Expr) Builder
 	Project(projections ...logicalplan.Expr) Builder
+	Timeout(timeout time.Duration) Builder
+	Priority(priority Priority) Builder
+	PartialResults(interval time.Duration) Builder
 	Execute(ctx context.Context, callback func(r arrow.Record) error) error
+	ExecuteWithStats(ctx context.Context, callback func(r arrow.Record) error) (*QueryStats, error)
+	Iterator(ctx context.Context) (RecordReader, error)
+	Explain() (string, error)
+	Prepare() (*PreparedQuery, error)
 }
 
 type LocalEngine struct {
-	pool          memory.Allocator
-	tableProvider logicalplan.TableProvider
+	pool            memory.Allocator
+	tableProvider   logicalplan.TableProvider
+	functions       *functionRegistry
+	physicalOptions []physicalplan.Option
+	timeout         time.Duration
+	memoryLimit     int64
+	cache           *resultCache
+	scheduler       *scheduler
+	// remote is set if queries are executed by remote nodes.
+	remote bool
+
+	// The scheduler is created once all options are applied, as multiple
+	// options configure it.
+	slots        int
+	maxQueued    int
+	queueTimeout time.Duration
+}
+
+// Option configures a LocalEngine.
+type Option func(*LocalEngine)
+
+// WithAggregationMemoryLimit limits the memory each aggregation of a query
+// uses for its state to roughly limit bytes. Aggregations that exceed the
+// limit spill to temporary files.
+func WithAggregationMemoryLimit(limit int64) Option {
+	return func(e *LocalEngine) {
+		e.physicalOptions = append(e.physicalOptions, physicalplan.WithAggregationMemoryLimit(limit))
+	}
+}
+
+// WithSpillDir sets the directory of the temporary files that aggregations
+// spill to. The default directory for temporary files is used if not set.
+func WithSpillDir(dir string) Option {
+	return func(e *LocalEngine) {
+		e.physicalOptions = append(e.physicalOptions, physicalplan.WithSpillDir(dir))
+	}
+}
+
+// WithConcurrency sets the number of goroutines that aggregations of queries
+// read and aggregate their input with, if their results can be merged from
+// partial aggregations.
+func WithConcurrency(concurrency int) Option {
+	return func(e *LocalEngine) {
+		e.physicalOptions = append(e.physicalOptions, physicalplan.WithConcurrency(concurrency))
+	}
+}
+
+// WithScanConcurrency sets the number of goroutines that the table scans of
+// queries read the granules and row groups of tables with. The records they
+// read are passed on in no particular order, scans that have to read rows in
+// order aren't read concurrently.
+func WithScanConcurrency(concurrency int) Option {
+	return func(e *LocalEngine) {
+		e.physicalOptions = append(e.physicalOptions, physicalplan.WithScanConcurrency(concurrency))
+	}
+}
+
+// WithQueryTimeout sets the time queries can take by default, queries that
+// take longer fail with a TimeoutError. Queries can override it with
+// Timeout.
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(e *LocalEngine) {
+		e.timeout = timeout
+	}
+}
+
+// WithQueryMemoryLimit limits the memory each query allocates for its
+// records to roughly limit bytes. Queries that exceed the limit fail with
+// ErrQueryMemoryExceeded.
+func WithQueryMemoryLimit(limit int64) Option {
+	return func(e *LocalEngine) {
+		e.memoryLimit = limit
+	}
+}
+
+// WithResultCache caches the results of up to maxResults queries in memory.
+// Queries whose plans are identical to the plan of a cached result are served
+// from the cache, as long as nothing was written to the databases of the
+// tables they read since the result was cached. A write to any table of a
+// database invalidates the results of all queries reading its tables.
+// Executing a query with stats always executes it.
+func WithResultCache(maxResults int) Option {
+	return func(e *LocalEngine) {
+		e.cache = newResultCache(maxResults)
+	}
+}
+
+// WithScheduler limits the number of queries that are executed at the same
+// time to slots. Queries wait for a slot in the order of their Priority, and
+// queries that wait for a slot pause the queries of a lower priority between
+// the records their scans read, until they finished.
+func WithScheduler(slots int) Option {
+	return func(e *LocalEngine) {
+		e.slots = slots
+	}
+}
+
+// WithMaxQueuedQueries limits the number of queries that wait for a slot of
+// the engine's scheduler, queries fail with ErrQueueFull once the maximum
+// number of queries wait. It has no effect without WithScheduler.
+func WithMaxQueuedQueries(maxQueued int) Option {
+	return func(e *LocalEngine) {
+		e.maxQueued = maxQueued
+	}
+}
+
+// WithQueueTimeout sets the time queries wait for a slot of the engine's
+// scheduler, queries that waited longer fail with ErrQueueTimeout. The time
+// queries wait counts towards their timeout too. It has no effect without
+// WithScheduler.
+func WithQueueTimeout(timeout time.Duration) Option {
+	return func(e *LocalEngine) {
+		e.queueTimeout = timeout
+	}
+}
+
+// TimeoutError is returned by queries that didn't finish within their
+// timeout.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("query timed out after %s", e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded, as the query's deadline was
+// exceeded.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
 }
 
 func NewEngine(
 	pool memory.Allocator,
 	tableProvider logicalplan.TableProvider,
+	options ...Option,
 ) *LocalEngine {
-	return &LocalEngine{
+	e := &LocalEngine{
 		pool:          pool,
 		tableProvider: tableProvider,
+		functions: &functionRegistry{
+			functions:    map[string]*logicalplan.FunctionDefinition{},
+			aggregations: map[string]*logicalplan.AggregationDefinition{},
+		},
+	}
+	for _, option := range options {
+		option(e)
+	}
+	if e.slots > 0 {
+		e.scheduler = newScheduler(e.slots, e.maxQueued, e.queueTimeout)
+	}
+	if e.remote {
+		e.cache = nil
+	}
+	return e
+}
+
+// ErrFunctionExists is returned when registering a function under a name that
+// is already taken.
+var ErrFunctionExists = errors.New("function already registered")
+
+// RegisterFunction registers a user-defined function that queries can call
+// with logicalplan.Call. The function must return values of the given type.
+func (e *LocalEngine) RegisterFunction(name string, returnType arrow.DataType, fn logicalplan.UserDefinedFunction) error {
+	return e.functions.register(name, &logicalplan.FunctionDefinition{
+		ReturnType: returnType,
+		Func:       fn,
+	})
+}
+
+// RegisterAggregation registers a user-defined aggregation that queries can
+// use with logicalplan.UserAggregation. A new aggregation is created with
+// newAgg each time a query is executed, and must return values of the given
+// type.
+func (e *LocalEngine) RegisterAggregation(name string, returnType arrow.DataType, newAgg func() logicalplan.UserDefinedAggregation) error {
+	return e.functions.registerAggregation(name, &logicalplan.AggregationDefinition{
+		ReturnType: returnType,
+		New:        newAgg,
+	})
+}
+
+type functionRegistry struct {
+	mtx          sync.RWMutex
+	functions    map[string]*logicalplan.FunctionDefinition
+	aggregations map[string]*logicalplan.AggregationDefinition
+}
+
+func (r *functionRegistry) register(name string, def *logicalplan.FunctionDefinition) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.functions[name]; ok {
+		return fmt.Errorf("%w: %s", ErrFunctionExists, name)
+	}
+	r.functions[name] = def
+	return nil
+}
+
+func (r *functionRegistry) registerAggregation(name string, def *logicalplan.AggregationDefinition) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.aggregations[name]; ok {
+		return fmt.Errorf("%w: %s", ErrFunctionExists, name)
 	}
+	r.aggregations[name] = def
+	return nil
+}
+
+func (r *functionRegistry) Function(name string) (*logicalplan.FunctionDefinition, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	def, ok := r.functions[name]
+	return def, ok
+}
+
+func (r *functionRegistry) Aggregation(name string) (*logicalplan.AggregationDefinition, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	def, ok := r.aggregations[name]
+	return def, ok
 }
 
 type LocalQueryBuilder struct {
-	pool        memory.Allocator
-	planBuilder logicalplan.Builder
+	pool            memory.Allocator
+	functions       *functionRegistry
+	physicalOptions []physicalplan.Option
+	timeout         time.Duration
+	memoryLimit     int64
+	cache           *resultCache
+	scheduler       *scheduler
+	priority        Priority
+	partialResults  time.Duration
+	planBuilder     logicalplan.Builder
 }
 
 func (e *LocalEngine) ScanTable(name string) Builder {
 	return LocalQueryBuilder{
-		pool:        e.pool,
-		planBuilder: (&logicalplan.Builder{}).Scan(e.tableProvider, name),
+		pool:            e.pool,
+		functions:       e.functions,
+		physicalOptions: e.physicalOptions,
+		timeout:         e.timeout,
+		memoryLimit:     e.memoryLimit,
+		cache:           e.cache,
+		scheduler:       e.scheduler,
+		planBuilder:     (&logicalplan.Builder{}).Scan(e.tableProvider, name),
+	}
+}
+
+// ScanTables scans all of the tables one after the other, as if they were a
+// single table. The tables must have the same columns, the rows of all of
+// them have the union of the tables' dynamic columns.
+func (e *LocalEngine) ScanTables(names ...string) Builder {
+	scans := make([]logicalplan.Builder, 0, len(names))
+	for _, name := range names {
+		scans = append(scans, (&logicalplan.Builder{}).Scan(e.tableProvider, name))
+	}
+	return LocalQueryBuilder{
+		pool:            e.pool,
+		functions:       e.functions,
+		physicalOptions: e.physicalOptions,
+		timeout:         e.timeout,
+		memoryLimit:     e.memoryLimit,
+		cache:           e.cache,
+		scheduler:       e.scheduler,
+		planBuilder:     (&logicalplan.Builder{}).Union(scans...),
 	}
 }
 
 func (e *LocalEngine) ScanSchema(name string) Builder {
 	return LocalQueryBuilder{
-		pool:        e.pool,
-		planBuilder: (&logicalplan.Builder{}).ScanSchema(e.tableProvider, name),
+		pool:            e.pool,
+		functions:       e.functions,
+		physicalOptions: e.physicalOptions,
+		timeout:         e.timeout,
+		memoryLimit:     e.memoryLimit,
+		cache:           e.cache,
+		scheduler:       e.scheduler,
+		planBuilder:     (&logicalplan.Builder{}).ScanSchema(e.tableProvider, name),
 	}
 }
 
@@ -57,8 +329,34 @@ func (b LocalQueryBuilder) Aggregate(
 	groupExprs ...logicalplan.Expr,
 ) Builder {
 	return LocalQueryBuilder{
-		pool:        b.pool,
-		planBuilder: b.planBuilder.Aggregate(aggExpr, groupExprs...),
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Aggregate(aggExpr, groupExprs...),
+	}
+}
+
+func (b LocalQueryBuilder) Aggregations(
+	aggExprs []logicalplan.Expr,
+	groupExprs ...logicalplan.Expr,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Aggregations(aggExprs, groupExprs...),
 	}
 }
 
@@ -66,8 +364,16 @@ func (b LocalQueryBuilder) Filter(
 	expr logicalplan.Expr,
 ) Builder {
 	return LocalQueryBuilder{
-		pool:        b.pool,
-		planBuilder: b.planBuilder.Filter(expr),
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Filter(expr),
 	}
 }
 
@@ -75,8 +381,158 @@ func (b LocalQueryBuilder) Distinct(
 	expr ...logicalplan.Expr,
 ) Builder {
 	return LocalQueryBuilder{
-		pool:        b.pool,
-		planBuilder: b.planBuilder.Distinct(expr...),
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Distinct(expr...),
+	}
+}
+
+func (b LocalQueryBuilder) Having(
+	expr logicalplan.Expr,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Having(expr),
+	}
+}
+
+func (b LocalQueryBuilder) Window(
+	windowExprs []logicalplan.Expr,
+	orderBy logicalplan.Expr,
+	partitionBy ...logicalplan.Expr,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Window(windowExprs, orderBy, partitionBy...),
+	}
+}
+
+func (b LocalQueryBuilder) OrderBy(
+	exprs ...logicalplan.SortExpr,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.OrderBy(exprs...),
+	}
+}
+
+func (b LocalQueryBuilder) Limit(
+	count int64,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Limit(count),
+	}
+}
+
+func (b LocalQueryBuilder) Offset(
+	count int64,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Offset(count),
+	}
+}
+
+func (b LocalQueryBuilder) Sample(
+	fraction float64,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Sample(fraction),
+	}
+}
+
+func (b LocalQueryBuilder) Unpivot(
+	column *logicalplan.DynamicColumn,
+	nameColumn, valueColumn string,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Unpivot(column, nameColumn, valueColumn),
+	}
+}
+
+// Join joins the rows with the rows of the right query, which must be built
+// by the same engine.
+func (b LocalQueryBuilder) Join(
+	right Builder,
+	leftKeys, rightKeys []logicalplan.Expr,
+) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Join(right.(LocalQueryBuilder).planBuilder, leftKeys, rightKeys),
 	}
 }
 
@@ -84,29 +540,414 @@ func (b LocalQueryBuilder) Project(
 	projections ...logicalplan.Expr,
 ) Builder {
 	return LocalQueryBuilder{
-		pool:        b.pool,
-		planBuilder: b.planBuilder.Project(projections...),
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder.Project(projections...),
+	}
+}
+
+// Timeout sets the time the query can take, overriding the engine's default.
+// A timeout of zero means the query can take any time.
+func (b LocalQueryBuilder) Timeout(timeout time.Duration) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder,
+	}
+}
+
+// Priority sets the priority the query is scheduled with, if the engine has
+// a scheduler. Queries are interactive by default.
+func (b LocalQueryBuilder) Priority(priority Priority) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        priority,
+		partialResults:  b.partialResults,
+		planBuilder:     b.planBuilder,
+	}
+}
+
+// PartialResults makes the aggregations of the query pass on the results of
+// the groups aggregated so far every interval, before their final results.
+// Records that are partial results are reported by IsPartialResult, each of
+// them replaces the results of the previous ones. Partial results are never
+// served from or stored in the engine's result cache.
+func (b LocalQueryBuilder) PartialResults(interval time.Duration) Builder {
+	return LocalQueryBuilder{
+		pool:            b.pool,
+		functions:       b.functions,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  interval,
+		planBuilder:     b.planBuilder,
 	}
 }
 
+// IsPartialResult returns whether the record passed to the callback of a
+// query is a partial result of its aggregation, which is followed by more
+// complete results.
+func IsPartialResult(r arrow.Record) bool {
+	return physicalplan.IsPartialResult(r)
+}
+
 func (b LocalQueryBuilder) Execute(ctx context.Context, callback func(r arrow.Record) error) error {
-	logicalPlan, err := b.planBuilder.Build()
+	q, err := b.Prepare()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.execute(ctx, q.plan, callback)
+	return err
+}
+
+// ExecuteWithStats executes the query and returns the metrics of its
+// execution and of the operators that executed it. The stats are also
+// returned if the query failed once it started executing.
+func (b LocalQueryBuilder) ExecuteWithStats(ctx context.Context, callback func(r arrow.Record) error) (*QueryStats, error) {
+	q, err := b.Prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	return q.executeWithStats(ctx, q.plan, callback)
+}
+
+// Iterator executes the query while its records are read from the returned
+// reader, which must be closed.
+func (b LocalQueryBuilder) Iterator(ctx context.Context) (RecordReader, error) {
+	q, err := b.Prepare()
 	if err != nil {
+		return nil, err
+	}
+
+	return newRecordReader(ctx, func(ctx context.Context, callback func(r arrow.Record) error) error {
+		_, err := q.execute(ctx, q.plan, callback)
 		return err
+	}), nil
+}
+
+// Explain returns the optimized logical plan of the query and the physical
+// operators chosen to execute it.
+func (b LocalQueryBuilder) Explain() (string, error) {
+	q, err := b.Prepare()
+	if err != nil {
+		return "", err
+	}
+
+	return q.Explain()
+}
+
+// Prepare builds and optimizes the query's logical plan, so it can be
+// executed many times with different values for its placeholders.
+func (b LocalQueryBuilder) Prepare() (*PreparedQuery, error) {
+	logicalPlan, err := b.planBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.prepare(logicalPlan)
+}
+
+// PrepareProto prepares the query of the protobuf representation of a logical
+// plan, see logicalplan.ToProto, whose scans read the tables of the engine.
+func (e *LocalEngine) PrepareProto(node *logicalplanpb.PlanNode) (*PreparedQuery, error) {
+	logicalPlan, err := logicalplan.FromProto(node, e.tableProvider)
+	if err != nil {
+		return nil, err
+	}
+	return e.PreparePlan(logicalPlan)
+}
+
+// PreparePlan prepares the query of a logical plan that was built outside of
+// the engine, such as by a parser, so it can be executed with the engine's
+// options.
+func (e *LocalEngine) PreparePlan(logicalPlan *logicalplan.LogicalPlan) (*PreparedQuery, error) {
+	if logicalPlan == nil {
+		return nil, errors.New("empty logical plan")
+	}
+	if err := logicalplan.Validate(logicalPlan); err != nil {
+		return nil, err
+	}
+
+	b := LocalQueryBuilder{
+		pool:            e.pool,
+		functions:       e.functions,
+		physicalOptions: e.physicalOptions,
+		timeout:         e.timeout,
+		memoryLimit:     e.memoryLimit,
+		cache:           e.cache,
+		scheduler:       e.scheduler,
+	}
+	return b.prepare(logicalPlan)
+}
+
+// prepare resolves the functions of the logical plan and optimizes it.
+func (b LocalQueryBuilder) prepare(logicalPlan *logicalplan.LogicalPlan) (*PreparedQuery, error) {
+	logicalPlan, err := logicalPlan.ResolveFunctions(b.functions)
+	if err != nil {
+		return nil, err
 	}
 
 	for _, optimizer := range logicalplan.DefaultOptimizers {
 		logicalPlan = optimizer.Optimize(logicalPlan)
 	}
 
+	return &PreparedQuery{
+		pool:            b.pool,
+		physicalOptions: b.physicalOptions,
+		timeout:         b.timeout,
+		memoryLimit:     b.memoryLimit,
+		cache:           b.cache,
+		scheduler:       b.scheduler,
+		priority:        b.priority,
+		partialResults:  b.partialResults,
+		plan:            logicalPlan,
+	}, nil
+}
+
+// PreparedQuery is a query whose logical plan has been built and optimized.
+// It is safe to execute concurrently.
+type PreparedQuery struct {
+	pool            memory.Allocator
+	physicalOptions []physicalplan.Option
+	timeout         time.Duration
+	memoryLimit     int64
+	cache           *resultCache
+	scheduler       *scheduler
+	priority        Priority
+	partialResults  time.Duration
+	plan            *logicalplan.LogicalPlan
+}
+
+// Execute binds the query's placeholders to the given parameter values and
+// executes it.
+func (q *PreparedQuery) Execute(ctx context.Context, params map[string]interface{}, callback func(r arrow.Record) error) error {
+	logicalPlan, err := q.plan.Bind(params)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.execute(ctx, logicalPlan, callback)
+	return err
+}
+
+// ExecuteWithStats binds the query's placeholders to the given parameter
+// values, executes it and returns the metrics of its execution and of the
+// operators that executed it.
+func (q *PreparedQuery) ExecuteWithStats(ctx context.Context, params map[string]interface{}, callback func(r arrow.Record) error) (*QueryStats, error) {
+	logicalPlan, err := q.plan.Bind(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.executeWithStats(ctx, logicalPlan, callback)
+}
+
+// Iterator binds the query's placeholders to the given parameter values and
+// executes it while its records are read from the returned reader, which must
+// be closed.
+func (q *PreparedQuery) Iterator(ctx context.Context, params map[string]interface{}) (RecordReader, error) {
+	logicalPlan, err := q.plan.Bind(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecordReader(ctx, func(ctx context.Context, callback func(r arrow.Record) error) error {
+		_, err := q.execute(ctx, logicalPlan, callback)
+		return err
+	}), nil
+}
+
+// Explain returns the optimized logical plan of the query and the physical
+// operators chosen to execute it.
+func (q *PreparedQuery) Explain() (string, error) {
+	phyPlan, err := physicalplan.Build(
+		q.pool,
+		q.plan.InputSchema(),
+		q.plan,
+		q.physicalOptions...,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return "Logical Plan:\n" + q.plan.String() + "\n\nPhysical Plan:\n" + phyPlan.String(), nil
+}
+
+// executeWithStats executes the logical plan like execute, but never serves
+// its result from the engine's cache, since the stats of its execution are
+// returned.
+func (q *PreparedQuery) executeWithStats(ctx context.Context, logicalPlan *logicalplan.LogicalPlan, callback func(r arrow.Record) error) (*QueryStats, error) {
+	start := time.Now()
+	scanStats := &logicalplan.ScanStats{}
+	stats := &QueryStats{}
+	phyPlan, err := q.executePlan(
+		logicalplan.WithScanStats(ctx, scanStats),
+		logicalPlan,
+		callback,
+		stats,
+		physicalplan.WithStats(),
+	)
+	if phyPlan == nil {
+		return nil, err
+	}
+
+	stats.PlanStats = phyPlan.Stats()
+	stats.RowsScanned = scanStats.RowsScanned()
+	stats.RowGroupsScanned = scanStats.RowGroupsScanned()
+	stats.RowGroupsPruned = scanStats.RowGroupsPruned()
+	stats.BytesRead = scanStats.BytesRead()
+	stats.Duration = time.Since(start)
+	return stats, err
+}
+
+// execute builds the physical plan of the logical plan and executes it. It
+// returns the physical plan, unless it couldn't be built or the result was
+// served from the engine's cache.
+func (q *PreparedQuery) execute(
+	ctx context.Context,
+	logicalPlan *logicalplan.LogicalPlan,
+	callback func(r arrow.Record) error,
+	opts ...physicalplan.Option,
+) (*physicalplan.OutputPlan, error) {
+	if q.cache == nil || len(opts) > 0 || q.partialResults > 0 {
+		return q.executePlan(ctx, logicalPlan, callback, nil, opts...)
+	}
+	key, ok := cacheKey(logicalPlan)
+	if !ok {
+		return q.executePlan(ctx, logicalPlan, callback, nil, opts...)
+	}
+
+	if records, ok := q.cache.get(key); ok {
+		defer releaseRecords(records)
+		for _, r := range records {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := callback(r); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	var (
+		mtx     sync.Mutex
+		records []arrow.Record
+	)
+	phyPlan, err := q.executePlan(ctx, logicalPlan, func(r arrow.Record) error {
+		if err := callback(r); err != nil {
+			return err
+		}
+		r.Retain()
+		mtx.Lock()
+		records = append(records, r)
+		mtx.Unlock()
+		return nil
+	}, nil)
+	// The result is only cached if the tables weren't written to while the
+	// query was executed, as it may contain some of the rows written.
+	if after, ok := cacheKey(logicalPlan); err == nil && ok && after == key {
+		q.cache.put(key, records)
+	} else {
+		releaseRecords(records)
+	}
+	return phyPlan, err
+}
+
+// executePlan builds the physical plan of the logical plan and executes it.
+// It returns the physical plan, unless it couldn't be built. The peak memory
+// of the query is set on the stats, if any.
+func (q *PreparedQuery) executePlan(
+	ctx context.Context,
+	logicalPlan *logicalplan.LogicalPlan,
+	callback func(r arrow.Record) error,
+	stats *QueryStats,
+	opts ...physicalplan.Option,
+) (*physicalplan.OutputPlan, error) {
+	queryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pool := q.pool
+	var alloc *limitAllocator
+	if q.memoryLimit > 0 || stats != nil {
+		alloc = newLimitAllocator(q.pool, q.memoryLimit, cancel)
+		pool = alloc
+		if stats != nil {
+			defer func() {
+				stats.PeakMemory = alloc.Peak()
+			}()
+		}
+	}
+
+	if q.timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		queryCtx, cancelTimeout = context.WithTimeout(queryCtx, q.timeout)
+		defer cancelTimeout()
+	}
+
+	// The query waits for a slot before it allocates any of its state.
+	slot, err := q.scheduler.acquire(queryCtx, q.priority)
+	if err != nil {
+		return nil, q.contextError(ctx, err)
+	}
+	defer slot.release()
+	if slot != nil {
+		opts = append([]physicalplan.Option{physicalplan.WithYield(slot.yield)}, opts...)
+	}
+	if q.partialResults > 0 {
+		opts = append([]physicalplan.Option{physicalplan.WithPartialResults(q.partialResults)}, opts...)
+	}
+
 	phyPlan, err := physicalplan.Build(
-		b.pool,
+		pool,
 		logicalPlan.InputSchema(),
 		logicalPlan,
+		append(append([]physicalplan.Option{}, q.physicalOptions...), opts...)...,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return phyPlan.Execute(ctx, b.pool, callback)
+	// The operators release their state when the query fails, so nothing is
+	// left behind once the query is cancelled.
+	err = phyPlan.Execute(queryCtx, pool, callback)
+	if alloc != nil && alloc.Exceeded() {
+		return phyPlan, fmt.Errorf("%w: limit of %d bytes", ErrQueryMemoryExceeded, q.memoryLimit)
+	}
+	return phyPlan, q.contextError(ctx, err)
+}
+
+// contextError returns a TimeoutError if the query failed because it took
+// longer than its timeout, rather than because the context of its caller was
+// cancelled.
+func (q *PreparedQuery) contextError(ctx context.Context, err error) error {
+	if q.timeout > 0 && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return &TimeoutError{Timeout: q.timeout}
+	}
+	return err
 }
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/apache/arrow/go/v8/arrow"
+)
+
+// RecordReader iterates over the records of a query. The query is executed
+// while the records are read, it only continues once the caller asks for the
+// next record.
+type RecordReader interface {
+	// Next advances to the next record. It returns false once there are no
+	// more records or the query failed.
+	Next() bool
+	// Record returns the current record. It is valid until the next call to
+	// Next or Close, and must be retained if it is used after that.
+	Record() arrow.Record
+	// Err returns the error the query failed with, if any.
+	Err() error
+	// Close stops the query if it is still executing and releases the
+	// current record. It returns the error the query failed with, if any.
+	Close() error
+}
+
+// recordReader executes the query in a goroutine that passes the records to
+// the reader one at a time.
+type recordReader struct {
+	cancel  context.CancelFunc
+	records chan arrow.Record
+	done    chan struct{}
+	// err is the error the query failed with, it is set before done is
+	// closed.
+	err     error
+	current arrow.Record
+	closed  bool
+}
+
+func newRecordReader(ctx context.Context, execute func(ctx context.Context, callback func(r arrow.Record) error) error) *recordReader {
+	ctx, cancel := context.WithCancel(ctx)
+	rr := &recordReader{
+		cancel:  cancel,
+		records: make(chan arrow.Record),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(rr.done)
+		rr.err = execute(ctx, func(r arrow.Record) error {
+			r.Retain()
+			select {
+			case rr.records <- r:
+				return nil
+			case <-ctx.Done():
+				r.Release()
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return rr
+}
+
+func (rr *recordReader) Next() bool {
+	rr.release()
+	if rr.closed {
+		return false
+	}
+
+	select {
+	case r := <-rr.records:
+		rr.current = r
+		return true
+	case <-rr.done:
+		return false
+	}
+}
+
+func (rr *recordReader) Record() arrow.Record {
+	return rr.current
+}
+
+func (rr *recordReader) Err() error {
+	select {
+	case <-rr.done:
+		return rr.err
+	default:
+		return nil
+	}
+}
+
+func (rr *recordReader) Close() error {
+	rr.release()
+	if rr.closed {
+		return rr.err
+	}
+	rr.closed = true
+
+	// Stopping the query fails it with the context's error, which is not an
+	// error of the query.
+	select {
+	case <-rr.done:
+		rr.cancel()
+		return rr.err
+	default:
+	}
+	rr.cancel()
+	<-rr.done
+	if errors.Is(rr.err, context.Canceled) {
+		rr.err = nil
+	}
+	return rr.err
+}
+
+func (rr *recordReader) release() {
+	if rr.current != nil {
+		rr.current.Release()
+		rr.current = nil
+	}
+}
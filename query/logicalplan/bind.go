@@ -0,0 +1,83 @@
+package logicalplan
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// ErrUnboundPlaceholder is returned when a plan is bound without a value for
+// one of its placeholders.
+var ErrUnboundPlaceholder = errors.New("unbound placeholder")
+
+// Bind returns a copy of the plan with all placeholders replaced by literals
+// of the given parameter values. This allows planning and optimizing a query
+// once and executing it many times with different values. The plan itself is
+// never modified, so it can be bound concurrently.
+func (plan *LogicalPlan) Bind(params map[string]interface{}) (*LogicalPlan, error) {
+	b := &placeholderBinder{params: params}
+	res := plan.RewriteExprs(b)
+	if b.err != nil {
+		return nil, b.err
+	}
+	return res, nil
+}
+
+// BindExpr returns a copy of the expression with all placeholders replaced
+// by literals of the given parameter values.
+func BindExpr(expr Expr, params map[string]interface{}) (Expr, error) {
+	b := &placeholderBinder{params: params}
+	res := expr.Rewrite(b)
+	if b.err != nil {
+		return nil, b.err
+	}
+	return res, nil
+}
+
+type placeholderBinder struct {
+	params map[string]interface{}
+	err    error
+}
+
+func (b *placeholderBinder) PreRewrite(expr Expr) bool {
+	return b.err == nil
+}
+
+func (b *placeholderBinder) PostRewrite(expr Expr) Expr {
+	p, ok := expr.(*PlaceholderExpr)
+	if !ok {
+		return expr
+	}
+
+	v, ok := b.params[p.Placeholder]
+	if !ok {
+		b.err = fmt.Errorf("%w: %s", ErrUnboundPlaceholder, p.Placeholder)
+		return expr
+	}
+
+	l, err := paramLiteral(v)
+	if err != nil {
+		b.err = fmt.Errorf("placeholder %s: %w", p.Placeholder, err)
+		return expr
+	}
+	return l
+}
+
+// paramLiteral turns a parameter value into a literal. Only the types that
+// literals can be compared with are supported.
+func paramLiteral(v interface{}) (*LiteralExpr, error) {
+	switch v := v.(type) {
+	case nil:
+		return &LiteralExpr{Value: scalar.ScalarNull}, nil
+	case scalar.Scalar:
+		return &LiteralExpr{Value: v}, nil
+	case int:
+		return Literal(int64(v)), nil
+	case bool, int64, uint64, float64, string, []byte, time.Time:
+		return Literal(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported parameter type %T", v)
+	}
+}
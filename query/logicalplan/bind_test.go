@@ -0,0 +1,54 @@
+package logicalplan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+)
+
+func TestBind(t *testing.T) {
+	plan, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(And(
+			Col("labels.test").RegexMatch("abc.*"),
+			Col("timestamp").Between(Placeholder("from"), Placeholder("to")),
+			Col("labels.other").Eq(Placeholder("other")),
+		)).
+		Build()
+	require.NoError(t, err)
+
+	original := plan.Clone()
+	from, to := time.UnixMilli(1), time.UnixMilli(2)
+	bound, err := plan.Bind(map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"other": "x",
+	})
+	require.NoError(t, err)
+	require.NoError(t, Validate(bound))
+	require.Equal(t, And(
+		Col("labels.test").RegexMatch("abc.*"),
+		Col("timestamp").Between(Literal(from), Literal(to)),
+		Col("labels.other").Eq(Literal("x")),
+	), bound.Filter.Expr)
+
+	// The prepared plan is left untouched and can be bound again.
+	require.Equal(t, original, plan)
+}
+
+func TestBindErrors(t *testing.T) {
+	expr := Col("labels.test").Eq(Placeholder("value"))
+
+	_, err := BindExpr(expr, map[string]interface{}{})
+	require.ErrorIs(t, err, ErrUnboundPlaceholder)
+
+	_, err = BindExpr(expr, map[string]interface{}{"value": struct{}{}})
+	require.Error(t, err)
+
+	res, err := BindExpr(expr, map[string]interface{}{"value": 1})
+	require.NoError(t, err)
+	require.Equal(t, Col("labels.test").Eq(Literal(int64(1))), res)
+}
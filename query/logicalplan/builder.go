@@ -56,6 +56,18 @@ type Visitor interface {
 	PostVisit(expr Expr) bool
 }
 
+// Rewriter rewrites expression trees bottom up.
+type Rewriter interface {
+	// PreRewrite is called before the sub-expressions of an expression are
+	// rewritten. If it returns false, the expression is left as it is.
+	PreRewrite(expr Expr) bool
+
+	// PostRewrite is called once the sub-expressions of an expression have
+	// been rewritten and returns the expression that replaces it. The
+	// expression passed is a copy that it may modify.
+	PostRewrite(expr Expr) Expr
+}
+
 type Expr interface {
 	DataType(*dynparquet.Schema) (arrow.DataType, error)
 	Accept(Visitor) bool
@@ -78,13 +90,72 @@ type Expr interface {
 	// Computed returns whether the expression is computed as opposed to being
 	// a static value or unmodified physical column.
 	Computed() bool
+
+	// Clone returns a deep copy of the expression.
+	Clone() Expr
+
+	// Rewrite returns the expression rewritten by the rewriter. The
+	// expression itself is never modified, the rewriter operates on copies.
+	Rewrite(Rewriter) Expr
+}
+
+func cloneExpr(expr Expr) Expr {
+	if expr == nil {
+		return nil
+	}
+	return expr.Clone()
+}
+
+func cloneExprs(exprs []Expr) []Expr {
+	if exprs == nil {
+		return nil
+	}
+
+	res := make([]Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		res = append(res, expr.Clone())
+	}
+	return res
 }
 
+func rewriteExpr(expr Expr, rewriter Rewriter) Expr {
+	if expr == nil {
+		return nil
+	}
+	return expr.Rewrite(rewriter)
+}
+
+func rewriteExprs(exprs []Expr, rewriter Rewriter) []Expr {
+	if exprs == nil {
+		return nil
+	}
+
+	res := make([]Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		res = append(res, expr.Rewrite(rewriter))
+	}
+	return res
+}
+
+// Filter passes on only the rows the expression is true for. The
+// expressions of consecutive filters are combined with a logical AND into a
+// single filter.
 func (b Builder) Filter(expr Expr) Builder {
 	if expr == nil {
 		return b
 	}
 
+	if b.plan != nil && b.plan.Filter != nil {
+		return Builder{
+			plan: &LogicalPlan{
+				Input: b.plan.Input,
+				Filter: &Filter{
+					Expr: And(b.plan.Filter.Expr, expr),
+				},
+			},
+		}
+	}
+
 	return Builder{
 		plan: &LogicalPlan{
 			Input: b.plan,
@@ -111,13 +182,147 @@ func (b Builder) Distinct(
 func (b Builder) Aggregate(
 	aggExpr Expr,
 	groupExprs ...Expr,
+) Builder {
+	return b.Aggregations([]Expr{aggExpr}, groupExprs...)
+}
+
+// Aggregations computes all of the aggregation expressions for each group in
+// a single aggregation step.
+func (b Builder) Aggregations(
+	aggExprs []Expr,
+	groupExprs ...Expr,
 ) Builder {
 	return Builder{
 		plan: &LogicalPlan{
 			Input: b.plan,
 			Aggregation: &Aggregation{
 				GroupExprs: groupExprs,
-				AggExpr:    aggExpr,
+				AggExprs:   aggExprs,
+			},
+		},
+	}
+}
+
+// Having filters the groups of the preceding aggregation by the given
+// expression.
+func (b Builder) Having(expr Expr) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Having: &Having{
+				Expr: expr,
+			},
+		},
+	}
+}
+
+// Window computes the window functions for each row, over the rows of its
+// partition ordered by the order expression.
+func (b Builder) Window(
+	windowExprs []Expr,
+	orderBy Expr,
+	partitionBy ...Expr,
+) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Window: &Window{
+				PartitionBy: partitionBy,
+				OrderBy:     orderBy,
+				Exprs:       windowExprs,
+			},
+		},
+	}
+}
+
+// OrderBy sorts the rows by the sort expressions.
+func (b Builder) OrderBy(exprs ...SortExpr) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			OrderBy: &OrderBy{
+				Exprs: exprs,
+			},
+		},
+	}
+}
+
+// Join combines the rows with the rows of the right plan whose right keys
+// have the same values as their left keys.
+func (b Builder) Join(right Builder, leftKeys, rightKeys []Expr) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Join: &Join{
+				Right:     right.plan,
+				LeftKeys:  leftKeys,
+				RightKeys: rightKeys,
+			},
+		},
+	}
+}
+
+// Sample passes on a uniform random sample of the rows, each of them with
+// the probability fraction.
+func (b Builder) Sample(fraction float64) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Sample: &Sample{
+				Fraction: fraction,
+			},
+		},
+	}
+}
+
+// Unpivot turns the concrete columns of the dynamic column into rows of
+// their names and values, in the name and value columns.
+func (b Builder) Unpivot(column *DynamicColumn, nameColumn, valueColumn string) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Unpivot: &Unpivot{
+				Column:      column,
+				NameColumn:  nameColumn,
+				ValueColumn: valueColumn,
+			},
+		},
+	}
+}
+
+// Union passes on the rows of all plans, one plan after the other. Like a
+// scan it starts a new plan, the plans are its inputs.
+func (b Builder) Union(plans ...Builder) Builder {
+	union := &Union{Plans: make([]*LogicalPlan, 0, len(plans))}
+	for _, p := range plans {
+		union.Plans = append(union.Plans, p.plan)
+	}
+	return Builder{
+		plan: &LogicalPlan{
+			Union: union,
+		},
+	}
+}
+
+// Limit passes on only the first count rows.
+func (b Builder) Limit(count int64) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Limit: &Limit{
+				Count: count,
+			},
+		},
+	}
+}
+
+// Offset skips the first count rows.
+func (b Builder) Offset(count int64) Builder {
+	return Builder{
+		plan: &LogicalPlan{
+			Input: b.plan,
+			Offset: &Offset{
+				Count: count,
 			},
 		},
 	}
@@ -32,10 +32,10 @@ func TestLogicalPlanBuilder(t *testing.T) {
 		Input: &LogicalPlan{
 			Aggregation: &Aggregation{
 				GroupExprs: []Expr{&Column{ColumnName: "stacktrace"}},
-				AggExpr: &AliasExpr{
+				AggExprs: []Expr{&AliasExpr{
 					Expr:  &AggregationFunction{Func: AggFuncSum, Expr: &Column{ColumnName: "value"}},
 					Alias: "value_sum",
-				},
+				}},
 			},
 			Input: &LogicalPlan{
 				Filter: &Filter{
@@ -75,3 +75,31 @@ func TestLogicalPlanBuilderWithoutProjection(t *testing.T) {
 		},
 	}, p)
 }
+
+func TestLogicalPlanBuilderFilters(t *testing.T) {
+	tableProvider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+	filtered := (&Builder{}).
+		Scan(tableProvider, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc")))
+
+	p, err := filtered.
+		Filter(nil).
+		Filter(Col("value").Gt(Literal(int64(1)))).
+		Build()
+	require.NoError(t, err)
+
+	expected, err := (&Builder{}).
+		Scan(tableProvider, "table1").
+		Filter(And(
+			Col("labels.test").Eq(Literal("abc")),
+			Col("value").Gt(Literal(int64(1))),
+		)).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, expected, p)
+
+	// The builders the filters were added to are unchanged.
+	p, err = filtered.Build()
+	require.NoError(t, err)
+	require.Equal(t, Col("labels.test").Eq(Literal("abc")), p.Filter.Expr)
+}
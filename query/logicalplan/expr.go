@@ -2,7 +2,11 @@ package logicalplan
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/scalar"
@@ -24,6 +28,16 @@ const (
 	OpRegexMatch
 	OpRegexNotMatch
 	OpAnd
+	OpAdd
+	OpSub
+	OpMul
+	OpDiv
+	OpMod
+	OpOr
+	OpNot
+	OpStartsWith
+	OpEndsWith
+	OpContains
 )
 
 func (o Op) String() string {
@@ -46,11 +60,42 @@ func (o Op) String() string {
 		return "!~"
 	case OpAnd:
 		return "&&"
+	case OpAdd:
+		return "+"
+	case OpSub:
+		return "-"
+	case OpMul:
+		return "*"
+	case OpDiv:
+		return "/"
+	case OpMod:
+		return "%"
+	case OpOr:
+		return "||"
+	case OpNot:
+		return "!"
+	case OpStartsWith:
+		return "starts_with"
+	case OpEndsWith:
+		return "ends_with"
+	case OpContains:
+		return "contains"
 	default:
 		panic("unknown operator")
 	}
 }
 
+// IsArithmetic returns whether the operator computes a numeric value as
+// opposed to a boolean.
+func (o Op) IsArithmetic() bool {
+	switch o {
+	case OpAdd, OpSub, OpMul, OpDiv, OpMod:
+		return true
+	default:
+		return false
+	}
+}
+
 type BinaryExpr struct {
 	Left  Expr
 	Op    Op
@@ -76,8 +121,60 @@ func (e *BinaryExpr) Accept(visitor Visitor) bool {
 	return visitor.PostVisit(e)
 }
 
-func (e *BinaryExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
-	return &arrow.BooleanType{}, nil
+func (e *BinaryExpr) Clone() Expr {
+	return &BinaryExpr{
+		Left:  e.Left.Clone(),
+		Op:    e.Op,
+		Right: e.Right.Clone(),
+	}
+}
+
+func (e *BinaryExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(&BinaryExpr{
+		Left:  e.Left.Rewrite(rewriter),
+		Op:    e.Op,
+		Right: e.Right.Rewrite(rewriter),
+	})
+}
+
+func (e *BinaryExpr) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	if !e.Op.IsArithmetic() {
+		return &arrow.BooleanType{}, nil
+	}
+
+	leftType, err := e.Left.DataType(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rightType, err := e.Right.DataType(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return ArithmeticResultType(leftType, rightType)
+}
+
+// ArithmeticResultType returns the type resulting from an arithmetic
+// operation between the two given types. Operations between signed integers
+// and floats are widened to floats.
+func ArithmeticResultType(left, right arrow.DataType) (arrow.DataType, error) {
+	switch {
+	case left.ID() == arrow.INT64 && right.ID() == arrow.INT64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case left.ID() == arrow.UINT64 && right.ID() == arrow.UINT64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case left.ID() == arrow.FLOAT64 && (right.ID() == arrow.FLOAT64 || right.ID() == arrow.INT64):
+		return arrow.PrimitiveTypes.Float64, nil
+	case left.ID() == arrow.INT64 && right.ID() == arrow.FLOAT64:
+		return arrow.PrimitiveTypes.Float64, nil
+	default:
+		return nil, fmt.Errorf("unsupported arithmetic between %s and %s", left.Name(), right.Name())
+	}
 }
 
 func (e *BinaryExpr) Name() string {
@@ -96,8 +193,96 @@ func (e *BinaryExpr) Computed() bool {
 	return true
 }
 
-func (e *BinaryExpr) Alias(alias string) AliasExpr {
-	return AliasExpr{Expr: e, Alias: alias}
+func (e *BinaryExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: e, Alias: alias}
+}
+
+func (e *BinaryExpr) Eq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpEq,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) NotEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpNotEq,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Gt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpGt,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) GtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpGtEq,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Lt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpLt,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) LtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpLtEq,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Add(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpAdd,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Sub(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpSub,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Mul(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpMul,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Div(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpDiv,
+		Right: expr,
+	}
+}
+
+func (e *BinaryExpr) Mod(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpMod,
+		Right: expr,
+	}
 }
 
 type Column struct {
@@ -117,21 +302,37 @@ func (c *Column) Accept(visitor Visitor) bool {
 	return visitor.PostVisit(c)
 }
 
+func (c *Column) Clone() Expr {
+	return &Column{ColumnName: c.ColumnName}
+}
+
+func (c *Column) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(c) {
+		return c
+	}
+
+	return rewriter.PostRewrite(c.Clone())
+}
+
 func (c *Column) Name() string {
 	return c.ColumnName
 }
 
+// ErrColumnNotFound is returned when an expression references a column that
+// doesn't exist in the schema.
+var ErrColumnNotFound = errors.New("column not found")
+
 func (c *Column) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
-	colDef, found := s.ColumnByName(c.ColumnName)
+	colDef, found := s.FindColumn(c.ColumnName)
 	if !found {
-		return nil, errors.New("column not found")
+		return nil, fmt.Errorf("%w: %s", ErrColumnNotFound, c.ColumnName)
 	}
 
 	return convert.ParquetNodeToType(colDef.StorageLayout)
 }
 
-func (c *Column) Alias(alias string) AliasExpr {
-	return AliasExpr{Expr: c, Alias: alias}
+func (c *Column) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: c, Alias: alias}
 }
 
 func (c *Column) ColumnsUsedExprs() []Expr {
@@ -190,6 +391,46 @@ func (c *Column) LtEq(e Expr) *BinaryExpr {
 	}
 }
 
+func (c *Column) Add(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpAdd,
+		Right: e,
+	}
+}
+
+func (c *Column) Sub(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpSub,
+		Right: e,
+	}
+}
+
+func (c *Column) Mul(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpMul,
+		Right: e,
+	}
+}
+
+func (c *Column) Div(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpDiv,
+		Right: e,
+	}
+}
+
+func (c *Column) Mod(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpMod,
+		Right: e,
+	}
+}
+
 func (c *Column) RegexMatch(pattern string) *BinaryExpr {
 	return &BinaryExpr{
 		Left:  c,
@@ -206,10 +447,75 @@ func (c *Column) RegexNotMatch(pattern string) *BinaryExpr {
 	}
 }
 
+func (c *Column) StartsWith(prefix string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpStartsWith,
+		Right: Literal(prefix),
+	}
+}
+
+func (c *Column) EndsWith(suffix string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpEndsWith,
+		Right: Literal(suffix),
+	}
+}
+
+func (c *Column) Contains(substr string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpContains,
+		Right: Literal(substr),
+	}
+}
+
+// Between is true for values that are greater than or equal to low and less
+// than or equal to high.
+func (c *Column) Between(low, high Expr) *BetweenExpr {
+	return &BetweenExpr{
+		Expr: c,
+		Low:  low,
+		High: high,
+	}
+}
+
+// In is true for values that are equal to any of the values. It's a
+// disjunction of equalities, so the bloom filters of the column can rule out
+// row groups containing none of the values. In without values is false.
+func (c *Column) In(values ...Expr) Expr {
+	if len(values) == 0 {
+		return Literal(false)
+	}
+
+	exprs := make([]Expr, 0, len(values))
+	for _, v := range values {
+		exprs = append(exprs, c.Eq(v))
+	}
+	return or(exprs)
+}
+
 func Col(name string) *Column {
 	return &Column{ColumnName: name}
 }
 
+// Field returns the column of the field of the struct column. It is sugar for
+// the column named by the dotted path of the field's leaf column, for example
+// Col("resource").Field("service_name") is Col("resource.service_name"), there
+// is no separate field expression. Fields are therefore projected and filtered
+// on like any other column, and filters of fields are pushed down to their
+// leaf columns.
+func (c *Column) Field(name string) *Column {
+	return Field(c.ColumnName, name)
+}
+
+// Field returns the column of the child field of the parent struct column, it
+// is sugar for Col(parent + "." + child).
+func Field(parent, child string) *Column {
+	return &Column{ColumnName: parent + "." + child}
+}
+
 func And(exprs ...Expr) Expr {
 	return and(exprs)
 }
@@ -243,6 +549,102 @@ func and(exprs []Expr) Expr {
 	}
 }
 
+func Or(exprs ...Expr) Expr {
+	return or(exprs)
+}
+
+func or(exprs []Expr) Expr {
+	nonNilExprs := make([]Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		if expr != nil {
+			nonNilExprs = append(nonNilExprs, expr)
+		}
+	}
+
+	if len(nonNilExprs) == 0 {
+		return nil
+	}
+	if len(nonNilExprs) == 1 {
+		return nonNilExprs[0]
+	}
+
+	return &BinaryExpr{
+		Left:  nonNilExprs[0],
+		Op:    OpOr,
+		Right: or(nonNilExprs[1:]),
+	}
+}
+
+// UnaryExpr applies an operator to a single expression. The only supported
+// operator is OpNot, which negates a boolean expression.
+type UnaryExpr struct {
+	Op   Op
+	Expr Expr
+}
+
+func Not(expr Expr) *UnaryExpr {
+	return &UnaryExpr{
+		Op:   OpNot,
+		Expr: expr,
+	}
+}
+
+func (e *UnaryExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	continu = e.Expr.Accept(visitor)
+	if !continu {
+		return false
+	}
+
+	return visitor.PostVisit(e)
+}
+
+func (e *UnaryExpr) Clone() Expr {
+	return &UnaryExpr{
+		Op:   e.Op,
+		Expr: e.Expr.Clone(),
+	}
+}
+
+func (e *UnaryExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(&UnaryExpr{
+		Op:   e.Op,
+		Expr: e.Expr.Rewrite(rewriter),
+	})
+}
+
+func (e *UnaryExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	return &arrow.BooleanType{}, nil
+}
+
+func (e *UnaryExpr) Name() string {
+	return e.Op.String() + "(" + e.Expr.Name() + ")"
+}
+
+func (e *UnaryExpr) ColumnsUsedExprs() []Expr {
+	return e.Expr.ColumnsUsedExprs()
+}
+
+func (e *UnaryExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *UnaryExpr) Computed() bool {
+	return true
+}
+
+func (e *UnaryExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: e, Alias: alias}
+}
+
 type DynamicColumn struct {
 	ColumnName string
 }
@@ -258,7 +660,7 @@ func DynCol(name string) *DynamicColumn {
 func (c *DynamicColumn) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
 	colDef, found := s.ColumnByName(c.ColumnName)
 	if !found {
-		return nil, errors.New("column not found")
+		return nil, fmt.Errorf("%w: %s", ErrColumnNotFound, c.ColumnName)
 	}
 
 	return convert.ParquetNodeToType(colDef.StorageLayout)
@@ -280,84 +682,350 @@ func (c *DynamicColumn) Accept(visitor Visitor) bool {
 	return visitor.PreVisit(c) && visitor.PostVisit(c)
 }
 
-func Cols(names ...string) []Expr {
-	exprs := make([]Expr, len(names))
-	for i, name := range names {
-		exprs[i] = Col(name)
-	}
-	return exprs
+func (c *DynamicColumn) Clone() Expr {
+	return &DynamicColumn{ColumnName: c.ColumnName}
 }
 
-type LiteralExpr struct {
-	Value scalar.Scalar
+func (c *DynamicColumn) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(c) {
+		return c
+	}
+
+	return rewriter.PostRewrite(c.Clone())
 }
 
-func (e *LiteralExpr) Computed() bool {
-	return false
+// RegexColumn matches all columns whose name matches a regular expression,
+// including concrete columns of dynamic columns. The pattern must match the
+// whole column name. Filters on it hold for a row if they hold for any of the
+// matching columns.
+type RegexColumn struct {
+	Pattern string
+	re      *regexp.Regexp
 }
 
-func Literal(v interface{}) *LiteralExpr {
-	return &LiteralExpr{
-		Value: scalar.MakeScalar(v),
-	}
+func RegexCol(pattern string) *RegexColumn {
+	c := &RegexColumn{Pattern: pattern}
+	c.compile()
+	return c
 }
 
-func (e *LiteralExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
-	return e.Value.DataType(), nil
+// compile compiles the anchored pattern. Invalid patterns are reported by
+// validation and match no columns.
+func (c *RegexColumn) compile() {
+	c.re, _ = CompileRegexp("^(?:" + c.Pattern + ")$")
 }
 
-func (e *LiteralExpr) Name() string {
-	return e.Value.String()
+func (c *RegexColumn) Computed() bool {
+	return false
 }
 
-func (e *LiteralExpr) Accept(visitor Visitor) bool {
-	continu := visitor.PreVisit(e)
-	if !continu {
-		return false
+// DataType returns the type of the first column of the schema the pattern
+// matches. Concrete columns of dynamic columns are only known to be matched if
+// the pattern starts with the name of the dynamic column. If no column is
+// known to match, the type is unknown until execution and typed as null.
+func (c *RegexColumn) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	if c.re == nil {
+		return nil, fmt.Errorf("invalid column pattern %q", c.Pattern)
 	}
 
-	return visitor.PostVisit(e)
+	for _, def := range s.Columns() {
+		if (!def.Dynamic && c.re.MatchString(def.Name)) ||
+			(def.Dynamic && (strings.HasPrefix(c.Pattern, def.Name+".") || strings.HasPrefix(c.Pattern, def.Name+`\.`))) {
+			return convert.ParquetNodeToType(def.StorageLayout)
+		}
+	}
+
+	return arrow.Null, nil
 }
 
-func (e *LiteralExpr) ColumnsUsedExprs() []Expr { return nil }
+func (c *RegexColumn) ColumnsUsedExprs() []Expr {
+	return []Expr{c}
+}
 
-func (e *LiteralExpr) MatchColumn(columnName string) bool {
-	return e.Name() == columnName
+func (c *RegexColumn) MatchColumn(columnName string) bool {
+	return c.re != nil && c.re.MatchString(columnName)
 }
 
-type AggregationFunction struct {
-	Func AggFunc
-	Expr Expr
+func (c *RegexColumn) Name() string {
+	return c.Pattern
 }
 
-func (f *AggregationFunction) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
-	return f.Expr.DataType(s)
+func (c *RegexColumn) Accept(visitor Visitor) bool {
+	return visitor.PreVisit(c) && visitor.PostVisit(c)
 }
 
-func (f *AggregationFunction) Accept(visitor Visitor) bool {
-	continu := visitor.PreVisit(f)
-	if !continu {
-		return false
-	}
+func (c *RegexColumn) Clone() Expr {
+	return &RegexColumn{Pattern: c.Pattern, re: c.re}
+}
 
-	continu = f.Expr.Accept(visitor)
-	if !continu {
-		return false
+func (c *RegexColumn) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(c) {
+		return c
 	}
 
-	return visitor.PostVisit(f)
+	return rewriter.PostRewrite(c.Clone())
 }
 
-func (f *AggregationFunction) Computed() bool {
-	return true
-}
+func (c *RegexColumn) Eq(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpEq,
+		Right: e,
+	}
+}
+
+func (c *RegexColumn) NotEq(e Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpNotEq,
+		Right: e,
+	}
+}
+
+func (c *RegexColumn) RegexMatch(pattern string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpRegexMatch,
+		Right: Literal(pattern),
+	}
+}
+
+func (c *RegexColumn) RegexNotMatch(pattern string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpRegexNotMatch,
+		Right: Literal(pattern),
+	}
+}
+
+func Cols(names ...string) []Expr {
+	exprs := make([]Expr, len(names))
+	for i, name := range names {
+		exprs[i] = Col(name)
+	}
+	return exprs
+}
+
+type LiteralExpr struct {
+	Value scalar.Scalar
+}
+
+func (e *LiteralExpr) Computed() bool {
+	return false
+}
+
+// Literal creates a literal expression from a Go value. In addition to the
+// types supported by scalar.MakeScalar, time.Time values are turned into
+// millisecond timestamps. Timestamp columns are compared with them in the
+// unit of the column. Scalars, such as decimals, are used as is.
+func Literal(v interface{}) *LiteralExpr {
+	switch v := v.(type) {
+	case time.Time:
+		return &LiteralExpr{
+			Value: scalar.NewTimestampScalar(arrow.Timestamp(v.UnixMilli()), arrow.FixedWidthTypes.Timestamp_ms),
+		}
+	case scalar.Scalar:
+		return &LiteralExpr{Value: v}
+	}
+
+	return &LiteralExpr{
+		Value: scalar.MakeScalar(v),
+	}
+}
+
+func (e *LiteralExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	return e.Value.DataType(), nil
+}
+
+func (e *LiteralExpr) Name() string {
+	return e.Value.String()
+}
+
+func (e *LiteralExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	return visitor.PostVisit(e)
+}
+
+// Clone returns a copy of the literal. Scalars are immutable, so the value is
+// shared with the original.
+func (e *LiteralExpr) Clone() Expr {
+	return &LiteralExpr{Value: e.Value}
+}
+
+func (e *LiteralExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(e.Clone())
+}
+
+func (e *LiteralExpr) ColumnsUsedExprs() []Expr { return nil }
+
+func (e *LiteralExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *LiteralExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: e, Alias: alias}
+}
+
+// AggregationFunction aggregates the values of Expr. If Filter is set, only
+// the values of rows the filter selects are aggregated.
+type AggregationFunction struct {
+	Func   AggFunc
+	Expr   Expr
+	Filter Expr
+	// UserDefined is the name of the aggregation if Func is
+	// AggFuncUserDefined.
+	UserDefined string
+	// Quantiles are the quantiles computed by AggFuncQuantile and
+	// AggFuncQuantiles.
+	Quantiles []float64
+	// OrderBy orders the values of AggFuncFirstBy and AggFuncLastBy.
+	OrderBy Expr
+	// State makes the aggregation return the serialized state of each group
+	// as binary values instead of its result, so the states of partial
+	// aggregations can be merged by a final aggregation. Only aggregations
+	// with mergeable states, such as sketches, have states.
+	State bool
+
+	def *AggregationDefinition
+}
+
+// Where returns the aggregation restricted to the rows selected by the filter.
+func (f *AggregationFunction) Where(filter Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func:        f.Func,
+		Expr:        f.Expr,
+		Filter:      filter,
+		UserDefined: f.UserDefined,
+		Quantiles:   f.Quantiles,
+		OrderBy:     f.OrderBy,
+		State:       f.State,
+		def:         f.def,
+	}
+}
+
+func (f *AggregationFunction) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	if f.State {
+		return arrow.BinaryTypes.Binary, nil
+	}
+
+	switch f.Func {
+	case AggFuncUserDefined:
+		if f.def == nil {
+			return arrow.Null, nil
+		}
+		return f.def.ReturnType, nil
+	case AggFuncCount:
+		return arrow.PrimitiveTypes.Int64, nil
+	case AggFuncAvg:
+		return arrow.PrimitiveTypes.Float64, nil
+	case AggFuncApproxCountDistinct:
+		return arrow.PrimitiveTypes.Int64, nil
+	case AggFuncQuantile, AggFuncVariance, AggFuncStdDev:
+		return arrow.PrimitiveTypes.Float64, nil
+	case AggFuncQuantiles:
+		return arrow.ListOf(arrow.PrimitiveTypes.Float64), nil
+	}
+	return f.Expr.DataType(s)
+}
+
+func (f *AggregationFunction) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(f)
+	if !continu {
+		return false
+	}
+
+	continu = f.Expr.Accept(visitor)
+	if !continu {
+		return false
+	}
+
+	if f.OrderBy != nil {
+		continu = f.OrderBy.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	if f.Filter != nil {
+		continu = f.Filter.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(f)
+}
+
+func (f *AggregationFunction) Clone() Expr {
+	return &AggregationFunction{
+		Func:        f.Func,
+		Expr:        f.Expr.Clone(),
+		Filter:      cloneExpr(f.Filter),
+		UserDefined: f.UserDefined,
+		Quantiles:   f.Quantiles,
+		OrderBy:     cloneExpr(f.OrderBy),
+		State:       f.State,
+		def:         f.def,
+	}
+}
+
+func (f *AggregationFunction) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(f) {
+		return f
+	}
+
+	return rewriter.PostRewrite(&AggregationFunction{
+		Func:        f.Func,
+		Expr:        f.Expr.Rewrite(rewriter),
+		Filter:      rewriteExpr(f.Filter, rewriter),
+		UserDefined: f.UserDefined,
+		Quantiles:   f.Quantiles,
+		OrderBy:     rewriteExpr(f.OrderBy, rewriter),
+		State:       f.State,
+		def:         f.def,
+	})
+}
+
+func (f *AggregationFunction) Computed() bool {
+	return true
+}
 
 func (f *AggregationFunction) Name() string {
-	return f.Func.String() + "(" + f.Expr.Name() + ")"
+	fn := f.Func.String()
+	if f.Func == AggFuncUserDefined {
+		fn = f.UserDefined
+	}
+	name := fn + "(" + f.Expr.Name()
+	if f.OrderBy != nil {
+		name += ", " + f.OrderBy.Name()
+	}
+	for _, q := range f.Quantiles {
+		name += ", " + strconv.FormatFloat(q, 'g', -1, 64)
+	}
+	name += ")"
+	if f.Filter != nil {
+		name += " where " + f.Filter.Name()
+	}
+	return name
 }
 
 func (f *AggregationFunction) ColumnsUsedExprs() []Expr {
-	return f.Expr.ColumnsUsedExprs()
+	exprs := f.Expr.ColumnsUsedExprs()
+	if f.OrderBy != nil {
+		exprs = append(exprs, f.OrderBy.ColumnsUsedExprs()...)
+	}
+	if f.Filter != nil {
+		exprs = append(exprs, f.Filter.ColumnsUsedExprs()...)
+	}
+	return exprs
 }
 
 func (f *AggregationFunction) MatchColumn(columnName string) bool {
@@ -369,12 +1037,48 @@ type AggFunc uint32
 const (
 	AggFuncUnknown AggFunc = iota
 	AggFuncSum
+	AggFuncUserDefined
+	AggFuncCount
+	AggFuncMin
+	AggFuncMax
+	AggFuncAvg
+	AggFuncApproxCountDistinct
+	AggFuncQuantile
+	AggFuncQuantiles
+	AggFuncVariance
+	AggFuncStdDev
+	AggFuncFirstBy
+	AggFuncLastBy
 )
 
 func (f AggFunc) String() string {
 	switch f {
 	case AggFuncSum:
 		return "sum"
+	case AggFuncUserDefined:
+		return "user_defined"
+	case AggFuncCount:
+		return "count"
+	case AggFuncMin:
+		return "min"
+	case AggFuncMax:
+		return "max"
+	case AggFuncAvg:
+		return "avg"
+	case AggFuncApproxCountDistinct:
+		return "approx_count_distinct"
+	case AggFuncQuantile:
+		return "quantile"
+	case AggFuncQuantiles:
+		return "quantiles"
+	case AggFuncVariance:
+		return "variance"
+	case AggFuncStdDev:
+		return "stddev"
+	case AggFuncFirstBy:
+		return "first_by"
+	case AggFuncLastBy:
+		return "last_by"
 	default:
 		panic("unknown aggregation function")
 	}
@@ -387,6 +1091,106 @@ func Sum(expr Expr) *AggregationFunction {
 	}
 }
 
+// Count counts the non-null values of the expression.
+func Count(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncCount,
+		Expr: expr,
+	}
+}
+
+// Min returns the smallest non-null value of the expression.
+func Min(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncMin,
+		Expr: expr,
+	}
+}
+
+// Max returns the largest non-null value of the expression.
+func Max(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncMax,
+		Expr: expr,
+	}
+}
+
+// Avg averages the non-null values of the expression.
+func Avg(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncAvg,
+		Expr: expr,
+	}
+}
+
+// ApproxCountDistinct estimates the number of distinct non-null values of the
+// expression.
+func ApproxCountDistinct(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncApproxCountDistinct,
+		Expr: expr,
+	}
+}
+
+// Quantile estimates the value at quantile q, between 0 and 1, of the
+// non-null values of the expression.
+func Quantile(expr Expr, q float64) *AggregationFunction {
+	return &AggregationFunction{
+		Func:      AggFuncQuantile,
+		Expr:      expr,
+		Quantiles: []float64{q},
+	}
+}
+
+// Variance computes the sample variance of the non-null values of the
+// expression.
+func Variance(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncVariance,
+		Expr: expr,
+	}
+}
+
+// StdDev computes the sample standard deviation of the non-null values of the
+// expression.
+func StdDev(expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func: AggFuncStdDev,
+		Expr: expr,
+	}
+}
+
+// FirstBy returns the non-null value of the expression of the row with the
+// smallest value of the order expression, such as the timestamp.
+func FirstBy(expr, orderBy Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func:    AggFuncFirstBy,
+		Expr:    expr,
+		OrderBy: orderBy,
+	}
+}
+
+// LastBy returns the non-null value of the expression of the row with the
+// largest value of the order expression, such as the most recent value of a
+// series when ordered by timestamp.
+func LastBy(expr, orderBy Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func:    AggFuncLastBy,
+		Expr:    expr,
+		OrderBy: orderBy,
+	}
+}
+
+// Quantiles estimates the values at each of the quantiles of the non-null
+// values of the expression. The result is a list with a value per quantile.
+func Quantiles(expr Expr, qs ...float64) *AggregationFunction {
+	return &AggregationFunction{
+		Func:      AggFuncQuantiles,
+		Expr:      expr,
+		Quantiles: qs,
+	}
+}
+
 type AliasExpr struct {
 	Expr  Expr
 	Alias string
@@ -426,9 +1230,1125 @@ func (e *AliasExpr) Accept(visitor Visitor) bool {
 	return visitor.PostVisit(e)
 }
 
+func (e *AliasExpr) Clone() Expr {
+	return &AliasExpr{
+		Expr:  e.Expr.Clone(),
+		Alias: e.Alias,
+	}
+}
+
+func (e *AliasExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(&AliasExpr{
+		Expr:  e.Expr.Rewrite(rewriter),
+		Alias: e.Alias,
+	})
+}
+
 func (f *AggregationFunction) Alias(alias string) *AliasExpr {
 	return &AliasExpr{
 		Expr:  f,
 		Alias: alias,
 	}
 }
+
+// WhenThen is a single branch of a CaseExpr. If When evaluates to true, the
+// result of the case expression is Then.
+type WhenThen struct {
+	When Expr
+	Then Expr
+}
+
+func When(when, then Expr) WhenThen {
+	return WhenThen{
+		When: when,
+		Then: then,
+	}
+}
+
+// CaseExpr evaluates to the Then expression of the first branch whose When
+// expression is true, or to Else if none of them are. If there is no Else
+// expression the result is null.
+type CaseExpr struct {
+	Cases []WhenThen
+	Else  Expr
+}
+
+func Case(cases ...WhenThen) *CaseExpr {
+	return &CaseExpr{
+		Cases: cases,
+	}
+}
+
+func (e *CaseExpr) WithElse(expr Expr) *CaseExpr {
+	e.Else = expr
+	return e
+}
+
+func (e *CaseExpr) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	if len(e.Cases) == 0 {
+		if e.Else == nil {
+			return nil, errors.New("case expression without branches")
+		}
+		return e.Else.DataType(s)
+	}
+
+	return e.Cases[0].Then.DataType(s)
+}
+
+func (e *CaseExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	for _, c := range e.Cases {
+		continu = c.When.Accept(visitor)
+		if !continu {
+			return false
+		}
+
+		continu = c.Then.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	if e.Else != nil {
+		continu = e.Else.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(e)
+}
+
+func (e *CaseExpr) Clone() Expr {
+	res := &CaseExpr{
+		Cases: make([]WhenThen, 0, len(e.Cases)),
+		Else:  cloneExpr(e.Else),
+	}
+	for _, c := range e.Cases {
+		res.Cases = append(res.Cases, When(c.When.Clone(), c.Then.Clone()))
+	}
+	return res
+}
+
+func (e *CaseExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	res := &CaseExpr{
+		Cases: make([]WhenThen, 0, len(e.Cases)),
+		Else:  rewriteExpr(e.Else, rewriter),
+	}
+	for _, c := range e.Cases {
+		res.Cases = append(res.Cases, When(c.When.Rewrite(rewriter), c.Then.Rewrite(rewriter)))
+	}
+	return rewriter.PostRewrite(res)
+}
+
+func (e *CaseExpr) Name() string {
+	names := make([]string, 0, len(e.Cases)*4+4)
+	names = append(names, "case")
+	for _, c := range e.Cases {
+		names = append(names, "when", c.When.Name(), "then", c.Then.Name())
+	}
+	if e.Else != nil {
+		names = append(names, "else", e.Else.Name())
+	}
+	names = append(names, "end")
+	return strings.Join(names, " ")
+}
+
+func (e *CaseExpr) ColumnsUsedExprs() []Expr {
+	var exprs []Expr
+	for _, c := range e.Cases {
+		exprs = append(exprs, c.When.ColumnsUsedExprs()...)
+		exprs = append(exprs, c.Then.ColumnsUsedExprs()...)
+	}
+	if e.Else != nil {
+		exprs = append(exprs, e.Else.ColumnsUsedExprs()...)
+	}
+	return exprs
+}
+
+func (e *CaseExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *CaseExpr) Computed() bool {
+	return true
+}
+
+func (e *CaseExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: e, Alias: alias}
+}
+
+// CastExpr converts the result of an expression to another type.
+type CastExpr struct {
+	Expr Expr
+	Type arrow.DataType
+}
+
+func Cast(expr Expr, t arrow.DataType) *CastExpr {
+	return &CastExpr{
+		Expr: expr,
+		Type: t,
+	}
+}
+
+func (e *CastExpr) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	from, err := e.Expr.DataType(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !CanCast(from, e.Type) {
+		return nil, fmt.Errorf("cannot cast %s to %s", from.Name(), e.Type.Name())
+	}
+
+	return e.Type, nil
+}
+
+// CanCast returns whether values of the first type can be converted to the
+// second type.
+func CanCast(from, to arrow.DataType) bool {
+	castable := func(t arrow.DataType) bool {
+		switch t.ID() {
+		case arrow.INT64, arrow.UINT64, arrow.FLOAT64, arrow.STRING, arrow.BINARY:
+			return true
+		default:
+			return false
+		}
+	}
+
+	return castable(from) && castable(to)
+}
+
+func (e *CastExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	continu = e.Expr.Accept(visitor)
+	if !continu {
+		return false
+	}
+
+	return visitor.PostVisit(e)
+}
+
+func (e *CastExpr) Clone() Expr {
+	return &CastExpr{
+		Expr: e.Expr.Clone(),
+		Type: e.Type,
+	}
+}
+
+func (e *CastExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(&CastExpr{
+		Expr: e.Expr.Rewrite(rewriter),
+		Type: e.Type,
+	})
+}
+
+func (e *CastExpr) Name() string {
+	return "cast(" + e.Expr.Name() + " as " + e.Type.Name() + ")"
+}
+
+func (e *CastExpr) ColumnsUsedExprs() []Expr {
+	return e.Expr.ColumnsUsedExprs()
+}
+
+func (e *CastExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *CastExpr) Computed() bool {
+	return true
+}
+
+func (e *CastExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: e, Alias: alias}
+}
+
+func (e *CastExpr) Eq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpEq,
+		Right: expr,
+	}
+}
+
+func (e *CastExpr) NotEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpNotEq,
+		Right: expr,
+	}
+}
+
+func (e *CastExpr) Gt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpGt,
+		Right: expr,
+	}
+}
+
+func (e *CastExpr) GtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpGtEq,
+		Right: expr,
+	}
+}
+
+func (e *CastExpr) Lt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpLt,
+		Right: expr,
+	}
+}
+
+func (e *CastExpr) LtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  e,
+		Op:    OpLtEq,
+		Right: expr,
+	}
+}
+
+type ScalarFunc uint32
+
+const (
+	ScalarFuncUnknown ScalarFunc = iota
+	ScalarFuncLower
+	ScalarFuncUpper
+	ScalarFuncConcat
+	ScalarFuncLength
+	ScalarFuncAbs
+	ScalarFuncRound
+	ScalarFuncFloor
+	ScalarFuncCeil
+	ScalarFuncLog
+	ScalarFuncFingerprint
+	ScalarFuncMapValue
+)
+
+func (f ScalarFunc) String() string {
+	switch f {
+	case ScalarFuncLower:
+		return "lower"
+	case ScalarFuncUpper:
+		return "upper"
+	case ScalarFuncConcat:
+		return "concat"
+	case ScalarFuncLength:
+		return "length"
+	case ScalarFuncAbs:
+		return "abs"
+	case ScalarFuncRound:
+		return "round"
+	case ScalarFuncFloor:
+		return "floor"
+	case ScalarFuncCeil:
+		return "ceil"
+	case ScalarFuncLog:
+		return "log"
+	case ScalarFuncFingerprint:
+		return "fingerprint"
+	case ScalarFuncMapValue:
+		return "map_value"
+	default:
+		panic("unknown scalar function")
+	}
+}
+
+// ScalarFunctionExpr applies a function to the values of its arguments row by
+// row.
+type ScalarFunctionExpr struct {
+	Func ScalarFunc
+	Args []Expr
+}
+
+func Lower(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncLower,
+		Args: []Expr{expr},
+	}
+}
+
+func Upper(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncUpper,
+		Args: []Expr{expr},
+	}
+}
+
+func Concat(exprs ...Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncConcat,
+		Args: exprs,
+	}
+}
+
+func Length(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncLength,
+		Args: []Expr{expr},
+	}
+}
+
+func Abs(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncAbs,
+		Args: []Expr{expr},
+	}
+}
+
+func Round(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncRound,
+		Args: []Expr{expr},
+	}
+}
+
+func Floor(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncFloor,
+		Args: []Expr{expr},
+	}
+}
+
+func Ceil(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncCeil,
+		Args: []Expr{expr},
+	}
+}
+
+// Log returns the natural logarithm of its argument.
+func Log(expr Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncLog,
+		Args: []Expr{expr},
+	}
+}
+
+// Fingerprint returns a stable 64-bit hash of the values of its arguments,
+// for example to identify series by their labels.
+func Fingerprint(exprs ...Expr) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncFingerprint,
+		Args: exprs,
+	}
+}
+
+// MapValue returns the value of the key in the maps its argument evaluates to,
+// or null if a map doesn't have the key.
+func MapValue(expr Expr, key string) *ScalarFunctionExpr {
+	return &ScalarFunctionExpr{
+		Func: ScalarFuncMapValue,
+		Args: []Expr{expr, Literal(key)},
+	}
+}
+
+func isNumericType(t arrow.DataType) bool {
+	return t.ID() == arrow.INT64 || t.ID() == arrow.UINT64 || t.ID() == arrow.FLOAT64
+}
+
+func isStringType(t arrow.DataType) bool {
+	return t.ID() == arrow.STRING || t.ID() == arrow.BINARY
+}
+
+func (f *ScalarFunctionExpr) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	argTypes := make([]arrow.DataType, 0, len(f.Args))
+	for _, arg := range f.Args {
+		t, err := arg.DataType(s)
+		if err != nil {
+			return nil, err
+		}
+		argTypes = append(argTypes, t)
+	}
+
+	switch f.Func {
+	case ScalarFuncLower, ScalarFuncUpper, ScalarFuncLength:
+		if len(argTypes) != 1 {
+			return nil, fmt.Errorf("%s expects exactly one argument, got %d", f.Func.String(), len(argTypes))
+		}
+		if !isStringType(argTypes[0]) {
+			return nil, fmt.Errorf("%s expects a string argument, got %s", f.Func.String(), argTypes[0].Name())
+		}
+		if f.Func == ScalarFuncLength {
+			return arrow.PrimitiveTypes.Int64, nil
+		}
+		return argTypes[0], nil
+	case ScalarFuncConcat:
+		if len(argTypes) == 0 {
+			return nil, errors.New("concat expects at least one argument")
+		}
+		return arrow.BinaryTypes.String, nil
+	case ScalarFuncFingerprint:
+		if len(argTypes) == 0 {
+			return nil, errors.New("fingerprint expects at least one argument")
+		}
+		return arrow.PrimitiveTypes.Uint64, nil
+	case ScalarFuncMapValue:
+		if len(argTypes) != 2 {
+			return nil, fmt.Errorf("map_value expects exactly two arguments, got %d", len(argTypes))
+		}
+		m, ok := argTypes[0].(*arrow.MapType)
+		if !ok {
+			return nil, fmt.Errorf("map_value expects a map argument, got %s", argTypes[0].Name())
+		}
+		if !isStringType(argTypes[1]) {
+			return nil, fmt.Errorf("map_value expects a string key, got %s", argTypes[1].Name())
+		}
+		return m.ItemType(), nil
+	case ScalarFuncAbs, ScalarFuncRound, ScalarFuncFloor, ScalarFuncCeil, ScalarFuncLog:
+		if len(argTypes) != 1 {
+			return nil, fmt.Errorf("%s expects exactly one argument, got %d", f.Func.String(), len(argTypes))
+		}
+		if !isNumericType(argTypes[0]) {
+			return nil, fmt.Errorf("%s expects a numeric argument, got %s", f.Func.String(), argTypes[0].Name())
+		}
+		if f.Func == ScalarFuncLog {
+			return arrow.PrimitiveTypes.Float64, nil
+		}
+		return argTypes[0], nil
+	default:
+		return nil, fmt.Errorf("unknown scalar function %d", f.Func)
+	}
+}
+
+func (f *ScalarFunctionExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(f)
+	if !continu {
+		return false
+	}
+
+	for _, arg := range f.Args {
+		continu = arg.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(f)
+}
+
+func (f *ScalarFunctionExpr) Clone() Expr {
+	return &ScalarFunctionExpr{
+		Func: f.Func,
+		Args: cloneExprs(f.Args),
+	}
+}
+
+func (f *ScalarFunctionExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(f) {
+		return f
+	}
+
+	return rewriter.PostRewrite(&ScalarFunctionExpr{
+		Func: f.Func,
+		Args: rewriteExprs(f.Args, rewriter),
+	})
+}
+
+func (f *ScalarFunctionExpr) Name() string {
+	args := make([]string, 0, len(f.Args))
+	for _, arg := range f.Args {
+		args = append(args, arg.Name())
+	}
+	return f.Func.String() + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (f *ScalarFunctionExpr) ColumnsUsedExprs() []Expr {
+	var exprs []Expr
+	for _, arg := range f.Args {
+		exprs = append(exprs, arg.ColumnsUsedExprs()...)
+	}
+	return exprs
+}
+
+func (f *ScalarFunctionExpr) MatchColumn(columnName string) bool {
+	return f.Name() == columnName
+}
+
+func (f *ScalarFunctionExpr) Computed() bool {
+	return true
+}
+
+func (f *ScalarFunctionExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: f, Alias: alias}
+}
+
+func (f *ScalarFunctionExpr) Eq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpEq,
+		Right: expr,
+	}
+}
+
+func (f *ScalarFunctionExpr) NotEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpNotEq,
+		Right: expr,
+	}
+}
+
+func (f *ScalarFunctionExpr) Gt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpGt,
+		Right: expr,
+	}
+}
+
+func (f *ScalarFunctionExpr) GtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpGtEq,
+		Right: expr,
+	}
+}
+
+func (f *ScalarFunctionExpr) Lt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpLt,
+		Right: expr,
+	}
+}
+
+func (f *ScalarFunctionExpr) LtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpLtEq,
+		Right: expr,
+	}
+}
+
+func (f *ScalarFunctionExpr) RegexMatch(pattern string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpRegexMatch,
+		Right: Literal(pattern),
+	}
+}
+
+func (f *ScalarFunctionExpr) RegexNotMatch(pattern string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpRegexNotMatch,
+		Right: Literal(pattern),
+	}
+}
+
+func (f *ScalarFunctionExpr) StartsWith(prefix string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpStartsWith,
+		Right: Literal(prefix),
+	}
+}
+
+func (f *ScalarFunctionExpr) EndsWith(suffix string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpEndsWith,
+		Right: Literal(suffix),
+	}
+}
+
+func (f *ScalarFunctionExpr) Contains(substr string) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  f,
+		Op:    OpContains,
+		Right: Literal(substr),
+	}
+}
+
+// DurationTruncateExpr truncates timestamps to a multiple of a duration, such
+// as to bucket samples by minute. The timestamps are expected to be int64
+// milliseconds since the Unix epoch.
+type DurationTruncateExpr struct {
+	Expr     Expr
+	Duration time.Duration
+}
+
+func DurationTruncate(expr Expr, duration time.Duration) *DurationTruncateExpr {
+	return &DurationTruncateExpr{
+		Expr:     expr,
+		Duration: duration,
+	}
+}
+
+func (d *DurationTruncateExpr) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	t, err := d.Expr.DataType(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ID() != arrow.INT64 {
+		return nil, fmt.Errorf("duration truncate expects an int64 argument, got %s", t.Name())
+	}
+	if d.Duration.Milliseconds() <= 0 {
+		return nil, fmt.Errorf("duration truncate expects a duration of at least a millisecond, got %s", d.Duration)
+	}
+
+	return t, nil
+}
+
+func (d *DurationTruncateExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(d)
+	if !continu {
+		return false
+	}
+
+	continu = d.Expr.Accept(visitor)
+	if !continu {
+		return false
+	}
+
+	return visitor.PostVisit(d)
+}
+
+func (d *DurationTruncateExpr) Clone() Expr {
+	return &DurationTruncateExpr{
+		Expr:     d.Expr.Clone(),
+		Duration: d.Duration,
+	}
+}
+
+func (d *DurationTruncateExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(d) {
+		return d
+	}
+
+	return rewriter.PostRewrite(&DurationTruncateExpr{
+		Expr:     d.Expr.Rewrite(rewriter),
+		Duration: d.Duration,
+	})
+}
+
+func (d *DurationTruncateExpr) Name() string {
+	return "duration_truncate(" + d.Expr.Name() + ", " + d.Duration.String() + ")"
+}
+
+func (d *DurationTruncateExpr) ColumnsUsedExprs() []Expr {
+	return d.Expr.ColumnsUsedExprs()
+}
+
+func (d *DurationTruncateExpr) MatchColumn(columnName string) bool {
+	return d.Name() == columnName
+}
+
+func (d *DurationTruncateExpr) Computed() bool {
+	return true
+}
+
+func (d *DurationTruncateExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: d, Alias: alias}
+}
+
+// CoalesceExpr evaluates to the first of its expressions that is not null.
+// Columns that don't exist are treated as null.
+type CoalesceExpr struct {
+	Exprs []Expr
+}
+
+func Coalesce(exprs ...Expr) *CoalesceExpr {
+	return &CoalesceExpr{
+		Exprs: exprs,
+	}
+}
+
+func (c *CoalesceExpr) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	if len(c.Exprs) == 0 {
+		return nil, errors.New("coalesce expects at least one argument")
+	}
+
+	return c.Exprs[0].DataType(s)
+}
+
+func (c *CoalesceExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(c)
+	if !continu {
+		return false
+	}
+
+	for _, expr := range c.Exprs {
+		continu = expr.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(c)
+}
+
+func (c *CoalesceExpr) Clone() Expr {
+	return &CoalesceExpr{Exprs: cloneExprs(c.Exprs)}
+}
+
+func (c *CoalesceExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(c) {
+		return c
+	}
+
+	return rewriter.PostRewrite(&CoalesceExpr{Exprs: rewriteExprs(c.Exprs, rewriter)})
+}
+
+func (c *CoalesceExpr) Name() string {
+	names := make([]string, 0, len(c.Exprs))
+	for _, expr := range c.Exprs {
+		names = append(names, expr.Name())
+	}
+	return "coalesce(" + strings.Join(names, ", ") + ")"
+}
+
+func (c *CoalesceExpr) ColumnsUsedExprs() []Expr {
+	var exprs []Expr
+	for _, expr := range c.Exprs {
+		exprs = append(exprs, expr.ColumnsUsedExprs()...)
+	}
+	return exprs
+}
+
+func (c *CoalesceExpr) MatchColumn(columnName string) bool {
+	return c.Name() == columnName
+}
+
+func (c *CoalesceExpr) Computed() bool {
+	return true
+}
+
+func (c *CoalesceExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: c, Alias: alias}
+}
+
+// BetweenExpr is true for values of Expr within the inclusive range [Low,
+// High]. Null values are never within the range.
+type BetweenExpr struct {
+	Expr Expr
+	Low  Expr
+	High Expr
+}
+
+func (e *BetweenExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	return &arrow.BooleanType{}, nil
+}
+
+func (e *BetweenExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	for _, expr := range []Expr{e.Expr, e.Low, e.High} {
+		continu = expr.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(e)
+}
+
+func (e *BetweenExpr) Clone() Expr {
+	return &BetweenExpr{
+		Expr: e.Expr.Clone(),
+		Low:  e.Low.Clone(),
+		High: e.High.Clone(),
+	}
+}
+
+func (e *BetweenExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(&BetweenExpr{
+		Expr: e.Expr.Rewrite(rewriter),
+		Low:  e.Low.Rewrite(rewriter),
+		High: e.High.Rewrite(rewriter),
+	})
+}
+
+func (e *BetweenExpr) Name() string {
+	return e.Expr.Name() + " between " + e.Low.Name() + " and " + e.High.Name()
+}
+
+func (e *BetweenExpr) ColumnsUsedExprs() []Expr {
+	exprs := e.Expr.ColumnsUsedExprs()
+	exprs = append(exprs, e.Low.ColumnsUsedExprs()...)
+	return append(exprs, e.High.ColumnsUsedExprs()...)
+}
+
+func (e *BetweenExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *BetweenExpr) Computed() bool {
+	return true
+}
+
+func (e *BetweenExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: e, Alias: alias}
+}
+
+// PlaceholderExpr is a named parameter of a plan that is replaced by a
+// literal when the plan is bound. Its type is unknown until then, so it is
+// typed as null.
+type PlaceholderExpr struct {
+	Placeholder string
+}
+
+func Placeholder(name string) *PlaceholderExpr {
+	return &PlaceholderExpr{Placeholder: name}
+}
+
+func (e *PlaceholderExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	return arrow.Null, nil
+}
+
+func (e *PlaceholderExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	return visitor.PostVisit(e)
+}
+
+func (e *PlaceholderExpr) Clone() Expr {
+	return &PlaceholderExpr{Placeholder: e.Placeholder}
+}
+
+func (e *PlaceholderExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(e.Clone())
+}
+
+func (e *PlaceholderExpr) Name() string {
+	return "$" + e.Placeholder
+}
+
+func (e *PlaceholderExpr) ColumnsUsedExprs() []Expr { return nil }
+
+func (e *PlaceholderExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *PlaceholderExpr) Computed() bool {
+	return false
+}
+
+// AllColumnsExpr matches all columns, including all concrete columns of
+// dynamic columns. It is expanded against the schema of the data at execution
+// time.
+type AllColumnsExpr struct{}
+
+func AllColumns() *AllColumnsExpr {
+	return &AllColumnsExpr{}
+}
+
+func (e *AllColumnsExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	return nil, errors.New("all columns expression has no single data type")
+}
+
+func (e *AllColumnsExpr) Accept(visitor Visitor) bool {
+	return visitor.PreVisit(e) && visitor.PostVisit(e)
+}
+
+func (e *AllColumnsExpr) Clone() Expr {
+	return &AllColumnsExpr{}
+}
+
+func (e *AllColumnsExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(e.Clone())
+}
+
+func (e *AllColumnsExpr) Name() string {
+	return "*"
+}
+
+func (e *AllColumnsExpr) ColumnsUsedExprs() []Expr {
+	return []Expr{e}
+}
+
+func (e *AllColumnsExpr) MatchColumn(_ string) bool {
+	return true
+}
+
+func (e *AllColumnsExpr) Computed() bool {
+	return false
+}
+
+// TupleExpr is an ordered list of expressions. Tuples can only be compared
+// with tuples of the same length, which compares them lexicographically, e.g.
+// (timestamp, id) > (t0, id0).
+type TupleExpr struct {
+	Exprs []Expr
+}
+
+func Tuple(exprs ...Expr) *TupleExpr {
+	return &TupleExpr{Exprs: exprs}
+}
+
+func (e *TupleExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	return nil, errors.New("tuple has no data type, it can only be compared with tuples")
+}
+
+func (e *TupleExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(e)
+	if !continu {
+		return false
+	}
+
+	for _, expr := range e.Exprs {
+		continu = expr.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(e)
+}
+
+func (e *TupleExpr) Clone() Expr {
+	return &TupleExpr{Exprs: cloneExprs(e.Exprs)}
+}
+
+func (e *TupleExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(e) {
+		return e
+	}
+
+	return rewriter.PostRewrite(&TupleExpr{Exprs: rewriteExprs(e.Exprs, rewriter)})
+}
+
+func (e *TupleExpr) Name() string {
+	names := make([]string, 0, len(e.Exprs))
+	for _, expr := range e.Exprs {
+		names = append(names, expr.Name())
+	}
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+func (e *TupleExpr) ColumnsUsedExprs() []Expr {
+	exprs := []Expr{}
+	for _, expr := range e.Exprs {
+		exprs = append(exprs, expr.ColumnsUsedExprs()...)
+	}
+	return exprs
+}
+
+func (e *TupleExpr) MatchColumn(columnName string) bool {
+	return e.Name() == columnName
+}
+
+func (e *TupleExpr) Computed() bool {
+	return true
+}
+
+func (e *TupleExpr) Eq(t *TupleExpr) *BinaryExpr {
+	return &BinaryExpr{Left: e, Op: OpEq, Right: t}
+}
+
+func (e *TupleExpr) NotEq(t *TupleExpr) *BinaryExpr {
+	return &BinaryExpr{Left: e, Op: OpNotEq, Right: t}
+}
+
+func (e *TupleExpr) Gt(t *TupleExpr) *BinaryExpr {
+	return &BinaryExpr{Left: e, Op: OpGt, Right: t}
+}
+
+func (e *TupleExpr) GtEq(t *TupleExpr) *BinaryExpr {
+	return &BinaryExpr{Left: e, Op: OpGtEq, Right: t}
+}
+
+func (e *TupleExpr) Lt(t *TupleExpr) *BinaryExpr {
+	return &BinaryExpr{Left: e, Op: OpLt, Right: t}
+}
+
+func (e *TupleExpr) LtEq(t *TupleExpr) *BinaryExpr {
+	return &BinaryExpr{Left: e, Op: OpLtEq, Right: t}
+}
+
+// ExpandTupleComparison returns the comparison of two tuples as a comparison
+// of their elements, e.g. (a, b) > (x, y) becomes a > x OR (a = x AND b > y).
+// It returns false if the expression is not a comparison of tuples of the same
+// length.
+func ExpandTupleComparison(expr *BinaryExpr) (Expr, bool) {
+	left, ok := expr.Left.(*TupleExpr)
+	if !ok {
+		return nil, false
+	}
+	right, ok := expr.Right.(*TupleExpr)
+	if !ok || len(left.Exprs) != len(right.Exprs) || len(left.Exprs) == 0 {
+		return nil, false
+	}
+
+	n := len(left.Exprs)
+	elem := func(i int, op Op) Expr {
+		return &BinaryExpr{Left: left.Exprs[i], Op: op, Right: right.Exprs[i]}
+	}
+
+	switch expr.Op {
+	case OpEq, OpNotEq:
+		exprs := make([]Expr, 0, n)
+		for i := 0; i < n; i++ {
+			exprs = append(exprs, elem(i, expr.Op))
+		}
+		if expr.Op == OpEq {
+			return And(exprs...), true
+		}
+		return Or(exprs...), true
+	case OpLt, OpLtEq, OpGt, OpGtEq:
+		strict := OpLt
+		if expr.Op == OpGt || expr.Op == OpGtEq {
+			strict = OpGt
+		}
+
+		exprs := make([]Expr, 0, n)
+		for i := 0; i < n; i++ {
+			conjuncts := make([]Expr, 0, i+1)
+			for j := 0; j < i; j++ {
+				conjuncts = append(conjuncts, elem(j, OpEq))
+			}
+			op := strict
+			if i == n-1 {
+				// Only the last element is compared inclusively.
+				op = expr.Op
+			}
+			exprs = append(exprs, And(append(conjuncts, elem(i, op))...))
+		}
+		return Or(exprs...), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,262 @@
+package logicalplan
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// foldLiterals evaluates an operation between two literal values. It returns
+// false if the operation can't be evaluated ahead of time, in which case it is
+// left to be evaluated at query time.
+func foldLiterals(op Op, left, right scalar.Scalar) (scalar.Scalar, bool) {
+	if !left.IsValid() || !right.IsValid() {
+		return nil, false
+	}
+
+	switch op {
+	case OpAnd, OpOr:
+		l, ok := left.(*scalar.Boolean)
+		if !ok {
+			return nil, false
+		}
+		r, ok := right.(*scalar.Boolean)
+		if !ok {
+			return nil, false
+		}
+		if op == OpAnd {
+			return scalar.NewBooleanScalar(l.Value && r.Value), true
+		}
+		return scalar.NewBooleanScalar(l.Value || r.Value), true
+	case OpAdd, OpSub, OpMul, OpDiv, OpMod:
+		return foldArithmetic(op, left, right)
+	case OpEq, OpNotEq, OpLt, OpLtEq, OpGt, OpGtEq:
+		c, ok := compareScalars(op, foldableScalar(left), foldableScalar(right))
+		if !ok {
+			return nil, false
+		}
+		return scalar.NewBooleanScalar(c), true
+	case OpRegexMatch, OpRegexNotMatch, OpStartsWith, OpEndsWith, OpContains:
+		l, ok := scalarBytes(left)
+		if !ok {
+			return nil, false
+		}
+		r, ok := scalarBytes(right)
+		if !ok {
+			return nil, false
+		}
+
+		var res bool
+		switch op {
+		case OpRegexMatch, OpRegexNotMatch:
+			re, err := CompileRegexp(string(r))
+			if err != nil {
+				return nil, false
+			}
+			res = re.Match(l) == (op == OpRegexMatch)
+		case OpStartsWith:
+			res = bytes.HasPrefix(l, r)
+		case OpEndsWith:
+			res = bytes.HasSuffix(l, r)
+		case OpContains:
+			res = bytes.Contains(l, r)
+		}
+		return scalar.NewBooleanScalar(res), true
+	default:
+		return nil, false
+	}
+}
+
+// foldableScalar returns timestamps as int64 milliseconds, as that is how
+// they are compared at query time.
+func foldableScalar(s scalar.Scalar) scalar.Scalar {
+	if ts, ok := s.(*scalar.Timestamp); ok {
+		return scalar.NewInt64Scalar(int64(ts.Value))
+	}
+	return s
+}
+
+func scalarBytes(s scalar.Scalar) ([]byte, bool) {
+	switch v := s.(type) {
+	case *scalar.String:
+		return v.Data(), true
+	case *scalar.Binary:
+		return v.Data(), true
+	default:
+		return nil, false
+	}
+}
+
+// scalarFloat64 returns the value of a numeric scalar as float64, for
+// operations between floats and signed integers.
+func scalarFloat64(s scalar.Scalar) (float64, bool) {
+	switch v := s.(type) {
+	case *scalar.Float64:
+		return v.Value, true
+	case *scalar.Int64:
+		return float64(v.Value), true
+	default:
+		return 0, false
+	}
+}
+
+func compareScalars(op Op, left, right scalar.Scalar) (bool, bool) {
+	var c int
+	switch l := left.(type) {
+	case *scalar.Int64:
+		if r, ok := right.(*scalar.Int64); ok {
+			c = compareInt64(l.Value, r.Value)
+			break
+		}
+		lf, _ := scalarFloat64(l)
+		rf, ok := right.(*scalar.Float64)
+		if !ok || math.IsNaN(rf.Value) {
+			return false, false
+		}
+		c = compareFloat64(lf, rf.Value)
+	case *scalar.Uint64:
+		r, ok := right.(*scalar.Uint64)
+		if !ok {
+			return false, false
+		}
+		switch {
+		case l.Value < r.Value:
+			c = -1
+		case l.Value > r.Value:
+			c = 1
+		}
+	case *scalar.Float64:
+		rf, ok := scalarFloat64(right)
+		if !ok || math.IsNaN(l.Value) || math.IsNaN(rf) {
+			return false, false
+		}
+		c = compareFloat64(l.Value, rf)
+	case *scalar.String, *scalar.Binary:
+		lb, _ := scalarBytes(l)
+		rb, ok := scalarBytes(right)
+		if !ok {
+			return false, false
+		}
+		c = bytes.Compare(lb, rb)
+	case *scalar.Boolean:
+		r, ok := right.(*scalar.Boolean)
+		if !ok || (op != OpEq && op != OpNotEq) {
+			return false, false
+		}
+		if l.Value != r.Value {
+			c = 1
+		}
+	default:
+		return false, false
+	}
+
+	switch op {
+	case OpEq:
+		return c == 0, true
+	case OpNotEq:
+		return c != 0, true
+	case OpLt:
+		return c < 0, true
+	case OpLtEq:
+		return c <= 0, true
+	case OpGt:
+		return c > 0, true
+	case OpGtEq:
+		return c >= 0, true
+	default:
+		return false, false
+	}
+}
+
+func compareInt64(l, r int64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64(l, r float64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// foldArithmetic follows the typing rules of ArithmeticResultType. Integer
+// division by zero results in null at query time, so it isn't folded.
+func foldArithmetic(op Op, left, right scalar.Scalar) (scalar.Scalar, bool) {
+	t, err := ArithmeticResultType(left.DataType(), right.DataType())
+	if err != nil {
+		return nil, false
+	}
+
+	switch t.ID() {
+	case arrow.INT64:
+		l, r := left.(*scalar.Int64).Value, right.(*scalar.Int64).Value
+		switch op {
+		case OpAdd:
+			return scalar.NewInt64Scalar(l + r), true
+		case OpSub:
+			return scalar.NewInt64Scalar(l - r), true
+		case OpMul:
+			return scalar.NewInt64Scalar(l * r), true
+		case OpDiv:
+			if r == 0 {
+				return nil, false
+			}
+			return scalar.NewInt64Scalar(l / r), true
+		case OpMod:
+			if r == 0 {
+				return nil, false
+			}
+			return scalar.NewInt64Scalar(l % r), true
+		}
+	case arrow.UINT64:
+		l, r := left.(*scalar.Uint64).Value, right.(*scalar.Uint64).Value
+		switch op {
+		case OpAdd:
+			return scalar.NewUint64Scalar(l + r), true
+		case OpSub:
+			return scalar.NewUint64Scalar(l - r), true
+		case OpMul:
+			return scalar.NewUint64Scalar(l * r), true
+		case OpDiv:
+			if r == 0 {
+				return nil, false
+			}
+			return scalar.NewUint64Scalar(l / r), true
+		case OpMod:
+			if r == 0 {
+				return nil, false
+			}
+			return scalar.NewUint64Scalar(l % r), true
+		}
+	case arrow.FLOAT64:
+		l, _ := scalarFloat64(left)
+		r, _ := scalarFloat64(right)
+		switch op {
+		case OpAdd:
+			return scalar.NewFloat64Scalar(l + r), true
+		case OpSub:
+			return scalar.NewFloat64Scalar(l - r), true
+		case OpMul:
+			return scalar.NewFloat64Scalar(l * r), true
+		case OpDiv:
+			return scalar.NewFloat64Scalar(l / r), true
+		case OpMod:
+			return scalar.NewFloat64Scalar(math.Mod(l, r)), true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,656 @@
+package logicalplan
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// ExprFactory returns a new, empty expression of a registered type for JSON to
+// be decoded into.
+type ExprFactory func() Expr
+
+var exprRegistry = struct {
+	sync.RWMutex
+	factories map[string]ExprFactory
+	tags      map[reflect.Type]string
+}{
+	factories: map[string]ExprFactory{},
+	tags:      map[reflect.Type]string{},
+}
+
+// RegisterExpr registers an expression type for JSON serialization. Encoded
+// expressions are tagged with the given tag, which is used to look up the
+// factory creating the expression to decode into. Expressions of types that
+// aren't registered can't be serialized. RegisterExpr panics if the tag or
+// the type returned by the factory are already registered.
+func RegisterExpr(tag string, factory ExprFactory) {
+	exprRegistry.Lock()
+	defer exprRegistry.Unlock()
+
+	typ := reflect.TypeOf(factory())
+	if _, ok := exprRegistry.factories[tag]; ok {
+		panic("logicalplan: expression tag registered twice: " + tag)
+	}
+	if _, ok := exprRegistry.tags[typ]; ok {
+		panic("logicalplan: expression type registered twice: " + typ.String())
+	}
+
+	exprRegistry.factories[tag] = factory
+	exprRegistry.tags[typ] = tag
+}
+
+func init() {
+	// The tags of the built-in expressions are their type names, as
+	// those are what plans have always been serialized with.
+	for _, factory := range []ExprFactory{
+		func() Expr { return &AggregationFunction{} },
+		func() Expr { return &AliasExpr{} },
+		func() Expr { return &AllColumnsExpr{} },
+		func() Expr { return &BetweenExpr{} },
+		func() Expr { return &BinaryExpr{} },
+		func() Expr { return &CallExpr{} },
+		func() Expr { return &CaseExpr{} },
+		func() Expr { return &CastExpr{} },
+		func() Expr { return &CoalesceExpr{} },
+		func() Expr { return &Column{} },
+		func() Expr { return &DurationTruncateExpr{} },
+		func() Expr { return &DynamicColumn{} },
+		func() Expr { return &LiteralExpr{} },
+		func() Expr { return &PlaceholderExpr{} },
+		func() Expr { return &RegexColumn{} },
+		func() Expr { return &ScalarFunctionExpr{} },
+		func() Expr { return &TupleExpr{} },
+		func() Expr { return &UnaryExpr{} },
+		func() Expr { return &WindowFunction{} },
+	} {
+		RegisterExpr(reflect.TypeOf(factory()).String(), factory)
+	}
+}
+
+// exprJSON is the JSON representation of an expression, carrying the
+// expression's tag so it can be decoded into the correct implementation.
+type exprJSON struct {
+	ExprType string
+	Expr     json.RawMessage
+}
+
+func marshalExpr(expr Expr) (exprJSON, error) {
+	if expr == nil {
+		return exprJSON{}, nil
+	}
+
+	exprRegistry.RLock()
+	tag, ok := exprRegistry.tags[reflect.TypeOf(expr)]
+	exprRegistry.RUnlock()
+	if !ok {
+		return exprJSON{}, fmt.Errorf("unregistered expression type %T", expr)
+	}
+
+	data, err := json.Marshal(expr)
+	if err != nil {
+		return exprJSON{}, err
+	}
+
+	return exprJSON{
+		ExprType: tag,
+		Expr:     data,
+	}, nil
+}
+
+func unmarshalExpr(e exprJSON) (Expr, error) {
+	if e.ExprType == "" {
+		return nil, nil
+	}
+
+	exprRegistry.RLock()
+	factory, ok := exprRegistry.factories[e.ExprType]
+	exprRegistry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported expression type %q", e.ExprType)
+	}
+
+	expr := factory()
+	if err := json.Unmarshal(e.Expr, expr); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// typedExpr encodes an expression together with its tag, expressions that
+// have sub-expressions use it to encode them.
+type typedExpr struct {
+	Expr Expr
+}
+
+func (t typedExpr) MarshalJSON() ([]byte, error) {
+	e, err := marshalExpr(t.Expr)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(e)
+}
+
+func (t *typedExpr) UnmarshalJSON(data []byte) error {
+	var e exprJSON
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+
+	expr, err := unmarshalExpr(e)
+	if err != nil {
+		return err
+	}
+	t.Expr = expr
+	return nil
+}
+
+func typedExprs(exprs []Expr) []typedExpr {
+	if exprs == nil {
+		return nil
+	}
+	res := make([]typedExpr, len(exprs))
+	for i, expr := range exprs {
+		res[i] = typedExpr{Expr: expr}
+	}
+	return res
+}
+
+func untypedExprs(exprs []typedExpr) []Expr {
+	if exprs == nil {
+		return nil
+	}
+	res := make([]Expr, len(exprs))
+	for i, expr := range exprs {
+		res[i] = expr.Expr
+	}
+	return res
+}
+
+type binaryExprJSON struct {
+	Left  typedExpr
+	Op    Op
+	Right typedExpr
+}
+
+func (e *BinaryExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(binaryExprJSON{
+		Left:  typedExpr{Expr: e.Left},
+		Op:    e.Op,
+		Right: typedExpr{Expr: e.Right},
+	})
+}
+
+func (e *BinaryExpr) UnmarshalJSON(data []byte) error {
+	var be binaryExprJSON
+	if err := json.Unmarshal(data, &be); err != nil {
+		return err
+	}
+
+	e.Left = be.Left.Expr
+	e.Op = be.Op
+	e.Right = be.Right.Expr
+	return nil
+}
+
+type unaryExprJSON struct {
+	Op   Op
+	Expr typedExpr
+}
+
+func (e *UnaryExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(unaryExprJSON{
+		Op:   e.Op,
+		Expr: typedExpr{Expr: e.Expr},
+	})
+}
+
+func (e *UnaryExpr) UnmarshalJSON(data []byte) error {
+	var ue unaryExprJSON
+	if err := json.Unmarshal(data, &ue); err != nil {
+		return err
+	}
+
+	e.Op = ue.Op
+	e.Expr = ue.Expr.Expr
+	return nil
+}
+
+type aggregationFunctionJSON struct {
+	Func        AggFunc
+	Expr        typedExpr
+	Filter      typedExpr
+	UserDefined string
+	Quantiles   []float64
+	OrderBy     typedExpr
+}
+
+func (f *AggregationFunction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(aggregationFunctionJSON{
+		Func:        f.Func,
+		Expr:        typedExpr{Expr: f.Expr},
+		Filter:      typedExpr{Expr: f.Filter},
+		UserDefined: f.UserDefined,
+		Quantiles:   f.Quantiles,
+		OrderBy:     typedExpr{Expr: f.OrderBy},
+	})
+}
+
+func (f *AggregationFunction) UnmarshalJSON(data []byte) error {
+	var af aggregationFunctionJSON
+	if err := json.Unmarshal(data, &af); err != nil {
+		return err
+	}
+
+	f.Func = af.Func
+	f.Expr = af.Expr.Expr
+	f.Filter = af.Filter.Expr
+	f.UserDefined = af.UserDefined
+	f.Quantiles = af.Quantiles
+	f.OrderBy = af.OrderBy.Expr
+	return nil
+}
+
+type windowFunctionJSON struct {
+	Func   WindowFunc
+	Expr   typedExpr
+	Offset int64
+	Width  int64
+}
+
+func (f *WindowFunction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(windowFunctionJSON{
+		Func:   f.Func,
+		Expr:   typedExpr{Expr: f.Expr},
+		Offset: f.Offset,
+		Width:  f.Width,
+	})
+}
+
+func (f *WindowFunction) UnmarshalJSON(data []byte) error {
+	var wf windowFunctionJSON
+	if err := json.Unmarshal(data, &wf); err != nil {
+		return err
+	}
+
+	f.Func = wf.Func
+	f.Expr = wf.Expr.Expr
+	f.Offset = wf.Offset
+	f.Width = wf.Width
+	return nil
+}
+
+type aliasExprJSON struct {
+	Expr  typedExpr
+	Alias string
+}
+
+func (e *AliasExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(aliasExprJSON{
+		Expr:  typedExpr{Expr: e.Expr},
+		Alias: e.Alias,
+	})
+}
+
+func (e *AliasExpr) UnmarshalJSON(data []byte) error {
+	var ae aliasExprJSON
+	if err := json.Unmarshal(data, &ae); err != nil {
+		return err
+	}
+
+	e.Expr = ae.Expr.Expr
+	e.Alias = ae.Alias
+	return nil
+}
+
+type whenThenJSON struct {
+	When typedExpr
+	Then typedExpr
+}
+
+type caseExprJSON struct {
+	Cases []whenThenJSON
+	Else  typedExpr
+}
+
+func (e *CaseExpr) MarshalJSON() ([]byte, error) {
+	ce := caseExprJSON{
+		Cases: make([]whenThenJSON, 0, len(e.Cases)),
+		Else:  typedExpr{Expr: e.Else},
+	}
+	for _, wt := range e.Cases {
+		ce.Cases = append(ce.Cases, whenThenJSON{
+			When: typedExpr{Expr: wt.When},
+			Then: typedExpr{Expr: wt.Then},
+		})
+	}
+
+	return json.Marshal(ce)
+}
+
+func (e *CaseExpr) UnmarshalJSON(data []byte) error {
+	var ce caseExprJSON
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return err
+	}
+
+	e.Cases = make([]WhenThen, 0, len(ce.Cases))
+	for _, wt := range ce.Cases {
+		e.Cases = append(e.Cases, When(wt.When.Expr, wt.Then.Expr))
+	}
+	e.Else = ce.Else.Expr
+	return nil
+}
+
+type castExprJSON struct {
+	Expr typedExpr
+	Type string
+}
+
+// castTypes are the types that can be cast to, by name.
+var castTypes = map[string]arrow.DataType{
+	arrow.PrimitiveTypes.Int64.Name():   arrow.PrimitiveTypes.Int64,
+	arrow.PrimitiveTypes.Uint64.Name():  arrow.PrimitiveTypes.Uint64,
+	arrow.PrimitiveTypes.Float64.Name(): arrow.PrimitiveTypes.Float64,
+	arrow.BinaryTypes.String.Name():     arrow.BinaryTypes.String,
+	arrow.BinaryTypes.Binary.Name():     arrow.BinaryTypes.Binary,
+}
+
+func (e *CastExpr) MarshalJSON() ([]byte, error) {
+	if _, ok := castTypes[e.Type.Name()]; !ok {
+		return nil, fmt.Errorf("unsupported cast type %s", e.Type.Name())
+	}
+
+	return json.Marshal(castExprJSON{
+		Expr: typedExpr{Expr: e.Expr},
+		Type: e.Type.Name(),
+	})
+}
+
+func (e *CastExpr) UnmarshalJSON(data []byte) error {
+	var ce castExprJSON
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return err
+	}
+
+	t, ok := castTypes[ce.Type]
+	if !ok {
+		return fmt.Errorf("unsupported cast type %q", ce.Type)
+	}
+
+	e.Expr = ce.Expr.Expr
+	e.Type = t
+	return nil
+}
+
+type scalarFunctionExprJSON struct {
+	Func ScalarFunc
+	Args []typedExpr
+}
+
+func (f *ScalarFunctionExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(scalarFunctionExprJSON{
+		Func: f.Func,
+		Args: typedExprs(f.Args),
+	})
+}
+
+func (f *ScalarFunctionExpr) UnmarshalJSON(data []byte) error {
+	var sf scalarFunctionExprJSON
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return err
+	}
+
+	f.Func = sf.Func
+	f.Args = untypedExprs(sf.Args)
+	return nil
+}
+
+type durationTruncateExprJSON struct {
+	Expr     typedExpr
+	Duration time.Duration
+}
+
+func (d *DurationTruncateExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(durationTruncateExprJSON{
+		Expr:     typedExpr{Expr: d.Expr},
+		Duration: d.Duration,
+	})
+}
+
+func (d *DurationTruncateExpr) UnmarshalJSON(data []byte) error {
+	var dt durationTruncateExprJSON
+	if err := json.Unmarshal(data, &dt); err != nil {
+		return err
+	}
+
+	d.Expr = dt.Expr.Expr
+	d.Duration = dt.Duration
+	return nil
+}
+
+type coalesceExprJSON struct {
+	Exprs []typedExpr
+}
+
+func (c *CoalesceExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(coalesceExprJSON{
+		Exprs: typedExprs(c.Exprs),
+	})
+}
+
+func (c *CoalesceExpr) UnmarshalJSON(data []byte) error {
+	var ce coalesceExprJSON
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return err
+	}
+
+	c.Exprs = untypedExprs(ce.Exprs)
+	return nil
+}
+
+// callExprJSON only carries the name of the function, calls have to be
+// resolved again after decoding.
+type callExprJSON struct {
+	Func string
+	Args []typedExpr
+}
+
+func (c *CallExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(callExprJSON{
+		Func: c.Func,
+		Args: typedExprs(c.Args),
+	})
+}
+
+func (c *CallExpr) UnmarshalJSON(data []byte) error {
+	var ce callExprJSON
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return err
+	}
+
+	c.Func = ce.Func
+	c.Args = untypedExprs(ce.Args)
+	return nil
+}
+
+type tupleExprJSON struct {
+	Exprs []typedExpr
+}
+
+func (e *TupleExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tupleExprJSON{
+		Exprs: typedExprs(e.Exprs),
+	})
+}
+
+func (e *TupleExpr) UnmarshalJSON(data []byte) error {
+	var te tupleExprJSON
+	if err := json.Unmarshal(data, &te); err != nil {
+		return err
+	}
+
+	e.Exprs = untypedExprs(te.Exprs)
+	return nil
+}
+
+type betweenExprJSON struct {
+	Expr typedExpr
+	Low  typedExpr
+	High typedExpr
+}
+
+func (e *BetweenExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(betweenExprJSON{
+		Expr: typedExpr{Expr: e.Expr},
+		Low:  typedExpr{Expr: e.Low},
+		High: typedExpr{Expr: e.High},
+	})
+}
+
+func (e *BetweenExpr) UnmarshalJSON(data []byte) error {
+	var be betweenExprJSON
+	if err := json.Unmarshal(data, &be); err != nil {
+		return err
+	}
+
+	e.Expr = be.Expr.Expr
+	e.Low = be.Low.Expr
+	e.High = be.High.Expr
+	return nil
+}
+
+const (
+	literalTypeNull      = "null"
+	literalTypeBool      = "bool"
+	literalTypeInt64     = "int64"
+	literalTypeUint64    = "uint64"
+	literalTypeFloat64   = "float64"
+	literalTypeString    = "string"
+	literalTypeBinary    = "binary"
+	literalTypeTimestamp = "timestamp"
+)
+
+type literalJSON struct {
+	Type  string
+	Value json.RawMessage `json:",omitempty"`
+}
+
+func (e *LiteralExpr) MarshalJSON() ([]byte, error) {
+	var (
+		typ   string
+		value interface{}
+	)
+	switch v := e.Value.(type) {
+	case *scalar.Null:
+		typ = literalTypeNull
+	case *scalar.Boolean:
+		typ, value = literalTypeBool, v.Value
+	case *scalar.Int64:
+		typ, value = literalTypeInt64, v.Value
+	case *scalar.Uint64:
+		typ, value = literalTypeUint64, v.Value
+	case *scalar.Float64:
+		typ, value = literalTypeFloat64, v.Value
+	case *scalar.String:
+		typ, value = literalTypeString, string(v.Data())
+	case *scalar.Binary:
+		typ, value = literalTypeBinary, v.Data()
+	case *scalar.Timestamp:
+		if v.Type.(*arrow.TimestampType).Unit != arrow.Millisecond {
+			return nil, fmt.Errorf("unsupported timestamp unit %s", v.Type.(*arrow.TimestampType).Unit)
+		}
+		typ, value = literalTypeTimestamp, int64(v.Value)
+	default:
+		return nil, fmt.Errorf("unsupported literal type %T", e.Value)
+	}
+
+	l := literalJSON{Type: typ}
+	if value != nil {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		l.Value = data
+	}
+
+	return json.Marshal(l)
+}
+
+func (e *LiteralExpr) UnmarshalJSON(data []byte) error {
+	var l literalJSON
+	if err := json.Unmarshal(data, &l); err != nil {
+		return err
+	}
+
+	switch l.Type {
+	case literalTypeNull:
+		e.Value = scalar.ScalarNull
+		return nil
+	case literalTypeBool:
+		var v bool
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewBooleanScalar(v)
+	case literalTypeInt64:
+		var v int64
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewInt64Scalar(v)
+	case literalTypeUint64:
+		var v uint64
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewUint64Scalar(v)
+	case literalTypeFloat64:
+		var v float64
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewFloat64Scalar(v)
+	case literalTypeString:
+		var v string
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewStringScalar(v)
+	case literalTypeBinary:
+		var v []byte
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewBinaryScalar(memory.NewBufferBytes(v), arrow.BinaryTypes.Binary)
+	case literalTypeTimestamp:
+		var v int64
+		if err := json.Unmarshal(l.Value, &v); err != nil {
+			return err
+		}
+		e.Value = scalar.NewTimestampScalar(arrow.Timestamp(v), arrow.FixedWidthTypes.Timestamp_ms)
+	default:
+		return fmt.Errorf("unsupported literal type %q", l.Type)
+	}
+
+	return nil
+}
+
+func (c *RegexColumn) UnmarshalJSON(data []byte) error {
+	var rc struct {
+		Pattern string
+	}
+	if err := json.Unmarshal(data, &rc); err != nil {
+		return err
+	}
+
+	c.Pattern = rc.Pattern
+	c.compile()
+	return nil
+}
@@ -0,0 +1,100 @@
+package logicalplan
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExprJSONRoundTrip(t *testing.T) {
+	exprs := []Expr{
+		Col("a"),
+		DynCol("labels"),
+		Literal("x"),
+		Col("a").Eq(Literal(int64(1))),
+		Col("a").Add(Col("b")),
+		Not(Col("a").Eq(Literal("x"))),
+		Sum(Col("value")),
+		Sum(Col("value")).Alias("total"),
+		Sum(Col("value")).Where(Col("labels.code").RegexMatch("5..")),
+		Count(Col("value")),
+		Min(Col("value")),
+		Max(Col("timestamp")),
+		Avg(Col("value")),
+		ApproxCountDistinct(Col("labels.code")),
+		Quantiles(Col("value"), 0.5, 0.99),
+		StdDev(Col("value")),
+		LastBy(Col("value"), Col("timestamp")),
+		UserAggregation("merge", Col("stacktrace")),
+		Case(
+			When(Col("a").Gt(Literal(int64(1))), Literal("big")),
+		).WithElse(Literal("small")),
+		Case(When(Col("a").Gt(Literal(int64(1))), Literal("big"))),
+		Cast(Col("a"), arrow.PrimitiveTypes.Float64),
+		Concat(Col("a"), Literal("-"), Col("b")),
+		DurationTruncate(Col("timestamp"), time.Minute),
+		Coalesce(Col("labels.a"), Literal("none")),
+		Col("a").Between(Literal(int64(1)), Literal(int64(2))),
+		AllColumns(),
+		RegexCol("labels.region_.*"),
+		Tuple(Col("timestamp"), Col("a")).Gt(Tuple(Literal(int64(1)), Literal("x"))),
+		RowNumber(),
+		Lag(Col("value"), 1),
+		MovingAvg(Col("value"), 60).Alias("smoothed"),
+	}
+
+	for _, expr := range exprs {
+		t.Run(expr.Name(), func(t *testing.T) {
+			e, err := marshalExpr(expr)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(e)
+			require.NoError(t, err)
+
+			var decoded exprJSON
+			require.NoError(t, json.Unmarshal(data, &decoded))
+
+			res, err := unmarshalExpr(decoded)
+			require.NoError(t, err)
+			require.Equal(t, expr, res)
+		})
+	}
+}
+
+type customExpr struct {
+	Column
+}
+
+func TestRegisterExpr(t *testing.T) {
+	_, err := marshalExpr(&customExpr{Column{ColumnName: "a"}})
+	require.Error(t, err)
+
+	RegisterExpr("custom", func() Expr { return &customExpr{} })
+	require.Panics(t, func() {
+		RegisterExpr("custom", func() Expr { return &Column{} })
+	})
+
+	filter := &Filter{
+		Expr: &BinaryExpr{
+			Left:  &customExpr{Column{ColumnName: "a"}},
+			Op:    OpEq,
+			Right: Literal("b"),
+		},
+	}
+
+	data, err := json.Marshal(filter)
+	require.NoError(t, err)
+
+	var res Filter
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, filter.Expr, res.Expr)
+}
+
+func TestUnmarshalUnknownExpr(t *testing.T) {
+	var f Filter
+	err := json.Unmarshal([]byte(`{"ExprType":"unknown","Expr":{}}`), &f)
+	require.Error(t, err)
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/apache/arrow/go/v8/arrow"
@@ -25,6 +25,15 @@ type LogicalPlan struct {
 	Distinct    *Distinct
 	Projection  *Projection
 	Aggregation *Aggregation
+	Having      *Having
+	Window      *Window
+	OrderBy     *OrderBy
+	Limit       *Limit
+	Offset      *Offset
+	Join        *Join
+	Union       *Union
+	Sample      *Sample
+	Unpivot     *Unpivot
 }
 
 func (plan *LogicalPlan) String() string {
@@ -46,17 +55,60 @@ func (plan *LogicalPlan) string(indent int) string {
 		res = plan.Aggregation.String()
 	case plan.Distinct != nil:
 		res = plan.Distinct.String()
+	case plan.Having != nil:
+		res = plan.Having.String()
+	case plan.Window != nil:
+		res = plan.Window.String()
+	case plan.OrderBy != nil:
+		res = plan.OrderBy.String()
+	case plan.Limit != nil:
+		res = plan.Limit.String()
+	case plan.Offset != nil:
+		res = plan.Offset.String()
+	case plan.Join != nil:
+		res = plan.Join.String()
+	case plan.Union != nil:
+		res = plan.Union.String()
+	case plan.Sample != nil:
+		res = plan.Sample.String()
+	case plan.Unpivot != nil:
+		res = plan.Unpivot.String()
 	default:
 		res = "Unknown LogicalPlan"
 	}
 
 	res = strings.Repeat("  ", indent) + res
+	if plan.Join != nil {
+		res += "\n" + plan.Join.Right.string(indent+1)
+	}
+	if plan.Union != nil {
+		for _, p := range plan.Union.Plans {
+			res += "\n" + p.string(indent+1)
+		}
+	}
 	if plan.Input != nil {
 		res += "\n" + plan.Input.string(indent+1)
 	}
 	return res
 }
 
+// exprString returns the name of the expression, or <nil> if it isn't set.
+func exprString(e Expr) string {
+	if e == nil {
+		return "<nil>"
+	}
+	return e.Name()
+}
+
+// exprsString returns the names of the expressions.
+func exprsString(exprs []Expr) string {
+	names := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		names = append(names, exprString(e))
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
 // TableReader returns the table reader.
 func (plan *LogicalPlan) TableReader() TableReader {
 	if plan.TableScan != nil {
@@ -65,6 +117,10 @@ func (plan *LogicalPlan) TableReader() TableReader {
 	if plan.SchemaScan != nil {
 		return plan.SchemaScan.TableProvider.GetTable(plan.SchemaScan.TableName)
 	}
+	if plan.Union != nil && len(plan.Union.Plans) > 0 {
+		// The tables of a union have the same columns.
+		return plan.Union.Plans[0].TableReader()
+	}
 	if plan.Input != nil {
 		return plan.Input.TableReader()
 	}
@@ -80,6 +136,133 @@ func (plan *LogicalPlan) InputSchema() *dynparquet.Schema {
 	return nil
 }
 
+// Clone returns a deep copy of the plan. Table providers are shared with the
+// original plan.
+func (plan *LogicalPlan) Clone() *LogicalPlan {
+	if plan == nil {
+		return nil
+	}
+
+	res := &LogicalPlan{Input: plan.Input.Clone()}
+	switch {
+	case plan.SchemaScan != nil:
+		res.SchemaScan = plan.SchemaScan.Clone()
+	case plan.TableScan != nil:
+		res.TableScan = plan.TableScan.Clone()
+	case plan.Filter != nil:
+		res.Filter = plan.Filter.Clone()
+	case plan.Distinct != nil:
+		res.Distinct = plan.Distinct.Clone()
+	case plan.Projection != nil:
+		res.Projection = plan.Projection.Clone()
+	case plan.Aggregation != nil:
+		res.Aggregation = plan.Aggregation.Clone()
+	case plan.Having != nil:
+		res.Having = plan.Having.Clone()
+	case plan.Window != nil:
+		res.Window = plan.Window.Clone()
+	case plan.OrderBy != nil:
+		res.OrderBy = plan.OrderBy.Clone()
+	case plan.Limit != nil:
+		res.Limit = plan.Limit.Clone()
+	case plan.Offset != nil:
+		res.Offset = plan.Offset.Clone()
+	case plan.Join != nil:
+		res.Join = plan.Join.Clone()
+	case plan.Union != nil:
+		res.Union = plan.Union.Clone()
+	case plan.Sample != nil:
+		res.Sample = plan.Sample.Clone()
+	case plan.Unpivot != nil:
+		res.Unpivot = plan.Unpivot.Clone()
+	}
+	return res
+}
+
+// PlanRewriter rewrites logical plans bottom up.
+type PlanRewriter interface {
+	// RewritePlan is called for every node of a plan once its input has
+	// been rewritten and returns the node that replaces it. The node passed
+	// is a copy that may be modified. Returning the node's input removes the
+	// node from the plan.
+	RewritePlan(plan *LogicalPlan) *LogicalPlan
+}
+
+// Rewrite returns the plan rewritten by the rewriter. The plan itself is
+// never modified, the rewriter operates on a copy.
+func (plan *LogicalPlan) Rewrite(rewriter PlanRewriter) *LogicalPlan {
+	return plan.Clone().rewrite(rewriter)
+}
+
+func (plan *LogicalPlan) rewrite(rewriter PlanRewriter) *LogicalPlan {
+	if plan == nil {
+		return nil
+	}
+
+	plan.Input = plan.Input.rewrite(rewriter)
+	if plan.Join != nil {
+		plan.Join.Right = plan.Join.Right.rewrite(rewriter)
+	}
+	if plan.Union != nil {
+		for i, p := range plan.Union.Plans {
+			plan.Union.Plans[i] = p.rewrite(rewriter)
+		}
+	}
+	return rewriter.RewritePlan(plan)
+}
+
+// RewriteExprs returns the plan with all expressions of all its nodes
+// rewritten by the rewriter. The plan itself is never modified.
+func (plan *LogicalPlan) RewriteExprs(rewriter Rewriter) *LogicalPlan {
+	return plan.Rewrite(&exprPlanRewriter{rewriter: rewriter})
+}
+
+type exprPlanRewriter struct {
+	rewriter Rewriter
+}
+
+func (r *exprPlanRewriter) RewritePlan(plan *LogicalPlan) *LogicalPlan {
+	switch {
+	case plan.SchemaScan != nil:
+		plan.SchemaScan.PhysicalProjection = rewriteExprs(plan.SchemaScan.PhysicalProjection, r.rewriter)
+		plan.SchemaScan.Filter = rewriteExpr(plan.SchemaScan.Filter, r.rewriter)
+		plan.SchemaScan.Distinct = rewriteExprs(plan.SchemaScan.Distinct, r.rewriter)
+		plan.SchemaScan.Projection = rewriteExprs(plan.SchemaScan.Projection, r.rewriter)
+	case plan.TableScan != nil:
+		plan.TableScan.PhysicalProjection = rewriteExprs(plan.TableScan.PhysicalProjection, r.rewriter)
+		plan.TableScan.Filter = rewriteExpr(plan.TableScan.Filter, r.rewriter)
+		plan.TableScan.Distinct = rewriteExprs(plan.TableScan.Distinct, r.rewriter)
+		plan.TableScan.Projection = rewriteExprs(plan.TableScan.Projection, r.rewriter)
+	case plan.Filter != nil:
+		plan.Filter.Expr = rewriteExpr(plan.Filter.Expr, r.rewriter)
+	case plan.Distinct != nil:
+		plan.Distinct.Exprs = rewriteExprs(plan.Distinct.Exprs, r.rewriter)
+	case plan.Projection != nil:
+		plan.Projection.Exprs = rewriteExprs(plan.Projection.Exprs, r.rewriter)
+	case plan.Aggregation != nil:
+		plan.Aggregation.GroupExprs = rewriteExprs(plan.Aggregation.GroupExprs, r.rewriter)
+		plan.Aggregation.AggExprs = rewriteExprs(plan.Aggregation.AggExprs, r.rewriter)
+	case plan.Having != nil:
+		plan.Having.Expr = rewriteExpr(plan.Having.Expr, r.rewriter)
+	case plan.Window != nil:
+		plan.Window.PartitionBy = rewriteExprs(plan.Window.PartitionBy, r.rewriter)
+		plan.Window.OrderBy = rewriteExpr(plan.Window.OrderBy, r.rewriter)
+		plan.Window.Exprs = rewriteExprs(plan.Window.Exprs, r.rewriter)
+	case plan.OrderBy != nil:
+		for i, e := range plan.OrderBy.Exprs {
+			plan.OrderBy.Exprs[i].Expr = rewriteExpr(e.Expr, r.rewriter)
+		}
+	case plan.Join != nil:
+		plan.Join.LeftKeys = rewriteExprs(plan.Join.LeftKeys, r.rewriter)
+		plan.Join.RightKeys = rewriteExprs(plan.Join.RightKeys, r.rewriter)
+	case plan.Unpivot != nil:
+		if col, ok := rewriteExpr(plan.Unpivot.Column, r.rewriter).(*DynamicColumn); ok {
+			plan.Unpivot.Column = col
+		}
+	}
+	return plan
+}
+
 type PlanVisitor interface {
 	PreVisit(plan *LogicalPlan) bool
 	PostVisit(plan *LogicalPlan) bool
@@ -101,6 +284,9 @@ func (plan *LogicalPlan) Accept(visitor PlanVisitor) bool {
 	return visitor.PostVisit(plan)
 }
 
+// TableReader reads the records of a table. Its iterators should stop and
+// return the context's error once the context is cancelled, queries stop
+// passing on records once it is cancelled either way.
 type TableReader interface {
 	View(func(tx uint64) error) error
 	Iterator(
@@ -136,6 +322,54 @@ type TableReader interface {
 	Schema() *dynparquet.Schema
 }
 
+// ConcurrentTableReader is implemented by tables that can iterate over their
+// records concurrently, calling each of the callbacks from its own goroutine.
+type ConcurrentTableReader interface {
+	ConcurrentIterator(
+		ctx context.Context,
+		tx uint64,
+		pool memory.Allocator,
+		schema *arrow.Schema,
+		physicalProjection []Expr,
+		projection []Expr,
+		filter Expr,
+		distinctColumns []Expr,
+		callbacks []func(r arrow.Record) error,
+	) error
+}
+
+// SampledTableReader is implemented by tables that can iterate over a random
+// sample of their rows without reading the parts of the table none of whose
+// rows are sampled. The sample function is called with the number of rows
+// of each part before it's read and returns the sampled rows in ascending
+// order, the records only contain these rows.
+type SampledTableReader interface {
+	SampledIterator(
+		ctx context.Context,
+		tx uint64,
+		pool memory.Allocator,
+		schema *arrow.Schema,
+		physicalProjection []Expr,
+		projection []Expr,
+		filter Expr,
+		sample func(numRows int) []uint32,
+		callback func(r arrow.Record) error,
+	) error
+}
+
+// SortedTableReader is implemented by tables that can iterate over their rows
+// in the order of their sorting columns.
+type SortedTableReader interface {
+	SortedIterator(
+		ctx context.Context,
+		tx uint64,
+		pool memory.Allocator,
+		physicalProjection []Expr,
+		filter Expr,
+		callback func(r arrow.Record) error,
+	) error
+}
+
 type TableProvider interface {
 	GetTable(name string) TableReader
 }
@@ -157,14 +391,47 @@ type TableScan struct {
 
 	// Projection is the list of columns that are to be projected.
 	Projection []Expr
+
+	// Limit is the number of rows after which the table scan can stop
+	// reading, if it is greater than zero.
+	Limit int64
+
+	// Sample is the random sample of the rows that the table scan reads, all
+	// rows are read if it isn't set.
+	Sample *Sample
+}
+
+func (scan *TableScan) Clone() *TableScan {
+	return &TableScan{
+		TableProvider:      scan.TableProvider,
+		TableName:          scan.TableName,
+		PhysicalProjection: cloneExprs(scan.PhysicalProjection),
+		Filter:             cloneExpr(scan.Filter),
+		Distinct:           cloneExprs(scan.Distinct),
+		Projection:         cloneExprs(scan.Projection),
+		Limit:              scan.Limit,
+		Sample:             scan.Sample.Clone(),
+	}
 }
 
 func (scan *TableScan) String() string {
-	return "TableScan" +
+	res := "TableScan" +
 		" Table: " + scan.TableName +
-		" Projection: " + fmt.Sprint(scan.Projection) +
-		" Filter: " + fmt.Sprint(scan.Filter) +
-		" Distinct: " + fmt.Sprint(scan.Distinct)
+		" Projection: " + exprsString(scan.Projection) +
+		" Filter: " + exprString(scan.Filter) +
+		" Distinct: " + exprsString(scan.Distinct)
+	// The columns read and the limit and sample are only set if they were
+	// pushed down to the scan.
+	if len(scan.PhysicalProjection) > 0 {
+		res += " PhysicalProjection: " + exprsString(scan.PhysicalProjection)
+	}
+	if scan.Limit > 0 {
+		res += " Limit: " + strconv.FormatInt(scan.Limit, 10)
+	}
+	if scan.Sample != nil {
+		res += " " + scan.Sample.String()
+	}
+	return res
 }
 
 type SchemaScan struct {
@@ -186,8 +453,19 @@ type SchemaScan struct {
 	Projection []Expr
 }
 
+func (s *SchemaScan) Clone() *SchemaScan {
+	return &SchemaScan{
+		TableProvider:      s.TableProvider,
+		TableName:          s.TableName,
+		PhysicalProjection: cloneExprs(s.PhysicalProjection),
+		Filter:             cloneExpr(s.Filter),
+		Distinct:           cloneExprs(s.Distinct),
+		Projection:         cloneExprs(s.Projection),
+	}
+}
+
 func (s *SchemaScan) String() string {
-	return "SchemaScan"
+	return "SchemaScan Table: " + s.TableName
 }
 
 type Filter struct {
@@ -195,64 +473,273 @@ type Filter struct {
 }
 
 func (f *Filter) MarshalJSON() ([]byte, error) {
-	type filterJSON struct {
-		ExprType string
-		Expr     Expr
+	e, err := marshalExpr(f.Expr)
+	if err != nil {
+		return nil, err
 	}
-	return json.Marshal(filterJSON{
-		ExprType: reflect.TypeOf(f.Expr).String(),
-		Expr:     f.Expr,
-	})
+	return json.Marshal(e)
 }
 
 func (f *Filter) UnmarshalJSON(data []byte) error {
-	type filterType struct {
-		ExprType string
-		Expr     json.RawMessage
+	var e exprJSON
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
 	}
-	var ft filterType
-	err := json.Unmarshal(data, &ft)
+
+	expr, err := unmarshalExpr(e)
 	if err != nil {
 		return err
 	}
-	switch ft.ExprType {
-	case "*logicalplan.BinaryExpr":
-		var be BinaryExpr
-		err := json.Unmarshal(ft.Expr, &be)
-		if err != nil {
-			return err
-		}
-		f.Expr = &be
-	}
-
+	f.Expr = expr
 	return nil
 }
 
+func (f *Filter) Clone() *Filter {
+	return &Filter{Expr: cloneExpr(f.Expr)}
+}
+
 func (f *Filter) String() string {
-	return "Filter" + " Expr: " + fmt.Sprint(f.Expr)
+	return "Filter" + " Expr: " + exprString(f.Expr)
 }
 
 type Distinct struct {
 	Exprs []Expr
 }
 
+func (d *Distinct) Clone() *Distinct {
+	return &Distinct{Exprs: cloneExprs(d.Exprs)}
+}
+
 func (d *Distinct) String() string {
-	return "Distinct"
+	return "Distinct " + exprsString(d.Exprs)
 }
 
 type Projection struct {
 	Exprs []Expr
 }
 
+func (p *Projection) Clone() *Projection {
+	return &Projection{Exprs: cloneExprs(p.Exprs)}
+}
+
 func (p *Projection) String() string {
-	return "Projection"
+	return "Projection " + exprsString(p.Exprs)
 }
 
 type Aggregation struct {
 	GroupExprs []Expr
-	AggExpr    Expr
+	AggExprs   []Expr
+	// PreferHash is set by the CostBasedOptimizer if the aggregation has few
+	// enough groups for a hash aggregation to cost less than aggregating the
+	// rows in the order of the table's sorting columns.
+	PreferHash bool
+}
+
+func (a *Aggregation) Clone() *Aggregation {
+	return &Aggregation{
+		GroupExprs: cloneExprs(a.GroupExprs),
+		AggExprs:   cloneExprs(a.AggExprs),
+		PreferHash: a.PreferHash,
+	}
 }
 
 func (a *Aggregation) String() string {
-	return "Aggregation " + fmt.Sprint(a.AggExpr) + " Group: " + fmt.Sprint(a.GroupExprs)
+	return "Aggregation " + exprsString(a.AggExprs) + " Group: " + exprsString(a.GroupExprs)
+}
+
+// Having filters the output of the aggregation that is its input. Its
+// expression references the aggregation's result columns, such as
+// `sum(value)`, and its group columns.
+type Having struct {
+	Expr Expr
+}
+
+func (h *Having) Clone() *Having {
+	return &Having{Expr: cloneExpr(h.Expr)}
+}
+
+func (h *Having) String() string {
+	return "Having" + " Expr: " + exprString(h.Expr)
+}
+
+// Window computes window functions over the rows of its input. The rows are
+// partitioned by the partition expressions and each partition is ordered by
+// the order expression. All input rows are kept, the results of the window
+// functions are added as columns.
+type Window struct {
+	PartitionBy []Expr
+	OrderBy     Expr
+	Exprs       []Expr
+}
+
+func (w *Window) Clone() *Window {
+	return &Window{
+		PartitionBy: cloneExprs(w.PartitionBy),
+		OrderBy:     cloneExpr(w.OrderBy),
+		Exprs:       cloneExprs(w.Exprs),
+	}
+}
+
+func (w *Window) String() string {
+	return "Window " + exprsString(w.Exprs) + " Partition: " + exprsString(w.PartitionBy) + " Order: " + exprString(w.OrderBy)
+}
+
+// OrderBy sorts the rows of its input by the sort expressions. Rows that are
+// equal by the first expression are sorted by the second one and so on.
+type OrderBy struct {
+	Exprs []SortExpr
+}
+
+// SortExpr sorts rows by the values of Expr. Null values are sorted after all
+// other values in ascending order, and before them in descending order.
+type SortExpr struct {
+	Expr       Expr
+	Descending bool
+}
+
+// Asc sorts rows by the expression in ascending order.
+func Asc(expr Expr) SortExpr {
+	return SortExpr{Expr: expr}
+}
+
+// Desc sorts rows by the expression in descending order.
+func Desc(expr Expr) SortExpr {
+	return SortExpr{Expr: expr, Descending: true}
+}
+
+func (e SortExpr) String() string {
+	if e.Descending {
+		return e.Expr.Name() + " desc"
+	}
+	return e.Expr.Name() + " asc"
+}
+
+func (o *OrderBy) Clone() *OrderBy {
+	exprs := make([]SortExpr, 0, len(o.Exprs))
+	for _, e := range o.Exprs {
+		exprs = append(exprs, SortExpr{Expr: cloneExpr(e.Expr), Descending: e.Descending})
+	}
+	return &OrderBy{Exprs: exprs}
+}
+
+func (o *OrderBy) String() string {
+	return "OrderBy " + fmt.Sprint(o.Exprs)
+}
+
+// Limit passes on the first Count rows of its input.
+type Limit struct {
+	Count int64
+}
+
+func (l *Limit) Clone() *Limit {
+	return &Limit{Count: l.Count}
+}
+
+func (l *Limit) String() string {
+	return "Limit " + strconv.FormatInt(l.Count, 10)
+}
+
+// Offset skips the first Count rows of its input.
+type Offset struct {
+	Count int64
+}
+
+func (o *Offset) Clone() *Offset {
+	return &Offset{Count: o.Count}
+}
+
+func (o *Offset) String() string {
+	return "Offset " + strconv.FormatInt(o.Count, 10)
+}
+
+// Join combines each row of its input with the rows of the Right plan whose
+// RightKeys have the same values as the row's LeftKeys. Rows without a
+// matching row are dropped, as are rows with null keys. The result has the
+// columns of the input followed by the columns of the right plan, except its
+// keys and columns with the same name as a column of the input.
+type Join struct {
+	Right     *LogicalPlan
+	LeftKeys  []Expr
+	RightKeys []Expr
+	// BuildLeft is set by the CostBasedOptimizer if the input has fewer rows
+	// than the right plan, so a hash join builds its hash table from the
+	// rows of the input instead.
+	BuildLeft bool
+}
+
+func (j *Join) Clone() *Join {
+	return &Join{
+		Right:     j.Right.Clone(),
+		LeftKeys:  cloneExprs(j.LeftKeys),
+		RightKeys: cloneExprs(j.RightKeys),
+		BuildLeft: j.BuildLeft,
+	}
+}
+
+func (j *Join) String() string {
+	return "Join " + exprsString(j.LeftKeys) + " = " + exprsString(j.RightKeys)
+}
+
+// Union passes on the rows of all of its Plans, one plan after the other.
+// The plans must read tables with the same columns. When the plans are scans
+// of tables, the rows of all of them have the union of the dynamic columns
+// of the tables, rows of tables without a column are null.
+type Union struct {
+	Plans []*LogicalPlan
+}
+
+func (u *Union) Clone() *Union {
+	plans := make([]*LogicalPlan, 0, len(u.Plans))
+	for _, p := range u.Plans {
+		plans = append(plans, p.Clone())
+	}
+	return &Union{Plans: plans}
+}
+
+func (u *Union) String() string {
+	return "Union"
+}
+
+// Sample passes on a uniform random sample of the rows of its input. Each
+// row is passed on with the probability Fraction, independently of the other
+// rows. The rows are chosen by a random source seeded with Seed, or with a
+// random seed if it's zero.
+type Sample struct {
+	Fraction float64
+	Seed     int64
+}
+
+func (s *Sample) Clone() *Sample {
+	if s == nil {
+		return nil
+	}
+	return &Sample{Fraction: s.Fraction, Seed: s.Seed}
+}
+
+func (s *Sample) String() string {
+	return "Sample " + strconv.FormatFloat(s.Fraction, 'g', -1, 64)
+}
+
+// Unpivot turns the concrete columns of a dynamic column into rows. Each row
+// of its input results in a row for each of the concrete columns of the
+// dynamic Column that the row has a value for. The name of the concrete
+// column without the dynamic column's prefix is in the NameColumn of the
+// row, its value in the ValueColumn. The other columns of the input row are
+// kept as they are.
+type Unpivot struct {
+	Column      *DynamicColumn
+	NameColumn  string
+	ValueColumn string
+}
+
+func (u *Unpivot) Clone() *Unpivot {
+	return &Unpivot{
+		Column:      &DynamicColumn{ColumnName: u.Column.ColumnName},
+		NameColumn:  u.NameColumn,
+		ValueColumn: u.ValueColumn,
+	}
+}
+
+func (u *Unpivot) String() string {
+	return "Unpivot " + u.Column.Name() + " -> (" + u.NameColumn + ", " + u.ValueColumn + ")"
 }
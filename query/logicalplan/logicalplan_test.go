@@ -2,7 +2,9 @@ package logicalplan
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/memory"
@@ -111,3 +113,150 @@ func TestInputSchemaGetter(t *testing.T) {
 		Build()
 	require.Nil(t, plan.InputSchema())
 }
+
+func TestFilterJSONRoundTrip(t *testing.T) {
+	ts := time.UnixMilli(1650000000000)
+
+	filter := &Filter{
+		Expr: And(
+			Col("a").Eq(Literal("x")),
+			Col("b").Gt(Literal(int64(3))),
+			Col("c").LtEq(Literal(1.5)),
+			Col("d").Eq(Literal(true)),
+			Col("e").GtEq(Literal(ts)),
+			Col("f").NotEq(Literal([]byte{0x1, 0x2})),
+			Col("g").Eq(Literal(uint64(7))),
+			Col("h").Between(Literal(int64(1)), Literal(int64(5))),
+		),
+	}
+
+	data, err := json.Marshal(filter)
+	require.NoError(t, err)
+
+	var res Filter
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, filter.Expr.Name(), res.Expr.Name())
+
+	data2, err := json.Marshal(&res)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(data2))
+}
+
+func TestLiteralTime(t *testing.T) {
+	ts := time.UnixMilli(1650000000000)
+	l := Literal(ts)
+
+	dt, err := l.DataType(nil)
+	require.NoError(t, err)
+	require.Equal(t, arrow.FixedWidthTypes.Timestamp_ms, dt)
+}
+
+func TestExprClone(t *testing.T) {
+	exprs := []Expr{
+		Col("a"),
+		DynCol("labels"),
+		Literal("x"),
+		Col("a").Eq(Literal(int64(1))),
+		Not(Col("a").Eq(Literal("x"))),
+		Sum(Col("value")).Alias("total"),
+		Case(
+			When(Col("a").Gt(Literal(int64(1))), Literal("big")),
+		).WithElse(Literal("small")),
+		Case(When(Col("a").Gt(Literal(int64(1))), Literal("big"))),
+		Cast(Col("a"), arrow.PrimitiveTypes.Float64),
+		Concat(Col("a"), Literal("-"), Col("b")),
+		DurationTruncate(Col("timestamp"), time.Minute),
+		Coalesce(Col("labels.a"), Literal("none")),
+		Col("a").Between(Literal(int64(1)), Literal(int64(2))),
+	}
+
+	for _, expr := range exprs {
+		t.Run(expr.Name(), func(t *testing.T) {
+			clone := expr.Clone()
+			require.Equal(t, expr, clone)
+			require.NotSame(t, expr, clone)
+		})
+	}
+}
+
+// renameColumn rewrites all references to a column to reference a different
+// column instead.
+type renameColumn struct {
+	from, to string
+}
+
+func (r *renameColumn) PreRewrite(expr Expr) bool {
+	return true
+}
+
+func (r *renameColumn) PostRewrite(expr Expr) Expr {
+	if c, ok := expr.(*Column); ok && c.ColumnName == r.from {
+		c.ColumnName = r.to
+	}
+	return expr
+}
+
+func TestPlanRewriteExprs(t *testing.T) {
+	plan, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc"))).
+		Aggregate(
+			Sum(Col("value")).Alias("value_sum"),
+			Col("labels.test"),
+		).
+		Build()
+	require.NoError(t, err)
+
+	original := plan.Clone()
+	res := plan.RewriteExprs(&renameColumn{from: "labels.test", to: "labels.other"})
+
+	require.Equal(t, original, plan)
+	require.Equal(t, []Expr{Col("labels.other")}, res.Aggregation.GroupExprs)
+	require.Equal(t, Col("labels.other").Eq(Literal("abc")), res.Input.Filter.Expr)
+}
+
+// removeFilters removes all filters from a plan.
+type removeFilters struct{}
+
+func (r *removeFilters) RewritePlan(plan *LogicalPlan) *LogicalPlan {
+	if plan.Filter != nil {
+		return plan.Input
+	}
+	return plan
+}
+
+func TestPlanRewrite(t *testing.T) {
+	plan, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc"))).
+		Project(Col("labels.test")).
+		Build()
+	require.NoError(t, err)
+
+	res := plan.Rewrite(&removeFilters{})
+	require.NotNil(t, plan.Input.Filter)
+	require.NotNil(t, res.Projection)
+	require.NotNil(t, res.Input.TableScan)
+}
+
+func TestExpandTupleComparison(t *testing.T) {
+	left := Tuple(Col("a"), Col("b"))
+	right := Tuple(Literal(int64(1)), Literal(int64(2)))
+
+	expanded, ok := ExpandTupleComparison(left.GtEq(right))
+	require.True(t, ok)
+	require.Equal(t, Or(
+		Col("a").Gt(Literal(int64(1))),
+		And(Col("a").Eq(Literal(int64(1))), Col("b").GtEq(Literal(int64(2)))),
+	), expanded)
+
+	expanded, ok = ExpandTupleComparison(left.Eq(right))
+	require.True(t, ok)
+	require.Equal(t, And(
+		Col("a").Eq(Literal(int64(1))),
+		Col("b").Eq(Literal(int64(2))),
+	), expanded)
+
+	_, ok = ExpandTupleComparison(left.Gt(Tuple(Literal(int64(1)))))
+	require.False(t, ok)
+}
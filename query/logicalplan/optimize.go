@@ -1,14 +1,28 @@
 package logicalplan
 
+import (
+	"reflect"
+	"sort"
+
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+)
+
 type Optimizer interface {
 	Optimize(plan *LogicalPlan) *LogicalPlan
 }
 
 var DefaultOptimizers = []Optimizer{
+	&NotPushDown{},
+	&ConstantFolding{},
 	&PhysicalProjectionPushDown{},
 	&FilterPushDown{},
 	&DistinctPushDown{},
 	&ProjectionPushDown{},
+	&SamplePushDown{},
+	&LimitPushDown{},
+	&CostBasedOptimizer{},
 }
 
 // The PhysicalProjectionPushDown optimizer tries to push down the actual
@@ -42,7 +56,37 @@ func (p *PhysicalProjectionPushDown) optimize(plan *LogicalPlan, columnsUsedExpr
 		for _, expr := range plan.Aggregation.GroupExprs {
 			columnsUsedExprs = append(columnsUsedExprs, expr.ColumnsUsedExprs()...)
 		}
-		columnsUsedExprs = append(columnsUsedExprs, plan.Aggregation.AggExpr.ColumnsUsedExprs()...)
+		for _, expr := range plan.Aggregation.AggExprs {
+			columnsUsedExprs = append(columnsUsedExprs, expr.ColumnsUsedExprs()...)
+		}
+	case plan.Window != nil:
+		for _, expr := range plan.Window.PartitionBy {
+			columnsUsedExprs = append(columnsUsedExprs, expr.ColumnsUsedExprs()...)
+		}
+		columnsUsedExprs = append(columnsUsedExprs, plan.Window.OrderBy.ColumnsUsedExprs()...)
+		for _, expr := range plan.Window.Exprs {
+			columnsUsedExprs = append(columnsUsedExprs, expr.ColumnsUsedExprs()...)
+		}
+	case plan.OrderBy != nil:
+		for _, e := range plan.OrderBy.Exprs {
+			columnsUsedExprs = append(columnsUsedExprs, e.Expr.ColumnsUsedExprs()...)
+		}
+	case plan.Join != nil:
+		// The columns used after the join can be of either plan.
+		rightColumnsUsedExprs := append([]Expr{}, columnsUsedExprs...)
+		for _, expr := range plan.Join.RightKeys {
+			rightColumnsUsedExprs = append(rightColumnsUsedExprs, expr.ColumnsUsedExprs()...)
+		}
+		p.optimize(plan.Join.Right, rightColumnsUsedExprs)
+		for _, expr := range plan.Join.LeftKeys {
+			columnsUsedExprs = append(columnsUsedExprs, expr.ColumnsUsedExprs()...)
+		}
+	case plan.Union != nil:
+		for _, input := range plan.Union.Plans {
+			p.optimize(input, append([]Expr{}, columnsUsedExprs...))
+		}
+	case plan.Unpivot != nil:
+		columnsUsedExprs = append(columnsUsedExprs, plan.Unpivot.Column)
 	}
 
 	if plan.Input != nil {
@@ -60,11 +104,29 @@ func (p *PhysicalProjectionPushDown) optimize(plan *LogicalPlan, columnsUsedExpr
 type ProjectionPushDown struct{}
 
 func (p *ProjectionPushDown) Optimize(plan *LogicalPlan) *LogicalPlan {
+	// Projections after a window can use the columns the window adds, and
+	// the window needs all the rows' columns, so they can't be pushed down.
+	// The same is true for the columns an unpivot adds, and projections
+	// after an aggregation use the columns of its results.
+	if hasWindow(plan) || hasUnpivot(plan) || hasProjectedAggregation(plan) {
+		return plan
+	}
+	// Projections after a join can use the columns of the joined plan.
+	if hasJoin(plan) {
+		for input := plan; input != nil; input = input.Input {
+			if input.Join != nil {
+				input.Join.Right = p.Optimize(input.Join.Right)
+			}
+		}
+		return plan
+	}
+
 	// Don't perform the optimization if filters contain a column that projections do not.
 	// Otherwise we'll removed the columns we're filtering on before we filter.
 	projectColumns := projectionColumns(plan)
 	projectMap := map[string]bool{}
-	filterColumns := filterColumns(plan)
+	// The columns rows are sorted by are needed just like filtered ones.
+	filterColumns := append(filterColumns(plan), orderByColumns(plan)...)
 	for _, m := range projectColumns {
 		projectMap[m.Name()] = true
 	}
@@ -87,6 +149,50 @@ func (p *ProjectionPushDown) Optimize(plan *LogicalPlan) *LogicalPlan {
 	return insertProjection(plan, &Projection{Exprs: c.projections})
 }
 
+func hasWindow(plan *LogicalPlan) bool {
+	for ; plan != nil; plan = plan.Input {
+		if plan.Window != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUnpivot(plan *LogicalPlan) bool {
+	for ; plan != nil; plan = plan.Input {
+		if plan.Unpivot != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasProjectedAggregation returns whether the rows of an aggregation of the
+// plan are projected.
+func hasProjectedAggregation(plan *LogicalPlan) bool {
+	projected := false
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.Projection != nil, plan.Distinct != nil:
+			projected = true
+		case plan.Aggregation != nil:
+			if projected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasJoin(plan *LogicalPlan) bool {
+	for ; plan != nil; plan = plan.Input {
+		if plan.Join != nil {
+			return true
+		}
+	}
+	return false
+}
+
 type projectionCollector struct {
 	projections []Expr
 }
@@ -119,6 +225,23 @@ func filterColumns(plan *LogicalPlan) []Expr {
 	return append(columnsUsedExprs, filterColumns(plan.Input)...)
 }
 
+// orderByColumns returns all the column matchers that rows of a given plan
+// are sorted by.
+func orderByColumns(plan *LogicalPlan) []Expr {
+	if plan == nil {
+		return nil
+	}
+
+	columnsUsedExprs := []Expr{}
+	if plan.OrderBy != nil {
+		for _, e := range plan.OrderBy.Exprs {
+			columnsUsedExprs = append(columnsUsedExprs, e.Expr.ColumnsUsedExprs()...)
+		}
+	}
+
+	return append(columnsUsedExprs, orderByColumns(plan.Input)...)
+}
+
 // projectionColumns returns all the column matchers for projections in a given plan.
 func projectionColumns(plan *LogicalPlan) []Expr {
 	if plan == nil {
@@ -129,6 +252,11 @@ func projectionColumns(plan *LogicalPlan) []Expr {
 	switch {
 	case plan.Projection != nil:
 		for _, expr := range plan.Projection.Exprs {
+			// Computed and aliased projections don't pass the columns they
+			// use through, so they can't be filtered on after the projection.
+			if _, ok := expr.(*AliasExpr); ok || expr.Computed() {
+				continue
+			}
 			columnsUsedExprs = append(columnsUsedExprs, expr.ColumnsUsedExprs()...)
 		}
 	}
@@ -166,12 +294,88 @@ func insertProjection(cur *LogicalPlan, projection *Projection) *LogicalPlan {
 			Input:      cur,
 			Projection: projection,
 		}
+	case cur.Union != nil:
+		// The plans of a union are inputs of their own, the projection
+		// is applied to the rows of all of them.
+		return &LogicalPlan{
+			Input:      cur,
+			Projection: projection,
+		}
 	}
 
 	cur.Input = insertProjection(cur.Input, projection)
 	return cur
 }
 
+// Aggregations of at least minHashAggregationRows rows into at most
+// maxHashAggregationGroups groups are executed as hash aggregations, even if
+// they could aggregate their rows in the order of the table's sorting
+// columns. Reading that many rows in sorted order merges the rows of all
+// granules, which costs more than holding the state of that few groups.
+const (
+	minHashAggregationRows   = 1 << 16
+	maxHashAggregationGroups = 1 << 16
+)
+
+// The CostBasedOptimizer uses the statistics of the tables a query reads to
+// choose how it's executed. The predicates of filters are ordered by their
+// selectivity, so the most selective ones are evaluated first, aggregations
+// of many rows into few groups use a hash aggregation instead of reading
+// their rows in sorted order, and hash joins build their hash table from the
+// plan with fewer rows. Plans reading tables without statistics are left
+// unchanged. It modifies the plan in place.
+type CostBasedOptimizer struct{}
+
+func (p *CostBasedOptimizer) Optimize(plan *LogicalPlan) *LogicalPlan {
+	p.optimize(plan, newStatistics())
+	return plan
+}
+
+func (p *CostBasedOptimizer) optimize(plan *LogicalPlan, stats *statistics) {
+	switch {
+	case plan.TableScan != nil:
+		if table := stats.table(plan.TableScan); table != nil && plan.TableScan.Filter != nil {
+			plan.TableScan.Filter = orderPredicates(plan.TableScan.Filter, table)
+		}
+	case plan.Filter != nil:
+		if table := stats.input(plan.Input); table != nil {
+			plan.Filter.Expr = orderPredicates(plan.Filter.Expr, table)
+		}
+	case plan.Aggregation != nil:
+		rows, rowsOk := stats.rows(plan.Input)
+		groups, groupsOk := stats.groups(plan)
+		plan.Aggregation.PreferHash = rowsOk && groupsOk &&
+			rows >= minHashAggregationRows && groups <= maxHashAggregationGroups
+	case plan.Join != nil:
+		p.optimize(plan.Join.Right, stats)
+		left, leftOk := stats.rows(plan.Input)
+		right, rightOk := stats.rows(plan.Join.Right)
+		plan.Join.BuildLeft = leftOk && rightOk && left < right
+	case plan.Union != nil:
+		for _, input := range plan.Union.Plans {
+			p.optimize(input, stats)
+		}
+	}
+
+	if plan.Input != nil {
+		p.optimize(plan.Input, stats)
+	}
+}
+
+// orderPredicates orders the predicates of a conjunction by their
+// selectivity, the most selective first.
+func orderPredicates(expr Expr, stats *TableStatistics) Expr {
+	predicates := flattenJunction(OpAnd, expr, nil)
+	less := func(i, j int) bool {
+		return selectivity(predicates[i], stats) < selectivity(predicates[j], stats)
+	}
+	if sort.SliceIsSorted(predicates, less) {
+		return expr
+	}
+	sort.SliceStable(predicates, less)
+	return and(predicates)
+}
+
 // The FilterPushDown optimizer tries to push down the filters of a query down
 // to the actual physical table scan. This allows the table provider to make
 // smarter decisions about which pieces of data to load in the first place or
@@ -197,6 +401,25 @@ func (p *FilterPushDown) optimize(plan *LogicalPlan, exprs []Expr) {
 		}
 	case plan.Filter != nil:
 		exprs = append(exprs, plan.Filter.Expr)
+	case plan.Window != nil:
+		// Removing rows before the window would change the results of the
+		// window functions of the remaining rows.
+		exprs = nil
+	case plan.Limit != nil, plan.Offset != nil:
+		// Removing rows before a limit would change which rows are kept.
+		exprs = nil
+	case plan.Join != nil:
+		// Filters after the join can use the columns of the joined plan.
+		p.optimize(plan.Join.Right, nil)
+		exprs = nil
+	case plan.Unpivot != nil:
+		// Filters after the unpivot can use the columns it adds.
+		exprs = nil
+	case plan.Union != nil:
+		// Rows of all plans are filtered.
+		for _, input := range plan.Union.Plans {
+			p.optimize(input, append([]Expr{}, exprs...))
+		}
 	}
 
 	if plan.Input != nil {
@@ -227,9 +450,466 @@ func (p *DistinctPushDown) optimize(plan *LogicalPlan, distinctColumns []Expr) {
 		for _, expr := range plan.Distinct.Exprs {
 			distinctColumns = append(distinctColumns, expr)
 		}
+	case plan.Window != nil:
+		// The window needs all rows of its input.
+		distinctColumns = nil
+	case plan.Limit != nil, plan.Offset != nil:
+		// The limit counts all rows of its input.
+		distinctColumns = nil
+	case plan.Sample != nil:
+		// Each row is sampled, not each distinct row.
+		distinctColumns = nil
+	case plan.Unpivot != nil:
+		// The distinct columns can be the columns the unpivot adds.
+		distinctColumns = nil
+	case plan.Join != nil:
+		// Rows with the same distinct columns can join different rows.
+		p.optimize(plan.Join.Right, nil)
+		distinctColumns = nil
+	case plan.Union != nil:
+		for _, input := range plan.Union.Plans {
+			p.optimize(input, append([]Expr{}, distinctColumns...))
+		}
 	}
 
 	if plan.Input != nil {
 		p.optimize(plan.Input, distinctColumns)
 	}
 }
+
+// The LimitPushDown optimizer pushes the number of rows a limit needs down to
+// the table scan, so it can stop reading once it has read enough rows. This
+// is only possible if no step in between removes or reorders rows, so if
+// there are only projections and offsets, which increase the number of rows
+// needed. It modifies the plan in place.
+type LimitPushDown struct{}
+
+func (p *LimitPushDown) Optimize(plan *LogicalPlan) *LogicalPlan {
+	p.optimize(plan, 0)
+	return plan
+}
+
+func (p *LimitPushDown) optimize(plan *LogicalPlan, limit int64) {
+	switch {
+	case plan.TableScan != nil:
+		if limit > 0 {
+			plan.TableScan.Limit = limit
+		}
+	case plan.Limit != nil:
+		if limit == 0 || plan.Limit.Count < limit {
+			limit = plan.Limit.Count
+		}
+	case plan.Offset != nil:
+		if limit > 0 {
+			limit += plan.Offset.Count
+		}
+	case plan.Projection != nil:
+	case plan.Join != nil:
+		// Rows can join any number of rows, so the joined plan is read in
+		// full and the number of rows of the input needed isn't known.
+		p.optimize(plan.Join.Right, 0)
+		limit = 0
+	case plan.Union != nil:
+		// None of the plans has to read more rows than the limit needs.
+		for _, input := range plan.Union.Plans {
+			p.optimize(input, limit)
+		}
+	default:
+		limit = 0
+	}
+
+	if plan.Input != nil {
+		p.optimize(plan.Input, limit)
+	}
+}
+
+// The SamplePushDown optimizer pushes samples down to the table scan, so the
+// table can skip reading the parts of it none of whose rows are sampled. A
+// sample can only be pushed through steps that keep or drop each row on its
+// own, so through filters, projections and sorts, and into all plans of a
+// union. The sample is removed from the plan once it's pushed down.
+type SamplePushDown struct{}
+
+func (p *SamplePushDown) Optimize(plan *LogicalPlan) *LogicalPlan {
+	return p.optimize(plan)
+}
+
+func (p *SamplePushDown) optimize(plan *LogicalPlan) *LogicalPlan {
+	if plan == nil {
+		return nil
+	}
+
+	plan.Input = p.optimize(plan.Input)
+	if plan.Join != nil {
+		plan.Join.Right = p.optimize(plan.Join.Right)
+	}
+	if plan.Union != nil {
+		for i, input := range plan.Union.Plans {
+			plan.Union.Plans[i] = p.optimize(input)
+		}
+	}
+
+	if plan.Sample != nil && canPushSample(plan.Input) {
+		pushSample(plan.Input, plan.Sample)
+		return plan.Input
+	}
+	return plan
+}
+
+// canPushSample returns whether the sample can be pushed down to all table
+// scans of the plan. Scans that already sample their rows or that read
+// distinct rows can't sample them.
+func canPushSample(plan *LogicalPlan) bool {
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.Filter != nil, plan.Projection != nil, plan.OrderBy != nil:
+		case plan.TableScan != nil:
+			return plan.TableScan.Sample == nil && len(plan.TableScan.Distinct) == 0
+		case plan.Union != nil:
+			for _, input := range plan.Union.Plans {
+				if !canPushSample(input) {
+					return false
+				}
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func pushSample(plan *LogicalPlan, sample *Sample) {
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.TableScan != nil:
+			plan.TableScan.Sample = sample.Clone()
+		case plan.Union != nil:
+			for i, input := range plan.Union.Plans {
+				s := sample.Clone()
+				// The rows of the tables are sampled independently.
+				if s.Seed != 0 {
+					s.Seed += int64(i)
+				}
+				pushSample(input, s)
+			}
+		}
+	}
+}
+
+// The NotPushDown optimizer pushes negations of filter expressions as far
+// down the expression tree as possible. Negated conjunctions and disjunctions
+// are rewritten using De Morgan's laws, double negations are removed and
+// negated comparisons are replaced with their inverse comparison, so that the
+// storage layer can use the resulting expressions to prune data. Null values
+// match neither ordered comparisons nor regular expressions, nor their
+// inverses, so those are only inverted for columns of the scanned table that
+// can't be null. It modifies the plan in place.
+type NotPushDown struct{}
+
+func (p *NotPushDown) Optimize(plan *LogicalPlan) *LogicalPlan {
+	p.optimize(plan)
+	return plan
+}
+
+func (p *NotPushDown) optimize(plan *LogicalPlan) {
+	if plan.Filter != nil {
+		plan.Filter.Expr = pushDownNot(scannedSchema(plan.Input), plan.Filter.Expr)
+	}
+	if plan.Join != nil {
+		p.optimize(plan.Join.Right)
+	}
+	if plan.Union != nil {
+		for _, input := range plan.Union.Plans {
+			p.optimize(input)
+		}
+	}
+
+	if plan.Input != nil {
+		p.optimize(plan.Input)
+	}
+}
+
+// scannedSchema returns the schema of the table that the plan scans, if it
+// only scans and filters the table, so the columns of its rows are the
+// columns of the table.
+func scannedSchema(plan *LogicalPlan) *dynparquet.Schema {
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.Filter != nil:
+		case plan.TableScan != nil:
+			return plan.InputSchema()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func pushDownNot(s *dynparquet.Schema, expr Expr) Expr {
+	switch e := expr.(type) {
+	case *UnaryExpr:
+		if e.Op == OpNot {
+			return negate(s, e.Expr)
+		}
+	case *BinaryExpr:
+		switch e.Op {
+		case OpAnd, OpOr:
+			return &BinaryExpr{
+				Left:  pushDownNot(s, e.Left),
+				Op:    e.Op,
+				Right: pushDownNot(s, e.Right),
+			}
+		}
+	}
+
+	return expr
+}
+
+// negate returns the expression that is true whenever the given expression
+// is not.
+func negate(s *dynparquet.Schema, expr Expr) Expr {
+	switch e := expr.(type) {
+	case *UnaryExpr:
+		if e.Op == OpNot {
+			return pushDownNot(s, e.Expr)
+		}
+	case *BinaryExpr:
+		switch e.Op {
+		case OpAnd:
+			return &BinaryExpr{
+				Left:  negate(s, e.Left),
+				Op:    OpOr,
+				Right: negate(s, e.Right),
+			}
+		case OpOr:
+			return &BinaryExpr{
+				Left:  negate(s, e.Left),
+				Op:    OpAnd,
+				Right: negate(s, e.Right),
+			}
+		}
+
+		if op, ok := inverseComparison(e.Op); ok && (nullsInverted(e.Op) || !nullable(s, e.Left)) {
+			return &BinaryExpr{
+				Left:  e.Left,
+				Op:    op,
+				Right: e.Right,
+			}
+		}
+	}
+
+	return Not(pushDownNot(s, expr))
+}
+
+// nullsInverted returns whether null values match the inverse comparison of
+// the operator if they don't match the comparison. Nulls aren't equal to any
+// value, so they match inequalities.
+func nullsInverted(op Op) bool {
+	return op == OpEq || op == OpNotEq
+}
+
+// nullable returns whether the values of the expression can be null, which
+// is the case unless it's a column of the schema that isn't nullable.
+func nullable(s *dynparquet.Schema, expr Expr) bool {
+	col, ok := expr.(*Column)
+	if !ok || s == nil {
+		return true
+	}
+	def, found := s.FindColumn(col.ColumnName)
+	if !found {
+		return true
+	}
+	return def.Dynamic || def.StorageLayout.Optional()
+}
+
+func inverseComparison(op Op) (Op, bool) {
+	switch op {
+	case OpEq:
+		return OpNotEq, true
+	case OpNotEq:
+		return OpEq, true
+	case OpLt:
+		return OpGtEq, true
+	case OpLtEq:
+		return OpGt, true
+	case OpGt:
+		return OpLtEq, true
+	case OpGtEq:
+		return OpLt, true
+	case OpRegexMatch:
+		return OpRegexNotMatch, true
+	case OpRegexNotMatch:
+		return OpRegexMatch, true
+	default:
+		return OpUnknown, false
+	}
+}
+
+// The ConstantFolding optimizer simplifies filter expressions before they are
+// planned. Operations between literals are evaluated ahead of time, nested
+// conjunctions and disjunctions are flattened and their duplicate and
+// redundant operands removed, and filters that are always true are removed
+// from the plan altogether. It modifies the plan in place.
+type ConstantFolding struct{}
+
+func (p *ConstantFolding) Optimize(plan *LogicalPlan) *LogicalPlan {
+	return p.optimize(plan)
+}
+
+func (p *ConstantFolding) optimize(plan *LogicalPlan) *LogicalPlan {
+	if plan == nil {
+		return nil
+	}
+
+	plan.Input = p.optimize(plan.Input)
+	if plan.Join != nil {
+		plan.Join.Right = p.optimize(plan.Join.Right)
+	}
+	if plan.Union != nil {
+		for i, input := range plan.Union.Plans {
+			plan.Union.Plans[i] = p.optimize(input)
+		}
+	}
+
+	switch {
+	case plan.SchemaScan != nil && plan.SchemaScan.Filter != nil:
+		plan.SchemaScan.Filter = simplifyExpr(plan.SchemaScan.Filter)
+		if isBoolLiteral(plan.SchemaScan.Filter, true) {
+			plan.SchemaScan.Filter = nil
+		}
+	case plan.TableScan != nil && plan.TableScan.Filter != nil:
+		plan.TableScan.Filter = simplifyExpr(plan.TableScan.Filter)
+		if isBoolLiteral(plan.TableScan.Filter, true) {
+			plan.TableScan.Filter = nil
+		}
+	case plan.Filter != nil:
+		plan.Filter.Expr = simplifyExpr(plan.Filter.Expr)
+		if isBoolLiteral(plan.Filter.Expr, true) {
+			return plan.Input
+		}
+	}
+
+	return plan
+}
+
+// simplifyExpr returns a simplified copy of the expression. Sub-expressions
+// that can't be simplified are shared with the original expression.
+func simplifyExpr(expr Expr) Expr {
+	switch e := expr.(type) {
+	case *BinaryExpr:
+		switch e.Op {
+		case OpAnd:
+			return simplifyJunction(OpAnd, e)
+		case OpOr:
+			return simplifyJunction(OpOr, e)
+		}
+
+		left := simplifyExpr(e.Left)
+		right := simplifyExpr(e.Right)
+		if l, ok := left.(*LiteralExpr); ok {
+			if r, ok := right.(*LiteralExpr); ok {
+				if v, ok := foldLiterals(e.Op, l.Value, r.Value); ok {
+					return &LiteralExpr{Value: v}
+				}
+			}
+		}
+		if left == e.Left && right == e.Right {
+			return e
+		}
+		return &BinaryExpr{Left: left, Op: e.Op, Right: right}
+	case *UnaryExpr:
+		inner := simplifyExpr(e.Expr)
+		if e.Op == OpNot {
+			if l, ok := inner.(*LiteralExpr); ok {
+				if b, ok := l.Value.(*scalar.Boolean); ok && b.Valid {
+					return Literal(!b.Value)
+				}
+			}
+		}
+		if inner == e.Expr {
+			return e
+		}
+		return &UnaryExpr{Op: e.Op, Expr: inner}
+	case *BetweenExpr:
+		inner := simplifyExpr(e.Expr)
+		if l, ok := inner.(*LiteralExpr); ok {
+			if folded := simplifyExpr(And(
+				&BinaryExpr{Left: l, Op: OpGtEq, Right: e.Low},
+				&BinaryExpr{Left: l, Op: OpLtEq, Right: e.High},
+			)); isLiteral(folded) {
+				return folded
+			}
+		}
+		if inner == e.Expr {
+			return e
+		}
+		return &BetweenExpr{Expr: inner, Low: e.Low, High: e.High}
+	default:
+		return expr
+	}
+}
+
+// simplifyJunction flattens a chain of conjunctions or disjunctions into its
+// operands, simplifies and deduplicates them and rebuilds the chain. Operands
+// that don't affect the result are dropped, operands that determine the
+// result on their own replace the whole chain.
+func simplifyJunction(op Op, expr *BinaryExpr) Expr {
+	// True is the identity of a conjunction and false the identity of a
+	// disjunction. The opposite value decides either of them on its own.
+	identity := op == OpAnd
+
+	operands := []Expr{}
+	for _, operand := range flattenJunction(op, expr, nil) {
+		operand = simplifyExpr(operand)
+		if isBoolLiteral(operand, !identity) {
+			return Literal(!identity)
+		}
+		if isBoolLiteral(operand, identity) || containsExpr(operands, operand) {
+			continue
+		}
+		operands = append(operands, operand)
+	}
+
+	switch {
+	case len(operands) == 0:
+		return Literal(identity)
+	case op == OpAnd:
+		return and(operands)
+	default:
+		return or(operands)
+	}
+}
+
+// flattenJunction appends the operands of a chain of binary expressions with
+// the given operator, regardless of how the chain is nested.
+func flattenJunction(op Op, expr Expr, operands []Expr) []Expr {
+	if e, ok := expr.(*BinaryExpr); ok && e.Op == op {
+		operands = flattenJunction(op, e.Left, operands)
+		return flattenJunction(op, e.Right, operands)
+	}
+	return append(operands, expr)
+}
+
+func containsExpr(exprs []Expr, expr Expr) bool {
+	for _, e := range exprs {
+		if reflect.DeepEqual(e, expr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLiteral(expr Expr) bool {
+	_, ok := expr.(*LiteralExpr)
+	return ok
+}
+
+func isBoolLiteral(expr Expr, value bool) bool {
+	l, ok := expr.(*LiteralExpr)
+	if !ok {
+		return false
+	}
+	b, ok := l.Value.(*scalar.Boolean)
+	return ok && b.Valid && b.Value == value
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 
 	"github.com/apache/arrow/go/v8/arrow/scalar"
 	"github.com/stretchr/testify/require"
@@ -95,6 +96,150 @@ func TestOptimizeFilterPushDown(t *testing.T) {
 	)
 }
 
+func TestOptimizeLimitPushDown(t *testing.T) {
+	tableProvider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+	p, _ := (&Builder{}).
+		Scan(tableProvider, "table1").
+		Project(Col("value")).
+		Offset(5).
+		Limit(20).
+		Limit(10).
+		Build()
+
+	optimizer := &LimitPushDown{}
+	optimizer.Optimize(p)
+
+	require.Equal(t, &TableScan{
+		TableName:     "table1",
+		TableProvider: tableProvider,
+		// The offset rows are needed in addition to the limit.
+		Limit: 15,
+	},
+		// Limit -> Limit -> Offset -> Projection -> TableScan
+		p.Input.Input.Input.Input.TableScan,
+	)
+
+	// Rows that are filtered out don't count towards the limit.
+	p, _ = (&Builder{}).
+		Scan(tableProvider, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc"))).
+		Limit(10).
+		Build()
+
+	optimizer.Optimize(p)
+
+	require.Equal(t, &TableScan{
+		TableName:     "table1",
+		TableProvider: tableProvider,
+	},
+		// Limit -> Filter -> TableScan
+		p.Input.Input.TableScan,
+	)
+}
+
+func TestOptimizeSamplePushDown(t *testing.T) {
+	tableProvider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+	p, _ := (&Builder{}).
+		Scan(tableProvider, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc"))).
+		Project(Col("value")).
+		Sample(0.1).
+		Limit(10).
+		Build()
+
+	p = (&SamplePushDown{}).Optimize(p)
+
+	// The sample is removed once it's pushed down to the table scan.
+	require.Nil(t, p.Input.Sample)
+	require.Equal(t, &TableScan{
+		TableName:     "table1",
+		TableProvider: tableProvider,
+		Sample:        &Sample{Fraction: 0.1},
+	},
+		// Limit -> Projection -> Filter -> TableScan
+		p.Input.Input.Input.TableScan,
+	)
+
+	// Rows are sampled after they are aggregated.
+	p, _ = (&Builder{}).
+		Scan(tableProvider, "table1").
+		Aggregate(Sum(Col("value")), Col("labels.test")).
+		Sample(0.1).
+		Build()
+
+	p = (&SamplePushDown{}).Optimize(p)
+
+	require.Equal(t, &Sample{Fraction: 0.1}, p.Sample)
+	require.Nil(t, p.Input.Input.TableScan.Sample)
+}
+
+func TestOptimizeNotPushDown(t *testing.T) {
+	p, _ := (&Builder{}).
+		Scan(&mockTableProvider{schema: dynparquet.NewSampleSchema()}, "table1").
+		Filter(Not(And(
+			Col("labels.test").Eq(Literal("abc")),
+			Not(Col("labels.test2").RegexMatch("a.*")),
+			Or(
+				Col("timestamp").Gt(Literal(1)),
+				Not(Col("value").Lt(Literal(2))),
+			),
+		))).
+		Build()
+
+	p = (&NotPushDown{}).Optimize(p)
+
+	require.Equal(t, Or(
+		Col("labels.test").NotEq(Literal("abc")),
+		Col("labels.test2").RegexMatch("a.*"),
+		And(
+			Col("timestamp").LtEq(Literal(1)),
+			Col("value").Lt(Literal(2)),
+		),
+	), p.Filter.Expr)
+}
+
+func TestOptimizeNotPushDownNullable(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "count",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64, Nullable: true},
+		}, {
+			Name:          "method",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRING, Nullable: true},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	p, _ := (&Builder{}).
+		Scan(&mockTableProvider{schema: schema}, "table1").
+		Filter(Not(Or(
+			Col("count").Lt(Literal(2)),
+			Col("count").Eq(Literal(5)),
+			Col("method").RegexMatch("G.*"),
+			Col("timestamp").Lt(Literal(3)),
+		))).
+		Build()
+
+	p = (&NotPushDown{}).Optimize(p)
+
+	// Nulls match neither the comparisons of nullable columns nor their
+	// inverses, so those stay negated.
+	require.Equal(t, And(
+		Not(Col("count").Lt(Literal(2))),
+		Col("count").NotEq(Literal(5)),
+		Not(Col("method").RegexMatch("G.*")),
+		Col("timestamp").GtEq(Literal(3)),
+	), p.Filter.Expr)
+}
+
 func TestRemoveProjectionAtRoot(t *testing.T) {
 	p, _ := (&Builder{}).
 		Scan(&mockTableProvider{schema: dynparquet.NewSampleSchema()}, "table1").
@@ -159,6 +304,23 @@ func TestProjectionPushDown(t *testing.T) {
 	require.True(t, p.Input.Input.Projection == nil)
 }
 
+func TestProjectionPushDownOfAggregation(t *testing.T) {
+	p, err := (&Builder{}).
+		Scan(&mockTableProvider{schema: dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Count(Col("value"))).
+		Project(Col("count(value)").Alias("count")).
+		Build()
+	require.NoError(t, err)
+
+	// The projection uses the result of the aggregation, it can't be pushed
+	// below it.
+	p = (&ProjectionPushDown{}).Optimize(p)
+
+	require.NotNil(t, p.Projection)
+	require.NotNil(t, p.Input.Aggregation)
+	require.NotNil(t, p.Input.Input.TableScan)
+}
+
 func TestProjectionPushDownOfDistinct(t *testing.T) {
 	p, _ := (&Builder{}).
 		Scan(&mockTableProvider{schema: dynparquet.NewSampleSchema()}, "table1").
@@ -218,3 +380,194 @@ func TestAllOptimizers(t *testing.T) {
 		p.Input.Input.Input.TableScan,
 	)
 }
+
+func TestOptimizeConstantFolding(t *testing.T) {
+	tests := map[string]struct {
+		expr     Expr
+		expected Expr
+	}{
+		"fold arithmetic": {
+			expr:     Col("value").Gt(&BinaryExpr{Left: Literal(int64(1)), Op: OpAdd, Right: Literal(int64(2))}),
+			expected: Col("value").Gt(Literal(int64(3))),
+		},
+		"fold float arithmetic": {
+			expr:     Col("value").Gt(&BinaryExpr{Left: Literal(1.5), Op: OpMul, Right: Literal(int64(2))}),
+			expected: Col("value").Gt(Literal(3.0)),
+		},
+		"keep division by zero": {
+			expr:     Col("value").Gt(&BinaryExpr{Left: Literal(int64(1)), Op: OpDiv, Right: Literal(int64(0))}),
+			expected: Col("value").Gt(&BinaryExpr{Left: Literal(int64(1)), Op: OpDiv, Right: Literal(int64(0))}),
+		},
+		"remove true operands": {
+			expr: And(
+				Col("labels.a").Eq(Literal("a")),
+				&BinaryExpr{Left: Literal(int64(1)), Op: OpLt, Right: Literal(int64(2))},
+			),
+			expected: Col("labels.a").Eq(Literal("a")),
+		},
+		"false conjunction": {
+			expr: And(
+				Col("labels.a").Eq(Literal("a")),
+				&BinaryExpr{Left: Literal("a"), Op: OpEq, Right: Literal("b")},
+			),
+			expected: Literal(false),
+		},
+		"true disjunction": {
+			expr: Or(
+				Col("labels.a").Eq(Literal("a")),
+				&BinaryExpr{Left: Literal("abc"), Op: OpRegexMatch, Right: Literal("a.*")},
+			),
+			expected: Literal(true),
+		},
+		"remove false disjunction operands": {
+			expr: Or(
+				Col("labels.a").Eq(Literal("a")),
+				Not(Literal(true)),
+			),
+			expected: Col("labels.a").Eq(Literal("a")),
+		},
+		"flatten and deduplicate": {
+			expr: And(
+				And(Col("labels.a").Eq(Literal("a")), Col("labels.b").Eq(Literal("b"))),
+				And(Col("labels.a").Eq(Literal("a")), Col("labels.c").Eq(Literal("c"))),
+			),
+			expected: And(
+				Col("labels.a").Eq(Literal("a")),
+				Col("labels.b").Eq(Literal("b")),
+				Col("labels.c").Eq(Literal("c")),
+			),
+		},
+		"fold between": {
+			expr:     &BetweenExpr{Expr: Literal(int64(5)), Low: Literal(int64(1)), High: Literal(int64(10))},
+			expected: Literal(true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, simplifyExpr(test.expr))
+		})
+	}
+}
+
+func TestOptimizeConstantFoldingRemovesTrueFilter(t *testing.T) {
+	scan := &LogicalPlan{
+		TableScan: &TableScan{
+			TableName: "table1",
+		},
+	}
+	p := &LogicalPlan{
+		Projection: &Projection{
+			Exprs: []Expr{Col("labels.test")},
+		},
+		Input: &LogicalPlan{
+			Filter: &Filter{
+				Expr: &BinaryExpr{Left: Literal(int64(1)), Op: OpEq, Right: Literal(int64(1))},
+			},
+			Input: scan,
+		},
+	}
+
+	optimizer := &ConstantFolding{}
+	p = optimizer.Optimize(p)
+
+	// Projection -> TableScan
+	require.Equal(t, scan, p.Input)
+}
+
+func TestOptimizeJoin(t *testing.T) {
+	tableProvider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+	p, _ := (&Builder{}).
+		Scan(tableProvider, "table1").
+		Join(
+			(&Builder{}).
+				Scan(tableProvider, "table2").
+				Filter(Col("labels.team").Eq(Literal("abc"))),
+			Cols("labels.job"),
+			Cols("labels.name"),
+		).
+		Filter(Col("labels.team").Eq(Literal("def"))).
+		Project(Col("value")).
+		Build()
+
+	for _, optimizer := range DefaultOptimizers {
+		p = optimizer.Optimize(p)
+	}
+
+	// Filters after the join aren't pushed to either plan, and the plans read
+	// their keys in addition to the columns used after the join.
+	require.Equal(t, &TableScan{
+		TableName:     "table1",
+		TableProvider: tableProvider,
+		PhysicalProjection: []Expr{
+			&Column{ColumnName: "value"},
+			&Column{ColumnName: "labels.team"},
+			&Column{ColumnName: "labels.job"},
+		},
+	},
+		// Projection -> Filter -> Join -> TableScan
+		p.Input.Input.Input.TableScan,
+	)
+
+	right := p.Input.Input.Join.Right
+	require.Equal(t, &TableScan{
+		TableName:     "table2",
+		TableProvider: tableProvider,
+		PhysicalProjection: []Expr{
+			&Column{ColumnName: "value"},
+			&Column{ColumnName: "labels.team"},
+			&Column{ColumnName: "labels.name"},
+			&Column{ColumnName: "labels.team"},
+		},
+		Filter: &BinaryExpr{
+			Left: &Column{ColumnName: "labels.team"},
+			Op:   OpEq,
+			Right: &LiteralExpr{
+				Value: scalar.MakeScalar("abc"),
+			},
+		},
+	},
+		// Filter -> TableScan
+		right.Input.TableScan,
+	)
+}
+
+func TestOptimizeUnion(t *testing.T) {
+	tableProvider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+	p, _ := (&Builder{}).
+		Union(
+			(&Builder{}).Scan(tableProvider, "table1"),
+			(&Builder{}).Scan(tableProvider, "table2"),
+		).
+		Filter(Col("labels.job").Eq(Literal("api"))).
+		Project(Col("value")).
+		Limit(10).
+		Build()
+
+	for _, optimizer := range DefaultOptimizers {
+		p = optimizer.Optimize(p)
+	}
+
+	// The filter and the columns used are pushed to the scans of both
+	// tables.
+	// Limit -> Projection -> Filter -> Union
+	union := p.Input.Input.Input
+	require.NotNil(t, union.Union)
+	for i, name := range []string{"table1", "table2"} {
+		require.Equal(t, &TableScan{
+			TableName:     name,
+			TableProvider: tableProvider,
+			PhysicalProjection: []Expr{
+				&Column{ColumnName: "value"},
+				&Column{ColumnName: "labels.job"},
+			},
+			Filter: &BinaryExpr{
+				Left: &Column{ColumnName: "labels.job"},
+				Op:   OpEq,
+				Right: &LiteralExpr{
+					Value: scalar.MakeScalar("api"),
+				},
+			},
+		}, union.Union.Plans[i].TableScan)
+	}
+}
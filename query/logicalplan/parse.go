@@ -0,0 +1,264 @@
+package logicalplan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/scanner"
+)
+
+// ParseExpr parses a filter expression such as `labels.job="api" && value > 10`.
+//
+// Identifiers refer to columns, and may contain dots to refer to concrete
+// columns of dynamic columns. Literals are double-quoted strings, integers,
+// floats, true, false and null. Placeholders are written as $name. The
+// supported operators, from lowest to highest precedence, are:
+//
+//	||
+//	&&
+//	!
+//	= == != < <= > >= =~ !~
+//	+ -
+//	* / %
+//
+// Parentheses can be used for grouping.
+func ParseExpr(s string) (Expr, error) {
+	p := &parser{}
+	p.s.Init(strings.NewReader(s))
+	p.s.Mode = scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings
+	p.s.IsIdentRune = func(ch rune, i int) bool {
+		return ch == '_' || isLetter(ch) || (ch == '$' && i == 0) || ((isDigit(ch) || ch == '.') && i > 0)
+	}
+	p.s.Error = func(s *scanner.Scanner, msg string) {
+		if p.err == nil {
+			p.err = fmt.Errorf("%s: %s", s.Position, msg)
+		}
+	}
+	p.next()
+
+	expr := p.parseOr()
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok != scanner.EOF {
+		return nil, p.errorf("unexpected %s", p.s.TokenText())
+	}
+
+	return expr, nil
+}
+
+func isLetter(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+}
+
+func isDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+type parser struct {
+	s   scanner.Scanner
+	tok rune
+	err error
+}
+
+func (p *parser) next() {
+	p.tok = p.s.Scan()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if p.err == nil {
+		p.err = fmt.Errorf("%s: %s", p.s.Position, fmt.Sprintf(format, args...))
+	}
+	return p.err
+}
+
+// accept consumes the operator if it is next in the input. Operators are
+// scanned one character at a time, so two character operators are checked by
+// peeking at the following character.
+func (p *parser) accept(op string) bool {
+	if p.tok != rune(op[0]) {
+		return false
+	}
+	if len(op) == 2 {
+		if p.s.Peek() != rune(op[1]) {
+			return false
+		}
+		p.s.Next()
+	}
+	p.next()
+	return true
+}
+
+func (p *parser) parseOr() Expr {
+	exprs := []Expr{p.parseAnd()}
+	for p.err == nil && p.accept("||") {
+		exprs = append(exprs, p.parseAnd())
+	}
+	return Or(exprs...)
+}
+
+func (p *parser) parseAnd() Expr {
+	exprs := []Expr{p.parseNot()}
+	for p.err == nil && p.accept("&&") {
+		exprs = append(exprs, p.parseNot())
+	}
+	return And(exprs...)
+}
+
+func (p *parser) parseNot() Expr {
+	if p.tok == '!' && p.s.Peek() != '=' && p.s.Peek() != '~' {
+		p.next()
+		return Not(p.parseNot())
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = []struct {
+	token string
+	op    Op
+}{
+	// Longer operators must be tried first.
+	{"==", OpEq},
+	{"!=", OpNotEq},
+	{"=~", OpRegexMatch},
+	{"!~", OpRegexNotMatch},
+	{"<=", OpLtEq},
+	{">=", OpGtEq},
+	{"=", OpEq},
+	{"<", OpLt},
+	{">", OpGt},
+}
+
+func (p *parser) parseComparison() Expr {
+	left := p.parseAdditive()
+	if p.err != nil {
+		return nil
+	}
+
+	for _, c := range comparisonOps {
+		if p.accept(c.token) {
+			return &BinaryExpr{
+				Left:  left,
+				Op:    c.op,
+				Right: p.parseAdditive(),
+			}
+		}
+	}
+
+	return left
+}
+
+func (p *parser) parseAdditive() Expr {
+	left := p.parseMultiplicative()
+	for p.err == nil {
+		var op Op
+		switch {
+		case p.accept("+"):
+			op = OpAdd
+		case p.accept("-"):
+			op = OpSub
+		default:
+			return left
+		}
+		left = &BinaryExpr{Left: left, Op: op, Right: p.parseMultiplicative()}
+	}
+	return left
+}
+
+func (p *parser) parseMultiplicative() Expr {
+	left := p.parseOperand()
+	for p.err == nil {
+		var op Op
+		switch {
+		case p.accept("*"):
+			op = OpMul
+		case p.accept("/"):
+			op = OpDiv
+		case p.accept("%"):
+			op = OpMod
+		default:
+			return left
+		}
+		left = &BinaryExpr{Left: left, Op: op, Right: p.parseOperand()}
+	}
+	return left
+}
+
+func (p *parser) parseOperand() Expr {
+	if p.err != nil {
+		return nil
+	}
+
+	text := p.s.TokenText()
+	switch p.tok {
+	case '(':
+		p.next()
+		expr := p.parseOr()
+		if p.err == nil && !p.accept(")") {
+			p.errorf("expected ), got %s", p.s.TokenText())
+		}
+		return expr
+	case '-':
+		p.next()
+		if p.tok != scanner.Int && p.tok != scanner.Float {
+			p.errorf("expected number after -, got %s", p.s.TokenText())
+			return nil
+		}
+		return p.parseNumber("-" + p.s.TokenText())
+	case scanner.Int, scanner.Float:
+		return p.parseNumber(text)
+	case scanner.String:
+		p.next()
+		v, err := strconv.Unquote(text)
+		if err != nil {
+			p.errorf("invalid string %s: %v", text, err)
+			return nil
+		}
+		return Literal(v)
+	case scanner.Ident:
+		p.next()
+		switch {
+		case text == "true":
+			return Literal(true)
+		case text == "false":
+			return Literal(false)
+		case text == "null":
+			return Literal(nil)
+		case strings.HasPrefix(text, "$"):
+			if len(text) == 1 {
+				p.errorf("missing placeholder name")
+				return nil
+			}
+			return Placeholder(text[1:])
+		}
+		return Col(text)
+	case scanner.EOF:
+		p.errorf("unexpected end of expression")
+		return nil
+	default:
+		p.errorf("unexpected %s", text)
+		return nil
+	}
+}
+
+// parseNumber parses an integer as int64 and anything else as float64.
+func (p *parser) parseNumber(text string) Expr {
+	tok := p.tok
+	p.next()
+
+	if tok == scanner.Int {
+		v, err := strconv.ParseInt(text, 0, 64)
+		if err != nil {
+			p.errorf("invalid integer %s: %v", text, err)
+			return nil
+		}
+		return Literal(v)
+	}
+
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.errorf("invalid float %s: %v", text, err)
+		return nil
+	}
+	return Literal(v)
+}
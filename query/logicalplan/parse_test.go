@@ -0,0 +1,64 @@
+package logicalplan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpr(t *testing.T) {
+	tests := map[string]Expr{
+		`labels.job="api"`:    Col("labels.job").Eq(Literal("api")),
+		`labels.job == "api"`: Col("labels.job").Eq(Literal("api")),
+		`labels.job="api" && value > 10`: And(
+			Col("labels.job").Eq(Literal("api")),
+			Col("value").Gt(Literal(int64(10))),
+		),
+		`a = 1 || b = 2 && c = 3`: Or(
+			Col("a").Eq(Literal(int64(1))),
+			And(Col("b").Eq(Literal(int64(2))), Col("c").Eq(Literal(int64(3)))),
+		),
+		`(a = 1 || b = 2) && c = 3`: And(
+			Or(Col("a").Eq(Literal(int64(1))), Col("b").Eq(Literal(int64(2)))),
+			Col("c").Eq(Literal(int64(3))),
+		),
+		`!(a != -1.5)`:             Not(Col("a").NotEq(Literal(-1.5))),
+		`a =~ "api.*" && b !~ "x"`: And(Col("a").RegexMatch("api.*"), Col("b").RegexNotMatch("x")),
+		`a <= 1 && a >= 0 && a < 2`: And(
+			Col("a").LtEq(Literal(int64(1))),
+			Col("a").GtEq(Literal(int64(0))),
+			Col("a").Lt(Literal(int64(2))),
+		),
+		`a + b * 2 > 10`: Col("a").Add(Col("b").Mul(Literal(int64(2)))).Gt(Literal(int64(10))),
+		`flag = true`:    Col("flag").Eq(Literal(true)),
+		`a != null`:      Col("a").NotEq(Literal(nil)),
+		`ts >= $from`:    Col("ts").GtEq(Placeholder("from")),
+		`s = "a \"b\""`:  Col("s").Eq(Literal(`a "b"`)),
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			expr, err := ParseExpr(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, expr)
+		})
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`a =`,
+		`a = 1 b`,
+		`(a = 1`,
+		`a = "unterminated`,
+		`a = - b`,
+		`a = $`,
+		`a = 99999999999999999999`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseExpr(input)
+			require.Error(t, err)
+		})
+	}
+}
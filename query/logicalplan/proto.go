@@ -0,0 +1,703 @@
+package logicalplan
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+
+	logicalplanpb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/logicalplan/v1alpha1"
+)
+
+var opToProto = map[Op]logicalplanpb.Op{
+	OpEq:            logicalplanpb.Op_OP_EQ,
+	OpNotEq:         logicalplanpb.Op_OP_NOT_EQ,
+	OpLt:            logicalplanpb.Op_OP_LT,
+	OpLtEq:          logicalplanpb.Op_OP_LT_EQ,
+	OpGt:            logicalplanpb.Op_OP_GT,
+	OpGtEq:          logicalplanpb.Op_OP_GT_EQ,
+	OpRegexMatch:    logicalplanpb.Op_OP_REGEX_MATCH,
+	OpRegexNotMatch: logicalplanpb.Op_OP_REGEX_NOT_MATCH,
+	OpAnd:           logicalplanpb.Op_OP_AND,
+	OpAdd:           logicalplanpb.Op_OP_ADD,
+	OpSub:           logicalplanpb.Op_OP_SUB,
+	OpMul:           logicalplanpb.Op_OP_MUL,
+	OpDiv:           logicalplanpb.Op_OP_DIV,
+	OpMod:           logicalplanpb.Op_OP_MOD,
+	OpOr:            logicalplanpb.Op_OP_OR,
+	OpNot:           logicalplanpb.Op_OP_NOT,
+	OpStartsWith:    logicalplanpb.Op_OP_STARTS_WITH,
+	OpEndsWith:      logicalplanpb.Op_OP_ENDS_WITH,
+	OpContains:      logicalplanpb.Op_OP_CONTAINS,
+}
+
+var scalarFuncToProto = map[ScalarFunc]logicalplanpb.ScalarFunction_Type{
+	ScalarFuncLower:  logicalplanpb.ScalarFunction_TYPE_LOWER,
+	ScalarFuncUpper:  logicalplanpb.ScalarFunction_TYPE_UPPER,
+	ScalarFuncConcat: logicalplanpb.ScalarFunction_TYPE_CONCAT,
+	ScalarFuncLength: logicalplanpb.ScalarFunction_TYPE_LENGTH,
+	ScalarFuncAbs:    logicalplanpb.ScalarFunction_TYPE_ABS,
+	ScalarFuncRound:  logicalplanpb.ScalarFunction_TYPE_ROUND,
+	ScalarFuncFloor:  logicalplanpb.ScalarFunction_TYPE_FLOOR,
+	ScalarFuncCeil:   logicalplanpb.ScalarFunction_TYPE_CEIL,
+	ScalarFuncLog:    logicalplanpb.ScalarFunction_TYPE_LOG,
+}
+
+var aggFuncToProto = map[AggFunc]logicalplanpb.AggregationFunction_Type{
+	AggFuncSum:   logicalplanpb.AggregationFunction_TYPE_SUM,
+	AggFuncCount: logicalplanpb.AggregationFunction_TYPE_COUNT,
+	AggFuncMin:   logicalplanpb.AggregationFunction_TYPE_MIN,
+	AggFuncMax:   logicalplanpb.AggregationFunction_TYPE_MAX,
+
+	AggFuncApproxCountDistinct: logicalplanpb.AggregationFunction_TYPE_APPROX_COUNT_DISTINCT,
+	AggFuncQuantile:            logicalplanpb.AggregationFunction_TYPE_QUANTILE,
+	AggFuncQuantiles:           logicalplanpb.AggregationFunction_TYPE_QUANTILES,
+	AggFuncVariance:            logicalplanpb.AggregationFunction_TYPE_VARIANCE,
+	AggFuncStdDev:              logicalplanpb.AggregationFunction_TYPE_STDDEV,
+}
+
+var castTypeToProto = map[arrow.Type]logicalplanpb.DataType{
+	arrow.INT64:   logicalplanpb.DataType_DATA_TYPE_INT64,
+	arrow.UINT64:  logicalplanpb.DataType_DATA_TYPE_UINT64,
+	arrow.FLOAT64: logicalplanpb.DataType_DATA_TYPE_FLOAT64,
+	arrow.STRING:  logicalplanpb.DataType_DATA_TYPE_STRING,
+	arrow.BINARY:  logicalplanpb.DataType_DATA_TYPE_BINARY,
+}
+
+var (
+	opFromProto         = map[logicalplanpb.Op]Op{}
+	scalarFuncFromProto = map[logicalplanpb.ScalarFunction_Type]ScalarFunc{}
+	aggFuncFromProto    = map[logicalplanpb.AggregationFunction_Type]AggFunc{}
+	castTypeFromProto   = map[logicalplanpb.DataType]arrow.DataType{
+		logicalplanpb.DataType_DATA_TYPE_INT64:   arrow.PrimitiveTypes.Int64,
+		logicalplanpb.DataType_DATA_TYPE_UINT64:  arrow.PrimitiveTypes.Uint64,
+		logicalplanpb.DataType_DATA_TYPE_FLOAT64: arrow.PrimitiveTypes.Float64,
+		logicalplanpb.DataType_DATA_TYPE_STRING:  arrow.BinaryTypes.String,
+		logicalplanpb.DataType_DATA_TYPE_BINARY:  arrow.BinaryTypes.Binary,
+	}
+)
+
+func init() {
+	for k, v := range opToProto {
+		opFromProto[v] = k
+	}
+	for k, v := range scalarFuncToProto {
+		scalarFuncFromProto[v] = k
+	}
+	for k, v := range aggFuncToProto {
+		aggFuncFromProto[v] = k
+	}
+}
+
+// ToProto converts a logical plan to its protobuf representation.
+func ToProto(plan *LogicalPlan) (*logicalplanpb.PlanNode, error) {
+	if plan == nil {
+		return nil, nil
+	}
+
+	input, err := ToProto(plan.Input)
+	if err != nil {
+		return nil, err
+	}
+	node := &logicalplanpb.PlanNode{Input: input}
+
+	switch {
+	case plan.TableScan != nil:
+		scan, err := scanToProto(plan.TableScan.PhysicalProjection, plan.TableScan.Filter, plan.TableScan.Distinct, plan.TableScan.Projection)
+		if err != nil {
+			return nil, err
+		}
+		var sample *logicalplanpb.Sample
+		if plan.TableScan.Sample != nil {
+			sample = &logicalplanpb.Sample{
+				Fraction: plan.TableScan.Sample.Fraction,
+				Seed:     plan.TableScan.Sample.Seed,
+			}
+		}
+		node.Spec = &logicalplanpb.PlanNode_TableScan{TableScan: &logicalplanpb.TableScan{
+			TableName:          plan.TableScan.TableName,
+			PhysicalProjection: scan.PhysicalProjection,
+			Filter:             scan.Filter,
+			Distinct:           scan.Distinct,
+			Projection:         scan.Projection,
+			Limit:              plan.TableScan.Limit,
+			Sample:             sample,
+		}}
+	case plan.SchemaScan != nil:
+		scan, err := scanToProto(plan.SchemaScan.PhysicalProjection, plan.SchemaScan.Filter, plan.SchemaScan.Distinct, plan.SchemaScan.Projection)
+		if err != nil {
+			return nil, err
+		}
+		node.Spec = &logicalplanpb.PlanNode_SchemaScan{SchemaScan: &logicalplanpb.SchemaScan{
+			TableName:          plan.SchemaScan.TableName,
+			PhysicalProjection: scan.PhysicalProjection,
+			Filter:             scan.Filter,
+			Distinct:           scan.Distinct,
+			Projection:         scan.Projection,
+		}}
+	case plan.Filter != nil:
+		expr, err := ExprToProto(plan.Filter.Expr)
+		if err != nil {
+			return nil, err
+		}
+		node.Spec = &logicalplanpb.PlanNode_Filter{Filter: &logicalplanpb.Filter{Expr: expr}}
+	case plan.Distinct != nil:
+		exprs, err := exprsToProto(plan.Distinct.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		node.Spec = &logicalplanpb.PlanNode_Distinct{Distinct: &logicalplanpb.Distinct{Exprs: exprs}}
+	case plan.Projection != nil:
+		exprs, err := exprsToProto(plan.Projection.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		node.Spec = &logicalplanpb.PlanNode_Projection{Projection: &logicalplanpb.Projection{Exprs: exprs}}
+	case plan.Aggregation != nil:
+		groupExprs, err := exprsToProto(plan.Aggregation.GroupExprs)
+		if err != nil {
+			return nil, err
+		}
+		aggExprs, err := exprsToProto(plan.Aggregation.AggExprs)
+		if err != nil {
+			return nil, err
+		}
+		node.Spec = &logicalplanpb.PlanNode_Aggregation{Aggregation: &logicalplanpb.Aggregation{
+			GroupExprs: groupExprs,
+			AggExprs:   aggExprs,
+		}}
+	case plan.Limit != nil:
+		node.Spec = &logicalplanpb.PlanNode_Limit{Limit: &logicalplanpb.Limit{Count: plan.Limit.Count}}
+	case plan.Offset != nil:
+		node.Spec = &logicalplanpb.PlanNode_Offset{Offset: &logicalplanpb.Offset{Count: plan.Offset.Count}}
+	case plan.OrderBy != nil:
+		exprs := make([]*logicalplanpb.SortExpr, 0, len(plan.OrderBy.Exprs))
+		for _, e := range plan.OrderBy.Exprs {
+			expr, err := ExprToProto(e.Expr)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, &logicalplanpb.SortExpr{Expr: expr, Descending: e.Descending})
+		}
+		node.Spec = &logicalplanpb.PlanNode_OrderBy{OrderBy: &logicalplanpb.OrderBy{Exprs: exprs}}
+	default:
+		return nil, errors.New("unknown logical plan node")
+	}
+
+	return node, nil
+}
+
+// scanToProto converts the expressions shared by table and schema scans.
+func scanToProto(physicalProjection []Expr, filter Expr, distinct, projection []Expr) (*logicalplanpb.TableScan, error) {
+	var (
+		scan = &logicalplanpb.TableScan{}
+		err  error
+	)
+	if scan.PhysicalProjection, err = exprsToProto(physicalProjection); err != nil {
+		return nil, err
+	}
+	if scan.Filter, err = ExprToProto(filter); err != nil {
+		return nil, err
+	}
+	if scan.Distinct, err = exprsToProto(distinct); err != nil {
+		return nil, err
+	}
+	if scan.Projection, err = exprsToProto(projection); err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
+
+// FromProto converts the protobuf representation of a logical plan back to a
+// logical plan. Scans read their tables from the given table provider.
+func FromProto(node *logicalplanpb.PlanNode, provider TableProvider) (*LogicalPlan, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	input, err := FromProto(node.Input, provider)
+	if err != nil {
+		return nil, err
+	}
+	plan := &LogicalPlan{Input: input}
+
+	switch spec := node.Spec.(type) {
+	case *logicalplanpb.PlanNode_TableScan:
+		scan := &TableScan{
+			TableProvider: provider,
+			TableName:     spec.TableScan.TableName,
+			Limit:         spec.TableScan.Limit,
+		}
+		if sample := spec.TableScan.Sample; sample != nil {
+			scan.Sample = &Sample{Fraction: sample.Fraction, Seed: sample.Seed}
+		}
+		if scan.PhysicalProjection, err = exprsFromProto(spec.TableScan.PhysicalProjection); err != nil {
+			return nil, err
+		}
+		if scan.Filter, err = ExprFromProto(spec.TableScan.Filter); err != nil {
+			return nil, err
+		}
+		if scan.Distinct, err = exprsFromProto(spec.TableScan.Distinct); err != nil {
+			return nil, err
+		}
+		if scan.Projection, err = exprsFromProto(spec.TableScan.Projection); err != nil {
+			return nil, err
+		}
+		plan.TableScan = scan
+	case *logicalplanpb.PlanNode_SchemaScan:
+		scan := &SchemaScan{
+			TableProvider: provider,
+			TableName:     spec.SchemaScan.TableName,
+		}
+		if scan.PhysicalProjection, err = exprsFromProto(spec.SchemaScan.PhysicalProjection); err != nil {
+			return nil, err
+		}
+		if scan.Filter, err = ExprFromProto(spec.SchemaScan.Filter); err != nil {
+			return nil, err
+		}
+		if scan.Distinct, err = exprsFromProto(spec.SchemaScan.Distinct); err != nil {
+			return nil, err
+		}
+		if scan.Projection, err = exprsFromProto(spec.SchemaScan.Projection); err != nil {
+			return nil, err
+		}
+		plan.SchemaScan = scan
+	case *logicalplanpb.PlanNode_Filter:
+		expr, err := ExprFromProto(spec.Filter.Expr)
+		if err != nil {
+			return nil, err
+		}
+		plan.Filter = &Filter{Expr: expr}
+	case *logicalplanpb.PlanNode_Distinct:
+		exprs, err := exprsFromProto(spec.Distinct.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		plan.Distinct = &Distinct{Exprs: exprs}
+	case *logicalplanpb.PlanNode_Projection:
+		exprs, err := exprsFromProto(spec.Projection.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		plan.Projection = &Projection{Exprs: exprs}
+	case *logicalplanpb.PlanNode_Aggregation:
+		groupExprs, err := exprsFromProto(spec.Aggregation.GroupExprs)
+		if err != nil {
+			return nil, err
+		}
+		aggExprs, err := exprsFromProto(spec.Aggregation.AggExprs)
+		if err != nil {
+			return nil, err
+		}
+		// Aggregations of a single expression used to be serialized as
+		// agg_expr only.
+		if len(aggExprs) == 0 {
+			aggExpr, err := ExprFromProto(spec.Aggregation.AggExpr)
+			if err != nil {
+				return nil, err
+			}
+			aggExprs = []Expr{aggExpr}
+		}
+		plan.Aggregation = &Aggregation{
+			GroupExprs: groupExprs,
+			AggExprs:   aggExprs,
+		}
+	case *logicalplanpb.PlanNode_Limit:
+		plan.Limit = &Limit{Count: spec.Limit.Count}
+	case *logicalplanpb.PlanNode_Offset:
+		plan.Offset = &Offset{Count: spec.Offset.Count}
+	case *logicalplanpb.PlanNode_OrderBy:
+		exprs := make([]SortExpr, 0, len(spec.OrderBy.Exprs))
+		for _, e := range spec.OrderBy.Exprs {
+			expr, err := ExprFromProto(e.Expr)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, SortExpr{Expr: expr, Descending: e.Descending})
+		}
+		plan.OrderBy = &OrderBy{Exprs: exprs}
+	default:
+		return nil, fmt.Errorf("unsupported plan node %T", node.Spec)
+	}
+
+	return plan, nil
+}
+
+func exprsToProto(exprs []Expr) ([]*logicalplanpb.Expr, error) {
+	if exprs == nil {
+		return nil, nil
+	}
+
+	res := make([]*logicalplanpb.Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		e, err := ExprToProto(expr)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+func exprsFromProto(exprs []*logicalplanpb.Expr) ([]Expr, error) {
+	if exprs == nil {
+		return nil, nil
+	}
+
+	res := make([]Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		e, err := ExprFromProto(expr)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+// ExprToProto converts an expression to its protobuf representation.
+func ExprToProto(expr Expr) (*logicalplanpb.Expr, error) {
+	if expr == nil {
+		return nil, nil
+	}
+
+	switch e := expr.(type) {
+	case *Column:
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Column{
+			Column: &logicalplanpb.Column{Name: e.ColumnName},
+		}}, nil
+	case *DynamicColumn:
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_DynamicColumn{
+			DynamicColumn: &logicalplanpb.DynamicColumn{Name: e.ColumnName},
+		}}, nil
+	case *LiteralExpr:
+		l, err := literalToProto(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Literal{Literal: l}}, nil
+	case *BinaryExpr:
+		op, ok := opToProto[e.Op]
+		if !ok {
+			return nil, fmt.Errorf("unsupported binary operator %d", e.Op)
+		}
+		left, err := ExprToProto(e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := ExprToProto(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Binary{Binary: &logicalplanpb.BinaryExpr{
+			Left:  left,
+			Op:    op,
+			Right: right,
+		}}}, nil
+	case *UnaryExpr:
+		op, ok := opToProto[e.Op]
+		if !ok {
+			return nil, fmt.Errorf("unsupported unary operator %d", e.Op)
+		}
+		inner, err := ExprToProto(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Unary{Unary: &logicalplanpb.UnaryExpr{
+			Op:   op,
+			Expr: inner,
+		}}}, nil
+	case *AggregationFunction:
+		fn, ok := aggFuncToProto[e.Func]
+		if !ok {
+			return nil, fmt.Errorf("unsupported aggregation function %d", e.Func)
+		}
+		if e.Filter != nil {
+			return nil, errors.New("filtered aggregations can't be represented as protobuf")
+		}
+		inner, err := ExprToProto(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_AggregationFunction{AggregationFunction: &logicalplanpb.AggregationFunction{
+			Type:      fn,
+			Expr:      inner,
+			State:     e.State,
+			Quantiles: e.Quantiles,
+		}}}, nil
+	case *AliasExpr:
+		inner, err := ExprToProto(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Alias{Alias: &logicalplanpb.Alias{
+			Expr:  inner,
+			Alias: e.Alias,
+		}}}, nil
+	case *CaseExpr:
+		c := &logicalplanpb.CaseExpr{Cases: make([]*logicalplanpb.CaseExpr_WhenThen, 0, len(e.Cases))}
+		for _, wt := range e.Cases {
+			when, err := ExprToProto(wt.When)
+			if err != nil {
+				return nil, err
+			}
+			then, err := ExprToProto(wt.Then)
+			if err != nil {
+				return nil, err
+			}
+			c.Cases = append(c.Cases, &logicalplanpb.CaseExpr_WhenThen{When: when, Then: then})
+		}
+		var err error
+		if c.Else, err = ExprToProto(e.Else); err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_CaseExpr{CaseExpr: c}}, nil
+	case *CastExpr:
+		t, ok := castTypeToProto[e.Type.ID()]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cast type %s", e.Type.Name())
+		}
+		inner, err := ExprToProto(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Cast{Cast: &logicalplanpb.Cast{
+			Expr: inner,
+			Type: t,
+		}}}, nil
+	case *ScalarFunctionExpr:
+		fn, ok := scalarFuncToProto[e.Func]
+		if !ok {
+			return nil, fmt.Errorf("unsupported scalar function %d", e.Func)
+		}
+		args, err := exprsToProto(e.Args)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_ScalarFunction{ScalarFunction: &logicalplanpb.ScalarFunction{
+			Type: fn,
+			Args: args,
+		}}}, nil
+	case *DurationTruncateExpr:
+		inner, err := ExprToProto(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_DurationTruncate{DurationTruncate: &logicalplanpb.DurationTruncate{
+			Expr:     inner,
+			Duration: int64(e.Duration),
+		}}}, nil
+	case *CoalesceExpr:
+		exprs, err := exprsToProto(e.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Coalesce{Coalesce: &logicalplanpb.Coalesce{
+			Exprs: exprs,
+		}}}, nil
+	case *BetweenExpr:
+		inner, err := ExprToProto(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		low, err := ExprToProto(e.Low)
+		if err != nil {
+			return nil, err
+		}
+		high, err := ExprToProto(e.High)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalplanpb.Expr{Expr: &logicalplanpb.Expr_Between{Between: &logicalplanpb.Between{
+			Expr: inner,
+			Low:  low,
+			High: high,
+		}}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported expression type %T", expr)
+	}
+}
+
+// ExprFromProto converts the protobuf representation of an expression back to
+// an expression.
+func ExprFromProto(expr *logicalplanpb.Expr) (Expr, error) {
+	if expr == nil {
+		return nil, nil
+	}
+
+	switch e := expr.Expr.(type) {
+	case *logicalplanpb.Expr_Column:
+		return Col(e.Column.Name), nil
+	case *logicalplanpb.Expr_DynamicColumn:
+		return DynCol(e.DynamicColumn.Name), nil
+	case *logicalplanpb.Expr_Literal:
+		v, err := literalFromProto(e.Literal)
+		if err != nil {
+			return nil, err
+		}
+		return &LiteralExpr{Value: v}, nil
+	case *logicalplanpb.Expr_Binary:
+		op, ok := opFromProto[e.Binary.Op]
+		if !ok {
+			return nil, fmt.Errorf("unsupported binary operator %s", e.Binary.Op)
+		}
+		left, err := ExprFromProto(e.Binary.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := ExprFromProto(e.Binary.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &BinaryExpr{Left: left, Op: op, Right: right}, nil
+	case *logicalplanpb.Expr_Unary:
+		op, ok := opFromProto[e.Unary.Op]
+		if !ok {
+			return nil, fmt.Errorf("unsupported unary operator %s", e.Unary.Op)
+		}
+		inner, err := ExprFromProto(e.Unary.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Op: op, Expr: inner}, nil
+	case *logicalplanpb.Expr_AggregationFunction:
+		fn, ok := aggFuncFromProto[e.AggregationFunction.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported aggregation function %s", e.AggregationFunction.Type)
+		}
+		inner, err := ExprFromProto(e.AggregationFunction.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &AggregationFunction{
+			Func:      fn,
+			Expr:      inner,
+			State:     e.AggregationFunction.State,
+			Quantiles: e.AggregationFunction.Quantiles,
+		}, nil
+	case *logicalplanpb.Expr_Alias:
+		inner, err := ExprFromProto(e.Alias.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &AliasExpr{Expr: inner, Alias: e.Alias.Alias}, nil
+	case *logicalplanpb.Expr_CaseExpr:
+		c := &CaseExpr{Cases: make([]WhenThen, 0, len(e.CaseExpr.Cases))}
+		for _, wt := range e.CaseExpr.Cases {
+			when, err := ExprFromProto(wt.When)
+			if err != nil {
+				return nil, err
+			}
+			then, err := ExprFromProto(wt.Then)
+			if err != nil {
+				return nil, err
+			}
+			c.Cases = append(c.Cases, When(when, then))
+		}
+		var err error
+		if c.Else, err = ExprFromProto(e.CaseExpr.Else); err != nil {
+			return nil, err
+		}
+		return c, nil
+	case *logicalplanpb.Expr_Cast:
+		t, ok := castTypeFromProto[e.Cast.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cast type %s", e.Cast.Type)
+		}
+		inner, err := ExprFromProto(e.Cast.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return Cast(inner, t), nil
+	case *logicalplanpb.Expr_ScalarFunction:
+		fn, ok := scalarFuncFromProto[e.ScalarFunction.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported scalar function %s", e.ScalarFunction.Type)
+		}
+		args, err := exprsFromProto(e.ScalarFunction.Args)
+		if err != nil {
+			return nil, err
+		}
+		return &ScalarFunctionExpr{Func: fn, Args: args}, nil
+	case *logicalplanpb.Expr_DurationTruncate:
+		inner, err := ExprFromProto(e.DurationTruncate.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return DurationTruncate(inner, time.Duration(e.DurationTruncate.Duration)), nil
+	case *logicalplanpb.Expr_Coalesce:
+		exprs, err := exprsFromProto(e.Coalesce.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		return Coalesce(exprs...), nil
+	case *logicalplanpb.Expr_Between:
+		inner, err := ExprFromProto(e.Between.Expr)
+		if err != nil {
+			return nil, err
+		}
+		low, err := ExprFromProto(e.Between.Low)
+		if err != nil {
+			return nil, err
+		}
+		high, err := ExprFromProto(e.Between.High)
+		if err != nil {
+			return nil, err
+		}
+		return &BetweenExpr{Expr: inner, Low: low, High: high}, nil
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", expr.Expr)
+	}
+}
+
+func literalToProto(v scalar.Scalar) (*logicalplanpb.Literal, error) {
+	switch v := v.(type) {
+	case *scalar.Null:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_NullValue{NullValue: &logicalplanpb.Literal_Null{}}}, nil
+	case *scalar.Boolean:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_BoolValue{BoolValue: v.Value}}, nil
+	case *scalar.Int64:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_Int64Value{Int64Value: v.Value}}, nil
+	case *scalar.Uint64:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_Uint64Value{Uint64Value: v.Value}}, nil
+	case *scalar.Float64:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_Float64Value{Float64Value: v.Value}}, nil
+	case *scalar.String:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_StringValue{StringValue: string(v.Data())}}, nil
+	case *scalar.Binary:
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_BinaryValue{BinaryValue: v.Data()}}, nil
+	case *scalar.Timestamp:
+		if v.Type.(*arrow.TimestampType).Unit != arrow.Millisecond {
+			return nil, fmt.Errorf("unsupported timestamp unit %s", v.Type.(*arrow.TimestampType).Unit)
+		}
+		return &logicalplanpb.Literal{Value: &logicalplanpb.Literal_TimestampValue{TimestampValue: int64(v.Value)}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported literal type %T", v)
+	}
+}
+
+func literalFromProto(l *logicalplanpb.Literal) (scalar.Scalar, error) {
+	switch v := l.Value.(type) {
+	case *logicalplanpb.Literal_NullValue:
+		return scalar.ScalarNull, nil
+	case *logicalplanpb.Literal_BoolValue:
+		return scalar.NewBooleanScalar(v.BoolValue), nil
+	case *logicalplanpb.Literal_Int64Value:
+		return scalar.NewInt64Scalar(v.Int64Value), nil
+	case *logicalplanpb.Literal_Uint64Value:
+		return scalar.NewUint64Scalar(v.Uint64Value), nil
+	case *logicalplanpb.Literal_Float64Value:
+		return scalar.NewFloat64Scalar(v.Float64Value), nil
+	case *logicalplanpb.Literal_StringValue:
+		return scalar.NewStringScalar(v.StringValue), nil
+	case *logicalplanpb.Literal_BinaryValue:
+		return scalar.NewBinaryScalar(memory.NewBufferBytes(v.BinaryValue), arrow.BinaryTypes.Binary), nil
+	case *logicalplanpb.Literal_TimestampValue:
+		return scalar.NewTimestampScalar(arrow.Timestamp(v.TimestampValue), arrow.FixedWidthTypes.Timestamp_ms), nil
+	default:
+		return nil, fmt.Errorf("unsupported literal %T", l.Value)
+	}
+}
@@ -0,0 +1,135 @@
+package logicalplan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	logicalplanpb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/logicalplan/v1alpha1"
+)
+
+func TestExprProtoRoundTrip(t *testing.T) {
+	exprs := []Expr{
+		Col("a"),
+		DynCol("labels"),
+		Literal("x"),
+		Literal(int64(-1)),
+		Literal(uint64(1)),
+		Literal(1.5),
+		Literal(true),
+		Literal(time.UnixMilli(1000)),
+		Col("a").Eq(Literal(int64(1))),
+		Col("a").RegexMatch("x.*"),
+		Col("a").Add(Col("b")),
+		Not(Col("a").Eq(Literal("x"))),
+		Sum(Col("value")),
+		Sum(Col("value")).Alias("total"),
+		Count(Col("value")),
+		Min(Col("value")),
+		Max(Col("value")),
+		ApproxCountDistinct(Col("value")),
+		&AggregationFunction{Func: AggFuncApproxCountDistinct, Expr: Col("value"), State: true},
+		Quantile(Col("value"), 0.5),
+		Quantiles(Col("value"), 0.1, 0.9),
+		Variance(Col("value")),
+		StdDev(Col("value")),
+		Case(
+			When(Col("a").Gt(Literal(int64(1))), Literal("big")),
+		).WithElse(Literal("small")),
+		Cast(Col("a"), arrow.PrimitiveTypes.Float64),
+		Concat(Col("a"), Literal("-"), Col("b")),
+		DurationTruncate(Col("timestamp"), time.Minute),
+		Coalesce(Col("labels.a"), Literal("none")),
+		Col("a").Between(Literal(int64(1)), Literal(int64(2))),
+	}
+
+	for _, expr := range exprs {
+		t.Run(expr.Name(), func(t *testing.T) {
+			e, err := ExprToProto(expr)
+			require.NoError(t, err)
+
+			data, err := proto.Marshal(e)
+			require.NoError(t, err)
+
+			decoded := &logicalplanpb.Expr{}
+			require.NoError(t, proto.Unmarshal(data, decoded))
+
+			res, err := ExprFromProto(decoded)
+			require.NoError(t, err)
+			require.Equal(t, expr, res)
+		})
+	}
+}
+
+func TestExprToProtoUnsupported(t *testing.T) {
+	_, err := ExprToProto(&customExpr{Column{ColumnName: "a"}})
+	require.Error(t, err)
+
+	_, err = ExprToProto(Cast(Col("a"), arrow.FixedWidthTypes.Boolean))
+	require.Error(t, err)
+}
+
+func TestPlanProtoRoundTrip(t *testing.T) {
+	provider := &mockTableProvider{dynparquet.NewSampleSchema()}
+	plan, err := (&Builder{}).
+		Scan(provider, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc"))).
+		Aggregate(
+			Sum(Col("value")).Alias("value_sum"),
+			Col("stacktrace"),
+		).
+		Project(Col("stacktrace"), Col("value_sum")).
+		OrderBy(Desc(Col("value_sum")), Asc(Col("stacktrace"))).
+		Offset(1).
+		Limit(10).
+		Build()
+	require.NoError(t, err)
+
+	node, err := ToProto(plan)
+	require.NoError(t, err)
+
+	data, err := proto.Marshal(node)
+	require.NoError(t, err)
+
+	decoded := &logicalplanpb.PlanNode{}
+	require.NoError(t, proto.Unmarshal(data, decoded))
+
+	res, err := FromProto(decoded, provider)
+	require.NoError(t, err)
+	require.Equal(t, plan, res)
+}
+
+func TestFragmentProtoRoundTrip(t *testing.T) {
+	provider := &mockTableProvider{dynparquet.NewSampleSchema()}
+	plan, err := (&Builder{}).
+		Scan(provider, "table1").
+		Filter(Col("labels.test").Eq(Literal("abc"))).
+		Aggregations(
+			[]Expr{Sum(Col("value")), Count(Col("value")), Max(Col("timestamp"))},
+			Col("stacktrace"),
+		).
+		Build()
+	require.NoError(t, err)
+	// Scans can stop after a number of rows and sample their rows once the
+	// plan is optimized.
+	scan := plan.Input.Input.TableScan
+	scan.Limit = 10
+	scan.Sample = &Sample{Fraction: 0.5, Seed: 1}
+
+	node, err := ToProto(plan)
+	require.NoError(t, err)
+
+	data, err := proto.Marshal(node)
+	require.NoError(t, err)
+
+	decoded := &logicalplanpb.PlanNode{}
+	require.NoError(t, proto.Unmarshal(data, decoded))
+
+	res, err := FromProto(decoded, provider)
+	require.NoError(t, err)
+	require.Equal(t, plan, res)
+}
@@ -0,0 +1,87 @@
+package logicalplan
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// DefaultRegexpCacheSize is the number of compiled regular expressions kept
+// by CompileRegexp.
+const DefaultRegexpCacheSize = 1024
+
+var defaultRegexpCache = NewRegexpCache(DefaultRegexpCacheSize)
+
+// CompileRegexp compiles the pattern of a regex predicate. Compiled patterns
+// are kept in a process wide LRU cache so that queries repeatedly using the
+// same patterns don't have to recompile them.
+func CompileRegexp(pattern string) (*regexp.Regexp, error) {
+	return defaultRegexpCache.Compile(pattern)
+}
+
+// RegexpCache is an LRU cache of compiled regular expressions.
+type RegexpCache struct {
+	mtx   sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type regexpCacheEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// NewRegexpCache creates a cache holding up to size compiled regular
+// expressions.
+func NewRegexpCache(size int) *RegexpCache {
+	return &RegexpCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+// Compile returns the compiled pattern, compiling it only if it isn't cached
+// already. Patterns that fail to compile are not cached.
+func (c *RegexpCache) Compile(pattern string) (*regexp.Regexp, error) {
+	c.mtx.Lock()
+	if e, ok := c.items[pattern]; ok {
+		c.ll.MoveToFront(e)
+		re := e.Value.(*regexpCacheEntry).re
+		c.mtx.Unlock()
+		return re, nil
+	}
+	c.mtx.Unlock()
+
+	// Compile outside of the lock, a regexp.Regexp is safe for concurrent
+	// use so it doesn't matter if two callers compile the same pattern.
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.items[pattern]; ok {
+		c.ll.MoveToFront(e)
+		return e.Value.(*regexpCacheEntry).re, nil
+	}
+
+	c.items[pattern] = c.ll.PushFront(&regexpCacheEntry{pattern: pattern, re: re})
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*regexpCacheEntry).pattern)
+	}
+
+	return re, nil
+}
+
+// Len returns the number of cached regular expressions.
+func (c *RegexpCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.ll.Len()
+}
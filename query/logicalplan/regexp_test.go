@@ -0,0 +1,40 @@
+package logicalplan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexpCache(t *testing.T) {
+	c := NewRegexpCache(2)
+
+	a, err := c.Compile("a.*")
+	require.NoError(t, err)
+
+	a2, err := c.Compile("a.*")
+	require.NoError(t, err)
+	require.Same(t, a, a2)
+
+	_, err = c.Compile("(")
+	require.Error(t, err)
+	require.Equal(t, 1, c.Len())
+
+	_, err = c.Compile("b.*")
+	require.NoError(t, err)
+
+	// Using "a.*" makes "b.*" the least recently used pattern.
+	_, err = c.Compile("a.*")
+	require.NoError(t, err)
+
+	_, err = c.Compile("c.*")
+	require.NoError(t, err)
+	require.Equal(t, 2, c.Len())
+
+	a3, err := c.Compile("a.*")
+	require.NoError(t, err)
+	require.Same(t, a, a3)
+
+	_, ok := c.items["b.*"]
+	require.False(t, ok)
+}
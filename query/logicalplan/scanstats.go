@@ -0,0 +1,76 @@
+package logicalplan
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ScanStats counts the work tables do to read their rows. Tables add to the
+// ScanStats of the context their iterators are called with, if it has any.
+// Its methods can be called on nil, in which case nothing is counted.
+type ScanStats struct {
+	rowsScanned      int64
+	rowGroupsScanned int64
+	rowGroupsPruned  int64
+	bytesRead        int64
+}
+
+type scanStatsKey struct{}
+
+// WithScanStats returns a context whose table scans are counted by the stats.
+func WithScanStats(ctx context.Context, stats *ScanStats) context.Context {
+	return context.WithValue(ctx, scanStatsKey{}, stats)
+}
+
+// ScanStatsFromContext returns the stats scans of the context are counted by,
+// or nil if they aren't counted.
+func ScanStatsFromContext(ctx context.Context) *ScanStats {
+	stats, _ := ctx.Value(scanStatsKey{}).(*ScanStats)
+	return stats
+}
+
+// AddRowGroup counts a row group that is read and the number of its rows.
+func (s *ScanStats) AddRowGroup(rows int64) {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.rowsScanned, rows)
+	atomic.AddInt64(&s.rowGroupsScanned, 1)
+}
+
+// AddPrunedRowGroup counts a row group that isn't read, because none of its
+// rows match the filter of the scan.
+func (s *ScanStats) AddPrunedRowGroup() {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.rowGroupsPruned, 1)
+}
+
+// AddBytesRead counts bytes that are read from object storage.
+func (s *ScanStats) AddBytesRead(n int64) {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.bytesRead, n)
+}
+
+// RowsScanned returns the number of rows of the row groups that were read.
+func (s *ScanStats) RowsScanned() int64 {
+	return atomic.LoadInt64(&s.rowsScanned)
+}
+
+// RowGroupsScanned returns the number of row groups that were read.
+func (s *ScanStats) RowGroupsScanned() int64 {
+	return atomic.LoadInt64(&s.rowGroupsScanned)
+}
+
+// RowGroupsPruned returns the number of row groups that weren't read.
+func (s *ScanStats) RowGroupsPruned() int64 {
+	return atomic.LoadInt64(&s.rowGroupsPruned)
+}
+
+// BytesRead returns the number of bytes read from object storage.
+func (s *ScanStats) BytesRead() int64 {
+	return atomic.LoadInt64(&s.bytesRead)
+}
@@ -0,0 +1,274 @@
+package logicalplan
+
+import (
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// TableStatistics are estimates of the rows of a table, which the
+// CostBasedOptimizer uses to choose how the plans reading the table are
+// executed.
+type TableStatistics struct {
+	// NumRows is the number of rows of the table.
+	NumRows int64
+	// Columns are the statistics of the columns of the table by their names.
+	Columns map[string]ColumnStatistics
+}
+
+// ColumnStatistics are estimates of the values of a column of a table.
+type ColumnStatistics struct {
+	// DistinctCount is the number of distinct values of the column.
+	DistinctCount int64
+	// Min and Max are the least and greatest values of the column, they are
+	// nil if they aren't known.
+	Min scalar.Scalar
+	Max scalar.Scalar
+}
+
+// StatisticsTableReader is implemented by tables that can estimate the
+// statistics of their rows visible to a transaction.
+type StatisticsTableReader interface {
+	Statistics(tx uint64) (*TableStatistics, error)
+}
+
+const (
+	// The selectivities of predicates whose selectivity can't be estimated
+	// from the statistics of the columns they compare.
+	equalSelectivity   = 0.1
+	rangeSelectivity   = 1.0 / 3
+	defaultSelectivity = 0.5
+)
+
+// statistics returns the statistics of the tables of a plan, each table's
+// statistics are only read once.
+type statistics struct {
+	tables map[*TableScan]*TableStatistics
+}
+
+func newStatistics() *statistics {
+	return &statistics{tables: map[*TableScan]*TableStatistics{}}
+}
+
+// table returns the statistics of the table the scan reads, or nil if the
+// table can't estimate them.
+func (s *statistics) table(scan *TableScan) *TableStatistics {
+	if stats, ok := s.tables[scan]; ok {
+		return stats
+	}
+
+	var stats *TableStatistics
+	table := scan.TableProvider.GetTable(scan.TableName)
+	if statsTable, ok := table.(StatisticsTableReader); ok {
+		err := table.View(func(tx uint64) error {
+			var err error
+			stats, err = statsTable.Statistics(tx)
+			return err
+		})
+		if err != nil {
+			stats = nil
+		}
+	}
+	s.tables[scan] = stats
+	return stats
+}
+
+// input returns the statistics of the table the plan reads its rows from if
+// it only reads a single table, or nil.
+func (s *statistics) input(plan *LogicalPlan) *TableStatistics {
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.TableScan != nil:
+			return s.table(plan.TableScan)
+		case plan.Union != nil:
+			return nil
+		}
+	}
+	return nil
+}
+
+// rows returns the estimated number of rows of the plan, or false if it
+// can't be estimated.
+func (s *statistics) rows(plan *LogicalPlan) (float64, bool) {
+	switch {
+	case plan.TableScan != nil:
+		stats := s.table(plan.TableScan)
+		if stats == nil {
+			return 0, false
+		}
+		rows := float64(stats.NumRows)
+		if plan.TableScan.Filter != nil {
+			rows *= selectivity(plan.TableScan.Filter, stats)
+		}
+		if limit := plan.TableScan.Limit; limit > 0 && rows > float64(limit) {
+			rows = float64(limit)
+		}
+		return rows, true
+	case plan.Union != nil:
+		sum := 0.0
+		for _, p := range plan.Union.Plans {
+			rows, ok := s.rows(p)
+			if !ok {
+				return 0, false
+			}
+			sum += rows
+		}
+		return sum, true
+	case plan.Limit != nil:
+		rows, ok := s.rows(plan.Input)
+		if ok && rows > float64(plan.Limit.Count) {
+			rows = float64(plan.Limit.Count)
+		}
+		return rows, ok
+	case plan.Aggregation != nil:
+		rows, ok := s.rows(plan.Input)
+		if !ok {
+			return 0, false
+		}
+		if groups, ok := s.groups(plan); ok && groups < rows {
+			return groups, true
+		}
+		return rows, true
+	case plan.Filter != nil, plan.Projection != nil, plan.OrderBy != nil, plan.Window != nil:
+		// Filters are pushed down to the scan, whose estimate includes
+		// them.
+		return s.rows(plan.Input)
+	default:
+		return 0, false
+	}
+}
+
+// groups returns the estimated number of groups of the aggregation, or
+// false if it can't be estimated.
+func (s *statistics) groups(plan *LogicalPlan) (float64, bool) {
+	stats := s.input(plan.Input)
+	if stats == nil {
+		return 0, false
+	}
+
+	groups := 1.0
+	for _, e := range plan.Aggregation.GroupExprs {
+		col, ok := e.(*Column)
+		if !ok {
+			return 0, false
+		}
+		colStats, ok := stats.Columns[col.ColumnName]
+		if !ok || colStats.DistinctCount <= 0 {
+			return 0, false
+		}
+		groups *= float64(colStats.DistinctCount)
+	}
+	if rows := float64(stats.NumRows); groups > rows {
+		groups = rows
+	}
+	return groups, true
+}
+
+// selectivity returns the estimated fraction of the rows of the table that
+// match the filter.
+func selectivity(expr Expr, stats *TableStatistics) float64 {
+	switch e := expr.(type) {
+	case *BinaryExpr:
+		switch e.Op {
+		case OpAnd:
+			return selectivity(e.Left, stats) * selectivity(e.Right, stats)
+		case OpOr:
+			left, right := selectivity(e.Left, stats), selectivity(e.Right, stats)
+			return left + right - left*right
+		case OpEq:
+			return equalitySelectivity(e, stats)
+		case OpNotEq:
+			return 1 - equalitySelectivity(e, stats)
+		case OpLt, OpLtEq, OpGt, OpGtEq:
+			return comparisonSelectivity(e, stats)
+		}
+	case *UnaryExpr:
+		if e.Op == OpNot {
+			return 1 - selectivity(e.Expr, stats)
+		}
+	case *LiteralExpr:
+		if b, ok := e.Value.(*scalar.Boolean); ok && b.Valid {
+			if b.Value {
+				return 1
+			}
+			return 0
+		}
+	}
+	return defaultSelectivity
+}
+
+// columnLiteral returns the statistics of the column and the literal it's
+// compared with, if the expression compares a column with a literal.
+func columnLiteral(e *BinaryExpr, stats *TableStatistics) (ColumnStatistics, scalar.Scalar, bool) {
+	col, ok := e.Left.(*Column)
+	if !ok {
+		return ColumnStatistics{}, nil, false
+	}
+	lit, ok := e.Right.(*LiteralExpr)
+	if !ok {
+		return ColumnStatistics{}, nil, false
+	}
+	colStats, ok := stats.Columns[col.ColumnName]
+	return colStats, lit.Value, ok
+}
+
+func equalitySelectivity(e *BinaryExpr, stats *TableStatistics) float64 {
+	colStats, value, ok := columnLiteral(e, stats)
+	if !ok || colStats.DistinctCount <= 0 {
+		return equalSelectivity
+	}
+
+	// Values outside of the range of the column don't match any rows.
+	v, ok := scalarFloat(value)
+	min, minOk := scalarFloat(colStats.Min)
+	max, maxOk := scalarFloat(colStats.Max)
+	if ok && minOk && maxOk && (v < min || v > max) {
+		return 0
+	}
+	return 1 / float64(colStats.DistinctCount)
+}
+
+// comparisonSelectivity assumes the values of the column are distributed
+// uniformly between its min and max value.
+func comparisonSelectivity(e *BinaryExpr, stats *TableStatistics) float64 {
+	colStats, value, ok := columnLiteral(e, stats)
+	if !ok {
+		return rangeSelectivity
+	}
+	v, ok := scalarFloat(value)
+	min, minOk := scalarFloat(colStats.Min)
+	max, maxOk := scalarFloat(colStats.Max)
+	if !ok || !minOk || !maxOk || max <= min {
+		return rangeSelectivity
+	}
+
+	// The fraction of the range of the column below the value.
+	below := (v - min) / (max - min)
+	switch {
+	case below < 0:
+		below = 0
+	case below > 1:
+		below = 1
+	}
+	if e.Op == OpLt || e.Op == OpLtEq {
+		return below
+	}
+	return 1 - below
+}
+
+// scalarFloat returns the value of a numeric scalar as a float.
+func scalarFloat(s scalar.Scalar) (float64, bool) {
+	if s == nil || !s.IsValid() {
+		return 0, false
+	}
+	switch v := s.(type) {
+	case *scalar.Int64:
+		return float64(v.Value), true
+	case *scalar.Int32:
+		return float64(v.Value), true
+	case *scalar.Uint64:
+		return float64(v.Value), true
+	case *scalar.Float64:
+		return v.Value, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,144 @@
+package logicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+)
+
+type statisticsTableReader struct {
+	mockTableReader
+	stats *TableStatistics
+}
+
+func (m *statisticsTableReader) View(fn func(tx uint64) error) error {
+	return fn(1)
+}
+
+func (m *statisticsTableReader) Statistics(tx uint64) (*TableStatistics, error) {
+	return m.stats, nil
+}
+
+// statisticsTableProvider provides tables with the given statistics by their
+// names.
+type statisticsTableProvider map[string]*TableStatistics
+
+func (m statisticsTableProvider) GetTable(name string) TableReader {
+	return &statisticsTableReader{
+		mockTableReader: mockTableReader{schema: dynparquet.NewSampleSchema()},
+		stats:           m[name],
+	}
+}
+
+func optimize(t *testing.T, b Builder) *LogicalPlan {
+	p, err := b.Build()
+	require.NoError(t, err)
+	for _, optimizer := range DefaultOptimizers {
+		p = optimizer.Optimize(p)
+	}
+	return p
+}
+
+func TestCostBasedOptimizer(t *testing.T) {
+	provider := statisticsTableProvider{
+		"small": {
+			NumRows: 100,
+			Columns: map[string]ColumnStatistics{
+				"labels.job": {DistinctCount: 50},
+			},
+		},
+		"large": {
+			NumRows: 1 << 20,
+			Columns: map[string]ColumnStatistics{
+				"labels.job": {DistinctCount: 10},
+				"stacktrace": {DistinctCount: 1 << 18},
+				"value": {
+					DistinctCount: 100,
+					Min:           scalar.NewInt64Scalar(0),
+					Max:           scalar.NewInt64Scalar(100),
+				},
+			},
+		},
+	}
+
+	t.Run("filter predicates", func(t *testing.T) {
+		// The value of all rows is at least 0, while only a tenth of the
+		// rows have the job.
+		p := optimize(t, (&Builder{}).
+			Scan(provider, "large").
+			Filter(And(
+				Col("value").GtEq(Literal(int64(0))),
+				Col("labels.job").Eq(Literal("api")),
+			)))
+		expected := And(
+			Col("labels.job").Eq(Literal("api")),
+			Col("value").GtEq(Literal(int64(0))),
+		)
+		require.Equal(t, expected, p.Filter.Expr)
+		require.Equal(t, expected, p.Input.TableScan.Filter)
+
+		// Values outside of the range of the column don't match any rows.
+		p = optimize(t, (&Builder{}).
+			Scan(provider, "large").
+			Filter(And(
+				Col("labels.job").Eq(Literal("api")),
+				Col("value").Eq(Literal(int64(200))),
+			)))
+		require.Equal(t, And(
+			Col("value").Eq(Literal(int64(200))),
+			Col("labels.job").Eq(Literal("api")),
+		), p.Filter.Expr)
+	})
+
+	t.Run("aggregation", func(t *testing.T) {
+		p := optimize(t, (&Builder{}).
+			Scan(provider, "large").
+			Aggregate(Sum(Col("value")), Col("labels.job")))
+		require.True(t, p.Aggregation.PreferHash)
+
+		// Too many groups.
+		p = optimize(t, (&Builder{}).
+			Scan(provider, "large").
+			Aggregate(Sum(Col("value")), Col("labels.job"), Col("stacktrace")))
+		require.False(t, p.Aggregation.PreferHash)
+
+		// Too few rows.
+		p = optimize(t, (&Builder{}).
+			Scan(provider, "small").
+			Aggregate(Sum(Col("value")), Col("labels.job")))
+		require.False(t, p.Aggregation.PreferHash)
+	})
+
+	t.Run("join", func(t *testing.T) {
+		join := func(left, right string) *LogicalPlan {
+			return optimize(t, (&Builder{}).
+				Scan(provider, left).
+				Join((&Builder{}).Scan(provider, right), Cols("labels.job"), Cols("labels.job")))
+		}
+		require.True(t, join("small", "large").Join.BuildLeft)
+		require.False(t, join("large", "small").Join.BuildLeft)
+
+		// The filtered rows of the large table are fewer.
+		p := optimize(t, (&Builder{}).
+			Scan(provider, "small").
+			Join(
+				(&Builder{}).Scan(provider, "large").Filter(Col("value").Lt(Literal(int64(0)))),
+				Cols("labels.job"),
+				Cols("labels.job"),
+			))
+		require.False(t, p.Join.BuildLeft)
+	})
+
+	t.Run("without statistics", func(t *testing.T) {
+		tableProvider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+		filter := And(
+			Col("value").GtEq(Literal(int64(0))),
+			Col("labels.job").Eq(Literal("api")),
+		)
+		p := optimize(t, (&Builder{}).Scan(tableProvider, "table1").Filter(filter))
+		require.Equal(t, filter, p.Filter.Expr)
+	})
+}
@@ -0,0 +1,954 @@
+package logicalplan
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+
+	substraitpb "github.com/polarsignals/frostdb/gen/proto/go/substrait"
+	extensionspb "github.com/polarsignals/frostdb/gen/proto/go/substrait/extensions"
+)
+
+// substraitExtensions is the location of the YAML files of the functions of
+// the Substrait specification.
+const substraitExtensions = "https://github.com/substrait-io/substrait/blob/main/extensions/"
+
+// substraitFunction is a function of the YAML files of the Substrait
+// specification.
+type substraitFunction struct {
+	uri  string
+	name string
+}
+
+func newSubstraitFunction(file, name string) substraitFunction {
+	return substraitFunction{uri: substraitExtensions + file, name: name}
+}
+
+var opToSubstrait = map[Op]substraitFunction{
+	OpEq:         newSubstraitFunction("functions_comparison.yaml", "equal"),
+	OpNotEq:      newSubstraitFunction("functions_comparison.yaml", "not_equal"),
+	OpLt:         newSubstraitFunction("functions_comparison.yaml", "lt"),
+	OpLtEq:       newSubstraitFunction("functions_comparison.yaml", "lte"),
+	OpGt:         newSubstraitFunction("functions_comparison.yaml", "gt"),
+	OpGtEq:       newSubstraitFunction("functions_comparison.yaml", "gte"),
+	OpAnd:        newSubstraitFunction("functions_boolean.yaml", "and"),
+	OpOr:         newSubstraitFunction("functions_boolean.yaml", "or"),
+	OpNot:        newSubstraitFunction("functions_boolean.yaml", "not"),
+	OpAdd:        newSubstraitFunction("functions_arithmetic.yaml", "add"),
+	OpSub:        newSubstraitFunction("functions_arithmetic.yaml", "subtract"),
+	OpMul:        newSubstraitFunction("functions_arithmetic.yaml", "multiply"),
+	OpDiv:        newSubstraitFunction("functions_arithmetic.yaml", "divide"),
+	OpMod:        newSubstraitFunction("functions_arithmetic.yaml", "modulus"),
+	OpStartsWith: newSubstraitFunction("functions_string.yaml", "starts_with"),
+	OpEndsWith:   newSubstraitFunction("functions_string.yaml", "ends_with"),
+	OpContains:   newSubstraitFunction("functions_string.yaml", "contains"),
+}
+
+var aggFuncToSubstrait = map[AggFunc]substraitFunction{
+	AggFuncSum:   newSubstraitFunction("functions_arithmetic.yaml", "sum"),
+	AggFuncMin:   newSubstraitFunction("functions_arithmetic.yaml", "min"),
+	AggFuncMax:   newSubstraitFunction("functions_arithmetic.yaml", "max"),
+	AggFuncAvg:   newSubstraitFunction("functions_arithmetic.yaml", "avg"),
+	AggFuncCount: newSubstraitFunction("functions_aggregate_generic.yaml", "count"),
+}
+
+var (
+	opFromSubstrait      = map[string]Op{}
+	aggFuncFromSubstrait = map[string]AggFunc{}
+)
+
+func init() {
+	for k, v := range opToSubstrait {
+		opFromSubstrait[v.name] = k
+	}
+	for k, v := range aggFuncToSubstrait {
+		aggFuncFromSubstrait[v.name] = k
+	}
+}
+
+// ToSubstrait converts a logical plan, as it is built before it's optimized,
+// to a Substrait plan. Fields are referenced by their position in Substrait
+// plans, so scans read the static columns of their tables followed by the
+// dynamic columns the plan refers to, in ascending order. Plans that refer
+// to dynamic columns by their prefix or that Substrait can't express, such
+// as regular expression matches, can't be converted.
+func ToSubstrait(plan *LogicalPlan) (*substraitpb.Plan, error) {
+	e := &substraitExporter{
+		plan: &substraitpb.Plan{
+			Version: &substraitpb.Version{Producer: "frostdb"},
+		},
+		uris:      map[string]uint32{},
+		functions: map[substraitFunction]uint32{},
+		columns:   map[string]struct{}{},
+	}
+	if err := e.collectColumns(plan); err != nil {
+		return nil, err
+	}
+
+	rel, names, err := e.rel(plan)
+	if err != nil {
+		return nil, err
+	}
+	e.plan.Relations = []*substraitpb.PlanRel{{
+		RelType: &substraitpb.PlanRel_Root{Root: &substraitpb.RelRoot{Input: rel, Names: names}},
+	}}
+	return e.plan, nil
+}
+
+// substraitExporter converts a logical plan to a Substrait plan, and declares
+// the functions the plan uses.
+type substraitExporter struct {
+	plan      *substraitpb.Plan
+	uris      map[string]uint32
+	functions map[substraitFunction]uint32
+	// columns are the names of the columns the plan refers to.
+	columns map[string]struct{}
+}
+
+// collectColumns collects the names of the columns the expressions of the
+// plan refer to.
+func (e *substraitExporter) collectColumns(plan *LogicalPlan) error {
+	var exprs []Expr
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.Filter != nil:
+			exprs = append(exprs, plan.Filter.Expr)
+		case plan.Distinct != nil:
+			exprs = append(exprs, plan.Distinct.Exprs...)
+		case plan.Projection != nil:
+			exprs = append(exprs, plan.Projection.Exprs...)
+		case plan.Aggregation != nil:
+			exprs = append(exprs, plan.Aggregation.GroupExprs...)
+			exprs = append(exprs, plan.Aggregation.AggExprs...)
+		case plan.OrderBy != nil:
+			for _, sortExpr := range plan.OrderBy.Exprs {
+				exprs = append(exprs, sortExpr.Expr)
+			}
+		}
+	}
+
+	for _, expr := range exprs {
+		for _, used := range expr.ColumnsUsedExprs() {
+			switch used := used.(type) {
+			case *Column:
+				e.columns[used.ColumnName] = struct{}{}
+			case *DynamicColumn, *RegexColumn, *AllColumnsExpr:
+				return fmt.Errorf("substrait plans can't refer to the columns of %s", used.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// function returns the anchor of the declaration of the function.
+func (e *substraitExporter) function(f substraitFunction) uint32 {
+	if anchor, ok := e.functions[f]; ok {
+		return anchor
+	}
+
+	uri, ok := e.uris[f.uri]
+	if !ok {
+		uri = uint32(len(e.uris) + 1)
+		e.uris[f.uri] = uri
+		e.plan.ExtensionUris = append(e.plan.ExtensionUris, &extensionspb.SimpleExtensionURI{
+			ExtensionUriAnchor: uri,
+			Uri:                f.uri,
+		})
+	}
+
+	anchor := uint32(len(e.functions) + 1)
+	e.functions[f] = anchor
+	e.plan.Extensions = append(e.plan.Extensions, &extensionspb.SimpleExtensionDeclaration{
+		MappingType: &extensionspb.SimpleExtensionDeclaration_ExtensionFunction_{
+			ExtensionFunction: &extensionspb.SimpleExtensionDeclaration_ExtensionFunction{
+				ExtensionUriReference: uri,
+				FunctionAnchor:        anchor,
+				Name:                  f.name,
+			},
+		},
+	})
+	return anchor
+}
+
+// rel converts the plan to a relation and returns the names of its fields.
+func (e *substraitExporter) rel(plan *LogicalPlan) (*substraitpb.Rel, []string, error) {
+	if plan.TableScan != nil {
+		return e.read(plan.TableScan)
+	}
+	if plan.Input == nil {
+		return nil, nil, errors.New("substrait plans must read a table")
+	}
+
+	// A limit of an offset is a single fetch.
+	if plan.Limit != nil && plan.Input.Offset != nil {
+		input, names, err := e.rel(plan.Input.Input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &substraitpb.Rel{RelType: &substraitpb.Rel_Fetch{Fetch: &substraitpb.FetchRel{
+			Input:  input,
+			Offset: plan.Input.Offset.Count,
+			Count:  plan.Limit.Count,
+		}}}, names, nil
+	}
+
+	input, names, err := e.rel(plan.Input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case plan.Filter != nil:
+		condition, err := e.expr(plan.Filter.Expr, names)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &substraitpb.Rel{RelType: &substraitpb.Rel_Filter{Filter: &substraitpb.FilterRel{
+			Input:     input,
+			Condition: condition,
+		}}}, names, nil
+	case plan.Projection != nil:
+		exprs, err := e.exprs(plan.Projection.Exprs, names)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Projections append their expressions to the fields of their
+		// input, of which only the expressions are emitted.
+		mapping := make([]int32, 0, len(exprs))
+		for i := range exprs {
+			mapping = append(mapping, int32(len(names)+i))
+		}
+		return &substraitpb.Rel{RelType: &substraitpb.Rel_Project{Project: &substraitpb.ProjectRel{
+			Common: &substraitpb.RelCommon{EmitKind: &substraitpb.RelCommon_Emit_{
+				Emit: &substraitpb.RelCommon_Emit{OutputMapping: mapping},
+			}},
+			Input:       input,
+			Expressions: exprs,
+		}}}, exprNames(plan.Projection.Exprs), nil
+	case plan.Aggregation != nil:
+		return e.aggregate(input, names, plan.Aggregation.GroupExprs, plan.Aggregation.AggExprs)
+	case plan.Distinct != nil:
+		return e.aggregate(input, names, plan.Distinct.Exprs, nil)
+	case plan.OrderBy != nil:
+		sorts := make([]*substraitpb.SortField, 0, len(plan.OrderBy.Exprs))
+		for _, sortExpr := range plan.OrderBy.Exprs {
+			expr, err := e.expr(sortExpr.Expr, names)
+			if err != nil {
+				return nil, nil, err
+			}
+			direction := substraitpb.SortField_SORT_DIRECTION_ASC_NULLS_LAST
+			if sortExpr.Descending {
+				direction = substraitpb.SortField_SORT_DIRECTION_DESC_NULLS_FIRST
+			}
+			sorts = append(sorts, &substraitpb.SortField{
+				Expr:     expr,
+				SortKind: &substraitpb.SortField_Direction{Direction: direction},
+			})
+		}
+		return &substraitpb.Rel{RelType: &substraitpb.Rel_Sort{Sort: &substraitpb.SortRel{
+			Input: input,
+			Sorts: sorts,
+		}}}, names, nil
+	case plan.Limit != nil:
+		return &substraitpb.Rel{RelType: &substraitpb.Rel_Fetch{Fetch: &substraitpb.FetchRel{
+			Input: input,
+			Count: plan.Limit.Count,
+		}}}, names, nil
+	case plan.Offset != nil:
+		return &substraitpb.Rel{RelType: &substraitpb.Rel_Fetch{Fetch: &substraitpb.FetchRel{
+			Input:  input,
+			Offset: plan.Offset.Count,
+			Count:  -1,
+		}}}, names, nil
+	default:
+		return nil, nil, errors.New("unsupported logical plan node for substrait")
+	}
+}
+
+// read converts the table scan to a read of the static columns of the table
+// and of the dynamic columns the plan refers to.
+func (e *substraitExporter) read(scan *TableScan) (*substraitpb.Rel, []string, error) {
+	if scan.Filter != nil || scan.Projection != nil || scan.PhysicalProjection != nil ||
+		scan.Distinct != nil || scan.Limit != 0 || scan.Sample != nil {
+		return nil, nil, errors.New("substrait plans can't be converted from optimized table scans")
+	}
+	table := scan.TableProvider.GetTable(scan.TableName)
+	if table == nil {
+		return nil, nil, fmt.Errorf("table %s not found", scan.TableName)
+	}
+	schema := table.Schema()
+
+	names := []string{}
+	var dynamic []string
+	for _, col := range schema.Columns() {
+		if !col.Dynamic {
+			names = append(names, col.Name)
+		}
+	}
+	for name := range e.columns {
+		if col, ok := schema.FindColumn(name); ok && col.Dynamic {
+			dynamic = append(dynamic, name)
+		}
+	}
+	sort.Strings(dynamic)
+	names = append(names, dynamic...)
+
+	types := make([]*substraitpb.Type, 0, len(names))
+	for _, name := range names {
+		dataType, err := Col(name).DataType(schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		t, err := typeToSubstrait(dataType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		types = append(types, t)
+	}
+
+	return &substraitpb.Rel{RelType: &substraitpb.Rel_Read{Read: &substraitpb.ReadRel{
+		BaseSchema: &substraitpb.NamedStruct{
+			Names: names,
+			Struct: &substraitpb.Type_Struct{
+				Types:       types,
+				Nullability: substraitpb.Type_NULLABILITY_REQUIRED,
+			},
+		},
+		ReadType: &substraitpb.ReadRel_NamedTable_{NamedTable: &substraitpb.ReadRel_NamedTable{
+			Names: []string{scan.TableName},
+		}},
+	}}}, names, nil
+}
+
+// aggregate converts an aggregation, or a distinct without aggregations, to
+// an aggregate relation.
+func (e *substraitExporter) aggregate(input *substraitpb.Rel, names []string, groupExprs, aggExprs []Expr) (*substraitpb.Rel, []string, error) {
+	aggregate := &substraitpb.AggregateRel{Input: input}
+	if len(groupExprs) > 0 {
+		exprs, err := e.exprs(groupExprs, names)
+		if err != nil {
+			return nil, nil, err
+		}
+		aggregate.Groupings = []*substraitpb.AggregateRel_Grouping{{GroupingExpressions: exprs}}
+	}
+	for _, aggExpr := range aggExprs {
+		measure, err := e.measure(aggExpr, names)
+		if err != nil {
+			return nil, nil, err
+		}
+		aggregate.Measures = append(aggregate.Measures, measure)
+	}
+	return &substraitpb.Rel{RelType: &substraitpb.Rel_Aggregate{Aggregate: aggregate}},
+		append(exprNames(groupExprs), exprNames(aggExprs)...), nil
+}
+
+func (e *substraitExporter) measure(expr Expr, names []string) (*substraitpb.AggregateRel_Measure, error) {
+	if alias, ok := expr.(*AliasExpr); ok {
+		expr = alias.Expr
+	}
+	agg, ok := expr.(*AggregationFunction)
+	if !ok {
+		return nil, fmt.Errorf("unsupported aggregation for substrait: %s", expr.Name())
+	}
+	f, ok := aggFuncToSubstrait[agg.Func]
+	if !ok {
+		return nil, fmt.Errorf("unsupported aggregation function for substrait: %s", agg.Func)
+	}
+
+	arg, err := e.expr(agg.Expr, names)
+	if err != nil {
+		return nil, err
+	}
+	measure := &substraitpb.AggregateRel_Measure{Measure: &substraitpb.AggregateFunction{
+		FunctionReference: e.function(f),
+		Arguments:         []*substraitpb.FunctionArgument{valueArgument(arg)},
+		Phase:             substraitpb.AggregationPhase_AGGREGATION_PHASE_INITIAL_TO_RESULT,
+		Invocation:        substraitpb.AggregateFunction_AGGREGATION_INVOCATION_ALL,
+	}}
+	if agg.Filter != nil {
+		if measure.Filter, err = e.expr(agg.Filter, names); err != nil {
+			return nil, err
+		}
+	}
+	return measure, nil
+}
+
+func (e *substraitExporter) exprs(exprs []Expr, names []string) ([]*substraitpb.Expression, error) {
+	res := make([]*substraitpb.Expression, 0, len(exprs))
+	for _, expr := range exprs {
+		converted, err := e.expr(expr, names)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, converted)
+	}
+	return res, nil
+}
+
+// expr converts the expression to a Substrait expression, whose fields are
+// referenced by their position in the names.
+func (e *substraitExporter) expr(expr Expr, names []string) (*substraitpb.Expression, error) {
+	switch expr := expr.(type) {
+	case *Column:
+		for i, name := range names {
+			if name == expr.ColumnName {
+				return fieldReference(i), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrColumnNotFound, expr.ColumnName)
+	case *AliasExpr:
+		return e.expr(expr.Expr, names)
+	case *LiteralExpr:
+		literal, err := literalToSubstrait(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &substraitpb.Expression{RexType: &substraitpb.Expression_Literal_{Literal: literal}}, nil
+	case *BinaryExpr:
+		return e.call(expr.Op, expr.Left, expr.Right, names)
+	case *UnaryExpr:
+		return e.call(expr.Op, expr.Expr, nil, names)
+	default:
+		return nil, fmt.Errorf("unsupported expression for substrait: %s", expr.Name())
+	}
+}
+
+// call converts the operator and its operands to a scalar function.
+func (e *substraitExporter) call(op Op, left, right Expr, names []string) (*substraitpb.Expression, error) {
+	f, ok := opToSubstrait[op]
+	if !ok {
+		return nil, fmt.Errorf("unsupported operator for substrait: %s", op)
+	}
+
+	args := []*substraitpb.FunctionArgument{}
+	for _, operand := range []Expr{left, right} {
+		if operand == nil {
+			continue
+		}
+		arg, err := e.expr(operand, names)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, valueArgument(arg))
+	}
+	return &substraitpb.Expression{RexType: &substraitpb.Expression_ScalarFunction_{
+		ScalarFunction: &substraitpb.Expression_ScalarFunction{
+			FunctionReference: e.function(f),
+			Arguments:         args,
+		},
+	}}, nil
+}
+
+func valueArgument(expr *substraitpb.Expression) *substraitpb.FunctionArgument {
+	return &substraitpb.FunctionArgument{ArgType: &substraitpb.FunctionArgument_Value{Value: expr}}
+}
+
+func fieldReference(i int) *substraitpb.Expression {
+	return &substraitpb.Expression{RexType: &substraitpb.Expression_Selection{
+		Selection: &substraitpb.Expression_FieldReference{
+			ReferenceType: &substraitpb.Expression_FieldReference_DirectReference{
+				DirectReference: &substraitpb.Expression_ReferenceSegment{
+					ReferenceType: &substraitpb.Expression_ReferenceSegment_StructField_{
+						StructField: &substraitpb.Expression_ReferenceSegment_StructField{Field: int32(i)},
+					},
+				},
+			},
+			RootType: &substraitpb.Expression_FieldReference_RootReference_{
+				RootReference: &substraitpb.Expression_FieldReference_RootReference{},
+			},
+		},
+	}}
+}
+
+func exprNames(exprs []Expr) []string {
+	names := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		names = append(names, expr.Name())
+	}
+	return names
+}
+
+func typeToSubstrait(t arrow.DataType) (*substraitpb.Type, error) {
+	const nullable = substraitpb.Type_NULLABILITY_NULLABLE
+	switch t.ID() {
+	case arrow.BOOL:
+		return &substraitpb.Type{Kind: &substraitpb.Type_Bool{Bool: &substraitpb.Type_Boolean{Nullability: nullable}}}, nil
+	case arrow.INT32:
+		return &substraitpb.Type{Kind: &substraitpb.Type_I32_{I32: &substraitpb.Type_I32{Nullability: nullable}}}, nil
+	case arrow.INT64:
+		return &substraitpb.Type{Kind: &substraitpb.Type_I64_{I64: &substraitpb.Type_I64{Nullability: nullable}}}, nil
+	case arrow.FLOAT64:
+		return &substraitpb.Type{Kind: &substraitpb.Type_Fp64{Fp64: &substraitpb.Type_FP64{Nullability: nullable}}}, nil
+	case arrow.STRING:
+		return &substraitpb.Type{Kind: &substraitpb.Type_String_{String_: &substraitpb.Type_String{Nullability: nullable}}}, nil
+	case arrow.BINARY:
+		return &substraitpb.Type{Kind: &substraitpb.Type_Binary_{Binary: &substraitpb.Type_Binary{Nullability: nullable}}}, nil
+	case arrow.TIMESTAMP:
+		return &substraitpb.Type{Kind: &substraitpb.Type_Timestamp_{Timestamp: &substraitpb.Type_Timestamp{Nullability: nullable}}}, nil
+	case arrow.LIST:
+		elem, err := typeToSubstrait(t.(*arrow.ListType).Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &substraitpb.Type{Kind: &substraitpb.Type_List_{List: &substraitpb.Type_List{Type: elem, Nullability: nullable}}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type for substrait: %s", t)
+	}
+}
+
+func literalToSubstrait(v scalar.Scalar) (*substraitpb.Expression_Literal, error) {
+	switch v := v.(type) {
+	case *scalar.Boolean:
+		return &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_Boolean{Boolean: v.Value}}, nil
+	case *scalar.Int64:
+		return &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_I64{I64: v.Value}}, nil
+	case *scalar.Float64:
+		return &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_Fp64{Fp64: v.Value}}, nil
+	case *scalar.String:
+		return &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_String_{String_: string(v.Data())}}, nil
+	case *scalar.Binary:
+		return &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_Binary{Binary: v.Data()}}, nil
+	case *scalar.Timestamp:
+		if v.Type.(*arrow.TimestampType).Unit != arrow.Millisecond {
+			return nil, fmt.Errorf("unsupported timestamp unit %s", v.Type.(*arrow.TimestampType).Unit)
+		}
+		// Substrait timestamps are in microseconds.
+		return &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_Timestamp{
+			Timestamp: int64(v.Value) * 1000,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported literal type for substrait %T", v)
+	}
+}
+
+// FromSubstrait converts the first tree of relations of a Substrait plan to
+// a logical plan, whose scans read their tables from the given table
+// provider. The fields of reads are the columns of the names of their base
+// schemas, and the names of the root of the tree alias the columns of the
+// results. Rows are sorted with the null ordering of frostdb, regardless of
+// the null ordering of the sort fields.
+func FromSubstrait(plan *substraitpb.Plan, provider TableProvider) (*LogicalPlan, error) {
+	if len(plan.GetRelations()) == 0 {
+		return nil, errors.New("substrait plan has no relations")
+	}
+
+	i := &substraitImporter{provider: provider, functions: map[uint32]string{}}
+	for _, ext := range plan.GetExtensions() {
+		f := ext.GetExtensionFunction()
+		if f == nil {
+			continue
+		}
+		// Names may be followed by the signature of the function.
+		name := f.GetName()
+		if j := strings.IndexByte(name, ':'); j >= 0 {
+			name = name[:j]
+		}
+		i.functions[f.GetFunctionAnchor()] = name
+	}
+
+	rel := plan.GetRelations()[0]
+	input := rel.GetRel()
+	var rootNames []string
+	if root := rel.GetRoot(); root != nil {
+		input = root.GetInput()
+		rootNames = root.GetNames()
+	}
+	if input == nil {
+		return nil, errors.New("substrait plan has no relations")
+	}
+
+	res, err := i.rel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The results of scans have all of the columns of their tables, of which
+	// only the fields of the read are kept. Fields are renamed to the names
+	// of the root.
+	if len(rootNames) > 0 && len(rootNames) != len(res.names) {
+		return nil, fmt.Errorf("substrait plan has %d names for %d fields", len(rootNames), len(res.names))
+	}
+	renamed := false
+	for j, name := range rootNames {
+		renamed = renamed || name != res.names[j]
+	}
+	if !res.scanned && !renamed {
+		return res.plan, nil
+	}
+	if projection := res.plan.Projection; projection != nil {
+		// The expressions of projections are aliased themselves.
+		for j, expr := range projection.Exprs {
+			if rootNames[j] != res.names[j] {
+				if alias, ok := expr.(*AliasExpr); ok {
+					expr = alias.Expr
+				}
+				projection.Exprs[j] = &AliasExpr{Expr: expr, Alias: rootNames[j]}
+			}
+		}
+		return res.plan, nil
+	}
+	exprs := make([]Expr, 0, len(res.names))
+	for j, name := range res.names {
+		if renamed && rootNames[j] != name {
+			exprs = append(exprs, Col(name).Alias(rootNames[j]))
+			continue
+		}
+		exprs = append(exprs, Col(name))
+	}
+	return &LogicalPlan{Input: res.plan, Projection: &Projection{Exprs: exprs}}, nil
+}
+
+// substraitImporter converts relations of a Substrait plan to logical plans.
+type substraitImporter struct {
+	provider TableProvider
+	// functions are the names of the functions of the anchors of their
+	// declarations.
+	functions map[uint32]string
+}
+
+// importedRel is a relation converted to a logical plan.
+type importedRel struct {
+	plan *LogicalPlan
+	// names are the column names of the fields of the relation.
+	names []string
+	// scanned is set if the rows of the plan have all of the columns of the
+	// table, rather than only the fields of the relation.
+	scanned bool
+}
+
+func (i *substraitImporter) rel(rel *substraitpb.Rel) (*importedRel, error) {
+	if read := rel.GetRead(); read != nil {
+		return i.read(read)
+	}
+
+	var input *substraitpb.Rel
+	switch rel := rel.GetRelType().(type) {
+	case *substraitpb.Rel_Filter:
+		input = rel.Filter.GetInput()
+	case *substraitpb.Rel_Project:
+		input = rel.Project.GetInput()
+	case *substraitpb.Rel_Aggregate:
+		input = rel.Aggregate.GetInput()
+	case *substraitpb.Rel_Sort:
+		input = rel.Sort.GetInput()
+	case *substraitpb.Rel_Fetch:
+		input = rel.Fetch.GetInput()
+	default:
+		return nil, fmt.Errorf("unsupported substrait relation %T", rel)
+	}
+	if input == nil {
+		return nil, fmt.Errorf("substrait relation %T has no input", rel.GetRelType())
+	}
+	in, err := i.rel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *importedRel
+	switch rel := rel.GetRelType().(type) {
+	case *substraitpb.Rel_Filter:
+		condition, err := i.expr(rel.Filter.GetCondition(), in.names)
+		if err != nil {
+			return nil, err
+		}
+		res = &importedRel{
+			plan:    &LogicalPlan{Input: in.plan, Filter: &Filter{Expr: condition}},
+			names:   in.names,
+			scanned: in.scanned,
+		}
+		return res, emit(res, rel.Filter.GetCommon())
+	case *substraitpb.Rel_Project:
+		// The fields of projections are the fields of their input followed
+		// by their expressions.
+		exprs := make([]Expr, 0, len(in.names)+len(rel.Project.GetExpressions()))
+		for _, name := range in.names {
+			exprs = append(exprs, Col(name))
+		}
+		for _, e := range rel.Project.GetExpressions() {
+			expr, err := i.expr(e, in.names)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, expr)
+		}
+		if err := emitExprs(&exprs, rel.Project.GetCommon()); err != nil {
+			return nil, err
+		}
+		return &importedRel{
+			plan:  &LogicalPlan{Input: in.plan, Projection: &Projection{Exprs: exprs}},
+			names: exprNames(exprs),
+		}, nil
+	case *substraitpb.Rel_Aggregate:
+		res, err = i.aggregate(rel.Aggregate, in)
+		if err != nil {
+			return nil, err
+		}
+		return res, emit(res, rel.Aggregate.GetCommon())
+	case *substraitpb.Rel_Sort:
+		exprs := make([]SortExpr, 0, len(rel.Sort.GetSorts()))
+		for _, sort := range rel.Sort.GetSorts() {
+			expr, err := i.expr(sort.GetExpr(), in.names)
+			if err != nil {
+				return nil, err
+			}
+			switch sort.GetDirection() {
+			case substraitpb.SortField_SORT_DIRECTION_ASC_NULLS_FIRST, substraitpb.SortField_SORT_DIRECTION_ASC_NULLS_LAST:
+				exprs = append(exprs, Asc(expr))
+			case substraitpb.SortField_SORT_DIRECTION_DESC_NULLS_FIRST, substraitpb.SortField_SORT_DIRECTION_DESC_NULLS_LAST:
+				exprs = append(exprs, Desc(expr))
+			default:
+				return nil, fmt.Errorf("unsupported substrait sort direction %s", sort.GetDirection())
+			}
+		}
+		res = &importedRel{
+			plan:    &LogicalPlan{Input: in.plan, OrderBy: &OrderBy{Exprs: exprs}},
+			names:   in.names,
+			scanned: in.scanned,
+		}
+		return res, emit(res, rel.Sort.GetCommon())
+	default:
+		fetch := rel.(*substraitpb.Rel_Fetch).Fetch
+		plan := in.plan
+		if fetch.GetOffset() > 0 {
+			plan = &LogicalPlan{Input: plan, Offset: &Offset{Count: fetch.GetOffset()}}
+		}
+		// Fetches of negative counts fetch all of the rows.
+		if fetch.GetCount() >= 0 {
+			plan = &LogicalPlan{Input: plan, Limit: &Limit{Count: fetch.GetCount()}}
+		}
+		res = &importedRel{plan: plan, names: in.names, scanned: in.scanned}
+		return res, emit(res, fetch.GetCommon())
+	}
+}
+
+func (i *substraitImporter) read(read *substraitpb.ReadRel) (*importedRel, error) {
+	table := read.GetNamedTable().GetNames()
+	if len(table) == 0 {
+		return nil, errors.New("substrait reads must read named tables")
+	}
+	for _, t := range read.GetBaseSchema().GetStruct().GetTypes() {
+		if t.GetStruct() != nil {
+			return nil, errors.New("substrait reads can't read structs")
+		}
+	}
+
+	res := &importedRel{
+		plan: &LogicalPlan{TableScan: &TableScan{
+			TableProvider: i.provider,
+			TableName:     table[len(table)-1],
+		}},
+		names:   read.GetBaseSchema().GetNames(),
+		scanned: true,
+	}
+	if read.GetFilter() != nil {
+		filter, err := i.expr(read.GetFilter(), res.names)
+		if err != nil {
+			return nil, err
+		}
+		res.plan = &LogicalPlan{Input: res.plan, Filter: &Filter{Expr: filter}}
+	}
+	if items := read.GetProjection().GetSelect().GetStructItems(); len(items) > 0 {
+		names := make([]string, 0, len(items))
+		for _, item := range items {
+			if item.GetField() < 0 || int(item.GetField()) >= len(res.names) {
+				return nil, fmt.Errorf("substrait read projects field %d of %d", item.GetField(), len(res.names))
+			}
+			names = append(names, res.names[item.GetField()])
+		}
+		res.names = names
+	}
+	return res, emit(res, read.GetCommon())
+}
+
+func (i *substraitImporter) aggregate(aggregate *substraitpb.AggregateRel, in *importedRel) (*importedRel, error) {
+	if len(aggregate.GetGroupings()) > 1 {
+		return nil, errors.New("substrait aggregations of multiple grouping sets aren't supported")
+	}
+
+	var groupExprs []Expr
+	if len(aggregate.GetGroupings()) == 1 {
+		var err error
+		groupExprs, err = i.exprs(aggregate.GetGroupings()[0].GetGroupingExpressions(), in.names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(aggregate.GetMeasures()) == 0 {
+		return &importedRel{
+			plan:  &LogicalPlan{Input: in.plan, Distinct: &Distinct{Exprs: groupExprs}},
+			names: exprNames(groupExprs),
+		}, nil
+	}
+
+	aggExprs := make([]Expr, 0, len(aggregate.GetMeasures()))
+	for _, measure := range aggregate.GetMeasures() {
+		f := measure.GetMeasure()
+		if f.GetInvocation() == substraitpb.AggregateFunction_AGGREGATION_INVOCATION_DISTINCT {
+			return nil, errors.New("substrait aggregations of distinct values aren't supported")
+		}
+		if phase := f.GetPhase(); phase != substraitpb.AggregationPhase_AGGREGATION_PHASE_UNSPECIFIED &&
+			phase != substraitpb.AggregationPhase_AGGREGATION_PHASE_INITIAL_TO_RESULT {
+			return nil, fmt.Errorf("unsupported substrait aggregation phase %s", phase)
+		}
+		name := i.functions[f.GetFunctionReference()]
+		aggFunc, ok := aggFuncFromSubstrait[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported substrait aggregation function %q", name)
+		}
+		if len(f.GetArguments()) != 1 {
+			return nil, fmt.Errorf("substrait aggregation function %q has %d arguments", name, len(f.GetArguments()))
+		}
+		expr, err := i.expr(f.GetArguments()[0].GetValue(), in.names)
+		if err != nil {
+			return nil, err
+		}
+		agg := &AggregationFunction{Func: aggFunc, Expr: expr}
+		if measure.GetFilter() != nil {
+			if agg.Filter, err = i.expr(measure.GetFilter(), in.names); err != nil {
+				return nil, err
+			}
+		}
+		aggExprs = append(aggExprs, agg)
+	}
+
+	return &importedRel{
+		plan: &LogicalPlan{Input: in.plan, Aggregation: &Aggregation{
+			GroupExprs: groupExprs,
+			AggExprs:   aggExprs,
+		}},
+		names: append(exprNames(groupExprs), exprNames(aggExprs)...),
+	}, nil
+}
+
+// emit keeps the fields of the relation that it emits.
+func emit(rel *importedRel, common *substraitpb.RelCommon) error {
+	mapping := common.GetEmit().GetOutputMapping()
+	if len(mapping) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(mapping))
+	for _, j := range mapping {
+		if j < 0 || int(j) >= len(rel.names) {
+			return fmt.Errorf("substrait relation emits field %d of %d", j, len(rel.names))
+		}
+		names = append(names, rel.names[j])
+	}
+	rel.names = names
+	return nil
+}
+
+// emitExprs keeps the expressions of the fields of a projection that it
+// emits.
+func emitExprs(exprs *[]Expr, common *substraitpb.RelCommon) error {
+	mapping := common.GetEmit().GetOutputMapping()
+	if len(mapping) == 0 {
+		return nil
+	}
+	emitted := make([]Expr, 0, len(mapping))
+	for _, j := range mapping {
+		if j < 0 || int(j) >= len(*exprs) {
+			return fmt.Errorf("substrait relation emits field %d of %d", j, len(*exprs))
+		}
+		emitted = append(emitted, (*exprs)[j])
+	}
+	*exprs = emitted
+	return nil
+}
+
+func (i *substraitImporter) exprs(exprs []*substraitpb.Expression, names []string) ([]Expr, error) {
+	res := make([]Expr, 0, len(exprs))
+	for _, e := range exprs {
+		expr, err := i.expr(e, names)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, expr)
+	}
+	return res, nil
+}
+
+// expr converts a Substrait expression, whose fields are the columns of the
+// names, to an expression.
+func (i *substraitImporter) expr(expr *substraitpb.Expression, names []string) (Expr, error) {
+	switch e := expr.GetRexType().(type) {
+	case *substraitpb.Expression_Selection:
+		field := e.Selection.GetDirectReference().GetStructField()
+		if field == nil || field.GetChild() != nil || e.Selection.GetRootType() == nil && e.Selection.GetRootReference() == nil {
+			return nil, errors.New("unsupported substrait field reference")
+		}
+		if field.GetField() < 0 || int(field.GetField()) >= len(names) {
+			return nil, fmt.Errorf("substrait expression references field %d of %d", field.GetField(), len(names))
+		}
+		return Col(names[field.GetField()]), nil
+	case *substraitpb.Expression_Literal_:
+		v, err := literalFromSubstrait(e.Literal)
+		if err != nil {
+			return nil, err
+		}
+		return &LiteralExpr{Value: v}, nil
+	case *substraitpb.Expression_ScalarFunction_:
+		name := i.functions[e.ScalarFunction.GetFunctionReference()]
+		op, ok := opFromSubstrait[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported substrait function %q", name)
+		}
+		args := make([]Expr, 0, len(e.ScalarFunction.GetArguments()))
+		for _, arg := range e.ScalarFunction.GetArguments() {
+			if arg.GetValue() == nil {
+				return nil, fmt.Errorf("substrait function %q has an argument that isn't a value", name)
+			}
+			a, err := i.expr(arg.GetValue(), names)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a)
+		}
+		switch {
+		case op == OpNot && len(args) == 1:
+			return Not(args[0]), nil
+		case op == OpAnd && len(args) >= 2:
+			return And(args...), nil
+		case op == OpOr && len(args) >= 2:
+			return Or(args...), nil
+		case op != OpNot && len(args) == 2:
+			return &BinaryExpr{Left: args[0], Op: op, Right: args[1]}, nil
+		default:
+			return nil, fmt.Errorf("substrait function %q has %d arguments", name, len(args))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported substrait expression %T", e)
+	}
+}
+
+func literalFromSubstrait(l *substraitpb.Expression_Literal) (scalar.Scalar, error) {
+	switch v := l.GetLiteralType().(type) {
+	case *substraitpb.Expression_Literal_Boolean:
+		return scalar.NewBooleanScalar(v.Boolean), nil
+	case *substraitpb.Expression_Literal_I8:
+		return scalar.NewInt64Scalar(int64(v.I8)), nil
+	case *substraitpb.Expression_Literal_I16:
+		return scalar.NewInt64Scalar(int64(v.I16)), nil
+	case *substraitpb.Expression_Literal_I32:
+		return scalar.NewInt64Scalar(int64(v.I32)), nil
+	case *substraitpb.Expression_Literal_I64:
+		return scalar.NewInt64Scalar(v.I64), nil
+	case *substraitpb.Expression_Literal_Fp32:
+		return scalar.NewFloat64Scalar(float64(v.Fp32)), nil
+	case *substraitpb.Expression_Literal_Fp64:
+		return scalar.NewFloat64Scalar(v.Fp64), nil
+	case *substraitpb.Expression_Literal_String_:
+		return scalar.NewStringScalar(v.String_), nil
+	case *substraitpb.Expression_Literal_Binary:
+		return scalar.NewBinaryScalar(memory.NewBufferBytes(v.Binary), arrow.BinaryTypes.Binary), nil
+	case *substraitpb.Expression_Literal_Timestamp:
+		return scalar.NewTimestampScalar(arrow.Timestamp(v.Timestamp/1000), arrow.FixedWidthTypes.Timestamp_ms), nil
+	default:
+		return nil, fmt.Errorf("unsupported substrait literal %T", v)
+	}
+}
@@ -0,0 +1,163 @@
+package logicalplan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	substraitpb "github.com/polarsignals/frostdb/gen/proto/go/substrait"
+	extensionspb "github.com/polarsignals/frostdb/gen/proto/go/substrait/extensions"
+)
+
+func TestSubstraitRoundTrip(t *testing.T) {
+	provider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+
+	plan, err := (&Builder{}).
+		Scan(provider, "table1").
+		Filter(And(
+			Col("labels.namespace").Eq(Literal("default")),
+			Not(Col("value").Lt(Literal(int64(1)))),
+		)).
+		Aggregate(Sum(Col("value")).Alias("total"), Col("labels.namespace")).
+		OrderBy(Desc(Col("total"))).
+		Offset(1).
+		Limit(10).
+		Build()
+	require.NoError(t, err)
+
+	p, err := ToSubstrait(plan)
+	require.NoError(t, err)
+
+	// Scans read the static columns and the dynamic columns the plan refers
+	// to, the limit and the offset are a single fetch.
+	root := p.GetRelations()[0].GetRoot()
+	require.Equal(t, []string{"labels.namespace", "total"}, root.GetNames())
+	fetch := root.GetInput().GetFetch()
+	require.Equal(t, int64(1), fetch.GetOffset())
+	require.Equal(t, int64(10), fetch.GetCount())
+	read := fetch.GetInput().GetSort().GetInput().GetAggregate().GetInput().GetFilter().GetInput().GetRead()
+	require.Equal(t, []string{"example_type", "stacktrace", "timestamp", "value", "labels.namespace"}, read.GetBaseSchema().GetNames())
+	require.Equal(t, []string{"table1"}, read.GetNamedTable().GetNames())
+
+	data, err := proto.Marshal(p)
+	require.NoError(t, err)
+	decoded := &substraitpb.Plan{}
+	require.NoError(t, proto.Unmarshal(data, decoded))
+
+	// Fields of relations don't have names in Substrait, so the aggregation
+	// is aliased by the names of the root.
+	res, err := FromSubstrait(decoded, provider)
+	require.NoError(t, err)
+	expected, err := (&Builder{}).
+		Scan(provider, "table1").
+		Filter(And(
+			Col("labels.namespace").Eq(Literal("default")),
+			Not(Col("value").Lt(Literal(int64(1)))),
+		)).
+		Aggregate(Sum(Col("value")), Col("labels.namespace")).
+		OrderBy(Desc(Col("sum(value)"))).
+		Offset(1).
+		Limit(10).
+		Project(Col("labels.namespace"), Col("sum(value)").Alias("total")).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), res.String())
+}
+
+func TestFromSubstrait(t *testing.T) {
+	provider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+
+	field := func(i int32) *substraitpb.Expression {
+		return fieldReference(int(i))
+	}
+	call := func(anchor uint32, args ...*substraitpb.Expression) *substraitpb.Expression {
+		f := &substraitpb.Expression_ScalarFunction{FunctionReference: anchor}
+		for _, arg := range args {
+			f.Arguments = append(f.Arguments, valueArgument(arg))
+		}
+		return &substraitpb.Expression{RexType: &substraitpb.Expression_ScalarFunction_{ScalarFunction: f}}
+	}
+	i32 := func(v int32) *substraitpb.Expression {
+		return &substraitpb.Expression{RexType: &substraitpb.Expression_Literal_{
+			Literal: &substraitpb.Expression_Literal{LiteralType: &substraitpb.Expression_Literal_I32{I32: v}},
+		}}
+	}
+
+	// A projection of a read of projected fields with a filter, as other
+	// producers build them, with functions named by their signatures.
+	p := &substraitpb.Plan{
+		ExtensionUris: []*extensionspb.SimpleExtensionURI{{ExtensionUriAnchor: 1, Uri: "functions_arithmetic.yaml"}},
+		Extensions: []*extensionspb.SimpleExtensionDeclaration{{
+			MappingType: &extensionspb.SimpleExtensionDeclaration_ExtensionFunction_{
+				ExtensionFunction: &extensionspb.SimpleExtensionDeclaration_ExtensionFunction{
+					ExtensionUriReference: 1,
+					FunctionAnchor:        7,
+					Name:                  "multiply:i64_i64",
+				},
+			},
+		}, {
+			MappingType: &extensionspb.SimpleExtensionDeclaration_ExtensionFunction_{
+				ExtensionFunction: &extensionspb.SimpleExtensionDeclaration_ExtensionFunction{
+					ExtensionUriReference: 1,
+					FunctionAnchor:        8,
+					Name:                  "gt:i64_i64",
+				},
+			},
+		}},
+		Relations: []*substraitpb.PlanRel{{RelType: &substraitpb.PlanRel_Root{Root: &substraitpb.RelRoot{
+			Names: []string{"doubled"},
+			Input: &substraitpb.Rel{RelType: &substraitpb.Rel_Project{Project: &substraitpb.ProjectRel{
+				Common: &substraitpb.RelCommon{EmitKind: &substraitpb.RelCommon_Emit_{
+					Emit: &substraitpb.RelCommon_Emit{OutputMapping: []int32{2}},
+				}},
+				Expressions: []*substraitpb.Expression{call(7, field(1), i32(2))},
+				Input: &substraitpb.Rel{RelType: &substraitpb.Rel_Read{Read: &substraitpb.ReadRel{
+					BaseSchema: &substraitpb.NamedStruct{Names: []string{"timestamp", "value"}},
+					Filter:     call(8, field(0), i32(0)),
+					Projection: &substraitpb.Expression_MaskExpression{
+						Select: &substraitpb.Expression_MaskExpression_StructSelect{
+							StructItems: []*substraitpb.Expression_MaskExpression_StructItem{{Field: 0}, {Field: 1}},
+						},
+					},
+					ReadType: &substraitpb.ReadRel_NamedTable_{NamedTable: &substraitpb.ReadRel_NamedTable{
+						Names: []string{"db", "table1"},
+					}},
+				}}},
+			}}},
+		}}}},
+	}
+
+	res, err := FromSubstrait(p, provider)
+	require.NoError(t, err)
+	expected, err := (&Builder{}).
+		Scan(provider, "table1").
+		Filter(Col("timestamp").Gt(Literal(int64(0)))).
+		Project(Col("value").Mul(Literal(int64(2))).Alias("doubled")).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), res.String())
+
+	// Functions frostdb doesn't have can't be converted.
+	p.Extensions[0].GetExtensionFunction().Name = "power"
+	_, err = FromSubstrait(p, provider)
+	require.Error(t, err)
+}
+
+func TestToSubstraitUnsupported(t *testing.T) {
+	provider := &mockTableProvider{schema: dynparquet.NewSampleSchema()}
+
+	for name, builder := range map[string]Builder{
+		"dynamic column": (&Builder{}).Scan(provider, "table1").Project(DynCol("labels")),
+		"regex match":    (&Builder{}).Scan(provider, "table1").Filter(Col("labels.namespace").RegexMatch("d.*")),
+		"sample":         (&Builder{}).Scan(provider, "table1").Sample(0.5),
+	} {
+		t.Run(name, func(t *testing.T) {
+			plan, err := builder.Build()
+			require.NoError(t, err)
+			_, err = ToSubstrait(plan)
+			require.Error(t, err)
+		})
+	}
+}
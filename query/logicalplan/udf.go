@@ -0,0 +1,264 @@
+package logicalplan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+)
+
+// ErrUnknownFunction is returned when a plan calls a function that isn't
+// registered.
+var ErrUnknownFunction = errors.New("unknown function")
+
+// UserDefinedFunction computes the result of a function for each row of the
+// argument arrays. The result must have as many rows as the arguments.
+type UserDefinedFunction func(pool memory.Allocator, args []arrow.Array) (arrow.Array, error)
+
+// FunctionDefinition is a user-defined function together with the type of
+// its results.
+type FunctionDefinition struct {
+	ReturnType arrow.DataType
+	Func       UserDefinedFunction
+}
+
+// CallExpr calls a user-defined function by name. The function is looked up
+// when the plan is resolved, until then its type is unknown and typed as null.
+type CallExpr struct {
+	Func string
+	Args []Expr
+
+	def *FunctionDefinition
+}
+
+func Call(name string, args ...Expr) *CallExpr {
+	return &CallExpr{
+		Func: name,
+		Args: args,
+	}
+}
+
+// Definition returns the definition of the called function, or nil if the
+// call hasn't been resolved.
+func (c *CallExpr) Definition() *FunctionDefinition {
+	return c.def
+}
+
+func (c *CallExpr) DataType(_ *dynparquet.Schema) (arrow.DataType, error) {
+	if c.def == nil {
+		return arrow.Null, nil
+	}
+	return c.def.ReturnType, nil
+}
+
+func (c *CallExpr) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(c)
+	if !continu {
+		return false
+	}
+
+	for _, arg := range c.Args {
+		continu = arg.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(c)
+}
+
+func (c *CallExpr) Clone() Expr {
+	return &CallExpr{
+		Func: c.Func,
+		Args: cloneExprs(c.Args),
+		def:  c.def,
+	}
+}
+
+func (c *CallExpr) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(c) {
+		return c
+	}
+
+	return rewriter.PostRewrite(&CallExpr{
+		Func: c.Func,
+		Args: rewriteExprs(c.Args, rewriter),
+		def:  c.def,
+	})
+}
+
+func (c *CallExpr) Name() string {
+	args := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, arg.Name())
+	}
+	return c.Func + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (c *CallExpr) ColumnsUsedExprs() []Expr {
+	var exprs []Expr
+	for _, arg := range c.Args {
+		exprs = append(exprs, arg.ColumnsUsedExprs()...)
+	}
+	return exprs
+}
+
+func (c *CallExpr) MatchColumn(columnName string) bool {
+	return c.Name() == columnName
+}
+
+func (c *CallExpr) Computed() bool {
+	return true
+}
+
+func (c *CallExpr) Alias(alias string) *AliasExpr {
+	return &AliasExpr{Expr: c, Alias: alias}
+}
+
+func (c *CallExpr) Eq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpEq,
+		Right: expr,
+	}
+}
+
+func (c *CallExpr) NotEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpNotEq,
+		Right: expr,
+	}
+}
+
+func (c *CallExpr) Gt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpGt,
+		Right: expr,
+	}
+}
+
+func (c *CallExpr) GtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpGtEq,
+		Right: expr,
+	}
+}
+
+func (c *CallExpr) Lt(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpLt,
+		Right: expr,
+	}
+}
+
+func (c *CallExpr) LtEq(expr Expr) *BinaryExpr {
+	return &BinaryExpr{
+		Left:  c,
+		Op:    OpLtEq,
+		Right: expr,
+	}
+}
+
+// UserDefinedAggregation is a custom aggregation computed by a hash
+// aggregation. A new instance is created for each execution of a query, and
+// Init is called before any values are aggregated.
+type UserDefinedAggregation interface {
+	// Init prepares the aggregation to aggregate values.
+	Init(pool memory.Allocator) error
+	// Update aggregates the values of arr into their groups, groups[i] being
+	// the index of the group the value at i belongs to.
+	Update(arr arrow.Array, groups []int) error
+	// Finalize returns the result of the aggregation for each of the groups.
+	Finalize(numGroups int) (arrow.Array, error)
+}
+
+// SnapshotAggregation is a user-defined aggregation that can return its
+// results before all values are aggregated, which allows queries to pass on
+// partial results of aggregations.
+type SnapshotAggregation interface {
+	UserDefinedAggregation
+	// Snapshot returns the result of the aggregation for each of the groups
+	// so far, after which more values can be aggregated.
+	Snapshot(numGroups int) (arrow.Array, error)
+}
+
+// AggregationDefinition is a user-defined aggregation together with the type
+// of its results.
+type AggregationDefinition struct {
+	ReturnType arrow.DataType
+	New        func() UserDefinedAggregation
+}
+
+// UserAggregation aggregates the values of the expression with the
+// user-defined aggregation registered under the given name.
+func UserAggregation(name string, expr Expr) *AggregationFunction {
+	return &AggregationFunction{
+		Func:        AggFuncUserDefined,
+		UserDefined: name,
+		Expr:        expr,
+	}
+}
+
+// Definition returns the definition of a user-defined aggregation, or nil if
+// the aggregation is built-in or hasn't been resolved.
+func (f *AggregationFunction) Definition() *AggregationDefinition {
+	return f.def
+}
+
+// Functions looks up user-defined functions and aggregations by name.
+type Functions interface {
+	Function(name string) (*FunctionDefinition, bool)
+	Aggregation(name string) (*AggregationDefinition, bool)
+}
+
+// ResolveFunctions returns a copy of the plan with all function calls and
+// user-defined aggregations resolved to their definitions.
+func (plan *LogicalPlan) ResolveFunctions(functions Functions) (*LogicalPlan, error) {
+	r := &functionResolver{functions: functions}
+	res := plan.RewriteExprs(r)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return res, nil
+}
+
+type functionResolver struct {
+	functions Functions
+	err       error
+}
+
+func (r *functionResolver) PreRewrite(expr Expr) bool {
+	return r.err == nil
+}
+
+func (r *functionResolver) PostRewrite(expr Expr) Expr {
+	switch e := expr.(type) {
+	case *CallExpr:
+		def, ok := r.functions.Function(e.Func)
+		if !ok {
+			r.err = fmt.Errorf("%w: %s", ErrUnknownFunction, e.Func)
+			return expr
+		}
+		e.def = def
+	case *AggregationFunction:
+		if e.Func != AggFuncUserDefined {
+			return expr
+		}
+		def, ok := r.functions.Aggregation(e.UserDefined)
+		if !ok {
+			r.err = fmt.Errorf("%w: %s", ErrUnknownFunction, e.UserDefined)
+			return expr
+		}
+		e.def = def
+	}
+
+	return expr
+}
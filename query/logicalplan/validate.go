@@ -2,11 +2,16 @@ package logicalplan
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 
+	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/segmentio/parquet-go"
 	"github.com/segmentio/parquet-go/format"
+
+	"github.com/polarsignals/frostdb/dynparquet"
 )
 
 // PlanValidationError is the error representing a logical plan that is not valid.
@@ -75,9 +80,27 @@ func Validate(plan *LogicalPlan) error {
 		case plan.Distinct != nil:
 			err = nil
 		case plan.Projection != nil:
-			err = nil
+			err = ValidateProjection(plan)
 		case plan.Aggregation != nil:
 			err = ValidateAggregation(plan)
+		case plan.Having != nil:
+			err = ValidateHaving(plan)
+		case plan.Window != nil:
+			err = ValidateWindow(plan)
+		case plan.OrderBy != nil:
+			err = ValidateOrderBy(plan)
+		case plan.Limit != nil:
+			err = validateCount(plan, "limit", plan.Limit.Count)
+		case plan.Offset != nil:
+			err = validateCount(plan, "offset", plan.Offset.Count)
+		case plan.Join != nil:
+			err = ValidateJoin(plan)
+		case plan.Union != nil:
+			err = ValidateUnion(plan)
+		case plan.Sample != nil:
+			err = ValidateSample(plan)
+		case plan.Unpivot != nil:
+			err = ValidateUnpivot(plan)
 		}
 	}
 
@@ -118,10 +141,37 @@ func ValidateSingleFieldSet(plan *LogicalPlan) *PlanValidationError {
 	if plan.Aggregation != nil {
 		fieldsSet = append(fieldsSet, 5)
 	}
+	if plan.Having != nil {
+		fieldsSet = append(fieldsSet, 6)
+	}
+	if plan.Window != nil {
+		fieldsSet = append(fieldsSet, 7)
+	}
+	if plan.OrderBy != nil {
+		fieldsSet = append(fieldsSet, 8)
+	}
+	if plan.Limit != nil {
+		fieldsSet = append(fieldsSet, 9)
+	}
+	if plan.Offset != nil {
+		fieldsSet = append(fieldsSet, 10)
+	}
+	if plan.Join != nil {
+		fieldsSet = append(fieldsSet, 11)
+	}
+	if plan.Union != nil {
+		fieldsSet = append(fieldsSet, 12)
+	}
+	if plan.Sample != nil {
+		fieldsSet = append(fieldsSet, 13)
+	}
+	if plan.Unpivot != nil {
+		fieldsSet = append(fieldsSet, 14)
+	}
 
 	if len(fieldsSet) != 1 {
 		fieldsFound := make([]string, 0)
-		fields := []string{"SchemaScan", "TableScan", "Filter", "Distinct", "Projection", "Aggregation"}
+		fields := []string{"SchemaScan", "TableScan", "Filter", "Distinct", "Projection", "Aggregation", "Having", "Window", "OrderBy", "Limit", "Offset", "Join", "Union", "Sample", "Unpivot"}
 		for _, i := range fieldsSet {
 			fieldsFound = append(fieldsFound, fields[i])
 		}
@@ -145,39 +195,61 @@ func ValidateSingleFieldSet(plan *LogicalPlan) *PlanValidationError {
 
 // ValidateAggregation validates the logical plan's aggregation step.
 func ValidateAggregation(plan *LogicalPlan) *PlanValidationError {
-	// check that the expression is not nil
-	if plan.Aggregation.AggExpr == nil {
+	if len(plan.Aggregation.AggExprs) == 0 {
 		return &PlanValidationError{
 			plan:    plan,
-			message: "invalid aggregation: expression cannot be nil",
+			message: "invalid aggregation: at least one aggregation expression is required",
 		}
 	}
 
-	// check that the expression is valid
-	aggExprError := ValidateAggregationExpr(plan)
-	if aggExprError != nil {
-		return &PlanValidationError{
-			plan:     plan,
-			message:  "invalid aggregation",
-			children: []*ExprValidationError{aggExprError},
+	for _, aggExpr := range plan.Aggregation.AggExprs {
+		// check that the expression is not nil
+		if aggExpr == nil {
+			return &PlanValidationError{
+				plan:    plan,
+				message: "invalid aggregation: expression cannot be nil",
+			}
+		}
+
+		// check that the expression is valid
+		aggExprError := ValidateAggregationExpr(plan, aggExpr)
+		if aggExprError != nil {
+			return &PlanValidationError{
+				plan:     plan,
+				message:  "invalid aggregation",
+				children: []*ExprValidationError{aggExprError},
+			}
+		}
+	}
+
+	if hasComputedInput(plan) {
+		return nil
+	}
+	for _, expr := range append(append([]Expr{}, plan.Aggregation.AggExprs...), plan.Aggregation.GroupExprs...) {
+		if err := ValidateExprTypes(plan, expr); err != nil {
+			return &PlanValidationError{
+				plan:     plan,
+				message:  "invalid aggregation",
+				children: []*ExprValidationError{err},
+			}
 		}
 	}
 
 	return nil
 }
 
-func ValidateAggregationExpr(plan *LogicalPlan) *ExprValidationError {
+func ValidateAggregationExpr(plan *LogicalPlan, aggExpr Expr) *ExprValidationError {
 	// check that the aggregation expression has the required structure
 	colFinder := newTypeFinder((*Column)(nil))
-	plan.Aggregation.AggExpr.Accept(&colFinder)
+	aggExpr.Accept(&colFinder)
 
 	aggFuncFinder := newTypeFinder((*AggregationFunction)(nil))
-	plan.Aggregation.AggExpr.Accept(&aggFuncFinder)
+	aggExpr.Accept(&aggFuncFinder)
 
 	if colFinder.result == nil || aggFuncFinder.result == nil {
 		return &ExprValidationError{
 			message: "aggregation expression is invalid. must contain AggregationFunction and Column",
-			expr:    plan.Aggregation.AggExpr,
+			expr:    aggExpr,
 		}
 	}
 
@@ -188,21 +260,55 @@ func ValidateAggregationExpr(plan *LogicalPlan) *ExprValidationError {
 		return nil // cannot check column type if there's no input schema
 	}
 
-	column, found := schema.ColumnByName(colExpr.ColumnName)
+	column, found := schema.FindColumn(colExpr.ColumnName)
 	if !found {
 		return &ExprValidationError{
 			message: fmt.Sprintf("column not found: %s", colExpr.ColumnName),
-			expr:    plan.Aggregation.AggExpr,
+			expr:    aggExpr,
+		}
+	}
+
+	aggFuncExpr := aggFuncFinder.result.(*AggregationFunction)
+	if aggFuncExpr.Filter != nil {
+		if err := ValidateFilterExpr(plan, aggFuncExpr.Filter); err != nil {
+			return err
+		}
+	}
+
+	switch aggFuncExpr.Func {
+	case AggFuncFirstBy, AggFuncLastBy:
+		if aggFuncExpr.OrderBy == nil {
+			return &ExprValidationError{
+				message: fmt.Sprintf("%s aggregation requires an order by expression", aggFuncExpr.Func),
+				expr:    aggExpr,
+			}
+		}
+	case AggFuncQuantile, AggFuncQuantiles:
+		if len(aggFuncExpr.Quantiles) == 0 {
+			return &ExprValidationError{
+				message: "quantile aggregation requires at least one quantile",
+				expr:    aggExpr,
+			}
+		}
+		for _, q := range aggFuncExpr.Quantiles {
+			if !(q >= 0 && q <= 1) {
+				return &ExprValidationError{
+					message: fmt.Sprintf("quantile must be between 0 and 1, got %v", q),
+					expr:    aggExpr,
+				}
+			}
 		}
 	}
 
 	// check that the column type can be aggregated by the function type
 	columnType := column.StorageLayout.Type()
-	aggFuncExpr := aggFuncFinder.result.(*AggregationFunction)
-	if aggFuncExpr.Func == AggFuncSum && columnType.LogicalType().UTF8 != nil {
-		return &ExprValidationError{
-			message: "cannot sum text column",
-			expr:    plan.Aggregation.AggExpr,
+	switch aggFuncExpr.Func {
+	case AggFuncSum, AggFuncAvg, AggFuncQuantile, AggFuncQuantiles, AggFuncVariance, AggFuncStdDev:
+		if lt := columnType.LogicalType(); lt != nil && lt.UTF8 != nil {
+			return &ExprValidationError{
+				message: fmt.Sprintf("cannot %s text column", aggFuncExpr.Func),
+				expr:    aggExpr,
+			}
 		}
 	}
 
@@ -226,8 +332,238 @@ func ValidateInput(plan *LogicalPlan) *PlanValidationError {
 	return nil
 }
 
+// ValidateProjection validates the logical plan's projection step.
+func ValidateProjection(plan *LogicalPlan) *PlanValidationError {
+	// Projections of an aggregation or window reference their result
+	// columns, which aren't part of the table's schema.
+	checkTypes := !hasComputedInput(plan)
+	for _, expr := range plan.Projection.Exprs {
+		if err := ValidateCastExprs(plan, expr); err != nil {
+			return &PlanValidationError{
+				message:  "invalid projection",
+				plan:     plan,
+				children: []*ExprValidationError{err},
+			}
+		}
+		if !checkTypes {
+			continue
+		}
+		if err := ValidateExprTypes(plan, expr); err != nil {
+			return &PlanValidationError{
+				message:  "invalid projection",
+				plan:     plan,
+				children: []*ExprValidationError{err},
+			}
+		}
+	}
+	return nil
+}
+
+// hasComputedInput returns whether any input of the plan computes columns
+// that aren't part of the table's schema.
+func hasComputedInput(plan *LogicalPlan) bool {
+	return computesColumns(plan.Input)
+}
+
+// computesColumns returns whether the plan or any of its inputs computes
+// columns that aren't part of the table's schema, the columns of joined
+// plans are part of another table's schema.
+func computesColumns(plan *LogicalPlan) bool {
+	for ; plan != nil; plan = plan.Input {
+		if plan.Aggregation != nil || plan.Window != nil || plan.Join != nil || plan.Unpivot != nil {
+			return true
+		}
+		if plan.Union != nil {
+			for _, p := range plan.Union.Plans {
+				if computesColumns(p) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ValidateCastExprs validates that all the casts within the expression
+// convert between types that can be converted.
+func ValidateCastExprs(plan *LogicalPlan, e Expr) *ExprValidationError {
+	schema := plan.InputSchema()
+	if schema == nil {
+		return nil // cannot check types if there's no input schema
+	}
+
+	castFinder := newAllTypeFinder((*CastExpr)(nil))
+	e.Accept(&castFinder)
+	for _, expr := range castFinder.results {
+		cast := expr.(*CastExpr)
+		if _, err := cast.Expr.DataType(schema); err != nil {
+			// The input type can't be determined, e.g. for dynamic columns
+			// that haven't been written yet.
+			continue
+		}
+		if _, err := cast.DataType(schema); err != nil {
+			return &ExprValidationError{
+				message: err.Error(),
+				expr:    cast,
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateExprTypes infers the types of the expression and all of its
+// sub-expressions. It returns an error for columns that don't exist in the
+// schema and for operands whose types can't be compared or combined.
+func ValidateExprTypes(plan *LogicalPlan, e Expr) *ExprValidationError {
+	schema := plan.InputSchema()
+	if schema == nil {
+		return nil // cannot check types if there's no input schema
+	}
+
+	v := &typeCheckVisitor{schema: schema}
+	e.Accept(v)
+	return v.err
+}
+
+// typeCheckVisitor checks the types of expressions bottom up, so the first
+// error found is reported for the innermost invalid expression.
+type typeCheckVisitor struct {
+	schema *dynparquet.Schema
+	err    *ExprValidationError
+}
+
+func (v *typeCheckVisitor) PreVisit(expr Expr) bool {
+	return true
+}
+
+func (v *typeCheckVisitor) PostVisit(expr Expr) bool {
+	v.err = v.checkExpr(expr)
+	return v.err == nil
+}
+
+func (v *typeCheckVisitor) checkExpr(expr Expr) *ExprValidationError {
+	switch e := expr.(type) {
+	case *Column:
+		if _, err := e.DataType(v.schema); err != nil {
+			return &ExprValidationError{
+				message: "unknown column " + e.ColumnName,
+				expr:    e,
+			}
+		}
+	case *RegexColumn:
+		if _, err := e.DataType(v.schema); err != nil {
+			return &ExprValidationError{
+				message: err.Error(),
+				expr:    e,
+			}
+		}
+	case *BinaryExpr:
+		if e.Op == OpAnd || e.Op == OpOr {
+			return nil
+		}
+		if _, err := e.DataType(v.schema); err != nil {
+			return &ExprValidationError{
+				message: err.Error(),
+				expr:    e,
+			}
+		}
+		if expanded, ok := ExpandTupleComparison(e); ok {
+			// The elements of the tuples have been checked already, only
+			// the comparisons between them remain.
+			return v.checkExpandedTuple(expanded)
+		}
+		if !e.Op.IsArithmetic() {
+			return v.checkComparable(e, e.Left, e.Right)
+		}
+	case *BetweenExpr:
+		if err := v.checkComparable(e, e.Expr, e.Low); err != nil {
+			return err
+		}
+		return v.checkComparable(e, e.Expr, e.High)
+	}
+
+	return nil
+}
+
+func (v *typeCheckVisitor) checkExpandedTuple(expr Expr) *ExprValidationError {
+	switch e := expr.(type) {
+	case *BinaryExpr:
+		if e.Op == OpAnd || e.Op == OpOr {
+			if err := v.checkExpandedTuple(e.Left); err != nil {
+				return err
+			}
+			return v.checkExpandedTuple(e.Right)
+		}
+		return v.checkComparable(e, e.Left, e.Right)
+	}
+	return nil
+}
+
+func (v *typeCheckVisitor) checkComparable(expr, left, right Expr) *ExprValidationError {
+	leftType, err := left.DataType(v.schema)
+	if err != nil {
+		return &ExprValidationError{
+			message: err.Error(),
+			expr:    expr,
+		}
+	}
+
+	rightType, err := right.DataType(v.schema)
+	if err != nil {
+		return &ExprValidationError{
+			message: err.Error(),
+			expr:    expr,
+		}
+	}
+
+	if !ComparableTypes(leftType, rightType) {
+		return &ExprValidationError{
+			message: fmt.Sprintf("type mismatch: %s cannot be compared with %s", leftType.Name(), rightType.Name()),
+			expr:    expr,
+		}
+	}
+
+	return nil
+}
+
+// ComparableTypes returns whether values of the two types can be compared
+// with each other. Numbers can be compared with numbers and strings with
+// strings, null can be compared with anything.
+func ComparableTypes(left, right arrow.DataType) bool {
+	if left.ID() == arrow.NULL || right.ID() == arrow.NULL {
+		return true
+	}
+
+	leftFamily, rightFamily := typeFamily(left), typeFamily(right)
+	return leftFamily != "" && leftFamily == rightFamily
+}
+
+func typeFamily(t arrow.DataType) string {
+	switch t.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64,
+		arrow.FLOAT32, arrow.FLOAT64, arrow.DECIMAL128, arrow.TIMESTAMP:
+		return "numeric"
+	case arrow.STRING, arrow.BINARY:
+		return "string"
+	case arrow.BOOL:
+		return "bool"
+	case arrow.FIXED_SIZE_BINARY:
+		return "fixed_size_binary"
+	default:
+		return ""
+	}
+}
+
 // ValidateFilter validates the logical plan's filter step.
 func ValidateFilter(plan *LogicalPlan) *PlanValidationError {
+	if err := ValidateCastExprs(plan, plan.Filter.Expr); err != nil {
+		return &PlanValidationError{
+			message:  "invalid filter",
+			plan:     plan,
+			children: []*ExprValidationError{err},
+		}
+	}
 	if err := ValidateFilterExpr(plan, plan.Filter.Expr); err != nil {
 		return &PlanValidationError{
 			message:  "invalid filter",
@@ -235,15 +571,489 @@ func ValidateFilter(plan *LogicalPlan) *PlanValidationError {
 			children: []*ExprValidationError{err},
 		}
 	}
+	if hasComputedInput(plan) {
+		return nil
+	}
+	if err := ValidateExprTypes(plan, plan.Filter.Expr); err != nil {
+		return &PlanValidationError{
+			message:  "invalid filter",
+			plan:     plan,
+			children: []*ExprValidationError{err},
+		}
+	}
+	return nil
+}
+
+// ValidateHaving validates the logical plan's having step. It must filter the
+// output of an aggregation by the aggregation's result and group columns.
+func ValidateHaving(plan *LogicalPlan) *PlanValidationError {
+	if plan.Having.Expr == nil {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid having: expression cannot be nil",
+		}
+	}
+
+	if plan.Input == nil || plan.Input.Aggregation == nil {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid having: input must be an aggregation",
+		}
+	}
+
+	agg := plan.Input.Aggregation
+	for _, expr := range plan.Having.Expr.ColumnsUsedExprs() {
+		if !aggregationResultColumn(agg, expr.Name()) {
+			return &PlanValidationError{
+				message: "invalid having",
+				plan:    plan,
+				children: []*ExprValidationError{{
+					message: fmt.Sprintf("column is not a result of the aggregation: %s", expr.Name()),
+					expr:    plan.Having.Expr,
+				}},
+			}
+		}
+	}
+
+	if err := ValidateCastExprs(plan, plan.Having.Expr); err != nil {
+		return &PlanValidationError{
+			message:  "invalid having",
+			plan:     plan,
+			children: []*ExprValidationError{err},
+		}
+	}
+	if err := ValidateFilterExpr(plan, plan.Having.Expr); err != nil {
+		return &PlanValidationError{
+			message:  "invalid having",
+			plan:     plan,
+			children: []*ExprValidationError{err},
+		}
+	}
 	return nil
 }
 
+// ValidateWindow validates the logical plan's window step.
+func ValidateWindow(plan *LogicalPlan) *PlanValidationError {
+	if len(plan.Window.Exprs) == 0 {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid window: at least one window function is required",
+		}
+	}
+
+	if _, ok := plan.Window.OrderBy.(*Column); !ok {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid window: must be ordered by a column",
+		}
+	}
+
+	// The types of the columns are only known if they are read from the
+	// table, not computed by a preceding aggregation or window.
+	checkTypes := !hasComputedInput(plan) && plan.InputSchema() != nil
+	if checkTypes {
+		dataType, err := plan.Window.OrderBy.DataType(plan.InputSchema())
+		if err != nil || (dataType.ID() != arrow.INT64 && dataType.ID() != arrow.TIMESTAMP) {
+			return &PlanValidationError{
+				plan:    plan,
+				message: "invalid window: order column must be an integer or timestamp column",
+			}
+		}
+	}
+
+	for _, expr := range plan.Window.Exprs {
+		if err := ValidateWindowExpr(plan, expr, checkTypes); err != nil {
+			return &PlanValidationError{
+				plan:     plan,
+				message:  "invalid window",
+				children: []*ExprValidationError{err},
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateWindowExpr validates a window function of the window step.
+func ValidateWindowExpr(plan *LogicalPlan, expr Expr, checkTypes bool) *ExprValidationError {
+	if alias, ok := expr.(*AliasExpr); ok {
+		expr = alias.Expr
+	}
+
+	f, ok := expr.(*WindowFunction)
+	if !ok {
+		return &ExprValidationError{
+			message: "window expression must be a window function",
+			expr:    expr,
+		}
+	}
+
+	if f.Func == WindowFuncRowNumber {
+		return nil
+	}
+
+	if _, ok := f.Expr.(*Column); !ok {
+		return &ExprValidationError{
+			message: fmt.Sprintf("%s window function requires a column", f.Func),
+			expr:    expr,
+		}
+	}
+
+	switch f.Func {
+	case WindowFuncLag, WindowFuncLead:
+		if f.Offset < 0 {
+			return &ExprValidationError{
+				message: fmt.Sprintf("%s offset must not be negative, got %d", f.Func, f.Offset),
+				expr:    expr,
+			}
+		}
+	case WindowFuncMovingSum, WindowFuncMovingAvg:
+		if f.Width <= 0 {
+			return &ExprValidationError{
+				message: fmt.Sprintf("%s width must be positive, got %d", f.Func, f.Width),
+				expr:    expr,
+			}
+		}
+		if !checkTypes {
+			return nil
+		}
+		dataType, err := f.Expr.DataType(plan.InputSchema())
+		if err != nil {
+			return &ExprValidationError{
+				message: err.Error(),
+				expr:    expr,
+			}
+		}
+		if dataType.ID() != arrow.INT64 && dataType.ID() != arrow.FLOAT64 {
+			return &ExprValidationError{
+				message: fmt.Sprintf("cannot compute %s of %s column", f.Func, dataType.Name()),
+				expr:    expr,
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateOrderBy validates the logical plan's order by step.
+func ValidateOrderBy(plan *LogicalPlan) *PlanValidationError {
+	if len(plan.OrderBy.Exprs) == 0 {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid order by: at least one sort expression is required",
+		}
+	}
+
+	// The columns of aggregations and windows aren't part of the table's
+	// schema.
+	checkColumns := !hasComputedInput(plan) && plan.InputSchema() != nil
+	for _, e := range plan.OrderBy.Exprs {
+		col, ok := e.Expr.(*Column)
+		if !ok {
+			return &PlanValidationError{
+				plan:    plan,
+				message: "invalid order by: rows can only be sorted by columns",
+			}
+		}
+		if !checkColumns {
+			continue
+		}
+		if _, found := plan.InputSchema().FindColumn(col.ColumnName); !found {
+			return &PlanValidationError{
+				plan:     plan,
+				message:  "invalid order by",
+				children: []*ExprValidationError{{message: "unknown column " + col.ColumnName, expr: col}},
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateJoin validates the logical plan's join step and the plan it joins.
+// The keys must be columns, and the keys joined must have the same type.
+func ValidateJoin(plan *LogicalPlan) *PlanValidationError {
+	join := plan.Join
+	if join.Right == nil {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid join: right plan cannot be nil",
+		}
+	}
+	if err := Validate(join.Right); err != nil {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid join: invalid right plan",
+			input:   err.(*PlanValidationError),
+		}
+	}
+
+	if len(join.LeftKeys) == 0 {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid join: at least one key is required",
+		}
+	}
+	if len(join.LeftKeys) != len(join.RightKeys) {
+		return &PlanValidationError{
+			plan:    plan,
+			message: fmt.Sprintf("invalid join: expected %d right keys, got %d", len(join.LeftKeys), len(join.RightKeys)),
+		}
+	}
+
+	for i := range join.LeftKeys {
+		leftType, err := joinKeyType(plan.Input, join.LeftKeys[i])
+		if err == nil {
+			var rightType arrow.DataType
+			rightType, err = joinKeyType(join.Right, join.RightKeys[i])
+			if err == nil && leftType != nil && rightType != nil && !arrow.TypeEqual(leftType, rightType) {
+				err = &ExprValidationError{
+					message: fmt.Sprintf("cannot join %s column %s with %s column", leftType, join.LeftKeys[i].Name(), rightType),
+					expr:    join.RightKeys[i],
+				}
+			}
+		}
+		if err != nil {
+			return &PlanValidationError{
+				plan:     plan,
+				message:  "invalid join",
+				children: []*ExprValidationError{err},
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateUnion validates the logical plan's union step and the plans it
+// unites. The tables the plans read must have the same columns.
+func ValidateUnion(plan *LogicalPlan) *PlanValidationError {
+	union := plan.Union
+	if len(union.Plans) == 0 {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid union: at least one plan is required",
+		}
+	}
+
+	var first *dynparquet.Schema
+	for i, p := range union.Plans {
+		if p == nil {
+			return &PlanValidationError{
+				plan:    plan,
+				message: fmt.Sprintf("invalid union: plan %d cannot be nil", i),
+			}
+		}
+		if err := Validate(p); err != nil {
+			return &PlanValidationError{
+				plan:    plan,
+				message: fmt.Sprintf("invalid union: invalid plan %d", i),
+				input:   err.(*PlanValidationError),
+			}
+		}
+
+		schema := p.InputSchema()
+		if schema == nil {
+			continue
+		}
+		if first == nil {
+			first = schema
+			continue
+		}
+		if err := compatibleSchemas(first, schema); err != "" {
+			return &PlanValidationError{
+				plan:    plan,
+				message: fmt.Sprintf("invalid union: plan %d reads a table with different columns: %s", i, err),
+			}
+		}
+	}
+	return nil
+}
+
+// compatibleSchemas returns why tables with the schemas can't be united, or
+// an empty string if they have the same columns. Their sorting columns may
+// differ.
+func compatibleSchemas(a, b *dynparquet.Schema) string {
+	for _, col := range a.Columns() {
+		if _, ok := b.ColumnByName(col.Name); !ok {
+			return "missing column " + col.Name
+		}
+	}
+	for _, col := range b.Columns() {
+		other, ok := a.ColumnByName(col.Name)
+		if !ok {
+			return "unexpected column " + col.Name
+		}
+		if col.Dynamic != other.Dynamic ||
+			col.StorageLayout.Type().String() != other.StorageLayout.Type().String() ||
+			col.StorageLayout.Optional() != other.StorageLayout.Optional() ||
+			col.StorageLayout.Repeated() != other.StorageLayout.Repeated() {
+			return "column " + col.Name + " has a different definition"
+		}
+	}
+	return ""
+}
+
+// joinKeyType returns the type of a join key of the plan, or nil if the type
+// is only known once the plan is executed.
+func joinKeyType(plan *LogicalPlan, key Expr) (arrow.DataType, *ExprValidationError) {
+	col, ok := key.(*Column)
+	if !ok {
+		return nil, &ExprValidationError{
+			message: "join key must be a column",
+			expr:    key,
+		}
+	}
+
+	schema := plan.InputSchema()
+	if schema == nil || computesColumns(plan) {
+		return nil, nil
+	}
+	dataType, err := col.DataType(schema)
+	if err != nil {
+		return nil, &ExprValidationError{
+			message: "unknown column " + col.ColumnName,
+			expr:    col,
+		}
+	}
+	return dataType, nil
+}
+
+// ValidateSample validates the logical plan's sample step, the fraction of
+// rows sampled must be a probability.
+func ValidateSample(plan *LogicalPlan) *PlanValidationError {
+	fraction := plan.Sample.Fraction
+	if math.IsNaN(fraction) || fraction < 0 || fraction > 1 {
+		return &PlanValidationError{
+			plan:    plan,
+			message: fmt.Sprintf("invalid sample: fraction must be between 0 and 1, got %v", fraction),
+		}
+	}
+	return nil
+}
+
+// ValidateUnpivot validates the logical plan's unpivot step. The column must
+// be a dynamic column of the table, and the columns the rows are turned into
+// must have distinct names that aren't the names of columns of the table.
+func ValidateUnpivot(plan *LogicalPlan) *PlanValidationError {
+	unpivot := plan.Unpivot
+	if unpivot.Column == nil {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid unpivot: column cannot be nil",
+		}
+	}
+	if unpivot.NameColumn == "" || unpivot.ValueColumn == "" {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid unpivot: name and value columns must have names",
+		}
+	}
+	if unpivot.NameColumn == unpivot.ValueColumn {
+		return &PlanValidationError{
+			plan:    plan,
+			message: "invalid unpivot: name and value columns must have different names",
+		}
+	}
+
+	schema := plan.InputSchema()
+	if schema == nil || hasComputedInput(plan) {
+		return nil
+	}
+	if col, ok := schema.ColumnByName(unpivot.Column.ColumnName); !ok || !col.Dynamic {
+		return &PlanValidationError{
+			plan:    plan,
+			message: fmt.Sprintf("invalid unpivot: %s is not a dynamic column", unpivot.Column.ColumnName),
+		}
+	}
+	for _, name := range []string{unpivot.NameColumn, unpivot.ValueColumn} {
+		if _, ok := schema.FindColumn(name); ok {
+			return &PlanValidationError{
+				plan:    plan,
+				message: fmt.Sprintf("invalid unpivot: column %s already exists", name),
+			}
+		}
+	}
+	return nil
+}
+
+// validateCount validates the number of rows of a limit or offset step.
+func validateCount(plan *LogicalPlan, step string, count int64) *PlanValidationError {
+	if count < 0 {
+		return &PlanValidationError{
+			plan:    plan,
+			message: fmt.Sprintf("invalid %s: number of rows must not be negative, got %d", step, count),
+		}
+	}
+	return nil
+}
+
+// aggregationResultColumn returns whether the aggregation's output has the
+// column.
+func aggregationResultColumn(agg *Aggregation, columnName string) bool {
+	for _, expr := range agg.AggExprs {
+		if expr.Name() == columnName {
+			return true
+		}
+	}
+	for _, expr := range agg.GroupExprs {
+		if expr.Name() == columnName || expr.MatchColumn(columnName) {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateFilterExpr validates filter's expression.
 func ValidateFilterExpr(plan *LogicalPlan, e Expr) *ExprValidationError {
 	switch expr := e.(type) {
 	case *BinaryExpr:
 		err := ValidateFilterBinaryExpr(plan, expr)
 		return err
+	case *UnaryExpr:
+		return ValidateFilterExpr(plan, expr.Expr)
+	case *BetweenExpr:
+		return ValidateFilterBetweenExpr(plan, expr)
+	}
+
+	return nil
+}
+
+// ValidateFilterBetweenExpr validates that the bounds of a between expression
+// are literals that are compatible with the column being compared.
+func ValidateFilterBetweenExpr(plan *LogicalPlan, expr *BetweenExpr) *ExprValidationError {
+	bounds := make([]*LiteralExpr, 0, 2)
+	for _, bound := range []Expr{expr.Low, expr.High} {
+		switch b := bound.(type) {
+		case *LiteralExpr:
+			bounds = append(bounds, b)
+		case *PlaceholderExpr:
+			// Placeholders are typed once they are bound.
+		default:
+			return &ExprValidationError{
+				message: "bounds of between expression must be literals",
+				expr:    expr,
+			}
+		}
+	}
+
+	column, ok := expr.Expr.(*Column)
+	if !ok {
+		return nil
+	}
+
+	schema := plan.InputSchema()
+	if schema == nil {
+		return nil
+	}
+
+	def, found := schema.ColumnByName(column.ColumnName)
+	if !found {
+		return nil
+	}
+
+	t := def.StorageLayout.Type()
+	for _, l := range bounds {
+		if err := validateComparingColumn(t, OpGtEq, l.Value); err != nil {
+			err.expr = expr
+			return err
+		}
 	}
 
 	return nil
@@ -251,10 +1061,25 @@ func ValidateFilterExpr(plan *LogicalPlan, e Expr) *ExprValidationError {
 
 // ValidateFilterBinaryExpr validates the filter's binary expression.
 func ValidateFilterBinaryExpr(plan *LogicalPlan, expr *BinaryExpr) *ExprValidationError {
-	if expr.Op == OpAnd {
+	if expr.Op == OpAnd || expr.Op == OpOr {
 		return ValidateFilterAndBinaryExpr(plan, expr)
 	}
 
+	if _, ok := expr.Left.(*TupleExpr); ok {
+		return ValidateFilterTupleExpr(plan, expr)
+	}
+
+	if expr.Op == OpRegexMatch || expr.Op == OpRegexNotMatch {
+		if err := ValidateRegexpExpr(expr); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := expr.Left.(*RegexColumn); ok {
+		// The columns compared are only known at execution time.
+		return nil
+	}
+
 	// try to find the column expression on the left side of the binary expression
 	leftColumnFinder := newTypeFinder((*Column)(nil))
 	expr.Left.Accept(&leftColumnFinder)
@@ -265,10 +1090,11 @@ func ValidateFilterBinaryExpr(plan *LogicalPlan, expr *BinaryExpr) *ExprValidati
 		}
 	}
 
-	// try to find the column in the schema
+	// try to find the column in the schema, the column's type is only the
+	// type being compared if the left side is not computed
 	columnExpr := leftColumnFinder.result.(*Column)
 	schema := plan.InputSchema()
-	if schema != nil {
+	if schema != nil && !expr.Left.Computed() {
 		column, found := schema.ColumnByName(columnExpr.ColumnName)
 		if found {
 			// try to find the literal on the other side of the expression
@@ -278,7 +1104,7 @@ func ValidateFilterBinaryExpr(plan *LogicalPlan, expr *BinaryExpr) *ExprValidati
 				// ensure that the column type is compatible with the literal being compared to it
 				t := column.StorageLayout.Type()
 				literalExpr := rightLiteralFinder.result.(*LiteralExpr)
-				if err := ValidateComparingTypes(t.LogicalType(), literalExpr.Value); err != nil {
+				if err := validateComparingColumn(t, expr.Op, literalExpr.Value); err != nil {
 					err.expr = expr
 					return err
 				}
@@ -289,11 +1115,78 @@ func ValidateFilterBinaryExpr(plan *LogicalPlan, expr *BinaryExpr) *ExprValidati
 	return nil
 }
 
+// ValidateRegexpExpr validates that the pattern of a regex predicate is a
+// string literal that compiles.
+func ValidateRegexpExpr(expr *BinaryExpr) *ExprValidationError {
+	if _, ok := expr.Right.(*PlaceholderExpr); ok {
+		// The pattern is validated once the placeholder is bound.
+		return nil
+	}
+
+	literal, ok := expr.Right.(*LiteralExpr)
+	if !ok {
+		return &ExprValidationError{
+			message: "right side of regex expression must be a literal",
+			expr:    expr,
+		}
+	}
+
+	pattern, ok := literal.Value.(*scalar.String)
+	if !ok {
+		return &ExprValidationError{
+			message: "regex pattern must be a string",
+			expr:    expr,
+		}
+	}
+
+	if _, err := CompileRegexp(string(pattern.Data())); err != nil {
+		return &ExprValidationError{
+			message: "invalid regex pattern: " + err.Error(),
+			expr:    expr,
+		}
+	}
+
+	return nil
+}
+
+// validateComparingColumn validates if a column of the type can be compared
+// with the literal by the operator. Boolean and binary columns have no logical
+// type, booleans can only be compared with booleans for equality and binary
+// columns can't be compared with numbers.
+func validateComparingColumn(t parquet.Type, op Op, literal scalar.Scalar) *ExprValidationError {
+	if t.Kind() == parquet.ByteArray && t.LogicalType() == nil {
+		switch literal.(type) {
+		case *scalar.Int64, *scalar.Float64:
+			return &ExprValidationError{
+				message: "incompatible types: binary column cannot be compared with numeric literal",
+			}
+		}
+		return nil
+	}
+	if t.Kind() != parquet.Boolean {
+		return ValidateComparingTypes(t.LogicalType(), literal)
+	}
+
+	switch literal.(type) {
+	case *scalar.Boolean, *scalar.Null:
+	default:
+		return &ExprValidationError{
+			message: "incompatible types: boolean column cannot be compared with " + literal.DataType().Name() + " literal",
+		}
+	}
+	if op != OpEq && op != OpNotEq {
+		return &ExprValidationError{
+			message: "incompatible types: boolean column can only be compared for equality",
+		}
+	}
+	return nil
+}
+
 // ValidateComparingTypes validates if the types being compared by a binary expression are compatible.
 func ValidateComparingTypes(columnType *format.LogicalType, literal scalar.Scalar) *ExprValidationError {
 	switch {
 	// if the column is a string type, it shouldn't be compared to a number
-	case columnType.UTF8 != nil:
+	case columnType != nil && columnType.UTF8 != nil:
 		switch literal.(type) {
 		case *scalar.Float64:
 			return &ExprValidationError{
@@ -304,8 +1197,10 @@ func ValidateComparingTypes(columnType *format.LogicalType, literal scalar.Scala
 				message: "incompatible types: string column cannot be compared with numeric literal",
 			}
 		}
-	// if the column is a numeric type, it shouldn't be compared to a string
-	case columnType.Integer != nil:
+	// if the column is a numeric type, it shouldn't be compared to a string,
+	// doubles are numeric columns without a logical type and timestamps are
+	// compared as integers
+	case columnType == nil || columnType.Integer != nil || columnType.Decimal != nil || columnType.Timestamp != nil:
 		switch literal.(type) {
 		case *scalar.String:
 			return &ExprValidationError{
@@ -316,7 +1211,7 @@ func ValidateComparingTypes(columnType *format.LogicalType, literal scalar.Scala
 	return nil
 }
 
-// ValidateFilterAndBinaryExpr validates the filter's binary expression where Op = AND.
+// ValidateFilterAndBinaryExpr validates the filter's binary expression where Op = AND or Op = OR.
 func ValidateFilterAndBinaryExpr(plan *LogicalPlan, expr *BinaryExpr) *ExprValidationError {
 	leftErr := ValidateFilterExpr(plan, expr.Left)
 	rightErr := ValidateFilterExpr(plan, expr.Right)
@@ -374,3 +1269,61 @@ func (v *findExpressionForTypeVisitor) PostVisit(expr Expr) bool {
 	}
 	return !found
 }
+
+// newAllTypeFinder returns an instance of the findAllExpressionsForTypeVisitor
+// for the passed type. It expects to receive a pointer to the type it will find.
+func newAllTypeFinder(val interface{}) findAllExpressionsForTypeVisitor {
+	return findAllExpressionsForTypeVisitor{exprType: reflect.TypeOf(val)}
+}
+
+// findAllExpressionsForTypeVisitor is an instance of Visitor that collects all
+// expressions of the given type while visiting the expressions.
+type findAllExpressionsForTypeVisitor struct {
+	exprType reflect.Type
+	results  []Expr
+}
+
+func (v *findAllExpressionsForTypeVisitor) PreVisit(expr Expr) bool {
+	return true
+}
+
+func (v *findAllExpressionsForTypeVisitor) PostVisit(expr Expr) bool {
+	if v.exprType == reflect.TypeOf(expr) {
+		v.results = append(v.results, expr)
+	}
+	return true
+}
+
+// ValidateFilterTupleExpr validates that a tuple is compared with a tuple of
+// the same length, and that each pair of elements is a valid comparison.
+func ValidateFilterTupleExpr(plan *LogicalPlan, expr *BinaryExpr) *ExprValidationError {
+	left := expr.Left.(*TupleExpr)
+	right, ok := expr.Right.(*TupleExpr)
+	if !ok || len(left.Exprs) != len(right.Exprs) || len(left.Exprs) == 0 {
+		return &ExprValidationError{
+			message: "tuples can only be compared with tuples of the same length",
+			expr:    expr,
+		}
+	}
+
+	switch expr.Op {
+	case OpEq, OpNotEq, OpLt, OpLtEq, OpGt, OpGtEq:
+	default:
+		return &ExprValidationError{
+			message: "unsupported tuple comparison operator " + expr.Op.String(),
+			expr:    expr,
+		}
+	}
+
+	for i := range left.Exprs {
+		if err := ValidateFilterBinaryExpr(plan, &BinaryExpr{
+			Left:  left.Exprs[i],
+			Op:    expr.Op,
+			Right: right.Exprs[i],
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
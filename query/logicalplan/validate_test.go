@@ -3,10 +3,14 @@ package logicalplan
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 )
 
 func TestOnlyOneFieldCanBeSet(t *testing.T) {
@@ -33,19 +37,23 @@ func TestOnlyOneFieldCanBeSet(t *testing.T) {
 }
 
 func TestCanTraverseInputThatIsInvalid(t *testing.T) {
-	_, err := (&Builder{}).
+	// The builder combines consecutive filters, so the plan of two filters
+	// is built by hand.
+	input := (&Builder{}).
 		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
 		Filter(&BinaryExpr{
 			Left:  Col("example_type"),
 			Op:    OpEq,
 			Right: Literal(4),
-		}).
-		Filter(&BinaryExpr{
+		}).plan
+	err := Validate(&LogicalPlan{
+		Input: input,
+		Filter: &Filter{Expr: &BinaryExpr{
 			Left:  Col("stacktrace"),
 			Op:    OpEq,
 			Right: Literal(4),
-		}).
-		Build()
+		}},
+	})
 
 	require.NotNil(t, err)
 	planErr, ok := err.(*PlanValidationError)
@@ -122,6 +130,41 @@ func TestAggregationCannotSumTextColumn(t *testing.T) {
 	require.True(t, strings.HasPrefix(exprErr.message, "cannot sum text column"))
 }
 
+func TestAggregationCannotAvgTextColumn(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Avg(Col("example_type"))).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(planErr.message, "invalid aggregation"))
+	require.Len(t, planErr.children, 1)
+	exprErr := planErr.children[0]
+	require.True(t, strings.HasPrefix(exprErr.message, "cannot avg text column"))
+}
+
+func TestAggregationQuantileMustBeBetweenZeroAndOne(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Quantiles(Col("value"), 0.5, 1.5)).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	exprErr := planErr.children[0]
+	require.Equal(t, "quantile must be between 0 and 1, got 1.5", exprErr.message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Quantile(Col("value"), 0.99)).
+		Build()
+	require.NoError(t, err)
+}
+
 func TestFilterBinaryExprLeftSideMustBeColumn(t *testing.T) {
 	_, err := (&Builder{}).
 		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
@@ -212,3 +255,664 @@ func TestFilterAndExprEvaluatesEachAndedRule(t *testing.T) {
 	rightErr := exprErr.children[1]
 	require.True(t, strings.HasPrefix(rightErr.message, "left side of binary expression must be a column"))
 }
+
+func TestProjectionCastMustBeSupported(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Project(Cast(Col("timestamp"), &arrow.BooleanType{})).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(planErr.message, "invalid projection"))
+	require.Len(t, planErr.children, 1)
+	require.Equal(t, "cannot cast int64 to bool", planErr.children[0].message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Cast(Col("labels.test"), arrow.PrimitiveTypes.Int64).Gt(Literal(int64(1)))).
+		Project(Cast(Col("timestamp"), arrow.PrimitiveTypes.Float64)).
+		Build()
+	require.NoError(t, err)
+}
+
+func TestFilterRegexMustCompile(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("example_type").RegexMatch("(unclosed")).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(planErr.message, "invalid filter"))
+	require.Len(t, planErr.children, 1)
+	exprErr := planErr.children[0]
+	require.True(t, strings.HasPrefix(exprErr.message, "invalid regex pattern"))
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("example_type").RegexNotMatch("^cpu.*")).
+		Build()
+	require.NoError(t, err)
+}
+
+func TestTypeCheckUnknownColumn(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("unknown").Eq(Col("timestamp"))).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(planErr.message, "invalid filter"))
+	require.Len(t, planErr.children, 1)
+	exprErr := planErr.children[0]
+	require.Equal(t, "unknown column unknown", exprErr.message)
+}
+
+func TestTypeCheckOperandsMustBeComparable(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("labels.label1").Gt(Col("timestamp"))).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	exprErr := planErr.children[0]
+	require.True(t, strings.HasPrefix(exprErr.message, "type mismatch"))
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Col("value").Gt(Col("timestamp"))).
+		Build()
+	require.NoError(t, err)
+}
+
+func TestTypeCheckProjection(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Project(Col("example_type").Add(Literal(1))).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(planErr.message, "invalid projection"))
+	require.Len(t, planErr.children, 1)
+	exprErr := planErr.children[0]
+	require.True(t, strings.HasPrefix(exprErr.message, "unsupported arithmetic"))
+
+	// Projections of aggregations reference the aggregation's results.
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Sum(Col("value")), Col("labels.label1")).
+		Project(Col("sum(value)")).
+		Build()
+	require.NoError(t, err)
+}
+
+func TestRegexColumnPatternMustCompile(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(RegexCol("labels.(unclosed").Eq(Literal("a"))).
+		Build()
+
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(planErr.message, "invalid filter"))
+	require.Len(t, planErr.children, 1)
+	require.True(t, strings.HasPrefix(planErr.children[0].message, "invalid column pattern"))
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(RegexCol("labels.region_.*").Eq(Literal("eu"))).
+		Project(RegexCol("labels.region_.*")).
+		Build()
+	require.NoError(t, err)
+}
+
+func TestFilterTupleComparison(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Tuple(Col("timestamp"), Col("labels.id")).Gt(Tuple(Literal(int64(1)), Literal("a")))).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Tuple(Col("timestamp"), Col("labels.id")).Gt(Tuple(Literal(int64(1))))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	require.Equal(t, "tuples can only be compared with tuples of the same length", planErr.children[0].message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Filter(Tuple(Col("timestamp"), Col("labels.id")).Gt(Tuple(Literal("a"), Literal("a")))).
+		Build()
+	require.NotNil(t, err)
+}
+
+func TestHaving(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Sum(Col("value")), Col("labels.job")).
+		Having(And(
+			Col("sum(value)").Gt(Literal(int64(100))),
+			Col("labels.job").Eq(Literal("api")),
+		)).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Sum(Col("value")), Col("labels.job")).
+		Having(Col("value").Gt(Literal(int64(100)))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid having", planErr.message)
+	require.Len(t, planErr.children, 1)
+	require.Equal(t, "column is not a result of the aggregation: value", planErr.children[0].message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Having(Col("value").Gt(Literal(int64(100)))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid having: input must be an aggregation", planErr.message)
+}
+
+func TestWindow(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Window(
+			[]Expr{RowNumber(), Lead(Col("labels.job"), 1), MovingSum(Col("value"), 10)},
+			Col("timestamp"),
+			Col("labels.job"),
+		).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Window([]Expr{RowNumber()}, Col("labels.job")).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid window: order column must be an integer or timestamp column", planErr.message)
+
+	for expr, message := range map[Expr]string{
+		MovingSum(Col("value"), 0):         "moving_sum width must be positive, got 0",
+		MovingAvg(Col("labels.job"), 10):   "cannot compute moving_avg of binary column",
+		Lag(Col("value"), -1):              "lag offset must not be negative, got -1",
+		Lag(Sum(Col("value")), 1):          "lag window function requires a column",
+		Col("value").Gt(Literal(int64(1))): "window expression must be a window function",
+	} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+			Window([]Expr{expr}, Col("timestamp")).
+			Build()
+		require.NotNil(t, err)
+		planErr, ok := err.(*PlanValidationError)
+		require.True(t, ok)
+		require.Equal(t, "invalid window", planErr.message)
+		require.Len(t, planErr.children, 1)
+		require.Equal(t, message, planErr.children[0].message)
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		OrderBy(Asc(Col("labels.job")), Desc(Col("timestamp"))).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		OrderBy(Asc(Col("unknown"))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid order by", planErr.message)
+	require.Len(t, planErr.children, 1)
+	require.Equal(t, "unknown column unknown", planErr.children[0].message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		OrderBy(Asc(Col("value").Add(Col("timestamp")))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid order by: rows can only be sorted by columns", planErr.message)
+
+	// The results of an aggregation can be sorted.
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Aggregate(Sum(Col("value")), Col("labels.job")).
+		OrderBy(Desc(Col("sum(value)"))).
+		Build()
+	require.NoError(t, err)
+}
+
+func TestLimitOffset(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Offset(10).
+		Limit(0).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Limit(-1).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid limit: number of rows must not be negative, got -1", planErr.message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Offset(-2).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid offset: number of rows must not be negative, got -2", planErr.message)
+}
+
+func TestSample(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Sample(0.1).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Sample(1.5).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid sample: fraction must be between 0 and 1, got 1.5", planErr.message)
+}
+
+func TestUnpivot(t *testing.T) {
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Unpivot(DynCol("labels"), "label", "label_value").
+		Filter(Col("label").Eq(Literal("job"))).
+		Build()
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		column      *DynamicColumn
+		name, value string
+		message     string
+	}{{
+		column:  DynCol("labels"),
+		name:    "label",
+		value:   "label",
+		message: "invalid unpivot: name and value columns must have different names",
+	}, {
+		column:  DynCol("labels"),
+		name:    "",
+		value:   "label_value",
+		message: "invalid unpivot: name and value columns must have names",
+	}, {
+		column:  DynCol("timestamp"),
+		name:    "label",
+		value:   "label_value",
+		message: "invalid unpivot: timestamp is not a dynamic column",
+	}, {
+		column:  DynCol("labels"),
+		name:    "label",
+		value:   "value",
+		message: "invalid unpivot: column value already exists",
+	}} {
+		_, err := (&Builder{}).
+			Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+			Unpivot(test.column, test.name, test.value).
+			Build()
+		require.NotNil(t, err)
+		planErr, ok := err.(*PlanValidationError)
+		require.True(t, ok)
+		require.Equal(t, test.message, planErr.message)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	right := (&Builder{}).Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table2")
+
+	_, err := (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Join(right, Cols("labels.job", "timestamp"), Cols("labels.job", "timestamp")).
+		Project(Col("labels.job"), Col("value")).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Join(right, nil, nil).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid join: at least one key is required", planErr.message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Join(right, Cols("labels.job", "timestamp"), Cols("labels.job")).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid join: expected 2 right keys, got 1", planErr.message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Join(right, Cols("labels.job"), Cols("timestamp")).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid join", planErr.message)
+	require.Len(t, planErr.children, 1)
+	require.Equal(t, "cannot join binary column labels.job with int64 column", planErr.children[0].message)
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Join(right, []Expr{Col("value").Add(Literal(int64(1)))}, Cols("value")).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid join", planErr.message)
+	require.Equal(t, "join key must be a column", planErr.children[0].message)
+
+	// The right plan is validated.
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{dynparquet.NewSampleSchema()}, "table1").
+		Join(right.Limit(-1), Cols("value"), Cols("value")).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid join: invalid right plan", planErr.message)
+	require.Equal(t, "invalid limit: number of rows must not be negative, got -1", planErr.input.message)
+}
+
+func TestUnion(t *testing.T) {
+	scan := func(schema *dynparquet.Schema, name string) Builder {
+		return (&Builder{}).Scan(&mockTableProvider{schema}, name)
+	}
+
+	_, err := (&Builder{}).
+		Union(
+			scan(dynparquet.NewSampleSchema(), "table1"),
+			scan(dynparquet.NewSampleSchema(), "table2"),
+		).
+		Filter(Col("labels.job").Eq(Literal("api"))).
+		Project(Col("labels.job"), Col("value")).
+		Build()
+	require.NoError(t, err)
+
+	_, err = (&Builder{}).Union().Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid union: at least one plan is required", planErr.message)
+
+	// The plans are validated.
+	_, err = (&Builder{}).
+		Union(
+			scan(dynparquet.NewSampleSchema(), "table1"),
+			scan(dynparquet.NewSampleSchema(), "table2").Limit(-1),
+		).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid union: invalid plan 1", planErr.message)
+	require.Equal(t, "invalid limit: number of rows must not be negative, got -1", planErr.input.message)
+
+	values, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "values",
+		Columns: []*schemapb.Column{{
+			Name: "value",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_INT64,
+			},
+		}},
+	})
+	require.NoError(t, err)
+	_, err = (&Builder{}).
+		Union(
+			scan(dynparquet.NewSampleSchema(), "table1"),
+			scan(values, "table2"),
+		).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid union: plan 1 reads a table with different columns: missing column example_type", planErr.message)
+
+	def := proto.Clone(dynparquet.NewSampleSchema().Definition()).(*schemapb.Schema)
+	for _, col := range def.Columns {
+		if col.Name == "value" {
+			col.StorageLayout.Type = schemapb.StorageLayout_TYPE_DOUBLE
+		}
+	}
+	doubles, err := dynparquet.SchemaFromDefinition(def)
+	require.NoError(t, err)
+	_, err = (&Builder{}).
+		Union(
+			scan(dynparquet.NewSampleSchema(), "table1"),
+			scan(doubles, "table2"),
+		).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok = err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Equal(t, "invalid union: plan 1 reads a table with different columns: column value has a different definition", planErr.message)
+}
+
+func TestFilterBinaryExprDoubleColMustMatchLiteralType(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "ratio",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_DOUBLE,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "ratio",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, literal := range []Expr{Literal(1.5), Literal(int64(2))} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{schema}, "table1").
+			Filter(Col("ratio").Lt(literal)).
+			Build()
+		require.NoError(t, err)
+	}
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{schema}, "table1").
+		Filter(Col("ratio").Eq(Literal("albert"))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	require.True(t, strings.HasPrefix(planErr.children[0].message, "incompatible types"))
+}
+
+func TestFilterBinaryExprBooleanCol(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "error",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_BOOL,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "error",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, expr := range []Expr{Col("error").Eq(Literal(true)), Col("error").NotEq(Literal(false))} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{schema}, "table1").
+			Filter(expr).
+			Build()
+		require.NoError(t, err)
+	}
+
+	for expr, msg := range map[Expr]string{
+		Col("error").Eq(Literal(int64(1))):                  "incompatible types: boolean column cannot be compared with int64 literal",
+		Col("error").Lt(Literal(true)):                      "incompatible types: boolean column can only be compared for equality",
+		Col("error").Between(Literal(false), Literal(true)): "incompatible types: boolean column can only be compared for equality",
+	} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{schema}, "table1").
+			Filter(expr).
+			Build()
+		require.NotNil(t, err)
+		planErr, ok := err.(*PlanValidationError)
+		require.True(t, ok)
+		require.Len(t, planErr.children, 1)
+		require.Equal(t, msg, planErr.children[0].message)
+	}
+}
+
+func TestFilterBinaryExprTimestampColMustMatchLiteralType(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "timestamp",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_TIMESTAMP_NANOS,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, literal := range []Expr{Literal(time.UnixMilli(1)), Literal(int64(2))} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{schema}, "table1").
+			Filter(Col("timestamp").Lt(literal)).
+			Build()
+		require.NoError(t, err)
+	}
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{schema}, "table1").
+		Filter(Col("timestamp").Eq(Literal("albert"))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	require.True(t, strings.HasPrefix(planErr.children[0].message, "incompatible types"))
+}
+
+func TestFilterBinaryExprDecimalColMustMatchLiteralType(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "price",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:      schemapb.StorageLayout_TYPE_DECIMAL,
+				Precision: 10,
+				Scale:     2,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "price",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, literal := range []Expr{Literal(12.34), Literal(int64(2))} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{schema}, "table1").
+			Filter(Col("price").Lt(literal)).
+			Build()
+		require.NoError(t, err)
+	}
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{schema}, "table1").
+		Filter(Col("price").Eq(Literal("albert"))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	require.True(t, strings.HasPrefix(planErr.children[0].message, "incompatible types"))
+}
+
+func TestFilterBinaryExprBytesColMustMatchLiteralType(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "digest",
+			StorageLayout: &schemapb.StorageLayout{
+				Type: schemapb.StorageLayout_TYPE_BYTES,
+			},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "digest",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, literal := range []Expr{Literal([]byte{0xff}), Literal("albert")} {
+		_, err = (&Builder{}).
+			Scan(&mockTableProvider{schema}, "table1").
+			Filter(Col("digest").Eq(literal)).
+			Build()
+		require.NoError(t, err)
+	}
+
+	_, err = (&Builder{}).
+		Scan(&mockTableProvider{schema}, "table1").
+		Filter(Col("digest").Eq(Literal(int64(1)))).
+		Build()
+	require.NotNil(t, err)
+	planErr, ok := err.(*PlanValidationError)
+	require.True(t, ok)
+	require.Len(t, planErr.children, 1)
+	require.Equal(t, "incompatible types: binary column cannot be compared with numeric literal", planErr.children[0].message)
+}
@@ -0,0 +1,186 @@
+package logicalplan
+
+import (
+	"strconv"
+
+	"github.com/apache/arrow/go/v8/arrow"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+)
+
+// WindowFunction computes a value for each row from the rows of its
+// partition, which are ordered by the order expression of the Window they
+// are computed by. In contrast to aggregations, every row is kept.
+type WindowFunction struct {
+	Func WindowFunc
+	// Expr is the expression the function is computed over, it is not set
+	// for WindowFuncRowNumber.
+	Expr Expr
+	// Offset is the number of rows WindowFuncLag and WindowFuncLead look
+	// behind or ahead.
+	Offset int64
+	// Width is the range of order values of the frames of
+	// WindowFuncMovingSum and WindowFuncMovingAvg. The frame of a row holds
+	// the rows whose order value is greater than the row's order value minus
+	// Width and at most the row's order value.
+	Width int64
+}
+
+func (f *WindowFunction) DataType(s *dynparquet.Schema) (arrow.DataType, error) {
+	switch f.Func {
+	case WindowFuncRowNumber:
+		return arrow.PrimitiveTypes.Int64, nil
+	case WindowFuncMovingAvg:
+		return arrow.PrimitiveTypes.Float64, nil
+	}
+	return f.Expr.DataType(s)
+}
+
+func (f *WindowFunction) Accept(visitor Visitor) bool {
+	continu := visitor.PreVisit(f)
+	if !continu {
+		return false
+	}
+
+	if f.Expr != nil {
+		continu = f.Expr.Accept(visitor)
+		if !continu {
+			return false
+		}
+	}
+
+	return visitor.PostVisit(f)
+}
+
+func (f *WindowFunction) Clone() Expr {
+	return &WindowFunction{
+		Func:   f.Func,
+		Expr:   cloneExpr(f.Expr),
+		Offset: f.Offset,
+		Width:  f.Width,
+	}
+}
+
+func (f *WindowFunction) Rewrite(rewriter Rewriter) Expr {
+	if !rewriter.PreRewrite(f) {
+		return f
+	}
+
+	return rewriter.PostRewrite(&WindowFunction{
+		Func:   f.Func,
+		Expr:   rewriteExpr(f.Expr, rewriter),
+		Offset: f.Offset,
+		Width:  f.Width,
+	})
+}
+
+func (f *WindowFunction) Computed() bool {
+	return true
+}
+
+func (f *WindowFunction) Name() string {
+	name := f.Func.String() + "("
+	if f.Expr != nil {
+		name += f.Expr.Name()
+	}
+	switch f.Func {
+	case WindowFuncLag, WindowFuncLead:
+		name += ", " + strconv.FormatInt(f.Offset, 10)
+	case WindowFuncMovingSum, WindowFuncMovingAvg:
+		name += ", " + strconv.FormatInt(f.Width, 10)
+	}
+	return name + ")"
+}
+
+func (f *WindowFunction) ColumnsUsedExprs() []Expr {
+	if f.Expr == nil {
+		return nil
+	}
+	return f.Expr.ColumnsUsedExprs()
+}
+
+func (f *WindowFunction) MatchColumn(columnName string) bool {
+	return f.Name() == columnName
+}
+
+func (f *WindowFunction) Alias(alias string) *AliasExpr {
+	return &AliasExpr{
+		Expr:  f,
+		Alias: alias,
+	}
+}
+
+type WindowFunc uint32
+
+const (
+	WindowFuncUnknown WindowFunc = iota
+	WindowFuncRowNumber
+	WindowFuncLag
+	WindowFuncLead
+	WindowFuncMovingSum
+	WindowFuncMovingAvg
+)
+
+func (f WindowFunc) String() string {
+	switch f {
+	case WindowFuncRowNumber:
+		return "row_number"
+	case WindowFuncLag:
+		return "lag"
+	case WindowFuncLead:
+		return "lead"
+	case WindowFuncMovingSum:
+		return "moving_sum"
+	case WindowFuncMovingAvg:
+		return "moving_avg"
+	default:
+		panic("unknown window function")
+	}
+}
+
+// RowNumber numbers the rows of each partition in order, starting at 1.
+func RowNumber() *WindowFunction {
+	return &WindowFunction{
+		Func: WindowFuncRowNumber,
+	}
+}
+
+// Lag returns the value of the expression offset rows before the current row
+// of the partition, or null if there is no such row.
+func Lag(expr Expr, offset int64) *WindowFunction {
+	return &WindowFunction{
+		Func:   WindowFuncLag,
+		Expr:   expr,
+		Offset: offset,
+	}
+}
+
+// Lead returns the value of the expression offset rows after the current row
+// of the partition, or null if there is no such row.
+func Lead(expr Expr, offset int64) *WindowFunction {
+	return &WindowFunction{
+		Func:   WindowFuncLead,
+		Expr:   expr,
+		Offset: offset,
+	}
+}
+
+// MovingSum sums the non-null values of the expression of the rows whose
+// order value, such as the timestamp, is within width of the current row's.
+func MovingSum(expr Expr, width int64) *WindowFunction {
+	return &WindowFunction{
+		Func:  WindowFuncMovingSum,
+		Expr:  expr,
+		Width: width,
+	}
+}
+
+// MovingAvg averages the non-null values of the expression of the rows whose
+// order value, such as the timestamp, is within width of the current row's.
+func MovingAvg(expr Expr, width int64) *WindowFunction {
+	return &WindowFunction{
+		Func:  WindowFuncMovingAvg,
+		Expr:  expr,
+		Width: width,
+	}
+}
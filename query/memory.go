@@ -0,0 +1,71 @@
+package query
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+)
+
+// ErrQueryMemoryExceeded is returned by queries that allocated more memory
+// than the engine's memory limit of queries.
+var ErrQueryMemoryExceeded = errors.New("query memory limit exceeded")
+
+// limitAllocator keeps track of the number of bytes a query allocated and
+// hasn't freed yet, and cancels the query once they exceed the limit, unless
+// the limit is zero. Allocators can't fail, so allocations still succeed
+// after the limit is exceeded, the query stops at the next record instead.
+type limitAllocator struct {
+	memory.Allocator
+	limit     int64
+	allocated int64
+	peak      int64
+	exceeded  int32
+	cancel    func()
+}
+
+func newLimitAllocator(pool memory.Allocator, limit int64, cancel func()) *limitAllocator {
+	return &limitAllocator{
+		Allocator: pool,
+		limit:     limit,
+		cancel:    cancel,
+	}
+}
+
+func (a *limitAllocator) Allocate(size int) []byte {
+	a.add(int64(size))
+	return a.Allocator.Allocate(size)
+}
+
+func (a *limitAllocator) Reallocate(size int, b []byte) []byte {
+	a.add(int64(size - len(b)))
+	return a.Allocator.Reallocate(size, b)
+}
+
+func (a *limitAllocator) Free(b []byte) {
+	atomic.AddInt64(&a.allocated, -int64(len(b)))
+	a.Allocator.Free(b)
+}
+
+func (a *limitAllocator) add(size int64) {
+	allocated := atomic.AddInt64(&a.allocated, size)
+	for {
+		peak := atomic.LoadInt64(&a.peak)
+		if allocated <= peak || atomic.CompareAndSwapInt64(&a.peak, peak, allocated) {
+			break
+		}
+	}
+	if a.limit > 0 && allocated > a.limit && atomic.CompareAndSwapInt32(&a.exceeded, 0, 1) {
+		a.cancel()
+	}
+}
+
+// Peak returns the largest number of bytes the query had allocated at once.
+func (a *limitAllocator) Peak() int64 {
+	return atomic.LoadInt64(&a.peak)
+}
+
+// Exceeded returns whether the query exceeded the limit.
+func (a *limitAllocator) Exceeded() bool {
+	return atomic.LoadInt32(&a.exceeded) == 1
+}
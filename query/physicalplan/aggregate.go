@@ -1,9 +1,14 @@
 package physicalplan
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash/maphash"
+	gomath "math"
+	"sort"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
@@ -13,33 +18,141 @@ import (
 	"github.com/dgryski/go-metro"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/pqarrow/convert"
 	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/query/sketch"
 )
 
 func Aggregate(
 	pool memory.Allocator,
 	s *dynparquet.Schema,
 	agg *logicalplan.Aggregation,
+	opts ...Option,
 ) (*HashAggregate, error) {
+	o := newOptions(opts)
+
+	var alloc *countingAllocator
+	if o.aggregationMemoryLimit > 0 {
+		// The memory used by the state of the aggregation is tracked by
+		// counting what is allocated for it.
+		alloc = newCountingAllocator(pool)
+		pool = alloc
+	}
+
+	aggregations, err := hashAggregations(pool, s, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	groupByMatchers := make([]logicalplan.Expr, 0, len(agg.GroupExprs))
+	groupByExprs := make([]groupByExpr, 0)
+	for _, e := range agg.GroupExprs {
+		if !e.Computed() {
+			groupByMatchers = append(groupByMatchers, e)
+			continue
+		}
+
+		// Computed group by columns, such as time buckets, don't exist in
+		// the records and are evaluated instead.
+		arrExpr, err := arrayExpr(pool, e)
+		if err != nil {
+			return nil, fmt.Errorf("group by %s: %w", e.Name(), err)
+		}
+		groupByExprs = append(groupByExprs, groupByExpr{
+			name: e.Name(),
+			expr: arrExpr,
+		})
+	}
+
+	a := newHashAggregate(pool, aggregations, groupByMatchers)
+	a.groupByExprs = groupByExprs
+
+	if alloc != nil {
+		a.alloc = alloc
+		a.memoryLimit = o.aggregationMemoryLimit
+		a.spillDir = o.spillDir
+		if mergeableAggregation(s, agg) {
+			a.newAggregations = func() ([]*hashAggregation, error) {
+				return hashAggregations(pool, s, agg)
+			}
+			a.newMergeAggregate = func() (*HashAggregate, *AvgProjection, error) {
+				return mergeAggregate(alloc.Allocator, s, agg)
+			}
+			return a, nil
+		}
+
+		for _, e := range append(append([]logicalplan.Expr{}, agg.GroupExprs...), agg.AggExprs...) {
+			a.spillColumns = append(a.spillColumns, e.ColumnsUsedExprs()...)
+		}
+		a.newSpillAggregate = func() (*HashAggregate, error) {
+			return Aggregate(alloc.Allocator, s, agg, opts...)
+		}
+	}
+	return a, nil
+}
+
+// hashAggregations returns the aggregations of the aggregation expressions.
+func hashAggregations(pool memory.Allocator, s *dynparquet.Schema, agg *logicalplan.Aggregation) ([]*hashAggregation, error) {
+	aggregations := make([]*hashAggregation, 0, len(agg.AggExprs))
+	for _, aggExpr := range agg.AggExprs {
+		aggregation, err := newHashAggregation(pool, s, aggExpr)
+		if err != nil {
+			return nil, err
+		}
+		aggregations = append(aggregations, aggregation)
+	}
+	return aggregations, nil
+}
+
+// mergeableAggregation returns whether the results of the aggregation can be
+// merged from the states of its groups, see partialAggregation.
+func mergeableAggregation(s *dynparquet.Schema, agg *logicalplan.Aggregation) bool {
+	if s == nil {
+		return false
+	}
+	for _, aggExpr := range agg.AggExprs {
+		if _, ok := partialAggregationFunction(s, aggExpr); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func newHashAggregation(
+	pool memory.Allocator,
+	s *dynparquet.Schema,
+	aggExpr logicalplan.Expr,
+) (*hashAggregation, error) {
 	var (
-		aggFunc      logicalplan.AggFunc
+		aggFunc      *logicalplan.AggregationFunction
 		aggFuncFound bool
 
 		aggColumnExpr  logicalplan.Expr
 		aggColumnFound bool
+
+		aggFilterExpr logicalplan.Expr
 	)
 
-	agg.AggExpr.Accept(PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
+	findColumn := PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
+		if e, ok := expr.(*logicalplan.Column); ok {
+			aggColumnExpr = e
+			aggColumnFound = true
+		}
+		return true
+	})
+	aggExpr.Accept(PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
 		switch e := expr.(type) {
 		case *logicalplan.AggregationFunction:
-			aggFunc = e.Func
+			aggFunc = e
 			aggFuncFound = true
-		case *logicalplan.Column:
-			aggColumnExpr = e
-			aggColumnFound = true
+			aggFilterExpr = e.Filter
+			// The columns of the filter are not aggregated.
+			e.Expr.Accept(findColumn)
+			return false
 		}
 
-		return true
+		return findColumn(expr)
 	}))
 
 	if !aggFuncFound {
@@ -50,23 +163,85 @@ func Aggregate(
 		return nil, errors.New("aggregation column not found")
 	}
 
-	dataType, err := agg.AggExpr.DataType(s)
-	if err != nil {
-		return nil, err
+	a := &hashAggregation{
+		resultColumnName:  aggExpr.Name(),
+		columnToAggregate: aggColumnExpr,
+		arraysToAggregate: make([]array.Builder, 0),
 	}
 
-	f, err := chooseAggregationFunction(aggFunc, dataType)
-	if err != nil {
-		return nil, err
+	switch aggFunc.Func {
+	case logicalplan.AggFuncUserDefined:
+		def := aggFunc.Definition()
+		if def == nil {
+			return nil, fmt.Errorf("aggregation %s: %w", aggFunc.UserDefined, logicalplan.ErrUnknownFunction)
+		}
+		a.userDefined = def.New()
+		if err := a.userDefined.Init(pool); err != nil {
+			return nil, fmt.Errorf("init aggregation %s: %w", aggFunc.UserDefined, err)
+		}
+	case logicalplan.AggFuncApproxCountDistinct, logicalplan.AggFuncQuantile, logicalplan.AggFuncQuantiles,
+		logicalplan.AggFuncVariance, logicalplan.AggFuncStdDev, logicalplan.AggFuncFirstBy, logicalplan.AggFuncLastBy:
+		// These are updated as records are received, so the values don't
+		// have to be buffered.
+		switch aggFunc.Func {
+		case logicalplan.AggFuncApproxCountDistinct:
+			a.userDefined = &ApproxCountDistinctAggregation{}
+		case logicalplan.AggFuncFirstBy, logicalplan.AggFuncLastBy:
+			dataType, err := aggExpr.DataType(s)
+			if err != nil {
+				return nil, err
+			}
+			a.userDefined = &FirstLastAggregation{
+				DataType: dataType,
+				Last:     aggFunc.Func == logicalplan.AggFuncLastBy,
+			}
+			a.orderBy, err = arrayExpr(pool, aggFunc.OrderBy)
+			if err != nil {
+				return nil, fmt.Errorf("order by %s: %w", aggFunc.OrderBy.Name(), err)
+			}
+		case logicalplan.AggFuncVariance, logicalplan.AggFuncStdDev:
+			a.userDefined = &VarianceAggregation{StdDev: aggFunc.Func == logicalplan.AggFuncStdDev}
+		default:
+			a.userDefined = &QuantileAggregation{
+				Quantiles: aggFunc.Quantiles,
+				List:      aggFunc.Func == logicalplan.AggFuncQuantiles,
+			}
+		}
+		if err := a.userDefined.Init(pool); err != nil {
+			return nil, err
+		}
+	default:
+		dataType, err := aggExpr.DataType(s)
+		if err != nil {
+			return nil, err
+		}
+
+		a.aggregationFunction, err = chooseAggregationFunction(aggFunc.Func, dataType)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return NewHashAggregate(
-		pool,
-		agg.AggExpr.Name(),
-		f,
-		aggColumnExpr,
-		agg.GroupExprs,
-	), nil
+	if aggFunc.State {
+		if _, ok := a.userDefined.(stateAggregation); !ok {
+			return nil, fmt.Errorf("aggregation %s has no mergeable state", aggFunc.Name())
+		}
+		a.state = true
+	}
+
+	if aggFilterExpr != nil {
+		var err error
+		a.filter, err = booleanExpr(pool, aggFilterExpr)
+		if err != nil {
+			return nil, fmt.Errorf("aggregation filter: %w", err)
+		}
+	}
+	return a, nil
+}
+
+type groupByExpr struct {
+	name string
+	expr ArrayExpression
 }
 
 func chooseAggregationFunction(
@@ -78,9 +253,27 @@ func chooseAggregationFunction(
 		switch dataType.ID() {
 		case arrow.INT64:
 			return &Int64SumAggregation{}, nil
+		case arrow.FLOAT64:
+			return &Float64SumAggregation{}, nil
+		case arrow.DECIMAL128:
+			return &Decimal128SumAggregation{DataType: dataType}, nil
 		default:
 			return nil, fmt.Errorf("unsupported sum of type: %s", dataType.Name())
 		}
+	case logicalplan.AggFuncCount:
+		return &CountAggregation{}, nil
+	case logicalplan.AggFuncMin, logicalplan.AggFuncMax:
+		switch dataType.ID() {
+		case arrow.INT64, arrow.UINT64, arrow.FLOAT64, arrow.STRING, arrow.BINARY, arrow.TIMESTAMP, arrow.DECIMAL128:
+		default:
+			return nil, fmt.Errorf("unsupported %s of type: %s", aggFunc.String(), dataType.Name())
+		}
+		if aggFunc == logicalplan.AggFuncMin {
+			return &MinAggregation{DataType: dataType}, nil
+		}
+		return &MaxAggregation{DataType: dataType}, nil
+	case logicalplan.AggFuncAvg:
+		return &AvgAggregation{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported aggregation function: %s", aggFunc.String())
 	}
@@ -90,42 +283,104 @@ type AggregationFunction interface {
 	Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error)
 }
 
+// HashAggregate groups the rows it receives and computes its aggregations for
+// each of the groups in a single pass.
 type HashAggregate struct {
 	pool                  memory.Allocator
-	resultColumnName      string
 	groupByCols           map[string]array.Builder
-	arraysToAggregate     []array.Builder
 	hashToAggregate       map[uint64]int
 	groupByColumnMatchers []logicalplan.Expr
-	columnToAggregate     logicalplan.Expr
-	aggregationFunction   AggregationFunction
+	groupByExprs          []groupByExpr
+	aggregations          []*hashAggregation
+	numGroups             int
 	hashSeed              maphash.Seed
 	nextCallback          func(r arrow.Record) error
 
+	// memoryLimit is the number of bytes alloc can allocate before the
+	// groups are spilled, if set.
+	memoryLimit int64
+	alloc       *countingAllocator
+	spillDir    string
+	// newAggregations creates the aggregations of the groups aggregated
+	// after the states of the groups were spilled, and newMergeAggregate
+	// the aggregation that merges the states spilled to a partition, if the
+	// results of the aggregation can be merged from the states of its
+	// groups.
+	newAggregations   func() ([]*hashAggregation, error)
+	newMergeAggregate func() (*HashAggregate, *AvgProjection, error)
+	// spillColumns match the columns of the records that are spilled.
+	spillColumns []logicalplan.Expr
+	// newSpillAggregate creates the aggregation that aggregates the rows
+	// spilled to a partition.
+	newSpillAggregate func() (*HashAggregate, error)
+	// spilling is set once the memory limit was exceeded by an aggregation
+	// that can't be merged, from then on no new groups are added and their
+	// rows are spilled to the partitions instead.
+	spilling   bool
+	partitions []*spillPartition
+
+	// partialResults is the interval at which snapshots of the results of
+	// the groups aggregated so far are passed on, if set. lastSnapshot is
+	// when the last snapshot was passed on.
+	partialResults time.Duration
+	lastSnapshot   time.Time
+
 	// Buffers that are reused across callback calls.
 	groupByFields      []arrow.Field
 	groupByFieldHashes []uint64
 	groupByArrays      []arrow.Array
 }
 
+// hashAggregation is the state of one of the aggregations of a HashAggregate.
+type hashAggregation struct {
+	resultColumnName  string
+	columnToAggregate logicalplan.Expr
+	// filter selects the rows whose values are aggregated, if set. Rows that
+	// aren't selected still contribute their group.
+	filter              BooleanExpression
+	aggregationFunction AggregationFunction
+	arraysToAggregate   []array.Builder
+	// userDefined aggregates the values as they are received instead of
+	// aggregationFunction, if set.
+	userDefined logicalplan.UserDefinedAggregation
+	// orderBy orders the values passed to userDefined, which must be an
+	// orderedAggregation, if set.
+	orderBy ArrayExpression
+	// state makes userDefined, which must be a stateAggregation, return the
+	// states of the groups instead of their results. merge makes it merge
+	// the states it's updated with instead of aggregating values.
+	state bool
+	merge bool
+}
+
 func NewHashAggregate(
 	pool memory.Allocator,
 	resultColumnName string,
 	aggregationFunction AggregationFunction,
 	columnToAggregate logicalplan.Expr,
 	groupByColumnMatchers []logicalplan.Expr,
+) *HashAggregate {
+	return newHashAggregate(pool, []*hashAggregation{{
+		resultColumnName:    resultColumnName,
+		columnToAggregate:   columnToAggregate,
+		aggregationFunction: aggregationFunction,
+		arraysToAggregate:   make([]array.Builder, 0),
+	}}, groupByColumnMatchers)
+}
+
+func newHashAggregate(
+	pool memory.Allocator,
+	aggregations []*hashAggregation,
+	groupByColumnMatchers []logicalplan.Expr,
 ) *HashAggregate {
 	return &HashAggregate{
-		pool:              pool,
-		resultColumnName:  resultColumnName,
-		groupByCols:       map[string]array.Builder{},
-		arraysToAggregate: make([]array.Builder, 0),
-		hashToAggregate:   map[uint64]int{},
-		columnToAggregate: columnToAggregate,
+		pool:            pool,
+		groupByCols:     map[string]array.Builder{},
+		hashToAggregate: map[uint64]int{},
+		aggregations:    aggregations,
 		// TODO: Matchers can be optimized to be something like a radix tree or just a fast-lookup datastructure for exact matches or prefix matches.
 		groupByColumnMatchers: groupByColumnMatchers,
 		hashSeed:              maphash.MakeSeed(),
-		aggregationFunction:   aggregationFunction,
 
 		groupByFields:      make([]arrow.Field, 0, 10),
 		groupByFieldHashes: make([]uint64, 0, 10),
@@ -151,8 +406,18 @@ func hashArray(arr arrow.Array) []uint64 {
 		return hashBinaryArray(arr.(*array.Binary))
 	case *array.Int64:
 		return hashInt64Array(arr.(*array.Int64))
+	case *array.Uint64:
+		return hashUint64Array(arr.(*array.Uint64))
+	case *array.Float64:
+		return hashFloat64Array(arr.(*array.Float64))
 	case *array.Boolean:
 		return hashBooleanArray(arr.(*array.Boolean))
+	case *array.Timestamp:
+		ints := timestampAsInt64Array(arr)
+		defer ints.Release()
+		return hashInt64Array(ints.(*array.Int64))
+	case *array.Decimal128:
+		return hashDecimal128Array(arr.(*array.Decimal128))
 	default:
 		panic("unsupported array type " + fmt.Sprintf("%T", arr))
 	}
@@ -194,17 +459,84 @@ func hashStringArray(arr *array.String) []uint64 {
 	return res
 }
 
+func hashDecimal128Array(arr *array.Decimal128) []uint64 {
+	res := make([]uint64, arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if !arr.IsNull(i) {
+			v := arr.Value(i)
+			res[i] = hashCombine(uint64(v.HighBits()), v.LowBits())
+		}
+	}
+	return res
+}
+
+// valueHashSeed is the seed of the hashes of fixed width values. The hash 0
+// is the hash of nulls, which the values have to hash differently from,
+// including zero.
+const valueHashSeed = 0x9e3779b97f4a7c15
+
+func hashUint64(v uint64) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return metro.Hash64(b[:], valueHashSeed)
+}
+
 func hashInt64Array(arr *array.Int64) []uint64 {
 	res := make([]uint64, arr.Len())
 	for i := 0; i < arr.Len(); i++ {
 		if !arr.IsNull(i) {
-			res[i] = uint64(arr.Value(i))
+			res[i] = hashUint64(uint64(arr.Value(i)))
+		}
+	}
+	return res
+}
+
+func hashUint64Array(arr *array.Uint64) []uint64 {
+	res := make([]uint64, arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if !arr.IsNull(i) {
+			res[i] = hashUint64(arr.Value(i))
+		}
+	}
+	return res
+}
+
+func hashFloat64Array(arr *array.Float64) []uint64 {
+	res := make([]uint64, arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if !arr.IsNull(i) {
+			v := arr.Value(i)
+			if v == 0 {
+				// -0 and 0 are equal, so they must hash the same.
+				v = 0
+			}
+			res[i] = hashUint64(gomath.Float64bits(v))
 		}
 	}
 	return res
 }
 
 func (a *HashAggregate) Callback(r arrow.Record) error {
+	if err := a.aggregate(r); err != nil {
+		return err
+	}
+	if a.partialResults <= 0 || a.partitions != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if a.lastSnapshot.IsZero() {
+		a.lastSnapshot = now
+		return nil
+	}
+	if now.Sub(a.lastSnapshot) < a.partialResults {
+		return nil
+	}
+	a.lastSnapshot = now
+	return a.snapshot()
+}
+
+func (a *HashAggregate) aggregate(r arrow.Record) error {
 	groupByFields := a.groupByFields
 	groupByFieldHashes := a.groupByFieldHashes
 	groupByArrays := a.groupByArrays
@@ -215,8 +547,7 @@ func (a *HashAggregate) Callback(r arrow.Record) error {
 		groupByArrays = groupByArrays[:0]
 	}()
 
-	var columnToAggregate arrow.Array
-	aggregateFieldFound := false
+	columnsToAggregate := make([]arrow.Array, len(a.aggregations))
 
 	for i, field := range r.Schema().Fields() {
 		for _, matcher := range a.groupByColumnMatchers {
@@ -224,26 +555,73 @@ func (a *HashAggregate) Callback(r arrow.Record) error {
 				groupByFields = append(groupByFields, field)
 				groupByFieldHashes = append(groupByFieldHashes, scalar.Hash(a.hashSeed, scalar.NewStringScalar(field.Name)))
 				groupByArrays = append(groupByArrays, r.Column(i))
+				// A column can be matched by several group by expressions,
+				// for example by two overlapping dynamic columns, but must
+				// only be part of the group key once.
+				break
 			}
 		}
 
-		if a.columnToAggregate.MatchColumn(field.Name) {
-			columnToAggregate = r.Column(i)
-			aggregateFieldFound = true
+		for j, agg := range a.aggregations {
+			if agg.columnToAggregate.MatchColumn(field.Name) {
+				columnsToAggregate[j] = r.Column(i)
+			}
+		}
+	}
+
+	for _, col := range columnsToAggregate {
+		if col == nil {
+			return errors.New("aggregate field not found, aggregations are not possible without it")
 		}
 	}
 
-	if !aggregateFieldFound {
-		return errors.New("aggregate field not found, aggregations are not possible without it")
+	for _, e := range a.groupByExprs {
+		arr, exists, err := e.expr.ArrowArray(r)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		defer arr.Release()
+
+		groupByFields = append(groupByFields, arrow.Field{Name: e.name, Type: arr.DataType()})
+		groupByFieldHashes = append(groupByFieldHashes, scalar.Hash(a.hashSeed, scalar.NewStringScalar(e.name)))
+		groupByArrays = append(groupByArrays, arr)
 	}
 
 	numRows := int(r.NumRows())
 
+	selected := make([]*Bitmap, len(a.aggregations))
+	for j, agg := range a.aggregations {
+		if agg.filter == nil {
+			continue
+		}
+		var err error
+		selected[j], err = agg.filter.Eval(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The groups and, if filtered, the rows of the values passed to the
+	// user-defined aggregations.
+	groups := make([][]int, len(a.aggregations))
+	rows := make([][]int, len(a.aggregations))
+	for j, agg := range a.aggregations {
+		if agg.userDefined != nil {
+			groups[j] = make([]int, 0, numRows)
+		}
+	}
+
 	colHashes := make([][]uint64, len(groupByArrays))
 	for i, arr := range groupByArrays {
 		colHashes[i] = hashArray(arr)
 	}
 
+	// The rows of new groups that are spilled to each of the partitions.
+	var spilled [spillPartitions][]int
+
 	for i := 0; i < numRows; i++ {
 		hash := uint64(0)
 		for j := range colHashes {
@@ -251,20 +629,28 @@ func (a *HashAggregate) Callback(r arrow.Record) error {
 				continue
 			}
 
-			hash = hashCombine(
-				hash,
-				hashCombine(
-					groupByFieldHashes[j],
-					colHashes[j][i],
-				),
+			// The hashes of the columns are added up, so the group key
+			// doesn't depend on the order of the columns in the record.
+			hash += hashCombine(
+				groupByFieldHashes[j],
+				colHashes[j][i],
 			)
 		}
 
 		k, ok := a.hashToAggregate[hash]
+		if !ok && a.spilling {
+			p := hash % spillPartitions
+			spilled[p] = append(spilled[p], i)
+			continue
+		}
 		if !ok {
-			agg := array.NewBuilder(a.pool, columnToAggregate.DataType())
-			a.arraysToAggregate = append(a.arraysToAggregate, agg)
-			k = len(a.arraysToAggregate) - 1
+			for j, agg := range a.aggregations {
+				if agg.userDefined == nil {
+					agg.arraysToAggregate = append(agg.arraysToAggregate, array.NewBuilder(a.pool, columnsToAggregate[j].DataType()))
+				}
+			}
+			k = a.numGroups
+			a.numGroups++
 			a.hashToAggregate[hash] = k
 
 			// insert new row into columns grouped by and create new aggregate array to append to.
@@ -277,10 +663,9 @@ func (a *HashAggregate) Callback(r arrow.Record) error {
 					a.groupByCols[fieldName] = groupByCol
 				}
 
-				// We already appended to the arrays to aggregate, so we have
-				// to account for that. We only want to back-fill null values
-				// up until the index that we are about to insert into.
-				for groupByCol.Len() < len(a.arraysToAggregate)-1 {
+				// We only want to back-fill null values up until the index
+				// that we are about to insert into.
+				for groupByCol.Len() < k {
 					groupByCol.AppendNull()
 				}
 
@@ -291,14 +676,168 @@ func (a *HashAggregate) Callback(r arrow.Record) error {
 			}
 		}
 
-		if err := appendValue(a.arraysToAggregate[k], columnToAggregate, i); err != nil {
+		for j, agg := range a.aggregations {
+			if selected[j] != nil && !selected[j].Contains(uint32(i)) {
+				continue
+			}
+
+			if agg.userDefined != nil {
+				groups[j] = append(groups[j], k)
+				if selected[j] != nil || a.spilling {
+					rows[j] = append(rows[j], i)
+				}
+				continue
+			}
+
+			if err := appendValue(agg.arraysToAggregate[k], columnsToAggregate[j], i); err != nil {
+				return err
+			}
+		}
+	}
+
+	for j, agg := range a.aggregations {
+		if agg.userDefined == nil {
+			continue
+		}
+		if err := agg.update(a.pool, r, columnsToAggregate[j], selected[j] != nil || a.spilling, rows[j], groups[j]); err != nil {
+			return err
+		}
+	}
+
+	if a.spilling {
+		for p, rows := range spilled {
+			if len(rows) == 0 {
+				continue
+			}
+			if err := spillRows(a.pool, a.partitions[p], r, a.spillColumns, rows); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if a.memoryLimit > 0 && a.alloc.Allocated() > a.memoryLimit {
+		if a.newMergeAggregate != nil {
+			return a.spillStates()
+		}
+		if err := a.createPartitions(); err != nil {
 			return err
 		}
+		a.spilling = true
 	}
+	return nil
+}
 
+func (a *HashAggregate) createPartitions() error {
+	a.partitions = make([]*spillPartition, 0, spillPartitions)
+	for i := 0; i < spillPartitions; i++ {
+		p, err := newSpillPartition(a.spillDir)
+		if err != nil {
+			return err
+		}
+		a.partitions = append(a.partitions, p)
+	}
 	return nil
 }
 
+// spillStates spills the states of the groups aggregated so far to the
+// partitions of their groups, and continues with no groups. All states of a
+// group are spilled to the same partition, where they are merged once all
+// rows are aggregated.
+func (a *HashAggregate) spillStates() error {
+	if a.numGroups == 0 {
+		return nil
+	}
+	if a.partitions == nil {
+		if err := a.createPartitions(); err != nil {
+			return err
+		}
+	}
+
+	r, err := a.results(false, true)
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+
+	var groups [spillPartitions][]uint32
+	for hash, k := range a.hashToAggregate {
+		p := hash % spillPartitions
+		groups[p] = append(groups[p], uint32(k))
+	}
+	for p, groups := range groups {
+		if len(groups) == 0 {
+			continue
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i] < groups[j] })
+		states, err := pqarrow.SelectRows(a.pool, r, groups)
+		if err != nil {
+			return err
+		}
+		err = a.partitions[p].write(a.pool, states)
+		states.Release()
+		if err != nil {
+			return err
+		}
+	}
+
+	a.release()
+	a.aggregations, err = a.newAggregations()
+	return err
+}
+
+// update passes the values of the record that belong to the given groups to
+// the user-defined aggregation. If filtered, only the given rows are passed.
+func (a *hashAggregation) update(pool memory.Allocator, r arrow.Record, columnToAggregate arrow.Array, filtered bool, rows, groups []int) error {
+	arr, err := selectRows(pool, columnToAggregate, filtered, rows)
+	if err != nil {
+		return err
+	}
+	defer arr.Release()
+
+	if a.merge {
+		return a.userDefined.(stateAggregation).Merge(arr, groups)
+	}
+	if a.orderBy == nil {
+		return a.userDefined.Update(arr, groups)
+	}
+
+	order, exists, err := a.orderBy.ArrowArray(r)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("order by field not found, ordered aggregations are not possible without it")
+	}
+	defer order.Release()
+
+	order, err = selectRows(pool, order, filtered, rows)
+	if err != nil {
+		return err
+	}
+	defer order.Release()
+
+	return a.userDefined.(orderedAggregation).UpdateOrdered(arr, order, groups)
+}
+
+// selectRows returns the given rows of the array if filtered, otherwise the
+// array itself. The result must be released.
+func selectRows(pool memory.Allocator, arr arrow.Array, filtered bool, rows []int) (arrow.Array, error) {
+	if !filtered {
+		arr.Retain()
+		return arr, nil
+	}
+
+	b := array.NewBuilder(pool, arr.DataType())
+	defer b.Release()
+	for _, i := range rows {
+		if err := appendValue(b, arr, i); err != nil {
+			return nil, err
+		}
+	}
+	return b.NewArray(), nil
+}
+
 func appendValue(b array.Builder, arr arrow.Array, i int) error {
 	if arr == nil || arr.IsNull(i) {
 		b.AppendNull()
@@ -309,6 +848,18 @@ func appendValue(b array.Builder, arr arrow.Array, i int) error {
 	case *array.Int64:
 		b.(*array.Int64Builder).Append(arr.Value(i))
 		return nil
+	case *array.Uint64:
+		b.(*array.Uint64Builder).Append(arr.Value(i))
+		return nil
+	case *array.Float64:
+		b.(*array.Float64Builder).Append(arr.Value(i))
+		return nil
+	case *array.Timestamp:
+		b.(*array.TimestampBuilder).Append(arr.Value(i))
+		return nil
+	case *array.Decimal128:
+		b.(*array.Decimal128Builder).Append(arr.Value(i))
+		return nil
 	case *array.String:
 		b.(*array.StringBuilder).Append(arr.Value(i))
 		return nil
@@ -346,58 +897,353 @@ func appendValue(b array.Builder, arr arrow.Array, i int) error {
 	}
 }
 
-func (a *HashAggregate) Finish() error {
-	numCols := len(a.groupByCols) + 1
-	numRows := len(a.arraysToAggregate)
+// snapshot passes on the results of the groups aggregated so far, marked as
+// partial, without changing the state of the aggregation. No snapshot is
+// taken if any of the aggregations can't return their results before all
+// values are aggregated.
+func (a *HashAggregate) snapshot() error {
+	for _, agg := range a.aggregations {
+		if agg.userDefined == nil {
+			continue
+		}
+		if _, ok := agg.userDefined.(logicalplan.SnapshotAggregation); !ok {
+			return nil
+		}
+	}
 
-	groupByFields := make([]arrow.Field, 0, numCols)
-	groupByArrays := make([]arrow.Array, 0, numCols)
-	for fieldName, groupByCol := range a.groupByCols {
-		for groupByCol.Len() < numRows {
-			// It's possible that columns that are grouped by haven't occurred
-			// in all aggregated rows which causes them to not be of equal size
-			// as the total number of rows so we need to backfill. This happens
-			// for example when there are different sets of dynamic columns in
-			// different row-groups of the table.
-			groupByCol.AppendNull()
+	r, err := a.results(true, false)
+	if err != nil {
+		return err
+	}
+	return a.nextCallback(r)
+}
+
+// IsPartialResult returns whether the record is a snapshot of the results of
+// an aggregation, that is followed by its final results.
+func IsPartialResult(r arrow.Record) bool {
+	md := r.Schema().Metadata()
+	i := md.FindKey(partialResultKey)
+	return i >= 0 && md.Values()[i] == "true"
+}
+
+// partialResultKey is the key of the metadata of the schemas of records that
+// are partial results.
+const partialResultKey = "frostdb.partial"
+
+// copyBuilder returns the values appended to the builder, and a builder that
+// the same values are appended to, so more values can be appended to it. The
+// builder is released.
+func copyBuilder(pool memory.Allocator, b array.Builder) (arrow.Array, array.Builder, error) {
+	defer b.Release()
+	arr := b.NewArray()
+	res := array.NewBuilder(pool, arr.DataType())
+	for i := 0; i < arr.Len(); i++ {
+		if err := appendValue(res, arr, i); err != nil {
+			arr.Release()
+			res.Release()
+			return nil, nil, err
 		}
-		arr := groupByCol.NewArray()
-		groupByFields = append(groupByFields, arrow.Field{Name: fieldName, Type: arr.DataType()})
-		groupByArrays = append(groupByArrays, arr)
 	}
+	return arr, res, nil
+}
 
-	arrs := make([]arrow.Array, 0, numRows)
-	for _, arr := range a.arraysToAggregate {
-		arrs = append(arrs, arr.NewArray())
+func (a *HashAggregate) Finish() error {
+	if a.newMergeAggregate != nil && a.partitions != nil {
+		// The groups in memory are merged with the states that were
+		// spilled before.
+		if err := a.spillStates(); err != nil {
+			return err
+		}
+		return a.finishPartitions()
 	}
 
-	aggregateArray, err := a.aggregationFunction.Aggregate(a.pool, arrs)
+	r, err := a.results(false, false)
 	if err != nil {
-		return fmt.Errorf("aggregate batched arrays: %w", err)
+		return err
+	}
+	if err := a.nextCallback(r); err != nil {
+		return err
 	}
 
-	aggregateField := arrow.Field{Name: a.resultColumnName, Type: aggregateArray.DataType()}
-	cols := append(groupByArrays, aggregateArray)
-
-	return a.nextCallback(array.NewRecord(
-		arrow.NewSchema(append(groupByFields, aggregateField), nil),
-		cols,
-		int64(numRows),
-	))
+	return a.finishPartitions()
 }
 
-type Int64SumAggregation struct{}
-
-var ErrUnsupportedSumType = errors.New("unsupported type for sum aggregation, expected int64")
+// results returns the results of the groups aggregated so far. The results of
+// snapshots are marked as partial, and leave the state of the aggregation as
+// it is. If states is set the states of the groups are returned instead of
+// their results, see hashAggregation.states.
+func (a *HashAggregate) results(snapshot, states bool) (arrow.Record, error) {
+	numCols := len(a.groupByCols) + len(a.aggregations)
+	numRows := a.numGroups
 
-func (a *Int64SumAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
-	if len(arrs) == 0 {
-		return array.NewInt64Builder(pool).NewArray(), nil
+	fields := make([]arrow.Field, 0, numCols)
+	cols := make([]arrow.Array, 0, numCols)
+	// Sort the group by columns, so the columns of the result are in the same
+	// order regardless of the order of the map.
+	groupByColNames := make([]string, 0, len(a.groupByCols))
+	for fieldName := range a.groupByCols {
+		groupByColNames = append(groupByColNames, fieldName)
 	}
+	sort.Strings(groupByColNames)
 
-	typ := arrs[0].DataType().ID()
-	switch typ {
-	case arrow.INT64:
+	for _, fieldName := range groupByColNames {
+		groupByCol := a.groupByCols[fieldName]
+		for groupByCol.Len() < numRows {
+			// It's possible that columns that are grouped by haven't occurred
+			// in all aggregated rows which causes them to not be of equal size
+			// as the total number of rows so we need to backfill. This happens
+			// for example when there are different sets of dynamic columns in
+			// different row-groups of the table.
+			groupByCol.AppendNull()
+		}
+		var arr arrow.Array
+		if snapshot {
+			var err error
+			arr, a.groupByCols[fieldName], err = copyBuilder(a.pool, groupByCol)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			arr = groupByCol.NewArray()
+		}
+		fields = append(fields, arrow.Field{Name: fieldName, Type: arr.DataType()})
+		cols = append(cols, arr)
+	}
+
+	for _, agg := range a.aggregations {
+		if states {
+			stateFields, stateCols, err := agg.states(a.pool, numRows)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, stateFields...)
+			cols = append(cols, stateCols...)
+			continue
+		}
+
+		arr, err := agg.aggregate(a.pool, numRows, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, arrow.Field{Name: agg.resultColumnName, Type: arr.DataType()})
+		cols = append(cols, arr)
+	}
+
+	var metadata *arrow.Metadata
+	if snapshot {
+		md := arrow.NewMetadata([]string{partialResultKey}, []string{"true"})
+		metadata = &md
+	}
+	return array.NewRecord(
+		arrow.NewSchema(fields, metadata),
+		cols,
+		int64(numRows),
+	), nil
+}
+
+// Close drops the groups aggregated so far and removes the spill files,
+// without passing on any results. It's called instead of Finish when the
+// query fails.
+func (a *HashAggregate) Close() {
+	a.release()
+	for _, p := range a.partitions {
+		_ = p.remove()
+	}
+	a.partitions = nil
+}
+
+// release drops the groups aggregated so far.
+func (a *HashAggregate) release() {
+	for _, b := range a.groupByCols {
+		b.Release()
+	}
+	a.groupByCols = map[string]array.Builder{}
+	a.hashToAggregate = map[uint64]int{}
+	for _, agg := range a.aggregations {
+		for _, b := range agg.arraysToAggregate {
+			b.Release()
+		}
+		agg.arraysToAggregate = agg.arraysToAggregate[:0]
+		if c, ok := agg.userDefined.(closableAggregation); ok {
+			c.Close()
+		}
+	}
+	a.numGroups = 0
+}
+
+// finishPartitions aggregates the rows, or merges the states, spilled to each
+// of the partitions. The groups of the partitions are distinct from the
+// groups of other partitions and from those that were aggregated in memory,
+// so their results are passed on as they are.
+func (a *HashAggregate) finishPartitions() error {
+	defer func() {
+		for _, p := range a.partitions {
+			// The spill files are temporary, failing to remove them
+			// doesn't affect the result.
+			_ = p.remove()
+		}
+		a.partitions = nil
+	}()
+
+	for _, p := range a.partitions {
+		if p.streams == 0 {
+			continue
+		}
+
+		agg, err := a.partitionAggregate()
+		if err != nil {
+			return err
+		}
+
+		if err := p.read(a.alloc.Allocator, agg.Callback); err != nil {
+			return err
+		}
+		if err := agg.Finish(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partitionAggregate returns the aggregation of the records spilled to a
+// partition, which passes on its results.
+func (a *HashAggregate) partitionAggregate() (*HashAggregate, error) {
+	if a.newMergeAggregate == nil {
+		agg, err := a.newSpillAggregate()
+		if err != nil {
+			return nil, err
+		}
+		agg.SetNextCallback(a.nextCallback)
+		return agg, nil
+	}
+
+	agg, avgs, err := a.newMergeAggregate()
+	if err != nil {
+		return nil, err
+	}
+	if avgs == nil {
+		agg.SetNextCallback(a.nextCallback)
+		return agg, nil
+	}
+	avgs.SetNextCallback(a.nextCallback)
+	agg.SetNextCallback(avgs.Callback)
+	return agg, nil
+}
+
+// states returns the states of the aggregation for each of the groups, which
+// the aggregations of mergeAggregate merge. Averages are returned as the sums
+// and counts of their values.
+func (a *hashAggregation) states(pool memory.Allocator, numGroups int) ([]arrow.Field, []arrow.Array, error) {
+	if a.userDefined != nil {
+		arr, err := a.userDefined.(stateAggregation).States(numGroups)
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregation states: %w", err)
+		}
+		return []arrow.Field{{Name: a.resultColumnName, Type: arr.DataType()}}, []arrow.Array{arr}, nil
+	}
+	if _, ok := a.aggregationFunction.(*AvgAggregation); !ok {
+		arr, err := a.aggregate(pool, numGroups, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []arrow.Field{{Name: a.resultColumnName, Type: arr.DataType()}}, []arrow.Array{arr}, nil
+	}
+
+	arrs := make([]arrow.Array, 0, len(a.arraysToAggregate))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+	for _, b := range a.arraysToAggregate {
+		arrs = append(arrs, b.NewArray())
+	}
+	sum, err := chooseAggregationFunction(logicalplan.AggFuncSum, arrs[0].DataType())
+	if err != nil {
+		return nil, nil, err
+	}
+	sums, err := sum.Aggregate(pool, arrs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("aggregate sums: %w", err)
+	}
+	counts, err := (&CountAggregation{}).Aggregate(pool, arrs)
+	if err != nil {
+		sums.Release()
+		return nil, nil, fmt.Errorf("aggregate counts: %w", err)
+	}
+	return []arrow.Field{
+		{Name: avgSumName(a.resultColumnName), Type: sums.DataType()},
+		{Name: avgCountName(a.resultColumnName), Type: counts.DataType()},
+	}, []arrow.Array{sums, counts}, nil
+}
+
+// aggregate returns the results of the aggregation for each of the groups,
+// snapshots leave the state of the aggregation as it is.
+func (a *hashAggregation) aggregate(pool memory.Allocator, numGroups int, snapshot bool) (arrow.Array, error) {
+	if a.userDefined != nil {
+		var (
+			arr arrow.Array
+			err error
+		)
+		switch {
+		case a.state:
+			// The states are left as they are.
+			arr, err = a.userDefined.(stateAggregation).States(numGroups)
+		case snapshot:
+			arr, err = a.userDefined.(logicalplan.SnapshotAggregation).Snapshot(numGroups)
+		default:
+			arr, err = a.userDefined.Finalize(numGroups)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("finalize aggregation: %w", err)
+		}
+		if arr.Len() != numGroups {
+			arr.Release()
+			return nil, fmt.Errorf("aggregation returned %d values for %d groups", arr.Len(), numGroups)
+		}
+		return arr, nil
+	}
+
+	arrs := make([]arrow.Array, 0, len(a.arraysToAggregate))
+	for i, b := range a.arraysToAggregate {
+		if !snapshot {
+			arrs = append(arrs, b.NewArray())
+			continue
+		}
+		arr, copied, err := copyBuilder(pool, b)
+		if err != nil {
+			return nil, err
+		}
+		a.arraysToAggregate[i] = copied
+		arrs = append(arrs, arr)
+	}
+
+	arr, err := a.aggregationFunction.Aggregate(pool, arrs)
+	if snapshot {
+		// The copies of the arrays of snapshots aren't referenced by the
+		// aggregation.
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("aggregate batched arrays: %w", err)
+	}
+	return arr, nil
+}
+
+type Int64SumAggregation struct{}
+
+var ErrUnsupportedSumType = errors.New("unsupported type for sum aggregation, expected int64, float64 or decimal")
+
+func (a *Int64SumAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	if len(arrs) == 0 {
+		return array.NewInt64Builder(pool).NewArray(), nil
+	}
+
+	typ := arrs[0].DataType().ID()
+	switch typ {
+	case arrow.INT64:
 		return sumInt64arrays(pool, arrs), nil
 	default:
 		return nil, fmt.Errorf("sum array of %s: %w", typ, ErrUnsupportedSumType)
@@ -416,3 +1262,791 @@ func sumInt64arrays(pool memory.Allocator, arrs []arrow.Array) arrow.Array {
 func sumInt64array(arr *array.Int64) int64 {
 	return math.Int64.Sum(arr)
 }
+
+type Float64SumAggregation struct{}
+
+func (a *Float64SumAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	res := array.NewFloat64Builder(pool)
+	defer res.Release()
+	for _, arr := range arrs {
+		floats, ok := arr.(*array.Float64)
+		if !ok {
+			return nil, fmt.Errorf("sum array of %s: %w", arr.DataType().ID(), ErrUnsupportedSumType)
+		}
+		res.Append(math.Float64.Sum(floats))
+	}
+
+	return res.NewArray(), nil
+}
+
+// CountAggregation counts the non-null values of each group.
+type CountAggregation struct{}
+
+func (a *CountAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	res := array.NewInt64Builder(pool)
+	defer res.Release()
+	for _, arr := range arrs {
+		res.Append(int64(arr.Len() - arr.NullN()))
+	}
+
+	return res.NewArray(), nil
+}
+
+// MinAggregation returns the smallest non-null value of each group, or null
+// if the group has none.
+type MinAggregation struct {
+	DataType arrow.DataType
+}
+
+func (a *MinAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	return minMaxArrays(pool, a.DataType, arrs, false)
+}
+
+// MaxAggregation returns the largest non-null value of each group, or null if
+// the group has none.
+type MaxAggregation struct {
+	DataType arrow.DataType
+}
+
+func (a *MaxAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	return minMaxArrays(pool, a.DataType, arrs, true)
+}
+
+func minMaxArrays(pool memory.Allocator, dataType arrow.DataType, arrs []arrow.Array, largest bool) (arrow.Array, error) {
+	res := array.NewBuilder(pool, dataType)
+	defer res.Release()
+
+	for _, arr := range arrs {
+		best := -1
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsNull(i) {
+				continue
+			}
+			if best == -1 {
+				best = i
+				continue
+			}
+
+			a, b := i, best
+			if largest {
+				a, b = best, i
+			}
+			less, err := lessValue(arr, a, b)
+			if err != nil {
+				return nil, err
+			}
+			if less {
+				best = i
+			}
+		}
+
+		if best == -1 {
+			res.AppendNull()
+			continue
+		}
+		if err := appendValue(res, arr, best); err != nil {
+			return nil, err
+		}
+	}
+
+	return res.NewArray(), nil
+}
+
+// lessValue returns whether the value at i is less than the value at j.
+func lessValue(arr arrow.Array, i, j int) (bool, error) {
+	switch arr := arr.(type) {
+	case *array.Int64:
+		return arr.Value(i) < arr.Value(j), nil
+	case *array.Uint64:
+		return arr.Value(i) < arr.Value(j), nil
+	case *array.Float64:
+		return arr.Value(i) < arr.Value(j), nil
+	case *array.Timestamp:
+		return arr.Value(i) < arr.Value(j), nil
+	case *array.Decimal128:
+		return convert.CompareDecimals(arr.Value(i), arr.Value(j)) < 0, nil
+	case *array.String:
+		return arr.Value(i) < arr.Value(j), nil
+	case *array.Binary:
+		return bytes.Compare(arr.Value(i), arr.Value(j)) < 0, nil
+	default:
+		return false, fmt.Errorf("unsupported type for min/max aggregation: %s", arr.DataType().Name())
+	}
+}
+
+// AvgAggregation averages the non-null values of each group, or returns null
+// if the group has none. The average is computed from the sum and count of
+// the values, which unlike averages can be merged across partial
+// aggregations, see AvgProjection.
+type AvgAggregation struct{}
+
+func (a *AvgAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	res := array.NewFloat64Builder(pool)
+	defer res.Release()
+
+	for _, arr := range arrs {
+		state, err := avgStateOf(arr)
+		if err != nil {
+			return nil, err
+		}
+
+		avg, ok := state.Avg()
+		if !ok {
+			res.AppendNull()
+			continue
+		}
+		res.Append(avg)
+	}
+
+	return res.NewArray(), nil
+}
+
+// AvgState is the partial state of an average.
+type AvgState struct {
+	Sum   float64
+	Count int64
+}
+
+// Avg returns the average, or false if no values were aggregated.
+func (s AvgState) Avg() (float64, bool) {
+	if s.Count == 0 {
+		return 0, false
+	}
+	return s.Sum / float64(s.Count), true
+}
+
+func avgStateOf(arr arrow.Array) (AvgState, error) {
+	var value func(i int) float64
+	switch arr := arr.(type) {
+	case *array.Int64:
+		value = func(i int) float64 { return float64(arr.Value(i)) }
+	case *array.Uint64:
+		value = func(i int) float64 { return float64(arr.Value(i)) }
+	case *array.Float64:
+		value = arr.Value
+	case *array.Decimal128:
+		scale := arr.DataType().(*arrow.Decimal128Type).Scale
+		value = func(i int) float64 { return convert.DecimalFloat64(arr.Value(i), scale) }
+	default:
+		return AvgState{}, fmt.Errorf("unsupported type for avg aggregation: %s", arr.DataType().Name())
+	}
+
+	state := AvgState{}
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		state.Sum += value(i)
+		state.Count++
+	}
+	return state, nil
+}
+
+// stateAggregation is an aggregation with mergeable states. Partial
+// aggregations return the serialized states of their groups, see
+// logicalplan.AggregationFunction.State, which are merged by the final
+// aggregation.
+type stateAggregation interface {
+	logicalplan.UserDefinedAggregation
+	// States returns the serialized state of each group as binary values.
+	States(numGroups int) (arrow.Array, error)
+	// Merge merges the serialized states of the binary array into the states
+	// of their groups.
+	Merge(states arrow.Array, groups []int) error
+}
+
+// binaryStates returns the binary values of the states returned by state for
+// each group, which returns nil for groups without a state.
+func binaryStates(pool memory.Allocator, numGroups int, state func(g int) ([]byte, error)) (arrow.Array, error) {
+	res := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	defer res.Release()
+
+	for g := 0; g < numGroups; g++ {
+		data, err := state(g)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			res.AppendNull()
+			continue
+		}
+		res.Append(data)
+	}
+	return res.NewArray(), nil
+}
+
+// mergeBinaryStates passes the non-null states of the binary array to merge
+// with their groups, after grow made sure the groups exist.
+func mergeBinaryStates(states arrow.Array, groups []int, grow func(g int) error, merge func(g int, data []byte) error) error {
+	binaries, ok := states.(*array.Binary)
+	if !ok {
+		return fmt.Errorf("unsupported type of aggregation states: %s", states.DataType().Name())
+	}
+
+	for i, g := range groups {
+		if err := grow(g); err != nil {
+			return err
+		}
+		if binaries.IsNull(i) {
+			continue
+		}
+		if err := merge(g, binaries.Value(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApproxCountDistinctAggregation estimates the number of distinct non-null
+// values of each group with HyperLogLog sketches.
+type ApproxCountDistinctAggregation struct {
+	pool     memory.Allocator
+	sketches []*sketch.HyperLogLog
+}
+
+func (a *ApproxCountDistinctAggregation) Init(pool memory.Allocator) error {
+	a.pool = pool
+	return nil
+}
+
+func (a *ApproxCountDistinctAggregation) Update(arr arrow.Array, groups []int) error {
+	for i, g := range groups {
+		for len(a.sketches) <= g {
+			a.sketches = append(a.sketches, sketch.NewHyperLogLog())
+		}
+		if arr.IsNull(i) {
+			continue
+		}
+
+		h, err := hashValue(arr, i)
+		if err != nil {
+			return fmt.Errorf("approx count distinct: %w", err)
+		}
+		// Booleans hash to small constants, so all hashes are mixed to
+		// distribute them uniformly.
+		a.sketches[g].Insert(mixHash(h))
+	}
+	return nil
+}
+
+// Sketches returns the sketch of each group, so they can be merged with the
+// sketches of other partial aggregations.
+func (a *ApproxCountDistinctAggregation) Sketches() []*sketch.HyperLogLog {
+	return a.sketches
+}
+
+// States returns the serialized sketch of each group.
+func (a *ApproxCountDistinctAggregation) States(numGroups int) (arrow.Array, error) {
+	return binaryStates(a.pool, numGroups, func(g int) ([]byte, error) {
+		if g >= len(a.sketches) {
+			return nil, nil
+		}
+		return a.sketches[g].MarshalBinary()
+	})
+}
+
+// Merge merges the serialized sketches into the sketches of their groups.
+func (a *ApproxCountDistinctAggregation) Merge(states arrow.Array, groups []int) error {
+	grow := func(g int) error {
+		for len(a.sketches) <= g {
+			a.sketches = append(a.sketches, sketch.NewHyperLogLog())
+		}
+		return nil
+	}
+	return mergeBinaryStates(states, groups, grow, func(g int, data []byte) error {
+		h := &sketch.HyperLogLog{}
+		if err := h.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("approx count distinct: %w", err)
+		}
+		a.sketches[g].Merge(h)
+		return nil
+	})
+}
+
+// Snapshot returns the same results as Finalize, which leaves the sketches as
+// they are.
+func (a *ApproxCountDistinctAggregation) Snapshot(numGroups int) (arrow.Array, error) {
+	return a.Finalize(numGroups)
+}
+
+func (a *ApproxCountDistinctAggregation) Finalize(numGroups int) (arrow.Array, error) {
+	res := array.NewInt64Builder(a.pool)
+	defer res.Release()
+
+	for g := 0; g < numGroups; g++ {
+		if g >= len(a.sketches) {
+			res.Append(0)
+			continue
+		}
+		res.Append(int64(a.sketches[g].Estimate()))
+	}
+
+	return res.NewArray(), nil
+}
+
+// QuantileRelativeAccuracy is the relative accuracy of the values estimated by
+// quantile aggregations.
+const QuantileRelativeAccuracy = 0.01
+
+// QuantileAggregation estimates quantiles of the non-null values of each group
+// with DDSketches. Groups without values result in null.
+type QuantileAggregation struct {
+	Quantiles []float64
+	// List results in a list of the values of all quantiles instead of the
+	// value of the first one.
+	List bool
+
+	pool     memory.Allocator
+	sketches []*sketch.DDSketch
+}
+
+func (a *QuantileAggregation) Init(pool memory.Allocator) error {
+	if len(a.Quantiles) == 0 {
+		return errors.New("quantile aggregation requires at least one quantile")
+	}
+	a.pool = pool
+	return nil
+}
+
+func (a *QuantileAggregation) Update(arr arrow.Array, groups []int) error {
+	var value func(i int) float64
+	switch arr := arr.(type) {
+	case *array.Int64:
+		value = func(i int) float64 { return float64(arr.Value(i)) }
+	case *array.Uint64:
+		value = func(i int) float64 { return float64(arr.Value(i)) }
+	case *array.Float64:
+		value = arr.Value
+	default:
+		return fmt.Errorf("unsupported type for quantile aggregation: %s", arr.DataType().Name())
+	}
+
+	for i, g := range groups {
+		for len(a.sketches) <= g {
+			s, err := sketch.NewDDSketch(QuantileRelativeAccuracy)
+			if err != nil {
+				return err
+			}
+			a.sketches = append(a.sketches, s)
+		}
+		if arr.IsNull(i) {
+			continue
+		}
+		a.sketches[g].Add(value(i))
+	}
+	return nil
+}
+
+// Sketches returns the sketch of each group, so they can be merged with the
+// sketches of other partial aggregations.
+func (a *QuantileAggregation) Sketches() []*sketch.DDSketch {
+	return a.sketches
+}
+
+// States returns the serialized sketch of each group.
+func (a *QuantileAggregation) States(numGroups int) (arrow.Array, error) {
+	return binaryStates(a.pool, numGroups, func(g int) ([]byte, error) {
+		if g >= len(a.sketches) {
+			return nil, nil
+		}
+		return a.sketches[g].MarshalBinary()
+	})
+}
+
+// Merge merges the serialized sketches into the sketches of their groups.
+func (a *QuantileAggregation) Merge(states arrow.Array, groups []int) error {
+	grow := func(g int) error {
+		for len(a.sketches) <= g {
+			s, err := sketch.NewDDSketch(QuantileRelativeAccuracy)
+			if err != nil {
+				return err
+			}
+			a.sketches = append(a.sketches, s)
+		}
+		return nil
+	}
+	return mergeBinaryStates(states, groups, grow, func(g int, data []byte) error {
+		s := &sketch.DDSketch{}
+		if err := s.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("quantile: %w", err)
+		}
+		return a.sketches[g].Merge(s)
+	})
+}
+
+// Snapshot returns the same results as Finalize, which leaves the sketches as
+// they are.
+func (a *QuantileAggregation) Snapshot(numGroups int) (arrow.Array, error) {
+	return a.Finalize(numGroups)
+}
+
+func (a *QuantileAggregation) Finalize(numGroups int) (arrow.Array, error) {
+	if a.List {
+		res := array.NewListBuilder(a.pool, arrow.PrimitiveTypes.Float64)
+		defer res.Release()
+
+		values := res.ValueBuilder().(*array.Float64Builder)
+		for g := 0; g < numGroups; g++ {
+			if g >= len(a.sketches) || a.sketches[g].Count() == 0 {
+				res.AppendNull()
+				continue
+			}
+			res.Append(true)
+			for _, q := range a.Quantiles {
+				v, _ := a.sketches[g].Quantile(q)
+				values.Append(v)
+			}
+		}
+		return res.NewArray(), nil
+	}
+
+	res := array.NewFloat64Builder(a.pool)
+	defer res.Release()
+
+	for g := 0; g < numGroups; g++ {
+		if g >= len(a.sketches) {
+			res.AppendNull()
+			continue
+		}
+		v, ok := a.sketches[g].Quantile(a.Quantiles[0])
+		if !ok {
+			res.AppendNull()
+			continue
+		}
+		res.Append(v)
+	}
+	return res.NewArray(), nil
+}
+
+// VarianceAggregation computes the sample variance, or standard deviation, of
+// the non-null values of each group. Groups with less than two values result
+// in null.
+type VarianceAggregation struct {
+	StdDev bool
+
+	pool   memory.Allocator
+	states []VarianceState
+}
+
+func (a *VarianceAggregation) Init(pool memory.Allocator) error {
+	a.pool = pool
+	return nil
+}
+
+func (a *VarianceAggregation) Update(arr arrow.Array, groups []int) error {
+	var value func(i int) float64
+	switch arr := arr.(type) {
+	case *array.Int64:
+		value = func(i int) float64 { return float64(arr.Value(i)) }
+	case *array.Uint64:
+		value = func(i int) float64 { return float64(arr.Value(i)) }
+	case *array.Float64:
+		value = arr.Value
+	default:
+		return fmt.Errorf("unsupported type for variance aggregation: %s", arr.DataType().Name())
+	}
+
+	for i, g := range groups {
+		for len(a.states) <= g {
+			a.states = append(a.states, VarianceState{})
+		}
+		if arr.IsNull(i) {
+			continue
+		}
+		a.states[g].Add(value(i))
+	}
+	return nil
+}
+
+// States returns the serialized state of each group.
+func (a *VarianceAggregation) States(numGroups int) (arrow.Array, error) {
+	return binaryStates(a.pool, numGroups, func(g int) ([]byte, error) {
+		if g >= len(a.states) {
+			return nil, nil
+		}
+		return a.states[g].MarshalBinary()
+	})
+}
+
+// Merge merges the serialized states into the states of their groups.
+func (a *VarianceAggregation) Merge(states arrow.Array, groups []int) error {
+	grow := func(g int) error {
+		for len(a.states) <= g {
+			a.states = append(a.states, VarianceState{})
+		}
+		return nil
+	}
+	return mergeBinaryStates(states, groups, grow, func(g int, data []byte) error {
+		var s VarianceState
+		if err := s.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("variance: %w", err)
+		}
+		a.states[g] = a.states[g].Merge(s)
+		return nil
+	})
+}
+
+// Snapshot returns the same results as Finalize, which leaves the states as
+// they are.
+func (a *VarianceAggregation) Snapshot(numGroups int) (arrow.Array, error) {
+	return a.Finalize(numGroups)
+}
+
+func (a *VarianceAggregation) Finalize(numGroups int) (arrow.Array, error) {
+	res := array.NewFloat64Builder(a.pool)
+	defer res.Release()
+
+	for g := 0; g < numGroups; g++ {
+		if g >= len(a.states) {
+			res.AppendNull()
+			continue
+		}
+		v, ok := a.states[g].Variance()
+		if !ok {
+			res.AppendNull()
+			continue
+		}
+		if a.StdDev {
+			v = gomath.Sqrt(v)
+		}
+		res.Append(v)
+	}
+	return res.NewArray(), nil
+}
+
+// VarianceState is the partial state of a variance, updated with Welford's
+// algorithm to be numerically stable.
+type VarianceState struct {
+	Count int64
+	Mean  float64
+	// M2 is the sum of the squared differences to the mean.
+	M2 float64
+}
+
+func (s *VarianceState) Add(v float64) {
+	s.Count++
+	delta := v - s.Mean
+	s.Mean += delta / float64(s.Count)
+	s.M2 += delta * (v - s.Mean)
+}
+
+// Merge returns the state of the variance of the values of both states.
+func (s VarianceState) Merge(o VarianceState) VarianceState {
+	count := s.Count + o.Count
+	if count == 0 {
+		return VarianceState{}
+	}
+
+	delta := o.Mean - s.Mean
+	return VarianceState{
+		Count: count,
+		Mean:  s.Mean + delta*float64(o.Count)/float64(count),
+		M2:    s.M2 + o.M2 + delta*delta*float64(s.Count)*float64(o.Count)/float64(count),
+	}
+}
+
+// varianceStateSize is the size of serialized variance states.
+const varianceStateSize = 24
+
+// MarshalBinary encodes the state so it can be merged elsewhere.
+func (s VarianceState) MarshalBinary() ([]byte, error) {
+	data := make([]byte, varianceStateSize)
+	binary.LittleEndian.PutUint64(data, uint64(s.Count))
+	binary.LittleEndian.PutUint64(data[8:], gomath.Float64bits(s.Mean))
+	binary.LittleEndian.PutUint64(data[16:], gomath.Float64bits(s.M2))
+	return data, nil
+}
+
+func (s *VarianceState) UnmarshalBinary(data []byte) error {
+	if len(data) != varianceStateSize {
+		return fmt.Errorf("invalid variance state of %d bytes", len(data))
+	}
+	s.Count = int64(binary.LittleEndian.Uint64(data))
+	s.Mean = gomath.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+	s.M2 = gomath.Float64frombits(binary.LittleEndian.Uint64(data[16:]))
+	return nil
+}
+
+// Variance returns the sample variance, or false if less than two values
+// were aggregated.
+func (s VarianceState) Variance() (float64, bool) {
+	if s.Count < 2 {
+		return 0, false
+	}
+	return s.M2 / float64(s.Count-1), true
+}
+
+// orderedAggregation is an aggregation of values that are ordered by the
+// values of another array.
+type orderedAggregation interface {
+	UpdateOrdered(arr, order arrow.Array, groups []int) error
+}
+
+// closableAggregation is an aggregation that holds memory until it's closed.
+type closableAggregation interface {
+	Close()
+}
+
+// FirstLastAggregation returns the non-null value of each group with the
+// smallest, or if Last is set the largest, order value. Values are copied only
+// when they replace the current value of their group, which happens at most
+// once per group for each record.
+type FirstLastAggregation struct {
+	DataType arrow.DataType
+	Last     bool
+
+	pool memory.Allocator
+	// values holds the candidate values, of which each group refers to the
+	// one with the best order so far, or -1. Replaced values are dropped
+	// once they make up half of the values.
+	values     array.Builder
+	valueIndex []int
+	order      []int64
+	// numValues is the number of groups that have a value.
+	numValues int
+	// Buffers that are reused across updates.
+	bestRow   map[int]int
+	bestOrder map[int]int64
+}
+
+func (a *FirstLastAggregation) Init(pool memory.Allocator) error {
+	a.pool = pool
+	a.values = array.NewBuilder(pool, a.DataType)
+	a.bestRow = map[int]int{}
+	a.bestOrder = map[int]int64{}
+	return nil
+}
+
+func (a *FirstLastAggregation) Update(arr arrow.Array, groups []int) error {
+	return errors.New("first/last aggregation requires an order")
+}
+
+func (a *FirstLastAggregation) better(order, current int64) bool {
+	if a.Last {
+		return order > current
+	}
+	return order < current
+}
+
+func (a *FirstLastAggregation) UpdateOrdered(arr, order arrow.Array, groups []int) error {
+	var orderValue func(i int) int64
+	switch order := order.(type) {
+	case *array.Int64:
+		orderValue = order.Value
+	case *array.Timestamp:
+		orderValue = func(i int) int64 { return int64(order.Value(i)) }
+	default:
+		return fmt.Errorf("unsupported order type for first/last aggregation: %s", order.DataType().Name())
+	}
+
+	for g := range a.bestRow {
+		delete(a.bestRow, g)
+		delete(a.bestOrder, g)
+	}
+
+	// Find the best row of each group of the record first, so only one
+	// value per group has to be copied.
+	for i, g := range groups {
+		for len(a.valueIndex) <= g {
+			a.valueIndex = append(a.valueIndex, -1)
+			a.order = append(a.order, 0)
+		}
+		if arr.IsNull(i) || order.IsNull(i) {
+			continue
+		}
+
+		o := orderValue(i)
+		if best, ok := a.bestOrder[g]; ok && !a.better(o, best) {
+			continue
+		}
+		a.bestRow[g] = i
+		a.bestOrder[g] = o
+	}
+
+	for g, i := range a.bestRow {
+		o := a.bestOrder[g]
+		if a.valueIndex[g] != -1 && !a.better(o, a.order[g]) {
+			continue
+		}
+		if err := appendValue(a.values, arr, i); err != nil {
+			return err
+		}
+		if a.valueIndex[g] == -1 {
+			a.numValues++
+		}
+		a.valueIndex[g] = a.values.Len() - 1
+		a.order[g] = o
+	}
+
+	if a.values.Len() > 2*a.numValues {
+		return a.compact()
+	}
+	return nil
+}
+
+// compact drops the values that were replaced by better ones.
+func (a *FirstLastAggregation) compact() error {
+	values := a.values.NewArray()
+	defer values.Release()
+
+	for g, i := range a.valueIndex {
+		if i == -1 {
+			continue
+		}
+		if err := appendValue(a.values, values, i); err != nil {
+			return err
+		}
+		a.valueIndex[g] = a.values.Len() - 1
+	}
+	return nil
+}
+
+func (a *FirstLastAggregation) Finalize(numGroups int) (arrow.Array, error) {
+	values := a.values.NewArray()
+	defer values.Release()
+
+	return a.results(values, numGroups)
+}
+
+// Snapshot returns the first or last values of the groups so far, the values
+// are copied to a new builder so more values can be appended to them.
+func (a *FirstLastAggregation) Snapshot(numGroups int) (arrow.Array, error) {
+	values, copied, err := copyBuilder(a.pool, a.values)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+	a.values = copied
+
+	return a.results(values, numGroups)
+}
+
+func (a *FirstLastAggregation) Close() {
+	a.values.Release()
+}
+
+func (a *FirstLastAggregation) results(values arrow.Array, numGroups int) (arrow.Array, error) {
+	res := array.NewBuilder(a.pool, a.DataType)
+	defer res.Release()
+
+	for g := 0; g < numGroups; g++ {
+		if g >= len(a.valueIndex) || a.valueIndex[g] == -1 {
+			res.AppendNull()
+			continue
+		}
+		if err := appendValue(res, values, a.valueIndex[g]); err != nil {
+			return nil, err
+		}
+	}
+	return res.NewArray(), nil
+}
+
+// mixHash is the finalizer of splitmix64.
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
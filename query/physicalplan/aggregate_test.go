@@ -0,0 +1,752 @@
+package physicalplan
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestMinMaxAggregation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	groups := func(b array.Builder, appendGroups ...func()) []arrow.Array {
+		arrs := make([]arrow.Array, 0, len(appendGroups))
+		for _, appendGroup := range appendGroups {
+			appendGroup()
+			arrs = append(arrs, b.NewArray())
+		}
+		return arrs
+	}
+
+	fb := array.NewFloat64Builder(pool)
+	floats := groups(fb,
+		func() { fb.AppendValues([]float64{2.5, -1, 3}, nil) },
+		func() { fb.AppendNull() },
+		func() { fb.AppendValues([]float64{0, 7}, []bool{false, true}) },
+	)
+
+	ub := array.NewUint64Builder(pool)
+	uints := groups(ub,
+		func() { ub.AppendValues([]uint64{5, 1, 9}, nil) },
+	)
+
+	sb := array.NewStringBuilder(pool)
+	strs := groups(sb,
+		func() { sb.AppendValues([]string{"b", "c", "a"}, nil) },
+	)
+
+	bb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	bins := groups(bb,
+		func() { bb.AppendStringValues([]string{"web", "api"}, nil) },
+	)
+
+	tsType := &arrow.TimestampType{Unit: arrow.Millisecond}
+	tb := array.NewTimestampBuilder(pool, tsType)
+	timestamps := groups(tb,
+		func() { tb.AppendValues([]arrow.Timestamp{20, 10, 30}, nil) },
+	)
+
+	for _, tc := range []struct {
+		name     string
+		dataType arrow.DataType
+		arrs     []arrow.Array
+		min      []interface{}
+		max      []interface{}
+	}{
+		{"float64", arrow.PrimitiveTypes.Float64, floats, []interface{}{-1.0, nil, 7.0}, []interface{}{3.0, nil, 7.0}},
+		{"uint64", arrow.PrimitiveTypes.Uint64, uints, []interface{}{uint64(1)}, []interface{}{uint64(9)}},
+		{"string", arrow.BinaryTypes.String, strs, []interface{}{"a"}, []interface{}{"c"}},
+		{"binary", arrow.BinaryTypes.Binary, bins, []interface{}{[]byte("api")}, []interface{}{[]byte("web")}},
+		{"timestamp", tsType, timestamps, []interface{}{arrow.Timestamp(10)}, []interface{}{arrow.Timestamp(30)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := (&MinAggregation{DataType: tc.dataType}).Aggregate(pool, tc.arrs)
+			require.NoError(t, err)
+			require.Equal(t, tc.min, arrayValues(res))
+
+			res, err = (&MaxAggregation{DataType: tc.dataType}).Aggregate(pool, tc.arrs)
+			require.NoError(t, err)
+			require.Equal(t, tc.max, arrayValues(res))
+		})
+	}
+}
+
+func TestAvgAggregation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewInt64Builder(pool)
+	b.AppendValues([]int64{1, 2, 3, 0}, []bool{true, true, true, false})
+	first := b.NewArray()
+	b.AppendValues([]int64{10}, nil)
+	second := b.NewArray()
+	b.AppendNull()
+	empty := b.NewArray()
+
+	res, err := (&AvgAggregation{}).Aggregate(pool, []arrow.Array{first, second, empty})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{2.0, 10.0, nil}, arrayValues(res))
+
+	// The sums and counts of partial aggregations average all values rather
+	// than the averages.
+	firstState, err := avgStateOf(first)
+	require.NoError(t, err)
+	secondState, err := avgStateOf(second)
+	require.NoError(t, err)
+	avg, ok := AvgState{
+		Sum:   firstState.Sum + secondState.Sum,
+		Count: firstState.Count + secondState.Count,
+	}.Avg()
+	require.True(t, ok)
+	require.Equal(t, 4.0, avg)
+
+	_, ok = AvgState{}.Avg()
+	require.False(t, ok)
+}
+
+func TestAvgProjection(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "avg(value).sum", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "avg(value).count", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "labels.job", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+	record := func(counts *array.Int64Builder) arrow.Record {
+		jb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		defer jb.Release()
+		jb.AppendString("a")
+		jb.AppendString("b")
+		sb := array.NewInt64Builder(pool)
+		defer sb.Release()
+		sb.AppendValues([]int64{6, 4}, nil)
+		cols := []arrow.Array{sb.NewArray(), counts.NewArray(), jb.NewArray()}
+		defer func() {
+			for _, col := range cols {
+				col.Release()
+			}
+		}()
+		return array.NewRecord(schema, cols, 2)
+	}
+
+	p := &AvgProjection{
+		pool:   pool,
+		sums:   map[string]string{"avg(value).sum": "avg(value)"},
+		counts: map[string]bool{"avg(value).count": true},
+	}
+	var avgs []interface{}
+	p.SetNextCallback(func(r arrow.Record) error {
+		require.Equal(t, int64(2), r.NumCols())
+		require.Equal(t, "avg(value)", r.ColumnName(0))
+		avgs = arrayValues(r.Column(0))
+		return nil
+	})
+
+	cb := array.NewInt64Builder(pool)
+	defer cb.Release()
+	cb.AppendValues([]int64{3, 0}, nil)
+	r := record(cb)
+	require.NoError(t, p.Callback(r))
+	r.Release()
+	require.Equal(t, []interface{}{2.0, nil}, avgs)
+
+	// The averages already computed are released when averaging a later
+	// column fails.
+	p.sums["labels.job"] = "job"
+	cb.AppendValues([]int64{3, 0}, nil)
+	r = record(cb)
+	require.Error(t, p.Callback(r))
+	r.Release()
+}
+
+func TestFirstLastAggregation(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	a := &FirstLastAggregation{DataType: arrow.PrimitiveTypes.Int64, Last: true}
+	require.NoError(t, a.Init(pool))
+
+	b := array.NewInt64Builder(pool)
+	defer b.Release()
+	for i := 0; i < 100; i++ {
+		b.AppendValues([]int64{int64(i), int64(-i)}, nil)
+		arr := b.NewArray()
+		require.NoError(t, a.UpdateOrdered(arr, arr, []int{0, 1}))
+		arr.Release()
+
+		// Values that were replaced are dropped rather than kept until the
+		// aggregation is finalized.
+		require.LessOrEqual(t, a.values.Len(), 4)
+	}
+
+	res, err := a.Snapshot(3)
+	require.NoError(t, err)
+	defer res.Release()
+	values := res.(*array.Int64)
+	require.Equal(t, []int64{99, 0}, values.Int64Values()[:2])
+	require.True(t, values.IsNull(2))
+
+	// Closing the aggregation releases the values without finalizing it.
+	a.Close()
+}
+
+func TestApproxCountDistinctAggregation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	a := &ApproxCountDistinctAggregation{}
+	require.NoError(t, a.Init(pool))
+
+	b := array.NewInt64Builder(pool)
+	groups := []int{}
+	for i := 0; i < 10000; i++ {
+		b.Append(int64(i % 1000))
+		groups = append(groups, 0)
+	}
+	b.AppendValues([]int64{1, 1, 2, 0}, []bool{true, true, true, false})
+	groups = append(groups, 1, 1, 1, 1)
+	arr := b.NewArray()
+	require.NoError(t, a.Update(arr, groups))
+
+	bb := array.NewBooleanBuilder(pool)
+	bb.AppendValues([]bool{true, false, true}, nil)
+	bools := bb.NewArray()
+	require.NoError(t, a.Update(bools, []int{2, 2, 2}))
+
+	res, err := a.Finalize(4)
+	require.NoError(t, err)
+	counts := res.(*array.Int64).Int64Values()
+	require.InEpsilon(t, 1000, counts[0], 0.05)
+	require.Equal(t, []int64{2, 2, 0}, counts[1:])
+
+	// Merging states again doesn't change the estimates of their groups.
+	states, err := a.States(4)
+	require.NoError(t, err)
+	require.True(t, states.IsNull(3))
+	merged := &ApproxCountDistinctAggregation{}
+	require.NoError(t, merged.Init(pool))
+	require.NoError(t, merged.Merge(states, []int{0, 1, 2, 2}))
+	require.NoError(t, merged.Merge(states, []int{0, 1, 2, 2}))
+	res, err = merged.Finalize(3)
+	require.NoError(t, err)
+	counts = res.(*array.Int64).Int64Values()
+	require.InEpsilon(t, 1000, counts[0], 0.05)
+	require.Equal(t, []int64{2, 2}, counts[1:])
+}
+
+func TestQuantileAggregation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewFloat64Builder(pool)
+	groups := []int{}
+	for i := 1; i <= 100; i++ {
+		b.Append(float64(i))
+		groups = append(groups, 0)
+	}
+	b.AppendNull()
+	groups = append(groups, 1)
+	arr := b.NewArray()
+
+	a := &QuantileAggregation{Quantiles: []float64{0.5}}
+	require.NoError(t, a.Init(pool))
+	require.NoError(t, a.Update(arr, groups))
+	res, err := a.Finalize(3)
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Len())
+	require.InEpsilon(t, 50, res.(*array.Float64).Value(0), QuantileRelativeAccuracy)
+	require.True(t, res.IsNull(1))
+	require.True(t, res.IsNull(2))
+
+	a = &QuantileAggregation{Quantiles: []float64{0.1, 0.9}, List: true}
+	require.NoError(t, a.Init(pool))
+	require.NoError(t, a.Update(arr, groups))
+	res, err = a.Finalize(2)
+	require.NoError(t, err)
+	list := res.(*array.List)
+	require.True(t, list.IsNull(1))
+	values := list.ListValues().(*array.Float64)
+	require.Equal(t, 2, values.Len())
+	require.InEpsilon(t, 10, values.Value(0), QuantileRelativeAccuracy)
+	require.InEpsilon(t, 90, values.Value(1), QuantileRelativeAccuracy)
+
+	// Merged states result in the quantiles of the values of all states.
+	states, err := a.States(2)
+	require.NoError(t, err)
+	require.False(t, states.IsNull(1))
+	merged := &QuantileAggregation{Quantiles: []float64{0.5}}
+	require.NoError(t, merged.Init(pool))
+	require.NoError(t, merged.Merge(states, []int{0, 1}))
+	require.NoError(t, merged.Update(arr, groups))
+	res, err = merged.Finalize(2)
+	require.NoError(t, err)
+	require.InEpsilon(t, 50, res.(*array.Float64).Value(0), QuantileRelativeAccuracy)
+	require.True(t, res.IsNull(1))
+	require.Equal(t, uint64(200), merged.Sketches()[0].Count())
+
+	require.Error(t, (&QuantileAggregation{}).Init(pool))
+}
+
+func TestVarianceAggregation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewInt64Builder(pool)
+	b.AppendValues([]int64{2, 4, 4, 4, 5, 5, 7, 9, 1, 0}, []bool{true, true, true, true, true, true, true, true, true, false})
+	arr := b.NewArray()
+	groups := []int{0, 0, 0, 0, 0, 0, 0, 0, 1, 1}
+
+	a := &VarianceAggregation{}
+	require.NoError(t, a.Init(pool))
+	require.NoError(t, a.Update(arr, groups))
+	res, err := a.Finalize(3)
+	require.NoError(t, err)
+	require.InDelta(t, 32.0/7, res.(*array.Float64).Value(0), 1e-9)
+	// A single value has no sample variance.
+	require.True(t, res.IsNull(1))
+	require.True(t, res.IsNull(2))
+
+	a = &VarianceAggregation{StdDev: true}
+	require.NoError(t, a.Init(pool))
+	require.NoError(t, a.Update(arr, groups))
+	res, err = a.Finalize(1)
+	require.NoError(t, err)
+	require.InDelta(t, math.Sqrt(32.0/7), res.(*array.Float64).Value(0), 1e-9)
+
+	// Large offsets don't lose precision, and partial states merge to the
+	// state of all values.
+	first, second := VarianceState{}, VarianceState{}
+	for _, v := range []float64{4, 7} {
+		first.Add(1e9 + v)
+	}
+	for _, v := range []float64{13, 16} {
+		second.Add(1e9 + v)
+	}
+	v, ok := first.Merge(second).Variance()
+	require.True(t, ok)
+	require.InDelta(t, 30, v, 1e-6)
+
+	_, ok = VarianceState{}.Merge(VarianceState{}).Variance()
+	require.False(t, ok)
+
+	// The serialized states of partial aggregations are merged with Merge.
+	a = &VarianceAggregation{StdDev: true}
+	require.NoError(t, a.Init(pool))
+	require.NoError(t, a.Update(arr, groups))
+	states, err := a.States(3)
+	require.NoError(t, err)
+	require.True(t, states.IsNull(2))
+	merged := &VarianceAggregation{}
+	require.NoError(t, merged.Init(pool))
+	require.NoError(t, merged.Merge(states, []int{0, 0, 1}))
+	res, err = merged.Finalize(2)
+	require.NoError(t, err)
+	// The values of both groups are merged into the first one.
+	require.InDelta(t, 52.0/9, res.(*array.Float64).Value(0), 1e-9)
+	require.True(t, res.IsNull(1))
+	require.Error(t, merged.Merge(arr, []int{0}))
+
+	var decoded VarianceState
+	require.Error(t, decoded.UnmarshalBinary([]byte{1}))
+}
+
+func TestHashAggregateSpill(t *testing.T) {
+	pool := memory.NewGoAllocator()
+	dir := t.TempDir()
+
+	agg, err := Aggregate(pool, dynparquet.NewSampleSchema(), &logicalplan.Aggregation{
+		AggExprs: []logicalplan.Expr{
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Count(logicalplan.Col("value")).Where(logicalplan.Col("value").Gt(logicalplan.Literal(int64(1)))),
+			logicalplan.ApproxCountDistinct(logicalplan.Col("value")),
+			logicalplan.Avg(logicalplan.Col("value")),
+		},
+		GroupExprs: []logicalplan.Expr{logicalplan.Col("labels.job")},
+	}, WithAggregationMemoryLimit(1), WithSpillDir(dir))
+	require.NoError(t, err)
+
+	res := map[string][]interface{}{}
+	records := 0
+	agg.SetNextCallback(func(r arrow.Record) error {
+		records++
+		jobs := r.Column(0).(*array.Binary)
+		for i := 0; i < int(r.NumRows()); i++ {
+			res[string(jobs.Value(i))] = []interface{}{
+				r.Column(1).(*array.Int64).Value(i),
+				r.Column(2).(*array.Int64).Value(i),
+				r.Column(3).(*array.Int64).Value(i),
+				r.Column(4).(*array.Float64).Value(i),
+			}
+		}
+		return nil
+	})
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "labels.job", Type: arrow.BinaryTypes.Binary},
+		{Name: "value", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	value := int64(0)
+	// The memory limit is exceeded after each record, so the states of the
+	// groups are spilled after each of them and merged once all records are
+	// aggregated.
+	for _, jobs := range [][]string{{"a", "b"}, {"a", "c", "d"}, {"b", "c", "e"}} {
+		jb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		vb := array.NewInt64Builder(pool)
+		for _, job := range jobs {
+			value++
+			jb.AppendString(job)
+			vb.Append(value)
+		}
+		r := array.NewRecord(schema, []arrow.Array{jb.NewArray(), vb.NewArray()}, int64(len(jobs)))
+		require.NoError(t, agg.Callback(r))
+		r.Release()
+	}
+	require.NoError(t, agg.Finish())
+
+	require.Greater(t, records, 1)
+	require.Equal(t, map[string][]interface{}{
+		"a": {int64(4), int64(1), int64(2), 2.0},
+		"b": {int64(8), int64(2), int64(2), 4.0},
+		"c": {int64(11), int64(2), int64(2), 5.5},
+		"d": {int64(5), int64(1), int64(1), 5.0},
+		"e": {int64(8), int64(1), int64(1), 8.0},
+	}, res)
+
+	// The spill files are removed once the aggregation is finished.
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestHashAggregateSpillRows(t *testing.T) {
+	pool := memory.NewGoAllocator()
+	dir := t.TempDir()
+
+	// The results of the last values can't be merged from the states of
+	// their groups, so the rows of new groups are spilled instead.
+	agg, err := Aggregate(pool, dynparquet.NewSampleSchema(), &logicalplan.Aggregation{
+		AggExprs: []logicalplan.Expr{
+			logicalplan.LastBy(logicalplan.Col("value"), logicalplan.Col("value")),
+		},
+		GroupExprs: []logicalplan.Expr{logicalplan.Col("labels.job")},
+	}, WithAggregationMemoryLimit(1), WithSpillDir(dir))
+	require.NoError(t, err)
+	require.Nil(t, agg.newMergeAggregate)
+
+	res := map[string]int64{}
+	agg.SetNextCallback(func(r arrow.Record) error {
+		jobs := r.Column(0).(*array.Binary)
+		for i := 0; i < int(r.NumRows()); i++ {
+			res[string(jobs.Value(i))] = r.Column(1).(*array.Int64).Value(i)
+		}
+		return nil
+	})
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "labels.job", Type: arrow.BinaryTypes.Binary},
+		{Name: "value", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	value := int64(0)
+	for _, jobs := range [][]string{{"a", "b"}, {"a", "c", "d"}, {"b", "c", "e"}} {
+		jb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		vb := array.NewInt64Builder(pool)
+		for _, job := range jobs {
+			value++
+			jb.AppendString(job)
+			vb.Append(value)
+		}
+		r := array.NewRecord(schema, []arrow.Array{jb.NewArray(), vb.NewArray()}, int64(len(jobs)))
+		require.NoError(t, agg.Callback(r))
+		r.Release()
+	}
+	require.NoError(t, agg.Finish())
+
+	require.Equal(t, map[string]int64{"a": 3, "b": 6, "c": 7, "d": 5, "e": 8}, res)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestHashAggregateSpillPartial(t *testing.T) {
+	pool := memory.NewGoAllocator()
+	s := dynparquet.NewSampleSchema()
+	final := &logicalplan.Aggregation{
+		AggExprs: []logicalplan.Expr{
+			logicalplan.Avg(logicalplan.Col("value")),
+			logicalplan.ApproxCountDistinct(logicalplan.Col("value")),
+		},
+		GroupExprs: []logicalplan.Expr{logicalplan.Col("labels.job")},
+	}
+
+	// The spilled states of a partial aggregation are merged into states,
+	// that the final aggregation merges.
+	partial, err := Aggregate(pool, s, partialAggregation(final), WithAggregationMemoryLimit(1), WithSpillDir(t.TempDir()))
+	require.NoError(t, err)
+	merge, avgs, err := mergeAggregate(pool, s, final)
+	require.NoError(t, err)
+	partial.SetNextCallback(merge.Callback)
+	merge.SetNextCallback(avgs.Callback)
+
+	res := map[string][]interface{}{}
+	avgs.SetNextCallback(func(r arrow.Record) error {
+		jobs := r.Column(0).(*array.Binary)
+		for i := 0; i < int(r.NumRows()); i++ {
+			res[string(jobs.Value(i))] = []interface{}{
+				r.Column(1).(*array.Float64).Value(i),
+				r.Column(2).(*array.Int64).Value(i),
+			}
+		}
+		return nil
+	})
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "labels.job", Type: arrow.BinaryTypes.Binary},
+		{Name: "value", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	for _, values := range [][]int64{{1, 2}, {3, 3}, {5, 6}} {
+		jb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		vb := array.NewInt64Builder(pool)
+		for i, value := range values {
+			jb.AppendString([]string{"a", "b"}[i])
+			vb.Append(value)
+		}
+		r := array.NewRecord(schema, []arrow.Array{jb.NewArray(), vb.NewArray()}, int64(len(values)))
+		require.NoError(t, partial.Callback(r))
+		r.Release()
+	}
+	require.NoError(t, partial.Finish())
+	require.NoError(t, merge.Finish())
+
+	require.Equal(t, map[string][]interface{}{
+		"a": {3.0, int64(3)},
+		"b": {11.0 / 3, int64(3)},
+	}, res)
+}
+
+func TestHashAggregateClose(t *testing.T) {
+	pool := memory.NewGoAllocator()
+	dir := t.TempDir()
+
+	agg, err := Aggregate(pool, dynparquet.NewSampleSchema(), &logicalplan.Aggregation{
+		AggExprs:   []logicalplan.Expr{logicalplan.Sum(logicalplan.Col("value"))},
+		GroupExprs: []logicalplan.Expr{logicalplan.Col("labels.job")},
+	}, WithAggregationMemoryLimit(1), WithSpillDir(dir))
+	require.NoError(t, err)
+	agg.SetNextCallback(func(r arrow.Record) error {
+		t.Fatal("unexpected record")
+		return nil
+	})
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "labels.job", Type: arrow.BinaryTypes.Binary},
+		{Name: "value", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	for _, jobs := range [][]string{{"a", "b"}, {"c", "d"}} {
+		jb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		vb := array.NewInt64Builder(pool)
+		for _, job := range jobs {
+			jb.AppendString(job)
+			vb.Append(1)
+		}
+		r := array.NewRecord(schema, []arrow.Array{jb.NewArray(), vb.NewArray()}, int64(len(jobs)))
+		require.NoError(t, agg.Callback(r))
+		r.Release()
+	}
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	// Closing the aggregation removes the spill files without passing on
+	// any groups.
+	agg.Close()
+	files, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestHashAggregatePartialResults(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	agg, err := Aggregate(pool, dynparquet.NewSampleSchema(), &logicalplan.Aggregation{
+		AggExprs: []logicalplan.Expr{
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.ApproxCountDistinct(logicalplan.Col("value")),
+		},
+		GroupExprs: []logicalplan.Expr{logicalplan.Col("labels.job")},
+	})
+	require.NoError(t, err)
+	agg.partialResults = time.Second
+
+	type result struct {
+		partial bool
+		groups  map[string][]int64
+	}
+	results := []result{}
+	agg.SetNextCallback(func(r arrow.Record) error {
+		res := result{partial: IsPartialResult(r), groups: map[string][]int64{}}
+		jobs := r.Column(0).(*array.Binary)
+		for i := 0; i < int(r.NumRows()); i++ {
+			res.groups[string(jobs.Value(i))] = []int64{
+				r.Column(1).(*array.Int64).Value(i),
+				r.Column(2).(*array.Int64).Value(i),
+			}
+		}
+		results = append(results, res)
+		return nil
+	})
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "labels.job", Type: arrow.BinaryTypes.Binary},
+		{Name: "value", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	for i, jobs := range [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}} {
+		jb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		vb := array.NewInt64Builder(pool)
+		for j, job := range jobs {
+			jb.AppendString(job)
+			vb.Append(int64(i*2 + j + 1))
+		}
+		r := array.NewRecord(schema, []arrow.Array{jb.NewArray(), vb.NewArray()}, int64(len(jobs)))
+		// The first record starts the interval, a snapshot is taken after
+		// each of the following ones.
+		if i > 0 {
+			agg.lastSnapshot = time.Now().Add(-agg.partialResults)
+		}
+		require.NoError(t, agg.Callback(r))
+		r.Release()
+	}
+	require.NoError(t, agg.Finish())
+
+	// Snapshots don't change the state of the aggregation, so the final
+	// results aggregate all of the records.
+	require.Equal(t, []result{{
+		partial: true,
+		groups: map[string][]int64{
+			"a": {4, 2},
+			"b": {2, 1},
+			"c": {4, 1},
+		},
+	}, {
+		partial: true,
+		groups: map[string][]int64{
+			"a": {4, 2},
+			"b": {7, 2},
+			"c": {10, 2},
+		},
+	}, {
+		partial: false,
+		groups: map[string][]int64{
+			"a": {4, 2},
+			"b": {7, 2},
+			"c": {10, 2},
+		},
+	}}, results)
+}
+
+func arrayValues(arr arrow.Array) []interface{} {
+	res := make([]interface{}, arr.Len())
+	for i := range res {
+		if arr.IsNull(i) {
+			continue
+		}
+		switch arr := arr.(type) {
+		case *array.Float64:
+			res[i] = arr.Value(i)
+		case *array.Uint64:
+			res[i] = arr.Value(i)
+		case *array.String:
+			res[i] = arr.Value(i)
+		case *array.Binary:
+			res[i] = arr.Value(i)
+		case *array.Timestamp:
+			res[i] = arr.Value(i)
+		}
+	}
+	return res
+}
+
+func TestHashAggregateZeroAndNullGroups(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	valid := []bool{true, false, true, true, false, true}
+	for name, build := range map[string]func() arrow.Array{
+		"int64": func() arrow.Array {
+			b := array.NewInt64Builder(pool)
+			defer b.Release()
+			b.AppendValues([]int64{0, 0, 0, 1, 0, 1}, valid)
+			return b.NewArray()
+		},
+		"uint64": func() arrow.Array {
+			b := array.NewUint64Builder(pool)
+			defer b.Release()
+			b.AppendValues([]uint64{0, 0, 0, 1, 0, 1}, valid)
+			return b.NewArray()
+		},
+		"float64": func() arrow.Array {
+			b := array.NewFloat64Builder(pool)
+			defer b.Release()
+			b.AppendValues([]float64{0, 0, math.Copysign(0, -1), 1, 0, 1}, valid)
+			return b.NewArray()
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			agg, err := Aggregate(pool, dynparquet.NewSampleSchema(), &logicalplan.Aggregation{
+				AggExprs:   []logicalplan.Expr{logicalplan.Count(logicalplan.Col("timestamp"))},
+				GroupExprs: []logicalplan.Expr{logicalplan.Col("group")},
+			})
+			require.NoError(t, err)
+			defer agg.Close()
+
+			// The zeros and the nulls are separate groups.
+			counts := map[string]int64{}
+			agg.SetNextCallback(func(r arrow.Record) error {
+				groups := r.Column(0)
+				for i := 0; i < int(r.NumRows()); i++ {
+					key := "null"
+					if groups.IsNull(i) {
+						counts[key] = r.Column(1).(*array.Int64).Value(i)
+						continue
+					}
+					switch arr := groups.(type) {
+					case *array.Int64:
+						key = strconv.FormatInt(arr.Value(i), 10)
+					case *array.Uint64:
+						key = strconv.FormatUint(arr.Value(i), 10)
+					case *array.Float64:
+						key = strconv.FormatFloat(arr.Value(i), 'g', -1, 64)
+					}
+					counts[key] = r.Column(1).(*array.Int64).Value(i)
+				}
+				return nil
+			})
+
+			groups := build()
+			tb := array.NewInt64Builder(pool)
+			tb.AppendValues([]int64{1, 2, 3, 4, 5, 6}, nil)
+			timestamps := tb.NewArray()
+			tb.Release()
+			r := array.NewRecord(arrow.NewSchema([]arrow.Field{
+				{Name: "group", Type: groups.DataType(), Nullable: true},
+				{Name: "timestamp", Type: arrow.PrimitiveTypes.Int64},
+			}, nil), []arrow.Array{groups, timestamps}, 6)
+			groups.Release()
+			timestamps.Release()
+			require.NoError(t, agg.Callback(r))
+			r.Release()
+			require.NoError(t, agg.Finish())
+
+			require.Len(t, counts, 3)
+			require.Equal(t, int64(2), counts["null"])
+			require.Equal(t, int64(2), counts["1"])
+			require.Equal(t, int64(2), counts["0"]+counts["-0"])
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package physicalplan
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// ArrayExpression is an expression that evaluates to an Apache Arrow array
+// with one value per row of the record it is evaluated on. The boolean return
+// value reports whether the expression could be evaluated at all, which is
+// not the case when a column it references is not present in the record. The
+// returned array must be released by the caller.
+type ArrayExpression interface {
+	ArrowArray(r arrow.Record) (arrow.Array, bool, error)
+	String() string
+}
+
+// LiteralArray is an ArrayExpression repeating a scalar value for each row of
+// the record.
+type LiteralArray struct {
+	pool  memory.Allocator
+	Value scalar.Scalar
+}
+
+func (l *LiteralArray) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	arr, err := scalar.MakeArrayFromScalar(l.Value, int(r.NumRows()), l.pool)
+	if err != nil {
+		return nil, false, err
+	}
+	return arr, true, nil
+}
+
+func (l *LiteralArray) String() string {
+	return l.Value.String()
+}
+
+// ArithmeticExpr computes the element-wise arithmetic operation between the
+// results of two other array expressions.
+type ArithmeticExpr struct {
+	pool  memory.Allocator
+	Left  ArrayExpression
+	Op    logicalplan.Op
+	Right ArrayExpression
+}
+
+func (e *ArithmeticExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	left, exists, err := e.Left.ArrowArray(r)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	defer left.Release()
+
+	right, exists, err := e.Right.ArrowArray(r)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	defer right.Release()
+
+	res, err := ArithmeticOperation(e.pool, left, right, e.Op)
+	if err != nil {
+		return nil, false, err
+	}
+	return res, true, nil
+}
+
+func (e *ArithmeticExpr) String() string {
+	return e.Left.String() + " " + e.Op.String() + " " + e.Right.String()
+}
+
+// BooleanArray is an ArrayExpression evaluating a boolean expression into a
+// boolean array, which is true for the rows the expression matches.
+type BooleanArray struct {
+	pool memory.Allocator
+	Expr BooleanExpression
+}
+
+func (b *BooleanArray) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	bitmap, err := b.Expr.Eval(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	vals := make([]bool, r.NumRows())
+	for _, pos := range bitmap.ToArray() {
+		vals[int(pos)] = true
+	}
+
+	builder := array.NewBooleanBuilder(b.pool)
+	defer builder.Release()
+	builder.AppendValues(vals, nil)
+	return builder.NewArray(), true, nil
+}
+
+func (b *BooleanArray) String() string {
+	return b.Expr.String()
+}
+
+// arrayExpr converts a logical expression into an ArrayExpression that can be
+// evaluated against records.
+func arrayExpr(pool memory.Allocator, expr logicalplan.Expr) (ArrayExpression, error) {
+	switch e := expr.(type) {
+	case *logicalplan.Column:
+		return &ArrayRef{ColumnName: e.ColumnName}, nil
+	case *logicalplan.LiteralExpr:
+		return &LiteralArray{pool: pool, Value: e.Value}, nil
+	case *logicalplan.AliasExpr:
+		return arrayExpr(pool, e.Expr)
+	case *logicalplan.CaseExpr:
+		return caseExpr(pool, e)
+	case *logicalplan.ScalarFunctionExpr:
+		args := make([]ArrayExpression, 0, len(e.Args))
+		for _, arg := range e.Args {
+			argExpr, err := arrayExpr(pool, arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, argExpr)
+		}
+
+		return &ScalarFunctionExpr{
+			pool: pool,
+			Func: e.Func,
+			Args: args,
+		}, nil
+	case *logicalplan.CallExpr:
+		return callExpr(pool, e)
+	case *logicalplan.CoalesceExpr:
+		exprs := make([]ArrayExpression, 0, len(e.Exprs))
+		for _, expr := range e.Exprs {
+			arrExpr, err := arrayExpr(pool, expr)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, arrExpr)
+		}
+
+		return &CoalesceExpr{
+			pool:  pool,
+			Exprs: exprs,
+		}, nil
+	case *logicalplan.DurationTruncateExpr:
+		if e.Duration.Milliseconds() <= 0 {
+			return nil, fmt.Errorf("invalid duration to truncate to: %s", e.Duration)
+		}
+
+		inner, err := arrayExpr(pool, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DurationTruncateExpr{
+			pool:     pool,
+			Expr:     inner,
+			Duration: e.Duration.Milliseconds(),
+		}, nil
+	case *logicalplan.CastExpr:
+		inner, err := arrayExpr(pool, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CastExpr{
+			pool: pool,
+			Expr: inner,
+			Type: e.Type,
+		}, nil
+	case *logicalplan.UnaryExpr, *logicalplan.BetweenExpr:
+		return booleanArrayExpr(pool, e)
+	case *logicalplan.BinaryExpr:
+		if !e.Op.IsArithmetic() {
+			return booleanArrayExpr(pool, e)
+		}
+
+		left, err := arrayExpr(pool, e.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := arrayExpr(pool, e.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ArithmeticExpr{
+			pool:  pool,
+			Left:  left,
+			Op:    e.Op,
+			Right: right,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported array expression: %T", expr)
+	}
+}
+
+func booleanArrayExpr(pool memory.Allocator, expr logicalplan.Expr) (ArrayExpression, error) {
+	boolExpr, err := booleanExpr(pool, expr)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported array expression %s: %w", expr.Name(), err)
+	}
+
+	return &BooleanArray{
+		pool: pool,
+		Expr: boolExpr,
+	}, nil
+}
+
+// ArithmeticOperation computes the element-wise arithmetic operation of the
+// two given arrays. If the result of a single element is undefined, such as
+// an integer division by zero, the resulting element is null.
+func ArithmeticOperation(pool memory.Allocator, left, right arrow.Array, operator logicalplan.Op) (arrow.Array, error) {
+	if left.Len() != right.Len() {
+		return nil, fmt.Errorf("arithmetic on arrays of different lengths: %d and %d", left.Len(), right.Len())
+	}
+
+	switch l := left.(type) {
+	case *array.Int64:
+		switch r := right.(type) {
+		case *array.Int64:
+			return Int64ArrayArithmetic(pool, l, r, operator)
+		case *array.Float64:
+			return Float64ArrayArithmetic(pool, int64ToFloat64Array(pool, l), r, operator)
+		}
+	case *array.Uint64:
+		switch r := right.(type) {
+		case *array.Uint64:
+			return Uint64ArrayArithmetic(pool, l, r, operator)
+		}
+	case *array.Float64:
+		switch r := right.(type) {
+		case *array.Float64:
+			return Float64ArrayArithmetic(pool, l, r, operator)
+		case *array.Int64:
+			return Float64ArrayArithmetic(pool, l, int64ToFloat64Array(pool, r), operator)
+		}
+	}
+
+	return nil, fmt.Errorf("arithmetic between %s and %s: %w", left.DataType().Name(), right.DataType().Name(), ErrUnsupportedBinaryOperation)
+}
+
+func int64ToFloat64Array(pool memory.Allocator, arr *array.Int64) *array.Float64 {
+	b := array.NewFloat64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		b.UnsafeAppend(float64(arr.Value(i)))
+	}
+	return b.NewFloat64Array()
+}
+
+func Int64ArrayArithmetic(pool memory.Allocator, left, right *array.Int64, operator logicalplan.Op) (arrow.Array, error) {
+	b := array.NewInt64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(left.Len())
+	lv, rv := left.Int64Values(), right.Int64Values()
+	for i := range lv {
+		if left.IsNull(i) || right.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		switch operator {
+		case logicalplan.OpAdd:
+			b.UnsafeAppend(lv[i] + rv[i])
+		case logicalplan.OpSub:
+			b.UnsafeAppend(lv[i] - rv[i])
+		case logicalplan.OpMul:
+			b.UnsafeAppend(lv[i] * rv[i])
+		case logicalplan.OpDiv:
+			if rv[i] == 0 {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(lv[i] / rv[i])
+		case logicalplan.OpMod:
+			if rv[i] == 0 {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(lv[i] % rv[i])
+		default:
+			return nil, fmt.Errorf("int64 arithmetic with operator %s: %w", operator.String(), ErrUnsupportedBinaryOperation)
+		}
+	}
+
+	return b.NewArray(), nil
+}
+
+func Uint64ArrayArithmetic(pool memory.Allocator, left, right *array.Uint64, operator logicalplan.Op) (arrow.Array, error) {
+	b := array.NewUint64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(left.Len())
+	lv, rv := left.Uint64Values(), right.Uint64Values()
+	for i := range lv {
+		if left.IsNull(i) || right.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		switch operator {
+		case logicalplan.OpAdd:
+			b.UnsafeAppend(lv[i] + rv[i])
+		case logicalplan.OpSub:
+			b.UnsafeAppend(lv[i] - rv[i])
+		case logicalplan.OpMul:
+			b.UnsafeAppend(lv[i] * rv[i])
+		case logicalplan.OpDiv:
+			if rv[i] == 0 {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(lv[i] / rv[i])
+		case logicalplan.OpMod:
+			if rv[i] == 0 {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(lv[i] % rv[i])
+		default:
+			return nil, fmt.Errorf("uint64 arithmetic with operator %s: %w", operator.String(), ErrUnsupportedBinaryOperation)
+		}
+	}
+
+	return b.NewArray(), nil
+}
+
+func Float64ArrayArithmetic(pool memory.Allocator, left, right *array.Float64, operator logicalplan.Op) (arrow.Array, error) {
+	b := array.NewFloat64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(left.Len())
+	lv, rv := left.Float64Values(), right.Float64Values()
+	for i := range lv {
+		if left.IsNull(i) || right.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		switch operator {
+		case logicalplan.OpAdd:
+			b.UnsafeAppend(lv[i] + rv[i])
+		case logicalplan.OpSub:
+			b.UnsafeAppend(lv[i] - rv[i])
+		case logicalplan.OpMul:
+			b.UnsafeAppend(lv[i] * rv[i])
+		case logicalplan.OpDiv:
+			b.UnsafeAppend(lv[i] / rv[i])
+		case logicalplan.OpMod:
+			b.UnsafeAppend(math.Mod(lv[i], rv[i]))
+		default:
+			return nil, fmt.Errorf("float64 arithmetic with operator %s: %w", operator.String(), ErrUnsupportedBinaryOperation)
+		}
+	}
+
+	return b.NewArray(), nil
+}
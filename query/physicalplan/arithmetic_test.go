@@ -0,0 +1,127 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestArithmeticOperation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	lb := array.NewInt64Builder(pool)
+	lb.AppendValues([]int64{10, 7, 3, 4}, []bool{true, true, true, false})
+	left := lb.NewInt64Array()
+
+	rb := array.NewInt64Builder(pool)
+	rb.AppendValues([]int64{2, 0, 2, 1}, nil)
+	right := rb.NewInt64Array()
+
+	tests := map[string]struct {
+		op       logicalplan.Op
+		expected []int64
+		valid    []bool
+	}{
+		"add": {op: logicalplan.OpAdd, expected: []int64{12, 7, 5, 0}, valid: []bool{true, true, true, false}},
+		"sub": {op: logicalplan.OpSub, expected: []int64{8, 7, 1, 0}, valid: []bool{true, true, true, false}},
+		"mul": {op: logicalplan.OpMul, expected: []int64{20, 0, 6, 0}, valid: []bool{true, true, true, false}},
+		"div": {op: logicalplan.OpDiv, expected: []int64{5, 0, 1, 0}, valid: []bool{true, false, true, false}},
+		"mod": {op: logicalplan.OpMod, expected: []int64{0, 0, 1, 0}, valid: []bool{true, false, true, false}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := ArithmeticOperation(pool, left, right, test.op)
+			require.NoError(t, err)
+
+			arr := res.(*array.Int64)
+			for i := range test.expected {
+				require.Equal(t, test.valid[i], arr.IsValid(i))
+				if test.valid[i] {
+					require.Equal(t, test.expected[i], arr.Value(i))
+				}
+			}
+		})
+	}
+}
+
+func TestArithmeticOperationWidensToFloat(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	lb := array.NewInt64Builder(pool)
+	lb.AppendValues([]int64{3}, nil)
+
+	rb := array.NewFloat64Builder(pool)
+	rb.AppendValues([]float64{2}, nil)
+
+	res, err := ArithmeticOperation(pool, lb.NewInt64Array(), rb.NewFloat64Array(), logicalplan.OpDiv)
+	require.NoError(t, err)
+	require.Equal(t, []float64{1.5}, res.(*array.Float64).Float64Values())
+}
+
+func TestInt64ArrayTruncate(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewInt64Builder(pool)
+	b.AppendValues([]int64{0, 59_999, 60_000, 61_000, -1}, nil)
+	b.AppendNull()
+
+	res := Int64ArrayTruncate(pool, b.NewInt64Array(), 60_000).(*array.Int64)
+	require.Equal(t, []int64{0, 0, 60_000, 60_000, -60_000}, res.Int64Values()[:5])
+	require.True(t, res.IsNull(5))
+}
+
+func TestBooleanArray(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewInt64Builder(pool)
+	b.AppendValues([]int64{1, 2, 3, 4}, nil)
+	arr := b.NewArray()
+	r := array.NewRecord(
+		arrow.NewSchema([]arrow.Field{{Name: "value", Type: arrow.PrimitiveTypes.Int64}}, nil),
+		[]arrow.Array{arr},
+		4,
+	)
+	defer r.Release()
+
+	tests := map[string]struct {
+		expr     logicalplan.Expr
+		expected []bool
+	}{
+		"comparison": {
+			expr:     logicalplan.Col("value").Gt(logicalplan.Literal(int64(2))),
+			expected: []bool{false, false, true, true},
+		},
+		"not": {
+			expr:     logicalplan.Not(logicalplan.Col("value").Gt(logicalplan.Literal(int64(2)))),
+			expected: []bool{true, true, false, false},
+		},
+		"between": {
+			expr:     logicalplan.Col("value").Between(logicalplan.Literal(int64(2)), logicalplan.Literal(int64(3))),
+			expected: []bool{false, true, true, false},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := arrayExpr(pool, test.expr)
+			require.NoError(t, err)
+
+			res, exists, err := expr.ArrowArray(r)
+			require.NoError(t, err)
+			require.True(t, exists)
+			defer res.Release()
+
+			vals := make([]bool, res.Len())
+			for i := range vals {
+				vals[i] = res.(*array.Boolean).Value(i)
+			}
+			require.Equal(t, test.expected, vals)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package physicalplan
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+)
+
+// BetweenFilter selects the rows whose value lies within the inclusive range
+// [low, high] in a single pass over the array.
+type BetweenFilter struct {
+	left ArrayExpression
+	low  scalar.Scalar
+	high scalar.Scalar
+}
+
+func (f *BetweenFilter) Eval(r arrow.Record) (*Bitmap, error) {
+	leftData, exists, err := f.left.ArrowArray(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		// A column that doesn't exist is treated as the empty string, so it
+		// can only be within a range of strings starting at the empty string.
+		res := NewBitmap()
+		if low, ok := f.low.(*scalar.String); ok && low.Value.Len() == 0 {
+			res.AddRange(0, uint64(r.NumRows()))
+		}
+		return res, nil
+	}
+	defer leftData.Release()
+
+	return BetweenOperation(leftData, f.low, f.high)
+}
+
+func (f *BetweenFilter) String() string {
+	return f.left.String() + " between " + f.low.String() + " and " + f.high.String()
+}
+
+// BetweenOperation returns the indices of the values of the array that are
+// greater than or equal to low and less than or equal to high.
+func BetweenOperation(left arrow.Array, low, high scalar.Scalar) (*Bitmap, error) {
+	unit := timeUnit(left)
+	low, high = timestampAsInt64Scalar(low, unit), timestampAsInt64Scalar(high, unit)
+	if left.DataType().ID() == arrow.TIMESTAMP {
+		left = timestampAsInt64Array(left)
+		defer left.Release()
+	}
+
+	res := newBitset(left.Len())
+	switch arr := left.(type) {
+	case *array.Int64:
+		l, lok := low.(*scalar.Int64)
+		h, hok := high.(*scalar.Int64)
+		if !lok || !hok {
+			break
+		}
+		for i, v := range arr.Int64Values() {
+			if l.Value <= v && v <= h.Value {
+				res.set(i)
+			}
+		}
+		return res.bitmap(arr, false), nil
+	case *array.Float64:
+		l, lok := float64Scalar(low)
+		h, hok := float64Scalar(high)
+		if !lok || !hok {
+			break
+		}
+		for i, v := range arr.Float64Values() {
+			if l.Value <= v && v <= h.Value {
+				res.set(i)
+			}
+		}
+		return res.bitmap(arr, false), nil
+	case *array.Decimal128:
+		return decimal128Between(arr, low, high)
+	case *array.String:
+		l, lok := bytesScalar(low)
+		h, hok := bytesScalar(high)
+		if !lok || !hok {
+			break
+		}
+		ls, hs := string(l), string(h)
+		for i := 0; i < arr.Len(); i++ {
+			if v := arr.Value(i); ls <= v && v <= hs {
+				res.set(i)
+			}
+		}
+		return res.bitmap(arr, false), nil
+	case *array.Binary:
+		l, lok := bytesScalar(low)
+		h, hok := bytesScalar(high)
+		if !lok || !hok {
+			break
+		}
+		for i := 0; i < arr.Len(); i++ {
+			if v := arr.Value(i); bytes.Compare(l, v) <= 0 && bytes.Compare(v, h) <= 0 {
+				res.set(i)
+			}
+		}
+		return res.bitmap(arr, false), nil
+	}
+
+	return nil, fmt.Errorf("between on %s with bounds %s and %s: %w", left.DataType().Name(), low.DataType().Name(), high.DataType().Name(), ErrUnsupportedBinaryOperation)
+}
+
+func bytesScalar(s scalar.Scalar) ([]byte, bool) {
+	switch v := s.(type) {
+	case *scalar.String:
+		return v.Data(), true
+	case *scalar.Binary:
+		return v.Data(), true
+	default:
+		return nil, false
+	}
+}
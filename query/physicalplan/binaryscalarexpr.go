@@ -3,11 +3,13 @@ package physicalplan
 import (
 	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
 	"github.com/apache/arrow/go/v8/arrow/scalar"
 
+	"github.com/polarsignals/frostdb/pqarrow/convert"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 )
 
@@ -21,7 +23,9 @@ func (a *ArrayRef) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
 		return nil, false, nil
 	}
 
-	return r.Column(fields[0]), true, nil
+	arr := r.Column(fields[0])
+	arr.Retain()
+	return arr, true, nil
 }
 
 func (a *ArrayRef) String() string {
@@ -29,7 +33,7 @@ func (a *ArrayRef) String() string {
 }
 
 type BinaryScalarExpr struct {
-	Left  *ArrayRef
+	Left  ArrayExpression
 	Op    logicalplan.Op
 	Right scalar.Scalar
 }
@@ -45,11 +49,10 @@ func (e BinaryScalarExpr) Eval(r arrow.Record) (*Bitmap, error) {
 	// wrong and needs per operation, per type specific behavior.
 	if !exists {
 		res := NewBitmap()
-		for i := uint32(0); i < uint32(r.NumRows()); i++ {
-			res.Add(i)
-		}
+		res.AddRange(0, uint64(r.NumRows()))
 		return res, nil
 	}
+	defer leftData.Release()
 
 	return BinaryScalarOperation(leftData, e.Right, e.Op)
 }
@@ -61,6 +64,18 @@ func (e BinaryScalarExpr) String() string {
 var ErrUnsupportedBinaryOperation = errors.New("unsupported binary operation")
 
 func BinaryScalarOperation(left arrow.Array, right scalar.Scalar, operator logicalplan.Op) (*Bitmap, error) {
+	// Timestamps are compared as int64 epochs in the unit of the column,
+	// int64 columns hold epoch milliseconds.
+	right = timestampAsInt64Scalar(right, timeUnit(left))
+	if left.DataType().ID() == arrow.TIMESTAMP {
+		left = timestampAsInt64Array(left)
+		defer left.Release()
+	}
+
+	if arr, ok := left.(*array.Decimal128); ok {
+		return Decimal128ArrayScalarOperation(arr, right, operator)
+	}
+
 	leftType := left.DataType()
 	switch leftType {
 	case &arrow.FixedSizeBinaryType{ByteWidth: 16}:
@@ -73,6 +88,9 @@ func BinaryScalarOperation(left arrow.Array, right scalar.Scalar, operator logic
 			panic("something terrible has happened, this should have errored previously during validation")
 		}
 	case arrow.BinaryTypes.String:
+		if b, ok := right.(*scalar.Binary); ok {
+			right = scalar.NewStringScalarFromBuffer(b.Value)
+		}
 		switch operator {
 		case logicalplan.OpEq:
 			return StringArrayScalarEqual(left.(*array.String), right.(*scalar.String))
@@ -101,26 +119,84 @@ func BinaryScalarOperation(left arrow.Array, right scalar.Scalar, operator logic
 			default:
 				panic("something terrible has happened, this should have errored previously during validation")
 			}
+		case logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq:
+			var r []byte
+			switch v := right.(type) {
+			case *scalar.Binary:
+				r = v.Data()
+			case *scalar.String:
+				r = v.Data()
+			default:
+				return nil, fmt.Errorf("comparing binary with %s: %w", right.DataType().Name(), ErrUnsupportedBinaryOperation)
+			}
+			switch operator {
+			case logicalplan.OpLt:
+				return BinaryArrayScalarLessThan(left.(*array.Binary), r)
+			case logicalplan.OpLtEq:
+				return BinaryArrayScalarLessThanOrEqual(left.(*array.Binary), r)
+			case logicalplan.OpGt:
+				return BinaryArrayScalarGreaterThan(left.(*array.Binary), r)
+			default:
+				return BinaryArrayScalarGreaterThanOrEqual(left.(*array.Binary), r)
+			}
 		default:
 			panic("something terrible has happened, this should have errored previously during validation")
 		}
 	case arrow.PrimitiveTypes.Int64:
+		r, ok := right.(*scalar.Int64)
+		if !ok {
+			return nil, fmt.Errorf("comparing int64 with %s: %w", right.DataType().Name(), ErrUnsupportedBinaryOperation)
+		}
 		switch operator {
 		case logicalplan.OpEq:
-			return Int64ArrayScalarEqual(left.(*array.Int64), right.(*scalar.Int64))
+			return Int64ArrayScalarEqual(left.(*array.Int64), r)
 		case logicalplan.OpNotEq:
-			return Int64ArrayScalarNotEqual(left.(*array.Int64), right.(*scalar.Int64))
+			return Int64ArrayScalarNotEqual(left.(*array.Int64), r)
 		case logicalplan.OpLt:
-			return Int64ArrayScalarLessThan(left.(*array.Int64), right.(*scalar.Int64))
+			return Int64ArrayScalarLessThan(left.(*array.Int64), r)
 		case logicalplan.OpLtEq:
-			return Int64ArrayScalarLessThanOrEqual(left.(*array.Int64), right.(*scalar.Int64))
+			return Int64ArrayScalarLessThanOrEqual(left.(*array.Int64), r)
 		case logicalplan.OpGt:
-			return Int64ArrayScalarGreaterThan(left.(*array.Int64), right.(*scalar.Int64))
+			return Int64ArrayScalarGreaterThan(left.(*array.Int64), r)
 		case logicalplan.OpGtEq:
-			return Int64ArrayScalarGreaterThanOrEqual(left.(*array.Int64), right.(*scalar.Int64))
+			return Int64ArrayScalarGreaterThanOrEqual(left.(*array.Int64), r)
 		default:
 			panic("something terrible has happened, this should have errored previously during validation")
 		}
+	case arrow.PrimitiveTypes.Float64:
+		r, ok := float64Scalar(right)
+		if !ok {
+			return nil, fmt.Errorf("comparing float64 with %s: %w", right.DataType().Name(), ErrUnsupportedBinaryOperation)
+		}
+		switch operator {
+		case logicalplan.OpEq:
+			return Float64ArrayScalarEqual(left.(*array.Float64), r)
+		case logicalplan.OpNotEq:
+			return Float64ArrayScalarNotEqual(left.(*array.Float64), r)
+		case logicalplan.OpLt:
+			return Float64ArrayScalarLessThan(left.(*array.Float64), r)
+		case logicalplan.OpLtEq:
+			return Float64ArrayScalarLessThanOrEqual(left.(*array.Float64), r)
+		case logicalplan.OpGt:
+			return Float64ArrayScalarGreaterThan(left.(*array.Float64), r)
+		case logicalplan.OpGtEq:
+			return Float64ArrayScalarGreaterThanOrEqual(left.(*array.Float64), r)
+		default:
+			panic("something terrible has happened, this should have errored previously during validation")
+		}
+	case arrow.FixedWidthTypes.Boolean:
+		r, ok := right.(*scalar.Boolean)
+		if !ok {
+			return nil, fmt.Errorf("comparing bool with %s: %w", right.DataType().Name(), ErrUnsupportedBinaryOperation)
+		}
+		switch operator {
+		case logicalplan.OpEq:
+			return BooleanArrayScalarEqual(left.(*array.Boolean), r)
+		case logicalplan.OpNotEq:
+			return BooleanArrayScalarNotEqual(left.(*array.Boolean), r)
+		default:
+			return nil, fmt.Errorf("%s on bool: %w", operator.String(), ErrUnsupportedBinaryOperation)
+		}
 	}
 
 	switch leftType.(type) {
@@ -132,179 +208,335 @@ func BinaryScalarOperation(left arrow.Array, right scalar.Scalar, operator logic
 }
 
 func FixedSizeBinaryArrayScalarEqual(left *array.FixedSizeBinary, right *scalar.FixedSizeBinary) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := right.Data()
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
-		}
-		if bytes.Compare(left.Value(i), right.Data()) == 0 {
-			res.Add(uint32(i))
+		if bytes.Equal(left.Value(i), v) {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
 }
 
 func FixedSizeBinaryArrayScalarNotEqual(left *array.FixedSizeBinary, right *scalar.FixedSizeBinary) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := right.Data()
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			res.Add(uint32(i))
-			continue
-		}
-		if bytes.Compare(left.Value(i), right.Data()) != 0 {
-			res.Add(uint32(i))
+		if !bytes.Equal(left.Value(i), v) {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, true), nil
 }
 
 func StringArrayScalarEqual(left *array.String, right *scalar.String) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := string(right.Data())
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
-		}
-		if left.Value(i) == string(right.Data()) {
-			res.Add(uint32(i))
+		if left.Value(i) == v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
 }
 
 func StringArrayScalarNotEqual(left *array.String, right *scalar.String) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := string(right.Data())
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			res.Add(uint32(i))
-			continue
-		}
-		if left.Value(i) != string(right.Data()) {
-			res.Add(uint32(i))
+		if left.Value(i) != v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, true), nil
 }
 
 func BinaryArrayScalarEqual(left *array.Binary, right *scalar.Binary) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := right.Data()
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
-		}
-		if bytes.Equal(left.Value(i), right.Data()) {
-			res.Add(uint32(i))
+		if bytes.Equal(left.Value(i), v) {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
 }
 
 func BinaryArrayScalarNotEqual(left *array.Binary, right *scalar.Binary) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := right.Data()
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			res.Add(uint32(i))
-			continue
-		}
-		if !bytes.Equal(left.Value(i), right.Data()) {
-			res.Add(uint32(i))
+		if !bytes.Equal(left.Value(i), v) {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, true), nil
 }
 
-func Int64ArrayScalarEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
-	res := NewBitmap()
-
+func BinaryArrayScalarLessThan(left *array.Binary, right []byte) (*Bitmap, error) {
+	res := newBitset(left.Len())
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
+		if bytes.Compare(left.Value(i), right) < 0 {
+			res.set(i)
 		}
-		if left.Value(i) == right.Value {
-			res.Add(uint32(i))
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func BinaryArrayScalarLessThanOrEqual(left *array.Binary, right []byte) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	for i := 0; i < left.Len(); i++ {
+		if bytes.Compare(left.Value(i), right) <= 0 {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
 }
 
-func Int64ArrayScalarNotEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
-	res := NewBitmap()
+func BinaryArrayScalarGreaterThan(left *array.Binary, right []byte) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	for i := 0; i < left.Len(); i++ {
+		if bytes.Compare(left.Value(i), right) > 0 {
+			res.set(i)
+		}
+	}
 
+	return res.bitmap(left, false), nil
+}
+
+func BinaryArrayScalarGreaterThanOrEqual(left *array.Binary, right []byte) (*Bitmap, error) {
+	res := newBitset(left.Len())
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			res.Add(uint32(i))
-			continue
+		if bytes.Compare(left.Value(i), right) >= 0 {
+			res.set(i)
 		}
-		if left.Value(i) != right.Value {
-			res.Add(uint32(i))
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func Int64ArrayScalarEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Int64Values() {
+		if x == v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
+}
+
+func Int64ArrayScalarNotEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Int64Values() {
+		if x != v {
+			res.set(i)
+		}
+	}
+
+	return res.bitmap(left, true), nil
 }
 
 func Int64ArrayScalarLessThan(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
-	res := NewBitmap()
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Int64Values() {
+		if x < v {
+			res.set(i)
+		}
+	}
 
-	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
+	return res.bitmap(left, false), nil
+}
+
+func Int64ArrayScalarLessThanOrEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Int64Values() {
+		if x <= v {
+			res.set(i)
+		}
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func Int64ArrayScalarGreaterThan(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Int64Values() {
+		if x > v {
+			res.set(i)
 		}
-		if left.Value(i) < right.Value {
-			res.Add(uint32(i))
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func Int64ArrayScalarGreaterThanOrEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Int64Values() {
+		if x >= v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
 }
 
-func Int64ArrayScalarLessThanOrEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
-	res := NewBitmap()
+// timestampAsInt64Scalar converts a timestamp scalar to an int64 scalar of
+// the epoch in the unit.
+func timestampAsInt64Scalar(s scalar.Scalar, unit arrow.TimeUnit) scalar.Scalar {
+	if ts, ok := s.(*scalar.Timestamp); ok {
+		from := ts.Type.(*arrow.TimestampType).Unit
+		return scalar.NewInt64Scalar(convert.ConvertTimestamp(int64(ts.Value), from, unit))
+	}
+	return s
+}
 
-	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
+// timeUnit returns the unit of the timestamps of the array, arrays of other
+// types are compared with timestamps as epoch milliseconds.
+func timeUnit(arr arrow.Array) arrow.TimeUnit {
+	if typ, ok := arr.DataType().(*arrow.TimestampType); ok {
+		return typ.Unit
+	}
+	return arrow.Millisecond
+}
+
+// timestampAsInt64Array returns an int64 array sharing the buffers of the
+// given timestamp array. The caller must release the returned array.
+func timestampAsInt64Array(arr arrow.Array) arrow.Array {
+	data := array.NewData(
+		arrow.PrimitiveTypes.Int64,
+		arr.Len(),
+		arr.Data().Buffers(),
+		nil,
+		arr.NullN(),
+		arr.Data().Offset(),
+	)
+	defer data.Release()
+
+	return array.NewInt64Data(data)
+}
+
+// float64Scalar converts numeric scalars to float64 scalars so they can be
+// compared with float64 arrays.
+func float64Scalar(s scalar.Scalar) (*scalar.Float64, bool) {
+	switch v := s.(type) {
+	case *scalar.Float64:
+		return v, true
+	case *scalar.Int64:
+		return scalar.NewFloat64Scalar(float64(v.Value)), true
+	case *scalar.Uint64:
+		return scalar.NewFloat64Scalar(float64(v.Value)), true
+	default:
+		return nil, false
+	}
+}
+
+func Float64ArrayScalarEqual(left *array.Float64, right *scalar.Float64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Float64Values() {
+		if x == v {
+			res.set(i)
 		}
-		if left.Value(i) <= right.Value {
-			res.Add(uint32(i))
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func Float64ArrayScalarNotEqual(left *array.Float64, right *scalar.Float64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Float64Values() {
+		if x != v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, true), nil
 }
 
-func Int64ArrayScalarGreaterThan(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
-	res := NewBitmap()
+func Float64ArrayScalarLessThan(left *array.Float64, right *scalar.Float64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Float64Values() {
+		if x < v {
+			res.set(i)
+		}
+	}
 
-	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
+	return res.bitmap(left, false), nil
+}
+
+func Float64ArrayScalarLessThanOrEqual(left *array.Float64, right *scalar.Float64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Float64Values() {
+		if x <= v {
+			res.set(i)
 		}
-		if left.Value(i) > right.Value {
-			res.Add(uint32(i))
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func Float64ArrayScalarGreaterThan(left *array.Float64, right *scalar.Float64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Float64Values() {
+		if x > v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, false), nil
 }
 
-func Int64ArrayScalarGreaterThanOrEqual(left *array.Int64, right *scalar.Int64) (*Bitmap, error) {
-	res := NewBitmap()
+func Float64ArrayScalarGreaterThanOrEqual(left *array.Float64, right *scalar.Float64) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i, x := range left.Float64Values() {
+		if x >= v {
+			res.set(i)
+		}
+	}
 
+	return res.bitmap(left, false), nil
+}
+
+func BooleanArrayScalarEqual(left *array.Boolean, right *scalar.Boolean) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
 	for i := 0; i < left.Len(); i++ {
-		if left.IsNull(i) {
-			continue
+		if left.Value(i) == v {
+			res.set(i)
 		}
-		if left.Value(i) >= right.Value {
-			res.Add(uint32(i))
+	}
+
+	return res.bitmap(left, false), nil
+}
+
+func BooleanArrayScalarNotEqual(left *array.Boolean, right *scalar.Boolean) (*Bitmap, error) {
+	res := newBitset(left.Len())
+	v := right.Value
+	for i := 0; i < left.Len(); i++ {
+		if left.Value(i) != v {
+			res.set(i)
 		}
 	}
 
-	return res, nil
+	return res.bitmap(left, true), nil
 }
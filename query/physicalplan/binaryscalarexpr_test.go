@@ -0,0 +1,170 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestBinaryScalarOperation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	fb := array.NewFloat64Builder(pool)
+	fb.AppendValues([]float64{0.5, 1.5, 2.5}, []bool{true, true, false})
+	floats := fb.NewArray()
+	defer floats.Release()
+
+	bb := array.NewBooleanBuilder(pool)
+	bb.AppendValues([]bool{true, false, true}, nil)
+	bools := bb.NewArray()
+	defer bools.Release()
+
+	tb := array.NewTimestampBuilder(pool, arrow.FixedWidthTypes.Timestamp_ms.(*arrow.TimestampType))
+	tb.AppendValues([]arrow.Timestamp{1000, 2000, 3000}, nil)
+	timestamps := tb.NewArray()
+	defer timestamps.Release()
+
+	ib := array.NewInt64Builder(pool)
+	ib.AppendValues([]int64{1000, 2000, 3000}, nil)
+	ints := ib.NewArray()
+	defer ints.Release()
+
+	sb := array.NewStringBuilder(pool)
+	sb.AppendValues([]string{"a", "b", "a"}, nil)
+	strs := sb.NewArray()
+	defer strs.Release()
+
+	ts := scalar.NewTimestampScalar(2000, arrow.FixedWidthTypes.Timestamp_ms)
+
+	tests := map[string]struct {
+		left     arrow.Array
+		op       logicalplan.Op
+		right    scalar.Scalar
+		expected []uint32
+	}{
+		"float64 > float64": {
+			left:     floats,
+			op:       logicalplan.OpGt,
+			right:    scalar.NewFloat64Scalar(1),
+			expected: []uint32{1},
+		},
+		"float64 <= int64": {
+			left:     floats,
+			op:       logicalplan.OpLtEq,
+			right:    scalar.NewInt64Scalar(1),
+			expected: []uint32{0},
+		},
+		"float64 != null matches null": {
+			left:     floats,
+			op:       logicalplan.OpNotEq,
+			right:    scalar.NewFloat64Scalar(0.5),
+			expected: []uint32{1, 2},
+		},
+		"bool ==": {
+			left:     bools,
+			op:       logicalplan.OpEq,
+			right:    scalar.NewBooleanScalar(true),
+			expected: []uint32{0, 2},
+		},
+		"timestamp >= timestamp": {
+			left:     timestamps,
+			op:       logicalplan.OpGtEq,
+			right:    ts,
+			expected: []uint32{1, 2},
+		},
+		"int64 < timestamp": {
+			left:     ints,
+			op:       logicalplan.OpLt,
+			right:    ts,
+			expected: []uint32{0},
+		},
+		"string == binary": {
+			left:     strs,
+			op:       logicalplan.OpEq,
+			right:    scalar.MakeScalar([]byte("a")),
+			expected: []uint32{0, 2},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := BinaryScalarOperation(test.left, test.right, test.op)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res.ToArray())
+		})
+	}
+}
+
+func TestBinaryScalarOperationMismatchedTypes(t *testing.T) {
+	b := array.NewInt64Builder(memory.NewGoAllocator())
+	b.AppendValues([]int64{1, 2}, nil)
+	arr := b.NewArray()
+	defer arr.Release()
+
+	_, err := BinaryScalarOperation(arr, scalar.NewBooleanScalar(true), logicalplan.OpEq)
+	require.ErrorIs(t, err, ErrUnsupportedBinaryOperation)
+}
+
+func TestBetweenOperation(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	ib := array.NewInt64Builder(pool)
+	ib.AppendValues([]int64{1, 5, 10, 0}, []bool{true, true, true, false})
+	ints := ib.NewArray()
+	defer ints.Release()
+
+	fb := array.NewFloat64Builder(pool)
+	fb.AppendValues([]float64{0.5, 1.5, 2.5}, nil)
+	floats := fb.NewArray()
+	defer floats.Release()
+
+	sb := array.NewStringBuilder(pool)
+	sb.AppendValues([]string{"a", "b", "c"}, nil)
+	strs := sb.NewArray()
+	defer strs.Release()
+
+	tests := map[string]struct {
+		left      arrow.Array
+		low, high scalar.Scalar
+		expected  []uint32
+	}{
+		"int64 inclusive": {
+			left:     ints,
+			low:      scalar.NewInt64Scalar(1),
+			high:     scalar.NewInt64Scalar(5),
+			expected: []uint32{0, 1},
+		},
+		"float64 with int bounds": {
+			left:     floats,
+			low:      scalar.NewInt64Scalar(1),
+			high:     scalar.NewInt64Scalar(3),
+			expected: []uint32{1, 2},
+		},
+		"string": {
+			left:     strs,
+			low:      scalar.NewStringScalar("b"),
+			high:     scalar.NewStringScalar("z"),
+			expected: []uint32{1, 2},
+		},
+		"empty range": {
+			left:     ints,
+			low:      scalar.NewInt64Scalar(6),
+			high:     scalar.NewInt64Scalar(2),
+			expected: []uint32{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := BetweenOperation(test.left, test.low, test.high)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res.ToArray())
+		})
+	}
+}
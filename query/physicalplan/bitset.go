@@ -0,0 +1,87 @@
+package physicalplan
+
+import (
+	"math/bits"
+
+	"github.com/apache/arrow/go/v8/arrow"
+)
+
+// bitset holds one bit for each of the rows of an array, comparisons set the
+// bits of the rows that match in a tight loop over the array's values and
+// convert them to a Bitmap once all rows are compared.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+// bitmap returns the bitmap of the set bits of the rows of the array. Null
+// values never compare equal, so the rows of the array that are null are
+// only included if nullsMatch is set.
+func (b bitset) bitmap(arr arrow.Array, nullsMatch bool) *Bitmap {
+	if arr.NullN() > 0 {
+		b.applyNulls(arr, nullsMatch)
+	}
+
+	res := NewBitmap()
+	indices := make([]uint32, 0, 64)
+	for w, word := range b {
+		base := uint64(w) * 64
+		switch word {
+		case 0:
+		case ^uint64(0):
+			res.AddRange(base, base+64)
+		default:
+			indices = indices[:0]
+			for word != 0 {
+				indices = append(indices, uint32(base)+uint32(bits.TrailingZeros64(word)))
+				word &= word - 1
+			}
+			res.AddMany(indices)
+		}
+	}
+	return res
+}
+
+// applyNulls sets or clears the bits of the rows of the array that are null.
+// The validity bitmap is applied a word at a time if the array's offset is
+// aligned to a byte.
+func (b bitset) applyNulls(arr arrow.Array, nullsMatch bool) {
+	n := arr.Len()
+	offset := arr.Data().Offset()
+	valid := arr.NullBitmapBytes()
+	if offset%8 != 0 || len(valid) < (offset+n+7)/8 {
+		for i := 0; i < n; i++ {
+			if !arr.IsNull(i) {
+				continue
+			}
+			if nullsMatch {
+				b.set(i)
+			} else {
+				b[i/64] &^= 1 << (uint(i) % 64)
+			}
+		}
+		return
+	}
+
+	valid = valid[offset/8:]
+	for w := range b {
+		var word uint64
+		for j := 0; j < 8 && w*8+j < len(valid); j++ {
+			word |= uint64(valid[w*8+j]) << (8 * j)
+		}
+		if nullsMatch {
+			b[w] |= ^word
+		} else {
+			b[w] &= word
+		}
+	}
+	// The bits past the last row may have been set by the nulls.
+	if rem := n % 64; rem != 0 && len(b) > 0 {
+		b[len(b)-1] &= 1<<uint(rem) - 1
+	}
+}
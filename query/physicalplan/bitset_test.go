@@ -0,0 +1,71 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestBitsetComparisons(t *testing.T) {
+	// The values span multiple words of the bitset, every third value is
+	// null and every fifth value is 1.
+	const n = 150
+	b := array.NewInt64Builder(memory.NewGoAllocator())
+	for i := 0; i < n; i++ {
+		switch {
+		case i%3 == 0:
+			b.AppendNull()
+		case i%5 == 0:
+			b.Append(1)
+		default:
+			b.Append(0)
+		}
+	}
+	arr := b.NewArray()
+	defer arr.Release()
+
+	expected := func(arr arrow.Array, op logicalplan.Op) []uint32 {
+		ints := arr.(*array.Int64)
+		res := []uint32{}
+		for i := 0; i < ints.Len(); i++ {
+			switch {
+			case ints.IsNull(i):
+				if op == logicalplan.OpNotEq {
+					res = append(res, uint32(i))
+				}
+			case op == logicalplan.OpEq && ints.Value(i) == 1,
+				op == logicalplan.OpNotEq && ints.Value(i) != 1,
+				op == logicalplan.OpLt && ints.Value(i) < 1:
+				res = append(res, uint32(i))
+			}
+		}
+		return res
+	}
+
+	// Slices of arrays whose offsets aren't aligned to a byte apply the
+	// nulls row by row.
+	aligned := array.NewSlice(arr, 64, n)
+	defer aligned.Release()
+	unaligned := array.NewSlice(arr, 3, 140)
+	defer unaligned.Release()
+
+	for name, arr := range map[string]arrow.Array{
+		"array":           arr,
+		"aligned slice":   aligned,
+		"unaligned slice": unaligned,
+	} {
+		for _, op := range []logicalplan.Op{logicalplan.OpEq, logicalplan.OpNotEq, logicalplan.OpLt} {
+			t.Run(name+" "+op.String(), func(t *testing.T) {
+				res, err := BinaryScalarOperation(arr, scalar.NewInt64Scalar(1), op)
+				require.NoError(t, err)
+				require.Equal(t, expected(arr, op), res.ToArray())
+			})
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package physicalplan
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+)
+
+const (
+	// scanBufferPoolBytes is the maximum number of bytes of freed buffers
+	// that the buffer pool of a scan holds on to.
+	scanBufferPoolBytes = 32 << 20
+	// minPooledBufferClass and maxPooledBufferClass are the sizes of the
+	// smallest and the largest buffers that are pooled as powers of two.
+	// Allocations of more than the largest size go to the query's allocator
+	// directly.
+	minPooledBufferClass = 6
+	maxPooledBufferClass = 24
+)
+
+// bufferPool is an allocator that holds on to the buffers that are freed and
+// reuses them for the following allocations. Scans convert the row groups
+// they read to records of similar sizes, which are released once they are
+// passed on, so the buffers of the builders of the next records are taken
+// from the buffers of the previous records instead of being allocated again.
+//
+// Buffers are allocated from the query's allocator in sizes of powers of two,
+// which must return buffers of the capacity they were allocated with. They
+// are freed to the query's allocator once the pool holds on to more than its
+// maximum number of bytes, or once the pool is closed.
+type bufferPool struct {
+	memory.Allocator
+	maxBytes int
+
+	mtx    sync.Mutex
+	closed bool
+	bytes  int
+	free   [maxPooledBufferClass + 1][][]byte
+}
+
+func newBufferPool(pool memory.Allocator, maxBytes int) *bufferPool {
+	return &bufferPool{
+		Allocator: pool,
+		maxBytes:  maxBytes,
+	}
+}
+
+// bufferClass returns the power of two of the size of the buffers that hold
+// allocations of the size.
+func bufferClass(size int) int {
+	class := bits.Len(uint(size - 1))
+	if class < minPooledBufferClass {
+		return minPooledBufferClass
+	}
+	return class
+}
+
+func (p *bufferPool) Allocate(size int) []byte {
+	class := bufferClass(size)
+	if class > maxPooledBufferClass {
+		return p.Allocator.Allocate(size)
+	}
+
+	p.mtx.Lock()
+	free := p.free[class]
+	if n := len(free); n > 0 {
+		buf := free[n-1]
+		p.free[class] = free[:n-1]
+		p.bytes -= len(buf)
+		p.mtx.Unlock()
+
+		// Allocations are expected to be zeroed, like the ones of the
+		// query's allocator.
+		buf = buf[:size]
+		for i := range buf {
+			buf[i] = 0
+		}
+		return buf
+	}
+	p.mtx.Unlock()
+
+	return p.Allocator.Allocate(1 << class)[:size]
+}
+
+func (p *bufferPool) Reallocate(size int, b []byte) []byte {
+	if size <= cap(b) {
+		return b[:size]
+	}
+	buf := p.Allocate(size)
+	copy(buf, b)
+	p.Free(b)
+	return buf
+}
+
+func (p *bufferPool) Free(b []byte) {
+	if cap(b) == 0 {
+		return
+	}
+	b = b[:cap(b)]
+	class := bufferClass(len(b))
+	if class > maxPooledBufferClass || len(b) != 1<<class {
+		p.Allocator.Free(b)
+		return
+	}
+
+	p.mtx.Lock()
+	if p.closed || p.bytes+len(b) > p.maxBytes {
+		p.mtx.Unlock()
+		p.Allocator.Free(b)
+		return
+	}
+	p.free[class] = append(p.free[class], b)
+	p.bytes += len(b)
+	p.mtx.Unlock()
+}
+
+// close frees the buffers of the pool. The buffers of records that are
+// released after the pool was closed are freed right away.
+func (p *bufferPool) close() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.closed = true
+	for class, free := range p.free {
+		for _, buf := range free {
+			p.Allocator.Free(buf)
+		}
+		p.free[class] = nil
+	}
+	p.bytes = 0
+}
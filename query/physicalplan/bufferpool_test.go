@@ -0,0 +1,48 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferPool(t *testing.T) {
+	allocator := newCountingAllocator(memory.NewGoAllocator())
+	pool := newBufferPool(allocator, 1<<10)
+
+	// Allocations are rounded up to powers of two.
+	buf := pool.Allocate(100)
+	require.Len(t, buf, 100)
+	require.Equal(t, int64(128), allocator.Allocated())
+	buf[0] = 1
+	pool.Free(buf)
+	require.Equal(t, int64(128), allocator.Allocated())
+
+	// Freed buffers are reused zeroed.
+	reused := pool.Allocate(120)
+	require.Len(t, reused, 120)
+	require.Same(t, &buf[0], &reused[0])
+	require.Equal(t, byte(0), reused[0])
+	require.Equal(t, int64(128), allocator.Allocated())
+
+	// Buffers are freed once the pool holds more than its maximum number of
+	// bytes.
+	large := pool.Allocate(1 << 10)
+	pool.Free(reused)
+	pool.Free(large)
+	require.Equal(t, int64(128), allocator.Allocated())
+
+	// Buffers larger than the largest pooled size aren't rounded up.
+	huge := pool.Allocate(1<<maxPooledBufferClass + 1)
+	require.Equal(t, int64(1<<maxPooledBufferClass+1+128), allocator.Allocated())
+	pool.Free(huge)
+
+	pool.close()
+	require.Equal(t, int64(0), allocator.Allocated())
+
+	// Buffers freed after the pool was closed aren't held on to.
+	buf = pool.Allocate(64)
+	pool.Free(buf)
+	require.Equal(t, int64(0), allocator.Allocated())
+}
@@ -0,0 +1,210 @@
+package physicalplan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+type whenThen struct {
+	when BooleanExpression
+	then ArrayExpression
+}
+
+// CaseExpr is an ArrayExpression that picks the value of each row from the
+// first branch whose condition selects the row.
+type CaseExpr struct {
+	pool  memory.Allocator
+	cases []whenThen
+	els   ArrayExpression
+}
+
+func caseExpr(pool memory.Allocator, expr *logicalplan.CaseExpr) (*CaseExpr, error) {
+	c := &CaseExpr{
+		pool:  pool,
+		cases: make([]whenThen, 0, len(expr.Cases)),
+	}
+
+	for _, wt := range expr.Cases {
+		when, err := booleanExpr(pool, wt.When)
+		if err != nil {
+			return nil, err
+		}
+
+		then, err := arrayExpr(pool, wt.Then)
+		if err != nil {
+			return nil, err
+		}
+
+		c.cases = append(c.cases, whenThen{when: when, then: then})
+	}
+
+	if expr.Else != nil {
+		els, err := arrayExpr(pool, expr.Else)
+		if err != nil {
+			return nil, err
+		}
+		c.els = els
+	}
+
+	return c, nil
+}
+
+func (c *CaseExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	// Branch results are nil if they reference a column that doesn't exist
+	// in the record, in which case they evaluate to null.
+	thens := make([]arrow.Array, len(c.cases)+1)
+	defer func() {
+		for _, arr := range thens {
+			if arr != nil {
+				arr.Release()
+			}
+		}
+	}()
+
+	var dataType arrow.DataType
+	for i, wt := range c.cases {
+		arr, exists, err := wt.then.ArrowArray(r)
+		if err != nil {
+			return nil, false, err
+		}
+		if exists {
+			thens[i] = arr
+			if dataType == nil {
+				dataType = arr.DataType()
+			}
+		}
+	}
+	if c.els != nil {
+		arr, exists, err := c.els.ArrowArray(r)
+		if err != nil {
+			return nil, false, err
+		}
+		if exists {
+			thens[len(c.cases)] = arr
+			if dataType == nil {
+				dataType = arr.DataType()
+			}
+		}
+	}
+
+	if dataType == nil {
+		return nil, false, nil
+	}
+
+	// The index of the branch each row takes its value from, the else branch
+	// being the last one.
+	numRows := int(r.NumRows())
+	branches := make([]int, numRows)
+	for i := range branches {
+		branches[i] = -1
+	}
+	for i, wt := range c.cases {
+		bitmap, err := wt.when.Eval(r)
+		if err != nil {
+			return nil, false, err
+		}
+
+		it := bitmap.Iterator()
+		for it.HasNext() {
+			row := int(it.Next())
+			if branches[row] == -1 {
+				branches[row] = i
+			}
+		}
+	}
+
+	b := array.NewBuilder(c.pool, dataType)
+	defer b.Release()
+
+	b.Reserve(numRows)
+	for row, branch := range branches {
+		if branch == -1 {
+			branch = len(c.cases)
+		}
+
+		arr := thens[branch]
+		if arr == nil || arr.IsNull(row) {
+			b.AppendNull()
+			continue
+		}
+
+		if err := appendArrayValue(b, arr, row); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return b.NewArray(), true, nil
+}
+
+func (c *CaseExpr) String() string {
+	s := make([]string, 0, len(c.cases)*4+4)
+	s = append(s, "case")
+	for _, wt := range c.cases {
+		s = append(s, "when", wt.when.String(), "then", wt.then.String())
+	}
+	if c.els != nil {
+		s = append(s, "else", c.els.String())
+	}
+	s = append(s, "end")
+	return strings.Join(s, " ")
+}
+
+// appendArrayValue appends the value at index i of arr to the builder,
+// converting between compatible types where necessary.
+func appendArrayValue(b array.Builder, arr arrow.Array, i int) error {
+	switch builder := b.(type) {
+	case *array.Int64Builder:
+		switch a := arr.(type) {
+		case *array.Int64:
+			builder.Append(a.Value(i))
+			return nil
+		}
+	case *array.Uint64Builder:
+		switch a := arr.(type) {
+		case *array.Uint64:
+			builder.Append(a.Value(i))
+			return nil
+		}
+	case *array.Float64Builder:
+		switch a := arr.(type) {
+		case *array.Float64:
+			builder.Append(a.Value(i))
+			return nil
+		case *array.Int64:
+			builder.Append(float64(a.Value(i)))
+			return nil
+		}
+	case *array.BooleanBuilder:
+		switch a := arr.(type) {
+		case *array.Boolean:
+			builder.Append(a.Value(i))
+			return nil
+		}
+	case *array.StringBuilder:
+		switch a := arr.(type) {
+		case *array.String:
+			builder.Append(a.Value(i))
+			return nil
+		case *array.Binary:
+			builder.Append(string(a.Value(i)))
+			return nil
+		}
+	case *array.BinaryBuilder:
+		switch a := arr.(type) {
+		case *array.Binary:
+			builder.Append(a.Value(i))
+			return nil
+		case *array.String:
+			builder.AppendString(a.Value(i))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot append %s value to %T", arr.DataType().Name(), b)
+}
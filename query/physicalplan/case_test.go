@@ -0,0 +1,76 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestCaseExpr(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewInt64Builder(pool)
+	b.AppendValues([]int64{200, 404, 500, 302}, nil)
+	status := b.NewInt64Array()
+
+	r := array.NewRecord(
+		arrow.NewSchema([]arrow.Field{{Name: "status", Type: arrow.PrimitiveTypes.Int64}}, nil),
+		[]arrow.Array{status},
+		4,
+	)
+
+	tests := map[string]struct {
+		expr     *logicalplan.CaseExpr
+		expected []string
+		valid    []bool
+	}{
+		"first matching branch wins": {
+			expr: logicalplan.Case(
+				logicalplan.When(logicalplan.Col("status").GtEq(logicalplan.Literal(500)), logicalplan.Literal("5xx")),
+				logicalplan.When(logicalplan.Col("status").GtEq(logicalplan.Literal(400)), logicalplan.Literal("4xx")),
+				logicalplan.When(logicalplan.Col("status").GtEq(logicalplan.Literal(200)), logicalplan.Literal("ok")),
+			),
+			expected: []string{"ok", "4xx", "5xx", "ok"},
+			valid:    []bool{true, true, true, true},
+		},
+		"else": {
+			expr: logicalplan.Case(
+				logicalplan.When(logicalplan.Col("status").Eq(logicalplan.Literal(200)), logicalplan.Literal("ok")),
+			).WithElse(logicalplan.Literal("error")),
+			expected: []string{"ok", "error", "error", "error"},
+			valid:    []bool{true, true, true, true},
+		},
+		"no else is null": {
+			expr: logicalplan.Case(
+				logicalplan.When(logicalplan.Col("status").Lt(logicalplan.Literal(300)), logicalplan.Literal("ok")),
+			),
+			expected: []string{"ok", "", "", ""},
+			valid:    []bool{true, false, false, false},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := caseExpr(pool, test.expr)
+			require.NoError(t, err)
+
+			res, exists, err := expr.ArrowArray(r)
+			require.NoError(t, err)
+			require.True(t, exists)
+			defer res.Release()
+
+			arr := res.(*array.String)
+			for i := range test.expected {
+				require.Equal(t, test.valid[i], arr.IsValid(i))
+				if test.valid[i] {
+					require.Equal(t, test.expected[i], arr.Value(i))
+				}
+			}
+		})
+	}
+}
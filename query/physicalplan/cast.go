@@ -0,0 +1,237 @@
+package physicalplan
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+)
+
+// CastExpr converts the result of another array expression to a different
+// type.
+type CastExpr struct {
+	pool memory.Allocator
+	Expr ArrayExpression
+	Type arrow.DataType
+}
+
+func (c *CastExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	arr, exists, err := c.Expr.ArrowArray(r)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	defer arr.Release()
+
+	res, err := CastArray(c.pool, arr, c.Type)
+	if err != nil {
+		return nil, false, err
+	}
+	return res, true, nil
+}
+
+func (c *CastExpr) String() string {
+	return "cast(" + c.Expr.String() + " as " + c.Type.Name() + ")"
+}
+
+// CastArray converts the array to the given type. Values that can't be
+// represented in the target type, such as strings that don't contain a
+// number, are converted to null.
+func CastArray(pool memory.Allocator, arr arrow.Array, to arrow.DataType) (arrow.Array, error) {
+	if arrow.TypeEqual(arr.DataType(), to) {
+		arr.Retain()
+		return arr, nil
+	}
+
+	switch to.ID() {
+	case arrow.INT64:
+		return castToInt64(pool, arr)
+	case arrow.UINT64:
+		return castToUint64(pool, arr)
+	case arrow.FLOAT64:
+		return castToFloat64(pool, arr)
+	case arrow.STRING:
+		return castToString(pool, arr)
+	case arrow.BINARY:
+		return castToBinary(pool, arr)
+	default:
+		return nil, fmt.Errorf("unsupported cast from %s to %s", arr.DataType().Name(), to.Name())
+	}
+}
+
+// stringValue returns the value at index i of a string or binary array.
+func stringValue(arr arrow.Array, i int) (string, bool) {
+	switch a := arr.(type) {
+	case *array.String:
+		return a.Value(i), true
+	case *array.Binary:
+		return string(a.Value(i)), true
+	default:
+		return "", false
+	}
+}
+
+func castToInt64(pool memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	b := array.NewInt64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		switch a := arr.(type) {
+		case *array.Uint64:
+			b.UnsafeAppend(int64(a.Value(i)))
+		case *array.Float64:
+			b.UnsafeAppend(int64(a.Value(i)))
+		default:
+			s, ok := stringValue(arr, i)
+			if !ok {
+				return nil, fmt.Errorf("unsupported cast from %s to int64", arr.DataType().Name())
+			}
+			v, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(v)
+		}
+	}
+
+	return b.NewArray(), nil
+}
+
+func castToUint64(pool memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	b := array.NewUint64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		switch a := arr.(type) {
+		case *array.Int64:
+			if a.Value(i) < 0 {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(uint64(a.Value(i)))
+		case *array.Float64:
+			if a.Value(i) < 0 {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(uint64(a.Value(i)))
+		default:
+			s, ok := stringValue(arr, i)
+			if !ok {
+				return nil, fmt.Errorf("unsupported cast from %s to uint64", arr.DataType().Name())
+			}
+			v, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(v)
+		}
+	}
+
+	return b.NewArray(), nil
+}
+
+func castToFloat64(pool memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	b := array.NewFloat64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		switch a := arr.(type) {
+		case *array.Int64:
+			b.UnsafeAppend(float64(a.Value(i)))
+		case *array.Uint64:
+			b.UnsafeAppend(float64(a.Value(i)))
+		default:
+			s, ok := stringValue(arr, i)
+			if !ok {
+				return nil, fmt.Errorf("unsupported cast from %s to float64", arr.DataType().Name())
+			}
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				b.UnsafeAppendBoolToBitmap(false)
+				continue
+			}
+			b.UnsafeAppend(v)
+		}
+	}
+
+	return b.NewArray(), nil
+}
+
+// formatValue returns the textual representation of the value at index i of
+// a numeric, string or binary array.
+func formatValue(arr arrow.Array, i int) (string, bool) {
+	switch a := arr.(type) {
+	case *array.Int64:
+		return strconv.FormatInt(a.Value(i), 10), true
+	case *array.Uint64:
+		return strconv.FormatUint(a.Value(i), 10), true
+	case *array.Float64:
+		return strconv.FormatFloat(a.Value(i), 'g', -1, 64), true
+	default:
+		return stringValue(arr, i)
+	}
+}
+
+func castToString(pool memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	b := array.NewStringBuilder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+
+		s, ok := formatValue(arr, i)
+		if !ok {
+			return nil, fmt.Errorf("unsupported cast from %s to string", arr.DataType().Name())
+		}
+		b.Append(s)
+	}
+
+	return b.NewArray(), nil
+}
+
+func castToBinary(pool memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	b := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+
+		s, ok := formatValue(arr, i)
+		if !ok {
+			return nil, fmt.Errorf("unsupported cast from %s to binary", arr.DataType().Name())
+		}
+		b.AppendString(s)
+	}
+
+	return b.NewArray(), nil
+}
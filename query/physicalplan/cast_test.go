@@ -0,0 +1,45 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCastArray(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	sb := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	sb.AppendStringValues([]string{"200", "abc", "-3"}, []bool{true, true, true})
+	sb.AppendNull()
+	strs := sb.NewBinaryArray()
+
+	res, err := CastArray(pool, strs, arrow.PrimitiveTypes.Int64)
+	require.NoError(t, err)
+	ints := res.(*array.Int64)
+	require.Equal(t, 4, ints.Len())
+	require.Equal(t, int64(200), ints.Value(0))
+	require.True(t, ints.IsNull(1))
+	require.Equal(t, int64(-3), ints.Value(2))
+	require.True(t, ints.IsNull(3))
+
+	res, err = CastArray(pool, ints, arrow.PrimitiveTypes.Uint64)
+	require.NoError(t, err)
+	uints := res.(*array.Uint64)
+	require.Equal(t, uint64(200), uints.Value(0))
+	require.True(t, uints.IsNull(2))
+
+	res, err = CastArray(pool, ints, arrow.PrimitiveTypes.Float64)
+	require.NoError(t, err)
+	require.Equal(t, float64(-3), res.(*array.Float64).Value(2))
+
+	res, err = CastArray(pool, res, arrow.BinaryTypes.String)
+	require.NoError(t, err)
+	require.Equal(t, "-3", res.(*array.String).Value(2))
+
+	_, err = CastArray(pool, ints, &arrow.BooleanType{})
+	require.Error(t, err)
+}
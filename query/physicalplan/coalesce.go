@@ -0,0 +1,80 @@
+package physicalplan
+
+import (
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+)
+
+// CoalesceExpr picks the first non-null value of its expressions for each
+// row.
+type CoalesceExpr struct {
+	pool  memory.Allocator
+	Exprs []ArrayExpression
+}
+
+func (c *CoalesceExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	arrs := make([]arrow.Array, 0, len(c.Exprs))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+
+	for _, expr := range c.Exprs {
+		arr, exists, err := expr.ArrowArray(r)
+		if err != nil {
+			return nil, false, err
+		}
+		if !exists {
+			// A column that doesn't exist is all null, so it can never
+			// be picked.
+			continue
+		}
+		arrs = append(arrs, arr)
+	}
+
+	if len(arrs) == 0 {
+		return nil, false, nil
+	}
+	if len(arrs) == 1 || arrs[0].NullN() == 0 {
+		arrs[0].Retain()
+		return arrs[0], true, nil
+	}
+
+	b := array.NewBuilder(c.pool, arrs[0].DataType())
+	defer b.Release()
+
+	numRows := int(r.NumRows())
+	b.Reserve(numRows)
+	for i := 0; i < numRows; i++ {
+		var arr arrow.Array
+		for _, a := range arrs {
+			if a.IsValid(i) {
+				arr = a
+				break
+			}
+		}
+
+		if arr == nil {
+			b.AppendNull()
+			continue
+		}
+
+		if err := appendArrayValue(b, arr, i); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return b.NewArray(), true, nil
+}
+
+func (c *CoalesceExpr) String() string {
+	names := make([]string, 0, len(c.Exprs))
+	for _, expr := range c.Exprs {
+		names = append(names, expr.String())
+	}
+	return "coalesce(" + strings.Join(names, ", ") + ")"
+}
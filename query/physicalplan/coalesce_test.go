@@ -0,0 +1,87 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestCoalesceExpr(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewStringBuilder(pool)
+	b.AppendValues([]string{"a", "", "", "d"}, []bool{true, false, false, true})
+	first := b.NewStringArray()
+	b.AppendValues([]string{"", "b", "", "x"}, []bool{false, true, false, true})
+	second := b.NewStringArray()
+	b.Release()
+
+	r := array.NewRecord(
+		arrow.NewSchema([]arrow.Field{
+			{Name: "first", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "second", Type: arrow.BinaryTypes.String, Nullable: true},
+		}, nil),
+		[]arrow.Array{first, second},
+		4,
+	)
+
+	tests := map[string]struct {
+		expr     *logicalplan.CoalesceExpr
+		expected []string
+		valid    []bool
+	}{
+		"first non-null": {
+			expr:     logicalplan.Coalesce(logicalplan.Col("first"), logicalplan.Col("second")),
+			expected: []string{"a", "b", "", "d"},
+			valid:    []bool{true, true, false, true},
+		},
+		"default": {
+			expr:     logicalplan.Coalesce(logicalplan.Col("first"), logicalplan.Col("second"), logicalplan.Literal("none")),
+			expected: []string{"a", "b", "none", "d"},
+			valid:    []bool{true, true, true, true},
+		},
+		"missing column": {
+			expr:     logicalplan.Coalesce(logicalplan.Col("missing"), logicalplan.Literal("none")),
+			expected: []string{"none", "none", "none", "none"},
+			valid:    []bool{true, true, true, true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := arrayExpr(pool, test.expr)
+			require.NoError(t, err)
+
+			res, exists, err := expr.ArrowArray(r)
+			require.NoError(t, err)
+			require.True(t, exists)
+			defer res.Release()
+
+			arr := res.(*array.String)
+			for i := range test.expected {
+				require.Equal(t, test.valid[i], arr.IsValid(i))
+				if test.valid[i] {
+					require.Equal(t, test.expected[i], arr.Value(i))
+				}
+			}
+		})
+	}
+}
+
+func TestCoalesceExprAllMissing(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	r := array.NewRecord(arrow.NewSchema(nil, nil), nil, 0)
+
+	expr, err := arrayExpr(pool, logicalplan.Coalesce(logicalplan.Col("a"), logicalplan.Col("b")))
+	require.NoError(t, err)
+
+	_, exists, err := expr.ArrowArray(r)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
@@ -0,0 +1,112 @@
+package physicalplan
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/decimal128"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+
+	"github.com/polarsignals/frostdb/pqarrow/convert"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Decimal128ArrayScalarOperation compares the decimals of the array with an
+// integer, float or decimal scalar. The scalar is converted to the scale of
+// the array, scalars with more digits than the scale compare as if they were
+// between two decimals of the scale.
+func Decimal128ArrayScalarOperation(left *array.Decimal128, right scalar.Scalar, operator logicalplan.Op) (*Bitmap, error) {
+	scale := left.DataType().(*arrow.Decimal128Type).Scale
+	v, exact, ok := convert.DecimalValue(right, scale)
+	if !ok {
+		return nil, fmt.Errorf("comparing decimal with %s: %w", right.DataType().Name(), ErrUnsupportedBinaryOperation)
+	}
+
+	// v is the literal rounded down, if it isn't exact the literal is
+	// greater than v and less than the next decimal of the scale.
+	var match func(cmp int) bool
+	switch operator {
+	case logicalplan.OpEq:
+		match = func(cmp int) bool { return exact && cmp == 0 }
+	case logicalplan.OpNotEq:
+		match = func(cmp int) bool { return !exact || cmp != 0 }
+	case logicalplan.OpLt:
+		match = func(cmp int) bool { return cmp < 0 || (!exact && cmp == 0) }
+	case logicalplan.OpLtEq:
+		match = func(cmp int) bool { return cmp <= 0 }
+	case logicalplan.OpGt:
+		match = func(cmp int) bool { return cmp > 0 }
+	case logicalplan.OpGtEq:
+		match = func(cmp int) bool { return cmp > 0 || (exact && cmp == 0) }
+	default:
+		return nil, fmt.Errorf("%s on decimal: %w", operator.String(), ErrUnsupportedBinaryOperation)
+	}
+
+	res := newBitset(left.Len())
+	for i := 0; i < left.Len(); i++ {
+		if match(convert.CompareDecimals(left.Value(i), v)) {
+			res.set(i)
+		}
+	}
+
+	return res.bitmap(left, operator == logicalplan.OpNotEq), nil
+}
+
+// decimal128Between returns the indices of the decimals of the array that
+// are within the bounds.
+func decimal128Between(left *array.Decimal128, low, high scalar.Scalar) (*Bitmap, error) {
+	lowRes, err := Decimal128ArrayScalarOperation(left, low, logicalplan.OpGtEq)
+	if err != nil {
+		return nil, err
+	}
+	highRes, err := Decimal128ArrayScalarOperation(left, high, logicalplan.OpLtEq)
+	if err != nil {
+		return nil, err
+	}
+	lowRes.And(highRes)
+	return lowRes, nil
+}
+
+// Decimal128SumAggregation sums the non-null decimals of each group, the sums
+// have the type of the decimals.
+type Decimal128SumAggregation struct {
+	DataType arrow.DataType
+}
+
+func (a *Decimal128SumAggregation) Aggregate(pool memory.Allocator, arrs []arrow.Array) (arrow.Array, error) {
+	res := array.NewDecimal128Builder(pool, a.DataType.(*arrow.Decimal128Type))
+	defer res.Release()
+
+	for _, arr := range arrs {
+		decimals, ok := arr.(*array.Decimal128)
+		if !ok {
+			return nil, fmt.Errorf("sum array of %s: %w", arr.DataType().Name(), ErrUnsupportedSumType)
+		}
+		res.Append(sumDecimal128Array(decimals))
+	}
+
+	return res.NewArray(), nil
+}
+
+func sumDecimal128Array(arr *array.Decimal128) decimal128.Num {
+	var sum decimal128.Num
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		sum = addDecimals(sum, arr.Value(i))
+	}
+	return sum
+}
+
+// addDecimals adds the two's complement representations of the decimals.
+func addDecimals(a, b decimal128.Num) decimal128.Num {
+	lo := a.LowBits() + b.LowBits()
+	hi := a.HighBits() + b.HighBits()
+	if lo < a.LowBits() {
+		hi++
+	}
+	return decimal128.New(hi, lo)
+}
@@ -56,6 +56,11 @@ func (d *Distinction) Callback(r arrow.Record) error {
 	for _, arr := range distinctArrays {
 		resBuilders = append(resBuilders, array.NewBuilder(d.pool, arr.DataType()))
 	}
+	defer func() {
+		for _, builder := range resBuilders {
+			builder.Release()
+		}
+	}()
 	rows := int64(0)
 
 	numRows := int(r.NumRows())
@@ -120,6 +125,10 @@ func (d *Distinction) Callback(r arrow.Record) error {
 		rows,
 	)
 
+	for _, arr := range resArrays {
+		arr.Release()
+	}
+
 	err := d.next(distinctRecord)
 	distinctRecord.Release()
 	return err
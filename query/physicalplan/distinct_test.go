@@ -0,0 +1,99 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// labelsRecord returns a record with a binary column for each label, values
+// that are empty strings are null.
+func labelsRecord(pool memory.Allocator, names []string, rows ...[]string) arrow.Record {
+	fields := make([]arrow.Field, 0, len(names))
+	cols := make([]arrow.Array, 0, len(names))
+	for i, name := range names {
+		fields = append(fields, arrow.Field{Name: "labels." + name, Type: arrow.BinaryTypes.Binary, Nullable: true})
+		b := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+		for _, row := range rows {
+			if row[i] == "" {
+				b.AppendNull()
+				continue
+			}
+			b.Append([]byte(row[i]))
+		}
+		cols = append(cols, b.NewArray())
+		b.Release()
+	}
+
+	r := array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(rows)))
+	for _, col := range cols {
+		col.Release()
+	}
+	return r
+}
+
+func TestDistinction(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// The records have different dynamic columns, a row of a record without
+	// a column is the same as a row with a null value.
+	records := []arrow.Record{
+		labelsRecord(pool, []string{"job"},
+			[]string{"api"},
+			[]string{"web"},
+			[]string{"api"},
+		),
+		labelsRecord(pool, []string{"job", "region"},
+			[]string{"api", ""},
+			[]string{"api", "eu"},
+			[]string{"web", "eu"},
+			[]string{"api", "eu"},
+		),
+		labelsRecord(pool, []string{"region"},
+			[]string{"eu"},
+			[]string{"us"},
+		),
+	}
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	d := Distinct(pool, []logicalplan.Expr{logicalplan.DynCol("labels")})
+
+	rows := []string{}
+	d.SetNextCallback(func(r arrow.Record) error {
+		for i := 0; i < int(r.NumRows()); i++ {
+			row := ""
+			for j, field := range r.Schema().Fields() {
+				col := r.Column(j).(*array.Binary)
+				if col.IsNull(i) {
+					continue
+				}
+				row += field.Name + "=" + string(col.Value(i)) + " "
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+
+	for _, r := range records {
+		require.NoError(t, d.Callback(r))
+	}
+
+	require.Equal(t, []string{
+		"labels.job=api ",
+		"labels.job=web ",
+		"labels.job=api labels.region=eu ",
+		"labels.job=web labels.region=eu ",
+		"labels.region=eu ",
+		"labels.region=us ",
+	}, rows)
+}
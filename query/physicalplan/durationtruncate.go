@@ -0,0 +1,61 @@
+package physicalplan
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+)
+
+// DurationTruncateExpr truncates int64 millisecond timestamps to a multiple
+// of Duration milliseconds.
+type DurationTruncateExpr struct {
+	pool     memory.Allocator
+	Expr     ArrayExpression
+	Duration int64
+}
+
+func (d *DurationTruncateExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	arr, exists, err := d.Expr.ArrowArray(r)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	defer arr.Release()
+
+	ints, ok := arr.(*array.Int64)
+	if !ok {
+		return nil, false, fmt.Errorf("duration truncate of %s: %w", arr.DataType().Name(), ErrUnsupportedBinaryOperation)
+	}
+
+	return Int64ArrayTruncate(d.pool, ints, d.Duration), true, nil
+}
+
+func (d *DurationTruncateExpr) String() string {
+	return fmt.Sprintf("duration_truncate(%s, %dms)", d.Expr.String(), d.Duration)
+}
+
+// Int64ArrayTruncate rounds every value of the array down to the closest
+// multiple of the given divisor.
+func Int64ArrayTruncate(pool memory.Allocator, arr *array.Int64, divisor int64) arrow.Array {
+	b := array.NewInt64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i, v := range arr.Int64Values() {
+		if arr.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		rem := v % divisor
+		if rem < 0 {
+			// Round negative values towards negative infinity instead
+			// of zero so that all buckets have the same size.
+			rem += divisor
+		}
+		b.UnsafeAppend(v - rem)
+	}
+
+	return b.NewArray()
+}
@@ -0,0 +1,108 @@
+package physicalplan
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// operator describes one of the operators of a plan, so it can be explained
+// which operators were chosen for the logical plan.
+type operator struct {
+	name string
+	// plans are the plans the operator executes itself, such as the plans of
+	// a union or the right plan of a join.
+	plans []*OutputPlan
+	// stats are the metrics of the operator, if the plan is built with
+	// WithStats.
+	stats *operatorStats
+}
+
+// String returns the operators of the plan, starting with the one that
+// passes on the plan's results and ending with the scan. Each operator
+// passes its results on to the operator above it, the plans an operator
+// executes itself are indented below it.
+func (e *OutputPlan) String() string {
+	return e.string(0)
+}
+
+func (e *OutputPlan) string(indent int) string {
+	lines := make([]string, 0, len(e.operators))
+	for _, op := range e.operators {
+		lines = append(lines, strings.Repeat("  ", indent)+op.name)
+		for _, p := range op.plans {
+			lines = append(lines, p.string(indent+1))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *TableScan) String() string {
+	res := "TableScan Table: " + s.options.TableName
+	if s.sorted {
+		res += " Sorted"
+	}
+	if s.concurrency > 1 {
+		res += " Concurrency: " + strconv.Itoa(s.concurrency)
+	}
+	if len(s.options.PhysicalProjection) > 0 {
+		res += " Columns: " + exprNames(s.options.PhysicalProjection)
+	}
+	if s.options.Filter != nil {
+		res += " Filter: " + s.options.Filter.Name()
+	}
+	if len(s.options.Distinct) > 0 {
+		res += " Distinct: " + exprNames(s.options.Distinct)
+	}
+	if s.options.Limit > 0 {
+		res += " Limit: " + strconv.FormatInt(s.options.Limit, 10)
+	}
+	if s.options.Sample != nil {
+		res += " " + s.options.Sample.String()
+	}
+	return res
+}
+
+func (s *ConcurrentTableScan) String() string {
+	res := "ConcurrentTableScan Table: " + s.options.TableName +
+		" Concurrency: " + strconv.Itoa(len(s.callbacks))
+	if len(s.options.PhysicalProjection) > 0 {
+		res += " Columns: " + exprNames(s.options.PhysicalProjection)
+	}
+	if s.options.Filter != nil {
+		res += " Filter: " + s.options.Filter.Name()
+	}
+	return res
+}
+
+func (g *Gather) String() string {
+	scan := fragmentScan(g.fragment)
+	res := "Gather Table: " + scan.TableName + " Nodes: " + strconv.Itoa(len(g.nodes))
+	if g.fragment.Aggregation != nil {
+		res += " Aggregation: " + aggregationString(g.fragment.Aggregation)
+	}
+	if len(scan.PhysicalProjection) > 0 {
+		res += " Columns: " + exprNames(scan.PhysicalProjection)
+	}
+	if scan.Filter != nil {
+		res += " Filter: " + scan.Filter.Name()
+	}
+	return res
+}
+
+func exprNames(exprs []logicalplan.Expr) string {
+	names := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		names = append(names, e.Name())
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func aggregationString(agg *logicalplan.Aggregation) string {
+	return exprNames(agg.AggExprs) + " Group: " + exprNames(agg.GroupExprs)
+}
+
+func joinKeysString(join *logicalplan.Join) string {
+	return exprNames(join.LeftKeys) + " = " + exprNames(join.RightKeys)
+}
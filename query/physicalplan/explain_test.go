@@ -0,0 +1,61 @@
+package physicalplan
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestOutputPlanString(t *testing.T) {
+	provider := &recordsTableProvider{table: &recordsTableReader{}}
+	scan := func() logicalplan.Builder {
+		return (&logicalplan.Builder{}).Scan(provider, "table1")
+	}
+
+	for _, test := range []struct {
+		name     string
+		plan     logicalplan.Builder
+		expected string
+	}{{
+		name: "aggregation",
+		plan: scan().
+			Filter(logicalplan.Col("value").Gt(logicalplan.Literal(int64(1)))).
+			Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.job")).
+			OrderBy(logicalplan.Desc(logicalplan.Col("sum(value)"))).
+			Limit(2),
+		expected: "Limit 2\n" +
+			"OrderBy [sum(value) desc]\n" +
+			"HashAggregate [sum(value)] Group: [labels.job]\n" +
+			"Filter value > 1\n" +
+			"TableScan Table: table1 Columns: [sum(value), labels.job, value, value] Filter: value > 1",
+	}, {
+		name: "union",
+		plan: (&logicalplan.Builder{}).Union(scan(), scan()).Limit(2),
+		expected: "Limit 2\n" +
+			"Union\n" +
+			"  TableScan Table: table1 Limit: 2\n" +
+			"  TableScan Table: table1 Limit: 2",
+	}, {
+		name: "join",
+		plan: scan().Join(scan(), []logicalplan.Expr{logicalplan.Col("a")}, []logicalplan.Expr{logicalplan.Col("b")}),
+		expected: "HashJoin [a] = [b]\n" +
+			"  TableScan Table: table1 Columns: [b]\n" +
+			"TableScan Table: table1 Columns: [a]",
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := test.plan.Build()
+			require.NoError(t, err)
+			for _, optimizer := range logicalplan.DefaultOptimizers {
+				p = optimizer.Optimize(p)
+			}
+
+			plan, err := Build(memory.NewGoAllocator(), dynparquet.NewSampleSchema(), p)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, plan.String())
+		})
+	}
+}
@@ -2,7 +2,9 @@ package physicalplan
 
 import (
 	"errors"
-	"regexp"
+	"fmt"
+	"strconv"
+	"sync"
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/apache/arrow/go/v8/arrow"
@@ -44,9 +46,24 @@ func (f PreExprVisitorFunc) PostVisit(expr logicalplan.Expr) bool {
 	return false
 }
 
-func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (BooleanExpression, error) {
+func binaryBooleanExpr(pool memory.Allocator, expr *logicalplan.BinaryExpr) (BooleanExpression, error) {
+	if expanded, ok := logicalplan.ExpandTupleComparison(expr); ok {
+		return booleanExpr(pool, expanded)
+	}
+
 	switch expr.Op {
-	case logicalplan.OpEq, logicalplan.OpNotEq, logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq, logicalplan.OpRegexMatch, logicalplan.OpRegexNotMatch:
+	case logicalplan.OpEq, logicalplan.OpNotEq, logicalplan.OpLt, logicalplan.OpLtEq, logicalplan.OpGt, logicalplan.OpGtEq, logicalplan.OpRegexMatch, logicalplan.OpRegexNotMatch,
+		logicalplan.OpStartsWith, logicalplan.OpEndsWith, logicalplan.OpContains:
+		if rc, ok := expr.Left.(*logicalplan.RegexColumn); ok {
+			return &RegexColumnExpr{
+				pool:    pool,
+				Column:  rc,
+				Op:      expr.Op,
+				Right:   expr.Right,
+				perName: map[string]BooleanExpression{},
+			}, nil
+		}
+
 		var leftColumnRef *ArrayRef
 		expr.Left.Accept(PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
 			switch e := expr.(type) {
@@ -62,6 +79,17 @@ func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (BooleanExpression, error)
 			return nil, errors.New("left side of binary expression must be a column")
 		}
 
+		var left ArrayExpression = leftColumnRef
+		if expr.Left.Computed() {
+			// The left side is computed so it must be evaluated before it can
+			// be compared.
+			var err error
+			left, err = arrayExpr(pool, expr.Left)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		var rightScalar scalar.Scalar
 		expr.Right.Accept(PreExprVisitorFunc(func(expr logicalplan.Expr) bool {
 			switch e := expr.(type) {
@@ -72,40 +100,58 @@ func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (BooleanExpression, error)
 			return true
 		}))
 
+		if cast, ok := expr.Right.(*logicalplan.CastExpr); ok && rightScalar != nil {
+			var err error
+			rightScalar, err = rightScalar.CastTo(cast.Type)
+			if err != nil {
+				return nil, fmt.Errorf("cast %s: %w", cast.Name(), err)
+			}
+		}
+
 		switch expr.Op {
 		case logicalplan.OpRegexMatch:
-			regexp, err := regexp.Compile(string(rightScalar.(*scalar.String).Data()))
+			regexp, err := logicalplan.CompileRegexp(string(rightScalar.(*scalar.String).Data()))
 			if err != nil {
 				return nil, err
 			}
 			return &RegExpFilter{
-				left:  leftColumnRef,
+				left:  left,
 				right: regexp,
 			}, nil
 		case logicalplan.OpRegexNotMatch:
-			regexp, err := regexp.Compile(string(rightScalar.(*scalar.String).Data()))
+			regexp, err := logicalplan.CompileRegexp(string(rightScalar.(*scalar.String).Data()))
 			if err != nil {
 				return nil, err
 			}
 			return &RegExpFilter{
-				left:     leftColumnRef,
+				left:     left,
 				right:    regexp,
 				notMatch: true,
 			}, nil
+		case logicalplan.OpStartsWith, logicalplan.OpEndsWith, logicalplan.OpContains:
+			str, ok := rightScalar.(*scalar.String)
+			if !ok {
+				return nil, fmt.Errorf("right side of %s must be a string literal", expr.Op.String())
+			}
+			return &StringMatchFilter{
+				left:  left,
+				op:    expr.Op,
+				right: str.Data(),
+			}, nil
 		}
 
 		return &BinaryScalarExpr{
-			Left:  leftColumnRef,
+			Left:  left,
 			Op:    expr.Op,
 			Right: rightScalar,
 		}, nil
 	case logicalplan.OpAnd:
-		left, err := booleanExpr(expr.Left)
+		left, err := booleanExpr(pool, expr.Left)
 		if err != nil {
 			return nil, err
 		}
 
-		right, err := booleanExpr(expr.Right)
+		right, err := booleanExpr(pool, expr.Right)
 		if err != nil {
 			return nil, err
 		}
@@ -114,6 +160,23 @@ func binaryBooleanExpr(expr *logicalplan.BinaryExpr) (BooleanExpression, error)
 			Left:  left,
 			Right: right,
 		}, nil
+	case logicalplan.OpOr:
+		left, err := booleanExpr(pool, expr.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := booleanExpr(pool, expr.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		return &OrExpr{
+			Left:  left,
+			Right: right,
+		}, nil
+	case logicalplan.OpAdd, logicalplan.OpSub, logicalplan.OpMul, logicalplan.OpDiv, logicalplan.OpMod:
+		return nil, fmt.Errorf("arithmetic expression %s is not a boolean expression", expr.Name())
 	default:
 		panic("unsupported binary boolean expression")
 	}
@@ -129,6 +192,11 @@ func (a *AndExpr) Eval(r arrow.Record) (*Bitmap, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The right side doesn't have to be evaluated if no rows are left, so
+	// the most selective predicates of a conjunction should come first.
+	if left.IsEmpty() {
+		return left, nil
+	}
 
 	right, err := a.Right.Eval(r)
 	if err != nil {
@@ -144,17 +212,196 @@ func (a *AndExpr) String() string {
 	return "(" + a.Left.String() + " AND " + a.Right.String() + ")"
 }
 
-func booleanExpr(expr logicalplan.Expr) (BooleanExpression, error) {
+type OrExpr struct {
+	Left  BooleanExpression
+	Right BooleanExpression
+}
+
+func (a *OrExpr) Eval(r arrow.Record) (*Bitmap, error) {
+	left, err := a.Left.Eval(r)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := a.Right.Eval(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// This stores the result in place to avoid allocations.
+	left.Or(right)
+	return left, nil
+}
+
+func (a *OrExpr) String() string {
+	return "(" + a.Left.String() + " OR " + a.Right.String() + ")"
+}
+
+// RegexColumnExpr selects the rows of a record for which the comparison is
+// true for any of the columns matched by the pattern. If no column of the
+// record matches, the comparison is made against a missing column.
+type RegexColumnExpr struct {
+	pool   memory.Allocator
+	Column *logicalplan.RegexColumn
+	Op     logicalplan.Op
+	Right  logicalplan.Expr
+
+	mtx     sync.Mutex
+	perName map[string]BooleanExpression
+}
+
+func (e *RegexColumnExpr) columnExpr(name string) (BooleanExpression, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if expr, ok := e.perName[name]; ok {
+		return expr, nil
+	}
+
+	expr, err := binaryBooleanExpr(e.pool, &logicalplan.BinaryExpr{
+		Left:  logicalplan.Col(name),
+		Op:    e.Op,
+		Right: e.Right,
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.perName[name] = expr
+	return expr, nil
+}
+
+func (e *RegexColumnExpr) Eval(r arrow.Record) (*Bitmap, error) {
+	var res *Bitmap
+	for _, field := range r.Schema().Fields() {
+		if !e.Column.MatchColumn(field.Name) {
+			continue
+		}
+
+		expr, err := e.columnExpr(field.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		bm, err := expr.Eval(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if res == nil {
+			res = bm
+			continue
+		}
+		res.Or(bm)
+	}
+
+	if res != nil {
+		return res, nil
+	}
+
+	// An empty column name is never part of a record.
+	expr, err := e.columnExpr("")
+	if err != nil {
+		return nil, err
+	}
+	return expr.Eval(r)
+}
+
+func (e *RegexColumnExpr) String() string {
+	return e.Column.Name() + " " + e.Op.String() + " " + e.Right.Name()
+}
+
+// NotExpr selects all rows of a record that are not selected by the wrapped
+// expression.
+type NotExpr struct {
+	Expr BooleanExpression
+}
+
+func (n *NotExpr) Eval(r arrow.Record) (*Bitmap, error) {
+	selected, err := n.Expr.Eval(r)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewBitmap()
+	res.AddRange(0, uint64(r.NumRows()))
+	res.AndNot(selected)
+	return res, nil
+}
+
+func (n *NotExpr) String() string {
+	return "NOT " + n.Expr.String()
+}
+
+// LiteralFilter selects either all or none of the rows of a record.
+type LiteralFilter struct {
+	Value bool
+}
+
+func (f *LiteralFilter) Eval(r arrow.Record) (*Bitmap, error) {
+	res := NewBitmap()
+	if f.Value {
+		res.AddRange(0, uint64(r.NumRows()))
+	}
+	return res, nil
+}
+
+func (f *LiteralFilter) String() string {
+	return strconv.FormatBool(f.Value)
+}
+
+func booleanExpr(pool memory.Allocator, expr logicalplan.Expr) (BooleanExpression, error) {
 	switch e := expr.(type) {
+	case *logicalplan.LiteralExpr:
+		b, ok := e.Value.(*scalar.Boolean)
+		if !ok || !b.Valid {
+			return nil, ErrUnsupportedBooleanExpression
+		}
+		return &LiteralFilter{Value: b.Value}, nil
 	case *logicalplan.BinaryExpr:
-		return binaryBooleanExpr(e)
+		return binaryBooleanExpr(pool, e)
+	case *logicalplan.UnaryExpr:
+		if e.Op != logicalplan.OpNot {
+			return nil, ErrUnsupportedBooleanExpression
+		}
+
+		inner, err := booleanExpr(pool, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &NotExpr{Expr: inner}, nil
+	case *logicalplan.BetweenExpr:
+		return betweenFilter(pool, e)
 	default:
 		return nil, ErrUnsupportedBooleanExpression
 	}
 }
 
+func betweenFilter(pool memory.Allocator, expr *logicalplan.BetweenExpr) (*BetweenFilter, error) {
+	left, err := arrayExpr(pool, expr.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	low, ok := expr.Low.(*logicalplan.LiteralExpr)
+	if !ok {
+		return nil, errors.New("lower bound of between expression must be a literal")
+	}
+
+	high, ok := expr.High.(*logicalplan.LiteralExpr)
+	if !ok {
+		return nil, errors.New("upper bound of between expression must be a literal")
+	}
+
+	return &BetweenFilter{
+		left: left,
+		low:  low.Value,
+		high: high.Value,
+	}, nil
+}
+
 func Filter(pool memory.Allocator, filterExpr logicalplan.Expr) (*PredicateFilter, error) {
-	expr, err := booleanExpr(filterExpr)
+	expr, err := booleanExpr(pool, filterExpr)
 	if err != nil {
 		return nil, err
 	}
@@ -173,6 +420,11 @@ func (f *PredicateFilter) SetNextCallback(callback func(r arrow.Record) error) {
 	f.nextCallback = callback
 }
 
+// Eval returns the indexes of the rows of the record that match the filter.
+func (f *PredicateFilter) Eval(r arrow.Record) (*Bitmap, error) {
+	return f.filterExpr.Eval(r)
+}
+
 func (f *PredicateFilter) Callback(r arrow.Record) error {
 	filtered, empty, err := filter(f.pool, f.filterExpr, r)
 	if err != nil {
@@ -0,0 +1,181 @@
+package physicalplan
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// RemoteExecutor executes fragments of plans on a remote node, which reads
+// the rows of its own tables, and passes the records of their results to the
+// callback.
+type RemoteExecutor interface {
+	ExecuteFragment(ctx context.Context, fragment *logicalplan.LogicalPlan, callback func(r arrow.Record) error) error
+}
+
+// WithRemoteExecutors makes the plan execute its leaf fragments on the remote
+// nodes instead of scanning the tables itself. A leaf fragment is a table
+// scan, together with the filters and the aggregation above it if the results
+// of the aggregation can be merged from partial aggregations. The results of
+// the nodes are gathered and, if the fragment aggregates, merged by a final
+// aggregation, before the rest of the plan is executed on them. Without any
+// nodes, the plan scans the tables itself.
+func WithRemoteExecutors(nodes ...RemoteExecutor) Option {
+	return func(o *options) {
+		o.remote = nodes
+	}
+}
+
+// leafFragment returns whether the plan is a leaf fragment, which can be
+// executed by remote nodes.
+func leafFragment(s *dynparquet.Schema, plan *logicalplan.LogicalPlan) bool {
+	if plan.Aggregation != nil {
+		for _, aggExpr := range plan.Aggregation.AggExprs {
+			aggFunc, ok := partialAggregationFunction(s, aggExpr)
+			if !ok || aggFunc.Filter != nil {
+				return false
+			}
+		}
+		plan = plan.Input
+	}
+
+	for ; plan != nil; plan = plan.Input {
+		switch {
+		case plan.Filter != nil:
+		case plan.TableScan != nil:
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// fragmentScan returns the table scan of the leaf fragment.
+func fragmentScan(plan *logicalplan.LogicalPlan) *logicalplan.TableScan {
+	for plan.TableScan == nil {
+		plan = plan.Input
+	}
+	return plan.TableScan
+}
+
+// Gather executes a leaf fragment on remote nodes concurrently and passes on
+// the records of their results as they are received.
+type Gather struct {
+	fragment *logicalplan.LogicalPlan
+	nodes    []RemoteExecutor
+	next     PhysicalPlan
+	finisher func() error
+	yield    func(ctx context.Context) error
+	stats    *operatorStats
+}
+
+// gather returns the scan that gathers the results of the leaf fragment from
+// the nodes. The results of fragments that aggregate are the results of their
+// partial aggregations, see partialAggregation, which are merged by the
+// returned final aggregation and passed on to the returned projection of its
+// averages, if any.
+func gather(
+	pool memory.Allocator,
+	s *dynparquet.Schema,
+	plan *logicalplan.LogicalPlan,
+	nodes []RemoteExecutor,
+) (*Gather, *HashAggregate, *AvgProjection, error) {
+	g := &Gather{
+		fragment: plan,
+		nodes:    nodes,
+	}
+	if plan.Aggregation == nil {
+		return g, nil, nil, nil
+	}
+
+	final, avgs, err := mergeAggregate(pool, s, plan.Aggregation)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	fragment := *plan
+	fragment.Aggregation = partialAggregation(plan.Aggregation)
+	g.fragment = &fragment
+	return g, final, avgs, nil
+}
+
+func (g *Gather) Execute(ctx context.Context, pool memory.Allocator) error {
+	defer g.stats.since(time.Now())
+
+	// The nodes are stopped once any of them failed, or once the records
+	// can't be passed on anymore.
+	nodeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := g.stats.out(g.next.Callback)
+	scan := fragmentScan(g.fragment)
+	if len(scan.Distinct) > 0 && g.fragment.Aggregation == nil {
+		// The rows of different nodes aren't deduplicated yet.
+		distinct := Distinct(pool, scan.Distinct)
+		distinct.SetNextCallback(next)
+		next = distinct.Callback
+	}
+	if scan.Limit > 0 && g.fragment.Aggregation == nil {
+		// Each of the nodes stops after the limit, of which only as many
+		// rows as the limit are passed on.
+		limit := NewLimit(scan.Limit)
+		limit.SetNextCallback(next)
+		next = limit.Callback
+	}
+	callback := contextCallback(nodeCtx, yieldCallback(nodeCtx, g.yield, next))
+
+	var (
+		mtx sync.Mutex
+		// err is the error that stopped the nodes, which is returned
+		// rather than the cancellation it caused.
+		err     error
+		stopped bool
+	)
+	stop := func(stopErr error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if !stopped {
+			stopped = true
+			err = stopErr
+			cancel()
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, node := range g.nodes {
+		wg.Add(1)
+		go func(node RemoteExecutor) {
+			defer wg.Done()
+			nodeErr := node.ExecuteFragment(nodeCtx, g.fragment, func(r arrow.Record) error {
+				mtx.Lock()
+				if stopped {
+					mtx.Unlock()
+					return context.Canceled
+				}
+				callbackErr := callback(r)
+				mtx.Unlock()
+				if callbackErr != nil {
+					stop(callbackErr)
+				}
+				return callbackErr
+			})
+			if nodeErr != nil {
+				stop(nodeErr)
+			}
+		}(node)
+	}
+	wg.Wait()
+
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return err
+	}
+
+	return finish(ctx, g.finisher)
+}
@@ -0,0 +1,413 @@
+package physicalplan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// HashJoin joins the records it receives with the records of the right plan.
+// The right plan is executed first and its rows are put in a hash table by
+// their keys, the rows received are then looked up by their keys and passed
+// on in the order they were received, once for each row they match.
+//
+// If the join builds its hash table from the left rows, the rows received
+// are put in the hash table instead. Once all of them were received, the
+// right plan is executed and the rows matching each of its records are
+// passed on.
+type HashJoin struct {
+	pool         memory.Allocator
+	right        *OutputPlan
+	leftKeys     []logicalplan.Expr
+	rightKeys    []logicalplan.Expr
+	buildLeft    bool
+	nextCallback func(r arrow.Record) error
+
+	// The records the hash table is built from, the arrays of their keys and
+	// the rows with each hash of their keys.
+	records []arrow.Record
+	keys    [][]arrow.Array
+	rows    map[uint64][]joinRow
+
+	// ctx and executePool execute the right plan once all left rows were
+	// received, if the hash table is built from them.
+	ctx         context.Context
+	executePool memory.Allocator
+}
+
+// joinRow references a row of one of the records of the right plan.
+type joinRow struct {
+	record int
+	row    int
+}
+
+func NewHashJoin(pool memory.Allocator, right *OutputPlan, join *logicalplan.Join) *HashJoin {
+	return &HashJoin{
+		pool:      pool,
+		right:     right,
+		leftKeys:  join.LeftKeys,
+		rightKeys: join.RightKeys,
+		buildLeft: join.BuildLeft,
+		rows:      map[uint64][]joinRow{},
+	}
+}
+
+func (j *HashJoin) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	j.nextCallback = nextCallback
+}
+
+// Start executes the right plan and builds the hash table of its rows. It
+// must be called before any records are received.
+func (j *HashJoin) Start(ctx context.Context, pool memory.Allocator) error {
+	if j.buildLeft {
+		j.ctx, j.executePool = ctx, pool
+		return nil
+	}
+	return j.right.Execute(ctx, pool, func(r arrow.Record) error {
+		return j.insert(r, j.rightKeys)
+	})
+}
+
+func (j *HashJoin) insert(r arrow.Record, keyExprs []logicalplan.Expr) error {
+	if r.NumRows() == 0 {
+		return nil
+	}
+
+	keys, ok := joinKeys(r, keyExprs)
+	if !ok {
+		return nil
+	}
+	hashes, err := hashJoinKeys(keys, int(r.NumRows()))
+	if err != nil {
+		return err
+	}
+
+	r.Retain()
+	j.records = append(j.records, r)
+	j.keys = append(j.keys, keys)
+	record := len(j.records) - 1
+	for i, hash := range hashes {
+		if hasNullKey(keys, i) {
+			continue
+		}
+		j.rows[hash] = append(j.rows[hash], joinRow{record: record, row: i})
+	}
+
+	return nil
+}
+
+func (j *HashJoin) Callback(r arrow.Record) error {
+	if j.buildLeft {
+		return j.insert(r, j.leftKeys)
+	}
+
+	rows, matches, err := j.lookup(r, j.leftKeys)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+
+	right := make([]joinedRow, 0, len(matches))
+	for _, m := range matches {
+		right = append(right, joinedRow{record: j.records[m.record], row: m.row})
+	}
+	return j.join(r, rows, right)
+}
+
+// probe passes on the left rows matching the rows of a record of the right
+// plan, if the hash table is built from the left rows. The rows of each left
+// record are joined with the rows of the right record separately.
+func (j *HashJoin) probe(r arrow.Record) error {
+	rows, matches, err := j.lookup(r, j.rightKeys)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+
+	var (
+		records []int
+		left    = map[int][]int{}
+		right   = map[int][]joinedRow{}
+	)
+	for k, m := range matches {
+		if _, ok := left[m.record]; !ok {
+			records = append(records, m.record)
+		}
+		left[m.record] = append(left[m.record], m.row)
+		right[m.record] = append(right[m.record], joinedRow{record: r, row: rows[k]})
+	}
+	sort.Ints(records)
+
+	for _, record := range records {
+		if err := j.join(j.records[record], left[record], right[record]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup returns the rows of the record that match rows of the hash table,
+// and the rows of the hash table each of them matches.
+func (j *HashJoin) lookup(r arrow.Record, keyExprs []logicalplan.Expr) ([]int, []joinRow, error) {
+	if r.NumRows() == 0 || len(j.records) == 0 {
+		return nil, nil, nil
+	}
+
+	keys, ok := joinKeys(r, keyExprs)
+	if !ok {
+		return nil, nil, nil
+	}
+	hashes, err := hashJoinKeys(keys, int(r.NumRows()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		rows    []int
+		matches []joinRow
+	)
+	for i, hash := range hashes {
+		if hasNullKey(keys, i) {
+			continue
+		}
+		for _, row := range j.rows[hash] {
+			// Rows with different keys can have the same hash.
+			equal := true
+			for k := range keys {
+				c, err := compareValues(keys[k], i, j.keys[row.record][k], row.row)
+				if err != nil {
+					return nil, nil, err
+				}
+				if c != 0 {
+					equal = false
+					break
+				}
+			}
+			if equal {
+				rows = append(rows, i)
+				matches = append(matches, row)
+			}
+		}
+	}
+	return rows, matches, nil
+}
+
+// join passes on the given rows of the left record joined with the right
+// rows.
+func (j *HashJoin) join(r arrow.Record, left []int, right []joinedRow) error {
+	res, err := takeJoined(j.pool, r, left, right, j.rightKeys)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+
+	return j.nextCallback(res)
+}
+
+// Finish executes the right plan if the hash table is built from the left
+// rows, and releases the records of the hash table.
+func (j *HashJoin) Finish() error {
+	defer j.Close()
+
+	if !j.buildLeft {
+		return nil
+	}
+	return j.right.Execute(j.ctx, j.executePool, j.probe)
+}
+
+// Close releases the records of the hash table.
+func (j *HashJoin) Close() {
+	for _, r := range j.records {
+		r.Release()
+	}
+	j.records = nil
+	j.keys = nil
+	j.rows = map[uint64][]joinRow{}
+}
+
+// joinKeys returns the arrays of the keys of the record in the order of the
+// keys. If the record doesn't have all keys, all of its rows have a null key
+// and it returns false.
+func joinKeys(r arrow.Record, keys []logicalplan.Expr) ([]arrow.Array, bool) {
+	arrs := make([]arrow.Array, len(keys))
+	for k, key := range keys {
+		for i, field := range r.Schema().Fields() {
+			if key.MatchColumn(field.Name) {
+				arrs[k] = r.Column(i)
+			}
+		}
+		if arrs[k] == nil {
+			return nil, false
+		}
+	}
+	return arrs, true
+}
+
+// hashJoinKeys returns the combined hash of the keys of each row. Unlike the
+// hashes of groups and distinct rows, the hashes don't include the names of
+// the key columns, as the keys of both plans can have different names.
+func hashJoinKeys(keys []arrow.Array, numRows int) ([]uint64, error) {
+	hashes := make([]uint64, numRows)
+	for _, arr := range keys {
+		switch arr.(type) {
+		case *array.String, *array.Binary, *array.Int64, *array.Uint64, *array.Boolean:
+		default:
+			return nil, fmt.Errorf("unsupported type for join key: %s", arr.DataType().Name())
+		}
+		for i, hash := range hashArray(arr) {
+			hashes[i] = hashCombine(hashes[i], hash)
+		}
+	}
+	return hashes, nil
+}
+
+// hasNullKey returns whether any of the keys of the row is null, such rows
+// don't match any rows.
+func hasNullKey(keys []arrow.Array, i int) bool {
+	for _, arr := range keys {
+		if arr.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinedRow references a row of a record of a join's right plan.
+type joinedRow struct {
+	record arrow.Record
+	row    int
+}
+
+// takeJoined returns a record with the given rows of the record and the
+// columns of the right rows they are joined with. The columns added are the
+// union of the columns of the right records, except their keys and columns
+// the record has, rows of right records without a column are null.
+func takeJoined(
+	pool memory.Allocator,
+	r arrow.Record,
+	left []int,
+	right []joinedRow,
+	rightKeys []logicalplan.Expr,
+) (arrow.Record, error) {
+	fields := append([]arrow.Field{}, r.Schema().Fields()...)
+	cols := make([]arrow.Array, 0, len(fields))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for i, field := range r.Schema().Fields() {
+		b := array.NewBuilder(pool, field.Type)
+		for _, row := range left {
+			if err := appendValue(b, r.Column(i), row); err != nil {
+				b.Release()
+				return nil, fmt.Errorf("join column %s: %w", field.Name, err)
+			}
+		}
+		cols = append(cols, b.NewArray())
+		b.Release()
+	}
+
+	var (
+		rightFields []arrow.Field
+		indices     = map[string]int{}
+		// The index of each of the right fields in each right record, or -1.
+		columns = map[arrow.Record][]int{}
+	)
+	for _, row := range right {
+		if _, ok := columns[row.record]; ok {
+			continue
+		}
+		columns[row.record] = nil
+		for _, field := range row.record.Schema().Fields() {
+			if matchesAny(rightKeys, field.Name) || r.Schema().HasField(field.Name) {
+				continue
+			}
+			idx, ok := indices[field.Name]
+			if !ok {
+				indices[field.Name] = len(rightFields)
+				rightFields = append(rightFields, field)
+				continue
+			}
+			if !arrow.TypeEqual(rightFields[idx].Type, field.Type) {
+				return nil, fmt.Errorf("column %s has different types: %s and %s", field.Name, rightFields[idx].Type, field.Type)
+			}
+		}
+	}
+	for record := range columns {
+		idx := make([]int, len(rightFields))
+		for k, field := range rightFields {
+			idx[k] = -1
+			for i, f := range record.Schema().Fields() {
+				if f.Name == field.Name {
+					idx[k] = i
+				}
+			}
+		}
+		columns[record] = idx
+	}
+
+	for k, field := range rightFields {
+		b := array.NewBuilder(pool, field.Type)
+		for _, row := range right {
+			i := columns[row.record][k]
+			if i == -1 {
+				b.AppendNull()
+				continue
+			}
+			if err := appendValue(b, row.record.Column(i), row.row); err != nil {
+				b.Release()
+				return nil, fmt.Errorf("join column %s: %w", field.Name, err)
+			}
+		}
+		arr := b.NewArray()
+		b.Release()
+		// Rows of right records without the column are null.
+		field.Nullable = field.Nullable || arr.NullN() > 0
+		fields = append(fields, field)
+		cols = append(cols, arr)
+	}
+
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(left))), nil
+}
+
+// joinPlan is a join operator whose right plan is executed before or while
+// the scan whose records are joined is executed.
+type joinPlan interface {
+	PhysicalPlan
+	Start(ctx context.Context, pool memory.Allocator) error
+	Finish() error
+	Close()
+}
+
+// joinScan starts the joins before executing the scan whose records are
+// joined.
+type joinScan struct {
+	joins []joinPlan
+	scan  ScanPhysicalPlan
+}
+
+func (s *joinScan) Execute(ctx context.Context, pool memory.Allocator) error {
+	err := s.execute(ctx, pool)
+	if err != nil {
+		// The joins are finished by the scan, unless it failed.
+		for _, j := range s.joins {
+			j.Close()
+		}
+	}
+	return err
+}
+
+func (s *joinScan) execute(ctx context.Context, pool memory.Allocator) error {
+	for _, j := range s.joins {
+		if err := j.Start(ctx, pool); err != nil {
+			return err
+		}
+	}
+	return s.scan.Execute(ctx, pool)
+}
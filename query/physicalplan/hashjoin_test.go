@@ -0,0 +1,165 @@
+package physicalplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// joinRecord returns a record with an int64 column for each of the names,
+// values that are negative are null.
+func joinRecord(pool memory.Allocator, names []string, rows ...[]int64) arrow.Record {
+	fields := make([]arrow.Field, 0, len(names))
+	cols := make([]arrow.Array, 0, len(names))
+	for i, name := range names {
+		fields = append(fields, arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Int64, Nullable: true})
+		b := array.NewInt64Builder(pool)
+		for _, row := range rows {
+			if row[i] < 0 {
+				b.AppendNull()
+				continue
+			}
+			b.Append(row[i])
+		}
+		cols = append(cols, b.NewArray())
+		b.Release()
+	}
+
+	r := array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(rows)))
+	for _, col := range cols {
+		col.Release()
+	}
+	return r
+}
+
+func TestHashJoin(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	left := []arrow.Record{
+		joinRecord(pool, []string{"a", "b", "value"},
+			[]int64{1, 1, 10},
+			[]int64{1, 2, 20},
+			[]int64{2, 1, 30},
+			[]int64{-1, 1, 40},
+		),
+		// Records without a key don't match any rows.
+		joinRecord(pool, []string{"a", "value"},
+			[]int64{1, 50},
+		),
+		joinRecord(pool, []string{"b", "a", "value"},
+			[]int64{2, 1, 60},
+			[]int64{3, 3, 70},
+		),
+	}
+	right := []arrow.Record{
+		joinRecord(pool, []string{"x", "y", "name"},
+			[]int64{1, 1, 100},
+			[]int64{1, 2, 200},
+			[]int64{1, 2, 201},
+			[]int64{-1, 1, 300},
+		),
+		// The right records can have different columns.
+		joinRecord(pool, []string{"x", "y", "value", "other"},
+			[]int64{2, 1, 0, 400},
+		),
+	}
+	defer func() {
+		for _, r := range append(left, right...) {
+			r.Release()
+		}
+	}()
+
+	for _, test := range []struct {
+		name      string
+		buildLeft bool
+		fields    [][]string
+		rows      [][]interface{}
+	}{{
+		name: "build right",
+		// The keys and value column of the right records aren't added,
+		// since the left records have a value column. Only the columns of
+		// the right records that rows are joined with are added.
+		fields: [][]string{
+			{"a", "b", "value", "name", "other"},
+			{"b", "a", "value", "name"},
+		},
+		rows: [][]interface{}{
+			{int64(1), int64(1), int64(10), int64(100), nil},
+			{int64(1), int64(2), int64(20), int64(200), nil},
+			{int64(1), int64(2), int64(20), int64(201), nil},
+			{int64(2), int64(1), int64(30), nil, int64(400)},
+			{int64(2), int64(1), int64(60), int64(200)},
+			{int64(2), int64(1), int64(60), int64(201)},
+		},
+	}, {
+		name:      "build left",
+		buildLeft: true,
+		// The rows of each right record are joined with each left record
+		// separately.
+		fields: [][]string{
+			{"a", "b", "value", "name"},
+			{"b", "a", "value", "name"},
+			{"a", "b", "value", "other"},
+		},
+		rows: [][]interface{}{
+			{int64(1), int64(1), int64(10), int64(100)},
+			{int64(1), int64(2), int64(20), int64(200)},
+			{int64(1), int64(2), int64(20), int64(201)},
+			{int64(2), int64(1), int64(60), int64(200)},
+			{int64(2), int64(1), int64(60), int64(201)},
+			{int64(2), int64(1), int64(30), int64(400)},
+		},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			leftTable := &recordsTableReader{records: left}
+			rightTable := &recordsTableReader{records: right}
+			p, err := (&logicalplan.Builder{}).
+				Scan(&recordsTableProvider{table: leftTable}, "left").
+				Join(
+					(&logicalplan.Builder{}).Scan(&recordsTableProvider{table: rightTable}, "right"),
+					logicalplan.Cols("a", "b"),
+					logicalplan.Cols("x", "y"),
+				).
+				Build()
+			require.NoError(t, err)
+			p.Join.BuildLeft = test.buildLeft
+
+			plan, err := Build(pool, nil, p)
+			require.NoError(t, err)
+
+			fields := [][]string{}
+			rows := [][]interface{}{}
+			err = plan.Execute(context.Background(), pool, func(r arrow.Record) error {
+				names := []string{}
+				for _, field := range r.Schema().Fields() {
+					names = append(names, field.Name)
+				}
+				fields = append(fields, names)
+
+				for i := 0; i < int(r.NumRows()); i++ {
+					row := []interface{}{}
+					for j := 0; j < int(r.NumCols()); j++ {
+						col := r.Column(j).(*array.Int64)
+						if col.IsNull(i) {
+							row = append(row, nil)
+							continue
+						}
+						row = append(row, col.Value(i))
+					}
+					rows = append(rows, row)
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.fields, fields)
+			require.Equal(t, test.rows, rows)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package physicalplan
+
+import (
+	"errors"
+
+	"github.com/apache/arrow/go/v8/arrow"
+)
+
+// errLimitReached is returned by a Limit once it passed on all rows it
+// allows. It stops the scan from reading further records, but isn't returned
+// by the scan, and operators finishing after the limit is reached still pass
+// on their results.
+var errLimitReached = errors.New("limit reached")
+
+// Limit passes on the first rows it receives, up to a number of rows.
+type Limit struct {
+	remaining    int64
+	nextCallback func(r arrow.Record) error
+}
+
+func NewLimit(count int64) *Limit {
+	return &Limit{remaining: count}
+}
+
+func (l *Limit) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	l.nextCallback = nextCallback
+}
+
+func (l *Limit) Callback(r arrow.Record) error {
+	if l.remaining <= 0 {
+		return errLimitReached
+	}
+
+	if r.NumRows() > l.remaining {
+		r = r.NewSlice(0, l.remaining)
+		defer r.Release()
+	}
+	l.remaining -= r.NumRows()
+
+	if err := l.nextCallback(r); err != nil {
+		return err
+	}
+	if l.remaining == 0 {
+		return errLimitReached
+	}
+	return nil
+}
+
+// Offset skips the first rows it receives, up to a number of rows, and
+// passes on all following rows.
+type Offset struct {
+	remaining    int64
+	nextCallback func(r arrow.Record) error
+}
+
+func NewOffset(count int64) *Offset {
+	return &Offset{remaining: count}
+}
+
+func (o *Offset) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	o.nextCallback = nextCallback
+}
+
+func (o *Offset) Callback(r arrow.Record) error {
+	if o.remaining >= r.NumRows() {
+		o.remaining -= r.NumRows()
+		return nil
+	}
+
+	if o.remaining > 0 {
+		r = r.NewSlice(o.remaining, r.NumRows())
+		defer r.Release()
+		o.remaining = 0
+	}
+	return o.nextCallback(r)
+}
@@ -0,0 +1,175 @@
+package physicalplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// recordsTableReader is a table that consists of the given records.
+type recordsTableReader struct {
+	mockTableReader
+	records []arrow.Record
+	// read is the number of records that were read.
+	read int
+}
+
+func (m *recordsTableReader) View(fn func(tx uint64) error) error {
+	return fn(0)
+}
+
+func (m *recordsTableReader) Iterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	schema *arrow.Schema,
+	physicalProjection []logicalplan.Expr,
+	projection []logicalplan.Expr,
+	filter logicalplan.Expr,
+	distinctColumns []logicalplan.Expr,
+	callback func(r arrow.Record) error,
+) error {
+	for _, r := range m.records {
+		m.read++
+		if err := callback(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type recordsTableProvider struct {
+	table *recordsTableReader
+}
+
+func (m *recordsTableProvider) GetTable(name string) logicalplan.TableReader {
+	return m.table
+}
+
+func int64Records(pool memory.Allocator, batches ...[]int64) []arrow.Record {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "value", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	records := make([]arrow.Record, 0, len(batches))
+	for _, values := range batches {
+		b := array.NewInt64Builder(pool)
+		b.AppendValues(values, nil)
+		arr := b.NewArray()
+		records = append(records, array.NewRecord(schema, []arrow.Array{arr}, int64(len(values))))
+		arr.Release()
+		b.Release()
+	}
+	return records
+}
+
+func TestLimitOffset(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	records := int64Records(pool, []int64{1, 2, 3}, []int64{4, 5, 6}, []int64{7, 8, 9})
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	for _, test := range []struct {
+		name     string
+		build    func(b logicalplan.Builder) logicalplan.Builder
+		expected []int64
+		read     int
+	}{{
+		name:     "limit",
+		build:    func(b logicalplan.Builder) logicalplan.Builder { return b.Limit(4) },
+		expected: []int64{1, 2, 3, 4},
+		read:     2,
+	}, {
+		name:     "offset",
+		build:    func(b logicalplan.Builder) logicalplan.Builder { return b.Offset(4) },
+		expected: []int64{5, 6, 7, 8, 9},
+		read:     3,
+	}, {
+		name:     "offset and limit",
+		build:    func(b logicalplan.Builder) logicalplan.Builder { return b.Offset(2).Limit(3) },
+		expected: []int64{3, 4, 5},
+		read:     2,
+	}, {
+		name: "limit of filtered rows",
+		build: func(b logicalplan.Builder) logicalplan.Builder {
+			return b.Filter(logicalplan.Col("value").Gt(logicalplan.Literal(int64(5)))).Limit(2)
+		},
+		expected: []int64{6, 7},
+		read:     3,
+	}, {
+		name:     "zero",
+		build:    func(b logicalplan.Builder) logicalplan.Builder { return b.Limit(0) },
+		expected: []int64{},
+		read:     1,
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			table := &recordsTableReader{records: records}
+			p, err := test.build((&logicalplan.Builder{}).Scan(&recordsTableProvider{table: table}, "table1")).Build()
+			require.NoError(t, err)
+			for _, optimizer := range logicalplan.DefaultOptimizers {
+				p = optimizer.Optimize(p)
+			}
+
+			plan, err := Build(pool, nil, p)
+			require.NoError(t, err)
+
+			values := []int64{}
+			err = plan.Execute(context.Background(), pool, func(r arrow.Record) error {
+				col := r.Column(0).(*array.Int64)
+				for i := 0; i < col.Len(); i++ {
+					values = append(values, col.Value(i))
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, values)
+			// The scan stops once the limit is reached.
+			require.Equal(t, test.read, table.read)
+		})
+	}
+}
+
+func TestLimitAfterFinish(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	records := int64Records(pool, []int64{3, 1}, []int64{2, 5, 4})
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	// The limit is reached while the first sort finishes, the second one
+	// still has to pass on its rows.
+	table := &recordsTableReader{records: records}
+	p, err := (&logicalplan.Builder{}).
+		Scan(&recordsTableProvider{table: table}, "table1").
+		OrderBy(logicalplan.Asc(logicalplan.Col("value"))).
+		Limit(3).
+		OrderBy(logicalplan.Desc(logicalplan.Col("value"))).
+		Build()
+	require.NoError(t, err)
+
+	plan, err := Build(pool, dynparquet.NewSampleSchema(), p)
+	require.NoError(t, err)
+
+	values := []int64{}
+	err = plan.Execute(context.Background(), pool, func(r arrow.Record) error {
+		col := r.Column(0).(*array.Int64)
+		for i := 0; i < col.Len(); i++ {
+			values = append(values, col.Value(i))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 2, 1}, values)
+}
@@ -0,0 +1,307 @@
+package physicalplan
+
+import (
+	"context"
+	"errors"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// MergeJoin joins the records it receives with the records of the right plan
+// like a HashJoin, but requires the rows of both to be sorted by their keys
+// in ascending order. The right plan is executed concurrently and its rows
+// are merged with the rows received, so instead of a hash table of all right
+// rows only the right rows with the keys of the current row are held.
+type MergeJoin struct {
+	pool         memory.Allocator
+	right        *OutputPlan
+	leftKeys     []logicalplan.Expr
+	rightKeys    []logicalplan.Expr
+	nextCallback func(r arrow.Record) error
+
+	cancel  context.CancelFunc
+	records chan arrow.Record
+	errs    chan error
+	// done is set once all records of the right plan were received.
+	done bool
+
+	// cur is the right record being merged, keys are the arrays of its keys
+	// and row is the next of its rows to merge.
+	cur  arrow.Record
+	keys []arrow.Array
+	row  int
+
+	// group are the right rows with the keys of the last row received that
+	// had any matches, groupKeys and groupRow reference their keys.
+	group        []joinedRow
+	groupRecords []arrow.Record
+	groupKeys    []arrow.Array
+	groupRow     int
+}
+
+func NewMergeJoin(pool memory.Allocator, right *OutputPlan, join *logicalplan.Join) *MergeJoin {
+	return &MergeJoin{
+		pool:      pool,
+		right:     right,
+		leftKeys:  join.LeftKeys,
+		rightKeys: join.RightKeys,
+	}
+}
+
+func (j *MergeJoin) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	j.nextCallback = nextCallback
+}
+
+// Start starts executing the right plan. Its records are received as they
+// are needed to merge the records passed to Callback.
+func (j *MergeJoin) Start(ctx context.Context, pool memory.Allocator) error {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.records = make(chan arrow.Record)
+	j.errs = make(chan error, 1)
+
+	go func() {
+		defer close(j.records)
+		j.errs <- j.right.Execute(ctx, pool, func(r arrow.Record) error {
+			r.Retain()
+			select {
+			case j.records <- r:
+				return nil
+			case <-ctx.Done():
+				r.Release()
+				return ctx.Err()
+			}
+		})
+	}()
+	return nil
+}
+
+func (j *MergeJoin) Callback(r arrow.Record) error {
+	keys, ok := joinKeys(r, j.leftKeys)
+	if !ok {
+		return nil
+	}
+
+	// The rows of the record and the rows they match. The right records of
+	// the rows matched are held until they're joined, the group only holds
+	// them until the rows of the next keys are merged.
+	var (
+		left  []int
+		right []joinedRow
+		held  = map[arrow.Record]struct{}{}
+	)
+	defer func() {
+		for r := range held {
+			r.Release()
+		}
+	}()
+	match := func(i int) {
+		for _, r := range j.groupRecords {
+			if _, ok := held[r]; !ok {
+				r.Retain()
+				held[r] = struct{}{}
+			}
+		}
+		for _, row := range j.group {
+			left = append(left, i)
+			right = append(right, row)
+		}
+	}
+	for i := 0; i < int(r.NumRows()); i++ {
+		if hasNullKey(keys, i) {
+			continue
+		}
+
+		if len(j.group) > 0 {
+			c, err := compareKeys(keys, i, j.groupKeys, j.groupRow)
+			if err != nil {
+				return err
+			}
+			if c == 0 {
+				match(i)
+				continue
+			}
+			j.releaseGroup()
+		}
+
+		// Skip the right rows with smaller keys and collect the ones with
+		// the same keys.
+		for {
+			ok, err := j.peek()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			c, err := compareKeys(keys, i, j.keys, j.row)
+			if err != nil {
+				return err
+			}
+			if c < 0 {
+				break
+			}
+			if c == 0 {
+				j.addToGroup()
+			}
+			j.row++
+		}
+
+		match(i)
+	}
+	if len(left) == 0 {
+		return nil
+	}
+
+	res, err := takeJoined(j.pool, r, left, right, j.rightKeys)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+
+	return j.nextCallback(res)
+}
+
+// peek moves to the next right row with non-null keys. It returns false once
+// all right rows were merged.
+func (j *MergeJoin) peek() (bool, error) {
+	for {
+		if j.cur != nil && j.row < int(j.cur.NumRows()) {
+			if !hasNullKey(j.keys, j.row) {
+				return true, nil
+			}
+			j.row++
+			continue
+		}
+
+		if j.cur != nil {
+			j.cur.Release()
+			j.cur, j.keys = nil, nil
+		}
+		if j.done {
+			return false, nil
+		}
+
+		r, ok := <-j.records
+		if !ok {
+			j.done = true
+			return false, <-j.errs
+		}
+		keys, ok := joinKeys(r, j.rightKeys)
+		if !ok {
+			// None of the rows of the record match.
+			r.Release()
+			continue
+		}
+		j.cur, j.keys, j.row = r, keys, 0
+	}
+}
+
+func (j *MergeJoin) addToGroup() {
+	if len(j.group) == 0 {
+		j.groupKeys, j.groupRow = j.keys, j.row
+	}
+	if len(j.groupRecords) == 0 || j.groupRecords[len(j.groupRecords)-1] != j.cur {
+		j.cur.Retain()
+		j.groupRecords = append(j.groupRecords, j.cur)
+	}
+	j.group = append(j.group, joinedRow{record: j.cur, row: j.row})
+}
+
+func (j *MergeJoin) releaseGroup() {
+	for _, r := range j.groupRecords {
+		r.Release()
+	}
+	j.group, j.groupRecords, j.groupKeys = nil, nil, nil
+}
+
+// Finish stops executing the right plan if it still is and releases the
+// right records held.
+func (j *MergeJoin) Finish() error {
+	j.releaseGroup()
+	if j.cur != nil {
+		j.cur.Release()
+		j.cur, j.keys = nil, nil
+	}
+	if j.cancel == nil {
+		return nil
+	}
+
+	j.cancel()
+	j.cancel = nil
+	if j.done {
+		return nil
+	}
+	j.done = true
+	for r := range j.records {
+		r.Release()
+	}
+	if err := <-j.errs; err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Close stops executing the right plan if it still is and releases the right
+// records held, if the join failed before it finished.
+func (j *MergeJoin) Close() {
+	_ = j.Finish()
+}
+
+// compareKeys compares the keys of row i of a with the keys of row j of b.
+func compareKeys(a []arrow.Array, i int, b []arrow.Array, j int) (int, error) {
+	for k := range a {
+		c, err := compareValues(a[k], i, b[k], j)
+		if err != nil || c != 0 {
+			return c, err
+		}
+	}
+	return 0, nil
+}
+
+// mergeJoin returns whether both plans of the join can be read in the order
+// of their keys, so they can be joined by a MergeJoin.
+func mergeJoin(s *dynparquet.Schema, plan *logicalplan.LogicalPlan) bool {
+	rightSchema := plan.Join.Right.InputSchema()
+	if s == nil || rightSchema == nil ||
+		!canReadSorted(plan.Input) || !canReadSorted(plan.Join.Right) ||
+		!sortedByKeys(s, plan.Join.LeftKeys) || !sortedByKeys(rightSchema, plan.Join.RightKeys) {
+		return false
+	}
+
+	for k := range plan.Join.LeftKeys {
+		leftType, err := plan.Join.LeftKeys[k].DataType(s)
+		if err != nil {
+			return false
+		}
+		rightType, err := plan.Join.RightKeys[k].DataType(rightSchema)
+		if err != nil || !arrow.TypeEqual(leftType, rightType) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedByKeys returns whether the keys are a prefix of the sorting columns
+// of the schema, that are sorted in ascending order.
+func sortedByKeys(s *dynparquet.Schema, keys []logicalplan.Expr) bool {
+	sortingColumns := s.SortingColumns()
+	if len(keys) > len(sortingColumns) {
+		return false
+	}
+
+	for k, key := range keys {
+		col, ok := key.(*logicalplan.Column)
+		if !ok || col.ColumnName != sortingColumns[k].ColumnName() || sortingColumns[k].Descending() {
+			return false
+		}
+		def, found := s.ColumnByName(col.ColumnName)
+		if !found || def.Dynamic {
+			return false
+		}
+	}
+	return true
+}
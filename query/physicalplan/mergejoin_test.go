@@ -0,0 +1,206 @@
+package physicalplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestMergeJoin(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// The rows are sorted by their keys, rows with null keys can be anywhere.
+	left := []arrow.Record{
+		joinRecord(pool, []string{"a", "value"},
+			[]int64{1, 10},
+			[]int64{2, 20},
+			[]int64{-1, 30},
+			[]int64{2, 40},
+		),
+		joinRecord(pool, []string{"a", "value"},
+			[]int64{4, 50},
+			[]int64{5, 60},
+			[]int64{7, 70},
+		),
+	}
+	// The rows with the same keys span records.
+	right := []arrow.Record{
+		joinRecord(pool, []string{"x", "name"},
+			[]int64{0, 100},
+			[]int64{2, 200},
+		),
+		joinRecord(pool, []string{"x", "name"},
+			[]int64{-1, 300},
+			[]int64{2, 201},
+			[]int64{3, 400},
+		),
+		joinRecord(pool, []string{"x", "name"},
+			[]int64{5, 500},
+			[]int64{6, 600},
+		),
+	}
+	defer func() {
+		for _, r := range append(left, right...) {
+			r.Release()
+		}
+	}()
+
+	rightPlan, err := (&logicalplan.Builder{}).
+		Scan(&recordsTableProvider{table: &recordsTableReader{records: right}}, "right").
+		Build()
+	require.NoError(t, err)
+
+	newJoin := func() *MergeJoin {
+		plan, err := Build(pool, nil, rightPlan)
+		require.NoError(t, err)
+		return NewMergeJoin(pool, plan, &logicalplan.Join{
+			LeftKeys:  logicalplan.Cols("a"),
+			RightKeys: logicalplan.Cols("x"),
+		})
+	}
+
+	j := newJoin()
+	rows := [][]int64{}
+	j.SetNextCallback(func(r arrow.Record) error {
+		for i := 0; i < int(r.NumRows()); i++ {
+			row := []int64{}
+			for k := 0; k < int(r.NumCols()); k++ {
+				row = append(row, r.Column(k).(*array.Int64).Value(i))
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+
+	require.NoError(t, j.Start(context.Background(), pool))
+	for _, r := range left {
+		require.NoError(t, j.Callback(r))
+	}
+	require.NoError(t, j.Finish())
+
+	require.Equal(t, [][]int64{
+		{2, 20, 200},
+		{2, 20, 201},
+		{2, 40, 200},
+		{2, 40, 201},
+		{5, 60, 500},
+	}, rows)
+
+	// The right plan stops once the join finishes.
+	j = newJoin()
+	j.SetNextCallback(func(r arrow.Record) error { return nil })
+	require.NoError(t, j.Start(context.Background(), pool))
+	r := joinRecord(pool, []string{"a"}, []int64{0})
+	defer r.Release()
+	require.NoError(t, j.Callback(r))
+	require.NoError(t, j.Finish())
+}
+
+// ownedRecordsTableReader is a table that passes on the records it consists
+// of like scans do, releasing them once the callback returned.
+type ownedRecordsTableReader struct {
+	recordsTableReader
+}
+
+func (m *ownedRecordsTableReader) Iterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	schema *arrow.Schema,
+	physicalProjection []logicalplan.Expr,
+	projection []logicalplan.Expr,
+	filter logicalplan.Expr,
+	distinctColumns []logicalplan.Expr,
+	callback func(r arrow.Record) error,
+) error {
+	defer func() {
+		for _, r := range m.records[m.read:] {
+			r.Release()
+		}
+	}()
+	for _, r := range m.records {
+		m.read++
+		err := callback(r)
+		r.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestMergeJoinGroupAcrossRecords(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// The right rows of the first key span records, which are released by
+	// the scan once they are merged, and the group is released once the
+	// second key is merged, before the rows are joined.
+	right := &ownedRecordsTableReader{recordsTableReader{records: []arrow.Record{
+		joinRecord(pool, []string{"x", "name"},
+			[]int64{1, 100},
+			[]int64{1, 101},
+		),
+		joinRecord(pool, []string{"x", "name"},
+			[]int64{1, 102},
+			[]int64{3, 300},
+		),
+		joinRecord(pool, []string{"x", "name"},
+			[]int64{4, 400},
+		),
+	}}}
+	rightPlan, err := (&logicalplan.Builder{}).
+		Scan(&ownedRecordsTableProvider{table: right}, "right").
+		Build()
+	require.NoError(t, err)
+	plan, err := Build(pool, nil, rightPlan)
+	require.NoError(t, err)
+
+	j := NewMergeJoin(pool, plan, &logicalplan.Join{
+		LeftKeys:  logicalplan.Cols("a"),
+		RightKeys: logicalplan.Cols("x"),
+	})
+	rows := [][]int64{}
+	j.SetNextCallback(func(r arrow.Record) error {
+		for i := 0; i < int(r.NumRows()); i++ {
+			row := []int64{}
+			for k := 0; k < int(r.NumCols()); k++ {
+				row = append(row, r.Column(k).(*array.Int64).Value(i))
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+
+	require.NoError(t, j.Start(context.Background(), pool))
+	left := joinRecord(pool, []string{"a", "value"},
+		[]int64{1, 10},
+		[]int64{2, 20},
+		[]int64{4, 40},
+	)
+	defer left.Release()
+	require.NoError(t, j.Callback(left))
+	require.NoError(t, j.Finish())
+
+	require.Equal(t, [][]int64{
+		{1, 10, 100},
+		{1, 10, 101},
+		{1, 10, 102},
+		{4, 40, 400},
+	}, rows)
+}
+
+type ownedRecordsTableProvider struct {
+	table *ownedRecordsTableReader
+}
+
+func (m *ownedRecordsTableProvider) GetTable(name string) logicalplan.TableReader {
+	return m.table
+}
@@ -0,0 +1,284 @@
+package physicalplan
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// OrderBy sorts all rows it receives. Rows of later records can sort before
+// rows of earlier ones, so all records are held until Finish, which passes
+// on a single record with the sorted rows. Its columns are the union of the
+// columns of the records received, rows of records without a column are
+// null in it.
+type OrderBy struct {
+	pool         memory.Allocator
+	exprs        []logicalplan.SortExpr
+	records      []arrow.Record
+	nextCallback func(r arrow.Record) error
+}
+
+func NewOrderBy(pool memory.Allocator, exprs []logicalplan.SortExpr) *OrderBy {
+	return &OrderBy{
+		pool:  pool,
+		exprs: exprs,
+	}
+}
+
+func (o *OrderBy) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	o.nextCallback = nextCallback
+}
+
+func (o *OrderBy) Callback(r arrow.Record) error {
+	if r.NumRows() == 0 {
+		return nil
+	}
+	r.Retain()
+	o.records = append(o.records, r)
+	return nil
+}
+
+// Close releases the records received, without passing on any rows. It's
+// called instead of Finish when the query fails.
+func (o *OrderBy) Close() {
+	for _, r := range o.records {
+		r.Release()
+	}
+	o.records = nil
+}
+
+// sortRow references a row of one of the records being sorted.
+type sortRow struct {
+	record int
+	row    int
+}
+
+func (o *OrderBy) Finish() error {
+	defer func() {
+		for _, r := range o.records {
+			r.Release()
+		}
+		o.records = nil
+	}()
+
+	if len(o.records) == 0 {
+		return nil
+	}
+
+	// The arrays of the sort columns of each record, nil if a record
+	// doesn't have the column.
+	keys := make([][]arrow.Array, len(o.exprs))
+	for k, e := range o.exprs {
+		keys[k] = make([]arrow.Array, len(o.records))
+		for j, r := range o.records {
+			for i, field := range r.Schema().Fields() {
+				if e.Expr.MatchColumn(field.Name) {
+					keys[k][j] = r.Column(i)
+				}
+			}
+		}
+	}
+
+	numRows := 0
+	for _, r := range o.records {
+		numRows += int(r.NumRows())
+	}
+	rows := make([]sortRow, 0, numRows)
+	for j, r := range o.records {
+		for i := 0; i < int(r.NumRows()); i++ {
+			rows = append(rows, sortRow{record: j, row: i})
+		}
+	}
+
+	var err error
+	sort.SliceStable(rows, func(a, b int) bool {
+		for k, e := range o.exprs {
+			c, cerr := compareValues(
+				keys[k][rows[a].record], rows[a].row,
+				keys[k][rows[b].record], rows[b].row,
+			)
+			if cerr != nil {
+				err = cerr
+				return false
+			}
+			if c == 0 {
+				continue
+			}
+			if e.Descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := o.take(rows)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+
+	return o.nextCallback(res)
+}
+
+// take returns a record with the given rows of the records in order.
+func (o *OrderBy) take(rows []sortRow) (arrow.Record, error) {
+	var (
+		fields  []arrow.Field
+		indices = map[string]int{}
+		// The index of each field of the result in each record, or -1.
+		columns [][]int
+	)
+	for j, r := range o.records {
+		for i, field := range r.Schema().Fields() {
+			idx, ok := indices[field.Name]
+			if !ok {
+				idx = len(fields)
+				indices[field.Name] = idx
+				fields = append(fields, field)
+				columns = append(columns, make([]int, len(o.records)))
+				for k := range columns[idx] {
+					columns[idx][k] = -1
+				}
+			}
+			if !arrow.TypeEqual(fields[idx].Type, field.Type) {
+				return nil, fmt.Errorf("column %s has different types: %s and %s", field.Name, fields[idx].Type, field.Type)
+			}
+			columns[idx][j] = i
+		}
+	}
+
+	cols := make([]arrow.Array, 0, len(fields))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	for idx, field := range fields {
+		b := array.NewBuilder(o.pool, field.Type)
+		for _, row := range rows {
+			i := columns[idx][row.record]
+			if i == -1 {
+				b.AppendNull()
+				continue
+			}
+			if err := appendValue(b, o.records[row.record].Column(i), row.row); err != nil {
+				b.Release()
+				return nil, fmt.Errorf("sort column %s: %w", field.Name, err)
+			}
+		}
+		cols = append(cols, b.NewArray())
+		b.Release()
+		// Rows of records without the column are null.
+		fields[idx].Nullable = fields[idx].Nullable || cols[idx].NullN() > 0
+	}
+
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(rows))), nil
+}
+
+// compareValues compares the value at i of a with the value at j of b. Null
+// values, including those of missing arrays, are greater than all others.
+func compareValues(a arrow.Array, i int, b arrow.Array, j int) (int, error) {
+	aNull := a == nil || a.IsNull(i)
+	bNull := b == nil || b.IsNull(j)
+	switch {
+	case aNull && bNull:
+		return 0, nil
+	case aNull:
+		return 1, nil
+	case bNull:
+		return -1, nil
+	}
+
+	switch a := a.(type) {
+	case *array.Int64:
+		if b, ok := b.(*array.Int64); ok {
+			return compareOrdered(a.Value(i) < b.Value(j), a.Value(i) > b.Value(j)), nil
+		}
+	case *array.Uint64:
+		if b, ok := b.(*array.Uint64); ok {
+			return compareOrdered(a.Value(i) < b.Value(j), a.Value(i) > b.Value(j)), nil
+		}
+	case *array.Float64:
+		if b, ok := b.(*array.Float64); ok {
+			return compareOrdered(a.Value(i) < b.Value(j), a.Value(i) > b.Value(j)), nil
+		}
+	case *array.Timestamp:
+		if b, ok := b.(*array.Timestamp); ok {
+			return compareOrdered(a.Value(i) < b.Value(j), a.Value(i) > b.Value(j)), nil
+		}
+	case *array.String:
+		if b, ok := b.(*array.String); ok {
+			return compareOrdered(a.Value(i) < b.Value(j), a.Value(i) > b.Value(j)), nil
+		}
+	case *array.Binary:
+		if b, ok := b.(*array.Binary); ok {
+			return bytes.Compare(a.Value(i), b.Value(j)), nil
+		}
+	case *array.FixedSizeBinary:
+		if b, ok := b.(*array.FixedSizeBinary); ok {
+			return bytes.Compare(a.Value(i), b.Value(j)), nil
+		}
+	case *array.Boolean:
+		if b, ok := b.(*array.Boolean); ok {
+			return compareOrdered(!a.Value(i) && b.Value(j), a.Value(i) && !b.Value(j)), nil
+		}
+	default:
+		return 0, fmt.Errorf("unsupported type for sorting: %s", a.DataType().Name())
+	}
+	return 0, fmt.Errorf("cannot compare %s with %s", a.DataType().Name(), b.DataType().Name())
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortedOrderBy returns whether the rows of the order by's input are in the
+// order of the order by when they're read in the order of the sorting columns
+// of the table, so that the order by doesn't have to sort them. The order by
+// has to sort by a prefix of the sorting columns in their directions, and by
+// nullable columns only if they sort nulls like order bys do.
+func sortedOrderBy(s *dynparquet.Schema, plan *logicalplan.LogicalPlan) bool {
+	exprs := plan.OrderBy.Exprs
+	if s == nil {
+		return false
+	}
+	sortingColumns := s.SortingColumns()
+	if len(exprs) > len(sortingColumns) {
+		return false
+	}
+
+	for i, e := range exprs {
+		col, ok := e.Expr.(*logicalplan.Column)
+		if !ok || col.ColumnName != sortingColumns[i].ColumnName() || e.Descending != sortingColumns[i].Descending() {
+			return false
+		}
+		def, found := s.ColumnByName(col.ColumnName)
+		if !found || def.Dynamic || !def.StorageLayout.Leaf() || def.StorageLayout.Repeated() {
+			return false
+		}
+		// Order bys sort nulls last in ascending order and first in
+		// descending order.
+		if def.StorageLayout.Optional() && sortingColumns[i].NullsFirst() != e.Descending {
+			return false
+		}
+	}
+	return canReadSorted(plan.Input)
+}
@@ -0,0 +1,228 @@
+package physicalplan
+
+import (
+	"bytes"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// OrderedAggregate aggregates rows that are ordered by the columns they are
+// grouped by. A group is complete as soon as a row of another group follows
+// it, so groups are passed on while rows are still being received and only
+// the groups of a single record are held in memory at a time.
+type OrderedAggregate struct {
+	groupByColumns []logicalplan.Expr
+	newAggregate   func() (*HashAggregate, error)
+	// current aggregates the groups that may not be complete yet.
+	current      *HashAggregate
+	nextCallback func(r arrow.Record) error
+}
+
+func NewOrderedAggregate(
+	pool memory.Allocator,
+	s *dynparquet.Schema,
+	agg *logicalplan.Aggregation,
+	opts ...Option,
+) (*OrderedAggregate, error) {
+	a := &OrderedAggregate{
+		groupByColumns: agg.GroupExprs,
+		newAggregate: func() (*HashAggregate, error) {
+			return Aggregate(pool, s, agg, opts...)
+		},
+	}
+
+	var err error
+	a.current, err = a.newAggregate()
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *OrderedAggregate) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	a.nextCallback = nextCallback
+	a.current.SetNextCallback(nextCallback)
+}
+
+func (a *OrderedAggregate) Callback(r arrow.Record) error {
+	numRows := int(r.NumRows())
+	if numRows == 0 {
+		return nil
+	}
+
+	start := a.lastGroupStart(r)
+	if start == 0 {
+		// All rows belong to the same group, which can be the last group of
+		// the previous records.
+		return a.current.Callback(r)
+	}
+
+	head := r.NewSlice(0, int64(start))
+	err := a.current.Callback(head)
+	head.Release()
+	if err != nil {
+		return err
+	}
+
+	// The last group of the record follows the other groups, so they are
+	// complete.
+	if err := a.flush(); err != nil {
+		return err
+	}
+
+	tail := r.NewSlice(int64(start), int64(numRows))
+	defer tail.Release()
+	return a.current.Callback(tail)
+}
+
+// flush passes on the groups aggregated so far and starts aggregating the
+// following groups.
+func (a *OrderedAggregate) flush() error {
+	if err := a.current.Finish(); err != nil {
+		return err
+	}
+
+	next, err := a.newAggregate()
+	if err != nil {
+		return err
+	}
+	next.SetNextCallback(a.nextCallback)
+	a.current = next
+	return nil
+}
+
+func (a *OrderedAggregate) Finish() error {
+	return a.current.Finish()
+}
+
+// Close drops the groups that weren't passed on yet.
+func (a *OrderedAggregate) Close() {
+	a.current.Close()
+}
+
+// lastGroupStart returns the index of the first row of the last group of the
+// record.
+func (a *OrderedAggregate) lastGroupStart(r arrow.Record) int {
+	cols := make([]arrow.Array, 0, len(a.groupByColumns))
+	for i, field := range r.Schema().Fields() {
+		if matchesAny(a.groupByColumns, field.Name) {
+			cols = append(cols, r.Column(i))
+		}
+	}
+
+	last := int(r.NumRows()) - 1
+	start := last
+	for start > 0 && rowsEqual(cols, start-1, last) {
+		start--
+	}
+	return start
+}
+
+func rowsEqual(cols []arrow.Array, i, j int) bool {
+	for _, col := range cols {
+		if !valuesEqual(col, i, j) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesEqual returns whether the values at i and j of the array are equal.
+// The type of the array must be supported by orderedGroupType.
+func valuesEqual(arr arrow.Array, i, j int) bool {
+	if arr.IsNull(i) || arr.IsNull(j) {
+		return arr.IsNull(i) == arr.IsNull(j)
+	}
+
+	switch a := arr.(type) {
+	case *array.Binary:
+		return bytes.Equal(a.Value(i), a.Value(j))
+	case *array.String:
+		return a.Value(i) == a.Value(j)
+	case *array.Int64:
+		return a.Value(i) == a.Value(j)
+	case *array.Uint64:
+		return a.Value(i) == a.Value(j)
+	case *array.Float64:
+		return a.Value(i) == a.Value(j)
+	case *array.Timestamp:
+		return a.Value(i) == a.Value(j)
+	case *array.Boolean:
+		return a.Value(i) == a.Value(j)
+	case *array.FixedSizeBinary:
+		return bytes.Equal(a.Value(i), a.Value(j))
+	default:
+		return false
+	}
+}
+
+func orderedGroupType(dataType arrow.DataType) bool {
+	switch dataType.ID() {
+	case arrow.BINARY, arrow.STRING, arrow.INT64, arrow.UINT64, arrow.FLOAT64,
+		arrow.TIMESTAMP, arrow.BOOL, arrow.FIXED_SIZE_BINARY:
+		return true
+	default:
+		return false
+	}
+}
+
+// sortedAggregation returns whether the aggregation groups by a prefix of
+// the sorting columns of the table it reads from, and the table can be read
+// in that order, so the aggregation can be computed by an OrderedAggregate.
+func sortedAggregation(s *dynparquet.Schema, plan *logicalplan.LogicalPlan) bool {
+	groupExprs := plan.Aggregation.GroupExprs
+	if s == nil || len(groupExprs) == 0 || plan.Aggregation.PreferHash {
+		return false
+	}
+
+	sortingColumns := s.SortingColumns()
+	if len(groupExprs) > len(sortingColumns) {
+		return false
+	}
+
+	prefix := make(map[string]bool, len(groupExprs))
+	for _, col := range sortingColumns[:len(groupExprs)] {
+		def, found := s.ColumnByName(col.ColumnName())
+		if !found || def.Dynamic {
+			return false
+		}
+		prefix[col.ColumnName()] = true
+	}
+
+	for _, e := range groupExprs {
+		col, ok := e.(*logicalplan.Column)
+		if !ok || !prefix[col.ColumnName] {
+			return false
+		}
+		delete(prefix, col.ColumnName)
+
+		dataType, err := col.DataType(s)
+		if err != nil || !orderedGroupType(dataType) {
+			return false
+		}
+	}
+
+	return canReadSorted(plan.Input)
+}
+
+// canReadSorted returns whether the rows of the plan can be read in the order
+// of the sorting columns of the table it reads from. Filters don't change the
+// order of the rows, anything else might.
+func canReadSorted(plan *logicalplan.LogicalPlan) bool {
+	for input := plan; input != nil; input = input.Input {
+		switch {
+		case input.Filter != nil:
+		case input.TableScan != nil:
+			_, ok := input.TableScan.TableProvider.GetTable(input.TableScan.TableName).(logicalplan.SortedTableReader)
+			return ok
+		default:
+			return false
+		}
+	}
+	return false
+}
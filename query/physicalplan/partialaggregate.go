@@ -0,0 +1,406 @@
+package physicalplan
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/pqarrow/convert"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// mergeFuncs are the aggregation functions that can be computed by partial
+// aggregations, together with the function that merges their results.
+var mergeFuncs = map[logicalplan.AggFunc]logicalplan.AggFunc{
+	logicalplan.AggFuncSum:   logicalplan.AggFuncSum,
+	logicalplan.AggFuncCount: logicalplan.AggFuncSum,
+	logicalplan.AggFuncMin:   logicalplan.AggFuncMin,
+	logicalplan.AggFuncMax:   logicalplan.AggFuncMax,
+	// Averages are computed from partial sums and counts, see
+	// partialAggregation.
+	logicalplan.AggFuncAvg: logicalplan.AggFuncSum,
+
+	logicalplan.AggFuncApproxCountDistinct: logicalplan.AggFuncApproxCountDistinct,
+	logicalplan.AggFuncQuantile:            logicalplan.AggFuncQuantile,
+	logicalplan.AggFuncQuantiles:           logicalplan.AggFuncQuantiles,
+	logicalplan.AggFuncVariance:            logicalplan.AggFuncVariance,
+	logicalplan.AggFuncStdDev:              logicalplan.AggFuncStdDev,
+}
+
+// stateFuncs are the aggregation functions whose partial aggregations return
+// their states, see logicalplan.AggregationFunction.State, which are merged by
+// the same function.
+var stateFuncs = map[logicalplan.AggFunc]bool{
+	logicalplan.AggFuncApproxCountDistinct: true,
+	logicalplan.AggFuncQuantile:            true,
+	logicalplan.AggFuncQuantiles:           true,
+	logicalplan.AggFuncVariance:            true,
+	logicalplan.AggFuncStdDev:              true,
+}
+
+// partialAggregationFunction returns the aggregation function of the
+// expression if its results can be merged from partial aggregations.
+func partialAggregationFunction(s *dynparquet.Schema, expr logicalplan.Expr) (*logicalplan.AggregationFunction, bool) {
+	if alias, ok := expr.(*logicalplan.AliasExpr); ok {
+		expr = alias.Expr
+	}
+
+	aggFunc, ok := expr.(*logicalplan.AggregationFunction)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := mergeFuncs[aggFunc.Func]; !ok {
+		return nil, false
+	}
+	if aggFunc.Func == logicalplan.AggFuncAvg {
+		// The partial sums must be supported by sum aggregations.
+		dataType, err := aggFunc.Expr.DataType(s)
+		if err != nil {
+			return nil, false
+		}
+		switch dataType.ID() {
+		case arrow.INT64, arrow.FLOAT64, arrow.DECIMAL128:
+		default:
+			return nil, false
+		}
+	}
+	return aggFunc, true
+}
+
+// partialAggregation returns the aggregation computed by the partial
+// aggregations of the aggregation. Averages are computed from the sums and
+// counts of their values, which replace them in the partial aggregations, and
+// aggregations of stateFuncs return their states.
+func partialAggregation(agg *logicalplan.Aggregation) *logicalplan.Aggregation {
+	aggExprs := make([]logicalplan.Expr, 0, len(agg.AggExprs))
+	for _, aggExpr := range agg.AggExprs {
+		alias, isAlias := aggExpr.(*logicalplan.AliasExpr)
+		aggFunc, ok := aggExpr.(*logicalplan.AggregationFunction)
+		if isAlias {
+			aggFunc, ok = alias.Expr.(*logicalplan.AggregationFunction)
+		}
+		switch {
+		case ok && stateFuncs[aggFunc.Func]:
+			// The states have the names of the results.
+			state := aggFunc.Clone().(*logicalplan.AggregationFunction)
+			state.State = true
+			if isAlias {
+				aggExprs = append(aggExprs, state.Alias(alias.Alias))
+				continue
+			}
+			aggExprs = append(aggExprs, state)
+			continue
+		case !ok || aggFunc.Func != logicalplan.AggFuncAvg:
+			aggExprs = append(aggExprs, aggExpr)
+			continue
+		}
+
+		name := aggExpr.Name()
+		aggExprs = append(aggExprs,
+			(&logicalplan.AggregationFunction{
+				Func:   logicalplan.AggFuncSum,
+				Expr:   aggFunc.Expr,
+				Filter: aggFunc.Filter,
+			}).Alias(avgSumName(name)),
+			(&logicalplan.AggregationFunction{
+				Func:   logicalplan.AggFuncCount,
+				Expr:   aggFunc.Expr,
+				Filter: aggFunc.Filter,
+			}).Alias(avgCountName(name)),
+		)
+	}
+	return &logicalplan.Aggregation{
+		GroupExprs: agg.GroupExprs,
+		AggExprs:   aggExprs,
+		PreferHash: agg.PreferHash,
+	}
+}
+
+// avgSumName and avgCountName are the names of the results of the partial
+// sums and counts of the average of the given name.
+func avgSumName(name string) string   { return name + ".sum" }
+func avgCountName(name string) string { return name + ".count" }
+
+// parallelAggregation returns whether the aggregation can be computed by
+// concurrent partial aggregations whose results are merged. Only filters may
+// be between the aggregation and a table scan of a table that can be read
+// concurrently.
+func parallelAggregation(s *dynparquet.Schema, plan *logicalplan.LogicalPlan, o *options) bool {
+	// The final aggregation only receives records once the partial
+	// aggregations finished, so it couldn't take snapshots of its results.
+	if o.concurrency <= 1 || s == nil || o.partialResults > 0 || o.remote != nil {
+		return false
+	}
+
+	for _, aggExpr := range plan.Aggregation.AggExprs {
+		if _, ok := partialAggregationFunction(s, aggExpr); !ok {
+			return false
+		}
+	}
+
+	for input := plan.Input; input != nil; input = input.Input {
+		switch {
+		case input.Filter != nil:
+		case input.TableScan != nil:
+			// The rows of sampled scans are sampled by a single goroutine.
+			if input.TableScan.Sample != nil {
+				return false
+			}
+			_, ok := input.TableScan.TableProvider.GetTable(input.TableScan.TableName).(logicalplan.ConcurrentTableReader)
+			return ok
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// mergeAggregate returns an aggregation that merges the results of the
+// partial aggregations of the given aggregation, see partialAggregation. The
+// merged results of averages are passed on to the returned projection, which
+// is nil if the aggregation has no averages, to compute the averages. The
+// aggregations of the given aggregation that return their states return
+// their merged states.
+func mergeAggregate(
+	pool memory.Allocator,
+	s *dynparquet.Schema,
+	agg *logicalplan.Aggregation,
+) (*HashAggregate, *AvgProjection, error) {
+	states := map[string]bool{}
+	for _, aggExpr := range agg.AggExprs {
+		if aggFunc, ok := partialAggregationFunction(s, aggExpr); ok && aggFunc.State {
+			states[aggExpr.Name()] = true
+		}
+	}
+
+	partial := partialAggregation(agg)
+	aggregations := make([]*hashAggregation, 0, len(partial.AggExprs))
+	for _, aggExpr := range partial.AggExprs {
+		aggFunc, _ := partialAggregationFunction(s, aggExpr)
+		if aggFunc.State {
+			// The states are merged by the aggregation itself, the rows of
+			// the partial aggregations were filtered already.
+			merge := aggFunc.Clone().(*logicalplan.AggregationFunction)
+			merge.State = false
+			merge.Filter = nil
+			a, err := newHashAggregation(pool, s, merge)
+			if err != nil {
+				return nil, nil, err
+			}
+			a.resultColumnName = aggExpr.Name()
+			a.columnToAggregate = logicalplan.Col(aggExpr.Name())
+			a.merge = true
+			a.state = states[aggExpr.Name()]
+			aggregations = append(aggregations, a)
+			continue
+		}
+
+		dataType, err := aggExpr.DataType(s)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		f, err := chooseAggregationFunction(mergeFuncs[aggFunc.Func], dataType)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		aggregations = append(aggregations, &hashAggregation{
+			resultColumnName:    aggExpr.Name(),
+			columnToAggregate:   logicalplan.Col(aggExpr.Name()),
+			aggregationFunction: f,
+			arraysToAggregate:   make([]array.Builder, 0),
+		})
+	}
+
+	groupByMatchers := make([]logicalplan.Expr, 0, len(agg.GroupExprs))
+	for _, e := range agg.GroupExprs {
+		if e.Computed() {
+			// Computed group by columns are columns of the partial results.
+			groupByMatchers = append(groupByMatchers, logicalplan.Col(e.Name()))
+			continue
+		}
+		groupByMatchers = append(groupByMatchers, e)
+	}
+
+	var avgs *AvgProjection
+	for _, aggExpr := range agg.AggExprs {
+		if aggFunc, ok := partialAggregationFunction(s, aggExpr); ok && aggFunc.Func == logicalplan.AggFuncAvg {
+			if avgs == nil {
+				avgs = &AvgProjection{pool: pool, sums: map[string]string{}, counts: map[string]bool{}}
+			}
+			avgs.sums[avgSumName(aggExpr.Name())] = aggExpr.Name()
+			avgs.counts[avgCountName(aggExpr.Name())] = true
+		}
+	}
+
+	return newHashAggregate(pool, aggregations, groupByMatchers), avgs, nil
+}
+
+// AvgProjection computes the averages of the records of a final aggregation
+// from the merged sums and counts of their partial aggregations, which are
+// replaced by the averages. Averages of no values are null.
+type AvgProjection struct {
+	pool memory.Allocator
+	// sums are the names of the averages by the names of their sums, counts
+	// the names of their counts.
+	sums   map[string]string
+	counts map[string]bool
+	next   func(r arrow.Record) error
+}
+
+func (p *AvgProjection) SetNextCallback(next func(r arrow.Record) error) {
+	p.next = next
+}
+
+func (p *AvgProjection) Callback(r arrow.Record) error {
+	schema := r.Schema()
+	fields := make([]arrow.Field, 0, len(schema.Fields()))
+	cols := make([]arrow.Array, 0, len(schema.Fields()))
+	// The averages are released once the record holds them, or if computing
+	// one of them fails.
+	avgs := make([]arrow.Array, 0, len(p.sums))
+	defer func() {
+		for _, avg := range avgs {
+			avg.Release()
+		}
+	}()
+	for i, field := range schema.Fields() {
+		name, ok := p.sums[field.Name]
+		switch {
+		case p.counts[field.Name]:
+		case !ok:
+			fields = append(fields, field)
+			cols = append(cols, r.Column(i))
+		default:
+			counts := schema.FieldIndices(avgCountName(name))
+			if len(counts) == 0 {
+				return fmt.Errorf("count of average %s not found", name)
+			}
+			avg, err := averages(p.pool, r.Column(i), r.Column(counts[0]))
+			if err != nil {
+				return fmt.Errorf("average %s: %w", name, err)
+			}
+			avgs = append(avgs, avg)
+			fields = append(fields, arrow.Field{Name: name, Type: avg.DataType(), Nullable: true})
+			cols = append(cols, avg)
+		}
+	}
+
+	// The metadata of the record is kept, such as whether it's a partial
+	// result.
+	var metadata *arrow.Metadata
+	if md := schema.Metadata(); md.Len() > 0 {
+		metadata = &md
+	}
+	res := array.NewRecord(arrow.NewSchema(fields, metadata), cols, r.NumRows())
+	defer res.Release()
+	return p.next(res)
+}
+
+// averages returns the averages of the sums and counts, or null where the
+// count is zero.
+func averages(pool memory.Allocator, sums, counts arrow.Array) (arrow.Array, error) {
+	var sum func(i int) float64
+	switch sums := sums.(type) {
+	case *array.Int64:
+		sum = func(i int) float64 { return float64(sums.Value(i)) }
+	case *array.Float64:
+		sum = sums.Value
+	case *array.Decimal128:
+		scale := sums.DataType().(*arrow.Decimal128Type).Scale
+		sum = func(i int) float64 { return convert.DecimalFloat64(sums.Value(i), scale) }
+	default:
+		return nil, fmt.Errorf("unsupported type for avg aggregation: %s", sums.DataType().Name())
+	}
+	ints, ok := counts.(*array.Int64)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type of counts: %s", counts.DataType().Name())
+	}
+
+	res := array.NewFloat64Builder(pool)
+	defer res.Release()
+	for i := 0; i < sums.Len(); i++ {
+		if sums.IsNull(i) || ints.IsNull(i) {
+			res.AppendNull()
+			continue
+		}
+		avg, ok := AvgState{Sum: sum(i), Count: ints.Value(i)}.Avg()
+		if !ok {
+			res.AppendNull()
+			continue
+		}
+		res.Append(avg)
+	}
+	return res.NewArray(), nil
+}
+
+// partialAggregates returns a scan that reads the input of the aggregation
+// with the given number of goroutines. Each of them filters and aggregates
+// the records it reads with its own partial aggregation, whose results are
+// merged by the final aggregation. The stats are the stats of the final
+// aggregation, the partial aggregations and the filters before them.
+func partialAggregates(
+	pool memory.Allocator,
+	s *dynparquet.Schema,
+	plan *logicalplan.LogicalPlan,
+	final *HashAggregate,
+	concurrency int,
+	stats []*operatorStats,
+	opts []Option,
+) (*ConcurrentTableScan, error) {
+	// The final aggregation is called from all goroutines.
+	var mtx sync.Mutex
+	finalCallback := stats[0].in(final.Callback)
+	merge := func(r arrow.Record) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return finalCallback(r)
+	}
+
+	scan := &ConcurrentTableScan{}
+	partials := make([]*HashAggregate, 0, concurrency)
+	for i := 0; i < concurrency; i++ {
+		partial, err := Aggregate(pool, s, partialAggregation(plan.Aggregation), opts...)
+		if err != nil {
+			return nil, err
+		}
+		partials = append(partials, partial)
+
+		prev := stats[1].plan(partial)
+		prev.SetNextCallback(merge)
+		input := plan.Input
+		for i := 2; input.Filter != nil; i, input = i+1, input.Input {
+			filter, err := Filter(pool, input.Filter.Expr)
+			if err != nil {
+				return nil, err
+			}
+			filterPlan := stats[i].plan(filter)
+			filterPlan.SetNextCallback(prev.Callback)
+			prev = filterPlan
+		}
+
+		scan.options = input.TableScan
+		scan.callbacks = append(scan.callbacks, prev.Callback)
+	}
+
+	scan.finisher = func() error {
+		for _, partial := range partials {
+			if err := stats[1].finish(partial.Finish)(); err != nil {
+				return err
+			}
+		}
+		return stats[0].finish(final.Finish)()
+	}
+	scan.closer = func() {
+		for _, partial := range partials {
+			partial.Close()
+		}
+		final.Close()
+	}
+	return scan, nil
+}
@@ -3,6 +3,8 @@ package physicalplan
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/memory"
@@ -43,6 +45,12 @@ func (f PostPlanVisitorFunc) PostVisit(plan *logicalplan.LogicalPlan) bool {
 type OutputPlan struct {
 	callback func(r arrow.Record) error
 	scan     ScanPhysicalPlan
+	// closer releases the state of the operators that hold state until they
+	// finish, if the plan fails before they finished.
+	closer func()
+	// operators describe the operators of the plan, from the output to the
+	// scan.
+	operators []operator
 }
 
 func (e *OutputPlan) Callback(r arrow.Record) error {
@@ -55,20 +63,266 @@ func (e *OutputPlan) SetNextCallback(next func(r arrow.Record) error) {
 
 func (e *OutputPlan) Execute(ctx context.Context, pool memory.Allocator, callback func(r arrow.Record) error) error {
 	e.callback = callback
-	return e.scan.Execute(ctx, pool)
+	err := e.scan.Execute(ctx, pool)
+	if err != nil && e.closer != nil {
+		e.closer()
+	}
+	return err
 }
 
 type TableScan struct {
 	options  *logicalplan.TableScan
 	next     PhysicalPlan
 	finisher func() error
+	// sorted reads the rows in the order of the table's sorting columns.
+	sorted bool
+	// concurrency is the number of goroutines that read the table, if it's
+	// greater than one the records they read are passed on in no particular
+	// order.
+	concurrency int
+	// yield is called before each record is passed on, if set.
+	yield func(ctx context.Context) error
+	// schema is the schema of the records read, if not set it's the schema
+	// of the rows of the table the scan reads.
+	schema *arrow.Schema
+	stats  *operatorStats
 }
 
 func (s *TableScan) Execute(ctx context.Context, pool memory.Allocator) error {
+	defer s.stats.since(time.Now())
+
+	table := s.options.TableProvider.GetTable(s.options.TableName)
+	if table == nil {
+		return errors.New("table not found")
+	}
+
+	buffers := newBufferPool(pool, scanBufferPoolBytes)
+	defer buffers.close()
+	pool = buffers
+
+	if s.sorted {
+		return s.executeSorted(ctx, pool, table)
+	}
+	if s.concurrency > 1 {
+		return s.executeConcurrently(ctx, pool, table)
+	}
+
+	err := table.View(func(tx uint64) error {
+		schema := s.schema
+		if schema == nil {
+			var err error
+			schema, err = s.tableSchema(ctx, tx, pool, table)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Tables that can sample their rows don't read the parts of the
+		// table none of whose rows are sampled.
+		sampledTable, ok := table.(logicalplan.SampledTableReader)
+		if ok && s.options.Sample != nil && len(s.options.Distinct) == 0 {
+			return sampledTable.SampledIterator(
+				ctx,
+				tx,
+				pool,
+				schema,
+				s.options.PhysicalProjection,
+				s.options.Projection,
+				s.options.Filter,
+				newSampler(s.options.Sample).sample,
+				s.callback(ctx),
+			)
+		}
+
+		return table.Iterator(
+			ctx,
+			tx,
+			pool,
+			schema,
+			s.options.PhysicalProjection,
+			s.options.Projection,
+			s.options.Filter,
+			s.options.Distinct,
+			s.sampleCallback(pool, s.callback(ctx)),
+		)
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return err
+	}
+
+	return finish(ctx, s.finisher)
+}
+
+// tableSchema returns the schema of the rows of the table the scan reads.
+func (s *TableScan) tableSchema(ctx context.Context, tx uint64, pool memory.Allocator, table logicalplan.TableReader) (*arrow.Schema, error) {
+	return table.ArrowSchema(
+		ctx,
+		tx,
+		pool,
+		s.options.PhysicalProjection,
+		s.options.Projection,
+		s.options.Filter,
+		s.options.Distinct,
+	)
+}
+
+// callback returns the callback records are passed to. If the scan can stop
+// after a number of rows, it stops once it passed them on.
+func (s *TableScan) callback(ctx context.Context) func(r arrow.Record) error {
+	next := s.stats.out(s.next.Callback)
+	if s.options.Limit <= 0 {
+		return contextCallback(ctx, yieldCallback(ctx, s.yield, next))
+	}
+	limit := NewLimit(s.options.Limit)
+	limit.SetNextCallback(next)
+	return contextCallback(ctx, yieldCallback(ctx, s.yield, limit.Callback))
+}
+
+// sampleCallback returns the callback that records have to be passed to for
+// only their sampled rows to be passed on to the callback, if the scan
+// samples its rows.
+func (s *TableScan) sampleCallback(pool memory.Allocator, callback func(r arrow.Record) error) func(r arrow.Record) error {
+	if s.options.Sample == nil {
+		return callback
+	}
+	sample := NewSample(pool, s.options.Sample)
+	sample.SetNextCallback(callback)
+	return sample.Callback
+}
+
+func (s *TableScan) executeSorted(ctx context.Context, pool memory.Allocator, table logicalplan.TableReader) error {
+	sortedTable, ok := table.(logicalplan.SortedTableReader)
+	if !ok {
+		return errors.New("table can't be read in sorted order")
+	}
+
+	err := table.View(func(tx uint64) error {
+		return sortedTable.SortedIterator(
+			ctx,
+			tx,
+			pool,
+			s.options.PhysicalProjection,
+			s.options.Filter,
+			s.sampleCallback(pool, s.callback(ctx)),
+		)
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return err
+	}
+
+	return finish(ctx, s.finisher)
+}
+
+// executeConcurrently reads the table with multiple goroutines. They pass
+// the records they read to a bounded channel, from which the records are
+// passed on one at a time, so the operators after the scan don't need to be
+// safe for concurrent use.
+func (s *TableScan) executeConcurrently(ctx context.Context, pool memory.Allocator, table logicalplan.TableReader) error {
+	concurrentTable, ok := table.(logicalplan.ConcurrentTableReader)
+	if !ok {
+		return errors.New("table can't be read concurrently")
+	}
+
+	// The reading goroutines are stopped once the records can't be passed
+	// on anymore.
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	records := make(chan arrow.Record, s.concurrency)
+	callbacks := make([]func(r arrow.Record) error, 0, s.concurrency)
+	for i := 0; i < s.concurrency; i++ {
+		callbacks = append(callbacks, func(r arrow.Record) error {
+			r.Retain()
+			select {
+			case records <- r:
+				return nil
+			case <-readCtx.Done():
+				r.Release()
+				return readCtx.Err()
+			}
+		})
+	}
+
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(records)
+		readErr <- table.View(func(tx uint64) error {
+			schema := s.schema
+			if schema == nil {
+				var err error
+				schema, err = s.tableSchema(readCtx, tx, pool, table)
+				if err != nil {
+					return err
+				}
+			}
+
+			return concurrentTable.ConcurrentIterator(
+				readCtx,
+				tx,
+				pool,
+				schema,
+				s.options.PhysicalProjection,
+				s.options.Projection,
+				s.options.Filter,
+				s.options.Distinct,
+				callbacks,
+			)
+		})
+	}()
+
+	callback := s.callback(ctx)
+	var err error
+	for r := range records {
+		if err == nil {
+			if err = callback(r); err != nil {
+				cancel()
+			}
+		}
+		r.Release()
+	}
+	// The error of passing on the records is returned rather than the
+	// cancellation it caused.
+	if rerr := <-readErr; err == nil {
+		err = rerr
+	}
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return err
+	}
+
+	return finish(ctx, s.finisher)
+}
+
+// ConcurrentTableScan reads a table with multiple goroutines, each of them
+// passing the records it reads to its own callback.
+type ConcurrentTableScan struct {
+	options   *logicalplan.TableScan
+	callbacks []func(r arrow.Record) error
+	finisher  func() error
+	closer    func()
+	yield     func(ctx context.Context) error
+	stats     *operatorStats
+}
+
+func (s *ConcurrentTableScan) Execute(ctx context.Context, pool memory.Allocator) error {
+	defer s.stats.since(time.Now())
+
 	table := s.options.TableProvider.GetTable(s.options.TableName)
 	if table == nil {
 		return errors.New("table not found")
 	}
+	concurrentTable, ok := table.(logicalplan.ConcurrentTableReader)
+	if !ok {
+		return errors.New("table can't be read concurrently")
+	}
+
+	buffers := newBufferPool(pool, scanBufferPoolBytes)
+	defer buffers.close()
+	pool = buffers
+
+	callbacks := make([]func(r arrow.Record) error, 0, len(s.callbacks))
+	for _, callback := range s.callbacks {
+		callbacks = append(callbacks, contextCallback(ctx, yieldCallback(ctx, s.yield, s.stats.count(callback))))
+	}
 
 	err := table.View(func(tx uint64) error {
 		schema, err := table.ArrowSchema(
@@ -84,7 +338,7 @@ func (s *TableScan) Execute(ctx context.Context, pool memory.Allocator) error {
 			return err
 		}
 
-		return table.Iterator(
+		return concurrentTable.ConcurrentIterator(
 			ctx,
 			tx,
 			pool,
@@ -93,23 +347,27 @@ func (s *TableScan) Execute(ctx context.Context, pool memory.Allocator) error {
 			s.options.Projection,
 			s.options.Filter,
 			s.options.Distinct,
-			s.next.Callback,
+			callbacks,
 		)
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, errLimitReached) {
 		return err
 	}
 
-	return s.finisher()
+	return finish(ctx, s.finisher)
 }
 
 type SchemaScan struct {
 	options  *logicalplan.SchemaScan
 	next     PhysicalPlan
 	finisher func() error
+	yield    func(ctx context.Context) error
+	stats    *operatorStats
 }
 
 func (s *SchemaScan) Execute(ctx context.Context, pool memory.Allocator) error {
+	defer s.stats.since(time.Now())
+
 	table := s.options.TableProvider.GetTable(s.options.TableName)
 	if table == nil {
 		return errors.New("table not found")
@@ -123,54 +381,432 @@ func (s *SchemaScan) Execute(ctx context.Context, pool memory.Allocator) error {
 			s.options.Projection,
 			s.options.Filter,
 			s.options.Distinct,
-			s.next.Callback,
+			contextCallback(ctx, yieldCallback(ctx, s.yield, s.stats.out(s.next.Callback))),
 		)
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, errLimitReached) {
 		return err
 	}
 
-	return s.finisher()
+	return finish(ctx, s.finisher)
 }
 
-func Build(pool memory.Allocator, s *dynparquet.Schema, plan *logicalplan.LogicalPlan) (*OutputPlan, error) {
+// contextCallback returns a callback that passes records on to the callback
+// until the context is cancelled, so that queries stop between records even
+// if the table they read doesn't check the context.
+func contextCallback(ctx context.Context, callback func(r arrow.Record) error) func(r arrow.Record) error {
+	return func(r arrow.Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return callback(r)
+	}
+}
+
+// yieldCallback returns a callback that yields before passing records on to
+// the callback, so that the query can be paused while queries that outrank it
+// are executed.
+func yieldCallback(ctx context.Context, yield func(ctx context.Context) error, callback func(r arrow.Record) error) func(r arrow.Record) error {
+	if yield == nil {
+		return callback
+	}
+	return func(r arrow.Record) error {
+		if err := yield(ctx); err != nil {
+			return err
+		}
+		return callback(r)
+	}
+}
+
+// finish calls the finisher of a scan, unless the context was cancelled while
+// the table was read, in which case the results of the operators are
+// incomplete and not passed on.
+func finish(ctx context.Context, finisher func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return finisher()
+}
+
+// Option configures how a physical plan is built.
+type Option func(*options)
+
+type options struct {
+	aggregationMemoryLimit int64
+	spillDir               string
+	concurrency            int
+	scanConcurrency        int
+	// sortedScan reads the rows in the order of the table's sorting columns.
+	sortedScan bool
+	stats      bool
+	yield      func(ctx context.Context) error
+	// partialResults is the interval of the snapshots of the results of the
+	// plan's aggregation, if set.
+	partialResults time.Duration
+	// remote are the nodes that execute the leaf fragments of the plan, if
+	// set.
+	remote []RemoteExecutor
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithAggregationMemoryLimit limits the memory used by the state of each hash
+// aggregation to roughly limit bytes. Whenever the limit is exceeded, the
+// states of the groups are spilled to temporary files, which are merged when
+// the aggregation finishes. Aggregations whose results can't be merged from
+// their states spill the rows of groups that aren't in memory yet instead.
+func WithAggregationMemoryLimit(limit int64) Option {
+	return func(o *options) {
+		o.aggregationMemoryLimit = limit
+	}
+}
+
+// WithSpillDir sets the directory of the temporary files that aggregations
+// spill to. The default directory for temporary files is used if not set.
+func WithSpillDir(dir string) Option {
+	return func(o *options) {
+		o.spillDir = dir
+	}
+}
+
+// WithConcurrency sets the number of goroutines that aggregations read and
+// aggregate their input with. Aggregations only use multiple goroutines if
+// their results can be merged from partial aggregations.
+func WithConcurrency(concurrency int) Option {
+	return func(o *options) {
+		o.concurrency = concurrency
+	}
+}
+
+// WithScanConcurrency sets the number of goroutines that table scans read
+// the row groups of tables with. The records they read are passed on to the
+// operators after the scan one at a time, in no particular order. Scans that
+// read the rows in order, stop after a number of rows or sample their rows
+// read tables with a single goroutine.
+func WithScanConcurrency(concurrency int) Option {
+	return func(o *options) {
+		o.scanConcurrency = concurrency
+	}
+}
+
+// scanConcurrency returns the number of goroutines the table scan reads the
+// table with.
+func scanConcurrency(scan *logicalplan.TableScan, sorted bool, o *options) int {
+	if o.scanConcurrency <= 1 || sorted || scan.Limit > 0 || scan.Sample != nil {
+		return 1
+	}
+	if _, ok := scan.TableProvider.GetTable(scan.TableName).(logicalplan.ConcurrentTableReader); !ok {
+		return 1
+	}
+	return o.scanConcurrency
+}
+
+// WithYield makes the scans of the plan call yield before they pass on each
+// record they read. Yield may block to pause the query, the query fails if it
+// returns an error. It is called from multiple goroutines if the scan reads
+// the table concurrently.
+func WithYield(yield func(ctx context.Context) error) Option {
+	return func(o *options) {
+		o.yield = yield
+	}
+}
+
+// WithPartialResults makes the aggregation of the plan pass on snapshots of
+// the results of the groups it aggregated so far at the interval, while it
+// receives records, so that the results of long-running queries can be
+// rendered progressively. The records of snapshots are marked as partial,
+// see IsPartialResult. Only aggregations whose results are passed on to the
+// plan's output by projections and filters take snapshots, and aggregations
+// aren't executed concurrently while snapshots are taken. Aggregations don't
+// take snapshots once they spill, and skip user-defined aggregations that
+// don't implement logicalplan.SnapshotAggregation.
+func WithPartialResults(interval time.Duration) Option {
+	return func(o *options) {
+		o.partialResults = interval
+	}
+}
+
+// withoutPartialResults stops the aggregations of the plans that a plan
+// executes itself from taking snapshots, since their results aren't passed
+// on to the output of the plan.
+func withoutPartialResults() Option {
+	return func(o *options) {
+		o.partialResults = 0
+	}
+}
+
+// withSortedScan makes the table scan of the plan read the rows in the order
+// of the table's sorting columns.
+func withSortedScan() Option {
+	return func(o *options) {
+		o.sortedScan = true
+	}
+}
+
+// finishInOrder returns a finisher that calls the finishers in the given
+// order. Operators must finish before the operators they pass their results
+// to, as finishing passes on their remaining results. Operators after a
+// limit that was reached while finishing still have to finish.
+func finishInOrder(finishers ...func() error) func() error {
+	return func() error {
+		for _, finish := range finishers {
+			if err := finish(); err != nil && !errors.Is(err, errLimitReached) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// closeAll returns a closer that calls all of the closers.
+func closeAll(closers ...func()) func() {
+	return func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+}
+
+func Build(pool memory.Allocator, s *dynparquet.Schema, plan *logicalplan.LogicalPlan, opts ...Option) (*OutputPlan, error) {
 	outputPlan := &OutputPlan{}
+	o := newOptions(opts)
 	var (
 		err      error
 		prev     PhysicalPlan = outputPlan
 		finisher              = func() error { return nil }
+		closer                = func() {}
+		// sorted is set if the table scan has to read the rows in the order
+		// of the table's sorting columns.
+		sorted = o.sortedScan
+		// joins have to build their hash tables before the scan is executed.
+		joins []joinPlan
+		// partialResults is set until an operator is visited whose results
+		// aren't passed on by projections and filters only.
+		partialResults = o.partialResults > 0
+		// subOpts are the options of the plans the plan executes itself.
+		subOpts = append(append([]Option{}, opts...), withoutPartialResults())
 	)
 
+	// explain adds the operator to the operators of the plan and returns
+	// its stats, which are nil unless the plan collects stats.
+	explain := func(name string, plans ...*OutputPlan) *operatorStats {
+		var stats *operatorStats
+		if o.stats {
+			stats = &operatorStats{}
+		}
+		outputPlan.operators = append(outputPlan.operators, operator{name: name, plans: plans, stats: stats})
+		return stats
+	}
+
 	plan.Accept(PrePlanVisitorFunc(func(plan *logicalplan.LogicalPlan) bool {
-		var phyPlan PhysicalPlan
+		var (
+			phyPlan PhysicalPlan
+			stats   *operatorStats
+		)
 		switch {
+		case o.remote != nil && leafFragment(s, plan):
+			var (
+				g     *Gather
+				final *HashAggregate
+				avgs  *AvgProjection
+			)
+			g, final, avgs, err = gather(pool, s, plan, o.remote)
+			if err != nil {
+				return false
+			}
+			next := prev
+			if final != nil {
+				stats = explain("HashAggregate Final " + aggregationString(plan.Aggregation))
+				if partialResults {
+					final.partialResults = o.partialResults
+				}
+				next = stats.plan(final)
+				if avgs != nil {
+					avgs.SetNextCallback(prev.Callback)
+					next.SetNextCallback(avgs.Callback)
+				} else {
+					next.SetNextCallback(prev.Callback)
+				}
+				finisher = finishInOrder(stats.finish(final.Finish), finisher)
+				closer = closeAll(final.Close, closer)
+			}
+			g.next = next
+			g.yield = o.yield
+			g.stats = explain(g.String())
+			g.finisher = g.stats.exclude(finisher)
+			outputPlan.scan = g
+			return false
 		case plan.SchemaScan != nil:
+			stats = explain(plan.SchemaScan.String())
 			outputPlan.scan = &SchemaScan{
 				options:  plan.SchemaScan,
 				next:     prev,
-				finisher: finisher,
+				finisher: stats.exclude(finisher),
+				yield:    o.yield,
+				stats:    stats,
 			}
 			return false
 		case plan.TableScan != nil:
-			outputPlan.scan = &TableScan{
-				options:  plan.TableScan,
-				next:     prev,
-				finisher: finisher,
+			scan := &TableScan{
+				options:     plan.TableScan,
+				next:        prev,
+				sorted:      sorted,
+				concurrency: scanConcurrency(plan.TableScan, sorted, o),
+				yield:       o.yield,
+			}
+			scan.stats = explain(scan.String())
+			scan.finisher = scan.stats.exclude(finisher)
+			outputPlan.scan = scan
+			return false
+		case plan.Union != nil:
+			var union *Union
+			union, err = NewUnion(pool, plan.Union, prev, finisher, subOpts...)
+			outputPlan.scan = union
+			if union != nil {
+				union.stats = explain("Union", union.plans...)
+				union.finisher = union.stats.exclude(union.finisher)
 			}
 			return false
 		case plan.Projection != nil:
 			phyPlan, err = Project(pool, plan.Projection.Exprs)
+			stats = explain("Projection " + exprNames(plan.Projection.Exprs))
 		case plan.Distinct != nil:
 			phyPlan = Distinct(pool, plan.Distinct.Exprs)
+			stats = explain("Distinct " + exprNames(plan.Distinct.Exprs))
 		case plan.Filter != nil:
 			phyPlan, err = Filter(pool, plan.Filter.Expr)
+			stats = explain("Filter " + plan.Filter.Expr.Name())
+		case plan.Having != nil:
+			// The output of the aggregation is filtered like any other record.
+			phyPlan, err = Filter(pool, plan.Having.Expr)
+			stats = explain("Filter " + plan.Having.Expr.Name())
+		case plan.Aggregation != nil && parallelAggregation(s, plan, o):
+			var (
+				final *HashAggregate
+				avgs  *AvgProjection
+			)
+			final, avgs, err = mergeAggregate(pool, s, plan.Aggregation)
+			if err != nil {
+				return false
+			}
+			// Each goroutine of the scan filters and partially aggregates
+			// its records.
+			aggStats := []*operatorStats{
+				explain("HashAggregate Final " + aggregationString(plan.Aggregation)),
+				explain("HashAggregate Partial " + aggregationString(plan.Aggregation)),
+			}
+			for input := plan.Input; input.Filter != nil; input = input.Input {
+				aggStats = append(aggStats, explain("Filter "+input.Filter.Expr.Name()))
+			}
+			next := aggStats[0].out(prev.Callback)
+			if avgs != nil {
+				avgs.SetNextCallback(next)
+				next = avgs.Callback
+			}
+			final.SetNextCallback(next)
+			var scan *ConcurrentTableScan
+			scan, err = partialAggregates(pool, s, plan, final, o.concurrency, aggStats, opts)
+			if err != nil {
+				return false
+			}
+			scan.yield = o.yield
+			scan.stats = explain(scan.String())
+			scan.finisher = scan.stats.exclude(finishInOrder(scan.finisher, finisher))
+			closer = closeAll(scan.closer, closer)
+			outputPlan.scan = scan
+			return false
+		case plan.Aggregation != nil && o.remote == nil && sortedAggregation(s, plan):
+			var agg *OrderedAggregate
+			agg, err = NewOrderedAggregate(pool, s, plan.Aggregation, opts...)
+			phyPlan = agg
+			stats = explain("OrderedAggregate " + aggregationString(plan.Aggregation))
+			if agg != nil {
+				finisher = finishInOrder(stats.finish(agg.Finish), finisher)
+				closer = closeAll(agg.Close, closer)
+			}
+			sorted = true
 		case plan.Aggregation != nil:
 			var agg *HashAggregate
-			agg, err = Aggregate(pool, s, plan.Aggregation)
+			agg, err = Aggregate(pool, s, plan.Aggregation, opts...)
 			phyPlan = agg
+			name := "HashAggregate " + aggregationString(plan.Aggregation)
+			if o.aggregationMemoryLimit > 0 {
+				name += " MemoryLimit: " + strconv.FormatInt(o.aggregationMemoryLimit, 10)
+			}
+			stats = explain(name)
 			if agg != nil {
-				finisher = agg.Finish
+				if partialResults {
+					agg.partialResults = o.partialResults
+				}
+				finisher = finishInOrder(stats.finish(agg.Finish), finisher)
+				closer = closeAll(agg.Close, closer)
+			}
+		case plan.OrderBy != nil && o.remote == nil && sortedOrderBy(s, plan):
+			// The table is read in the order of the order by, which passes
+			// on the rows as they are.
+			sorted = true
+			partialResults = false
+			return true
+		case plan.OrderBy != nil:
+			o := NewOrderBy(pool, plan.OrderBy.Exprs)
+			phyPlan = o
+			stats = explain(plan.OrderBy.String())
+			finisher = finishInOrder(stats.finish(o.Finish), finisher)
+			closer = closeAll(o.Close, closer)
+		case plan.Limit != nil:
+			phyPlan = NewLimit(plan.Limit.Count)
+			stats = explain(plan.Limit.String())
+		case plan.Offset != nil:
+			phyPlan = NewOffset(plan.Offset.Count)
+			stats = explain(plan.Offset.String())
+		case plan.Sample != nil:
+			phyPlan = NewSample(pool, plan.Sample)
+			stats = explain(plan.Sample.String())
+		case plan.Unpivot != nil:
+			phyPlan = NewUnpivot(pool, plan.Unpivot)
+			stats = explain(plan.Unpivot.String())
+		case plan.Window != nil:
+			var w *Window
+			w, err = NewWindow(pool, s, plan.Window)
+			phyPlan = w
+			stats = explain(plan.Window.String())
+			if w != nil {
+				finisher = finishInOrder(stats.finish(w.Finish), finisher)
+				closer = closeAll(w.Close, closer)
 			}
+		case plan.Join != nil && o.remote == nil && mergeJoin(s, plan):
+			var right *OutputPlan
+			right, err = Build(pool, plan.Join.Right.InputSchema(), plan.Join.Right, append(subOpts, withSortedScan())...)
+			if err != nil {
+				return false
+			}
+			j := NewMergeJoin(pool, right, plan.Join)
+			phyPlan = j
+			stats = explain("MergeJoin "+joinKeysString(plan.Join), right)
+			finisher = finishInOrder(stats.finish(j.Finish), finisher)
+			joins = append(joins, j)
+			sorted = true
+		case plan.Join != nil:
+			var right *OutputPlan
+			right, err = Build(pool, plan.Join.Right.InputSchema(), plan.Join.Right, subOpts...)
+			if err != nil {
+				return false
+			}
+			j := NewHashJoin(pool, right, plan.Join)
+			phyPlan = j
+			name := "HashJoin " + joinKeysString(plan.Join)
+			if plan.Join.BuildLeft {
+				name += " BuildLeft"
+			}
+			stats = explain(name, right)
+			finisher = finishInOrder(stats.finish(j.Finish), finisher)
+			joins = append(joins, j)
 		default:
 			panic("Unsupported plan")
 		}
@@ -178,11 +814,19 @@ func Build(pool memory.Allocator, s *dynparquet.Schema, plan *logicalplan.Logica
 		if err != nil {
 			return false
 		}
+		if plan.Projection == nil && plan.Filter == nil && plan.Having == nil {
+			partialResults = false
+		}
 
+		phyPlan = stats.plan(phyPlan)
 		phyPlan.SetNextCallback(prev.Callback)
 		prev = phyPlan
 
 		return true
 	}))
+	if err == nil && len(joins) > 0 {
+		outputPlan.scan = &joinScan{joins: joins, scan: outputPlan.scan}
+	}
+	outputPlan.closer = closer
 	return outputPlan, err
 }
@@ -96,3 +96,93 @@ func TestBuildPhysicalPlan(t *testing.T) {
 	_, err := Build(memory.DefaultAllocator, dynparquet.NewSampleSchema(), p)
 	require.NoError(t, err)
 }
+
+// cancellingTableReader is a table of records that cancels the query after
+// passing on its first record, without checking the context itself.
+type cancellingTableReader struct {
+	recordsTableReader
+	cancel func()
+}
+
+func (m *cancellingTableReader) Iterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	schema *arrow.Schema,
+	physicalProjection []logicalplan.Expr,
+	projection []logicalplan.Expr,
+	filter logicalplan.Expr,
+	distinctColumns []logicalplan.Expr,
+	callback func(r arrow.Record) error,
+) error {
+	return m.recordsTableReader.Iterator(ctx, tx, pool, schema, physicalProjection, projection, filter, distinctColumns, func(r arrow.Record) error {
+		if err := callback(r); err != nil {
+			return err
+		}
+		m.cancel()
+		return nil
+	})
+}
+
+type cancellingTableProvider struct {
+	table *cancellingTableReader
+}
+
+func (m *cancellingTableProvider) GetTable(name string) logicalplan.TableReader {
+	return m.table
+}
+
+func TestExecuteCancelled(t *testing.T) {
+	// The records held by operators are released once the query fails.
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	records := int64Records(pool, []int64{1, 2, 3}, []int64{4, 5, 6}, []int64{7, 8, 9})
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	for _, test := range []struct {
+		name     string
+		build    func(b logicalplan.Builder) logicalplan.Builder
+		received int
+	}{{
+		name:     "scan",
+		build:    func(b logicalplan.Builder) logicalplan.Builder { return b },
+		received: 1,
+	}, {
+		// The rows sorted before the query was cancelled are not passed on.
+		name: "order by",
+		build: func(b logicalplan.Builder) logicalplan.Builder {
+			return b.OrderBy(logicalplan.Asc(logicalplan.Col("value")))
+		},
+		received: 0,
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			table := &cancellingTableReader{
+				recordsTableReader: recordsTableReader{records: records},
+				cancel:             cancel,
+			}
+			p, err := test.build((&logicalplan.Builder{}).Scan(&cancellingTableProvider{table: table}, "table1")).Build()
+			require.NoError(t, err)
+
+			plan, err := Build(pool, dynparquet.NewSampleSchema(), p)
+			require.NoError(t, err)
+
+			received := 0
+			err = plan.Execute(ctx, pool, func(r arrow.Record) error {
+				received++
+				return nil
+			})
+			require.ErrorIs(t, err, context.Canceled)
+			require.Equal(t, test.received, received)
+			// The scan stops at the first record after the cancellation.
+			require.Equal(t, 2, table.read)
+		})
+	}
+}
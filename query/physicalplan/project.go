@@ -14,8 +14,9 @@ type columnProjection interface {
 	Project(mem memory.Allocator, ar arrow.Record) ([]arrow.Field, []arrow.Array, error)
 }
 
+// aliasProjection renames the column matched by the expression.
 type aliasProjection struct {
-	expr *logicalplan.AliasExpr
+	expr logicalplan.Expr
 	name string
 }
 
@@ -73,6 +74,30 @@ func (b binaryExprProjection) Project(mem memory.Allocator, ar arrow.Record) ([]
 	}, []arrow.Array{builder.NewArray()}, nil
 }
 
+// arrayExprProjection evaluates a computed expression into a new column.
+type arrayExprProjection struct {
+	expr ArrayExpression
+	name string
+}
+
+func (a arrayExprProjection) Project(mem memory.Allocator, ar arrow.Record) ([]arrow.Field, []arrow.Array, error) {
+	arr, exists, err := a.expr.ArrowArray(ar)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return nil, nil, nil
+	}
+
+	return []arrow.Field{
+		{
+			Name:     a.name,
+			Type:     arr.DataType(),
+			Nullable: true,
+		},
+	}, []arrow.Array{arr}, nil
+}
+
 type plainProjection struct {
 	expr *logicalplan.Column
 }
@@ -88,7 +113,7 @@ func (p plainProjection) Project(mem memory.Allocator, ar arrow.Record) ([]arrow
 }
 
 type dynamicProjection struct {
-	expr *logicalplan.DynamicColumn
+	expr logicalplan.Expr
 }
 
 func (p dynamicProjection) Project(mem memory.Allocator, ar arrow.Record) ([]arrow.Field, []arrow.Array, error) {
@@ -104,7 +129,14 @@ func (p dynamicProjection) Project(mem memory.Allocator, ar arrow.Record) ([]arr
 	return fields, arrays, nil
 }
 
-func projectionFromExpr(expr logicalplan.Expr) (columnProjection, error) {
+// allProjection projects all columns of a record.
+type allProjection struct{}
+
+func (p allProjection) Project(mem memory.Allocator, ar arrow.Record) ([]arrow.Field, []arrow.Array, error) {
+	return ar.Schema().Fields(), ar.Columns(), nil
+}
+
+func projectionFromExpr(mem memory.Allocator, expr logicalplan.Expr) (columnProjection, error) {
 	switch e := expr.(type) {
 	case *logicalplan.Column:
 		return plainProjection{
@@ -114,24 +146,78 @@ func projectionFromExpr(expr logicalplan.Expr) (columnProjection, error) {
 		return dynamicProjection{
 			expr: e,
 		}, nil
+	case *logicalplan.RegexColumn:
+		return dynamicProjection{
+			expr: e,
+		}, nil
+	case *logicalplan.AllColumnsExpr:
+		return allProjection{}, nil
 	case *logicalplan.AliasExpr:
+		switch inner := e.Expr.(type) {
+		case *logicalplan.Column:
+			return aliasProjection{
+				expr: inner,
+				name: e.Name(),
+			}, nil
+		case *logicalplan.LiteralExpr, *logicalplan.BinaryExpr, *logicalplan.UnaryExpr, *logicalplan.BetweenExpr,
+			*logicalplan.CaseExpr, *logicalplan.CastExpr, *logicalplan.ScalarFunctionExpr, *logicalplan.DurationTruncateExpr, *logicalplan.CoalesceExpr, *logicalplan.CallExpr:
+			return computedProjection(mem, inner, e.Name())
+		}
+
+		// Aliases of other expressions, such as aggregations, name columns
+		// computed by earlier operators.
+
 		return aliasProjection{
 			expr: e,
 			name: e.Name(),
 		}, nil
 	case *logicalplan.BinaryExpr:
-		boolExpr, err := binaryBooleanExpr(e)
+		if e.Op.IsArithmetic() {
+			return computedProjection(mem, e, e.Name())
+		}
+
+		boolExpr, err := binaryBooleanExpr(mem, e)
 		if err != nil {
 			return nil, err
 		}
 		return binaryExprProjection{
 			boolExpr: boolExpr,
 		}, nil
+	case *logicalplan.LiteralExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.UnaryExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.BetweenExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.CaseExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.CastExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.ScalarFunctionExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.DurationTruncateExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.CoalesceExpr:
+		return computedProjection(mem, e, e.Name())
+	case *logicalplan.CallExpr:
+		return computedProjection(mem, e, e.Name())
 	default:
 		return nil, fmt.Errorf("unsupported expression type for projection: %T", expr)
 	}
 }
 
+func computedProjection(mem memory.Allocator, expr logicalplan.Expr, name string) (columnProjection, error) {
+	arrExpr, err := arrayExpr(mem, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return arrayExprProjection{
+		expr: arrExpr,
+		name: name,
+	}, nil
+}
+
 type Projection struct {
 	pool memory.Allocator
 
@@ -147,7 +233,7 @@ func Project(mem memory.Allocator, exprs []logicalplan.Expr) (*Projection, error
 	}
 
 	for _, e := range exprs {
-		proj, err := projectionFromExpr(e)
+		proj, err := projectionFromExpr(mem, e)
 		if err != nil {
 			return nil, err
 		}
@@ -179,8 +265,14 @@ func (p *Projection) Callback(r arrow.Record) error {
 		rows = int64(resArrays[0].Len())
 	}
 
+	// The metadata of the record is kept, such as whether it's a partial
+	// result.
+	var metadata *arrow.Metadata
+	if md := r.Schema().Metadata(); md.Len() > 0 {
+		metadata = &md
+	}
 	ar := array.NewRecord(
-		arrow.NewSchema(resFields, nil),
+		arrow.NewSchema(resFields, metadata),
 		resArrays,
 		rows,
 	)
@@ -10,7 +10,7 @@ import (
 )
 
 type RegExpFilter struct {
-	left     *ArrayRef
+	left     ArrayExpression
 	notMatch bool
 	right    *regexp.Regexp
 }
@@ -32,6 +32,7 @@ func (f *RegExpFilter) Eval(r arrow.Record) (*Bitmap, error) {
 		}
 		return res, nil
 	}
+	defer leftData.Release()
 
 	if f.notMatch {
 		return ArrayScalarRegexNotMatch(leftData, f.right)
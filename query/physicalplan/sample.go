@@ -0,0 +1,78 @@
+package physicalplan
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Sample passes on a uniform random sample of the rows it receives. Each row
+// is passed on with the probability of the sample's fraction, independently
+// of the other rows.
+type Sample struct {
+	pool         memory.Allocator
+	sampler      *sampler
+	nextCallback func(r arrow.Record) error
+}
+
+func NewSample(pool memory.Allocator, sample *logicalplan.Sample) *Sample {
+	return &Sample{
+		pool:    pool,
+		sampler: newSampler(sample),
+	}
+}
+
+func (s *Sample) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	s.nextCallback = nextCallback
+}
+
+func (s *Sample) Callback(r arrow.Record) error {
+	rows := s.sampler.sample(int(r.NumRows()))
+	switch len(rows) {
+	case 0:
+		return nil
+	case int(r.NumRows()):
+		return s.nextCallback(r)
+	}
+
+	res, err := pqarrow.SelectRows(s.pool, r, rows)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+
+	return s.nextCallback(res)
+}
+
+// sampler chooses the rows of a sample.
+type sampler struct {
+	fraction float64
+	rng      *rand.Rand
+}
+
+func newSampler(sample *logicalplan.Sample) *sampler {
+	seed := sample.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &sampler{
+		fraction: sample.Fraction,
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// sample returns the sampled rows out of numRows rows in ascending order.
+func (s *sampler) sample(numRows int) []uint32 {
+	var rows []uint32
+	for i := 0; i < numRows; i++ {
+		if s.rng.Float64() < s.fraction {
+			rows = append(rows, uint32(i))
+		}
+	}
+	return rows
+}
@@ -0,0 +1,76 @@
+package physicalplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestSample(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	batches := make([][]int64, 0, 10)
+	for i := 0; i < 10; i++ {
+		batch := make([]int64, 0, 10)
+		for j := 0; j < 10; j++ {
+			batch = append(batch, int64(i*10+j))
+		}
+		batches = append(batches, batch)
+	}
+	records := int64Records(pool, batches...)
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	sample := func(fraction float64, seed int64, optimize bool) []int64 {
+		table := &recordsTableReader{records: records}
+		p, err := (&logicalplan.Builder{}).
+			Scan(&recordsTableProvider{table: table}, "table1").
+			Sample(fraction).
+			Build()
+		require.NoError(t, err)
+		p.Sample.Seed = seed
+		if optimize {
+			for _, optimizer := range logicalplan.DefaultOptimizers {
+				p = optimizer.Optimize(p)
+			}
+		}
+
+		plan, err := Build(pool, nil, p)
+		require.NoError(t, err)
+
+		values := []int64{}
+		err = plan.Execute(context.Background(), pool, func(r arrow.Record) error {
+			col := r.Column(0).(*array.Int64)
+			for i := 0; i < col.Len(); i++ {
+				values = append(values, col.Value(i))
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return values
+	}
+
+	require.Equal(t, []int64{}, sample(0, 1, false))
+	all := sample(1, 1, false)
+	require.Len(t, all, 100)
+	require.IsIncreasing(t, all)
+
+	values := sample(0.5, 1, false)
+	require.Greater(t, len(values), 25)
+	require.Less(t, len(values), 75)
+	require.IsIncreasing(t, values)
+	// The rows only depend on the seed, whether they are sampled by the
+	// scan or after it.
+	require.Equal(t, values, sample(0.5, 1, true))
+	require.NotEqual(t, values, sample(0.5, 2, false))
+}
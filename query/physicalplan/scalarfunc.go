@@ -0,0 +1,374 @@
+package physicalplan
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/dgryski/go-metro"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// ScalarFunctionExpr applies a scalar function to the arrays its arguments
+// evaluate to.
+type ScalarFunctionExpr struct {
+	pool memory.Allocator
+	Func logicalplan.ScalarFunc
+	Args []ArrayExpression
+}
+
+func (f *ScalarFunctionExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	args := make([]arrow.Array, 0, len(f.Args))
+	defer func() {
+		for _, arg := range args {
+			arg.Release()
+		}
+	}()
+
+	for _, argExpr := range f.Args {
+		arg, exists, err := argExpr.ArrowArray(r)
+		if err != nil || !exists {
+			return nil, false, err
+		}
+		args = append(args, arg)
+	}
+
+	res, err := ScalarFunction(f.pool, f.Func, args)
+	if err != nil {
+		return nil, false, err
+	}
+	return res, true, nil
+}
+
+func (f *ScalarFunctionExpr) String() string {
+	args := make([]string, 0, len(f.Args))
+	for _, arg := range f.Args {
+		args = append(args, arg.String())
+	}
+	return f.Func.String() + "(" + strings.Join(args, ", ") + ")"
+}
+
+// ScalarFunction computes the result of the scalar function for each row of
+// the given argument arrays.
+func ScalarFunction(pool memory.Allocator, fn logicalplan.ScalarFunc, args []arrow.Array) (arrow.Array, error) {
+	switch fn {
+	case logicalplan.ScalarFuncLower:
+		if len(args) != 1 {
+			return nil, fmt.Errorf("lower expects exactly one argument, got %d", len(args))
+		}
+		return mapStringArray(pool, args[0], bytes.ToLower)
+	case logicalplan.ScalarFuncUpper:
+		if len(args) != 1 {
+			return nil, fmt.Errorf("upper expects exactly one argument, got %d", len(args))
+		}
+		return mapStringArray(pool, args[0], bytes.ToUpper)
+	case logicalplan.ScalarFuncLength:
+		if len(args) != 1 {
+			return nil, fmt.Errorf("length expects exactly one argument, got %d", len(args))
+		}
+		return StringArrayLength(pool, args[0])
+	case logicalplan.ScalarFuncConcat:
+		return ConcatArrays(pool, args)
+	case logicalplan.ScalarFuncFingerprint:
+		return FingerprintArrays(pool, args)
+	case logicalplan.ScalarFuncMapValue:
+		if len(args) != 2 {
+			return nil, fmt.Errorf("map_value expects exactly two arguments, got %d", len(args))
+		}
+		return MapValueArray(pool, args[0], args[1])
+	case logicalplan.ScalarFuncAbs, logicalplan.ScalarFuncRound, logicalplan.ScalarFuncFloor, logicalplan.ScalarFuncCeil, logicalplan.ScalarFuncLog:
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s expects exactly one argument, got %d", fn.String(), len(args))
+		}
+		return NumericFunction(pool, fn, args[0])
+	default:
+		return nil, fmt.Errorf("unsupported scalar function %s", fn.String())
+	}
+}
+
+// mapStringArray applies fn to every value of a string or binary array. The
+// result has the same type as the input.
+func mapStringArray(pool memory.Allocator, arr arrow.Array, fn func([]byte) []byte) (arrow.Array, error) {
+	switch a := arr.(type) {
+	case *array.Binary:
+		b := array.NewBinaryBuilder(pool, a.DataType().(arrow.BinaryDataType))
+		defer b.Release()
+
+		b.Reserve(a.Len())
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(fn(a.Value(i)))
+		}
+		return b.NewArray(), nil
+	case *array.String:
+		b := array.NewStringBuilder(pool)
+		defer b.Release()
+
+		b.Reserve(a.Len())
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(string(fn([]byte(a.Value(i)))))
+		}
+		return b.NewArray(), nil
+	default:
+		return nil, fmt.Errorf("expected string argument, got %s", arr.DataType().Name())
+	}
+}
+
+// StringArrayLength returns the number of characters of each value of a
+// string or binary array.
+func StringArrayLength(pool memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	b := array.NewInt64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+
+		s, ok := stringValue(arr, i)
+		if !ok {
+			return nil, fmt.Errorf("expected string argument, got %s", arr.DataType().Name())
+		}
+		b.UnsafeAppend(int64(utf8.RuneCountInString(s)))
+	}
+
+	return b.NewArray(), nil
+}
+
+// ConcatArrays concatenates the textual representation of the values of each
+// row. If any of the values of a row is null, the result is null.
+func ConcatArrays(pool memory.Allocator, args []arrow.Array) (arrow.Array, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("concat expects at least one argument")
+	}
+
+	b := array.NewStringBuilder(pool)
+	defer b.Release()
+
+	rows := args[0].Len()
+	b.Reserve(rows)
+
+	var sb strings.Builder
+	for i := 0; i < rows; i++ {
+		sb.Reset()
+		null := false
+		for _, arg := range args {
+			if arg.IsNull(i) {
+				null = true
+				break
+			}
+
+			s, ok := formatValue(arg, i)
+			if !ok {
+				return nil, fmt.Errorf("cannot concat %s values", arg.DataType().Name())
+			}
+			sb.WriteString(s)
+		}
+
+		if null {
+			b.AppendNull()
+			continue
+		}
+		b.Append(sb.String())
+	}
+
+	return b.NewArray(), nil
+}
+
+// FingerprintArrays computes a hash of the values of each row. The hash only
+// depends on the values and their order, so it is stable across records and
+// processes. Null values hash to a fixed value, so the result is never null.
+func FingerprintArrays(pool memory.Allocator, args []arrow.Array) (arrow.Array, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("fingerprint expects at least one argument")
+	}
+
+	rows := args[0].Len()
+	hashes := make([]uint64, rows)
+	for _, arg := range args {
+		for i := 0; i < rows; i++ {
+			var h uint64
+			if !arg.IsNull(i) {
+				var err error
+				h, err = hashValue(arg, i)
+				if err != nil {
+					return nil, fmt.Errorf("cannot fingerprint: %w", err)
+				}
+			}
+			hashes[i] = hashCombine(hashes[i], h)
+		}
+	}
+
+	b := array.NewUint64Builder(pool)
+	defer b.Release()
+
+	b.AppendValues(hashes, nil)
+	return b.NewArray(), nil
+}
+
+// hashValue hashes the non-null value at index i of the array. The hash only
+// depends on the value, booleans hash to 1 and 2.
+func hashValue(arr arrow.Array, i int) (uint64, error) {
+	var buf [8]byte
+	switch a := arr.(type) {
+	case *array.Binary:
+		return metro.Hash64(a.Value(i), 0), nil
+	case *array.String:
+		return metro.Hash64([]byte(a.Value(i)), 0), nil
+	case *array.Int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(a.Value(i)))
+	case *array.Uint64:
+		binary.LittleEndian.PutUint64(buf[:], a.Value(i))
+	case *array.Float64:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(a.Value(i)))
+	case *array.Timestamp:
+		binary.LittleEndian.PutUint64(buf[:], uint64(a.Value(i)))
+	case *array.Boolean:
+		if a.Value(i) {
+			return 2, nil
+		}
+		return 1, nil
+	case *array.FixedSizeBinary:
+		return metro.Hash64(a.Value(i), 0), nil
+	default:
+		return 0, fmt.Errorf("unsupported type %s", arr.DataType().Name())
+	}
+	return metro.Hash64(buf[:], 0), nil
+}
+
+// MapValueArray returns the value of the key of each row in the map of the
+// row. The result is null if the map is null or doesn't have the key.
+func MapValueArray(pool memory.Allocator, arr, keys arrow.Array) (arrow.Array, error) {
+	m, ok := arr.(*array.Map)
+	if !ok {
+		return nil, fmt.Errorf("map_value expects a map argument, got %s", arr.DataType().Name())
+	}
+	mapKeys, items := m.Keys(), m.Items()
+	offsets := m.Offsets()[m.Data().Offset():]
+
+	b := array.NewBuilder(pool, m.DataType().(*arrow.MapType).ItemType())
+	defer b.Release()
+
+	b.Reserve(m.Len())
+	for i := 0; i < m.Len(); i++ {
+		if m.IsNull(i) || keys.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		key, ok := stringValue(keys, i)
+		if !ok {
+			return nil, fmt.Errorf("map_value expects a string key, got %s", keys.DataType().Name())
+		}
+
+		found := -1
+		for j := int(offsets[i]); j < int(offsets[i+1]); j++ {
+			if k, _ := stringValue(mapKeys, j); k == key {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			b.AppendNull()
+			continue
+		}
+		if err := appendValue(b, items, found); err != nil {
+			return nil, fmt.Errorf("map_value: %w", err)
+		}
+	}
+
+	return b.NewArray(), nil
+}
+
+var float64Funcs = map[logicalplan.ScalarFunc]func(float64) float64{
+	logicalplan.ScalarFuncAbs:   math.Abs,
+	logicalplan.ScalarFuncRound: math.Round,
+	logicalplan.ScalarFuncFloor: math.Floor,
+	logicalplan.ScalarFuncCeil:  math.Ceil,
+	logicalplan.ScalarFuncLog:   math.Log,
+}
+
+// NumericFunction applies a numeric scalar function to every value of the
+// array. Rounding integers is a no-op, the logarithm always results in
+// float64 values.
+func NumericFunction(pool memory.Allocator, fn logicalplan.ScalarFunc, arr arrow.Array) (arrow.Array, error) {
+	f, ok := float64Funcs[fn]
+	if !ok {
+		return nil, fmt.Errorf("unsupported numeric function %s", fn.String())
+	}
+
+	switch a := arr.(type) {
+	case *array.Float64:
+		return mapFloat64Array(pool, a.Len(), a.IsNull, a.Value, f), nil
+	case *array.Int64:
+		switch fn {
+		case logicalplan.ScalarFuncLog:
+			return mapFloat64Array(pool, a.Len(), a.IsNull, func(i int) float64 { return float64(a.Value(i)) }, f), nil
+		case logicalplan.ScalarFuncAbs:
+			return Int64ArrayAbs(pool, a), nil
+		default:
+			a.Retain()
+			return a, nil
+		}
+	case *array.Uint64:
+		switch fn {
+		case logicalplan.ScalarFuncLog:
+			return mapFloat64Array(pool, a.Len(), a.IsNull, func(i int) float64 { return float64(a.Value(i)) }, f), nil
+		default:
+			a.Retain()
+			return a, nil
+		}
+	default:
+		return nil, fmt.Errorf("%s of %s: %w", fn.String(), arr.DataType().Name(), ErrUnsupportedBinaryOperation)
+	}
+}
+
+func mapFloat64Array(pool memory.Allocator, n int, isNull func(int) bool, value func(int) float64, f func(float64) float64) arrow.Array {
+	b := array.NewFloat64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(n)
+	for i := 0; i < n; i++ {
+		if isNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+		b.UnsafeAppend(f(value(i)))
+	}
+
+	return b.NewArray()
+}
+
+func Int64ArrayAbs(pool memory.Allocator, arr *array.Int64) arrow.Array {
+	b := array.NewInt64Builder(pool)
+	defer b.Release()
+
+	b.Reserve(arr.Len())
+	for i, v := range arr.Int64Values() {
+		if arr.IsNull(i) {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+		if v < 0 {
+			v = -v
+		}
+		b.UnsafeAppend(v)
+	}
+
+	return b.NewArray()
+}
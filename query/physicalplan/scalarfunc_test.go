@@ -0,0 +1,138 @@
+package physicalplan
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestScalarFunction(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	b.AppendStringValues([]string{"GET", "Post", "héllo"}, nil)
+	b.AppendNull()
+	methods := b.NewBinaryArray()
+
+	ib := array.NewInt64Builder(pool)
+	ib.AppendValues([]int64{1, 2, 3, 4}, nil)
+	ints := ib.NewInt64Array()
+
+	res, err := ScalarFunction(pool, logicalplan.ScalarFuncLower, []arrow.Array{methods})
+	require.NoError(t, err)
+	lower := res.(*array.Binary)
+	require.Equal(t, "get", lower.ValueString(0))
+	require.Equal(t, "post", lower.ValueString(1))
+	require.True(t, lower.IsNull(3))
+
+	res, err = ScalarFunction(pool, logicalplan.ScalarFuncUpper, []arrow.Array{methods})
+	require.NoError(t, err)
+	require.Equal(t, "POST", res.(*array.Binary).ValueString(1))
+
+	res, err = ScalarFunction(pool, logicalplan.ScalarFuncLength, []arrow.Array{methods})
+	require.NoError(t, err)
+	length := res.(*array.Int64)
+	require.Equal(t, []int64{3, 4, 5}, length.Int64Values()[:3])
+	require.True(t, length.IsNull(3))
+
+	res, err = ScalarFunction(pool, logicalplan.ScalarFuncConcat, []arrow.Array{methods, ints})
+	require.NoError(t, err)
+	concat := res.(*array.String)
+	require.Equal(t, "GET1", concat.Value(0))
+	require.Equal(t, "héllo3", concat.Value(2))
+	require.True(t, concat.IsNull(3))
+
+	_, err = ScalarFunction(pool, logicalplan.ScalarFuncLower, []arrow.Array{ints})
+	require.Error(t, err)
+}
+
+func TestNumericFunction(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	fb := array.NewFloat64Builder(pool)
+	fb.AppendValues([]float64{-1.5, 2.4, 2.5}, nil)
+	floats := fb.NewFloat64Array()
+
+	ib := array.NewInt64Builder(pool)
+	ib.AppendValues([]int64{-2, 1}, []bool{true, true})
+	ib.AppendNull()
+	ints := ib.NewInt64Array()
+
+	tests := map[string]struct {
+		fn       logicalplan.ScalarFunc
+		arr      arrow.Array
+		expected interface{}
+	}{
+		"abs float": {fn: logicalplan.ScalarFuncAbs, arr: floats, expected: []float64{1.5, 2.4, 2.5}},
+		"round":     {fn: logicalplan.ScalarFuncRound, arr: floats, expected: []float64{-2, 2, 3}},
+		"floor":     {fn: logicalplan.ScalarFuncFloor, arr: floats, expected: []float64{-2, 2, 2}},
+		"ceil":      {fn: logicalplan.ScalarFuncCeil, arr: floats, expected: []float64{-1, 3, 3}},
+		"abs int":   {fn: logicalplan.ScalarFuncAbs, arr: ints, expected: []int64{2, 1}},
+		"ceil int":  {fn: logicalplan.ScalarFuncCeil, arr: ints, expected: []int64{-2, 1}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := ScalarFunction(pool, test.fn, []arrow.Array{test.arr})
+			require.NoError(t, err)
+			defer res.Release()
+
+			switch expected := test.expected.(type) {
+			case []float64:
+				require.Equal(t, expected, res.(*array.Float64).Float64Values())
+			case []int64:
+				require.Equal(t, expected, res.(*array.Int64).Int64Values()[:2])
+				require.True(t, res.IsNull(2))
+			}
+		})
+	}
+
+	res, err := ScalarFunction(pool, logicalplan.ScalarFuncLog, []arrow.Array{ints})
+	require.NoError(t, err)
+	logs := res.(*array.Float64)
+	require.True(t, math.IsNaN(logs.Value(0)))
+	require.Equal(t, float64(0), logs.Value(1))
+	require.True(t, logs.IsNull(2))
+}
+
+func TestFingerprintArrays(t *testing.T) {
+	pool := memory.NewGoAllocator()
+
+	b := array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+	b.AppendStringValues([]string{"api", "api", "web"}, nil)
+	b.AppendNull()
+	jobs := b.NewBinaryArray()
+
+	ib := array.NewInt64Builder(pool)
+	ib.AppendValues([]int64{1, 1, 1, 1}, nil)
+	ints := ib.NewInt64Array()
+
+	res, err := ScalarFunction(pool, logicalplan.ScalarFuncFingerprint, []arrow.Array{jobs, ints})
+	require.NoError(t, err)
+	fp := res.(*array.Uint64)
+	require.Equal(t, 4, fp.Len())
+	require.Zero(t, fp.NullN())
+	require.Equal(t, fp.Value(0), fp.Value(1))
+	require.NotEqual(t, fp.Value(0), fp.Value(2))
+	require.NotEqual(t, fp.Value(0), fp.Value(3))
+
+	// The fingerprint only depends on the values, so it is the same for every
+	// evaluation.
+	again, err := FingerprintArrays(pool, []arrow.Array{jobs, ints})
+	require.NoError(t, err)
+	require.Equal(t, fp.Uint64Values(), again.(*array.Uint64).Uint64Values())
+
+	// The order of the arguments is part of the fingerprint.
+	swapped, err := FingerprintArrays(pool, []arrow.Array{ints, jobs})
+	require.NoError(t, err)
+	require.NotEqual(t, fp.Value(0), swapped.(*array.Uint64).Value(0))
+
+	_, err = FingerprintArrays(pool, nil)
+	require.Error(t, err)
+}
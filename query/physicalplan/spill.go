@@ -0,0 +1,157 @@
+package physicalplan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// spillPartitions is the number of partitions the rows of an aggregation are
+// spilled to.
+const spillPartitions = 16
+
+// countingAllocator keeps track of the number of bytes that were allocated
+// and haven't been freed yet.
+type countingAllocator struct {
+	memory.Allocator
+	allocated int64
+}
+
+func newCountingAllocator(pool memory.Allocator) *countingAllocator {
+	return &countingAllocator{Allocator: pool}
+}
+
+func (a *countingAllocator) Allocate(size int) []byte {
+	atomic.AddInt64(&a.allocated, int64(size))
+	return a.Allocator.Allocate(size)
+}
+
+func (a *countingAllocator) Reallocate(size int, b []byte) []byte {
+	atomic.AddInt64(&a.allocated, int64(size-len(b)))
+	return a.Allocator.Reallocate(size, b)
+}
+
+func (a *countingAllocator) Free(b []byte) {
+	atomic.AddInt64(&a.allocated, -int64(len(b)))
+	a.Allocator.Free(b)
+}
+
+func (a *countingAllocator) Allocated() int64 {
+	return atomic.LoadInt64(&a.allocated)
+}
+
+// spillPartition is a temporary file that records are spilled to. Records can
+// have different schemas, so each of them is written as a separate arrow IPC
+// stream.
+type spillPartition struct {
+	file    *os.File
+	streams int
+}
+
+func newSpillPartition(dir string) (*spillPartition, error) {
+	f, err := os.CreateTemp(dir, "frostdb-spill-")
+	if err != nil {
+		return nil, fmt.Errorf("create spill file: %w", err)
+	}
+	return &spillPartition{file: f}, nil
+}
+
+func (p *spillPartition) write(pool memory.Allocator, r arrow.Record) error {
+	w := ipc.NewWriter(p.file, ipc.WithSchema(r.Schema()), ipc.WithAllocator(pool))
+	if err := w.Write(r); err != nil {
+		return fmt.Errorf("spill record: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("spill record: %w", err)
+	}
+	p.streams++
+	return nil
+}
+
+// read calls the callback with all the records spilled to the partition.
+func (p *spillPartition) read(pool memory.Allocator, callback func(r arrow.Record) error) error {
+	if _, err := p.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("read spill file: %w", err)
+	}
+
+	br := bufio.NewReader(p.file)
+	for i := 0; i < p.streams; i++ {
+		if err := readSpilledStream(pool, br, callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readSpilledStream(pool memory.Allocator, r io.Reader, callback func(r arrow.Record) error) error {
+	rdr, err := ipc.NewReader(r, ipc.WithAllocator(pool))
+	if err != nil {
+		return fmt.Errorf("read spill file: %w", err)
+	}
+	defer rdr.Release()
+
+	for rdr.Next() {
+		if err := callback(rdr.Record()); err != nil {
+			return err
+		}
+	}
+	if err := rdr.Err(); err != nil && err != io.EOF {
+		return fmt.Errorf("read spill file: %w", err)
+	}
+	return nil
+}
+
+func (p *spillPartition) remove() error {
+	if err := p.file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(p.file.Name())
+}
+
+// spillRows writes the given rows of the columns of the record that match
+// any of the columns to the partition.
+func spillRows(pool memory.Allocator, p *spillPartition, r arrow.Record, columns []logicalplan.Expr, rows []int) error {
+	fields := make([]arrow.Field, 0, len(r.Schema().Fields()))
+	cols := make([]arrow.Array, 0, len(r.Schema().Fields()))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for i, field := range r.Schema().Fields() {
+		if !matchesAny(columns, field.Name) {
+			continue
+		}
+
+		col, err := selectRows(pool, r.Column(i), true, rows)
+		if err != nil {
+			return fmt.Errorf("spill column %s: %w", field.Name, err)
+		}
+		fields = append(fields, field)
+		cols = append(cols, col)
+	}
+
+	rec := array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(rows)))
+	defer rec.Release()
+
+	return p.write(pool, rec)
+}
+
+func matchesAny(exprs []logicalplan.Expr, columnName string) bool {
+	for _, expr := range exprs {
+		if expr.MatchColumn(columnName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package physicalplan
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+)
+
+// WithStats collects the metrics of the operators of the plan while it is
+// executed, they are returned by the plan's Stats.
+func WithStats() Option {
+	return func(o *options) {
+		o.stats = true
+	}
+}
+
+// OperatorStats are the metrics of an operator of an executed plan.
+type OperatorStats struct {
+	// Name describes the operator like the plan's String.
+	Name       string
+	RowsIn     int64
+	BatchesIn  int64
+	RowsOut    int64
+	BatchesOut int64
+	// Duration is the time spent in the operator, excluding the time spent
+	// in the operators it passed its records to. The time of an operator
+	// executed by multiple goroutines is the time of all of them, and the
+	// time of a scan that reads a table concurrently is the time until all
+	// of its goroutines finished.
+	Duration time.Duration
+	// Plans are the stats of the plans the operator executes itself, such as
+	// the plans of a union or the right plan of a join.
+	Plans []*PlanStats
+}
+
+// PlanStats are the metrics of the operators of an executed plan, starting
+// with the operator that passes on the plan's results and ending with the
+// scan.
+type PlanStats struct {
+	Operators []OperatorStats
+}
+
+// String returns the operators of the plan like the plan's String, each of
+// them followed by its metrics.
+func (s *PlanStats) String() string {
+	return s.string(0)
+}
+
+func (s *PlanStats) string(indent int) string {
+	lines := make([]string, 0, len(s.Operators))
+	for _, op := range s.Operators {
+		lines = append(lines, fmt.Sprintf(
+			"%s%s (rows in: %d, batches in: %d, rows out: %d, batches out: %d, time: %s)",
+			strings.Repeat("  ", indent),
+			op.Name,
+			op.RowsIn,
+			op.BatchesIn,
+			op.RowsOut,
+			op.BatchesOut,
+			op.Duration,
+		))
+		for _, p := range op.Plans {
+			lines = append(lines, p.string(indent+1))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Stats returns the metrics of the operators of the plan collected while it
+// was executed, or nil if the plan wasn't built with WithStats.
+func (e *OutputPlan) Stats() *PlanStats {
+	if len(e.operators) == 0 || e.operators[0].stats == nil {
+		return nil
+	}
+
+	stats := &PlanStats{Operators: make([]OperatorStats, 0, len(e.operators))}
+	for _, op := range e.operators {
+		opStats := OperatorStats{
+			Name:       op.name,
+			RowsIn:     atomic.LoadInt64(&op.stats.rowsIn),
+			BatchesIn:  atomic.LoadInt64(&op.stats.batchesIn),
+			RowsOut:    atomic.LoadInt64(&op.stats.rowsOut),
+			BatchesOut: atomic.LoadInt64(&op.stats.batchesOut),
+			Duration:   time.Duration(atomic.LoadInt64(&op.stats.duration)),
+		}
+		for _, p := range op.plans {
+			opStats.Plans = append(opStats.Plans, p.Stats())
+		}
+		stats.Operators = append(stats.Operators, opStats)
+	}
+	return stats
+}
+
+// operatorStats collects the metrics of an operator while the plan is
+// executed, by wrapping the callbacks and finishers of the operator. Its
+// methods can be called on nil, in which case they return what they wrap
+// unchanged, so plans without stats aren't slowed down.
+type operatorStats struct {
+	rowsIn     int64
+	batchesIn  int64
+	rowsOut    int64
+	batchesOut int64
+	duration   int64
+}
+
+// plan returns the operator wrapped so its records and time are counted.
+func (s *operatorStats) plan(p PhysicalPlan) PhysicalPlan {
+	if s == nil {
+		return p
+	}
+	return &statsPlan{plan: p, stats: s, callback: s.in(p.Callback)}
+}
+
+// in wraps the callback the operator receives records with.
+func (s *operatorStats) in(callback func(r arrow.Record) error) func(r arrow.Record) error {
+	if s == nil {
+		return callback
+	}
+	return func(r arrow.Record) error {
+		atomic.AddInt64(&s.rowsIn, r.NumRows())
+		atomic.AddInt64(&s.batchesIn, 1)
+		start := time.Now()
+		err := callback(r)
+		atomic.AddInt64(&s.duration, int64(time.Since(start)))
+		return err
+	}
+}
+
+// out wraps the callback the operator passes records on to, the time spent
+// in it is the time of the operators after this one.
+func (s *operatorStats) out(callback func(r arrow.Record) error) func(r arrow.Record) error {
+	if s == nil {
+		return callback
+	}
+	return func(r arrow.Record) error {
+		atomic.AddInt64(&s.rowsOut, r.NumRows())
+		atomic.AddInt64(&s.batchesOut, 1)
+		start := time.Now()
+		err := callback(r)
+		atomic.AddInt64(&s.duration, -int64(time.Since(start)))
+		return err
+	}
+}
+
+// count wraps the callback the operator passes records on to, without
+// excluding the time spent in it.
+func (s *operatorStats) count(callback func(r arrow.Record) error) func(r arrow.Record) error {
+	if s == nil {
+		return callback
+	}
+	return func(r arrow.Record) error {
+		atomic.AddInt64(&s.rowsOut, r.NumRows())
+		atomic.AddInt64(&s.batchesOut, 1)
+		return callback(r)
+	}
+}
+
+// finish wraps the finisher of the operator.
+func (s *operatorStats) finish(finisher func() error) func() error {
+	if s == nil {
+		return finisher
+	}
+	return func() error {
+		start := time.Now()
+		err := finisher()
+		atomic.AddInt64(&s.duration, int64(time.Since(start)))
+		return err
+	}
+}
+
+// exclude wraps the finishers of the operators after the scan, which the
+// scan calls.
+func (s *operatorStats) exclude(finisher func() error) func() error {
+	if s == nil {
+		return finisher
+	}
+	return func() error {
+		start := time.Now()
+		err := finisher()
+		atomic.AddInt64(&s.duration, -int64(time.Since(start)))
+		return err
+	}
+}
+
+// since adds the time since start, scans call it once they are executed.
+func (s *operatorStats) since(start time.Time) {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.duration, int64(time.Since(start)))
+}
+
+// statsPlan counts the records and time of the operator it wraps.
+type statsPlan struct {
+	plan     PhysicalPlan
+	stats    *operatorStats
+	callback func(r arrow.Record) error
+}
+
+func (p *statsPlan) Callback(r arrow.Record) error {
+	return p.callback(r)
+}
+
+func (p *statsPlan) SetNextCallback(next func(r arrow.Record) error) {
+	p.plan.SetNextCallback(p.stats.out(next))
+}
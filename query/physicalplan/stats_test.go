@@ -0,0 +1,56 @@
+package physicalplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestOutputPlanStats(t *testing.T) {
+	pool := memory.NewGoAllocator()
+	table := &recordsTableReader{records: int64Records(pool, []int64{1, 2, 3}, []int64{4, 5})}
+	p, err := (&logicalplan.Builder{}).
+		Scan(&recordsTableProvider{table: table}, "table1").
+		Filter(logicalplan.Col("value").Gt(logicalplan.Literal(int64(1)))).
+		OrderBy(logicalplan.Desc(logicalplan.Col("value"))).
+		Limit(2).
+		Build()
+	require.NoError(t, err)
+
+	plan, err := Build(pool, nil, p)
+	require.NoError(t, err)
+	require.Nil(t, plan.Stats())
+
+	plan, err = Build(pool, nil, p, WithStats())
+	require.NoError(t, err)
+	rows := int64(0)
+	require.NoError(t, plan.Execute(context.Background(), pool, func(r arrow.Record) error {
+		rows += r.NumRows()
+		return nil
+	}))
+	require.Equal(t, int64(2), rows)
+
+	stats := plan.Stats()
+	require.Len(t, stats.Operators, 4)
+	type metrics struct {
+		name                                   string
+		rowsIn, batchesIn, rowsOut, batchesOut int64
+	}
+	actual := make([]metrics, 0, len(stats.Operators))
+	for _, op := range stats.Operators {
+		require.GreaterOrEqual(t, op.Duration.Nanoseconds(), int64(0))
+		actual = append(actual, metrics{op.Name, op.RowsIn, op.BatchesIn, op.RowsOut, op.BatchesOut})
+	}
+	require.Equal(t, []metrics{
+		{"Limit 2", 4, 1, 2, 1},
+		{"OrderBy [value desc]", 4, 2, 4, 1},
+		{"Filter value > 1", 5, 2, 4, 2},
+		{"TableScan Table: table1", 0, 0, 5, 2},
+	}, actual)
+	require.Contains(t, stats.String(), "Filter value > 1 (rows in: 5, batches in: 2, rows out: 4, batches out: 2, time: ")
+}
@@ -0,0 +1,73 @@
+package physicalplan
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// StringMatchFilter selects the rows whose value starts with, ends with or
+// contains a fixed byte sequence.
+type StringMatchFilter struct {
+	left  ArrayExpression
+	op    logicalplan.Op
+	right []byte
+}
+
+func (f *StringMatchFilter) Eval(r arrow.Record) (*Bitmap, error) {
+	leftData, exists, err := f.left.ArrowArray(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		// A column that doesn't exist is treated as the empty string.
+		res := NewBitmap()
+		if f.match(nil) {
+			res.AddRange(0, uint64(r.NumRows()))
+		}
+		return res, nil
+	}
+	defer leftData.Release()
+
+	res := newBitset(leftData.Len())
+	switch arr := leftData.(type) {
+	case *array.Binary:
+		for i := 0; i < arr.Len(); i++ {
+			if f.match(arr.Value(i)) {
+				res.set(i)
+			}
+		}
+	case *array.String:
+		for i := 0; i < arr.Len(); i++ {
+			if f.match([]byte(arr.Value(i))) {
+				res.set(i)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s on %s: %w", f.op.String(), leftData.DataType().Name(), ErrUnsupportedBinaryOperation)
+	}
+
+	return res.bitmap(leftData, false), nil
+}
+
+func (f *StringMatchFilter) match(v []byte) bool {
+	switch f.op {
+	case logicalplan.OpStartsWith:
+		return bytes.HasPrefix(v, f.right)
+	case logicalplan.OpEndsWith:
+		return bytes.HasSuffix(v, f.right)
+	case logicalplan.OpContains:
+		return bytes.Contains(v, f.right)
+	default:
+		panic("something terrible has happened, this should have errored previously during validation")
+	}
+}
+
+func (f *StringMatchFilter) String() string {
+	return fmt.Sprintf("%s %s \"%s\"", f.left.String(), f.op.String(), string(f.right))
+}
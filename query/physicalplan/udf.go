@@ -0,0 +1,78 @@
+package physicalplan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// CallExpr calls a user-defined function with the arrays its arguments
+// evaluate to.
+type CallExpr struct {
+	pool memory.Allocator
+	Name string
+	Func logicalplan.UserDefinedFunction
+	Args []ArrayExpression
+}
+
+func callExpr(pool memory.Allocator, e *logicalplan.CallExpr) (*CallExpr, error) {
+	def := e.Definition()
+	if def == nil {
+		return nil, fmt.Errorf("function %s: %w", e.Func, logicalplan.ErrUnknownFunction)
+	}
+
+	args := make([]ArrayExpression, 0, len(e.Args))
+	for _, arg := range e.Args {
+		argExpr, err := arrayExpr(pool, arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, argExpr)
+	}
+
+	return &CallExpr{
+		pool: pool,
+		Name: e.Func,
+		Func: def.Func,
+		Args: args,
+	}, nil
+}
+
+func (c *CallExpr) ArrowArray(r arrow.Record) (arrow.Array, bool, error) {
+	args := make([]arrow.Array, 0, len(c.Args))
+	defer func() {
+		for _, arg := range args {
+			arg.Release()
+		}
+	}()
+
+	for _, argExpr := range c.Args {
+		arg, exists, err := argExpr.ArrowArray(r)
+		if err != nil || !exists {
+			return nil, false, err
+		}
+		args = append(args, arg)
+	}
+
+	res, err := c.Func(c.pool, args)
+	if err != nil {
+		return nil, false, fmt.Errorf("function %s: %w", c.Name, err)
+	}
+	if res.Len() != int(r.NumRows()) {
+		res.Release()
+		return nil, false, fmt.Errorf("function %s returned %d values for %d rows", c.Name, res.Len(), r.NumRows())
+	}
+	return res, true, nil
+}
+
+func (c *CallExpr) String() string {
+	args := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, arg.String())
+	}
+	return c.Name + "(" + strings.Join(args, ", ") + ")"
+}
@@ -0,0 +1,143 @@
+package physicalplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Union executes the plans of a union one after the other and passes on the
+// records of all of them. The plans that only scan a table read the rows
+// with the union of the columns of all of these tables, so that the records
+// of the different tables have the same columns.
+type Union struct {
+	plans    []*OutputPlan
+	next     PhysicalPlan
+	finisher func() error
+	// stats count the time of the plans too, except the time spent in the
+	// operators the union passes their records to.
+	stats *operatorStats
+}
+
+func NewUnion(
+	pool memory.Allocator,
+	union *logicalplan.Union,
+	next PhysicalPlan,
+	finisher func() error,
+	opts ...Option,
+) (*Union, error) {
+	plans := make([]*OutputPlan, 0, len(union.Plans))
+	for _, p := range union.Plans {
+		plan, err := Build(pool, p.InputSchema(), p, opts...)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return &Union{
+		plans:    plans,
+		next:     next,
+		finisher: finisher,
+	}, nil
+}
+
+func (u *Union) Execute(ctx context.Context, pool memory.Allocator) error {
+	defer u.stats.since(time.Now())
+
+	if err := u.mergeSchemas(ctx, pool); err != nil {
+		return err
+	}
+
+	// Once the limit is reached the remaining plans don't have to be
+	// executed.
+	limitReached := false
+	next := u.stats.out(u.next.Callback)
+	callback := func(r arrow.Record) error {
+		err := next(r)
+		if errors.Is(err, errLimitReached) {
+			limitReached = true
+		}
+		return err
+	}
+	for _, p := range u.plans {
+		if err := p.Execute(ctx, pool, callback); err != nil && !errors.Is(err, errLimitReached) {
+			return err
+		}
+		if limitReached {
+			break
+		}
+	}
+
+	return finish(ctx, u.finisher)
+}
+
+// mergeSchemas sets the schema of the scans of the plans that only scan a
+// table to the union of the columns of their tables. The columns are sorted
+// by their names, like the columns of the records of a single table.
+func (u *Union) mergeSchemas(ctx context.Context, pool memory.Allocator) error {
+	scans := make([]*TableScan, 0, len(u.plans))
+	for _, p := range u.plans {
+		if scan, ok := p.scan.(*TableScan); ok && scan.next == p && !scan.sorted {
+			scans = append(scans, scan)
+		}
+	}
+	if len(scans) < 2 {
+		return nil
+	}
+
+	fieldNames := make([]string, 0, 16)
+	fieldsMap := make(map[string]arrow.Field)
+	// The number of tables with each column.
+	tables := make(map[string]int)
+	for _, scan := range scans {
+		table := scan.options.TableProvider.GetTable(scan.options.TableName)
+		if table == nil {
+			return errors.New("table not found")
+		}
+
+		var schema *arrow.Schema
+		err := table.View(func(tx uint64) error {
+			var err error
+			schema, err = scan.tableSchema(ctx, tx, pool, table)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, f := range schema.Fields() {
+			tables[f.Name]++
+			existing, ok := fieldsMap[f.Name]
+			if !ok {
+				fieldNames = append(fieldNames, f.Name)
+				fieldsMap[f.Name] = f
+				continue
+			}
+			if !arrow.TypeEqual(existing.Type, f.Type) {
+				return fmt.Errorf("column %s has different types: %s and %s", f.Name, existing.Type, f.Type)
+			}
+		}
+	}
+
+	sort.Strings(fieldNames)
+	fields := make([]arrow.Field, 0, len(fieldNames))
+	for _, name := range fieldNames {
+		f := fieldsMap[name]
+		// Rows of tables without the column are null.
+		f.Nullable = f.Nullable || tables[name] < len(scans)
+		fields = append(fields, f)
+	}
+
+	schema := arrow.NewSchema(fields, nil)
+	for _, scan := range scans {
+		scan.schema = schema
+	}
+	return nil
+}
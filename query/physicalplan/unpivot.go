@@ -0,0 +1,118 @@
+package physicalplan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Unpivot turns the concrete columns of a dynamic column into rows. Each row
+// it receives is passed on once for each of the concrete columns it has a
+// value for, with the name and the value of the concrete column instead of
+// the dynamic column's columns.
+type Unpivot struct {
+	pool         memory.Allocator
+	column       *logicalplan.DynamicColumn
+	nameColumn   string
+	valueColumn  string
+	nextCallback func(r arrow.Record) error
+}
+
+func NewUnpivot(pool memory.Allocator, unpivot *logicalplan.Unpivot) *Unpivot {
+	return &Unpivot{
+		pool:        pool,
+		column:      unpivot.Column,
+		nameColumn:  unpivot.NameColumn,
+		valueColumn: unpivot.ValueColumn,
+	}
+}
+
+func (u *Unpivot) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	u.nextCallback = nextCallback
+}
+
+func (u *Unpivot) Callback(r arrow.Record) error {
+	// The indices of the concrete columns and of the other columns.
+	var concrete, others []int
+	for i, field := range r.Schema().Fields() {
+		if u.column.MatchColumn(field.Name) {
+			concrete = append(concrete, i)
+		} else {
+			others = append(others, i)
+		}
+	}
+	if len(concrete) == 0 {
+		return nil
+	}
+
+	valueType := r.Schema().Field(concrete[0]).Type
+	for _, i := range concrete[1:] {
+		if field := r.Schema().Field(i); !arrow.TypeEqual(field.Type, valueType) {
+			return fmt.Errorf("column %s has different types: %s and %s", u.column.ColumnName, valueType, field.Type)
+		}
+	}
+
+	// The row and the concrete column of each row passed on.
+	var rows, columns []int
+	for row := 0; row < int(r.NumRows()); row++ {
+		for _, i := range concrete {
+			if r.Column(i).IsValid(row) {
+				rows = append(rows, row)
+				columns = append(columns, i)
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	fields := make([]arrow.Field, 0, len(others)+2)
+	cols := make([]arrow.Array, 0, len(others)+2)
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for _, i := range others {
+		field := r.Schema().Field(i)
+		b := array.NewBuilder(u.pool, field.Type)
+		for _, row := range rows {
+			if err := appendValue(b, r.Column(i), row); err != nil {
+				b.Release()
+				return fmt.Errorf("unpivot column %s: %w", field.Name, err)
+			}
+		}
+		fields = append(fields, field)
+		cols = append(cols, b.NewArray())
+		b.Release()
+	}
+
+	names := array.NewStringBuilder(u.pool)
+	values := array.NewBuilder(u.pool, valueType)
+	defer names.Release()
+	defer values.Release()
+	prefix := u.column.ColumnName + "."
+	for k, row := range rows {
+		i := columns[k]
+		names.Append(strings.TrimPrefix(r.Schema().Field(i).Name, prefix))
+		if err := appendValue(values, r.Column(i), row); err != nil {
+			return fmt.Errorf("unpivot column %s: %w", r.Schema().Field(i).Name, err)
+		}
+	}
+	fields = append(fields,
+		arrow.Field{Name: u.nameColumn, Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: u.valueColumn, Type: valueType},
+	)
+	cols = append(cols, names.NewArray(), values.NewArray())
+
+	res := array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(rows)))
+	defer res.Release()
+
+	return u.nextCallback(res)
+}
@@ -0,0 +1,427 @@
+package physicalplan
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"sort"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Window computes window functions over the rows of all records it receives.
+// The window functions of a row depend on rows of later records, so all
+// records are held until Finish. The records are then passed on in the order
+// they were received, with a column added for each window function.
+type Window struct {
+	pool         memory.Allocator
+	partitionBy  []logicalplan.Expr
+	orderBy      logicalplan.Expr
+	functions    []*windowFunction
+	hashSeed     maphash.Seed
+	records      []arrow.Record
+	nextCallback func(r arrow.Record) error
+}
+
+type windowFunction struct {
+	resultColumnName string
+	dataType         arrow.DataType
+	*logicalplan.WindowFunction
+}
+
+func NewWindow(
+	pool memory.Allocator,
+	s *dynparquet.Schema,
+	window *logicalplan.Window,
+) (*Window, error) {
+	functions := make([]*windowFunction, 0, len(window.Exprs))
+	for _, expr := range window.Exprs {
+		e := expr
+		if alias, ok := e.(*logicalplan.AliasExpr); ok {
+			e = alias.Expr
+		}
+		f, ok := e.(*logicalplan.WindowFunction)
+		if !ok {
+			return nil, fmt.Errorf("unsupported window expression %s", expr.Name())
+		}
+
+		// The type of columns that aren't read from the table, such as the
+		// results of an aggregation, is only known once records are received.
+		dataType, err := f.DataType(s)
+		if err != nil && !errors.Is(err, logicalplan.ErrColumnNotFound) {
+			return nil, err
+		}
+		if dataType != nil && f.Func == logicalplan.WindowFuncMovingSum &&
+			dataType.ID() != arrow.INT64 && dataType.ID() != arrow.FLOAT64 {
+			return nil, fmt.Errorf("unsupported type for %s: %s", f.Func, dataType.Name())
+		}
+
+		functions = append(functions, &windowFunction{
+			resultColumnName: expr.Name(),
+			dataType:         dataType,
+			WindowFunction:   f,
+		})
+	}
+
+	return &Window{
+		pool:        pool,
+		partitionBy: window.PartitionBy,
+		orderBy:     window.OrderBy,
+		functions:   functions,
+		hashSeed:    maphash.MakeSeed(),
+	}, nil
+}
+
+func (w *Window) SetNextCallback(nextCallback func(r arrow.Record) error) {
+	w.nextCallback = nextCallback
+}
+
+func (w *Window) Callback(r arrow.Record) error {
+	r.Retain()
+	w.records = append(w.records, r)
+	return nil
+}
+
+// Close releases the records received, without passing on any rows. It's
+// called instead of Finish when the query fails.
+func (w *Window) Close() {
+	for _, r := range w.records {
+		r.Release()
+	}
+	w.records = nil
+}
+
+// windowRow references a row of one of the records of a window.
+type windowRow struct {
+	record int
+	row    int
+	// order is the value of the order column, rows without one are ordered
+	// first and have no frame.
+	order    int64
+	hasOrder bool
+}
+
+func (w *Window) Finish() error {
+	defer func() {
+		for _, r := range w.records {
+			r.Release()
+		}
+		w.records = nil
+	}()
+
+	partitions, err := w.partitions()
+	if err != nil {
+		return err
+	}
+	w.resolveTypes()
+
+	// The results of each function for each row of each record.
+	results := make([][][]windowValue, len(w.functions))
+	for i := range w.functions {
+		results[i] = make([][]windowValue, len(w.records))
+		for j, r := range w.records {
+			results[i][j] = make([]windowValue, r.NumRows())
+		}
+	}
+
+	for _, rows := range partitions {
+		sort.SliceStable(rows, func(i, j int) bool {
+			if rows[i].hasOrder != rows[j].hasOrder {
+				return !rows[i].hasOrder
+			}
+			return rows[i].order < rows[j].order
+		})
+
+		for i, f := range w.functions {
+			if err := w.compute(f, rows, results[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for j, r := range w.records {
+		fields := append([]arrow.Field{}, r.Schema().Fields()...)
+		cols := append([]arrow.Array{}, r.Columns()...)
+		added := make([]arrow.Array, 0, len(w.functions))
+		for i, f := range w.functions {
+			arr, err := w.build(f, results[i][j])
+			if err != nil {
+				for _, a := range added {
+					a.Release()
+				}
+				return err
+			}
+			added = append(added, arr)
+			fields = append(fields, arrow.Field{Name: f.resultColumnName, Type: arr.DataType(), Nullable: true})
+			cols = append(cols, arr)
+		}
+
+		res := array.NewRecord(arrow.NewSchema(fields, nil), cols, r.NumRows())
+		for _, a := range added {
+			a.Release()
+		}
+		err := w.nextCallback(res)
+		res.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partitions returns the rows of each partition. Like the groups of a
+// HashAggregate, partitions are identified by the hash of their values.
+func (w *Window) partitions() (map[uint64][]windowRow, error) {
+	partitions := map[uint64][]windowRow{}
+	for j, r := range w.records {
+		var (
+			hashes      [][]uint64
+			fieldHashes []uint64
+			order       arrow.Array
+		)
+		for i, field := range r.Schema().Fields() {
+			if matchesAny(w.partitionBy, field.Name) {
+				hashes = append(hashes, hashArray(r.Column(i)))
+				fieldHashes = append(fieldHashes, scalar.Hash(w.hashSeed, scalar.NewStringScalar(field.Name)))
+			}
+			if w.orderBy.MatchColumn(field.Name) {
+				order = r.Column(i)
+			}
+		}
+		if order == nil {
+			return nil, errors.New("window order column not found")
+		}
+
+		var orderValue func(i int) int64
+		switch order := order.(type) {
+		case *array.Int64:
+			orderValue = order.Value
+		case *array.Timestamp:
+			orderValue = func(i int) int64 { return int64(order.Value(i)) }
+		default:
+			return nil, fmt.Errorf("unsupported order type for window: %s", order.DataType().Name())
+		}
+
+		for i := 0; i < int(r.NumRows()); i++ {
+			hash := uint64(0)
+			for k := range hashes {
+				if hashes[k][i] == 0 {
+					continue
+				}
+				hash += hashCombine(fieldHashes[k], hashes[k][i])
+			}
+
+			row := windowRow{record: j, row: i}
+			if !order.IsNull(i) {
+				row.order = orderValue(i)
+				row.hasOrder = true
+			}
+			partitions[hash] = append(partitions[hash], row)
+		}
+	}
+	return partitions, nil
+}
+
+// resolveTypes sets the result types of the window functions whose type
+// wasn't known from the schema to the type of the column they are computed
+// over.
+func (w *Window) resolveTypes() {
+	for _, f := range w.functions {
+		if f.dataType != nil {
+			continue
+		}
+		for _, r := range w.records {
+			for i, field := range r.Schema().Fields() {
+				if f.dataType == nil && f.Expr.MatchColumn(field.Name) {
+					f.dataType = r.Column(i).DataType()
+				}
+			}
+		}
+	}
+}
+
+// windowValue is the result of a window function for a row. Either one of
+// the numbers is set or, for lag and lead, the row whose value is the result.
+type windowValue struct {
+	valid bool
+	i     int64
+	f     float64
+	row   windowRow
+}
+
+// compute computes the results of the window function for the ordered rows
+// of a partition.
+func (w *Window) compute(f *windowFunction, rows []windowRow, results [][]windowValue) error {
+	switch f.Func {
+	case logicalplan.WindowFuncRowNumber:
+		for k, row := range rows {
+			results[row.record][row.row] = windowValue{valid: true, i: int64(k + 1)}
+		}
+		return nil
+	case logicalplan.WindowFuncLag, logicalplan.WindowFuncLead:
+		offset := int(f.Offset)
+		if f.Func == logicalplan.WindowFuncLag {
+			offset = -offset
+		}
+		for k, row := range rows {
+			if k+offset < 0 || k+offset >= len(rows) {
+				continue
+			}
+			results[row.record][row.row] = windowValue{valid: true, row: rows[k+offset]}
+		}
+		return nil
+	case logicalplan.WindowFuncMovingSum, logicalplan.WindowFuncMovingAvg:
+		return w.computeMoving(f, rows, results)
+	default:
+		return fmt.Errorf("unsupported window function %s", f.Func)
+	}
+}
+
+// computeMoving computes moving sums and averages by sliding the frame over
+// the ordered rows, so each row is added and removed once.
+func (w *Window) computeMoving(f *windowFunction, rows []windowRow, results [][]windowValue) error {
+	values := make([]arrow.Array, len(w.records))
+	for j, r := range w.records {
+		for i, field := range r.Schema().Fields() {
+			if f.Expr.MatchColumn(field.Name) {
+				values[j] = r.Column(i)
+			}
+		}
+	}
+
+	// value returns the value of the row as both an integer and a float.
+	value := func(row windowRow) (int64, float64, bool, error) {
+		arr := values[row.record]
+		if arr == nil || arr.IsNull(row.row) {
+			return 0, 0, false, nil
+		}
+		switch arr := arr.(type) {
+		case *array.Int64:
+			v := arr.Value(row.row)
+			return v, float64(v), true, nil
+		case *array.Float64:
+			v := arr.Value(row.row)
+			return int64(v), v, true, nil
+		default:
+			return 0, 0, false, fmt.Errorf("unsupported type for %s: %s", f.Func, arr.DataType().Name())
+		}
+	}
+
+	var (
+		start, end int
+		sumInt     int64
+		sumFloat   float64
+		count      int64
+	)
+	// Rows without an order value have no frame and are ordered first.
+	for start < len(rows) && !rows[start].hasOrder {
+		start++
+	}
+	end = start
+
+	for k := start; k < len(rows); k++ {
+		cur := rows[k].order
+		// Rows with the same order value are all part of the frame.
+		for end < len(rows) && rows[end].order <= cur {
+			i, fl, ok, err := value(rows[end])
+			if err != nil {
+				return err
+			}
+			if ok {
+				sumInt += i
+				sumFloat += fl
+				count++
+			}
+			end++
+		}
+		for cur-rows[start].order >= f.Width {
+			i, fl, ok, err := value(rows[start])
+			if err != nil {
+				return err
+			}
+			if ok {
+				sumInt -= i
+				sumFloat -= fl
+				count--
+			}
+			start++
+		}
+
+		if count == 0 {
+			continue
+		}
+		row := rows[k]
+		switch {
+		case f.Func == logicalplan.WindowFuncMovingAvg:
+			results[row.record][row.row] = windowValue{valid: true, f: sumFloat / float64(count)}
+		case f.dataType.ID() == arrow.INT64:
+			results[row.record][row.row] = windowValue{valid: true, i: sumInt}
+		default:
+			results[row.record][row.row] = windowValue{valid: true, f: sumFloat}
+		}
+	}
+	return nil
+}
+
+// build returns the array of the results of the window function for the rows
+// of a record.
+func (w *Window) build(f *windowFunction, results []windowValue) (arrow.Array, error) {
+	switch {
+	case f.dataType == nil:
+		// None of the records has the column the function is computed over.
+		return array.NewNull(len(results)), nil
+	case f.Func == logicalplan.WindowFuncLag || f.Func == logicalplan.WindowFuncLead:
+		b := array.NewBuilder(w.pool, f.dataType)
+		defer b.Release()
+
+		values := make([]arrow.Array, len(w.records))
+		for j, r := range w.records {
+			for i, field := range r.Schema().Fields() {
+				if f.Expr.MatchColumn(field.Name) {
+					values[j] = r.Column(i)
+				}
+			}
+		}
+
+		for _, v := range results {
+			if !v.valid {
+				b.AppendNull()
+				continue
+			}
+			if err := appendValue(b, values[v.row.record], v.row.row); err != nil {
+				return nil, err
+			}
+		}
+		return b.NewArray(), nil
+	case f.dataType.ID() == arrow.INT64:
+		b := array.NewInt64Builder(w.pool)
+		defer b.Release()
+		for _, v := range results {
+			if !v.valid {
+				b.AppendNull()
+				continue
+			}
+			b.Append(v.i)
+		}
+		return b.NewArray(), nil
+	case f.dataType.ID() == arrow.FLOAT64:
+		b := array.NewFloat64Builder(w.pool)
+		defer b.Release()
+		for _, v := range results {
+			if !v.valid {
+				b.AppendNull()
+				continue
+			}
+			b.Append(v.f)
+		}
+		return b.NewArray(), nil
+	default:
+		return nil, fmt.Errorf("unsupported type for %s: %s", f.Func, f.dataType.Name())
+	}
+}
@@ -0,0 +1,91 @@
+package query
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"google.golang.org/protobuf/proto"
+
+	logicalplanpb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/logicalplan/v1alpha1"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/query/physicalplan"
+)
+
+// RemoteNode executes fragments of plans serialized with MarshalFragment on
+// the tables of another frostdb node, and passes the records of their results
+// to the callback. LocalEngine is a RemoteNode, the transport between nodes is
+// up to the implementations.
+type RemoteNode interface {
+	ExecuteFragment(ctx context.Context, fragment []byte, callback func(r arrow.Record) error) error
+}
+
+// WithRemoteNodes makes the engine execute the leaf fragments of its queries
+// on the nodes, which each read their own rows of the queried tables, and
+// merge their results locally, see physicalplan.WithRemoteExecutors. The
+// tables of the engine's table provider are only used for their schemas.
+// Results aren't cached, since the engine can't tell whether the tables of
+// the nodes were written to.
+func WithRemoteNodes(nodes ...RemoteNode) Option {
+	return func(e *LocalEngine) {
+		executors := make([]physicalplan.RemoteExecutor, 0, len(nodes))
+		for _, node := range nodes {
+			executors = append(executors, remoteExecutor{node: node})
+		}
+		e.physicalOptions = append(e.physicalOptions, physicalplan.WithRemoteExecutors(executors...))
+		e.remote = len(nodes) > 0
+	}
+}
+
+// remoteExecutor serializes the fragments executed by a remote node.
+type remoteExecutor struct {
+	node RemoteNode
+}
+
+func (r remoteExecutor) ExecuteFragment(ctx context.Context, fragment *logicalplan.LogicalPlan, callback func(r arrow.Record) error) error {
+	data, err := MarshalFragment(fragment)
+	if err != nil {
+		return err
+	}
+	return r.node.ExecuteFragment(ctx, data, callback)
+}
+
+// MarshalFragment serializes a fragment of a plan as protobuf.
+func MarshalFragment(fragment *logicalplan.LogicalPlan) ([]byte, error) {
+	node, err := logicalplan.ToProto(fragment)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(node)
+}
+
+// UnmarshalFragment deserializes a fragment of a plan serialized with
+// MarshalFragment, whose scans read the tables of the provider.
+func UnmarshalFragment(data []byte, provider logicalplan.TableProvider) (*logicalplan.LogicalPlan, error) {
+	node := &logicalplanpb.PlanNode{}
+	if err := proto.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+	return logicalplan.FromProto(node, provider)
+}
+
+// ExecuteFragment executes a fragment of a plan serialized with
+// MarshalFragment on the tables of the engine, so the engine can be the
+// remote node of other engines. Fragments are optimized already, and always
+// read the engine's own tables.
+func (e *LocalEngine) ExecuteFragment(ctx context.Context, fragment []byte, callback func(r arrow.Record) error) error {
+	plan, err := UnmarshalFragment(fragment, e.tableProvider)
+	if err != nil {
+		return err
+	}
+
+	q := &PreparedQuery{
+		pool:            e.pool,
+		physicalOptions: e.physicalOptions,
+		timeout:         e.timeout,
+		memoryLimit:     e.memoryLimit,
+		scheduler:       e.scheduler,
+		plan:            plan,
+	}
+	_, err = q.executePlan(ctx, plan, callback, nil, physicalplan.WithRemoteExecutors())
+	return err
+}
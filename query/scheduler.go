@@ -0,0 +1,225 @@
+package query
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned by queries that couldn't wait for a slot of
+	// the engine's scheduler, because the maximum number of queries already
+	// wait.
+	ErrQueueFull = errors.New("query queue full")
+	// ErrQueueTimeout is returned by queries that waited for a slot of the
+	// engine's scheduler for longer than the queue timeout.
+	ErrQueueTimeout = errors.New("query queue timeout")
+)
+
+// Priority is the scheduling class of a query. Queries of a higher priority
+// outrank queries of a lower priority when they wait for a slot of the
+// engine's scheduler, and pause the queries of a lower priority that are
+// executed while they wait.
+type Priority int
+
+const (
+	// PriorityInteractive is the priority of queries that users wait for,
+	// it's the priority of queries by default.
+	PriorityInteractive Priority = iota
+	// PriorityBatch is the priority of queries whose results aren't waited
+	// for, such as reports.
+	PriorityBatch
+	// PriorityBackground is the priority of queries that should only take
+	// up slots that no other queries need.
+	PriorityBackground
+
+	numPriorities = int(PriorityBackground) + 1
+)
+
+// scheduler limits the number of queries that are executed at the same time
+// by their slots. Waiting queries are given the slots that are freed by their
+// priority, and in the order they started waiting within a priority.
+type scheduler struct {
+	// maxQueued is the number of queries that can wait to be admitted, and
+	// queueTimeout the time they wait until they fail. They are unlimited
+	// if zero. Paused queries always wait until they are resumed.
+	maxQueued    int
+	queueTimeout time.Duration
+
+	mtx  sync.Mutex
+	free int
+	// waiting are the waiters for a slot of each priority.
+	waiting [numPriorities]*list.List
+}
+
+func newScheduler(slots, maxQueued int, queueTimeout time.Duration) *scheduler {
+	s := &scheduler{
+		maxQueued:    maxQueued,
+		queueTimeout: queueTimeout,
+		free:         slots,
+	}
+	for i := range s.waiting {
+		s.waiting[i] = list.New()
+	}
+	return s
+}
+
+// waiter is closed once it's given a slot.
+type waiter chan struct{}
+
+// acquire waits for a slot for a query of the priority, which must be
+// released. Its methods can be called on nil, which acquire returns if the
+// scheduler is nil, in which case queries are never paused.
+func (s *scheduler) acquire(ctx context.Context, p Priority) (*slot, error) {
+	if s == nil {
+		return nil, nil
+	}
+	switch {
+	case p < PriorityInteractive:
+		p = PriorityInteractive
+	case p > PriorityBackground:
+		p = PriorityBackground
+	}
+	if err := s.wait(ctx, p, true); err != nil {
+		return nil, err
+	}
+	return &slot{scheduler: s, priority: p, held: true}, nil
+}
+
+// wait waits for a slot for a query of the priority. The limits of the queue
+// only apply to queries that wait to be admitted.
+func (s *scheduler) wait(ctx context.Context, p Priority, admit bool) error {
+	s.mtx.Lock()
+	if s.free > 0 && !s.outranked(p+1) {
+		s.free--
+		s.mtx.Unlock()
+		return nil
+	}
+	if admit && s.maxQueued > 0 && s.queued() >= s.maxQueued {
+		s.mtx.Unlock()
+		return fmt.Errorf("%w: %d queries waiting", ErrQueueFull, s.maxQueued)
+	}
+	w := make(waiter)
+	e := s.waiting[p].PushBack(w)
+	s.mtx.Unlock()
+
+	var timeout <-chan time.Time
+	if admit && s.queueTimeout > 0 {
+		timer := time.NewTimer(s.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-w:
+		return nil
+	case <-ctx.Done():
+		s.stopWaiting(p, e, w)
+		return ctx.Err()
+	case <-timeout:
+		s.stopWaiting(p, e, w)
+		return fmt.Errorf("%w: waited %s", ErrQueueTimeout, s.queueTimeout)
+	}
+}
+
+// stopWaiting removes the waiter of a query that stopped waiting.
+func (s *scheduler) stopWaiting(p Priority, e *list.Element, w waiter) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	select {
+	case <-w:
+		// The slot was given to the query before it stopped waiting.
+		s.releaseLocked()
+	default:
+		s.waiting[p].Remove(e)
+	}
+}
+
+// queued returns the number of queries waiting for a slot.
+func (s *scheduler) queued() int {
+	n := 0
+	for _, waiting := range s.waiting {
+		n += waiting.Len()
+	}
+	return n
+}
+
+// outranked returns whether queries of a priority higher than p wait for a
+// slot, priorities are higher the lower their value.
+func (s *scheduler) outranked(p Priority) bool {
+	for i := 0; i < int(p) && i < numPriorities; i++ {
+		if s.waiting[i].Len() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scheduler) release() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.releaseLocked()
+}
+
+// releaseLocked gives the slot to the first waiter of the highest priority,
+// or frees it if no query waits.
+func (s *scheduler) releaseLocked() {
+	for _, waiting := range s.waiting {
+		if e := waiting.Front(); e != nil {
+			close(waiting.Remove(e).(waiter))
+			return
+		}
+	}
+	s.free++
+}
+
+// slot is the slot a query is executed with.
+type slot struct {
+	scheduler *scheduler
+	priority  Priority
+	// mtx serializes the yields of a query that scans a table with multiple
+	// goroutines, so the query only gives up its slot once.
+	mtx sync.Mutex
+	// held is unset if the query stopped waiting for the slot after it gave
+	// it up.
+	held bool
+}
+
+// yield gives up the slot to the queries that outrank the query, and waits
+// for a slot again once they are given the slot.
+func (s *slot) yield(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.scheduler.mtx.Lock()
+	outranked := s.scheduler.outranked(s.priority)
+	s.scheduler.mtx.Unlock()
+	if !s.held || !outranked {
+		return nil
+	}
+
+	s.scheduler.release()
+	if err := s.scheduler.wait(ctx, s.priority, false); err != nil {
+		s.held = false
+		return err
+	}
+	return nil
+}
+
+func (s *slot) release() {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.held {
+		s.held = false
+		s.scheduler.release()
+	}
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	s := newScheduler(1, 0, 0)
+
+	waiting := func(p Priority) func() bool {
+		return func() bool {
+			s.mtx.Lock()
+			defer s.mtx.Unlock()
+			return s.waiting[p].Len() == 1
+		}
+	}
+	acquire := func(p Priority) chan *slot {
+		acquired := make(chan *slot, 1)
+		go func() {
+			slot, err := s.acquire(ctx, p)
+			require.NoError(t, err)
+			acquired <- slot
+		}()
+		require.Eventually(t, waiting(p), time.Second, time.Millisecond)
+		return acquired
+	}
+
+	batch, err := s.acquire(ctx, PriorityBatch)
+	require.NoError(t, err)
+	background := acquire(PriorityBackground)
+	interactive := acquire(PriorityInteractive)
+
+	// The batch query gives up its slot to the interactive query, and
+	// outranks the background query once the interactive query finished.
+	yielded := make(chan error, 1)
+	go func() {
+		yielded <- batch.yield(ctx)
+	}()
+	interactiveSlot := <-interactive
+	require.Eventually(t, waiting(PriorityBatch), time.Second, time.Millisecond)
+	interactiveSlot.release()
+	require.NoError(t, <-yielded)
+
+	// Nothing outranks the batch query anymore.
+	require.NoError(t, batch.yield(ctx))
+	select {
+	case <-background:
+		t.Fatal("background query acquired a slot before the batch query finished")
+	default:
+	}
+	batch.release()
+	(<-background).release()
+
+	// Queries stop waiting once their context is cancelled.
+	batch, err = s.acquire(ctx, PriorityBatch)
+	require.NoError(t, err)
+	cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = s.acquire(cancelCtx, PriorityInteractive)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 0, s.waiting[PriorityInteractive].Len())
+	batch.release()
+	require.Equal(t, 1, s.free)
+}
+
+func TestSchedulerQueue(t *testing.T) {
+	ctx := context.Background()
+	s := newScheduler(1, 1, 20*time.Millisecond)
+
+	running, err := s.acquire(ctx, PriorityBatch)
+	require.NoError(t, err)
+
+	queued := make(chan error, 1)
+	go func() {
+		_, err := s.acquire(ctx, PriorityBatch)
+		queued <- err
+	}()
+	require.Eventually(t, func() bool {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		return s.queued() == 1
+	}, time.Second, time.Millisecond)
+
+	// Queries fail right away once the queue is full.
+	_, err = s.acquire(ctx, PriorityInteractive)
+	require.ErrorIs(t, err, ErrQueueFull)
+
+	// The queued query fails once it waited longer than the timeout.
+	require.ErrorIs(t, <-queued, ErrQueueTimeout)
+	require.Equal(t, 0, s.queued())
+
+	running.release()
+	require.Equal(t, 1, s.free)
+}
@@ -0,0 +1,213 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const ddSketchVersion = 1
+
+// DDSketch estimates quantiles of the values it has seen with a bounded
+// relative error. Values are counted in buckets whose boundaries grow
+// exponentially, so sketches with the same relative accuracy can be merged
+// without losing accuracy.
+type DDSketch struct {
+	relativeAccuracy float64
+	gamma            float64
+	logGamma         float64
+
+	positive map[int32]uint64
+	negative map[int32]uint64
+	zeros    uint64
+	count    uint64
+}
+
+// NewDDSketch returns a sketch whose quantiles are within the given relative
+// accuracy, such as 0.01, of the actual values.
+func NewDDSketch(relativeAccuracy float64) (*DDSketch, error) {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		return nil, fmt.Errorf("relative accuracy must be between 0 and 1, got %v", relativeAccuracy)
+	}
+
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &DDSketch{
+		relativeAccuracy: relativeAccuracy,
+		gamma:            gamma,
+		logGamma:         math.Log(gamma),
+		positive:         map[int32]uint64{},
+		negative:         map[int32]uint64{},
+	}, nil
+}
+
+// minIndexableValue is the smallest magnitude that isn't counted as zero.
+const minIndexableValue = 1e-9
+
+func (s *DDSketch) index(v float64) int32 {
+	return int32(math.Ceil(math.Log(v) / s.logGamma))
+}
+
+// value returns the value a bucket is estimated as, which is within the
+// relative accuracy of all values of the bucket.
+func (s *DDSketch) value(i int32) float64 {
+	return 2 * math.Pow(s.gamma, float64(i)) / (s.gamma + 1)
+}
+
+// Add adds a value to the sketch. NaN values are ignored.
+func (s *DDSketch) Add(v float64) {
+	switch {
+	case math.IsNaN(v):
+		return
+	case v > minIndexableValue:
+		s.positive[s.index(v)]++
+	case v < -minIndexableValue:
+		s.negative[s.index(-v)]++
+	default:
+		s.zeros++
+	}
+	s.count++
+}
+
+// Count returns the number of values added to the sketch.
+func (s *DDSketch) Count() uint64 {
+	return s.count
+}
+
+// Merge adds all values seen by the other sketch, which must have the same
+// relative accuracy.
+func (s *DDSketch) Merge(o *DDSketch) error {
+	if o.relativeAccuracy != s.relativeAccuracy {
+		return fmt.Errorf("cannot merge sketches with relative accuracy %v and %v", s.relativeAccuracy, o.relativeAccuracy)
+	}
+
+	for i, c := range o.positive {
+		s.positive[i] += c
+	}
+	for i, c := range o.negative {
+		s.negative[i] += c
+	}
+	s.zeros += o.zeros
+	s.count += o.count
+	return nil
+}
+
+// Quantile returns the estimated value at quantile q, between 0 and 1. It
+// returns false if the sketch is empty.
+func (s *DDSketch) Quantile(q float64) (float64, bool) {
+	if s.count == 0 || q < 0 || q > 1 {
+		return 0, false
+	}
+
+	rank := uint64(q * float64(s.count-1))
+	seen := uint64(0)
+
+	// Negative values are ordered by decreasing magnitude.
+	negative := sortedIndexes(s.negative)
+	for j := len(negative) - 1; j >= 0; j-- {
+		seen += s.negative[negative[j]]
+		if seen > rank {
+			return -s.value(negative[j]), true
+		}
+	}
+
+	seen += s.zeros
+	if seen > rank {
+		return 0, true
+	}
+
+	positive := sortedIndexes(s.positive)
+	for _, i := range positive {
+		seen += s.positive[i]
+		if seen > rank {
+			return s.value(i), true
+		}
+	}
+
+	return s.value(positive[len(positive)-1]), true
+}
+
+func sortedIndexes(buckets map[int32]uint64) []int32 {
+	indexes := make([]int32, 0, len(buckets))
+	for i := range buckets {
+		indexes = append(indexes, i)
+	}
+	sort.Slice(indexes, func(a, b int) bool { return indexes[a] < indexes[b] })
+	return indexes
+}
+
+// MarshalBinary encodes the sketch so it can be merged elsewhere.
+func (s *DDSketch) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 9, 9+binary.MaxVarintLen64*(3+2*(len(s.positive)+len(s.negative))))
+	data[0] = ddSketchVersion
+	binary.LittleEndian.PutUint64(data[1:], math.Float64bits(s.relativeAccuracy))
+
+	var buf [binary.MaxVarintLen64]byte
+	appendUvarint := func(v uint64) {
+		data = append(data, buf[:binary.PutUvarint(buf[:], v)]...)
+	}
+	appendUvarint(s.zeros)
+	for _, buckets := range []map[int32]uint64{s.positive, s.negative} {
+		appendUvarint(uint64(len(buckets)))
+		for _, i := range sortedIndexes(buckets) {
+			data = append(data, buf[:binary.PutVarint(buf[:], int64(i))]...)
+			appendUvarint(buckets[i])
+		}
+	}
+	return data, nil
+}
+
+func (s *DDSketch) UnmarshalBinary(data []byte) error {
+	if len(data) < 9 {
+		return fmt.Errorf("%w: ddsketch of %d bytes", ErrInvalidSketch, len(data))
+	}
+	if data[0] != ddSketchVersion {
+		return fmt.Errorf("%w: unknown ddsketch version %d", ErrInvalidSketch, data[0])
+	}
+
+	decoded, err := NewDDSketch(math.Float64frombits(binary.LittleEndian.Uint64(data[1:9])))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSketch, err)
+	}
+	data = data[9:]
+
+	uvarint := func() uint64 {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			err = ErrInvalidSketch
+			return 0
+		}
+		data = data[n:]
+		return v
+	}
+	varint := func() int64 {
+		v, n := binary.Varint(data)
+		if n <= 0 {
+			err = ErrInvalidSketch
+			return 0
+		}
+		data = data[n:]
+		return v
+	}
+
+	decoded.zeros = uvarint()
+	decoded.count = decoded.zeros
+	for _, buckets := range []map[int32]uint64{decoded.positive, decoded.negative} {
+		n := uvarint()
+		for j := uint64(0); j < n && err == nil; j++ {
+			i := varint()
+			c := uvarint()
+			buckets[int32(i)] += c
+			decoded.count += c
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: truncated ddsketch", ErrInvalidSketch)
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidSketch, len(data))
+	}
+
+	*s = *decoded
+	return nil
+}
@@ -0,0 +1,88 @@
+package sketch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDDSketch(t *testing.T) {
+	s, err := NewDDSketch(0.01)
+	require.NoError(t, err)
+
+	_, ok := s.Quantile(0.5)
+	require.False(t, ok)
+
+	for i := 1; i <= 1000; i++ {
+		s.Add(float64(i))
+	}
+	s.Add(math.NaN())
+	require.Equal(t, uint64(1000), s.Count())
+
+	for _, tc := range []struct {
+		q        float64
+		expected float64
+	}{
+		{0, 1},
+		{0.5, 500},
+		{0.9, 900},
+		{0.99, 990},
+		{1, 1000},
+	} {
+		v, ok := s.Quantile(tc.q)
+		require.True(t, ok)
+		require.InEpsilon(t, tc.expected, v, 0.011, "quantile %v", tc.q)
+	}
+
+	_, err = NewDDSketch(0)
+	require.Error(t, err)
+}
+
+func TestDDSketchNegativeValues(t *testing.T) {
+	s, err := NewDDSketch(0.01)
+	require.NoError(t, err)
+
+	for _, v := range []float64{-100, -10, 0, 10, 100} {
+		s.Add(v)
+	}
+
+	for q, expected := range map[float64]float64{0: -100, 0.25: -10, 0.5: 0, 0.75: 10, 1: 100} {
+		v, ok := s.Quantile(q)
+		require.True(t, ok)
+		require.InDelta(t, expected, v, math.Abs(expected)*0.01, "quantile %v", q)
+	}
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	a, err := NewDDSketch(0.01)
+	require.NoError(t, err)
+	b, err := NewDDSketch(0.01)
+	require.NoError(t, err)
+
+	for i := 1; i <= 500; i++ {
+		a.Add(float64(i))
+	}
+	for i := 501; i <= 1000; i++ {
+		b.Add(float64(i))
+	}
+	b.Add(0)
+
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+	decoded := &DDSketch{}
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, b.Count(), decoded.Count())
+
+	require.NoError(t, a.Merge(decoded))
+	require.Equal(t, uint64(1001), a.Count())
+	v, ok := a.Quantile(0.5)
+	require.True(t, ok)
+	require.InEpsilon(t, 500, v, 0.011)
+
+	other, err := NewDDSketch(0.05)
+	require.NoError(t, err)
+	require.Error(t, a.Merge(other))
+
+	require.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), ErrInvalidSketch)
+}
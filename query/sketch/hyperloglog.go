@@ -0,0 +1,97 @@
+// Package sketch implements mergeable summaries of values that can be used to
+// compute approximate aggregations.
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	hllVersion = 1
+
+	// HLLPrecision is the number of bits of a hash used to pick its register.
+	// With 2^12 registers the standard error of an estimate is about 1.6%.
+	HLLPrecision = 12
+	hllRegisters = 1 << HLLPrecision
+)
+
+// HyperLogLog estimates the number of distinct values it has seen. Values are
+// added as 64-bit hashes, which must be uniformly distributed.
+type HyperLogLog struct {
+	registers []uint8
+}
+
+func NewHyperLogLog() *HyperLogLog {
+	return &HyperLogLog{
+		registers: make([]uint8, hllRegisters),
+	}
+}
+
+// Insert adds the value with the given hash.
+func (h *HyperLogLog) Insert(hash uint64) {
+	i := hash >> (64 - HLLPrecision)
+	// The guard bit bounds the rank if the remaining bits are all zero.
+	w := hash<<HLLPrecision | 1<<(HLLPrecision-1)
+	rank := uint8(bits.LeadingZeros64(w)) + 1
+	if rank > h.registers[i] {
+		h.registers[i] = rank
+	}
+}
+
+// Merge adds all values seen by the other sketch.
+func (h *HyperLogLog) Merge(o *HyperLogLog) {
+	for i, r := range o.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+}
+
+// Estimate returns the estimated number of distinct values.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(hllRegisters)
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+var ErrInvalidSketch = errors.New("invalid sketch")
+
+// MarshalBinary encodes the sketch so it can be merged elsewhere.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(h.registers))
+	data = append(data, hllVersion, HLLPrecision)
+	return append(data, h.registers...), nil
+}
+
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) != 2+hllRegisters {
+		return fmt.Errorf("%w: hyperloglog of %d bytes", ErrInvalidSketch, len(data))
+	}
+	if data[0] != hllVersion {
+		return fmt.Errorf("%w: unknown hyperloglog version %d", ErrInvalidSketch, data[0])
+	}
+	if data[1] != HLLPrecision {
+		return fmt.Errorf("%w: hyperloglog precision %d", ErrInvalidSketch, data[1])
+	}
+
+	h.registers = append(h.registers[:0], data[2:]...)
+	return nil
+}
@@ -0,0 +1,55 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/dgryski/go-metro"
+	"github.com/stretchr/testify/require"
+)
+
+func hash(i int) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(i))
+	return metro.Hash64(b[:], 0)
+}
+
+func requireEstimate(t *testing.T, expected int, h *HyperLogLog) {
+	t.Helper()
+	require.InEpsilon(t, expected, h.Estimate(), 0.05)
+}
+
+func TestHyperLogLog(t *testing.T) {
+	require.Equal(t, uint64(0), NewHyperLogLog().Estimate())
+
+	for _, n := range []int{10, 1000, 100000} {
+		h := NewHyperLogLog()
+		for i := 0; i < n; i++ {
+			// Duplicates don't change the estimate.
+			h.Insert(hash(i))
+			h.Insert(hash(i))
+		}
+		requireEstimate(t, n, h)
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a, b := NewHyperLogLog(), NewHyperLogLog()
+	for i := 0; i < 20000; i++ {
+		a.Insert(hash(i))
+	}
+	for i := 10000; i < 30000; i++ {
+		b.Insert(hash(i))
+	}
+
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+	decoded := NewHyperLogLog()
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, b.Estimate(), decoded.Estimate())
+
+	a.Merge(decoded)
+	requireEstimate(t, 30000, a)
+
+	require.ErrorIs(t, decoded.UnmarshalBinary(data[:10]), ErrInvalidSketch)
+}
@@ -0,0 +1,30 @@
+package query
+
+import (
+	"time"
+
+	"github.com/polarsignals/frostdb/query/physicalplan"
+)
+
+// QueryStats are the metrics of the execution of a query, so that the cost of
+// queries can be logged or billed.
+type QueryStats struct {
+	// PlanStats are the metrics of the operators that executed the query.
+	*physicalplan.PlanStats
+
+	// RowsScanned is the number of rows of the row groups the query read,
+	// and RowGroupsScanned the number of those row groups.
+	RowsScanned      int64
+	RowGroupsScanned int64
+	// RowGroupsPruned is the number of row groups the query didn't read,
+	// since none of their rows match its filter.
+	RowGroupsPruned int64
+	// BytesRead is the number of bytes read from object storage.
+	BytesRead int64
+	// PeakMemory is the largest number of bytes the query had allocated at
+	// once.
+	PeakMemory int64
+	// Duration is the time the query took, including the time it waited for
+	// a slot of the engine's scheduler.
+	Duration time.Duration
+}
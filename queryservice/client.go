@@ -0,0 +1,89 @@
+package queryservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"google.golang.org/grpc"
+
+	queryv1alpha1 "github.com/polarsignals/frostdb/gen/proto/go/frostdb/query/v1alpha1"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Client executes queries on a Server.
+type Client struct {
+	client queryv1alpha1.QueryServiceClient
+	pool   memory.Allocator
+}
+
+type ClientOption func(*Client)
+
+// WithClientAllocator sets the allocator the records of results are
+// deserialized with.
+func WithClientAllocator(pool memory.Allocator) ClientOption {
+	return func(c *Client) {
+		c.pool = pool
+	}
+}
+
+func NewClient(conn grpc.ClientConnInterface, options ...ClientOption) *Client {
+	c := &Client{
+		client: queryv1alpha1.NewQueryServiceClient(conn),
+		pool:   memory.NewGoAllocator(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Query executes the plan on the server and passes the records of its
+// result to the callback. The records are released once the callback
+// returns.
+func (c *Client) Query(ctx context.Context, plan *logicalplan.LogicalPlan, callback func(r arrow.Record) error) error {
+	node, err := logicalplan.ToProto(plan)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.Query(ctx, &queryv1alpha1.QueryRequest{Plan: node})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := c.record(res.GetRecord(), callback); err != nil {
+			return err
+		}
+	}
+}
+
+// record passes the records of the IPC stream to the callback.
+func (c *Client) record(data []byte, callback func(r arrow.Record) error) error {
+	reader, err := ipc.NewReader(bytes.NewReader(data), ipc.WithAllocator(c.pool))
+	if err != nil {
+		return err
+	}
+	defer reader.Release()
+
+	for reader.Next() {
+		if err := callback(reader.Record()); err != nil {
+			return err
+		}
+	}
+	return reader.Err()
+}
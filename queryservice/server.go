@@ -0,0 +1,89 @@
+// Package queryservice serves frostdb queries over gRPC, see the QueryService
+// of frostdb/query/v1alpha1/query.proto. Queries are protobuf logical plans,
+// see logicalplan.ToProto, and the records of their results are streamed
+// back as Arrow IPC streams.
+//
+//	s := grpc.NewServer()
+//	queryv1alpha1.RegisterQueryServiceServer(s, queryservice.NewServer(engine))
+//
+// A Client queries the server:
+//
+//	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	if err != nil {
+//		return err
+//	}
+//	err = queryservice.NewClient(conn).Query(ctx, plan, func(r arrow.Record) error {
+//		...
+//	})
+package queryservice
+
+import (
+	"bytes"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	queryv1alpha1 "github.com/polarsignals/frostdb/gen/proto/go/frostdb/query/v1alpha1"
+	"github.com/polarsignals/frostdb/query"
+)
+
+// Server executes the logical plans of queries on the tables of an engine
+// and streams their results.
+type Server struct {
+	queryv1alpha1.UnimplementedQueryServiceServer
+
+	engine *query.LocalEngine
+	pool   memory.Allocator
+}
+
+type Option func(*Server)
+
+// WithAllocator sets the allocator the records are serialized with.
+func WithAllocator(pool memory.Allocator) Option {
+	return func(s *Server) {
+		s.pool = pool
+	}
+}
+
+func NewServer(engine *query.LocalEngine, options ...Option) *Server {
+	s := &Server{
+		engine: engine,
+		pool:   memory.NewGoAllocator(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Query executes the plan of the request, the records of its result are
+// sent as they are produced.
+func (s *Server) Query(req *queryv1alpha1.QueryRequest, stream queryv1alpha1.QueryService_QueryServer) error {
+	q, err := s.engine.PrepareProto(req.GetPlan())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "prepare query: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = q.Execute(stream.Context(), nil, func(r arrow.Record) error {
+		buf.Reset()
+		w := ipc.NewWriter(&buf, ipc.WithSchema(r.Schema()), ipc.WithAllocator(s.pool))
+		if err := w.Write(r); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		return stream.Send(&queryv1alpha1.QueryResponse{Record: buf.Bytes()})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "execute query: %v", err)
+	}
+	return nil
+}
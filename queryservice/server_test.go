@@ -0,0 +1,93 @@
+package queryservice
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	queryv1alpha1 "github.com/polarsignals/frostdb/gen/proto/go/frostdb/query/v1alpha1"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// newTestConn serves the test samples of the table "test" and returns a
+// connection to the server.
+func newTestConn(t *testing.T) (*grpc.ClientConn, *frostdb.DB) {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+	s := grpc.NewServer()
+	queryv1alpha1.RegisterQueryServiceServer(s, NewServer(engine))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn, db
+}
+
+func TestQuery(t *testing.T) {
+	conn, db := newTestConn(t)
+
+	plan, err := (&logicalplan.Builder{}).
+		Scan(db.TableProvider(), "test").
+		Aggregate(logicalplan.Sum(logicalplan.Col("value")), logicalplan.Col("labels.namespace")).
+		Build()
+	require.NoError(t, err)
+
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	sums := map[string]int64{}
+	err = NewClient(conn, WithClientAllocator(pool)).Query(context.Background(), plan, func(r arrow.Record) error {
+		namespaces := r.Column(r.Schema().FieldIndices("labels.namespace")[0]).(*array.Binary)
+		values := r.Column(r.Schema().FieldIndices("sum(value)")[0]).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			sums[string(namespaces.Value(i))] = values.Value(i)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	// Samples without a namespace are grouped as null.
+	require.Equal(t, map[string]int64{"": 5, "default": 6}, sums)
+}
+
+func TestQueryInvalidPlan(t *testing.T) {
+	conn, _ := newTestConn(t)
+
+	stream, err := queryv1alpha1.NewQueryServiceClient(conn).Query(context.Background(), &queryv1alpha1.QueryRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,146 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestSample(t *testing.T) {
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	// Each insert results in its own row group, half of the rows are of
+	// the api job.
+	for i := 0; i < 10; i++ {
+		samples := dynparquet.Samples{}
+		for j := 0; j < 100; j++ {
+			job := "api"
+			if j%2 == 1 {
+				job = "web"
+			}
+			samples = append(samples, dynparquet.Sample{
+				Labels: []dynparquet.Label{
+					{Name: "job", Value: job},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: int64(i*100 + j),
+				Value:     int64(i*100 + j),
+			})
+		}
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	sample := func(fraction float64) []string {
+		jobs := []string{}
+		err := engine.ScanTable("test").
+			Filter(logicalplan.Col("labels.job").Eq(logicalplan.Literal("api"))).
+			Sample(fraction).
+			Project(logicalplan.Col("labels.job")).
+			Execute(context.Background(), func(r arrow.Record) error {
+				col := r.Column(0).(*array.Binary)
+				for i := 0; i < col.Len(); i++ {
+					jobs = append(jobs, string(col.Value(i)))
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		return jobs
+	}
+
+	require.Len(t, sample(0), 0)
+	require.Len(t, sample(1), 500)
+
+	// The sampled rows are rows that match the filter.
+	jobs := sample(0.5)
+	require.Greater(t, len(jobs), 150)
+	require.Less(t, len(jobs), 350)
+	for _, job := range jobs {
+		require.Equal(t, "api", job)
+	}
+}
+
+func TestSampledIterator(t *testing.T) {
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.Samples{}
+	for i := 0; i < 5000; i++ {
+		samples = append(samples, dynparquet.Sample{
+			Labels: []dynparquet.Label{
+				{Name: "job", Value: "api"},
+			},
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: int64(i),
+			Value:     int64(i),
+		})
+	}
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	// The sampled rows are far enough apart to be read from separate ranges
+	// of the row group, so only their pages are read.
+	values := []int64{}
+	err = table.View(func(tx uint64) error {
+		return table.SampledIterator(
+			context.Background(),
+			tx,
+			memory.NewGoAllocator(),
+			nil,
+			nil,
+			nil,
+			nil,
+			func(numRows int) []uint32 {
+				require.Equal(t, 5000, numRows)
+				return []uint32{3, 4, 2500, 4998}
+			},
+			func(r arrow.Record) error {
+				col := r.Column(r.Schema().FieldIndices("value")[0]).(*array.Int64)
+				values = append(values, col.Int64Values()...)
+				return nil
+			},
+		)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 4, 2500, 4998}, values)
+}
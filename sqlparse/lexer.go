@@ -0,0 +1,157 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenIdent is an identifier or a keyword.
+	tokenIdent
+	// tokenQuotedIdent is an identifier in double quotes, which is never a
+	// keyword.
+	tokenQuotedIdent
+	tokenNumber
+	tokenString
+	tokenPlaceholder
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	// text is the text of the token, without the quotes of strings and
+	// quoted identifiers, and without the $ of placeholders.
+	text string
+	pos  int
+}
+
+// is returns whether the token is the keyword or symbol, keywords are case
+// insensitive.
+func (t token) is(text string) bool {
+	switch t.kind {
+	case tokenIdent:
+		return strings.EqualFold(t.text, text)
+	case tokenSymbol:
+		return t.text == text
+	default:
+		return false
+	}
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("'%s'", t.text)
+	case tokenQuotedIdent:
+		return fmt.Sprintf("%q", t.text)
+	case tokenPlaceholder:
+		return "$" + t.text
+	default:
+		return t.text
+	}
+}
+
+// symbols are the symbols of the language, longer symbols first.
+var symbols = []string{"<=", ">=", "<>", "!=", "=~", "!~", "=", "<", ">", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", ";"}
+
+// lex splits the query into its tokens, the last of which is tokenEOF.
+func lex(query string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(query); {
+		c := rune(query[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			// Comments run until the end of the line.
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case isIdentStart(c):
+			start := i
+			for i < len(query) && isIdentPart(rune(query[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: query[start:i], pos: start})
+		case unicode.IsDigit(c) || c == '.' && i+1 < len(query) && unicode.IsDigit(rune(query[i+1])):
+			start := i
+			for i < len(query) && (unicode.IsDigit(rune(query[i])) || query[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: query[start:i], pos: start})
+		case c == '\'' || c == '"':
+			text, end, err := lexQuoted(query, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := tokenString
+			if c == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: i})
+			i = end
+		case c == '$':
+			start := i
+			i++
+			for i < len(query) && isIdentPart(rune(query[i])) {
+				i++
+			}
+			if i == start+1 {
+				return nil, fmt.Errorf("placeholder without a name at position %d", start)
+			}
+			tokens = append(tokens, token{kind: tokenPlaceholder, text: query[start+1 : i], pos: start})
+		default:
+			symbol := ""
+			for _, s := range symbols {
+				if strings.HasPrefix(query[i:], s) {
+					symbol = s
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, pos: i})
+			i += len(symbol)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(query)}), nil
+}
+
+// lexQuoted returns the text of the string or quoted identifier that starts
+// at the position, in which doubled quotes are escaped quotes, and the
+// position after it.
+func lexQuoted(query string, start int) (string, int, error) {
+	quote := query[start]
+	var text strings.Builder
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			text.WriteByte(query[i])
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			text.WriteByte(quote)
+			i++
+			continue
+		}
+		return text.String(), i + 1, nil
+	}
+	return "", 0, fmt.Errorf("unterminated quote at position %d", start)
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || unicode.IsLetter(c)
+}
+
+// isIdentPart returns whether the character can be part of an identifier.
+// Dots are, so the columns of dynamic columns can be named like
+// labels.job.
+func isIdentPart(c rune) bool {
+	return c == '_' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
@@ -0,0 +1,458 @@
+// Package sqlparse parses a subset of SQL into logical plans, so that ad-hoc
+// queries can be written without the logicalplan builder.
+//
+// The supported statement is
+//
+//	SELECT [DISTINCT] * | expr [AS alias], ...
+//	FROM table
+//	[WHERE expr]
+//	[GROUP BY expr, ...]
+//	[HAVING expr]
+//	[ORDER BY expr [ASC | DESC], ...]
+//	[LIMIT count [OFFSET count]]
+//
+// Identifiers refer to columns, and may contain dots to refer to concrete
+// columns of dynamic columns, which can also be written as labels['job'].
+// Identifiers in double quotes are never keywords. Literals are strings in
+// single quotes, integers, floats, TRUE, FALSE and NULL, and placeholders
+// are written as $name. The supported operators, from lowest to highest
+// precedence, are:
+//
+//	OR
+//	AND
+//	NOT
+//	= != <> < <= > >= =~ !~ LIKE NOT LIKE
+//	+ -
+//	* / %
+//
+// The aggregation functions are sum, count, min, max and avg.
+package sqlparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// Parse parses the query into a logical plan that scans the tables of the
+// provider.
+func Parse(provider logicalplan.TableProvider, query string) (*logicalplan.LogicalPlan, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	stmt := p.parseSelect()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return stmt.plan(provider)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	err    error
+}
+
+func (p *parser) tok() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() {
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if p.err == nil {
+		p.err = fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.tok().pos)
+	}
+	return p.err
+}
+
+// accept consumes the keyword or symbol if it is next in the input.
+func (p *parser) accept(text string) bool {
+	if p.err != nil || !p.tok().is(text) {
+		return false
+	}
+	p.next()
+	return true
+}
+
+// expect consumes the keyword or symbol, which must be next in the input.
+func (p *parser) expect(text string) {
+	if p.err == nil && !p.accept(text) {
+		p.errorf("expected %s, got %s", text, p.tok())
+	}
+}
+
+// keywords are the keywords that can't be used as unquoted column names.
+var keywords = map[string]bool{
+	"SELECT": true, "DISTINCT": true, "FROM": true, "WHERE": true,
+	"GROUP": true, "BY": true, "HAVING": true, "ORDER": true,
+	"ASC": true, "DESC": true, "LIMIT": true, "OFFSET": true,
+	"AS": true, "AND": true, "OR": true, "NOT": true, "LIKE": true,
+	"TRUE": true, "FALSE": true, "NULL": true,
+}
+
+func isKeyword(t token) bool {
+	return t.kind == tokenIdent && keywords[strings.ToUpper(t.text)]
+}
+
+// parseName parses a table name or an alias.
+func (p *parser) parseName() string {
+	t := p.tok()
+	if t.kind != tokenQuotedIdent && (t.kind != tokenIdent || isKeyword(t)) {
+		p.errorf("expected name, got %s", t)
+		return ""
+	}
+	p.next()
+	return t.text
+}
+
+func (p *parser) parseCount() int64 {
+	t := p.tok()
+	if t.kind != tokenNumber {
+		p.errorf("expected count, got %s", t)
+		return 0
+	}
+	v, err := strconv.ParseInt(t.text, 10, 64)
+	if err != nil {
+		p.errorf("invalid count %s", t)
+		return 0
+	}
+	p.next()
+	return v
+}
+
+func (p *parser) parseSelect() *selectStmt {
+	stmt := &selectStmt{}
+	p.expect("SELECT")
+	stmt.distinct = p.accept("DISTINCT")
+	if p.accept("*") {
+		stmt.star = true
+	} else {
+		for {
+			item := selectItem{expr: p.parseOr()}
+			if p.accept("AS") {
+				item.alias = p.parseName()
+			}
+			stmt.items = append(stmt.items, item)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	p.expect("FROM")
+	stmt.table = p.parseName()
+
+	if p.accept("WHERE") {
+		stmt.where = p.parseOr()
+	}
+	if p.accept("GROUP") {
+		p.expect("BY")
+		stmt.groupBy = p.parseExprs()
+	}
+	if p.accept("HAVING") {
+		stmt.having = p.parseOr()
+	}
+	if p.accept("ORDER") {
+		p.expect("BY")
+		for {
+			item := orderItem{expr: p.parseOr()}
+			if p.accept("DESC") {
+				item.descending = true
+			} else {
+				p.accept("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, item)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	if p.accept("LIMIT") {
+		limit := p.parseCount()
+		stmt.limit = &limit
+		if p.accept("OFFSET") {
+			offset := p.parseCount()
+			stmt.offset = &offset
+		}
+	}
+
+	p.accept(";")
+	if p.err == nil && p.tok().kind != tokenEOF {
+		p.errorf("unexpected %s", p.tok())
+	}
+	return stmt
+}
+
+func (p *parser) parseExprs() []logicalplan.Expr {
+	exprs := []logicalplan.Expr{p.parseOr()}
+	for p.accept(",") {
+		exprs = append(exprs, p.parseOr())
+	}
+	return exprs
+}
+
+func (p *parser) parseOr() logicalplan.Expr {
+	exprs := []logicalplan.Expr{p.parseAnd()}
+	for p.accept("OR") {
+		exprs = append(exprs, p.parseAnd())
+	}
+	return logicalplan.Or(exprs...)
+}
+
+func (p *parser) parseAnd() logicalplan.Expr {
+	exprs := []logicalplan.Expr{p.parseNot()}
+	for p.accept("AND") {
+		exprs = append(exprs, p.parseNot())
+	}
+	return logicalplan.And(exprs...)
+}
+
+func (p *parser) parseNot() logicalplan.Expr {
+	if p.accept("NOT") {
+		return logicalplan.Not(p.parseNot())
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = []struct {
+	token string
+	op    logicalplan.Op
+}{
+	{"=", logicalplan.OpEq},
+	{"!=", logicalplan.OpNotEq},
+	{"<>", logicalplan.OpNotEq},
+	{"=~", logicalplan.OpRegexMatch},
+	{"!~", logicalplan.OpRegexNotMatch},
+	{"<", logicalplan.OpLt},
+	{"<=", logicalplan.OpLtEq},
+	{">", logicalplan.OpGt},
+	{">=", logicalplan.OpGtEq},
+}
+
+func (p *parser) parseComparison() logicalplan.Expr {
+	left := p.parseAdditive()
+	if p.err != nil {
+		return nil
+	}
+
+	for _, c := range comparisonOps {
+		if p.accept(c.token) {
+			return &logicalplan.BinaryExpr{
+				Left:  left,
+				Op:    c.op,
+				Right: p.parseAdditive(),
+			}
+		}
+	}
+
+	op := logicalplan.OpRegexMatch
+	if p.tok().is("NOT") && p.tokens[p.pos+1].is("LIKE") {
+		p.next()
+		op = logicalplan.OpRegexNotMatch
+	}
+	if p.accept("LIKE") {
+		t := p.tok()
+		if t.kind != tokenString {
+			p.errorf("expected pattern, got %s", t)
+			return nil
+		}
+		p.next()
+		return &logicalplan.BinaryExpr{
+			Left:  left,
+			Op:    op,
+			Right: logicalplan.Literal(likeRegexp(t.text)),
+		}
+	}
+
+	return left
+}
+
+// likeRegexp returns the regular expression that matches the same strings as
+// the LIKE pattern.
+func likeRegexp(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+func (p *parser) parseAdditive() logicalplan.Expr {
+	left := p.parseMultiplicative()
+	for p.err == nil {
+		var op logicalplan.Op
+		switch {
+		case p.accept("+"):
+			op = logicalplan.OpAdd
+		case p.accept("-"):
+			op = logicalplan.OpSub
+		default:
+			return left
+		}
+		left = &logicalplan.BinaryExpr{Left: left, Op: op, Right: p.parseMultiplicative()}
+	}
+	return left
+}
+
+func (p *parser) parseMultiplicative() logicalplan.Expr {
+	left := p.parseOperand()
+	for p.err == nil {
+		var op logicalplan.Op
+		switch {
+		case p.accept("*"):
+			op = logicalplan.OpMul
+		case p.accept("/"):
+			op = logicalplan.OpDiv
+		case p.accept("%"):
+			op = logicalplan.OpMod
+		default:
+			return left
+		}
+		left = &logicalplan.BinaryExpr{Left: left, Op: op, Right: p.parseOperand()}
+	}
+	return left
+}
+
+// aggregations are the aggregation functions by their names.
+var aggregations = map[string]func(logicalplan.Expr) *logicalplan.AggregationFunction{
+	"sum":   logicalplan.Sum,
+	"count": logicalplan.Count,
+	"min":   logicalplan.Min,
+	"max":   logicalplan.Max,
+	"avg":   logicalplan.Avg,
+}
+
+func (p *parser) parseOperand() logicalplan.Expr {
+	if p.err != nil {
+		return nil
+	}
+
+	t := p.tok()
+	switch t.kind {
+	case tokenNumber:
+		return p.parseNumber(t.text)
+	case tokenString:
+		p.next()
+		return logicalplan.Literal(t.text)
+	case tokenPlaceholder:
+		p.next()
+		return logicalplan.Placeholder(t.text)
+	case tokenQuotedIdent:
+		p.next()
+		return p.parseColumn(t.text)
+	case tokenIdent:
+		switch {
+		case t.is("TRUE"):
+			p.next()
+			return logicalplan.Literal(true)
+		case t.is("FALSE"):
+			p.next()
+			return logicalplan.Literal(false)
+		case t.is("NULL"):
+			p.next()
+			return logicalplan.Literal(nil)
+		case isKeyword(t):
+			p.errorf("unexpected %s", t)
+			return nil
+		}
+		p.next()
+		if p.accept("(") {
+			return p.parseCall(t)
+		}
+		return p.parseColumn(t.text)
+	}
+
+	switch {
+	case p.accept("("):
+		expr := p.parseOr()
+		p.expect(")")
+		return expr
+	case p.accept("-"):
+		if p.tok().kind != tokenNumber {
+			p.errorf("expected number after -, got %s", p.tok())
+			return nil
+		}
+		return p.parseNumber("-" + p.tok().text)
+	default:
+		p.errorf("unexpected %s", t)
+		return nil
+	}
+}
+
+// parseColumn parses a column whose name has been consumed, which may be
+// followed by the name of a concrete column of a dynamic column in brackets.
+func (p *parser) parseColumn(name string) logicalplan.Expr {
+	if !p.accept("[") {
+		return logicalplan.Col(name)
+	}
+	t := p.tok()
+	if t.kind != tokenString {
+		p.errorf("expected column name, got %s", t)
+		return nil
+	}
+	p.next()
+	p.expect("]")
+	return logicalplan.Col(name + "." + t.text)
+}
+
+// parseCall parses the arguments of the function whose name and opening
+// parenthesis have been consumed.
+func (p *parser) parseCall(name token) logicalplan.Expr {
+	agg, ok := aggregations[strings.ToLower(name.text)]
+	if !ok {
+		p.err = fmt.Errorf("unknown function %s at position %d", name.text, name.pos)
+		return nil
+	}
+	if p.tok().is("*") {
+		p.errorf("%s(*) is not supported, aggregate a column instead", name.text)
+		return nil
+	}
+	expr := p.parseOr()
+	p.expect(")")
+	if p.err != nil {
+		return nil
+	}
+	return agg(expr)
+}
+
+// parseNumber parses an integer as int64 and anything else as float64.
+func (p *parser) parseNumber(text string) logicalplan.Expr {
+	p.next()
+
+	if !strings.Contains(text, ".") {
+		v, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			p.errorf("invalid integer %s: %v", text, err)
+			return nil
+		}
+		return logicalplan.Literal(v)
+	}
+
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.errorf("invalid float %s: %v", text, err)
+		return nil
+	}
+	return logicalplan.Literal(v)
+}
@@ -0,0 +1,184 @@
+package sqlparse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func newTestDB(t *testing.T) *frostdb.DB {
+	c, err := frostdb.New(log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", frostdb.NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+	return db
+}
+
+func TestParse(t *testing.T) {
+	provider := newTestDB(t).TableProvider()
+	scan := func() logicalplan.Builder {
+		return (&logicalplan.Builder{}).Scan(provider, "test")
+	}
+
+	for name, test := range map[string]struct {
+		query    string
+		expected logicalplan.Builder
+	}{
+		"all columns": {
+			query:    "SELECT * FROM test",
+			expected: scan(),
+		},
+		"filter": {
+			query: "select timestamp, value * 2 as doubled from test where labels['namespace'] = 'default' and not value < 1.5 or timestamp >= $start;",
+			expected: scan().
+				Filter(logicalplan.Or(
+					logicalplan.And(
+						logicalplan.Col("labels.namespace").Eq(logicalplan.Literal("default")),
+						logicalplan.Not(logicalplan.Col("value").Lt(logicalplan.Literal(1.5))),
+					),
+					logicalplan.Col("timestamp").GtEq(logicalplan.Placeholder("start")),
+				)).
+				Project(logicalplan.Col("timestamp"), logicalplan.Col("value").Mul(logicalplan.Literal(int64(2))).Alias("doubled")),
+		},
+		"like": {
+			query: `SELECT "labels.container" FROM test WHERE labels.container LIKE 'app_%.x' AND stacktrace NOT LIKE '%' AND labels.namespace =~ 'default|other'`,
+			expected: scan().
+				Filter(logicalplan.And(
+					logicalplan.Col("labels.container").RegexMatch(`^app..*\.x$`),
+					logicalplan.Col("stacktrace").RegexNotMatch("^.*$"),
+					logicalplan.Col("labels.namespace").RegexMatch("default|other"),
+				)).
+				Project(logicalplan.Col("labels.container")),
+		},
+		"aggregation": {
+			query: `
+				SELECT labels.namespace AS namespace, sum(value) AS total, count(value)
+				FROM test
+				GROUP BY namespace
+				HAVING sum(value) > 1 -- Groups of a single sample.
+				ORDER BY total DESC, namespace
+				LIMIT 10 OFFSET 1`,
+			expected: scan().
+				Aggregations(
+					[]logicalplan.Expr{logicalplan.Sum(logicalplan.Col("value")), logicalplan.Count(logicalplan.Col("value"))},
+					logicalplan.Col("labels.namespace"),
+				).
+				Having(logicalplan.Col("sum(value)").Gt(logicalplan.Literal(int64(1)))).
+				OrderBy(logicalplan.Desc(logicalplan.Col("sum(value)")), logicalplan.Asc(logicalplan.Col("labels.namespace"))).
+				Offset(1).
+				Limit(10).
+				Project(
+					logicalplan.Col("labels.namespace").Alias("namespace"),
+					logicalplan.Col("sum(value)").Alias("total"),
+					logicalplan.Col("count(value)"),
+				),
+		},
+		"aggregation without groups": {
+			query: "SELECT max(value) - min(value) FROM test",
+			expected: scan().
+				Aggregations([]logicalplan.Expr{logicalplan.Max(logicalplan.Col("value")), logicalplan.Min(logicalplan.Col("value"))}).
+				Project(logicalplan.Col("max(value)").Sub(logicalplan.Col("min(value)"))),
+		},
+		"distinct": {
+			query: "SELECT DISTINCT labels['namespace'] FROM test ORDER BY labels.namespace LIMIT 1",
+			expected: scan().
+				Distinct(logicalplan.Col("labels.namespace")).
+				OrderBy(logicalplan.Asc(logicalplan.Col("labels.namespace"))).
+				Limit(1),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			plan, err := Parse(provider, test.query)
+			require.NoError(t, err)
+			expected, err := test.expected.Build()
+			require.NoError(t, err)
+			require.Equal(t, expected.String(), plan.String())
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	provider := newTestDB(t).TableProvider()
+
+	for name, query := range map[string]string{
+		"missing from":            "SELECT value",
+		"trailing tokens":         "SELECT value FROM test test",
+		"unterminated string":     "SELECT value FROM test WHERE stacktrace = 'a",
+		"unexpected character":    "SELECT value FROM test WHERE value ? 1",
+		"keyword as column":       "SELECT from FROM test",
+		"unknown function":        "SELECT power(value) FROM test",
+		"count star":              "SELECT count(*) FROM test",
+		"like without pattern":    "SELECT value FROM test WHERE stacktrace LIKE value",
+		"aggregation in where":    "SELECT value FROM test WHERE sum(value) > 1",
+		"ungrouped column":        "SELECT timestamp, sum(value) FROM test GROUP BY labels.namespace",
+		"star in aggregation":     "SELECT * FROM test GROUP BY labels.namespace",
+		"having without grouping": "SELECT value FROM test HAVING value > 1",
+		"negative limit":          "SELECT value FROM test LIMIT -1",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(provider, query)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseExecute(t *testing.T) {
+	db := newTestDB(t)
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	plan, err := Parse(db.TableProvider(), `
+		SELECT labels['namespace'] AS namespace, sum(value) AS total
+		FROM test
+		WHERE value > 0
+		GROUP BY namespace
+		ORDER BY total`)
+	require.NoError(t, err)
+	node, err := logicalplan.ToProto(plan)
+	require.NoError(t, err)
+	q, err := engine.PrepareProto(node)
+	require.NoError(t, err)
+
+	totals := map[string]int64{}
+	require.NoError(t, q.Execute(context.Background(), nil, func(r arrow.Record) error {
+		require.Equal(t, "namespace", r.Schema().Field(0).Name)
+		require.Equal(t, "total", r.Schema().Field(1).Name)
+		namespaces := r.Column(0).(*array.Binary)
+		values := r.Column(1).(*array.Int64)
+		for i := 0; i < int(r.NumRows()); i++ {
+			totals[string(namespaces.Value(i))] = values.Value(i)
+		}
+		return nil
+	}))
+
+	// Samples without a namespace are grouped by a null namespace.
+	expected := map[string]int64{}
+	for _, s := range dynparquet.NewTestSamples() {
+		namespace := ""
+		for _, l := range s.Labels {
+			if l.Name == "namespace" {
+				namespace = l.Value
+			}
+		}
+		expected[namespace] += s.Value
+	}
+	require.Equal(t, expected, totals)
+}
@@ -0,0 +1,217 @@
+package sqlparse
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+type selectStmt struct {
+	distinct bool
+	// star is whether all columns are selected, in which case items is
+	// empty.
+	star    bool
+	items   []selectItem
+	table   string
+	where   logicalplan.Expr
+	groupBy []logicalplan.Expr
+	having  logicalplan.Expr
+	orderBy []orderItem
+	limit   *int64
+	offset  *int64
+}
+
+type selectItem struct {
+	expr  logicalplan.Expr
+	alias string
+}
+
+type orderItem struct {
+	expr       logicalplan.Expr
+	descending bool
+}
+
+// plan builds the logical plan of the statement. The rows are filtered,
+// aggregated, sorted, skipped and limited, and then projected to the
+// selected expressions.
+func (s *selectStmt) plan(provider logicalplan.TableProvider) (*logicalplan.LogicalPlan, error) {
+	b := (&logicalplan.Builder{}).Scan(provider, s.table)
+
+	if s.where != nil {
+		aggs := &aggregationRewriter{}
+		s.where.Rewrite(aggs)
+		if len(aggs.exprs) > 0 {
+			return nil, errors.New("aggregations are not allowed in WHERE")
+		}
+		b = b.Filter(s.where)
+	}
+
+	// GROUP BY and ORDER BY may refer to the aliases of selected
+	// expressions.
+	groupBy := make([]logicalplan.Expr, 0, len(s.groupBy))
+	for _, expr := range s.groupBy {
+		groupBy = append(groupBy, s.resolveAlias(expr))
+	}
+	orderBy := make([]orderItem, 0, len(s.orderBy))
+	for _, item := range s.orderBy {
+		orderBy = append(orderBy, orderItem{expr: s.resolveAlias(item.expr), descending: item.descending})
+	}
+
+	// Aggregations are computed in a single step, after which the selected
+	// expressions, HAVING and ORDER BY can only refer to the groups and the
+	// results of the aggregations.
+	aggs := &aggregationRewriter{groups: map[string]bool{}}
+	for _, expr := range groupBy {
+		aggs.groups[expr.Name()] = true
+	}
+	items := make([]selectItem, 0, len(s.items))
+	for _, item := range s.items {
+		items = append(items, selectItem{expr: item.expr.Rewrite(aggs), alias: item.alias})
+	}
+	var having logicalplan.Expr
+	if s.having != nil {
+		having = s.having.Rewrite(aggs)
+	}
+	for i, item := range orderBy {
+		orderBy[i].expr = item.expr.Rewrite(aggs)
+	}
+
+	switch {
+	case len(aggs.exprs) > 0 || len(groupBy) > 0:
+		if s.star {
+			return nil, errors.New("SELECT * is not allowed in aggregations")
+		}
+		if s.distinct {
+			return nil, errors.New("SELECT DISTINCT is not allowed in aggregations")
+		}
+		if err := aggs.checkGrouped(items, having, orderBy); err != nil {
+			return nil, err
+		}
+		b = b.Aggregations(aggs.exprs, groupBy...)
+		if having != nil {
+			b = b.Having(having)
+		}
+	case having != nil:
+		return nil, errors.New("HAVING is only allowed in aggregations")
+	default:
+		// Without aggregations the rewritten expressions are the same as
+		// the parsed ones.
+		items = s.items
+		if s.distinct {
+			if s.star {
+				return nil, errors.New("SELECT DISTINCT * is not supported")
+			}
+			b = b.Distinct(projection(items)...)
+			items = nil
+		}
+	}
+
+	if len(orderBy) > 0 {
+		exprs := make([]logicalplan.SortExpr, 0, len(orderBy))
+		for _, item := range orderBy {
+			if item.descending {
+				exprs = append(exprs, logicalplan.Desc(item.expr))
+			} else {
+				exprs = append(exprs, logicalplan.Asc(item.expr))
+			}
+		}
+		b = b.OrderBy(exprs...)
+	}
+	if s.offset != nil {
+		b = b.Offset(*s.offset)
+	}
+	if s.limit != nil {
+		b = b.Limit(*s.limit)
+	}
+	if len(items) > 0 {
+		b = b.Project(projection(items)...)
+	}
+
+	return b.Build()
+}
+
+// resolveAlias returns the selected expression the column refers to by its
+// alias, or the expression itself.
+func (s *selectStmt) resolveAlias(expr logicalplan.Expr) logicalplan.Expr {
+	col, ok := expr.(*logicalplan.Column)
+	if !ok {
+		return expr
+	}
+	for _, item := range s.items {
+		if item.alias != "" && item.alias == col.ColumnName {
+			return item.expr
+		}
+	}
+	return expr
+}
+
+func projection(items []selectItem) []logicalplan.Expr {
+	exprs := make([]logicalplan.Expr, 0, len(items))
+	for _, item := range items {
+		if item.alias == "" {
+			exprs = append(exprs, item.expr)
+			continue
+		}
+		exprs = append(exprs, &logicalplan.AliasExpr{Expr: item.expr, Alias: item.alias})
+	}
+	return exprs
+}
+
+// aggregationRewriter collects the aggregations of expressions, and replaces
+// them and the grouped expressions by the columns of their results.
+type aggregationRewriter struct {
+	groups map[string]bool
+	exprs  []logicalplan.Expr
+	names  map[string]bool
+}
+
+func (r *aggregationRewriter) PreRewrite(_ logicalplan.Expr) bool {
+	return true
+}
+
+func (r *aggregationRewriter) PostRewrite(expr logicalplan.Expr) logicalplan.Expr {
+	switch expr.(type) {
+	case *logicalplan.AggregationFunction:
+		// The same aggregation is computed once, however often it is
+		// referred to.
+		if r.names == nil {
+			r.names = map[string]bool{}
+		}
+		if !r.names[expr.Name()] {
+			r.names[expr.Name()] = true
+			r.exprs = append(r.exprs, expr)
+		}
+		return logicalplan.Col(expr.Name())
+	case *logicalplan.LiteralExpr, *logicalplan.PlaceholderExpr:
+		return expr
+	}
+	if r.groups[expr.Name()] {
+		return logicalplan.Col(expr.Name())
+	}
+	return expr
+}
+
+// checkGrouped returns an error if the rewritten expressions refer to
+// columns that are neither grouped nor the results of aggregations.
+func (r *aggregationRewriter) checkGrouped(items []selectItem, having logicalplan.Expr, orderBy []orderItem) error {
+	exprs := make([]logicalplan.Expr, 0, len(items)+len(orderBy)+1)
+	for _, item := range items {
+		exprs = append(exprs, item.expr)
+	}
+	if having != nil {
+		exprs = append(exprs, having)
+	}
+	for _, item := range orderBy {
+		exprs = append(exprs, item.expr)
+	}
+
+	for _, expr := range exprs {
+		for _, col := range expr.ColumnsUsedExprs() {
+			if !r.groups[col.Name()] && !r.names[col.Name()] {
+				return fmt.Errorf("column %s must be grouped or aggregated", col.Name())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package frostdb
+
+import (
+	"bytes"
+	"fmt"
+	"hash/maphash"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/google/btree"
+	"github.com/segmentio/parquet-go"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/query/sketch"
+)
+
+// columnDistincts estimates the number of distinct values of each column of
+// the rows inserted into a table block.
+type columnDistincts struct {
+	seed maphash.Seed
+
+	mtx      sync.Mutex
+	sketches map[string]*sketch.HyperLogLog
+}
+
+func newColumnDistincts() *columnDistincts {
+	return &columnDistincts{
+		seed:     maphash.MakeSeed(),
+		sketches: map[string]*sketch.HyperLogLog{},
+	}
+}
+
+// add adds the values of the columns of the buffer. The values of
+// dictionary encoded pages are only read from their dictionary.
+func (d *columnDistincts) add(buf *dynparquet.SerializedBuffer) error {
+	hashes := map[string][]uint64{}
+	var (
+		h     maphash.Hash
+		value []byte
+	)
+	h.SetSeed(d.seed)
+	for _, rowGroup := range buf.ParquetFile().RowGroups() {
+		columns := rowGroup.Schema().Columns()
+		for _, columnChunk := range rowGroup.ColumnChunks() {
+			name := strings.Join(columns[columnChunk.Column()], ".")
+			pages := columnChunk.Pages()
+			for {
+				p, err := pages.ReadPage()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					pages.Close()
+					return fmt.Errorf("read page: %w", err)
+				}
+				if dict := p.Dictionary(); dict != nil {
+					p = dict.Page()
+				}
+
+				values := make([]parquet.Value, p.NumValues())
+				if _, err := p.Values().ReadValues(values); err != nil && err != io.EOF {
+					pages.Close()
+					return fmt.Errorf("read values: %w", err)
+				}
+				for _, v := range values {
+					if v.IsNull() {
+						continue
+					}
+					value = v.AppendBytes(value[:0])
+					h.Reset()
+					_, _ = h.Write(value)
+					hashes[name] = append(hashes[name], h.Sum64())
+				}
+			}
+			pages.Close()
+		}
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for name, columnHashes := range hashes {
+		s, ok := d.sketches[name]
+		if !ok {
+			s = sketch.NewHyperLogLog()
+			d.sketches[name] = s
+		}
+		for _, hash := range columnHashes {
+			s.Insert(hash)
+		}
+	}
+	return nil
+}
+
+// estimates returns the estimated number of distinct values of each column.
+func (d *columnDistincts) estimates() map[string]int64 {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	estimates := make(map[string]int64, len(d.sketches))
+	for name, s := range d.sketches {
+		estimates[name] = int64(s.Estimate())
+	}
+	return estimates
+}
+
+// Statistics estimates the statistics of the rows of the table's blocks that
+// are held in memory. The number of rows only counts the rows visible to the
+// transaction, the distinct counts and the min and max values are estimated
+// for all rows inserted. It returns nil if the table isn't configured
+// WithStatistics. The statistics of the last transaction are cached, so
+// queries planned at the same transaction only estimate them once.
+func (t *Table) Statistics(tx uint64) (*logicalplan.TableStatistics, error) {
+	if !t.config.statistics {
+		return nil, nil
+	}
+
+	t.statsMtx.Lock()
+	defer t.statsMtx.Unlock()
+	if t.stats != nil && t.statsTx == tx {
+		return t.stats, nil
+	}
+
+	stats := t.statistics(tx)
+	t.stats, t.statsTx = stats, tx
+	return stats, nil
+}
+
+func (t *Table) statistics(tx uint64) *logicalplan.TableStatistics {
+	var (
+		numRows  int64
+		min, max = map[string]parquet.Value{}, map[string]parquet.Value{}
+		distinct = map[string]int64{}
+	)
+	memoryBlocks, _ := t.memoryBlocks()
+	for _, block := range memoryBlocks {
+		block.Index().Ascend(func(i btree.Item) bool {
+			g := i.(*Granule)
+			numRows += g.numRows(tx)
+
+			g.metadata.minlock.RLock()
+			for name, v := range g.metadata.min {
+				if cur, ok := min[name]; !ok || compareValues(*v, cur) < 0 {
+					min[name] = *v
+				}
+			}
+			g.metadata.minlock.RUnlock()
+
+			g.metadata.maxlock.RLock()
+			for name, v := range g.metadata.max {
+				if cur, ok := max[name]; !ok || compareValues(*v, cur) > 0 {
+					max[name] = *v
+				}
+			}
+			g.metadata.maxlock.RUnlock()
+			return true
+		})
+
+		// The distinct values of different blocks are counted separately,
+		// the sum is an upper bound of the distinct values of the table.
+		for name, n := range block.distincts.estimates() {
+			distinct[name] += n
+		}
+	}
+
+	stats := &logicalplan.TableStatistics{
+		NumRows: numRows,
+		Columns: map[string]logicalplan.ColumnStatistics{},
+	}
+	for name, n := range distinct {
+		if n > numRows {
+			n = numRows
+		}
+		colStats := stats.Columns[name]
+		colStats.DistinctCount = n
+		stats.Columns[name] = colStats
+	}
+	for name, v := range min {
+		colStats := stats.Columns[name]
+		colStats.Min = valueScalar(v)
+		stats.Columns[name] = colStats
+	}
+	for name, v := range max {
+		colStats := stats.Columns[name]
+		colStats.Max = valueScalar(v)
+		stats.Columns[name] = colStats
+	}
+	return stats
+}
+
+// numRows returns the number of rows of the granule that are visible to the
+// transaction.
+func (g *Granule) numRows(tx uint64) int64 {
+	rows := int64(0)
+	g.PartBuffersForTx(tx, func(buf *dynparquet.SerializedBuffer) bool {
+		rows += buf.NumRows()
+		return true
+	})
+	return rows
+}
+
+// compareValues compares two values of the same column.
+func compareValues(a, b parquet.Value) int {
+	switch a.Kind() {
+	case parquet.Boolean:
+		switch {
+		case a.Boolean() == b.Boolean():
+			return 0
+		case b.Boolean():
+			return -1
+		default:
+			return 1
+		}
+	case parquet.Int32:
+		switch {
+		case a.Int32() < b.Int32():
+			return -1
+		case a.Int32() > b.Int32():
+			return 1
+		default:
+			return 0
+		}
+	case parquet.Int64:
+		switch {
+		case a.Int64() < b.Int64():
+			return -1
+		case a.Int64() > b.Int64():
+			return 1
+		default:
+			return 0
+		}
+	case parquet.Float, parquet.Double:
+		switch {
+		case valueFloat(a) < valueFloat(b):
+			return -1
+		case valueFloat(a) > valueFloat(b):
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return bytes.Compare(a.ByteArray(), b.ByteArray())
+	}
+}
+
+func valueFloat(v parquet.Value) float64 {
+	if v.Kind() == parquet.Float {
+		return float64(v.Float())
+	}
+	return v.Double()
+}
+
+// valueScalar returns the value as a scalar like the literals that columns
+// are compared with, or nil if it has no such scalar.
+func valueScalar(v parquet.Value) scalar.Scalar {
+	switch v.Kind() {
+	case parquet.Boolean:
+		return scalar.NewBooleanScalar(v.Boolean())
+	case parquet.Int32:
+		return scalar.NewInt64Scalar(int64(v.Int32()))
+	case parquet.Int64:
+		return scalar.NewInt64Scalar(v.Int64())
+	case parquet.Float, parquet.Double:
+		return scalar.NewFloat64Scalar(valueFloat(v))
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return scalar.NewStringScalar(string(v.ByteArray()))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow/scalar"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestTableStatistics(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema(), WithStatistics()))
+	require.NoError(t, err)
+
+	samples := dynparquet.NewTestSamples()
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	var stats *logicalplan.TableStatistics
+	require.NoError(t, table.View(func(tx uint64) error {
+		var err error
+		stats, err = table.Statistics(tx)
+		return err
+	}))
+
+	require.Equal(t, int64(len(samples)), stats.NumRows)
+	require.Equal(t, logicalplan.ColumnStatistics{
+		DistinctCount: 2,
+		Min:           scalar.NewInt64Scalar(3),
+		Max:           scalar.NewInt64Scalar(5),
+	}, stats.Columns["value"])
+	require.Equal(t, int64(1), stats.Columns["timestamp"].DistinctCount)
+	require.Equal(t, int64(1), stats.Columns["labels.namespace"].DistinctCount)
+}
+
+func TestTableStatisticsDisabled(t *testing.T) {
+	c, err := New(newTestLogger(t), prometheus.NewRegistry())
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	buf, err := dynparquet.NewTestSamples().ToBuffer(table.Schema())
+	require.NoError(t, err)
+	_, err = table.InsertBuffer(context.Background(), buf)
+	require.NoError(t, err)
+	table.Sync()
+
+	require.Nil(t, table.ActiveBlock().distincts)
+	require.NoError(t, table.View(func(tx uint64) error {
+		stats, err := table.Statistics(tx)
+		require.Nil(t, stats)
+		return err
+	}))
+}
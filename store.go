@@ -3,6 +3,7 @@ package frostdb
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"path/filepath"
 
 	"github.com/go-kit/log"
@@ -12,6 +13,7 @@ import (
 	"github.com/thanos-io/objstore"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
 )
 
 // Persist uploads the block to the underlying bucket.
@@ -33,6 +35,7 @@ func (t *Table) IterateBucketBlocks(ctx context.Context, logger log.Logger, filt
 		return nil
 	}
 
+	schema := t.Schema()
 	n := 0
 	err := t.db.bucket.Iter(ctx, t.name, func(blockDir string) error {
 		blockUlid, err := ulid.Parse(filepath.Base(blockDir))
@@ -69,7 +72,12 @@ func (t *Table) IterateBucketBlocks(ctx context.Context, logger log.Logger, filt
 
 		n++
 		for i := 0; i < buf.NumRowGroups(); i++ {
-			rg := buf.DynamicRowGroup(i)
+			// Blocks written with earlier versions of the schema are
+			// migrated when they're read.
+			rg, err := t.config.migrations.Migrate(buf.DynamicRowGroup(i), buf.SchemaVersion(), schema.Version())
+			if err != nil {
+				return fmt.Errorf("migrate block %s: %w", blockUlid, err)
+			}
 			var mayContainUsefulData bool
 			mayContainUsefulData, err = filter.Eval(rg)
 			if err != nil {
@@ -104,5 +112,7 @@ func (b *BucketReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
 		err = rc.Close()
 	}()
 
-	return rc.Read(p)
+	n, err = rc.Read(p)
+	logicalplan.ScanStatsFromContext(b.ctx).AddBytesRead(int64(n))
+	return n, err
 }
@@ -24,11 +24,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
 	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/polarsignals/frostdb/dynparquet"
+	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
 	walpb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/wal/v1alpha1"
 	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/pqarrow/convert"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 )
 
@@ -49,15 +54,60 @@ func (e ErrCreateSchemaWriter) Error() string {
 }
 
 type TableConfig struct {
-	schema *dynparquet.Schema
+	// schema is the current schema of the table, it's replaced by a superset
+	// of it when columns are added to the table.
+	schema *atomic.UnsafePointer // *dynparquet.Schema
+	// migrations migrate data of earlier versions of the schema to the
+	// version of the schema when it's read.
+	migrations dynparquet.Migrations
+	// statistics enables estimating the statistics of the table's rows.
+	statistics bool
+}
+
+// TableOption configures a table.
+type TableOption func(*TableConfig)
+
+// WithMigrations sets the migrations of data written with earlier versions
+// of the table's schema. Data of earlier versions is migrated when it's
+// inserted and when it's read from storage, so that the table's persisted
+// data stays readable after the schema changes.
+func WithMigrations(migrations dynparquet.Migrations) TableOption {
+	return func(c *TableConfig) {
+		c.migrations = migrations
+	}
+}
+
+// WithStatistics enables estimating the statistics of the table's rows,
+// which the CostBasedOptimizer uses to plan the queries reading the table.
+// Estimating the number of distinct values of the columns hashes every value
+// inserted, so tables don't estimate their statistics by default.
+func WithStatistics() TableOption {
+	return func(c *TableConfig) {
+		c.statistics = true
+	}
 }
 
 func NewTableConfig(
 	schema *dynparquet.Schema,
+	options ...TableOption,
 ) *TableConfig {
-	return &TableConfig{
-		schema: schema,
+	c := &TableConfig{
+		schema: atomic.NewUnsafePointer(unsafe.Pointer(schema)),
+	}
+	for _, option := range options {
+		option(c)
 	}
+	return c
+}
+
+// Schema returns the current schema of the table. Operations that use the
+// schema more than once must use the same schema throughout.
+func (c *TableConfig) Schema() *dynparquet.Schema {
+	return (*dynparquet.Schema)(c.schema.Load())
+}
+
+func (c *TableConfig) setSchema(schema *dynparquet.Schema) {
+	c.schema.Store(unsafe.Pointer(schema))
 }
 
 type completedBlock struct {
@@ -80,6 +130,12 @@ type Table struct {
 	mtx    *sync.RWMutex
 	active *TableBlock
 
+	// stats caches the statistics last estimated, for the transaction
+	// statsTx.
+	statsMtx sync.Mutex
+	statsTx  uint64
+	stats    *logicalplan.TableStatistics
+
 	wal WAL
 }
 
@@ -102,6 +158,11 @@ type TableBlock struct {
 	size  *atomic.Int64
 	index *atomic.UnsafePointer // *btree.BTree
 
+	// distincts estimates the distinct values of the columns of the rows
+	// inserted into the block, it's nil if the table doesn't estimate its
+	// statistics.
+	distincts *columnDistincts
+
 	pendingWritersWg sync.WaitGroup
 
 	wg  *sync.WaitGroup
@@ -214,7 +275,7 @@ func (t *Table) newTableBlock(prevTx, tx uint64, id ulid.ULID) error {
 				NewTableBlock: &walpb.Entry_NewTableBlock{
 					TableName: t.name,
 					BlockId:   b,
-					Schema:    t.config.schema.Definition(),
+					Schema:    t.config.Schema().Definition(),
 				},
 			},
 		},
@@ -322,6 +383,63 @@ func (t *Table) RotateBlock(block *TableBlock) error {
 	return nil
 }
 
+// AddColumns adds the columns to the schema of the table. The rows inserted
+// before don't have values of the columns, so the columns must be nullable,
+// repeated or dynamic: they are read as nulls or empty lists for those rows.
+// Rows inserted afterwards may have values of the columns, using buffers of
+// the new schema of the table. The new schema is logged to the WAL.
+func (t *Table) AddColumns(columns ...*schemapb.Column) error {
+	// The table's lock is held so that new blocks log the new schema.
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	def := proto.Clone(t.config.Schema().Definition()).(*schemapb.Schema)
+	// Data of the previous version doesn't need migrations, it only lacks
+	// the added columns.
+	def.Version++
+	names := make(map[string]struct{}, len(def.Columns)+len(columns))
+	for _, col := range def.Columns {
+		names[col.Name] = struct{}{}
+	}
+	for _, col := range columns {
+		if _, ok := names[col.Name]; ok {
+			return fmt.Errorf("column %s already exists", col.Name)
+		}
+		names[col.Name] = struct{}{}
+		if col.StorageLayout == nil {
+			return fmt.Errorf("column %s doesn't have a storage layout", col.Name)
+		}
+		if !col.Dynamic && !col.StorageLayout.Nullable && !col.StorageLayout.Repeated {
+			return fmt.Errorf("added column %s must be nullable or repeated", col.Name)
+		}
+		def.Columns = append(def.Columns, col)
+	}
+
+	schema, err := dynparquet.SchemaFromDefinition(def)
+	if err != nil {
+		return fmt.Errorf("add columns: %w", err)
+	}
+
+	tx, _, commit := t.db.begin()
+	defer commit()
+
+	if err := t.wal.Log(tx, &walpb.Record{
+		Entry: &walpb.Entry{
+			EntryType: &walpb.Entry_TableSchema_{
+				TableSchema: &walpb.Entry_TableSchema{
+					TableName: t.name,
+					Schema:    def,
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("log table schema: %w", err)
+	}
+
+	t.config.setSchema(schema)
+	return nil
+}
+
 func (t *Table) ActiveBlock() *TableBlock {
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
@@ -338,7 +456,7 @@ func (t *Table) ActiveWriteBlock() (*TableBlock, func()) {
 }
 
 func (t *Table) Schema() *dynparquet.Schema {
-	return t.config.schema
+	return t.config.Schema()
 }
 
 func (t *Table) Sync() {
@@ -346,7 +464,7 @@ func (t *Table) Sync() {
 }
 
 func (t *Table) InsertBuffer(ctx context.Context, buf *dynparquet.Buffer) (uint64, error) {
-	b, err := t.config.schema.SerializeBuffer(buf) // TODO should we abort this function? If a large buffer is passed this could get long potentially...
+	b, err := t.config.Schema().SerializeBuffer(buf) // TODO should we abort this function? If a large buffer is passed this could get long potentially...
 	if err != nil {
 		return 0, fmt.Errorf("serialize buffer: %w", err)
 	}
@@ -412,6 +530,13 @@ func (t *Table) insert(ctx context.Context, buf []byte) (uint64, error) {
 		return tx, fmt.Errorf("deserialize buffer: %w", err)
 	}
 
+	// Buffers of earlier schemas of the table are migrated and don't have
+	// the columns that were added since.
+	serBuf, err = t.config.Schema().MigrateSerializedBuffer(serBuf, t.config.migrations)
+	if err != nil {
+		return tx, fmt.Errorf("migrate buffer: %w", err)
+	}
+
 	err = block.Insert(ctx, tx, serBuf)
 	if err != nil {
 		return tx, fmt.Errorf("insert buffer into block: %w", err)
@@ -436,11 +561,13 @@ func (t *Table) Iterator(
 	distinctColumns []logicalplan.Expr,
 	iterator func(r arrow.Record) error,
 ) error {
-	rowGroups, err := t.collectRowGroups(ctx, tx, filterExpr)
+	rowGroups, err := t.scanRowGroups(ctx, tx, filterExpr)
 	if err != nil {
 		return err
 	}
 
+	materializer := newLateMaterializer(pool, filterExpr, distinctColumns)
+
 	// Previously we sorted all row groups into a single row group here,
 	// but it turns out that none of the downstream uses actually rely on
 	// the sorting so it's not worth it in the general case. Physical plans
@@ -454,7 +581,7 @@ func (t *Table) Iterator(
 			if schema == nil {
 				schema, err = pqarrow.ParquetRowGroupToArrowSchema(
 					ctx,
-					t.config.schema,
+					t.config.Schema(),
 					rg,
 					physicalProjections,
 					projections,
@@ -467,7 +594,7 @@ func (t *Table) Iterator(
 			}
 
 			var record arrow.Record
-			record, err = pqarrow.ParquetRowGroupToArrowRecord(
+			record, err = materializer.record(
 				ctx,
 				pool,
 				rg,
@@ -478,6 +605,9 @@ func (t *Table) Iterator(
 			if err != nil {
 				return fmt.Errorf("failed to convert row group to arrow record: %v", err)
 			}
+			if record == nil {
+				continue
+			}
 			err = iterator(record)
 			record.Release()
 			if err != nil {
@@ -489,6 +619,289 @@ func (t *Table) Iterator(
 	return nil
 }
 
+// SampledIterator iterates over the sampled rows of all granules in the table
+// like Iterator. The sample function returns the sampled rows of each row
+// group, only the pages of a row group that contain sampled rows are
+// converted to records.
+func (t *Table) SampledIterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	schema *arrow.Schema,
+	physicalProjections []logicalplan.Expr,
+	projections []logicalplan.Expr,
+	filterExpr logicalplan.Expr,
+	sample func(numRows int) []uint32,
+	iterator func(r arrow.Record) error,
+) error {
+	rowGroups, err := t.scanRowGroups(ctx, tx, filterExpr)
+	if err != nil {
+		return err
+	}
+
+	for _, rg := range rowGroups {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			rows := sample(int(rg.NumRows()))
+			if len(rows) == 0 {
+				continue
+			}
+
+			if schema == nil {
+				schema, err = pqarrow.ParquetRowGroupToArrowSchema(
+					ctx,
+					t.config.Schema(),
+					rg,
+					physicalProjections,
+					projections,
+					filterExpr,
+					nil,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			sampledRG := rg
+			if len(rows) < int(rg.NumRows()) {
+				ranges := selectedRowRanges(rows)
+				sampledRG = dynparquet.NewRowRangesRowGroup(rg, ranges)
+				rows = rangeRows(ranges, rows)
+			}
+
+			record, err := pqarrow.ParquetRowGroupToArrowRecord(
+				ctx,
+				pool,
+				sampledRG,
+				schema,
+				filterExpr,
+				nil,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to convert row group to arrow record: %v", err)
+			}
+			if len(rows) < int(record.NumRows()) {
+				sampled, err := pqarrow.SelectRows(pool, record, rows)
+				record.Release()
+				if err != nil {
+					return err
+				}
+				record = sampled
+			}
+			err = iterator(record)
+			record.Release()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ConcurrentIterator iterates over all granules in the table like Iterator,
+// but converts the row groups concurrently. Each of the callbacks is called
+// from its own goroutine, so the order of the records isn't defined.
+func (t *Table) ConcurrentIterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	schema *arrow.Schema,
+	physicalProjections []logicalplan.Expr,
+	projections []logicalplan.Expr,
+	filterExpr logicalplan.Expr,
+	distinctColumns []logicalplan.Expr,
+	callbacks []func(r arrow.Record) error,
+) error {
+	rowGroups, err := t.scanRowGroups(ctx, tx, filterExpr)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	rgs := make(chan dynparquet.DynamicRowGroup)
+	g.Go(func() error {
+		defer close(rgs)
+		for _, rg := range rowGroups {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case rgs <- rg:
+			}
+		}
+		return nil
+	})
+
+	for _, callback := range callbacks {
+		callback := callback
+		g.Go(func() error {
+			materializer := newLateMaterializer(pool, filterExpr, distinctColumns)
+			for rg := range rgs {
+				rgSchema := schema
+				if rgSchema == nil {
+					var err error
+					rgSchema, err = pqarrow.ParquetRowGroupToArrowSchema(
+						ctx,
+						t.config.Schema(),
+						rg,
+						physicalProjections,
+						projections,
+						filterExpr,
+						distinctColumns,
+					)
+					if err != nil {
+						return err
+					}
+				}
+
+				record, err := materializer.record(
+					ctx,
+					pool,
+					rg,
+					rgSchema,
+					filterExpr,
+					distinctColumns,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to convert row group to arrow record: %v", err)
+				}
+				if record == nil {
+					continue
+				}
+				err = callback(record)
+				record.Release()
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// sortedIteratorBatchSize is the maximum number of rows of the records of
+// SortedIterator.
+const sortedIteratorBatchSize = 1024
+
+// SortedIterator iterates over the rows of the table in the order of the
+// table's sorting columns. The row groups are merged row by row, so unlike
+// Iterator the records don't correspond to row groups.
+func (t *Table) SortedIterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	physicalProjections []logicalplan.Expr,
+	filterExpr logicalplan.Expr,
+	iterator func(r arrow.Record) error,
+) error {
+	rowGroups, err := t.scanRowGroups(ctx, tx, filterExpr)
+	if err != nil {
+		return err
+	}
+	if len(rowGroups) == 0 {
+		return nil
+	}
+
+	// Rows are read row by row, which would decode the values of all
+	// columns, so the columns that aren't used are replaced with nulls.
+	schema := t.config.Schema()
+	projected := sortedProjection(schema, rowGroups, physicalProjections)
+	for i, rg := range rowGroups {
+		rowGroups[i] = dynparquet.ProjectRowGroup(rg, projected)
+	}
+
+	merged, err := schema.MergeDynamicRowGroups(rowGroups)
+	if err != nil {
+		return err
+	}
+
+	return pqarrow.ParquetRowGroupToArrowRecords(
+		ctx,
+		pool,
+		merged,
+		physicalProjections,
+		sortedIteratorBatchSize,
+		iterator,
+	)
+}
+
+// sortedProjection returns whether a column of the row groups is read by a
+// sorted scan with the physical projections. Sorting columns that aren't
+// projected are read as well if they precede a projected sorting column, since
+// the order of the rows by the projected column depends on them.
+func sortedProjection(
+	schema *dynparquet.Schema,
+	rowGroups []dynparquet.DynamicRowGroup,
+	physicalProjections []logicalplan.Expr,
+) func(column string) bool {
+	isProjected := func(column string) bool {
+		if len(physicalProjections) == 0 {
+			return true
+		}
+		for _, p := range physicalProjections {
+			if p.MatchColumn(column) {
+				return true
+			}
+		}
+		// Struct columns are read for the projections of their fields.
+		if def, ok := schema.ColumnByName(column); ok && !def.StorageLayout.Leaf() {
+			for _, field := range def.StorageLayout.Fields() {
+				for _, p := range physicalProjections {
+					if p.MatchColumn(column + "." + field.Name()) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
+	// sortIndex returns the index of the sorting column the column is a
+	// concrete column of.
+	sortingColumns := schema.SortingColumns()
+	sortIndex := func(column string) (int, bool) {
+		def, ok := schema.FindColumn(column)
+		if !ok {
+			return 0, false
+		}
+		for i, col := range sortingColumns {
+			if col.ColumnName() == def.Name {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	// Concrete columns of the same dynamic column are sorted by their names.
+	precedes := func(i int, column string, j int, other string) bool {
+		return i < j || (i == j && column < other)
+	}
+
+	lastIndex, last := -1, ""
+	for _, rg := range rowGroups {
+		for _, field := range rg.Schema().Fields() {
+			name := field.Name()
+			if !isProjected(name) {
+				continue
+			}
+			if i, ok := sortIndex(name); ok && precedes(lastIndex, last, i, name) {
+				lastIndex, last = i, name
+			}
+		}
+	}
+
+	return func(column string) bool {
+		if isProjected(column) {
+			return true
+		}
+		i, ok := sortIndex(column)
+		return ok && lastIndex >= 0 && !precedes(lastIndex, last, i, column)
+	}
+}
+
 // SchemaIterator iterates in order over all granules in the table and returns
 // all the schemas seen across the table.
 func (t *Table) SchemaIterator(
@@ -567,7 +980,7 @@ func (t *Table) ArrowSchema(
 		default:
 			schema, err := pqarrow.ParquetRowGroupToArrowSchema(
 				ctx,
-				t.config.schema,
+				t.config.Schema(),
 				rg,
 				physicalProjections,
 				projections,
@@ -616,6 +1029,9 @@ func newTableBlock(table *Table, prevTx, tx uint64, id ulid.ULID) (*TableBlock,
 		minTx:  tx,
 		prevTx: prevTx,
 	}
+	if table.config.statistics {
+		tb.distincts = newColumnDistincts()
+	}
 
 	g, err := NewGranule(tb.table.metrics.granulesCreated, tb.table.config, nil)
 	if err != nil {
@@ -643,6 +1059,12 @@ func (t *TableBlock) Insert(ctx context.Context, tx uint64, buf *dynparquet.Seri
 		return nil
 	}
 
+	if t.distincts != nil {
+		if err := t.distincts.add(buf); err != nil {
+			return fmt.Errorf("failed to estimate distinct values: %w", err)
+		}
+	}
+
 	rowsToInsertPerGranule, err := t.splitRowsByGranule(buf)
 	if err != nil {
 		return fmt.Errorf("failed to split rows by granule: %w", err)
@@ -711,7 +1133,10 @@ func (t *TableBlock) splitGranule(granule *Granule) {
 		return
 	}
 
-	merge, err := t.table.config.schema.MergeDynamicRowGroups(bufs)
+	// The schema is loaded after the parts, so that it has the columns of
+	// all of them.
+	schema := t.table.config.Schema()
+	merge, err := schema.MergeDynamicRowGroups(bufs)
 	if err != nil {
 		t.abort(granule)
 		level.Error(t.logger).Log("msg", "failed to merge dynamic row groups", "err", err)
@@ -720,23 +1145,25 @@ func (t *TableBlock) splitGranule(granule *Granule) {
 
 	b := bytes.NewBuffer(nil)
 	cols := merge.DynamicColumns()
-	w, err := t.table.config.schema.GetWriter(b, cols)
+	w, err := schema.GetWriter(b, cols)
 	if err != nil {
 		t.abort(granule)
 		level.Error(t.logger).Log("msg", "failed to create new schema writer", "err", err)
 		return
 	}
-	defer t.table.config.schema.PutWriter(w)
+	defer schema.PutWriter(w)
 
 	rowBuf := make([]parquet.Row, 1)
 	rows := merge.Rows()
 	n := 0
 	for {
-		_, err := rows.ReadRows(rowBuf)
-		if err == io.EOF {
+		// The last row may be read with io.EOF, such as the last row of
+		// repeated columns.
+		read, err := rows.ReadRows(rowBuf)
+		if err == io.EOF && read == 0 {
 			break
 		}
-		if err != nil {
+		if err != nil && err != io.EOF {
 			t.abort(granule)
 			level.Error(t.logger).Log("msg", "error reading rows", "err", err)
 			return
@@ -781,7 +1208,7 @@ func (t *TableBlock) splitGranule(granule *Granule) {
 		return
 	}
 
-	granules, err := g.split(tx, t.table.db.columnStore.granuleSize/t.table.db.columnStore.splitSize)
+	granules, err := g.split(tx, t.table.db.columnStore.granuleSize/t.table.db.columnStore.splitSize, schema)
 	if err != nil {
 		t.abort(granule)
 		level.Error(t.logger).Log("msg", "failed to split granule", "err", err)
@@ -866,17 +1293,42 @@ func (t *TableBlock) RowGroupIterator(
 	iterator func(rg dynparquet.DynamicRowGroup) bool,
 ) error {
 	index := t.Index()
+	// The row groups of the granules that are skipped are counted as pruned
+	// by scans whose row groups are counted.
+	counter, counting := filter.(*countingFilter)
 
-	var err error
+	var (
+		err       error
+		exhausted bool
+	)
 	index.Ascend(func(i btree.Item) bool {
 		g := i.(*Granule)
 
+		// Granules are iterated in sort order, so once a granule is past
+		// the range of the filter expr then so are all following granules.
+		if exhausted || granulesExhausted(t.table.config.Schema(), filterExpr, g) {
+			if !counting {
+				return false
+			}
+			exhausted = true
+			counter.prunedGranule(tx, g)
+			return true
+		}
+
 		// Check if the entire granule can be skipped due to the filter expr
 		if !filterGranule(t.logger, filterExpr, g) {
+			if counting {
+				counter.prunedGranule(tx, g)
+			}
 			return true
 		}
 
 		g.PartBuffersForTx(tx, func(buf *dynparquet.SerializedBuffer) bool {
+			// Stop iterating once the query is cancelled.
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+
 			f := buf.ParquetFile()
 			for i := range f.RowGroups() {
 				rg := buf.DynamicRowGroup(i)
@@ -894,7 +1346,7 @@ func (t *TableBlock) RowGroupIterator(
 			return true
 		})
 
-		return true
+		return err == nil
 	})
 
 	return err
@@ -911,26 +1363,27 @@ func (t *TableBlock) Index() *btree.BTree {
 }
 
 func (t *TableBlock) splitRowsByGranule(buf *dynparquet.SerializedBuffer) (map[*Granule]*dynparquet.SerializedBuffer, error) {
+	schema := t.table.config.Schema()
 	// Special case: if there is only one granule, insert parts into it until full.
 	index := t.Index()
 	if index.Len() == 1 {
 		b := bytes.NewBuffer(nil)
 
 		cols := buf.DynamicColumns()
-		w, err := t.table.config.schema.GetWriter(b, cols)
+		w, err := schema.GetWriter(b, cols)
 		if err != nil {
 			return nil, ErrCreateSchemaWriter{err}
 		}
-		defer t.table.config.schema.PutWriter(w)
+		defer schema.PutWriter(w)
 
 		rowBuf := make([]parquet.Row, 1)
 		rows := buf.Reader()
 		for {
-			_, err := rows.ReadRows(rowBuf)
-			if err == io.EOF {
+			n, err := rows.ReadRows(rowBuf)
+			if err == io.EOF && n == 0 {
 				break
 			}
-			if err != nil {
+			if err != nil && err != io.EOF {
 				return nil, ErrReadRow{err}
 			}
 			_, err = w.WriteRows(rowBuf)
@@ -958,7 +1411,7 @@ func (t *TableBlock) splitRowsByGranule(buf *dynparquet.SerializedBuffer) (map[*
 	bufByGranule := map[*Granule]*bytes.Buffer{}
 	defer func() {
 		for _, w := range writerByGranule {
-			t.table.config.schema.PutWriter(w)
+			schema.PutWriter(w)
 		}
 	}()
 
@@ -981,13 +1434,13 @@ func (t *TableBlock) splitRowsByGranule(buf *dynparquet.SerializedBuffer) (map[*
 
 		for {
 			least := g.Least()
-			isLess := t.table.config.schema.RowLessThan(row, least)
+			isLess := schema.RowLessThan(row, least)
 			if isLess {
 				if prev != nil {
 					w, ok := writerByGranule[prev]
 					if !ok {
 						b := bytes.NewBuffer(nil)
-						w, err = t.table.config.schema.GetWriter(b, buf.DynamicColumns())
+						w, err = schema.GetWriter(b, buf.DynamicColumns())
 						if err != nil {
 							ascendErr = ErrCreateSchemaWriter{err}
 							return false
@@ -1029,7 +1482,7 @@ func (t *TableBlock) splitRowsByGranule(buf *dynparquet.SerializedBuffer) (map[*
 		w, ok := writerByGranule[prev]
 		if !ok {
 			b := bytes.NewBuffer(nil)
-			w, err = t.table.config.schema.GetWriter(b, buf.DynamicColumns())
+			w, err = schema.GetWriter(b, buf.DynamicColumns())
 			if err != nil {
 				return nil, ErrCreateSchemaWriter{err}
 			}
@@ -1083,7 +1536,7 @@ func addPartToGranule(granules []*Granule, p *Part) error {
 
 	var prev *Granule
 	for _, g := range granules {
-		if g.tableConfig.schema.RowLessThan(row, g.Least()) {
+		if g.tableConfig.Schema().RowLessThan(row, g.Least()) {
 			if prev != nil {
 				if _, err := prev.addPart(p, row); err != nil {
 					return err
@@ -1128,28 +1581,29 @@ func (t *TableBlock) Serialize() ([]byte, error) {
 		return nil, err
 	}
 
-	merged, err := t.table.config.schema.MergeDynamicRowGroups(rowGroups)
+	schema := t.table.config.Schema()
+	merged, err := schema.MergeDynamicRowGroups(rowGroups)
 	if err != nil {
 		return nil, err
 	}
 
 	buf := bytes.NewBuffer(nil)
 	cols := merged.DynamicColumns()
-	w, err := t.table.config.schema.GetWriter(buf, cols)
+	w, err := schema.GetWriter(buf, cols)
 	if err != nil {
 		return nil, err
 	}
-	defer t.table.config.schema.PutWriter(w)
+	defer schema.PutWriter(w)
 
 	rows := merged.Rows()
 	n := 0
 	for {
 		rowsBuf := make([]parquet.Row, 1)
-		_, err := rows.ReadRows(rowsBuf)
-		if err == io.EOF {
+		read, err := rows.ReadRows(rowsBuf)
+		if err == io.EOF && read == 0 {
 			break
 		}
-		if err != nil {
+		if err != nil && err != io.EOF {
 			return nil, err
 		}
 		_, err = w.WriteRows(rowsBuf)
@@ -1175,37 +1629,52 @@ func filterGranule(logger log.Logger, filterExpr logicalplan.Expr, g *Granule) b
 	default: // unsupported filter
 		level.Info(logger).Log("msg", "unsupported filter")
 		return true
+	case *logicalplan.UnaryExpr:
+		// Granule statistics can't prove that a negated expression matches
+		// nothing.
+		return true
+	case *logicalplan.BetweenExpr:
+		return filterGranuleBetween(expr, g)
+	case *logicalplan.LiteralExpr:
+		b, ok := expr.Value.(*scalar.Boolean)
+		return !ok || !b.Valid || b.Value
 	case *logicalplan.BinaryExpr:
+		if expanded, ok := logicalplan.ExpandTupleComparison(expr); ok {
+			return filterGranule(logger, expanded, g)
+		}
+
+		switch expr.Op {
+		case logicalplan.OpAnd:
+			return filterGranule(logger, expr.Left, g) && filterGranule(logger, expr.Right, g)
+		case logicalplan.OpOr:
+			return filterGranule(logger, expr.Left, g) || filterGranule(logger, expr.Right, g)
+		}
+
+		if expr.Left.Computed() || expr.Right.Computed() || expr.Op.IsArithmetic() {
+			// Computed values have no granule statistics to compare against.
+			return true
+		}
+		if _, ok := expr.Left.(*logicalplan.RegexColumn); ok {
+			// The columns matched are only known per row group.
+			return true
+		}
+
 		var (
-			min, max   *parquet.Value
-			v          scalar.Scalar
-			leftresult bool
-			leftfound  bool
+			min, max  *parquet.Value
+			v         scalar.Scalar
+			leftfound bool
 		)
 		switch left := expr.Left.(type) {
-		case *logicalplan.BinaryExpr:
-			leftresult = filterGranule(logger, left, g)
 		case *logicalplan.Column:
 			min, max, leftfound = findColumnValues(left.ColumnsUsedExprs(), g)
 		case *logicalplan.LiteralExpr:
-			switch left.Value.(type) {
-			case *scalar.Int64:
-				v = left.Value.(*scalar.Int64)
-			case *scalar.String:
-				v = left.Value.(*scalar.String)
+			switch lv := granuleScalar(g.tableConfig.Schema(), expr.Right, left.Value).(type) {
+			case *scalar.Int64, *scalar.Float64, *scalar.String:
+				v = lv
 			}
 		}
 
 		switch right := expr.Right.(type) {
-		case *logicalplan.BinaryExpr:
-			switch expr.Op {
-			case logicalplan.OpAnd:
-				if !leftresult {
-					return false
-				}
-				rightresult := filterGranule(logger, right, g)
-				return leftresult && rightresult
-			}
 		case *logicalplan.Column:
 			var found bool
 			min, max, found = findColumnValues(right.ColumnsUsedExprs(), g)
@@ -1215,14 +1684,19 @@ func filterGranule(logger log.Logger, filterExpr logicalplan.Expr, g *Granule) b
 			}
 
 			switch val := v.(type) {
-			case *scalar.Int64:
+			case *scalar.Int64, *scalar.Float64:
+				minCmp, minOk := compareGranuleValue(min, val)
+				maxCmp, maxOk := compareGranuleValue(max, val)
+				if !minOk || !maxOk {
+					return true
+				}
 				switch expr.Op {
 				case logicalplan.OpLt:
-					return val.Value < max.Int64()
+					return maxCmp > 0
 				case logicalplan.OpGt:
-					return val.Value > min.Int64()
+					return minCmp < 0
 				case logicalplan.OpEq:
-					return val.Value >= min.Int64() && val.Value <= max.Int64()
+					return minCmp <= 0 && maxCmp >= 0
 				}
 			case *scalar.String:
 				s := string(val.Value.Bytes())
@@ -1240,18 +1714,25 @@ func filterGranule(logger log.Logger, filterExpr logicalplan.Expr, g *Granule) b
 			}
 
 		case *logicalplan.LiteralExpr:
-			switch v := right.Value.(type) {
-			case *scalar.Int64:
+			switch v := granuleScalar(g.tableConfig.Schema(), expr.Left, right.Value).(type) {
+			case *scalar.Int64, *scalar.Float64, *scalar.Boolean:
 				if !leftfound {
-					return false
+					// The values of the column are all null, which
+					// are only matched by inequalities.
+					return expr.Op == logicalplan.OpNotEq
+				}
+				minCmp, minOk := compareGranuleValue(min, v)
+				maxCmp, maxOk := compareGranuleValue(max, v)
+				if !minOk || !maxOk {
+					return true
 				}
 				switch expr.Op {
 				case logicalplan.OpLt:
-					return min.Int64() < v.Value
+					return minCmp < 0
 				case logicalplan.OpGt:
-					return max.Int64() > v.Value
+					return maxCmp > 0
 				case logicalplan.OpEq:
-					return v.Value >= min.Int64() && v.Value <= max.Int64()
+					return minCmp <= 0 && maxCmp >= 0
 				}
 			case *scalar.String:
 				s := string(v.Value.Bytes())
@@ -1264,11 +1745,17 @@ func filterGranule(logger log.Logger, filterExpr logicalplan.Expr, g *Granule) b
 						return true
 					case expr.Op == logicalplan.OpNotEq && len(s) != 0:
 						return true
-					case expr.Op == logicalplan.OpRegexNotMatch || expr.Op == logicalplan.OpRegexMatch:
-						// todo: run the regex on empty string to see if it matches
-						// this would allow to fully reject a granule faster.
-						// However it requires a bigger refactoring of the code to compile the regexp only once.
+					case (expr.Op == logicalplan.OpStartsWith || expr.Op == logicalplan.OpEndsWith || expr.Op == logicalplan.OpContains) && len(s) == 0:
 						return true
+					case expr.Op == logicalplan.OpRegexNotMatch || expr.Op == logicalplan.OpRegexMatch:
+						// A column that doesn't exist is the empty string,
+						// so the granule only matches if the regex matches
+						// the empty string.
+						re, err := logicalplan.CompileRegexp(string(v.Data()))
+						if err != nil {
+							return true
+						}
+						return re.MatchString("") == (expr.Op == logicalplan.OpRegexMatch)
 					default:
 						return false
 					}
@@ -1280,6 +1767,10 @@ func filterGranule(logger log.Logger, filterExpr logicalplan.Expr, g *Granule) b
 					return max.String() > s
 				case logicalplan.OpEq:
 					return s >= min.String() && s <= max.String()
+				case logicalplan.OpStartsWith:
+					// All values between min and max share a prefix between
+					// the prefixes of min and max of the same length.
+					return truncateString(min.String(), len(s)) <= s && s <= truncateString(max.String(), len(s))
 				}
 			}
 		}
@@ -1288,6 +1779,249 @@ func filterGranule(logger log.Logger, filterExpr logicalplan.Expr, g *Granule) b
 	return true
 }
 
+// granulesExhausted returns true if neither the granule nor any granule
+// after it can contain rows matching the filter expr. That is the case for
+// comparisons of the first sorting column, or tuples starting with it, once
+// the values of the column in the granule are past the compared value in sort
+// order.
+func granulesExhausted(schema *dynparquet.Schema, filterExpr logicalplan.Expr, g *Granule) bool {
+	expr, ok := filterExpr.(*logicalplan.BinaryExpr)
+	if !ok {
+		return false
+	}
+
+	if expr.Op == logicalplan.OpAnd {
+		return granulesExhausted(schema, expr.Left, g) || granulesExhausted(schema, expr.Right, g)
+	}
+
+	left, right := expr.Left, expr.Right
+	if lt, ok := left.(*logicalplan.TupleExpr); ok {
+		rt, ok := right.(*logicalplan.TupleExpr)
+		if !ok || len(lt.Exprs) == 0 || len(lt.Exprs) != len(rt.Exprs) {
+			return false
+		}
+		left, right = lt.Exprs[0], rt.Exprs[0]
+	}
+
+	column, ok := left.(*logicalplan.Column)
+	if !ok {
+		return false
+	}
+	literal, ok := right.(*logicalplan.LiteralExpr)
+	if !ok {
+		return false
+	}
+
+	sortingColumns := schema.SortingColumns()
+	if len(sortingColumns) == 0 || sortingColumns[0].ColumnName() != column.ColumnName {
+		return false
+	}
+	descending := sortingColumns[0].Descending()
+
+	switch expr.Op {
+	case logicalplan.OpLt, logicalplan.OpLtEq:
+		if descending {
+			return false
+		}
+	case logicalplan.OpGt, logicalplan.OpGtEq:
+		if !descending {
+			return false
+		}
+	default:
+		return false
+	}
+
+	min, max, found := findColumnValues(column.ColumnsUsedExprs(), g)
+	if !found || min.IsNull() || max.IsNull() {
+		return false
+	}
+
+	switch v := granuleScalar(schema, column, literal.Value).(type) {
+	case *scalar.Int64, *scalar.Float64:
+		if descending {
+			cmp, ok := compareGranuleValue(max, v)
+			return ok && cmp < 0
+		}
+		cmp, ok := compareGranuleValue(min, v)
+		return ok && cmp > 0
+	case *scalar.String:
+		if descending {
+			// The max may be truncated, in which case it is not an upper
+			// bound of the values.
+			return len(max.String()) < dynparquet.ColumnIndexSize && max.String() < string(v.Data())
+		}
+		// A truncated min is still a lower bound of the values.
+		return min.String() > string(v.Data())
+	}
+
+	return false
+}
+
+// filterGranuleBetween returns false if the range of the between expression
+// doesn't overlap with the min and max values of the granule.
+func filterGranuleBetween(expr *logicalplan.BetweenExpr, g *Granule) bool {
+	column, ok := expr.Expr.(*logicalplan.Column)
+	if !ok {
+		return true
+	}
+	low, ok := expr.Low.(*logicalplan.LiteralExpr)
+	if !ok {
+		return true
+	}
+	high, ok := expr.High.(*logicalplan.LiteralExpr)
+	if !ok {
+		return true
+	}
+
+	min, max, found := findColumnValues(column.ColumnsUsedExprs(), g)
+	switch l := granuleScalar(g.tableConfig.Schema(), column, low.Value).(type) {
+	case *scalar.Int64, *scalar.Float64:
+		h := granuleScalar(g.tableConfig.Schema(), column, high.Value)
+		switch h.(type) {
+		case *scalar.Int64, *scalar.Float64:
+		default:
+			return true
+		}
+		if !found {
+			return false
+		}
+		lowCmp, lowOk := compareGranuleValue(max, l)
+		highCmp, highOk := compareGranuleValue(min, h)
+		return !lowOk || !highOk || (lowCmp >= 0 && highCmp <= 0)
+	case *scalar.String:
+		h, ok := high.Value.(*scalar.String)
+		if !ok {
+			return true
+		}
+		if !found {
+			// A column that doesn't exist is the empty string.
+			return l.Value.Len() == 0
+		}
+		ls := truncateString(string(l.Data()), dynparquet.ColumnIndexSize)
+		hs := truncateString(string(h.Data()), dynparquet.ColumnIndexSize)
+		return ls <= max.String() && min.String() <= hs
+	}
+
+	return true
+}
+
+// granuleScalar converts a literal to the type it is compared as against
+// granule statistics of the column. Timestamps are int64 epochs in the unit
+// of timestamp columns and epoch milliseconds in other columns. Decimals are
+// unscaled int64 values, literals with more digits than the scale are
+// between two of them. Binary literals are strings. It returns nil if the
+// literal can't be compared.
+func granuleScalar(schema *dynparquet.Schema, column logicalplan.Expr, s scalar.Scalar) scalar.Scalar {
+	var lt *format.LogicalType
+	if col, ok := column.(*logicalplan.Column); ok {
+		if def, found := schema.FindColumn(col.ColumnName); found {
+			lt = def.StorageLayout.Type().LogicalType()
+		}
+	}
+
+	if lt != nil && lt.Decimal != nil {
+		v, exact, ok := convert.DecimalValue(s, lt.Decimal.Scale)
+		if !ok {
+			return nil
+		}
+		n, ok := convert.DecimalInt64(v)
+		if !ok {
+			return nil
+		}
+		if !exact {
+			return scalar.NewFloat64Scalar(float64(n) + 0.5)
+		}
+		return scalar.NewInt64Scalar(n)
+	}
+
+	if b, ok := s.(*scalar.Binary); ok {
+		// Binary literals are compared with the bytes of values like
+		// strings.
+		return scalar.NewStringScalarFromBuffer(b.Value)
+	}
+
+	ts, ok := s.(*scalar.Timestamp)
+	if !ok {
+		return s
+	}
+	unit := arrow.Millisecond
+	if lt != nil && lt.Timestamp != nil {
+		unit = convert.TimeUnit(lt.Timestamp)
+	}
+	return scalar.NewInt64Scalar(convert.ConvertTimestamp(int64(ts.Value), ts.Type.(*arrow.TimestampType).Unit, unit))
+}
+
+// compareGranuleValue compares a min or max value of a granule with a numeric
+// or boolean literal. Integers are compared with doubles as floats, false is
+// less than true. It returns false if the values can't be compared, such as
+// null values and NaNs.
+func compareGranuleValue(v *parquet.Value, s scalar.Scalar) (int, bool) {
+	if v == nil || v.IsNull() {
+		return 0, false
+	}
+
+	var a, b float64
+	switch s := s.(type) {
+	case *scalar.Boolean:
+		if v.Kind() != parquet.Boolean {
+			return 0, false
+		}
+		switch {
+		case v.Boolean() == s.Value:
+			return 0, true
+		case s.Value:
+			return -1, true
+		default:
+			return 1, true
+		}
+	case *scalar.Int64:
+		switch v.Kind() {
+		case parquet.Int64:
+			switch {
+			case v.Int64() < s.Value:
+				return -1, true
+			case v.Int64() > s.Value:
+				return 1, true
+			default:
+				return 0, true
+			}
+		case parquet.Double:
+			a, b = v.Double(), float64(s.Value)
+		default:
+			return 0, false
+		}
+	case *scalar.Float64:
+		switch v.Kind() {
+		case parquet.Int64:
+			a, b = float64(v.Int64()), s.Value
+		case parquet.Double:
+			a, b = v.Double(), s.Value
+		default:
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return 0, false
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+func truncateString(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
 func findColumnValues(matchers []logicalplan.Expr, g *Granule) (*parquet.Value, *parquet.Value, bool) {
 	findMinColumn := func() (*parquet.Value, string) {
 		g.metadata.minlock.RLock()
@@ -1341,16 +2075,48 @@ func (t *Table) memoryBlocks() ([]*TableBlock, uint64) {
 
 // collectRowGroups collects all the row groups from the table for the given filter.
 func (t *Table) collectRowGroups(ctx context.Context, tx uint64, filterExpr logicalplan.Expr) ([]dynparquet.DynamicRowGroup, error) {
+	return t.collectRowGroupsWithStats(ctx, tx, filterExpr, nil)
+}
+
+// scanRowGroups collects the row groups like collectRowGroups, for a scan
+// whose row groups are counted by the scan stats of the context.
+func (t *Table) scanRowGroups(ctx context.Context, tx uint64, filterExpr logicalplan.Expr) ([]dynparquet.DynamicRowGroup, error) {
+	return t.collectRowGroupsWithStats(ctx, tx, filterExpr, logicalplan.ScanStatsFromContext(ctx))
+}
+
+func (t *Table) collectRowGroupsWithStats(
+	ctx context.Context,
+	tx uint64,
+	filterExpr logicalplan.Expr,
+	stats *logicalplan.ScanStats,
+) ([]dynparquet.DynamicRowGroup, error) {
 	filter, err := booleanExpr(filterExpr)
 	if err != nil {
 		return nil, err
 	}
 
 	rowGroups := []dynparquet.DynamicRowGroup{}
+	var pruneErr error
 	iteratorFunc := func(rg dynparquet.DynamicRowGroup) bool {
+		// Pages none of whose rows match the filter are skipped, so large
+		// row groups with few matching rows aren't read entirely.
+		rg, pruneErr = pruneRowGroup(filter, rg)
+		if pruneErr != nil {
+			return false
+		}
+		if rg == nil {
+			stats.AddPrunedRowGroup()
+			return true
+		}
+		stats.AddRowGroup(rg.NumRows())
 		rowGroups = append(rowGroups, rg)
 		return true
 	}
+	// The row groups the filter rules out are counted as pruned too.
+	rowGroupFilter := TrueNegativeFilter(filter)
+	if stats != nil {
+		rowGroupFilter = &countingFilter{TrueNegativeFilter: filter, stats: stats}
+	}
 
 	// pending blocks could be uploaded to the bucket while we iterate on them.
 	// to avoid to iterate on them again while reading the block file
@@ -1358,14 +2124,44 @@ func (t *Table) collectRowGroups(ctx context.Context, tx uint64, filterExpr logi
 	// so that every block with a timestamp >= lastReadBlockTimestamp is discarded while being read.
 	memoryBlocks, lastReadBlockTimestamp := t.memoryBlocks()
 	for _, block := range memoryBlocks {
-		if err := block.RowGroupIterator(ctx, tx, filterExpr, filter, iteratorFunc); err != nil {
+		if err := block.RowGroupIterator(ctx, tx, filterExpr, rowGroupFilter, iteratorFunc); err != nil {
 			return nil, err
 		}
+		if pruneErr != nil {
+			return nil, pruneErr
+		}
 	}
 
-	if err := t.IterateBucketBlocks(ctx, t.logger, filter, iteratorFunc, lastReadBlockTimestamp); err != nil {
+	if err := t.IterateBucketBlocks(ctx, t.logger, rowGroupFilter, iteratorFunc, lastReadBlockTimestamp); err != nil {
 		return nil, err
 	}
+	if pruneErr != nil {
+		return nil, pruneErr
+	}
 
 	return rowGroups, nil
 }
+
+// countingFilter counts the row groups the filter rules out as pruned.
+type countingFilter struct {
+	TrueNegativeFilter
+	stats *logicalplan.ScanStats
+}
+
+func (f *countingFilter) Eval(rg dynparquet.DynamicRowGroup) (bool, error) {
+	mayContainUsefulData, err := f.TrueNegativeFilter.Eval(rg)
+	if err == nil && !mayContainUsefulData {
+		f.stats.AddPrunedRowGroup()
+	}
+	return mayContainUsefulData, err
+}
+
+// prunedGranule counts the row groups of the granule as pruned.
+func (f *countingFilter) prunedGranule(tx uint64, g *Granule) {
+	g.PartBuffersForTx(tx, func(buf *dynparquet.SerializedBuffer) bool {
+		for i := 0; i < buf.NumRowGroups(); i++ {
+			f.stats.AddPrunedRowGroup()
+		}
+		return true
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -27,7 +29,9 @@ import (
 
 	"github.com/polarsignals/frostdb/dynparquet"
 	schemapb "github.com/polarsignals/frostdb/gen/proto/go/frostdb/schema/v1alpha1"
+	"github.com/polarsignals/frostdb/query"
 	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/polarsignals/frostdb/query/physicalplan"
 )
 
 type TestLogHelper interface {
@@ -648,7 +652,7 @@ func benchmarkTableInserts(b *testing.B, rows, iterations, writers int) {
 			})
 		}
 
-		buf, err := rows.ToBuffer(config.schema)
+		buf, err := rows.ToBuffer(config.Schema())
 		require.NoError(b, err)
 
 		buf.Sort()
@@ -1165,6 +1169,283 @@ func Test_Table_Filter(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_Table_FilterPrefix(t *testing.T) {
+	table := basicTable(t, 2^12)
+
+	samples := dynparquet.Samples{{
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "label1", Value: "value1"},
+		},
+		Stacktrace: []uuid.UUID{
+			{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+		},
+		Timestamp: 1,
+		Value:     1,
+	}, {
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "label1", Value: "value3"},
+		},
+		Stacktrace: []uuid.UUID{
+			{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+		},
+		Timestamp: 2,
+		Value:     2,
+	}}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = table.InsertBuffer(ctx, buf)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		iterated   bool
+	}{
+		"prefix within range": {
+			filterExpr: logicalplan.Col("labels.label1").StartsWith("value2"),
+			iterated:   true,
+		},
+		"shared prefix": {
+			filterExpr: logicalplan.Col("labels.label1").StartsWith("val"),
+			iterated:   true,
+		},
+		"prefix before min": {
+			filterExpr: logicalplan.Col("labels.label1").StartsWith("aaa"),
+			iterated:   false,
+		},
+		"prefix after max": {
+			filterExpr: logicalplan.Col("labels.label1").StartsWith("value4"),
+			iterated:   false,
+		},
+		"missing column": {
+			filterExpr: logicalplan.Col("labels.label2").StartsWith("value"),
+			iterated:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err = table.View(func(tx uint64) error {
+				// The rows of the row groups that aren't filtered out are
+				// filtered by the iterator, so only the row groups are
+				// checked.
+				rowGroups, err := table.collectRowGroups(ctx, tx, test.filterExpr)
+				require.NoError(t, err)
+				require.Equal(t, test.iterated, len(rowGroups) > 0)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Table_FilterBetween(t *testing.T) {
+	table := basicTable(t, 2^12)
+
+	samples := dynparquet.Samples{{
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "label1", Value: "value1"},
+		},
+		Stacktrace: []uuid.UUID{
+			{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+		},
+		Timestamp: 10,
+		Value:     1,
+	}, {
+		ExampleType: "test",
+		Labels: []dynparquet.Label{
+			{Name: "label1", Value: "value3"},
+		},
+		Stacktrace: []uuid.UUID{
+			{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+		},
+		Timestamp: 20,
+		Value:     2,
+	}}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = table.InsertBuffer(ctx, buf)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		rows       int64
+	}{
+		"range within": {
+			filterExpr: logicalplan.Col("timestamp").Between(logicalplan.Literal(int64(12)), logicalplan.Literal(int64(18))),
+			rows:       0,
+		},
+		"inclusive bounds": {
+			filterExpr: logicalplan.Col("timestamp").Between(logicalplan.Literal(int64(10)), logicalplan.Literal(int64(20))),
+			rows:       2,
+		},
+		"overlapping min": {
+			filterExpr: logicalplan.Col("timestamp").Between(logicalplan.Literal(int64(0)), logicalplan.Literal(int64(10))),
+			rows:       1,
+		},
+		"range before min": {
+			filterExpr: logicalplan.Col("timestamp").Between(logicalplan.Literal(int64(0)), logicalplan.Literal(int64(9))),
+			rows:       0,
+		},
+		"range after max": {
+			filterExpr: logicalplan.Col("timestamp").Between(logicalplan.Literal(int64(21)), logicalplan.Literal(int64(30))),
+			rows:       0,
+		},
+		"string range": {
+			filterExpr: logicalplan.Col("labels.label1").Between(logicalplan.Literal("value2"), logicalplan.Literal("value4")),
+			rows:       1,
+		},
+		"missing column": {
+			filterExpr: logicalplan.Col("labels.label2").Between(logicalplan.Literal("a"), logicalplan.Literal("z")),
+			rows:       0,
+		},
+	}
+
+	pool := memory.NewGoAllocator()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err = table.View(func(tx uint64) error {
+				rows := int64(0)
+
+				as, err := table.ArrowSchema(ctx, tx, pool, nil, nil, nil, nil)
+				if err != nil {
+					return err
+				}
+
+				filter, err := physicalplan.Filter(pool, test.filterExpr)
+				if err != nil {
+					return err
+				}
+				filter.SetNextCallback(func(ar arrow.Record) error {
+					rows += ar.NumRows()
+					return nil
+				})
+
+				err = table.Iterator(ctx, tx, pool, as, nil, nil, test.filterExpr, nil, func(ar arrow.Record) error {
+					defer ar.Release()
+					return filter.Callback(ar)
+				})
+				require.NoError(t, err)
+				require.Equal(t, test.rows, rows)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Table_FilterTuple(t *testing.T) {
+	table := basicTable(t, 2^12)
+
+	samples := dynparquet.Samples{}
+	for _, exampleType := range []string{"a", "b", "c"} {
+		for ts := int64(1); ts <= 2; ts++ {
+			samples = append(samples, dynparquet.Sample{
+				ExampleType: exampleType,
+				Labels: []dynparquet.Label{
+					{Name: "label1", Value: "value1"},
+				},
+				Stacktrace: []uuid.UUID{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+				},
+				Timestamp: ts,
+				Value:     1,
+			})
+		}
+	}
+
+	buf, err := samples.ToBuffer(table.Schema())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = table.InsertBuffer(ctx, buf)
+	require.NoError(t, err)
+
+	cursor := func(exampleType string, ts int64) *logicalplan.TupleExpr {
+		return logicalplan.Tuple(logicalplan.Literal(exampleType), logicalplan.Literal(ts))
+	}
+	columns := logicalplan.Tuple(logicalplan.Col("example_type"), logicalplan.Col("timestamp"))
+
+	tests := map[string]struct {
+		filterExpr logicalplan.Expr
+		rows       int64
+	}{
+		"greater than": {
+			filterExpr: columns.Gt(cursor("b", 1)),
+			rows:       3,
+		},
+		"greater than or equal": {
+			filterExpr: columns.GtEq(cursor("b", 1)),
+			rows:       4,
+		},
+		"less than": {
+			filterExpr: columns.Lt(cursor("b", 2)),
+			rows:       3,
+		},
+		"less than or equal": {
+			filterExpr: columns.LtEq(cursor("b", 2)),
+			rows:       4,
+		},
+		"equal": {
+			filterExpr: columns.Eq(cursor("c", 2)),
+			rows:       1,
+		},
+		"not equal": {
+			filterExpr: columns.NotEq(cursor("c", 2)),
+			rows:       5,
+		},
+		"before all granules": {
+			filterExpr: columns.Lt(cursor("0", 0)),
+			rows:       0,
+		},
+	}
+
+	pool := memory.NewGoAllocator()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err = table.View(func(tx uint64) error {
+				rows := int64(0)
+
+				as, err := table.ArrowSchema(ctx, tx, pool, nil, nil, nil, nil)
+				if err != nil {
+					return err
+				}
+
+				filter, err := physicalplan.Filter(pool, test.filterExpr)
+				if err != nil {
+					return err
+				}
+				filter.SetNextCallback(func(ar arrow.Record) error {
+					rows += ar.NumRows()
+					return nil
+				})
+
+				err = table.Iterator(ctx, tx, pool, as, nil, nil, test.filterExpr, nil, func(ar arrow.Record) error {
+					defer ar.Release()
+					return filter.Callback(ar)
+				})
+				require.NoError(t, err)
+				require.Equal(t, test.rows, rows)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+
+	g := table.ActiveBlock().Index().Min().(*Granule)
+	require.True(t, granulesExhausted(table.Schema(), columns.Lt(cursor("0", 0)), g))
+	require.False(t, granulesExhausted(table.Schema(), columns.Lt(cursor("a", 1)), g))
+	require.False(t, granulesExhausted(table.Schema(), columns.Gt(cursor("z", 0)), g))
+}
+
 func Test_Table_Bloomfilter(t *testing.T) {
 	table := basicTable(t, 2^12)
 
@@ -1645,3 +1926,981 @@ func Test_DoubleTable(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func Test_Table_SortedIteratorProjection(t *testing.T) {
+	table := basicTable(t, 2^12)
+
+	// Each insert results in a separate row group, so the row groups are
+	// merged.
+	for _, values := range [][]string{{"b", "d"}, {"a", "c"}} {
+		samples := dynparquet.Samples{}
+		for _, v := range values {
+			samples = append(samples, dynparquet.Sample{
+				ExampleType: "test",
+				Labels: []dynparquet.Label{
+					{Name: "label0", Value: "value0"},
+					{Name: "label1", Value: v},
+					{Name: "label2", Value: "value2"},
+					{Name: "label3", Value: "value3"},
+				},
+				Timestamp: 1,
+				Value:     int64(v[0]),
+			})
+		}
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	physicalProjections := []logicalplan.Expr{
+		logicalplan.Col("labels.label1"),
+		logicalplan.Col("value"),
+	}
+
+	err := table.View(func(tx uint64) error {
+		rowGroups, err := table.collectRowGroups(context.Background(), tx, nil)
+		require.NoError(t, err)
+
+		// The sorting columns preceding the projected label are read too.
+		projected := sortedProjection(table.Schema(), rowGroups, physicalProjections)
+		for column, expected := range map[string]bool{
+			"example_type":  true,
+			"labels.label0": true,
+			"labels.label1": true,
+			"labels.label2": false,
+			"labels.label3": false,
+			"stacktrace":    false,
+			"timestamp":     false,
+			"value":         true,
+		} {
+			require.Equal(t, expected, projected(column), column)
+		}
+
+		labels := []string{}
+		values := []int64{}
+		err = table.SortedIterator(context.Background(), tx, memory.NewGoAllocator(), physicalProjections, nil, func(r arrow.Record) error {
+			require.Equal(t, int64(2), r.NumCols())
+			for i := 0; i < int(r.NumRows()); i++ {
+				labels = append(labels, string(r.Column(0).(*array.Binary).Value(i)))
+				values = append(values, r.Column(1).(*array.Int64).Value(i))
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b", "c", "d"}, labels)
+		require.Equal(t, []int64{'a', 'b', 'c', 'd'}, values)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func Test_Table_RepeatedColumn(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "locations",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_INT64,
+				Repeated: true,
+			},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "labels",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// The columns of rows are labels.job, locations and timestamp. Rows
+	// without locations have a single null value, the granules of the
+	// inserts are split and compacted.
+	for _, rows := range [][]parquet.Row{{
+		{parquet.ValueOf("a").Level(0, 1, 0), parquet.ValueOf(1).Level(0, 1, 1), parquet.ValueOf(2).Level(1, 1, 1), parquet.ValueOf(3).Level(0, 0, 2)},
+		{parquet.ValueOf("b").Level(0, 1, 0), parquet.ValueOf(nil).Level(0, 0, 1), parquet.ValueOf(1).Level(0, 0, 2)},
+	}, {
+		{parquet.ValueOf("a").Level(0, 1, 0), parquet.ValueOf(4).Level(0, 1, 1), parquet.ValueOf(2).Level(0, 0, 2)},
+		{parquet.ValueOf("b").Level(0, 1, 0), parquet.ValueOf(5).Level(0, 1, 1), parquet.ValueOf(6).Level(1, 1, 1), parquet.ValueOf(7).Level(1, 1, 1), parquet.ValueOf(5).Level(0, 0, 2)},
+	}, {
+		{parquet.ValueOf("a").Level(0, 1, 0), parquet.ValueOf(8).Level(0, 1, 1), parquet.ValueOf(4).Level(0, 0, 2)},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	locations := map[int64][]int64{}
+	err = table.View(func(tx uint64) error {
+		pool := memory.NewGoAllocator()
+
+		as, err := table.ArrowSchema(ctx, tx, pool, nil, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, arrow.ListOf(&arrow.Int64Type{}), as.Field(as.FieldIndices("locations")[0]).Type)
+
+		return table.Iterator(ctx, tx, pool, as, nil, nil, nil, nil, func(ar arrow.Record) error {
+			defer ar.Release()
+			list := ar.Column(ar.Schema().FieldIndices("locations")[0]).(*array.List)
+			values := list.ListValues().(*array.Int64)
+			timestamps := ar.Column(ar.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < list.Len(); i++ {
+				if list.IsNull(i) {
+					locations[timestamps.Value(i)] = nil
+					continue
+				}
+				offsets := list.Offsets()
+				locations[timestamps.Value(i)] = append([]int64{}, values.Int64Values()[offsets[i]:offsets[i+1]]...)
+			}
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]int64{
+		1: nil,
+		2: {4},
+		3: {1, 2},
+		4: {8},
+		5: {5, 6, 7},
+	}, locations)
+}
+
+func Test_Table_StructColumn(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name: "payload",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRUCT,
+				Nullable: true,
+				Fields: []*schemapb.Column{{
+					Name:          "code",
+					StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+				}, {
+					Name: "status",
+					StorageLayout: &schemapb.StorageLayout{
+						Type:     schemapb.StorageLayout_TYPE_STRING,
+						Nullable: true,
+					},
+				}},
+			},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// The columns of rows are labels.job, payload.code, payload.status and
+	// timestamp, the granules of the inserts are split and compacted.
+	payload := func(code int64, status interface{}) []parquet.Value {
+		if status == nil {
+			return []parquet.Value{parquet.ValueOf(code).Level(0, 1, 1), parquet.ValueOf(nil).Level(0, 1, 2)}
+		}
+		return []parquet.Value{parquet.ValueOf(code).Level(0, 1, 1), parquet.ValueOf(status).Level(0, 2, 2)}
+	}
+	noPayload := []parquet.Value{parquet.ValueOf(nil).Level(0, 0, 1), parquet.ValueOf(nil).Level(0, 0, 2)}
+	row := func(job string, payload []parquet.Value, timestamp int64) parquet.Row {
+		row := parquet.Row{parquet.ValueOf(job).Level(0, 1, 0)}
+		row = append(row, payload...)
+		return append(row, parquet.ValueOf(timestamp).Level(0, 0, 3))
+	}
+	for _, rows := range [][]parquet.Row{{
+		row("a", payload(200, "ok"), 3),
+		row("b", noPayload, 1),
+	}, {
+		row("a", payload(404, nil), 2),
+		row("b", payload(500, "error"), 5),
+	}, {
+		row("a", payload(201, "created"), 4),
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	codes := map[int64]interface{}{}
+	err = engine.ScanTable("test").
+		Project(logicalplan.Col("payload"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			payload := r.Column(r.Schema().FieldIndices("payload")[0]).(*array.Struct)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				if payload.IsNull(i) {
+					codes[timestamps.Value(i)] = nil
+					continue
+				}
+				codes[timestamps.Value(i)] = payload.Field(0).(*array.Int64).Value(i)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]interface{}{1: nil, 2: int64(404), 3: int64(200), 4: int64(201), 5: int64(500)}, codes)
+
+	// Fields of struct columns are projected on their own.
+	statuses := map[int64]string{}
+	err = engine.ScanTable("test").
+		Filter(logicalplan.Col("timestamp").Gt(logicalplan.Literal(1))).
+		Project(logicalplan.Col("payload.status"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			require.Equal(t, 2, len(r.Schema().Fields()))
+			status := r.Column(r.Schema().FieldIndices("payload.status")[0]).(*array.Binary)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				if !status.IsNull(i) {
+					statuses[timestamps.Value(i)] = string(status.Value(i))
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{3: "ok", 4: "created", 5: "error"}, statuses)
+
+	// Fields are filtered on and projected by field expressions.
+	codes = map[int64]interface{}{}
+	err = engine.ScanTable("test").
+		Filter(logicalplan.Col("payload").Field("code").GtEq(logicalplan.Literal(int64(400)))).
+		Project(logicalplan.Col("payload").Field("code"), logicalplan.Field("payload", "status"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			require.Equal(t, 3, len(r.Schema().Fields()))
+			code := r.Column(r.Schema().FieldIndices("payload.code")[0]).(*array.Int64)
+			status := r.Column(r.Schema().FieldIndices("payload.status")[0]).(*array.Binary)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				if status.IsNull(i) {
+					codes[timestamps.Value(i)] = code.Value(i)
+					continue
+				}
+				codes[timestamps.Value(i)] = fmt.Sprintf("%d %s", code.Value(i), status.Value(i))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]interface{}{2: int64(404), 5: "500 error"}, codes)
+}
+
+func Test_Table_MixedSortingColumns(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "region",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRING},
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}, {
+			Name:          "value",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "region",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_DESCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	type key struct {
+		region    string
+		timestamp int64
+	}
+	row := func(region string, timestamp int64) parquet.Row {
+		return parquet.Row{
+			parquet.ValueOf(region).Level(0, 0, 0),
+			parquet.ValueOf(timestamp).Level(0, 0, 1),
+			parquet.ValueOf(timestamp*10).Level(0, 0, 2),
+		}
+	}
+
+	// The granules of the inserts are split and compacted, the rows of
+	// each insert are out of order.
+	ctx := context.Background()
+	var tx uint64
+	for _, rows := range [][]parquet.Row{
+		{row("us", 1), row("eu", 2), row("us", 4)},
+		{row("eu", 4), row("us", 5), row("eu", 1)},
+		{row("ap", 2), row("us", 3), row("eu", 3)},
+		{row("ap", 5), row("eu", 5), row("ap", 1)},
+	} {
+		buf, err := schema.NewBuffer(nil)
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		buf.Sort()
+		tx, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+	table.db.Wait(tx)
+	require.Greater(t, table.active.Index().Len(), 1)
+
+	expected := []key{
+		{"ap", 5}, {"ap", 2}, {"ap", 1},
+		{"eu", 5}, {"eu", 4}, {"eu", 3}, {"eu", 2}, {"eu", 1},
+		{"us", 5}, {"us", 4}, {"us", 3}, {"us", 1},
+	}
+	less := func(a, b key) bool {
+		if a.region != b.region {
+			return a.region < b.region
+		}
+		return a.timestamp > b.timestamp
+	}
+
+	// The rows of each part are in the order of the sorting columns, and
+	// so are the least rows of the granules of the index.
+	var least []key
+	numRows := 0
+	table.active.Index().Ascend(func(i btree.Item) bool {
+		g := i.(*Granule)
+		l := g.Least()
+		least = append(least, key{l.Row[0].String(), l.Row[1].Int64()})
+		g.PartBuffersForTx(tx, func(buf *dynparquet.SerializedBuffer) bool {
+			rows := make([]parquet.Row, buf.NumRows())
+			n, err := buf.Reader().ReadRows(rows)
+			if err != io.EOF {
+				require.NoError(t, err)
+			}
+			for j := 1; j < n; j++ {
+				prev := key{rows[j-1][0].String(), rows[j-1][1].Int64()}
+				next := key{rows[j][0].String(), rows[j][1].Int64()}
+				require.False(t, less(next, prev), "%v before %v", prev, next)
+			}
+			numRows += n
+			return true
+		})
+		return true
+	})
+	require.Equal(t, len(expected), numRows)
+	require.True(t, sort.SliceIsSorted(least, func(i, j int) bool { return less(least[i], least[j]) }), least)
+
+	// Reading the table in order merges the granules in the order of the
+	// sorting columns.
+	err = table.View(func(tx uint64) error {
+		keys := []key{}
+		err := table.SortedIterator(ctx, tx, memory.NewGoAllocator(), []logicalplan.Expr{
+			logicalplan.Col("region"),
+			logicalplan.Col("timestamp"),
+		}, nil, func(r arrow.Record) error {
+			regions := r.Column(r.Schema().FieldIndices("region")[0]).(*array.Binary)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				keys = append(keys, key{string(regions.Value(i)), timestamps.Value(i)})
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, expected, keys)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func Test_Table_MapColumn(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "attributes",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:      schemapb.StorageLayout_TYPE_MAP,
+				ValueType: schemapb.StorageLayout_TYPE_STRING,
+			},
+		}, {
+			Name: "labels",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// The columns of rows are attributes.key_value.key,
+	// attributes.key_value.value, labels.job and timestamp, the granules of
+	// the inserts are split and compacted.
+	row := func(job string, timestamp int64, attributes ...string) parquet.Row {
+		keys := []parquet.Value{parquet.ValueOf(nil).Level(0, 0, 0)}
+		values := []parquet.Value{parquet.ValueOf(nil).Level(0, 0, 1)}
+		if len(attributes) > 0 {
+			keys, values = keys[:0], values[:0]
+		}
+		for i := 0; i < len(attributes); i += 2 {
+			rep := 1
+			if i == 0 {
+				rep = 0
+			}
+			keys = append(keys, parquet.ValueOf(attributes[i]).Level(rep, 1, 0))
+			values = append(values, parquet.ValueOf(attributes[i+1]).Level(rep, 1, 1))
+		}
+		row := append(parquet.Row{}, keys...)
+		row = append(row, values...)
+		row = append(row, parquet.ValueOf(job).Level(0, 1, 2))
+		return append(row, parquet.ValueOf(timestamp).Level(0, 0, 3))
+	}
+	for _, rows := range [][]parquet.Row{{
+		row("a", 3, "method", "GET", "path", "/"),
+		row("b", 1),
+	}, {
+		row("a", 2, "path", "/api"),
+		row("b", 5, "method", "POST"),
+	}, {
+		row("a", 4, "method", "PUT", "path", "/api"),
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"labels": {"job"}})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	entries := map[int64]int{}
+	err = engine.ScanTable("test").
+		Project(logicalplan.Col("attributes"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			attributes := r.Column(r.Schema().FieldIndices("attributes")[0]).(*array.Map)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			offsets := attributes.Offsets()[attributes.Data().Offset():]
+			for i := 0; i < int(r.NumRows()); i++ {
+				entries[timestamps.Value(i)] = int(offsets[i+1] - offsets[i])
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]int{1: 0, 2: 1, 3: 2, 4: 2, 5: 1}, entries)
+
+	methods := map[int64]interface{}{}
+	err = engine.ScanTable("test").
+		Filter(logicalplan.Col("timestamp").Gt(logicalplan.Literal(1))).
+		Project(logicalplan.MapValue(logicalplan.Col("attributes"), "method").Alias("method"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			method := r.Column(r.Schema().FieldIndices("method")[0]).(*array.Binary)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				if method.IsNull(i) {
+					methods[timestamps.Value(i)] = nil
+					continue
+				}
+				methods[timestamps.Value(i)] = string(method.Value(i))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]interface{}{2: nil, 3: "GET", 4: "PUT", 5: "POST"}, methods)
+}
+
+func Test_Table_TypedDynamicColumn(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name: "attributes",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_INT64,
+				Nullable: true,
+			},
+			Dynamic: true,
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:       "attributes",
+			Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+			NullsFirst: true,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// The concrete columns of the inserts differ, the granules of the
+	// inserts are split and compacted.
+	value := func(v interface{}, col int) parquet.Value {
+		if v == nil {
+			return parquet.ValueOf(nil).Level(0, 0, col)
+		}
+		return parquet.ValueOf(v).Level(0, 1, col)
+	}
+	for _, insert := range []struct {
+		names []string
+		rows  []parquet.Row
+	}{{
+		names: []string{"size"},
+		rows: []parquet.Row{
+			{value(int64(512), 0), parquet.ValueOf(int64(1)).Level(0, 0, 1)},
+			{value(int64(-3), 0), parquet.ValueOf(int64(2)).Level(0, 0, 1)},
+		},
+	}, {
+		names: []string{"retries", "size"},
+		rows: []parquet.Row{
+			{value(int64(2), 0), value(nil, 1), parquet.ValueOf(int64(3)).Level(0, 0, 2)},
+			{value(int64(1), 0), value(int64(100), 1), parquet.ValueOf(int64(4)).Level(0, 0, 2)},
+		},
+	}, {
+		names: []string{"retries"},
+		rows: []parquet.Row{
+			{value(int64(5), 0), parquet.ValueOf(int64(5)).Level(0, 0, 1)},
+		},
+	}} {
+		buf, err := schema.NewBuffer(map[string][]string{"attributes": insert.names})
+		require.NoError(t, err)
+		_, err = buf.WriteRows(insert.rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	// Rows without a value of a concrete column are null and don't match
+	// filters on it.
+	sizes := map[int64]interface{}{}
+	err = engine.ScanTable("test").
+		Filter(logicalplan.Col("attributes.size").Gt(logicalplan.Literal(int64(0)))).
+		Project(logicalplan.DynCol("attributes"), logicalplan.Col("timestamp")).
+		Execute(ctx, func(r arrow.Record) error {
+			size := r.Column(r.Schema().FieldIndices("attributes.size")[0]).(*array.Int64)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				sizes[timestamps.Value(i)] = size.Value(i)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]interface{}{1: int64(512), 4: int64(100)}, sizes)
+
+	// Distinct values of typed concrete columns keep their type.
+	retries := []int64{}
+	err = engine.ScanTable("test").
+		Distinct(logicalplan.Col("attributes.retries")).
+		Execute(ctx, func(r arrow.Record) error {
+			col := r.Column(0).(*array.Int64)
+			for i := 0; i < col.Len(); i++ {
+				if col.IsValid(i) {
+					retries = append(retries, col.Value(i))
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 2, 5}, retries)
+}
+
+func Test_Table_NullableColumns(t *testing.T) {
+	nullable := func(typ schemapb.StorageLayout_Type) *schemapb.StorageLayout {
+		return &schemapb.StorageLayout{Type: typ, Nullable: true}
+	}
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "active",
+			StorageLayout: nullable(schemapb.StorageLayout_TYPE_BOOL),
+		}, {
+			Name:          "count",
+			StorageLayout: nullable(schemapb.StorageLayout_TYPE_INT64),
+		}, {
+			Name: "method",
+			StorageLayout: &schemapb.StorageLayout{
+				Type:     schemapb.StorageLayout_TYPE_STRING,
+				Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+				Nullable: true,
+			},
+		}, {
+			Name:          "ratio",
+			StorageLayout: nullable(schemapb.StorageLayout_TYPE_DOUBLE),
+		}, {
+			Name:          "seen",
+			StorageLayout: nullable(schemapb.StorageLayout_TYPE_TIMESTAMP_MILLIS),
+		}, {
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:       "method",
+			Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+			NullsFirst: true,
+		}, {
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	value := func(v interface{}, col int) parquet.Value {
+		if v == nil {
+			return parquet.ValueOf(nil).Level(0, 0, col)
+		}
+		return parquet.ValueOf(v).Level(0, 1, col)
+	}
+	row := func(active, count, method, ratio, seen interface{}, ts int64) parquet.Row {
+		return parquet.Row{
+			value(active, 0),
+			value(count, 1),
+			value(method, 2),
+			value(ratio, 3),
+			value(seen, 4),
+			parquet.ValueOf(ts).Level(0, 0, 5),
+		}
+	}
+	// Each insert is its own granule, the granules are split and compacted.
+	for _, rows := range [][]parquet.Row{{
+		row(true, int64(1), "GET", 0.5, int64(1000), 1),
+		row(nil, nil, nil, nil, nil, 2),
+	}, {
+		row(false, int64(3), "POST", nil, int64(3000), 3),
+		row(nil, int64(4), nil, 1.5, nil, 4),
+	}} {
+		buf, err := schema.NewBuffer(nil)
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	// The null columns of each row by timestamp, in the order of the rows.
+	order := []int64{}
+	nulls := map[int64][]string{}
+	err = engine.ScanTable("test").
+		Project(
+			logicalplan.Col("active"),
+			logicalplan.Col("count"),
+			logicalplan.Col("method"),
+			logicalplan.Col("ratio"),
+			logicalplan.Col("seen"),
+			logicalplan.Col("timestamp"),
+		).
+		Execute(ctx, func(r arrow.Record) error {
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				ts := timestamps.Value(i)
+				order = append(order, ts)
+				nulls[ts] = []string{}
+				for j, field := range r.Schema().Fields() {
+					if r.Column(j).IsNull(i) {
+						nulls[ts] = append(nulls[ts], field.Name)
+					}
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 4, 1, 3}, order)
+	require.Equal(t, map[int64][]string{
+		1: {},
+		2: {"active", "count", "method", "ratio", "seen"},
+		3: {"ratio"},
+		4: {"active", "method", "seen"},
+	}, nulls)
+
+	// Null is a distinct value of nullable columns.
+	methods := []string{}
+	err = engine.ScanTable("test").
+		Distinct(logicalplan.Col("method")).
+		Execute(ctx, func(r arrow.Record) error {
+			col := r.Column(0).(*array.Binary)
+			for i := 0; i < col.Len(); i++ {
+				if col.IsNull(i) {
+					methods = append(methods, "<null>")
+					continue
+				}
+				methods = append(methods, string(col.Value(i)))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"GET", "POST", "<null>"}, methods)
+
+	// Negated comparisons match null values whether or not the negation is
+	// pushed down into the comparison.
+	for name, test := range map[string]struct {
+		expr       logicalplan.Expr
+		timestamps []int64
+	}{
+		"not less than": {
+			expr:       logicalplan.Not(logicalplan.Col("count").Lt(logicalplan.Literal(int64(3)))),
+			timestamps: []int64{2, 3, 4},
+		},
+		"not equal": {
+			expr:       logicalplan.Not(logicalplan.Col("count").Eq(logicalplan.Literal(int64(1)))),
+			timestamps: []int64{2, 3, 4},
+		},
+		"not regex match": {
+			expr:       logicalplan.Not(logicalplan.Col("method").RegexMatch("G.*")),
+			timestamps: []int64{2, 3, 4},
+		},
+		"not conjunction": {
+			expr: logicalplan.Not(logicalplan.And(
+				logicalplan.Col("ratio").GtEq(logicalplan.Literal(1.0)),
+				logicalplan.Col("timestamp").Gt(logicalplan.Literal(int64(1))),
+			)),
+			timestamps: []int64{1, 2, 3},
+		},
+		"not equal to absent value": {
+			expr:       logicalplan.Col("count").NotEq(logicalplan.Literal(int64(7))),
+			timestamps: []int64{1, 2, 3, 4},
+		},
+		"not less than non-nullable": {
+			expr:       logicalplan.Not(logicalplan.Col("timestamp").Lt(logicalplan.Literal(int64(3)))),
+			timestamps: []int64{3, 4},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			timestamps := []int64{}
+			err := engine.ScanTable("test").
+				Filter(test.expr).
+				Project(logicalplan.Col("timestamp")).
+				Execute(ctx, func(r arrow.Record) error {
+					timestamps = append(timestamps, r.Column(0).(*array.Int64).Int64Values()...)
+					return nil
+				})
+			require.NoError(t, err)
+			sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+			require.Equal(t, test.timestamps, timestamps)
+		})
+	}
+}
+
+func Test_Table_AddColumns(t *testing.T) {
+	schema, err := dynparquet.SchemaFromDefinition(&schemapb.Schema{
+		Name: "test",
+		Columns: []*schemapb.Column{{
+			Name:          "timestamp",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}, {
+			Name:          "value",
+			StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_INT64},
+		}},
+		SortingColumns: []*schemapb.SortingColumn{{
+			Name:      "timestamp",
+			Direction: schemapb.SortingColumn_DIRECTION_ASCENDING,
+		}},
+	})
+	require.NoError(t, err)
+
+	c, err := New(newTestLogger(t), prometheus.NewRegistry(), WithGranuleSize(2))
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(schema))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	insert := func(dynamicColumns map[string][]string, rows ...parquet.Row) {
+		buf, err := table.Schema().NewBuffer(dynamicColumns)
+		require.NoError(t, err)
+		_, err = buf.WriteRows(rows)
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	insert(nil, parquet.Row{
+		parquet.ValueOf(int64(1)).Level(0, 0, 0),
+		parquet.ValueOf(int64(10)).Level(0, 0, 1),
+	}, parquet.Row{
+		parquet.ValueOf(int64(3)).Level(0, 0, 0),
+		parquet.ValueOf(int64(30)).Level(0, 0, 1),
+	})
+
+	require.EqualError(t, table.AddColumns(&schemapb.Column{
+		Name:          "value",
+		StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_DOUBLE, Nullable: true},
+	}), "column value already exists")
+	require.EqualError(t, table.AddColumns(&schemapb.Column{
+		Name:          "region",
+		StorageLayout: &schemapb.StorageLayout{Type: schemapb.StorageLayout_TYPE_STRING},
+	}), "added column region must be nullable or repeated")
+
+	// A buffer of the schema before the columns are added, inserted after.
+	before, err := table.Schema().NewBuffer(nil)
+	require.NoError(t, err)
+	_, err = before.WriteRows([]parquet.Row{{
+		parquet.ValueOf(int64(5)).Level(0, 0, 0),
+		parquet.ValueOf(int64(50)).Level(0, 0, 1),
+	}})
+	require.NoError(t, err)
+	serialized, err := table.Schema().SerializeBuffer(before)
+	require.NoError(t, err)
+
+	require.NoError(t, table.AddColumns(&schemapb.Column{
+		Name: "attributes",
+		StorageLayout: &schemapb.StorageLayout{
+			Type:     schemapb.StorageLayout_TYPE_STRING,
+			Nullable: true,
+		},
+		Dynamic: true,
+	}, &schemapb.Column{
+		Name: "region",
+		StorageLayout: &schemapb.StorageLayout{
+			Type:     schemapb.StorageLayout_TYPE_STRING,
+			Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+			Nullable: true,
+		},
+	}))
+
+	// The columns of the new schema are in the order of their names.
+	insert(map[string][]string{"attributes": {"host"}}, parquet.Row{
+		parquet.ValueOf("a").Level(0, 1, 0),
+		parquet.ValueOf("eu").Level(0, 1, 1),
+		parquet.ValueOf(int64(2)).Level(0, 0, 2),
+		parquet.ValueOf(int64(20)).Level(0, 0, 3),
+	}, parquet.Row{
+		parquet.ValueOf("b").Level(0, 1, 0),
+		parquet.ValueOf("us").Level(0, 1, 1),
+		parquet.ValueOf(int64(4)).Level(0, 0, 2),
+		parquet.ValueOf(int64(40)).Level(0, 0, 3),
+	})
+	_, err = table.Insert(ctx, serialized)
+	require.NoError(t, err)
+	table.Sync()
+
+	engine := query.NewEngine(memory.NewGoAllocator(), db.TableProvider())
+
+	// Rows inserted before the columns were added are read as nulls.
+	regions := map[int64]interface{}{}
+	hosts := map[int64]interface{}{}
+	err = engine.ScanTable("test").
+		Project(
+			logicalplan.Col("attributes.host"),
+			logicalplan.Col("region"),
+			logicalplan.Col("timestamp"),
+		).
+		Execute(ctx, func(r arrow.Record) error {
+			host := r.Column(r.Schema().FieldIndices("attributes.host")[0]).(*array.Binary)
+			region := r.Column(r.Schema().FieldIndices("region")[0]).(*array.Binary)
+			timestamps := r.Column(r.Schema().FieldIndices("timestamp")[0]).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				regions[timestamps.Value(i)] = nil
+				if region.IsValid(i) {
+					regions[timestamps.Value(i)] = string(region.Value(i))
+				}
+				hosts[timestamps.Value(i)] = nil
+				if host.IsValid(i) {
+					hosts[timestamps.Value(i)] = string(host.Value(i))
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]interface{}{1: nil, 2: "eu", 3: nil, 4: "us", 5: nil}, regions)
+	require.Equal(t, map[int64]interface{}{1: nil, 2: "a", 3: nil, 4: "b", 5: nil}, hosts)
+
+	// Filters on the new columns match the new rows only.
+	values := []int64{}
+	err = engine.ScanTable("test").
+		Filter(logicalplan.Col("region").Eq(logicalplan.Literal("us"))).
+		Project(logicalplan.Col("value")).
+		Execute(ctx, func(r arrow.Record) error {
+			col := r.Column(0).(*array.Int64)
+			for i := 0; i < col.Len(); i++ {
+				values = append(values, col.Value(i))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []int64{40}, values)
+}
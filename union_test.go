@@ -0,0 +1,118 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestUnion(t *testing.T) {
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+
+	sample := func(job, region string, value int64) dynparquet.Sample {
+		labels := []dynparquet.Label{{Name: "job", Value: job}}
+		if region != "" {
+			labels = append(labels, dynparquet.Label{Name: "region", Value: region})
+		}
+		return dynparquet.Sample{
+			Labels: labels,
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: value,
+			Value:     value,
+		}
+	}
+
+	// Only the rows of the second table have a region.
+	for name, samples := range map[string]dynparquet.Samples{
+		"a": {sample("api", "", 1), sample("web", "", 2)},
+		"b": {sample("api", "eu", 3), sample("web", "us", 4), sample("api", "us", 5)},
+	} {
+		table, err := db.Table(name, NewTableConfig(dynparquet.NewSampleSchema()))
+		require.NoError(t, err)
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+		table.Sync()
+	}
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	// The records of both tables have the region column.
+	regions := map[int64]string{}
+	err = engine.ScanTables("a", "b").
+		Project(logicalplan.DynCol("labels"), logicalplan.Col("value")).
+		Execute(context.Background(), func(r arrow.Record) error {
+			require.Equal(t, []string{"labels.job", "labels.region", "value"}, fieldNames(r))
+			region := r.Column(1).(*array.Binary)
+			values := r.Column(2).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				regions[values.Value(i)] = ""
+				if region.IsValid(i) {
+					regions[values.Value(i)] = string(region.Value(i))
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{1: "", 2: "", 3: "eu", 4: "us", 5: "us"}, regions)
+
+	// The rows of both tables are aggregated together.
+	sums := map[string]int64{}
+	err = engine.ScanTables("a", "b").
+		Filter(logicalplan.Col("value").Gt(logicalplan.Literal(int64(1)))).
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Col("labels.job"),
+		).
+		Execute(context.Background(), func(r arrow.Record) error {
+			jobs := r.Column(0).(*array.Binary)
+			values := r.Column(1).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				sums[string(jobs.Value(i))] = values.Value(i)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"api": 8, "web": 6}, sums)
+
+	// The limit applies to the rows of both tables.
+	rows := int64(0)
+	err = engine.ScanTables("a", "b").
+		Limit(2).
+		Execute(context.Background(), func(r arrow.Record) error {
+			rows += r.NumRows()
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rows)
+}
+
+func fieldNames(r arrow.Record) []string {
+	names := make([]string, 0, r.NumCols())
+	for _, field := range r.Schema().Fields() {
+		names = append(names, field.Name)
+	}
+	return names
+}
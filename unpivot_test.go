@@ -0,0 +1,111 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+func TestUnpivot(t *testing.T) {
+	c, err := New(
+		newTestLogger(t),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", NewTableConfig(dynparquet.NewSampleSchema()))
+	require.NoError(t, err)
+
+	sample := func(value int64, labels ...dynparquet.Label) dynparquet.Sample {
+		return dynparquet.Sample{
+			Labels: labels,
+			Stacktrace: []uuid.UUID{
+				{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+			},
+			Timestamp: value,
+			Value:     value,
+		}
+	}
+
+	// The inserts result in records with different label columns.
+	for _, samples := range []dynparquet.Samples{{
+		sample(1, dynparquet.Label{Name: "job", Value: "api"}),
+		sample(2, dynparquet.Label{Name: "job", Value: "web"}),
+	}, {
+		sample(3, dynparquet.Label{Name: "job", Value: "api"}, dynparquet.Label{Name: "region", Value: "eu"}),
+		sample(4, dynparquet.Label{Name: "region", Value: "us"}),
+	}} {
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	// Each label of a row results in a row.
+	rows := map[int64][]string{}
+	err = engine.ScanTable("test").
+		Project(logicalplan.DynCol("labels"), logicalplan.Col("value")).
+		Unpivot(logicalplan.DynCol("labels"), "label", "label_value").
+		Execute(context.Background(), func(r arrow.Record) error {
+			require.Equal(t, []string{"value", "label", "label_value"}, fieldNames(r))
+			values := r.Column(0).(*array.Int64)
+			names := r.Column(1).(*array.String)
+			labels := r.Column(2).(*array.Binary)
+			for i := 0; i < int(r.NumRows()); i++ {
+				rows[values.Value(i)] = append(rows[values.Value(i)], names.Value(i)+"="+string(labels.Value(i)))
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]string{
+		1: {"job=api"},
+		2: {"job=web"},
+		3: {"job=api", "region=eu"},
+		4: {"region=us"},
+	}, rows)
+
+	// The label names and values that exist are the distinct rows.
+	pairs := []string{}
+	err = engine.ScanTable("test").
+		Unpivot(logicalplan.DynCol("labels"), "label", "label_value").
+		Filter(logicalplan.Col("label").Eq(logicalplan.Literal("job"))).
+		Distinct(logicalplan.Col("label"), logicalplan.Col("label_value")).
+		Execute(context.Background(), func(r arrow.Record) error {
+			for i := 0; i < int(r.NumRows()); i++ {
+				var name, value string
+				for j, field := range r.Schema().Fields() {
+					switch col := r.Column(j).(type) {
+					case *array.String:
+						if field.Name == "label" {
+							name = col.Value(i)
+						}
+					case *array.Binary:
+						if field.Name == "label_value" {
+							value = string(col.Value(i))
+						}
+					}
+				}
+				pairs = append(pairs, name+"="+value)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"job=api", "job=web"}, pairs)
+}
@@ -0,0 +1,175 @@
+package frostdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+)
+
+// windowValue returns the value of the column at row i, or nil if it is null.
+func windowValue(col arrow.Array, i int) interface{} {
+	if col.IsNull(i) {
+		return nil
+	}
+	switch col := col.(type) {
+	case *array.Int64:
+		return col.Value(i)
+	case *array.Float64:
+		return col.Value(i)
+	default:
+		panic("unexpected column type " + col.DataType().Name())
+	}
+}
+
+func TestWindow(t *testing.T) {
+	config := NewTableConfig(
+		dynparquet.NewSampleSchema(),
+	)
+
+	reg := prometheus.NewRegistry()
+	logger := newTestLogger(t)
+
+	c, err := New(
+		logger,
+		reg,
+	)
+	require.NoError(t, err)
+	db, err := c.DB("test")
+	require.NoError(t, err)
+	table, err := db.Table("test", config)
+	require.NoError(t, err)
+
+	// The later timestamps are inserted first, so the rows are not read in
+	// the order of the window.
+	for _, timestamps := range [][]int64{{4, 5, 6}, {1, 2, 3}} {
+		samples := dynparquet.Samples{}
+		for _, ts := range timestamps {
+			for j, job := range []string{"api", "web"} {
+				samples = append(samples, dynparquet.Sample{
+					Labels: []dynparquet.Label{
+						{Name: "job", Value: job},
+					},
+					Stacktrace: []uuid.UUID{
+						{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+					},
+					Timestamp: ts,
+					Value:     ts * int64(1+9*j),
+				})
+			}
+		}
+
+		buf, err := samples.ToBuffer(table.Schema())
+		require.NoError(t, err)
+
+		_, err = table.InsertBuffer(context.Background(), buf)
+		require.NoError(t, err)
+	}
+
+	// Ensure all transactions are completed
+	table.Sync()
+
+	engine := query.NewEngine(
+		memory.NewGoAllocator(),
+		db.TableProvider(),
+	)
+
+	res := map[string]map[int64][]interface{}{}
+	err = engine.ScanTable("test").
+		Window(
+			[]logicalplan.Expr{
+				logicalplan.RowNumber(),
+				logicalplan.Lag(logicalplan.Col("value"), 1),
+				logicalplan.Lead(logicalplan.Col("value"), 1),
+				logicalplan.MovingSum(logicalplan.Col("value"), 3),
+				logicalplan.MovingAvg(logicalplan.Col("value"), 3).Alias("avg"),
+			},
+			logicalplan.Col("timestamp"),
+			logicalplan.Col("labels.job"),
+		).
+		Project(
+			logicalplan.Col("labels.job"),
+			logicalplan.Col("timestamp"),
+			logicalplan.Col("row_number()"),
+			logicalplan.Col("lag(value, 1)"),
+			logicalplan.Col("lead(value, 1)"),
+			logicalplan.Col("moving_sum(value, 3)"),
+			logicalplan.Col("avg"),
+		).
+		Execute(context.Background(), func(r arrow.Record) error {
+			jobs := r.Column(0).(*array.Binary)
+			timestamps := r.Column(1).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				job := string(jobs.Value(i))
+				if res[job] == nil {
+					res[job] = map[int64][]interface{}{}
+				}
+				row := make([]interface{}, 0, 5)
+				for j := 2; j < int(r.NumCols()); j++ {
+					row = append(row, windowValue(r.Column(j), i))
+				}
+				res[job][timestamps.Value(i)] = row
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]map[int64][]interface{}{
+		"api": {
+			1: {int64(1), nil, int64(2), int64(1), 1.0},
+			2: {int64(2), int64(1), int64(3), int64(3), 1.5},
+			3: {int64(3), int64(2), int64(4), int64(6), 2.0},
+			4: {int64(4), int64(3), int64(5), int64(9), 3.0},
+			5: {int64(5), int64(4), int64(6), int64(12), 4.0},
+			6: {int64(6), int64(5), nil, int64(15), 5.0},
+		},
+		"web": {
+			1: {int64(1), nil, int64(20), int64(10), 10.0},
+			2: {int64(2), int64(10), int64(30), int64(30), 15.0},
+			3: {int64(3), int64(20), int64(40), int64(60), 20.0},
+			4: {int64(4), int64(30), int64(50), int64(90), 30.0},
+			5: {int64(5), int64(40), int64(60), int64(120), 40.0},
+			6: {int64(6), int64(50), nil, int64(150), 50.0},
+		},
+	}, res)
+
+	// Windows can smooth the results of an aggregation.
+	sums := map[int64]int64{}
+	err = engine.ScanTable("test").
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col("value")),
+			logicalplan.Col("timestamp"),
+		).
+		Window(
+			[]logicalplan.Expr{
+				logicalplan.MovingSum(logicalplan.Col("sum(value)"), 2),
+			},
+			logicalplan.Col("timestamp"),
+		).
+		Execute(context.Background(), func(r arrow.Record) error {
+			timestamps := r.Column(0).(*array.Int64)
+			moving := r.Column(2).(*array.Int64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				sums[timestamps.Value(i)] = moving.Value(i)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]int64{
+		1: 11,
+		2: 33,
+		3: 55,
+		4: 77,
+		5: 99,
+		6: 121,
+	}, sums)
+}